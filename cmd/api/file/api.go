@@ -2,87 +2,216 @@ package main
 
 import (
 	"context"
-	"log"
+	"flag"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
-	apRepo "github.com/braejan/go-transactions-summary/internal/domain/account/repository/postgres"
-	ucAccount "github.com/braejan/go-transactions-summary/internal/domain/account/usecases"
+	"github.com/braejan/go-transactions-summary/internal/app"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/service/rest/account"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/service/rest/apikey"
 	"github.com/braejan/go-transactions-summary/internal/domain/file/service/rest/file"
-	ucFile "github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
-	txRepo "github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/postgres"
-	ucTx "github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases"
-	upRepo "github.com/braejan/go-transactions-summary/internal/domain/user/repository/postgres"
-	ucUser "github.com/braejan/go-transactions-summary/internal/domain/user/usecases"
-	"github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/service/rest/transaction"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/service/rest/user"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/openapi"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
 	"github.com/gorilla/mux"
 )
 
-var fileUsecases ucFile.FileUseCases
-
-func init() {
-	// Create a postgres configuration from environment variables
-	postgresConfig := postgres.NewPostgresConfigurationFromEnv()
-	// Create a db Based on the configuration
-	postgresDatabase := postgres.NewBasePostgresDatabase(postgresConfig)
-	// Create a user repository
-	userRepository := upRepo.NewPostgresUserRepository(postgresDatabase)
-	// Create a account repository
-	accountRepository := apRepo.NewPostgresAccountRepository(postgresDatabase)
-	// Create a transaction repository
-	transactionRepository := txRepo.NewPostgresTransactionRepository(postgresDatabase)
-	// Create a user usecase
-	userUsecase, err := ucUser.NewUserUseCases(userRepository)
-	fataAnyErr(err)
-	// Create a account usecase
-	accountUsecase, err := ucAccount.NewAccountUseCases(accountRepository, userRepository)
-	fataAnyErr(err)
-	// Create a transaction usecase
-	transactionUsecase, err := ucTx.NewTransactionUseCases(transactionRepository)
-	fataAnyErr(err)
-	// Create a file usecase
-	fileUsecases, err = ucFile.NewFileUseCases(userUsecase, accountUsecase, transactionUsecase)
-	fataAnyErr(err)
-
-}
-
 func main() {
+	configuration := app.NewConfigurationFromEnv()
+	migrate := flag.Bool("migrate", migrateOnStart(), "apply the pending schema migrations before serving, MIGRATE_ON_START by default")
+	drainDelay := flag.Duration("drain", shutdownDrainDelay(),
+		"time the readiness fails before the server shuts down, SHUTDOWN_DRAIN_DELAY by default")
+	flag.StringVar(&configuration.Repositories, "storage", configuration.Repositories,
+		"repository backend: postgres, sqlite or memory, REPOSITORY_BACKEND by default")
+	flag.Parse()
+	// Build the dependencies from environment variables
+	application, err := app.New(configuration)
+	fataAnyErr(err)
+	log := application.Logger
+	// Apply the pending migrations, concurrent instances wait for the migrations lock.
+	// The memory repositories have no schema.
+	if *migrate && application.Database != nil {
+		migrator, err := migrations.NewMigrator(application.Database, migrations.WithLogger(log))
+		fataAnyErr(err)
+		applied, err := migrator.Up()
+		fataAnyErr(err)
+		log.Info("migrations applied", "applied", len(applied))
+	}
 	// Create context and register handlers
 	ctx := context.Background()
-	router := mux.NewRouter()
-	fileHandler, err := file.NewFileHandler(fileUsecases)
-	fataAnyErr(err)
-	fileHandler.RegisterRoutes(router)
+	root := newRouter(application)
 	// Create the server
 	server := &http.Server{
 		Addr:         "0.0.0.0:8080",
-		Handler:      router,
+		Handler:      root,
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 	}
+	// Start the outbox dispatcher
+	dispatcherCtx, stopDispatcher := context.WithCancel(ctx)
+	dispatcherDone := make(chan struct{})
+	go func() {
+		defer close(dispatcherDone)
+		application.DispatcherUseCases.Run(dispatcherCtx)
+	}()
+	// Start the consumer of the ingestion queue in the SQS ingestion mode
+	consumerDone := make(chan struct{})
+	go func() {
+		defer close(consumerDone)
+		if application.Configuration.Queue.Backend == queue.SQSBackend {
+			application.ConsumerUseCases.Run(dispatcherCtx)
+		}
+	}()
 	// Start server
 	// start server
 	go func() {
-		log.Printf("🆙 starting server 🙎 file on %s", server.Addr)
+		log.Info("starting server", "addr", server.Addr)
 		err := server.ListenAndServe()
-		if err != nil {
-			log.Fatal(err)
+		if err != nil && err != http.ErrServerClosed {
+			log.Error("server failed", "error", err)
+			os.Exit(1)
 		}
 	}()
 	// wait for SIGINT or SIGTERM signal
 	stopChan := make(chan os.Signal, 1)
 	signal.Notify(stopChan, syscall.SIGINT, syscall.SIGTERM)
 	<-stopChan
+	// fail the readiness and keep serving until the load balancer stops sending requests
+	application.Drain()
+	log.Info("draining server", "delay", drainDelay.String())
+	time.Sleep(*drainDelay)
 	// shutdown server
-	log.Println("🛑 shutting down server...")
+	log.Info("shutting down server")
 	err = server.Shutdown(ctx)
 	if err != nil {
-		log.Fatal(err, "shutting down server")
+		log.Error("error shutting down server", "error", err)
+		os.Exit(1)
 	}
+	// stop the dispatcher and the consumer after the in-flight work
+	stopDispatcher()
+	<-dispatcherDone
+	<-consumerDone
+	// close the database pool
+	err = application.Close()
+	if err != nil {
+		log.Error("error closing the database pool", "error", err)
+	}
+
+}
 
+// newRouter returns the router of the API: the probes, and every other route behind the
+// request ID, tracing, metrics, rate limit and authentication middlewares.
+func newRouter(application *app.App) (root *mux.Router) {
+	log := application.Logger
+	root = mux.NewRouter()
+	root.NotFoundHandler = apierror.NotFoundHandler()
+	root.MethodNotAllowedHandler = apierror.MethodNotAllowedHandler()
+	// The probes are registered before the middlewares, so they are not logged, traced or counted
+	root.Handle("/healthz", application.LivenessHandler()).Methods("GET")
+	root.Handle("/readyz", application.ReadinessHandler()).Methods("GET")
+	router := root.NewRoute().Subrouter()
+	router.Use(logger.RequestIDMiddleware(log), tracing.HTTPMiddleware(application.Tracer), application.Metrics.HTTPMiddleware())
+	// The rate limit of the IP addresses runs before the authentication, so the credential guessing is limited too
+	quotas := application.Configuration.Quota
+	if quotas.IPRequestsPerSecond > 0 && quotas.IPBurst > 0 {
+		router.Use(rateLimitMiddleware(log, quotas.IPRequestsPerSecond, quotas.IPBurst))
+	}
+	if application.Configuration.Auth.Required {
+		authMiddleware, err := auth.Middleware(authPolicy, log, authenticators(application)...)
+		fataAnyErr(err)
+		router.Use(authMiddleware)
+	}
+	// The rate limit runs after the authentication, so it limits every client by its ID
+	if quotas.RequestsPerSecond > 0 && quotas.Burst > 0 {
+		router.Use(rateLimitMiddleware(log, quotas.RequestsPerSecond, quotas.Burst))
+	}
+	router.Handle("/metrics", application.Metrics.Handler()).Methods("GET")
+	router.Handle(openapi.Path, openapi.Handler()).Methods("GET")
+	fileHandler, err := file.NewFileHandler(application.FileUseCases, file.WithObjectStore(application.ObjectStore),
+		file.WithLogger(log), file.WithProcessing(application.ProcessingUseCases, application.Configuration.Storage.Bucket),
+		file.WithQuotas(application.Configuration.Quota))
+	fataAnyErr(err)
+	fileHandler.RegisterRoutes(router)
+	accountHandler, err := account.NewAccountHandler(application.AccountUseCases, account.WithLogger(log))
+	fataAnyErr(err)
+	accountHandler.RegisterRoutes(router)
+	transactionHandler, err := transaction.NewTransactionHandler(application.AccountUseCases, application.TransactionUseCases,
+		transaction.WithLogger(log))
+	fataAnyErr(err)
+	transactionHandler.RegisterRoutes(router)
+	return
+}
+
+// authPolicy defines the scope required by every route of the API.
+var authPolicy = auth.Policy{
+	Scopes: map[string]string{
+		"POST /loadfile":          auth.ScopeFilesUpload,
+		"POST /loadfile/validate": auth.ScopeFilesUpload,
+		// The end users only read their own accounts, see auth.CanAccessUser.
+		"GET /accounts/{id}":              auth.ScopeTransactionsRead,
+		"GET /accounts/{id}/transactions": auth.ScopeTransactionsRead,
+		"GET /users/{id}/account":         auth.ScopeTransactionsRead,
+		"GET /users/{id}/summary":         auth.ScopeTransactionsRead,
+	},
+	Public: []string{"GET /metrics", "GET " + openapi.Path},
+}
+
+// authenticators returns the authenticators of the API keys and, when a JWT secret or a
+// JWKS file is configured, of the JWT of the end users.
+func authenticators(application *app.App) (list []auth.Authenticator) {
+	apiKeyAuthenticator, err := apikey.NewAuthenticator(application.APIKeyUseCases)
+	fataAnyErr(err)
+	list = append(list, apiKeyAuthenticator)
+	if application.Configuration.Auth.JWTEnabled() {
+		verifier, err := auth.NewJWTVerifier(application.Configuration.Auth)
+		fataAnyErr(err)
+		jwtAuthenticator, err := user.NewAuthenticator(verifier)
+		fataAnyErr(err)
+		list = append(list, jwtAuthenticator)
+	}
+	return
+}
+
+// migrateOnStart returns whether the MIGRATE_ON_START environment variable enables the
+// migrations on start.
+func migrateOnStart() bool {
+	enabled, err := strconv.ParseBool(os.Getenv("MIGRATE_ON_START"))
+	return err == nil && enabled
+}
+
+// rateLimitMiddleware returns the middleware limiting the requests with a token bucket of
+// its own.
+func rateLimitMiddleware(log logger.Logger, rate float64, burst int) func(next http.Handler) http.Handler {
+	limiter, err := quota.NewTokenBucketLimiter(rate, burst)
+	fataAnyErr(err)
+	middleware, err := quota.RateLimitMiddleware(limiter, log)
+	fataAnyErr(err)
+	return middleware
+}
+
+// defaultDrainDelay is the drain delay when SHUTDOWN_DRAIN_DELAY is not set, longer than
+// the period of the readiness probes.
+const defaultDrainDelay = 5 * time.Second
+
+// shutdownDrainDelay returns the drain delay of the SHUTDOWN_DRAIN_DELAY environment
+// variable, a duration such as 10s.
+func shutdownDrainDelay() time.Duration {
+	delay, err := time.ParseDuration(os.Getenv("SHUTDOWN_DRAIN_DELAY"))
+	if err != nil {
+		return defaultDrainDelay
+	}
+	return delay
 }
 
 func fataAnyErr(err error) {
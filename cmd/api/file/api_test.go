@@ -0,0 +1,63 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/app"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/openapi"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRoutesAreDocumented tests every route of the API, and no other, is documented in
+// the OpenAPI document.
+func TestRoutesAreDocumented(t *testing.T) {
+	// Given the router of an application with the memory repositories
+	storageConfig := storage.NewDefaultStorageConfiguration()
+	storageConfig.LocalDir = t.TempDir()
+	application, err := app.New(&app.Configuration{
+		Repositories: app.MemoryRepositories,
+		Postgres:     postgres.NewPostgresConfiguration("127.0.0.1", 1, "postgres", "postgres", "db"),
+		Notification: voNotification.NewDefaultNotificationConfiguration(),
+		Storage:      storageConfig,
+		Queue:        queue.NewDefaultQueueConfiguration(),
+		Logger:       logger.NewDefaultLoggerConfiguration(),
+		Tracing:      tracing.NewDefaultTracingConfiguration(),
+		Auth:         auth.NewDefaultAuthConfiguration(),
+		Quota:        quota.NewDefaultQuotaConfiguration(),
+	})
+	assert.Nil(t, err)
+	defer application.Close()
+	router := newRouter(application)
+	// When its routes are walked
+	routes := []string{}
+	err = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, errTemplate := route.GetPathTemplate()
+		methods, errMethods := route.GetMethods()
+		// The subrouter of the middlewares has no path nor methods of its own
+		if errTemplate != nil || errMethods != nil {
+			return nil
+		}
+		for _, method := range methods {
+			routes = append(routes, strings.ToUpper(method)+" "+template)
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	sort.Strings(routes)
+	// And the OpenAPI document is loaded
+	document, err := openapi.Load()
+	// Then it documents the same routes
+	assert.Nil(t, err)
+	assert.Equal(t, document.Routes(), routes)
+}
@@ -1,95 +1,394 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/braejan/go-transactions-summary/internal/app"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/service/rest/apikey"
+	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	fileUsecases "github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	processingEntity "github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	ucProcessing "github.com/braejan/go-transactions-summary/internal/domain/processing/usecases"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror/envelope"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
 )
 
-func uploadFileToS3(content string, fileName string) error {
-	// Create a new AWS session using environment variables
-	sess, err := session.NewSession(&aws.Config{
-		Region: aws.String(os.Getenv("AWS_REGION")),
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create AWS session: %v", err)
+// maxFileSize is the max size of an uploaded file, the API Gateway payload limit.
+const maxFileSize = 10 << 20
+
+// uploadError struct defines the error returned to the client, as the JSON envelope of
+// the errors of the REST API.
+type uploadError struct {
+	envelope.Envelope
+	// StatusCode is the HTTP status of the response.
+	StatusCode int `json:"-"`
+	// Headers are the headers of the response besides its content type.
+	Headers map[string]string `json:"-"`
+}
+
+func newUploadError(statusCode int, code string, message string) *uploadError {
+	return &uploadError{StatusCode: statusCode, Envelope: envelope.Envelope{Code: code, Message: message}}
+}
+
+// newDomainUploadError returns the error with the code and the status of the domain error
+// in the catalogue of the REST API.
+func newDomainUploadError(err error, message string) *uploadError {
+	entry := apierror.Lookup(err)
+	return newUploadError(entry.Status, entry.Code, message)
+}
+
+// response returns the error of the request as an API Gateway response.
+func (uploadErr *uploadError) response(requestID string) events.APIGatewayProxyResponse {
+	uploadErr.RequestID = requestID
+	body, _ := json.Marshal(uploadErr)
+	headers := map[string]string{"Content-Type": "application/json"}
+	for name, value := range uploadErr.Headers {
+		headers[name] = value
 	}
+	return events.APIGatewayProxyResponse{
+		StatusCode: uploadErr.StatusCode,
+		Headers:    headers,
+		Body:       string(body),
+	}
+}
 
-	// Create a S3 client using the session
-	svc := s3.New(sess)
-	file := strings.NewReader(content)
-	// S3 configuration options
-	params := &s3.PutObjectInput{
-		Bucket: aws.String(os.Getenv("AWS_S3_BUCKET_NAME")),
-		Key:    aws.String(fileName),
-		Body:   file,
+// application is built once per Lambda instance and reused by the warm invocations.
+var application *app.App
+
+func handleRequest(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// In the SQS ingestion mode the stored files are referenced in the ingestion queue
+	// instead of being processed on the S3 PUT event.
+	var ingestionQueue queue.Queue
+	if application.Configuration.Queue.Backend == queue.SQSBackend {
+		ingestionQueue = application.IngestionQueue
+	}
+	uploader := ""
+	if application.Configuration.Auth.Required {
+		authenticator, err := apikey.NewAuthenticator(application.APIKeyUseCases)
+		if err != nil {
+			return events.APIGatewayProxyResponse{}, err
+		}
+		principal, uploadErr := authenticateUpload(ctx, authenticator, event)
+		if uploadErr != nil {
+			return uploadErr.response(event.RequestContext.RequestID), nil
+		}
+		uploader = principal.ID
+	}
+	ctx = logger.WithRequestID(ctx, event.RequestContext.RequestID)
+	quotaHeaders, uploadErr := checkDailyQuota(ctx, application.FileUseCases, uploader)
+	if uploadErr != nil {
+		return uploadErr.response(event.RequestContext.RequestID), nil
+	}
+	target := uploads{
+		store:              application.ObjectStore,
+		structureUseCases:  application.FileUseCases,
+		ingestionQueue:     ingestionQueue,
+		processingUseCases: application.ProcessingUseCases,
+		bucket:             application.Configuration.Storage.Bucket,
+		maxFilesPerDay:     application.Configuration.Quota.MaxFilesPerDay,
+		logger:             application.Logger,
 	}
+	response := uploadFile(ctx, target, uploader, event)
+	if response.StatusCode == http.StatusTooManyRequests {
+		// A concurrent upload took the last file of the day after the quota was checked.
+		quotaHeaders, _ = checkDailyQuota(ctx, application.FileUseCases, uploader)
+	}
+	for name, value := range quotaHeaders {
+		if response.Headers == nil {
+			response.Headers = map[string]string{}
+		}
+		response.Headers[name] = value
+	}
+	return response, nil
+}
 
-	// Load the file into the S3 bucket
-	_, err = svc.PutObject(params)
-	if err != nil {
-		return fmt.Errorf("failed to upload file to S3: %v", err)
+// checkDailyQuota returns the quota headers of the uploader, with a 429 error when it
+// cannot upload more files today.
+func checkDailyQuota(ctx context.Context, fileUseCases fileUsecases.FileUseCases, uploader string) (headers map[string]string, uploadErr *uploadError) {
+	usage, err := fileUseCases.CheckDailyQuota(ctx, uploader)
+	headers = usage.Headers(time.Now())
+	switch {
+	case errors.Is(err, quota.ErrDailyQuotaExceeded):
+		uploadErr = newDomainUploadError(err, fmt.Sprintf("the client uploaded %d files today, the max files per day", usage.Used))
+		uploadErr.Headers = headers
+	case err != nil:
+		uploadErr = newDomainUploadError(err, "failed to check the daily quota")
 	}
+	return
+}
 
-	return nil
+// authenticateUpload returns the client of the credentials of the request, which must be
+// granted the files:upload scope.
+func authenticateUpload(ctx context.Context, authenticator auth.Authenticator, event events.APIGatewayProxyRequest) (principal *auth.Principal, uploadErr *uploadError) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", nil)
+	if err != nil {
+		uploadErr = newUploadError(http.StatusInternalServerError, envelope.CodeInternal, "failed to authenticate the request")
+		return
+	}
+	request.Header.Set(apikey.APIKeyHeader, header(event, apikey.APIKeyHeader))
+	request.Header.Set("Authorization", header(event, "Authorization"))
+	principal, err = authenticator.Authenticate(request)
+	switch {
+	case errors.Is(err, auth.ErrNoCredentials), errors.Is(err, auth.ErrUnauthenticated):
+		uploadErr = newUploadError(http.StatusUnauthorized, envelope.CodeUnauthenticated, "the API key is missing or invalid")
+	case err != nil:
+		uploadErr = newUploadError(http.StatusInternalServerError, envelope.CodeInternal, "failed to authenticate the request")
+	case !principal.HasScope(auth.ScopeFilesUpload):
+		uploadErr = newUploadError(http.StatusForbidden, envelope.CodeForbidden, "the API key is not granted the files:upload scope")
+	}
+	return
 }
 
-func handleRequest(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+// uploads struct defines where the uploaded files are checked, stored, enqueued and recorded.
+type uploads struct {
+	// store stores the uploaded files by their name.
+	store storage.ObjectStore
+	// structureUseCases checks the structure of the files before they are stored.
+	structureUseCases fileUsecases.StructureUseCases
+	// ingestionQueue receives a reference to every stored file, nil in the S3 event mode.
+	ingestionQueue queue.Queue
+	// processingUseCases reserves the processing record of every file within the max files
+	// per day of its uploader, nil disables it.
+	processingUseCases ucProcessing.ProcessingUseCases
+	// bucket is the bucket of the stored files.
+	bucket string
+	// maxFilesPerDay is the max number of files an uploader uploads per UTC day, zero
+	// without limit.
+	maxFilesPerDay int
+	// logger logs the failed uploads with the request ID of the context.
+	logger logger.Logger
+}
 
-	// extract file from request body
-	body := event.Body
-	cleanBody, fileName, err := cleanStringBody(body)
-	if err != nil {
-		return events.APIGatewayProxyResponse{StatusCode: 400, Body: err.Error()}, nil
+// uploadFile validates the file of the multipart request and stores it in the object store.
+// Invalid files are rejected before anything is stored, and so are the files beyond the
+// max files per day of the uploader and the files already processed. When the ingestion queue is not nil, a reference to
+// the stored file is sent to it with its uploader.
+func uploadFile(ctx context.Context, target uploads, uploader string, event events.APIGatewayProxyRequest) events.APIGatewayProxyResponse {
+	requestID := event.RequestContext.RequestID
+	content, fileName, uploadErr := parseUpload(event)
+	if uploadErr != nil {
+		return uploadErr.response(requestID)
 	}
-	err = uploadFileToS3(cleanBody, fileName)
+	uploadErr = validateUpload(target.structureUseCases, fileName, content)
+	if uploadErr != nil {
+		return uploadErr.response(requestID)
+	}
+	record, uploadErr := acceptUpload(ctx, target, fileName, uploader, content)
+	if uploadErr != nil {
+		return uploadErr.response(requestID)
+	}
+	err := target.store.Put(fileName, bytes.NewReader(content))
 	if err != nil {
-		return events.APIGatewayProxyResponse{StatusCode: 400, Body: err.Error()}, nil
+		target.log(ctx, fileName).Error("failed to upload file", "error", err)
+		failUpload(ctx, target, record, err)
+		return newUploadError(http.StatusInternalServerError, "STORAGE_FAILED", "failed to upload file").response(requestID)
+	}
+	if target.ingestionQueue != nil {
+		if err = enqueueUpload(target.store, target.ingestionQueue, target.bucket, fileName, uploader); err != nil {
+			target.log(ctx, fileName).Error("failed to enqueue file", "error", err)
+			failUpload(ctx, target, record, err)
+			return newUploadError(http.StatusInternalServerError, "QUEUE_FAILED", "failed to enqueue file").response(requestID)
+		}
 	}
 	response := "👏👏👏 Tu archivo ha sido subido a S3 exitosamente. Un proceso interno lo estará ejecutando. 😉"
-	return events.APIGatewayProxyResponse{StatusCode: 200, Body: response}, nil
+	return events.APIGatewayProxyResponse{StatusCode: 200, Body: response}
+}
+
+// log returns the logger of the file with the request ID of the context.
+func (target uploads) log(ctx context.Context, fileName string) logger.Logger {
+	return logger.FromContext(ctx, target.logger).With("file", fileName)
+}
+
+// acceptUpload reserves the processing record of the file within the max files per day of
+// the uploader, before the file is stored, so the concurrent uploads cannot exceed it. The
+// record is identified by the MD5 of the content, the ETag of the stored object. The same
+// file already processed under the same name is rejected with 409, the REST API loads it
+// again instead.
+func acceptUpload(ctx context.Context, target uploads, fileName string, uploader string, content []byte) (record *processingEntity.ProcessingRecord, uploadErr *uploadError) {
+	if target.processingUseCases == nil {
+		return
+	}
+	etag := fmt.Sprintf("%x", md5.Sum(content))
+	record, err := target.processingUseCases.Accept(ctx, target.bucket, fileName, etag, uploader, target.maxFilesPerDay)
+	switch {
+	case errors.Is(err, voProcessing.ErrObjectAlreadyProcessed):
+		uploadErr = newDomainUploadError(err, "the file was already processed, it is not stored again")
+	case errors.Is(err, quota.ErrDailyQuotaExceeded):
+		uploadErr = newDomainUploadError(err, fmt.Sprintf("the client uploaded %d files today, the max files per day", target.maxFilesPerDay))
+	case err != nil:
+		uploadErr = newDomainUploadError(err, "failed to record the upload")
+	}
+	return
+}
+
+// failUpload records the failure of an accepted file, so it does not count for the daily
+// quota of its uploader. Nothing is done without record.
+func failUpload(ctx context.Context, target uploads, record *processingEntity.ProcessingRecord, reason error) {
+	if record == nil {
+		return
+	}
+	record.Fail(reason, time.Now())
+	if err := target.processingUseCases.Finish(ctx, record); err != nil {
+		target.log(ctx, record.Key).Error("failed to record the failure of the file", "error", err)
+	}
 }
 
 func main() {
+	var err error
+	application, err = app.New(app.NewConfigurationFromEnv())
+	if err != nil {
+		log.Fatalf("failed to build the application: %v", err)
+	}
 	lambda.Start(handleRequest)
 }
 
-func cleanStringBody(body string) (cleanBody string, fileName string, err error) {
-	// return strings.Replace(strings.Replace(body, "\n", "", -1), "\r", "", -1)
-	splitted := strings.Split(body, "\n")
-	// Validate Header Content-Disposition
-	fmt.Println("splitted[4]", splitted[4])
-	if !strings.Contains(splitted[1], "Content-Disposition: form-data;") {
-		err = fmt.Errorf("invalid header Content-Disposition: missing form-data")
+// enqueueUpload sends a reference to the stored file to the ingestion queue. The ETag
+// identifies the content, so uploading the same file again is not ingested twice.
+func enqueueUpload(store storage.ObjectStore, ingestionQueue queue.Queue, bucket string, key string, uploader string) (err error) {
+	info, err := store.Head(key)
+	if err != nil {
+		return
+	}
+	body, err := processingEntity.ObjectReference{Bucket: bucket, Key: key, ETag: info.ETag, Uploader: uploader}.Body()
+	if err != nil {
+		return
+	}
+	_, err = ingestionQueue.Send(body)
+	return
+}
+
+// parseUpload returns the content and the name of the "file" part of a multipart/form-data
+// request. As in the REST API, a "filename" field overrides the name of the uploaded file.
+func parseUpload(event events.APIGatewayProxyRequest) (content []byte, fileName string, uploadErr *uploadError) {
+	mediaType, params, err := mime.ParseMediaType(header(event, "Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		uploadErr = newUploadError(http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", "the request must be multipart/form-data")
+		return
+	}
+	boundary := params["boundary"]
+	if boundary == "" {
+		uploadErr = newUploadError(http.StatusBadRequest, envelope.CodeInvalidRequest, "the multipart boundary is missing")
+		return
+	}
+	body := []byte(event.Body)
+	if event.IsBase64Encoded {
+		body, err = base64.StdEncoding.DecodeString(event.Body)
+		if err != nil {
+			uploadErr = newUploadError(http.StatusBadRequest, envelope.CodeInvalidRequest, "the body is not valid base64")
+			return
+		}
+	}
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	found := false
+	fieldName := ""
+	for {
+		part, errPart := reader.NextPart()
+		if errPart == io.EOF {
+			break
+		}
+		if errPart != nil {
+			uploadErr = newUploadError(http.StatusBadRequest, envelope.CodeInvalidRequest, "the multipart body is malformed")
+			return
+		}
+		switch part.FormName() {
+		case "file":
+			found = true
+			fileName = part.FileName()
+			content, err = io.ReadAll(io.LimitReader(part, maxFileSize+1))
+		case "filename":
+			var value []byte
+			value, err = io.ReadAll(io.LimitReader(part, 1024))
+			fieldName = strings.TrimSpace(string(value))
+		}
+		part.Close()
+		if err != nil {
+			uploadErr = newUploadError(http.StatusBadRequest, envelope.CodeInvalidRequest, "the multipart body is malformed")
+			return
+		}
+	}
+	if !found {
+		uploadErr = newDomainUploadError(voFile.ErrFileNotInRequest, "the \"file\" field is missing")
 		return
 	}
-	// Content-Disposition: form-data; name="file"; filename="txns.csv"
-	// Find the variable name:
-	variables := strings.Split(splitted[1], ";")
-	if len(variables) < 3 {
-		err = fmt.Errorf("invalid header Content-Disposition: missing variables")
+	if len(content) > maxFileSize {
+		uploadErr = newDomainUploadError(quota.ErrFileTooLarge, fmt.Sprintf("the file exceeds %d bytes", maxFileSize))
 		return
 	}
-	fileName = strings.Split(variables[2], "=")[1]
-	fileName = strings.ReplaceAll(fileName, "\"", "")
-	fileName = strings.Trim(fileName, " ")
+	if fieldName != "" {
+		fileName = fieldName
+	}
+	fileName = cleanFileName(fileName)
 	if fileName == "" {
-		err = fmt.Errorf("invalid header Content-Disposition: missing filename")
+		uploadErr = newUploadError(http.StatusBadRequest, "FILE_NAME_INVALID", "the file name is missing or invalid")
 		return
 	}
-	for _, line := range splitted[4:] {
-		cleanBody += line + "\n"
+	if len(bytes.TrimSpace(content)) == 0 {
+		uploadErr = newDomainUploadError(voFile.ErrFileIsEmpty, "the file is empty")
+		return
 	}
-	if strings.Trim(cleanBody, " ") == "" {
-		err = fmt.Errorf("invalid body: empty file")
+	return
+}
+
+// validateUpload checks the structure of the file with the file use cases. It does not
+// use the database, users and accounts are resolved when the file is processed.
+func validateUpload(structureUseCases fileUsecases.StructureUseCases, fileName string, content []byte) (uploadErr *uploadError) {
+	report, err := structureUseCases.CheckStructure(*fileEntity.NewTxFile(fileName, "", "", 0), bytes.NewReader(content))
+	if err != nil {
+		uploadErr = newDomainUploadError(err, err.Error())
 		return
 	}
+	if !report.IsValid() {
+		uploadErr = newDomainUploadError(voFile.ErrFileHasInvalidLines, fmt.Sprintf("the file has %d invalid lines", report.InvalidLines))
+		uploadErr.Details = report
+	}
 	return
 }
+
+// header returns the value of the request header, ignoring its case.
+func header(event events.APIGatewayProxyRequest, name string) string {
+	for key, value := range event.Headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	for key, values := range event.MultiValueHeaders {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// cleanFileName returns the base name of the uploaded file, or an empty string when
+// it cannot be used as an object key.
+func cleanFileName(fileName string) string {
+	fileName = path.Base(strings.ReplaceAll(strings.TrimSpace(fileName), "\\", "/"))
+	if !fs.ValidPath(fileName) || fileName == "." || fileName == "/" {
+		return ""
+	}
+	return fileName
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	accountMock "github.com/braejan/go-transactions-summary/internal/domain/account/usecases/mock"
+	apiKeyEntity "github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/service/rest/apikey"
+	apiKeyMock "github.com/braejan/go-transactions-summary/internal/domain/apikey/usecases/mock"
+	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	fileUsecases "github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	fileMock "github.com/braejan/go-transactions-summary/internal/domain/file/usecases/mock"
+	processingEntity "github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	processingMock "github.com/braejan/go-transactions-summary/internal/domain/processing/repository/mock"
+	ucProcessingMock "github.com/braejan/go-transactions-summary/internal/domain/processing/usecases/mock"
+	transactionMock "github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases/mock"
+	userMock "github.com/braejan/go-transactions-summary/internal/domain/user/usecases/mock"
+	voAPIKey "github.com/braejan/go-transactions-summary/internal/valueobject/apikey"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	queueMock "github.com/braejan/go-transactions-summary/internal/valueobject/queue/mock"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testCSV = "Id,Date,Transaction\r\n0,7/5,+60.5\r\n1,7/28,-10.3\r\n"
+
+// getTestRequest returns an API Gateway request with a multipart body. The fields
+// are written in the given order, "file" is written as a file part.
+func getTestRequest(t *testing.T, fields [][2]string) events.APIGatewayProxyRequest {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for _, field := range fields {
+		if field[0] == "file" {
+			part, err := writer.CreateFormFile("file", "txns.csv")
+			assert.Nil(t, err)
+			_, err = part.Write([]byte(field[1]))
+			assert.Nil(t, err)
+			continue
+		}
+		assert.Nil(t, writer.WriteField(field[0], field[1]))
+	}
+	assert.Nil(t, writer.Close())
+	return events.APIGatewayProxyRequest{
+		Headers: map[string]string{"content-type": writer.FormDataContentType()},
+		Body:    body.String(),
+	}
+}
+
+// getUploadError decodes the structured error of the response.
+func getUploadError(t *testing.T, response events.APIGatewayProxyResponse) (uploadErr uploadError) {
+	assert.Equal(t, "application/json", response.Headers["Content-Type"])
+	assert.Nil(t, json.Unmarshal([]byte(response.Body), &uploadErr))
+	return
+}
+
+// getValidationReport decodes the validation report in the details of the error.
+func getValidationReport(t *testing.T, uploadErr uploadError) (report fileEntity.ValidationReport) {
+	details, err := json.Marshal(uploadErr.Details)
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(details, &report))
+	return
+}
+
+func getStoredContent(t *testing.T, store storage.ObjectStore, key string) string {
+	body, err := store.Get(key)
+	assert.Nil(t, err)
+	defer body.Close()
+	content, _ := io.ReadAll(body)
+	return string(content)
+}
+
+// TestUploadFileToLocalStore tests the uploaded file is stored without the multipart framing.
+func TestUploadFileToLocalStore(t *testing.T) {
+	// Given a local object store
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	// When uploading a file with CRLF line endings
+	response := uploadFile(context.Background(), uploads{store: store, structureUseCases: fileUsecases.NewStructureUseCases()}, "", getTestRequest(t, [][2]string{{"file", testCSV}}))
+	// Then the response is OK
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	// And the file is stored under its name exactly as uploaded
+	assert.Equal(t, testCSV, getStoredContent(t, store, "txns.csv"))
+}
+
+// TestUploadFileEnqueuesReference tests a reference to the stored file is sent to the ingestion queue.
+func TestUploadFileEnqueuesReference(t *testing.T) {
+	// Given a local object store and an ingestion queue
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	ingestionQueue := queue.NewMemoryQueue()
+	// When a client uploads a file
+	response := uploadFile(context.Background(), uploads{store: store, structureUseCases: fileUsecases.NewStructureUseCases(), ingestionQueue: ingestionQueue, bucket: "bucket"}, "0123456789abcdef", getTestRequest(t, [][2]string{{"file", testCSV}}))
+	// Then the response is OK
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	// And the queue references the stored file and its uploader
+	messages, err := ingestionQueue.Receive(10, time.Minute)
+	assert.Nil(t, err)
+	assert.Len(t, messages, 1)
+	object, err := processingEntity.ParseObjectReference(messages[0].Body)
+	assert.Nil(t, err)
+	assert.Equal(t, "bucket", object.Bucket)
+	assert.Equal(t, "txns.csv", object.Key)
+	assert.NotEmpty(t, object.ETag)
+	assert.Equal(t, "0123456789abcdef", object.Uploader)
+}
+
+// TestAuthenticateUpload tests the uploads require an API key granted the files:upload scope.
+func TestAuthenticateUpload(t *testing.T) {
+	// Given an authenticator with an uploader and a reader key
+	useCases := apiKeyMock.NewMockAPIKeyUseCases()
+	uploader := apiKeyEntity.APIKey{ID: "0123456789abcdef", Scopes: []string{auth.ScopeFilesUpload}}
+	reader := apiKeyEntity.APIKey{ID: "fedcba9876543210", Scopes: []string{auth.ScopeTransactionsRead}}
+	useCases.On("Authenticate", mock.Anything, "tsk_uploader_secret").Return(uploader, nil)
+	useCases.On("Authenticate", mock.Anything, "tsk_reader_secret").Return(reader, nil)
+	useCases.On("Authenticate", mock.Anything, "tsk_unknown_secret").Return(apiKeyEntity.APIKey{}, voAPIKey.ErrInvalidAPIKey)
+	authenticator, err := apikey.NewAuthenticator(useCases)
+	assert.Nil(t, err)
+	authenticate := func(headers map[string]string) (*auth.Principal, *uploadError) {
+		return authenticateUpload(context.Background(), authenticator, events.APIGatewayProxyRequest{Headers: headers})
+	}
+	// When uploading without key or with an unknown key
+	for _, headers := range []map[string]string{nil, {"x-api-key": "tsk_unknown_secret"}} {
+		_, uploadErr := authenticate(headers)
+		// Then the upload is unauthorized
+		assert.Equal(t, http.StatusUnauthorized, uploadErr.StatusCode)
+	}
+	// When uploading with the reader key
+	_, uploadErr := authenticate(map[string]string{"x-api-key": "tsk_reader_secret"})
+	// Then the upload is forbidden
+	assert.Equal(t, http.StatusForbidden, uploadErr.StatusCode)
+	// When uploading with the uploader key as Bearer token
+	principal, uploadErr := authenticate(map[string]string{"authorization": "Bearer tsk_uploader_secret"})
+	// Then the uploader is authenticated
+	assert.Nil(t, uploadErr)
+	assert.Equal(t, "0123456789abcdef", principal.ID)
+}
+
+// TestUploadFileErrEnqueuing tests the error returned when the reference cannot be enqueued.
+func TestUploadFileErrEnqueuing(t *testing.T) {
+	// Given a local object store and an ingestion queue failing to send
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	ingestionQueue := queueMock.NewMockQueue()
+	ingestionQueue.On("Send", mock.Anything).Return("", queue.ErrSendingMessage)
+	// And a JSON logger
+	output := &bytes.Buffer{}
+	target := uploads{store: store, structureUseCases: fileUsecases.NewStructureUseCases(), ingestionQueue: ingestionQueue, bucket: "bucket",
+		logger: logger.NewJSONLogger(output, logger.LevelInfo)}
+	// When a client uploads a file
+	ctx := logger.WithRequestID(context.Background(), "request-1")
+	response := uploadFile(ctx, target, "0123456789abcdef", getTestRequest(t, [][2]string{{"file", testCSV}}))
+	// Then the error code is enqueue_failed
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	assert.Equal(t, "QUEUE_FAILED", getUploadError(t, response).Code)
+	// And the failure is logged with the request ID and the file
+	line := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(output.Bytes(), &line))
+	assert.Equal(t, "failed to enqueue file", line["msg"])
+	assert.Equal(t, "request-1", line["request_id"])
+	assert.Equal(t, "txns.csv", line["file"])
+}
+
+// TestUploadFileWithFilenameFieldFirst tests the file does not need to be the first part.
+func TestUploadFileWithFilenameFieldFirst(t *testing.T) {
+	// Given a local object store
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	// When uploading a file after a filename field
+	response := uploadFile(context.Background(), uploads{store: store, structureUseCases: fileUsecases.NewStructureUseCases()}, "", getTestRequest(t, [][2]string{{"filename", "julio.csv"}, {"file", testCSV}}))
+	// Then the file is stored with the name of the field
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, testCSV, getStoredContent(t, store, "julio.csv"))
+}
+
+// TestUploadFileWithBase64Body tests base64 encoded bodies are decoded.
+func TestUploadFileWithBase64Body(t *testing.T) {
+	// Given a local object store
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	// And a base64 encoded request
+	request := getTestRequest(t, [][2]string{{"file", testCSV}})
+	request.Body = base64.StdEncoding.EncodeToString([]byte(request.Body))
+	request.IsBase64Encoded = true
+	// When uploading the file
+	response := uploadFile(context.Background(), uploads{store: store, structureUseCases: fileUsecases.NewStructureUseCases()}, "", request)
+	// Then the decoded file is stored
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, testCSV, getStoredContent(t, store, "txns.csv"))
+}
+
+// TestUploadFileErrors tests the structured errors of the invalid requests.
+func TestUploadFileErrors(t *testing.T) {
+	valid := getTestRequest(t, [][2]string{{"file", testCSV}})
+	cases := []struct {
+		name       string
+		request    events.APIGatewayProxyRequest
+		statusCode int
+		code       string
+	}{
+		{
+			name:       "not multipart",
+			request:    events.APIGatewayProxyRequest{Headers: map[string]string{"Content-Type": "text/csv"}, Body: testCSV},
+			statusCode: http.StatusUnsupportedMediaType,
+			code:       "UNSUPPORTED_MEDIA_TYPE",
+		},
+		{
+			name:       "missing boundary",
+			request:    events.APIGatewayProxyRequest{Headers: map[string]string{"Content-Type": "multipart/form-data"}, Body: "--"},
+			statusCode: http.StatusBadRequest,
+			code:       "INVALID_REQUEST",
+		},
+		{
+			name:       "short body",
+			request:    events.APIGatewayProxyRequest{Headers: valid.Headers, Body: "x"},
+			statusCode: http.StatusBadRequest,
+			code:       "INVALID_REQUEST",
+		},
+		{
+			name:       "invalid base64",
+			request:    events.APIGatewayProxyRequest{Headers: valid.Headers, Body: "%%%", IsBase64Encoded: true},
+			statusCode: http.StatusBadRequest,
+			code:       "INVALID_REQUEST",
+		},
+		{
+			name:       "missing file",
+			request:    getTestRequest(t, [][2]string{{"filename", "txns.csv"}}),
+			statusCode: http.StatusBadRequest,
+			code:       "FILE_MISSING",
+		},
+		{
+			name:       "empty file",
+			request:    getTestRequest(t, [][2]string{{"file", "  \n"}}),
+			statusCode: http.StatusBadRequest,
+			code:       "FILE_EMPTY",
+		},
+		{
+			name:       "invalid file name",
+			request:    getTestRequest(t, [][2]string{{"filename", ".."}, {"file", testCSV}}),
+			statusCode: http.StatusBadRequest,
+			code:       "FILE_NAME_INVALID",
+		},
+		{
+			name:       "invalid record",
+			request:    getTestRequest(t, [][2]string{{"file", "Id,Date,Transaction\n0,7/5,+60.5\n1,13/45,-10.3\n"}}),
+			statusCode: http.StatusUnprocessableEntity,
+			code:       "FILE_HAS_INVALID_LINES",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given a local object store
+			store, err := storage.NewLocalObjectStore(t.TempDir())
+			assert.Nil(t, err)
+			// When uploading the request
+			response := uploadFile(context.Background(), uploads{store: store, structureUseCases: fileUsecases.NewStructureUseCases()}, "", tc.request)
+			// Then the structured error is returned
+			assert.Equal(t, tc.statusCode, response.StatusCode)
+			assert.Equal(t, tc.code, getUploadError(t, response).Code)
+			// And nothing is stored
+			objects, err := store.List("")
+			assert.Nil(t, err)
+			assert.Empty(t, objects)
+		})
+	}
+}
+
+// TestUploadFileInvalidLinesReport tests every invalid line is reported and nothing is stored.
+func TestUploadFileInvalidLinesReport(t *testing.T) {
+	// Given a local object store
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	// And a file with an invalid header, date and amount
+	content := "Id,Fecha,Transaction\n0,7/5,+60.5\n1,13/45,-10.3\n2,8/2,20.46\n3,8/13\n"
+	// When uploading the file
+	request := getTestRequest(t, [][2]string{{"file", content}})
+	request.RequestContext.RequestID = "request-id"
+	response := uploadFile(context.Background(), uploads{store: store, structureUseCases: fileUsecases.NewStructureUseCases()}, "", request)
+	// Then the file is rejected with the problems of every invalid line
+	assert.Equal(t, http.StatusUnprocessableEntity, response.StatusCode)
+	uploadErr := getUploadError(t, response)
+	assert.Equal(t, "FILE_HAS_INVALID_LINES", uploadErr.Code)
+	assert.Equal(t, "the file has 4 invalid lines", uploadErr.Message)
+	assert.Equal(t, "request-id", uploadErr.RequestID)
+	report := getValidationReport(t, uploadErr)
+	assert.Equal(t, 4, report.Lines)
+	assert.Equal(t, []fileEntity.LineProblem{
+		{Line: 1, Error: voFile.ErrFileHeaderIsInvalid.Error()},
+		{Line: 3, Column: "Date", Value: "13/45", Error: voFile.ErrFileDateIsInvalid.Error()},
+		{Line: 4, Column: "Transaction", Value: "20.46", Error: voFile.ErrFileAmountIsInvalid.Error()},
+		{Line: 5, Error: voFile.ErrFileColumnCountIsInvalid.Error()},
+	}, report.Problems)
+	// And nothing is stored
+	objects, err := store.List("")
+	assert.Nil(t, err)
+	assert.Empty(t, objects)
+}
+
+// TestCheckDailyQuota tests the uploads beyond the max files per day are rejected with the quota headers.
+func TestCheckDailyQuota(t *testing.T) {
+	// Given a client that uploaded 3 of its 3 files today and a client that uploaded 1
+	resetAt := quota.DayStart(time.Now()).Add(24 * time.Hour)
+	useCases := fileMock.NewMockFileUseCases()
+	useCases.On("CheckDailyQuota", mock.Anything, "exhausted").Return(quota.Usage{Limit: 3, Used: 3, ResetAt: resetAt}, quota.ErrDailyQuotaExceeded)
+	useCases.On("CheckDailyQuota", mock.Anything, "client").Return(quota.Usage{Limit: 3, Used: 1, ResetAt: resetAt}, nil)
+	// When the exhausted client uploads a file
+	_, uploadErr := checkDailyQuota(context.Background(), useCases, "exhausted")
+	// Then the upload is rejected with the quota headers
+	response := uploadErr.response("")
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	assert.Equal(t, "DAILY_QUOTA_EXCEEDED", getUploadError(t, response).Code)
+	assert.Equal(t, "0", response.Headers[quota.RemainingHeader])
+	assert.NotEmpty(t, response.Headers[quota.RetryAfterHeader])
+	// When the other client uploads a file
+	headers, uploadErr := checkDailyQuota(context.Background(), useCases, "client")
+	// Then the upload goes on with its remaining files
+	assert.Nil(t, uploadErr)
+	assert.Equal(t, "2", headers[quota.RemainingHeader])
+}
+
+// TestUploadFileTooManyRows tests the files beyond the max rows per file are rejected.
+func TestUploadFileTooManyRows(t *testing.T) {
+	// Given a local object store
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	// And file use cases allowing one row per file
+	configuration := quota.NewDefaultQuotaConfiguration()
+	configuration.MaxRowsPerFile = 1
+	useCases, err := fileUsecases.NewFileUseCases(userMock.NewMockUserUseCases(), accountMock.NewMockAccountUseCases(),
+		transactionMock.NewMockTransactionUseCases(), fileUsecases.WithQuotas(configuration, processingMock.NewMockProcessingRepository()))
+	assert.Nil(t, err)
+	// When uploading a file with two rows
+	response := uploadFile(context.Background(), uploads{store: store, structureUseCases: useCases}, "", getTestRequest(t, [][2]string{{"file", testCSV}}))
+	// Then the file is rejected
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.StatusCode)
+	assert.Equal(t, "FILE_TOO_MANY_ROWS", getUploadError(t, response).Code)
+	// And nothing is stored
+	objects, err := store.List("")
+	assert.Nil(t, err)
+	assert.Empty(t, objects)
+}
+
+// TestUploadFileReservesDailyQuota tests the file is accepted within the max files per day of the uploader before it is stored.
+func TestUploadFileReservesDailyQuota(t *testing.T) {
+	// Given a local object store
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	// And processing use cases with one file left for the client and none for the exhausted one
+	record, err := processingEntity.NewProcessingRecord("bucket", "txns.csv", "etag", time.Now())
+	assert.Nil(t, err)
+	processingUseCases := ucProcessingMock.NewMockProcessingUseCases()
+	processingUseCases.On("Accept", mock.Anything, "bucket", "txns.csv", fmt.Sprintf("%x", md5.Sum([]byte(testCSV))), "client", 3).Return(record, nil)
+	processingUseCases.On("Accept", mock.Anything, "bucket", "txns.csv", mock.Anything, "exhausted", 3).Return(nil, quota.ErrDailyQuotaExceeded)
+	target := uploads{store: store, structureUseCases: fileUsecases.NewStructureUseCases(), processingUseCases: processingUseCases,
+		bucket: "bucket", maxFilesPerDay: 3}
+	// When the exhausted client uploads a file
+	response := uploadFile(context.Background(), target, "exhausted", getTestRequest(t, [][2]string{{"file", testCSV}}))
+	// Then the file is rejected and nothing is stored
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	assert.Equal(t, "DAILY_QUOTA_EXCEEDED", getUploadError(t, response).Code)
+	objects, err := store.List("")
+	assert.Nil(t, err)
+	assert.Empty(t, objects)
+	// When the other client uploads a file
+	response = uploadFile(context.Background(), target, "client", getTestRequest(t, [][2]string{{"file", testCSV}}))
+	// Then the file is stored with the ETag of its record
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	info, err := store.Head("txns.csv")
+	assert.Nil(t, err)
+	processingUseCases.AssertCalled(t, "Accept", mock.Anything, "bucket", "txns.csv", info.ETag, "client", 3)
+}
+
+// TestUploadFileErrEnqueuingFailsRecord tests the record of a file that cannot be enqueued is failed, so it does not count for the daily quota.
+func TestUploadFileErrEnqueuingFailsRecord(t *testing.T) {
+	// Given a local object store and an ingestion queue failing to send
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	ingestionQueue := queueMock.NewMockQueue()
+	ingestionQueue.On("Send", mock.Anything).Return("", queue.ErrSendingMessage)
+	// And processing use cases accepting the file
+	record, err := processingEntity.NewProcessingRecord("bucket", "txns.csv", "etag", time.Now())
+	assert.Nil(t, err)
+	processingUseCases := ucProcessingMock.NewMockProcessingUseCases()
+	processingUseCases.On("Accept", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(record, nil)
+	processingUseCases.On("Finish", mock.Anything, record).Return(nil)
+	target := uploads{store: store, structureUseCases: fileUsecases.NewStructureUseCases(), ingestionQueue: ingestionQueue,
+		processingUseCases: processingUseCases, bucket: "bucket", maxFilesPerDay: 3, logger: logger.NewDefaultLogger()}
+	// When a client uploads a file
+	response := uploadFile(context.Background(), target, "client", getTestRequest(t, [][2]string{{"file", testCSV}}))
+	// Then the response is InternalServerError
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	// And the record is failed
+	assert.Equal(t, processingEntity.ProcessingStatusFailed, record.Status)
+	processingUseCases.AssertCalled(t, "Finish", mock.Anything, record)
+}
+
+// TestUploadFileAlreadyProcessed tests the same file already processed under the same name is rejected without storing it.
+func TestUploadFileAlreadyProcessed(t *testing.T) {
+	// Given a local object store
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	// And processing use cases with the file already processed
+	processingUseCases := ucProcessingMock.NewMockProcessingUseCases()
+	processingUseCases.On("Accept", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, voProcessing.ErrObjectAlreadyProcessed)
+	target := uploads{store: store, structureUseCases: fileUsecases.NewStructureUseCases(), processingUseCases: processingUseCases, bucket: "bucket"}
+	// When a client uploads the file again
+	response := uploadFile(context.Background(), target, "client", getTestRequest(t, [][2]string{{"file", testCSV}}))
+	// Then the response is Conflict with the code of the catalogue
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+	assert.Equal(t, "FILE_ALREADY_PROCESSED", getUploadError(t, response).Code)
+	// And nothing is stored
+	objects, err := store.List("")
+	assert.Nil(t, err)
+	assert.Empty(t, objects)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/braejan/go-transactions-summary/internal/app"
+	ucProcessing "github.com/braejan/go-transactions-summary/internal/domain/processing/usecases"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+)
+
+// application is built once per Lambda instance, so warm invocations reuse the database pool.
+var application *app.App
+
+func handler(ctx context.Context, sqsEvent events.SQSEvent) (events.SQSEventResponse, error) {
+	if lambdaContext, ok := lambdacontext.FromContext(ctx); ok {
+		ctx = logger.WithRequestID(ctx, lambdaContext.AwsRequestID)
+	}
+	response := handleMessages(ctx, application.ConsumerUseCases, sqsEvent)
+	// The instance may be frozen after the invocation, so its spans are exported now.
+	if err := application.Tracer.Flush(ctx); err != nil {
+		logger.FromContext(ctx, application.Logger).Warn("spans not exported", "error", err)
+	}
+	return response, nil
+}
+
+func main() {
+	var err error
+	application, err = app.New(app.NewConfigurationFromEnv())
+	if err != nil {
+		log.Fatalf("failed to build the application: %v", err)
+	}
+	lambda.Start(handler)
+}
+
+// handleMessages handles every message of the event independently and reports the failed
+// ones as batch item failures. SQS deletes the handled messages and delivers the failed
+// ones again after their visibility timeout.
+func handleMessages(ctx context.Context, consumerUsecases ucProcessing.ConsumerUseCases, sqsEvent events.SQSEvent) (response events.SQSEventResponse) {
+	response.BatchItemFailures = []events.SQSBatchItemFailure{}
+	for _, sqsMessage := range sqsEvent.Records {
+		if err := consumerUsecases.Handle(ctx, toMessage(sqsMessage)); err != nil {
+			response.BatchItemFailures = append(response.BatchItemFailures, events.SQSBatchItemFailure{
+				ItemIdentifier: sqsMessage.MessageId,
+			})
+		}
+	}
+	return
+}
+
+// toMessage returns the queue message of a SQS event record.
+func toMessage(sqsMessage events.SQSMessage) (message queue.Message) {
+	message = queue.Message{
+		ID:            sqsMessage.MessageId,
+		Body:          []byte(sqsMessage.Body),
+		ReceiptHandle: sqsMessage.ReceiptHandle,
+		ReceiveCount:  1,
+	}
+	if receiveCount, err := strconv.Atoi(sqsMessage.Attributes["ApproximateReceiveCount"]); err == nil {
+		message.ReceiveCount = receiveCount
+	}
+	return
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	processingMock "github.com/braejan/go-transactions-summary/internal/domain/processing/usecases/mock"
+	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func getTestSQSMessage(id string, receiveCount string) events.SQSMessage {
+	return events.SQSMessage{
+		MessageId:     id,
+		ReceiptHandle: "receipt-" + id,
+		Body:          `{"bucket":"bucket","key":"` + id + `.csv"}`,
+		Attributes:    map[string]string{"ApproximateReceiveCount": receiveCount},
+	}
+}
+
+// TestHandleMessagesReportsFailures tests only the failed messages are reported.
+func TestHandleMessagesReportsFailures(t *testing.T) {
+	// Given consumer use cases failing the second message
+	first, second := getTestSQSMessage("first", "1"), getTestSQSMessage("second", "2")
+	consumerUsecases := processingMock.NewMockConsumerUseCases()
+	consumerUsecases.On("Handle", mock.Anything, toMessage(first)).Return(nil)
+	consumerUsecases.On("Handle", mock.Anything, toMessage(second)).Return(voPostgres.ErrOpeningDatabase)
+	// When handling the SQS event
+	response := handleMessages(context.Background(), consumerUsecases, events.SQSEvent{Records: []events.SQSMessage{first, second}})
+	// Then the second message is a batch item failure
+	assert.Equal(t, []events.SQSBatchItemFailure{{ItemIdentifier: "second"}}, response.BatchItemFailures)
+}
+
+// TestHandleMessagesWithoutFailures tests an empty failure list is returned when every message is handled.
+func TestHandleMessagesWithoutFailures(t *testing.T) {
+	// Given consumer use cases handling every message
+	message := getTestSQSMessage("first", "1")
+	consumerUsecases := processingMock.NewMockConsumerUseCases()
+	consumerUsecases.On("Handle", mock.Anything, toMessage(message)).Return(nil)
+	// When handling the SQS event
+	response := handleMessages(context.Background(), consumerUsecases, events.SQSEvent{Records: []events.SQSMessage{message}})
+	// Then there are no batch item failures
+	assert.NotNil(t, response.BatchItemFailures)
+	assert.Empty(t, response.BatchItemFailures)
+}
+
+// TestToMessage tests the SQS record is converted with its receive count.
+func TestToMessage(t *testing.T) {
+	// When converting a record received three times
+	message := toMessage(getTestSQSMessage("first", "3"))
+	// Then the message keeps its identifiers and receive count
+	assert.Equal(t, queue.Message{
+		ID:            "first",
+		Body:          []byte(`{"bucket":"bucket","key":"first.csv"}`),
+		ReceiptHandle: "receipt-first",
+		ReceiveCount:  3,
+	}, message)
+	// And a record without the attribute counts as the first receive
+	assert.Equal(t, 1, toMessage(events.SQSMessage{}).ReceiveCount)
+}
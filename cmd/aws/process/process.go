@@ -2,102 +2,73 @@ package main
 
 import (
 	"context"
-	"fmt"
-	"os"
+	"errors"
+	"log"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
-	apRepo "github.com/braejan/go-transactions-summary/internal/domain/account/repository/postgres"
-	ucAccount "github.com/braejan/go-transactions-summary/internal/domain/account/usecases"
-	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
-	ucFile "github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
-	txRepo "github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/postgres"
-	ucTx "github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases"
-	upRepo "github.com/braejan/go-transactions-summary/internal/domain/user/repository/postgres"
-	ucUser "github.com/braejan/go-transactions-summary/internal/domain/user/usecases"
-	"github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/braejan/go-transactions-summary/internal/app"
+	processingEntity "github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	ucProcessing "github.com/braejan/go-transactions-summary/internal/domain/processing/usecases"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
 )
 
+// application is built once per Lambda instance, so warm invocations reuse the database pool.
+var application *app.App
+
 func handler(ctx context.Context, s3Event events.S3Event) (err error) {
-	// Create a new AWS session using environment variables
-	sess, err := session.NewSession(&aws.Config{
-		Region: aws.String(os.Getenv("AWS_REGION")),
-	})
-	if err != nil {
-		err = fmt.Errorf("failed to create AWS session: %v", err)
+	if lambdaContext, ok := lambdacontext.FromContext(ctx); ok {
+		ctx = logger.WithRequestID(ctx, lambdaContext.AwsRequestID)
 	}
-	for _, record := range s3Event.Records {
-		bucket := record.S3.Bucket.Name
-		key := record.S3.Object.Key
-		// Create a downloader with the session and default options
-		downloader := s3manager.NewDownloader(sess)
-		// Create a file to write the S3 Object contents to.
-		f, err := os.Create("/tmp/" + key)
-		if err != nil {
-			return fmt.Errorf("failed to create file %q, %v", key, err)
-		}
-		defer f.Close()
-		path := f.Name()
-		// Write the contents of S3 Object to the file
-		n, err := downloader.Download(f, &s3.GetObjectInput{
-			Bucket: aws.String(bucket),
-			Key:    aws.String(key),
-		})
-		if err != nil {
-			return fmt.Errorf("failed to download file, %v", err)
-		}
-
-		fmt.Printf("file downloaded, %d bytes\n", n)
-		f.Seek(0, 0)
-		errProccess := handleFile(f, key, path)
-		if errProccess != nil {
-			return fmt.Errorf("failed to process file, %v", errProccess)
-		}
-
+	records, err := processRecords(ctx, application.IngestionUseCases, s3Event)
+	// The instance may be frozen after the invocation, so its spans are exported now.
+	if errFlush := application.Tracer.Flush(ctx); errFlush != nil {
+		logger.FromContext(ctx, application.Logger).Warn("spans not exported", "error", errFlush)
+	}
+	for _, record := range records {
+		logger.FromContext(ctx, application.Logger).Info("file processed", "key", record.Key, "status", record.Status,
+			"lines", record.Lines, "invalid_lines", record.InvalidLines, "transactions", record.Transactions, "error", record.Error)
 	}
 	return
 }
 
 func main() {
+	var err error
+	application, err = app.New(app.NewConfigurationFromEnv())
+	if err != nil {
+		log.Fatalf("failed to build the application: %v", err)
+	}
 	lambda.Start(handler)
 }
 
-func handleFile(file *os.File, fileName string, path string) (err error) {
-	// Create a postgres configuration from environment variables
-	postgresConfig := postgres.NewPostgresConfigurationFromEnv()
-	// Create a db Based on the configuration
-	postgresDatabase := postgres.NewBasePostgresDatabase(postgresConfig)
-	// Create a user repository
-	userRepository := upRepo.NewPostgresUserRepository(postgresDatabase)
-	// Create a account repository
-	accountRepository := apRepo.NewPostgresAccountRepository(postgresDatabase)
-	// Create a transaction repository
-	transactionRepository := txRepo.NewPostgresTransactionRepository(postgresDatabase)
-	// Create a user usecase
-	userUsecase, err := ucUser.NewUserUseCases(userRepository)
-	if err != nil {
-		return
-	}
-	// Create a account usecase
-	accountUsecase, err := ucAccount.NewAccountUseCases(accountRepository, userRepository)
-	if err != nil {
-		return
-	}
-	// Create a transaction usecase
-	transactionUsecase, err := ucTx.NewTransactionUseCases(transactionRepository)
-	if err != nil {
-		return
-	}
-	// Create a file usecase
-	fileUsecases, err := ucFile.NewFileUseCases(userUsecase, accountUsecase, transactionUsecase)
-	if err != nil {
-		return
+// processRecords ingests the object of every record independently and returns their
+// processing records. Every attempt is the last one: the files that fail are moved to
+// the failed prefix with an error sidecar. The returned error is the first one that kept
+// a result from being recorded, so the event is retried; the objects already processed
+// are skipped on the retry.
+func processRecords(ctx context.Context, ingestionUsecases ucProcessing.IngestionUseCases, s3Event events.S3Event) (records []*processingEntity.ProcessingRecord, err error) {
+	for _, s3Record := range s3Event.Records {
+		key := s3Record.S3.Object.URLDecodedKey
+		if key == "" {
+			key = s3Record.S3.Object.Key
+		}
+		object := processingEntity.ObjectReference{
+			Bucket: s3Record.S3.Bucket.Name,
+			Key:    key,
+			ETag:   s3Record.S3.Object.ETag,
+		}
+		record, errRecord := ingestionUsecases.Ingest(ctx, object, true)
+		if errors.Is(errRecord, voProcessing.ErrIngestionAttemptsExhausted) {
+			errRecord = nil
+		}
+		if record != nil {
+			records = append(records, record)
+		}
+		if errRecord != nil && err == nil {
+			err = errRecord
+		}
 	}
-	txFile := fileEntity.NewTxFile(fileName, path, "s3hash", 0)
-	err = fileUsecases.ProcessFile(*txFile, file)
 	return
 }
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	ucFile "github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	fileMock "github.com/braejan/go-transactions-summary/internal/domain/file/usecases/mock"
+	processingEntity "github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	processingRepoMock "github.com/braejan/go-transactions-summary/internal/domain/processing/repository/mock"
+	ucProcessing "github.com/braejan/go-transactions-summary/internal/domain/processing/usecases"
+	processingMock "github.com/braejan/go-transactions-summary/internal/domain/processing/usecases/mock"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	validContent   = "Id,Date,Transaction\n0,7/5,+60.5\n1,7/28,-10.3\n"
+	invalidContent = "Id,Date,Transaction\n0,7/5,+60.5\n1,13/45,-10.3\n"
+)
+
+func getTestEvent(keys ...string) events.S3Event {
+	s3Event := events.S3Event{}
+	for _, key := range keys {
+		record := events.S3EventRecord{}
+		record.S3.Bucket.Name = "bucket"
+		record.S3.Object.Key = key
+		s3Event.Records = append(s3Event.Records, record)
+	}
+	return s3Event
+}
+
+// getTestStore returns a local object store with the given objects.
+func getTestStore(t *testing.T, objects map[string]string) storage.ObjectStore {
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	for key, content := range objects {
+		assert.Nil(t, store.Put(key, strings.NewReader(content)))
+	}
+	return store
+}
+
+// getTestFileUseCases returns file use cases checking the real structure of the files and
+// storing the content of the processed ones by name. ProcessFile returns processErr.
+func getTestFileUseCases(processed map[string]string, processErr error) ucFile.FileUseCases {
+	structure := ucFile.NewStructureUseCases()
+	fileUsecases := fileMock.NewMockFileUseCases()
+	fileUsecases.On("CheckStructure", mock.Anything, mock.Anything).Return(
+		func(txFile fileEntity.TxFile, reader io.Reader) *fileEntity.ValidationReport {
+			report, _ := structure.CheckStructure(txFile, reader)
+			return report
+		}, nil)
+	fileUsecases.On("ProcessFile", mock.Anything, mock.Anything, mock.Anything).Return(0, processErr).Run(func(args mock.Arguments) {
+		raw, _ := io.ReadAll(args.Get(2).(*os.File))
+		processed[args.Get(1).(fileEntity.TxFile).Name] = string(raw)
+	})
+	return fileUsecases
+}
+
+// getTestIngestionUseCases returns ingestion use cases over a processing repository without records.
+func getTestIngestionUseCases(t *testing.T, store storage.ObjectStore, fileUsecases ucFile.FileUseCases) ucProcessing.IngestionUseCases {
+	processingRepo := processingRepoMock.NewMockProcessingRepository()
+	processingRepo.On("GetByObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, voProcessing.ErrProcessingRecordNotFound)
+	processingRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	processingUsecases, err := ucProcessing.NewProcessingUseCases(processingRepo)
+	assert.Nil(t, err)
+	ingestionUsecases, err := ucProcessing.NewIngestionUseCases(store, fileUsecases, processingUsecases)
+	assert.Nil(t, err)
+	return ingestionUsecases
+}
+
+// TestProcessRecordsFromLocalStore tests the object of the event is processed and recorded.
+func TestProcessRecordsFromLocalStore(t *testing.T) {
+	// Given a local object store with an uploaded file
+	store := getTestStore(t, map[string]string{"txns.csv": validContent})
+	processed := map[string]string{}
+	ingestionUsecases := getTestIngestionUseCases(t, store, getTestFileUseCases(processed, nil))
+	// When processing the S3 event
+	records, err := processRecords(context.Background(), ingestionUsecases, getTestEvent("txns.csv"))
+	// Then the whole file is processed
+	assert.Nil(t, err)
+	assert.Equal(t, validContent, processed["txns.csv"])
+	// And the processing record is returned
+	assert.Len(t, records, 1)
+	assert.Equal(t, "bucket", records[0].Bucket)
+	assert.Equal(t, processingEntity.ProcessingStatusSucceeded, records[0].Status)
+}
+
+// TestProcessRecordsIndependently tests a failing record does not stop the rest of the event.
+func TestProcessRecordsIndependently(t *testing.T) {
+	// Given a local object store with an invalid and a valid file
+	store := getTestStore(t, map[string]string{"invalid.csv": invalidContent, "valid.csv": validContent})
+	processed := map[string]string{}
+	ingestionUsecases := getTestIngestionUseCases(t, store, getTestFileUseCases(processed, nil))
+	// When processing an event with the invalid, a missing, a moved and the valid file
+	records, err := processRecords(context.Background(), ingestionUsecases, getTestEvent("invalid.csv", "missing.csv", "failed/other.csv", "valid.csv"))
+	// Then every record but the moved one is processed
+	assert.Nil(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, validContent, processed["valid.csv"])
+	// And the invalid file failed and was moved
+	assert.Equal(t, processingEntity.ProcessingStatusFailed, records[0].Status)
+	assert.Equal(t, voFile.ErrFileHasInvalidLines.Error(), records[0].Error)
+	_, err = store.Head(ucProcessing.FailedPrefix + "invalid.csv")
+	assert.Nil(t, err)
+	// And the missing file failed
+	assert.Equal(t, storage.ErrObjectNotFound.Error(), records[1].Error)
+	// And the valid file succeeded
+	assert.Equal(t, processingEntity.ProcessingStatusSucceeded, records[2].Status)
+}
+
+// TestProcessRecordsMovesOnFirstFailure tests every attempt of the S3 event is the last one.
+func TestProcessRecordsMovesOnFirstFailure(t *testing.T) {
+	// Given a local object store with an uploaded file
+	store := getTestStore(t, map[string]string{"txns.csv": validContent})
+	// And file use cases failing to process it
+	ingestionUsecases := getTestIngestionUseCases(t, store, getTestFileUseCases(map[string]string{}, voPostgres.ErrOpeningDatabase))
+	// When processing the S3 event
+	records, err := processRecords(context.Background(), ingestionUsecases, getTestEvent("txns.csv"))
+	// Then the failure is handled
+	assert.Nil(t, err)
+	assert.Equal(t, voPostgres.ErrOpeningDatabase.Error(), records[0].Error)
+	// And the file is moved to the failed prefix
+	_, err = store.Head(ucProcessing.FailedPrefix + "txns.csv")
+	assert.Nil(t, err)
+}
+
+// TestProcessRecordsErrRecording tests the error returned when the processing cannot be recorded.
+func TestProcessRecordsErrRecording(t *testing.T) {
+	// Given ingestion use cases failing to record the first file
+	ingestionUsecases := processingMock.NewMockIngestionUseCases()
+	ingestionUsecases.On("Ingest", mock.Anything, processingEntity.ObjectReference{Bucket: "bucket", Key: "first.csv"}, true).Return(nil, voPostgres.ErrOpeningDatabase)
+	second := &processingEntity.ProcessingRecord{Key: "second.csv", Status: processingEntity.ProcessingStatusSucceeded}
+	ingestionUsecases.On("Ingest", mock.Anything, processingEntity.ObjectReference{Bucket: "bucket", Key: "second.csv"}, true).Return(second, nil)
+	// When processing the S3 event
+	records, err := processRecords(context.Background(), ingestionUsecases, getTestEvent("first.csv", "second.csv"))
+	// Then the error is returned so the event is retried
+	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	// And the second file is processed
+	assert.Equal(t, []*processingEntity.ProcessingRecord{second}, records)
+}
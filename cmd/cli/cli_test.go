@@ -0,0 +1,337 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	acEntity "github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	accountMock "github.com/braejan/go-transactions-summary/internal/domain/account/usecases/mock"
+	apiKeyEntity "github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+	apiKeyMock "github.com/braejan/go-transactions-summary/internal/domain/apikey/usecases/mock"
+	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	ucFile "github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	fileMock "github.com/braejan/go-transactions-summary/internal/domain/file/usecases/mock"
+	summaryEntity "github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
+	txEntity "github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	txMock "github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases/mock"
+	voAccount "github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	voAPIKey "github.com/braejan/go-transactions-summary/internal/valueobject/apikey"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+	migrationsMock "github.com/braejan/go-transactions-summary/internal/valueobject/migrations/mock"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	validContent   = "Id,Date,Transaction\n0,7/5,+60.5\n1,7/28,-10.3\n"
+	invalidContent = "Id,Date,Transaction\n0,7/5,+60.5\n1,13/45,-10.3\n"
+)
+
+// getTestFile writes the content to a temporary CSV file and returns its path.
+func getTestFile(t *testing.T, content string) string {
+	filePath := filepath.Join(t.TempDir(), "txns.csv")
+	assert.Nil(t, os.WriteFile(filePath, []byte(content), 0o644))
+	return filePath
+}
+
+// getTestFileUseCases returns file use cases checking the real structure of the files and
+// counting the processed ones.
+func getTestFileUseCases(processed *int) ucFile.FileUseCases {
+	structure := ucFile.NewStructureUseCases()
+	fileUseCases := fileMock.NewMockFileUseCases()
+	fileUseCases.On("CheckStructure", mock.Anything, mock.Anything).Return(
+		func(txFile fileEntity.TxFile, reader io.Reader) *fileEntity.ValidationReport {
+			report, _ := structure.CheckStructure(txFile, reader)
+			return report
+		}, nil)
+	fileUseCases.On("ProcessFile", mock.Anything, mock.Anything, mock.Anything).Return(2, nil).Run(func(args mock.Arguments) {
+		*processed++
+	})
+	return fileUseCases
+}
+
+// TestValidateValidFile tests a valid file prints its counts.
+func TestValidateValidFile(t *testing.T) {
+	out := &bytes.Buffer{}
+	// When validating a valid file
+	err := runValidate([]string{getTestFile(t, validContent)}, out)
+	// Then the counts are printed
+	assert.Nil(t, err)
+	assert.Equal(t, "txns.csv: 2 lines, 0 invalid\n", out.String())
+}
+
+// TestValidateInvalidFile tests an invalid file prints its problems and fails.
+func TestValidateInvalidFile(t *testing.T) {
+	out := &bytes.Buffer{}
+	// When validating an invalid file
+	err := runValidate([]string{getTestFile(t, invalidContent)}, out)
+	// Then the error returned is ErrFileHasInvalidLines
+	assert.Equal(t, voFile.ErrFileHasInvalidLines, err)
+	// And the problems are printed
+	assert.Contains(t, out.String(), "txns.csv: 2 lines, 1 invalid\n")
+	assert.Contains(t, out.String(), `line 3, Date "13/45"`)
+}
+
+// TestValidateAsJSON tests the report is printed as JSON.
+func TestValidateAsJSON(t *testing.T) {
+	out := &bytes.Buffer{}
+	// When validating an invalid file with the json flag
+	err := runValidate([]string{"-json", getTestFile(t, invalidContent)}, out)
+	assert.Equal(t, voFile.ErrFileHasInvalidLines, err)
+	// Then the report is printed as JSON
+	report := fileEntity.ValidationReport{}
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, 1, report.InvalidLines)
+	assert.Equal(t, 3, report.Problems[0].Line)
+}
+
+// TestValidateUsage tests the usage error when the file is missing.
+func TestValidateUsage(t *testing.T) {
+	// When validating without a file
+	err := runValidate(nil, &bytes.Buffer{})
+	// Then the error returned is errUsage
+	assert.Equal(t, errUsage, err)
+}
+
+// TestIngestValidFile tests a valid file is processed.
+func TestIngestValidFile(t *testing.T) {
+	out := &bytes.Buffer{}
+	processed := 0
+	// When ingesting a valid file
+	err := runIngest(tracing.NewNoopTracer(), getTestFileUseCases(&processed), []string{getTestFile(t, validContent)}, out)
+	// Then the file is processed
+	assert.Nil(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, "txns.csv: 2 lines, 0 invalid\n✅ txns.csv ingested\n", out.String())
+}
+
+// TestIngestInvalidFile tests nothing is processed when the file has invalid lines.
+func TestIngestInvalidFile(t *testing.T) {
+	processed := 0
+	// When ingesting an invalid file
+	err := runIngest(tracing.NewNoopTracer(), getTestFileUseCases(&processed), []string{getTestFile(t, invalidContent)}, &bytes.Buffer{})
+	// Then the error returned is ErrFileHasInvalidLines
+	assert.Equal(t, voFile.ErrFileHasInvalidLines, err)
+	// And the file is not processed
+	assert.Equal(t, 0, processed)
+}
+
+// TestIngestMissingFile tests the error returned when the file does not exist.
+func TestIngestMissingFile(t *testing.T) {
+	processed := 0
+	// When ingesting a missing file
+	err := runIngest(tracing.NewNoopTracer(), getTestFileUseCases(&processed), []string{filepath.Join(t.TempDir(), "missing.csv")}, &bytes.Buffer{})
+	// Then the error is returned
+	assert.True(t, os.IsNotExist(err))
+}
+
+// TestSummary tests the summary of the transactions of a user is printed.
+func TestSummary(t *testing.T) {
+	// Given a user with an account and two transactions
+	account := acEntity.Account{ID: uuid.New(), UserID: 7}
+	accountUseCases := accountMock.NewMockAccountUseCases()
+	accountUseCases.On("GetByUserID", mock.Anything, int64(7)).Return(account, nil)
+	credit, _ := txEntity.NewTransaction(account.ID, 60.5, time.Date(2023, time.July, 5, 0, 0, 0, 0, time.UTC), "txns.csv")
+	debit, _ := txEntity.NewTransaction(account.ID, -10.5, time.Date(2023, time.August, 2, 0, 0, 0, 0, time.UTC), "txns.csv")
+	transactionUseCases := txMock.NewMockTransactionUseCases()
+	transactionUseCases.On("GetByAccountID", mock.Anything, account.ID).Return([]txEntity.Transaction{*credit, *debit}, nil)
+	out := &bytes.Buffer{}
+	// When printing the summary of the user as JSON
+	err := runSummary(context.Background(), accountUseCases, transactionUseCases, []string{"--user", "7", "-json"}, out)
+	// Then the summary has the transactions of the user
+	assert.Nil(t, err)
+	summary := summaryEntity.Summary{}
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &summary))
+	assert.Equal(t, int64(7), summary.UserID)
+	assert.Equal(t, 50.0, summary.Balance)
+	assert.Equal(t, 2, summary.Transactions)
+}
+
+// TestSummaryAsText tests the summary is printed as text by default.
+func TestSummaryAsText(t *testing.T) {
+	// Given a user with an account without transactions
+	account := acEntity.Account{ID: uuid.New(), UserID: 7}
+	accountUseCases := accountMock.NewMockAccountUseCases()
+	accountUseCases.On("GetByUserID", mock.Anything, int64(7)).Return(account, nil)
+	transactionUseCases := txMock.NewMockTransactionUseCases()
+	transactionUseCases.On("GetByAccountID", mock.Anything, account.ID).Return([]txEntity.Transaction{}, nil)
+	out := &bytes.Buffer{}
+	// When printing the summary of the user
+	err := runSummary(context.Background(), accountUseCases, transactionUseCases, []string{"-user", "7"}, out)
+	// Then the summary is printed
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "user 7\n")
+	assert.Contains(t, out.String(), "transactions:   0\n")
+}
+
+// TestSummaryErrAccountNotFound tests the error returned when the user has no account.
+func TestSummaryErrAccountNotFound(t *testing.T) {
+	// Given a user without an account
+	accountUseCases := accountMock.NewMockAccountUseCases()
+	accountUseCases.On("GetByUserID", mock.Anything, int64(7)).Return(acEntity.Account{}, voAccount.ErrAccountNotFound)
+	// When printing the summary of the user
+	err := runSummary(context.Background(), accountUseCases, txMock.NewMockTransactionUseCases(), []string{"-user", "7"}, &bytes.Buffer{})
+	// Then the error returned is ErrAccountNotFound
+	assert.Equal(t, voAccount.ErrAccountNotFound, err)
+}
+
+// TestSummaryUsage tests the usage error when the user is missing.
+func TestSummaryUsage(t *testing.T) {
+	// When printing a summary without a user
+	err := runSummary(context.Background(), accountMock.NewMockAccountUseCases(), txMock.NewMockTransactionUseCases(), nil, &bytes.Buffer{})
+	// Then the error returned is errUsage
+	assert.Equal(t, errUsage, err)
+}
+
+// TestMigrateUp tests the applied migrations are printed.
+func TestMigrateUp(t *testing.T) {
+	// Given a migrator with a pending migration
+	migrator := migrationsMock.NewMockMigrator()
+	migrator.On("Up").Return([]migrations.Migration{{Version: 1, Name: "initial_schema"}}, nil)
+	out := &bytes.Buffer{}
+	// When applying the migrations
+	err := runMigrate(migrator, []string{"up"}, out)
+	// Then the applied migration is printed
+	assert.Nil(t, err)
+	assert.Equal(t, "applied 0001_initial_schema\n", out.String())
+}
+
+// TestMigrateDownWithSteps tests the steps flag is passed to the migrator.
+func TestMigrateDownWithSteps(t *testing.T) {
+	// Given a migrator without applied migrations
+	migrator := migrationsMock.NewMockMigrator()
+	migrator.On("Down", 2).Return(nil, nil)
+	out := &bytes.Buffer{}
+	// When reverting two migrations
+	err := runMigrate(migrator, []string{"down", "-steps", "2"}, out)
+	// Then nothing is reverted
+	assert.Nil(t, err)
+	assert.Equal(t, "no migrations reverted\n", out.String())
+	migrator.AssertExpectations(t)
+}
+
+// TestMigrateStatus tests the applied and the pending migrations are listed.
+func TestMigrateStatus(t *testing.T) {
+	// Given a migrator with an applied and a pending migration
+	migrator := migrationsMock.NewMockMigrator()
+	appliedAt := time.Date(2023, 7, 5, 10, 30, 0, 0, time.UTC)
+	migrator.On("Status").Return([]migrations.MigrationStatus{
+		{Version: 1, Name: "initial_schema", Applied: true, AppliedAt: appliedAt},
+		{Version: 2, Name: "add_index"},
+	}, nil)
+	out := &bytes.Buffer{}
+	// When listing the migrations
+	err := runMigrate(migrator, []string{"status"}, out)
+	// Then both are printed with their status
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "0001_initial_schema")
+	assert.Contains(t, out.String(), "applied 2023-07-05 10:30:00")
+	assert.Contains(t, out.String(), "0002_add_index")
+	assert.Contains(t, out.String(), "pending")
+}
+
+// TestMigrateErrApplyingMigration tests the error of the migrator is returned.
+func TestMigrateErrApplyingMigration(t *testing.T) {
+	// Given a migrator failing to apply a migration
+	migrator := migrationsMock.NewMockMigrator()
+	migrator.On("Up").Return(nil, migrations.ErrApplyingMigration)
+	// When applying the migrations
+	err := runMigrate(migrator, []string{"up"}, &bytes.Buffer{})
+	// Then the error returned is ErrApplyingMigration
+	assert.Equal(t, migrations.ErrApplyingMigration, err)
+}
+
+// TestMigrateUsage tests the usage error of an unknown migrate subcommand.
+func TestMigrateUsage(t *testing.T) {
+	// When running migrate without or with an unknown subcommand
+	errNone := runMigrate(migrationsMock.NewMockMigrator(), nil, &bytes.Buffer{})
+	errUnknown := runMigrate(migrationsMock.NewMockMigrator(), []string{"redo"}, &bytes.Buffer{})
+	// Then the errors returned are errUsage
+	assert.Equal(t, errUsage, errNone)
+	assert.Equal(t, errUsage, errUnknown)
+}
+
+// TestKeysCreate tests the token of the created key is printed.
+func TestKeysCreate(t *testing.T) {
+	// Given use cases creating a key
+	useCases := apiKeyMock.NewMockAPIKeyUseCases()
+	key := apiKeyEntity.APIKey{ID: "0123456789abcdef", Name: "uploader", Scopes: []string{"files:upload", "transactions:read"}}
+	useCases.On("Create", mock.Anything, "uploader", []string{"files:upload", "transactions:read"}).Return(key, "tsk_0123456789abcdef_secret", nil)
+	out := &bytes.Buffer{}
+	// When creating a key with two scopes
+	err := runKeys(context.Background(), useCases, []string{"create", "-name", "uploader", "-scopes", "files:upload, transactions:read"}, out)
+	// Then the key and its token are printed
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "created key 0123456789abcdef for uploader with scopes files:upload,transactions:read")
+	assert.Contains(t, out.String(), "tsk_0123456789abcdef_secret")
+}
+
+// TestKeysList tests the active and the revoked keys are listed.
+func TestKeysList(t *testing.T) {
+	// Given use cases with an active and a revoked key
+	useCases := apiKeyMock.NewMockAPIKeyUseCases()
+	revokedAt := time.Date(2023, 7, 5, 10, 30, 0, 0, time.UTC)
+	useCases.On("List", mock.Anything).Return([]apiKeyEntity.APIKey{
+		{ID: "0123456789abcdef", Name: "uploader", Scopes: []string{"files:upload"}},
+		{ID: "fedcba9876543210", Name: "reader", Scopes: []string{"transactions:read"}, RevokedAt: revokedAt},
+	}, nil)
+	out := &bytes.Buffer{}
+	// When listing the keys
+	err := runKeys(context.Background(), useCases, []string{"list"}, out)
+	// Then both are printed with their status
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "0123456789abcdef")
+	assert.Contains(t, lines[0], "active")
+	assert.Contains(t, lines[1], "revoked 2023-07-05 10:30:00")
+}
+
+// TestKeysRevoke tests the key of the argument is revoked.
+func TestKeysRevoke(t *testing.T) {
+	// Given use cases revoking a key and failing with an unknown one
+	useCases := apiKeyMock.NewMockAPIKeyUseCases()
+	useCases.On("Revoke", mock.Anything, "0123456789abcdef").Return(nil)
+	useCases.On("Revoke", mock.Anything, "unknown").Return(voAPIKey.ErrAPIKeyNotFound)
+	out := &bytes.Buffer{}
+	// When revoking the key
+	err := runKeys(context.Background(), useCases, []string{"revoke", "0123456789abcdef"}, out)
+	// Then the key is revoked
+	assert.Nil(t, err)
+	assert.Equal(t, "revoked key 0123456789abcdef\n", out.String())
+	// When revoking an unknown key
+	err = runKeys(context.Background(), useCases, []string{"revoke", "unknown"}, out)
+	// Then the error returned is ErrAPIKeyNotFound
+	assert.Equal(t, voAPIKey.ErrAPIKeyNotFound, err)
+}
+
+// TestKeysUsage tests the usage error of an unknown keys subcommand or a missing key.
+func TestKeysUsage(t *testing.T) {
+	useCases := apiKeyMock.NewMockAPIKeyUseCases()
+	// When running keys without subcommand, with an unknown one or revoke without key
+	errNone := runKeys(context.Background(), useCases, nil, &bytes.Buffer{})
+	errUnknown := runKeys(context.Background(), useCases, []string{"rotate"}, &bytes.Buffer{})
+	errRevoke := runKeys(context.Background(), useCases, []string{"revoke"}, &bytes.Buffer{})
+	// Then the errors returned are errUsage
+	assert.Equal(t, errUsage, errNone)
+	assert.Equal(t, errUsage, errUnknown)
+	assert.Equal(t, errUsage, errRevoke)
+}
+
+// TestRunUnknownCommand tests the usage error of an unknown command.
+func TestRunUnknownCommand(t *testing.T) {
+	// When running an unknown command
+	err := run("backfill", nil, &bytes.Buffer{})
+	// Then the error returned is errUsage
+	assert.Equal(t, errUsage, err)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	ucFile "github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+)
+
+// runIngest checks a file and stores its transactions. Nothing is stored when the file
+// has invalid lines.
+func runIngest(tracer tracing.Tracer, fileUseCases ucFile.FileUseCases, args []string, out io.Writer) (err error) {
+	flags := newFlagSet("ingest", "<file.csv>")
+	asJSON := flags.Bool("json", false, "print the validation report as JSON")
+	filePath, err := fileArgument(flags, args)
+	if err != nil {
+		return
+	}
+	txFile, file, err := checkFile(fileUseCases, filePath, *asJSON, out)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	ctx, span := tracer.Start(logger.WithFileID(context.Background(), txFile.Hash), "cli.ingest")
+	defer span.EndWithError(&err)
+	if _, err = fileUseCases.ProcessFile(ctx, *txFile, file); err != nil {
+		return
+	}
+	if !*asJSON {
+		fmt.Fprintf(out, "✅ %s ingested\n", txFile.Name)
+	}
+	return
+}
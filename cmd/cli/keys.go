@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	ucAPIKey "github.com/braejan/go-transactions-summary/internal/domain/apikey/usecases"
+)
+
+const keysUsage = `usage: cli keys <create|list|revoke> [flags]
+
+  create -name <name> -scopes <scope,...>
+                      create an API key and print its token, shown only once
+  list                list the API keys and their scopes
+  revoke <id>         revoke an API key
+`
+
+// runKeys creates, lists or revokes the API keys of the clients.
+func runKeys(ctx context.Context, apiKeyUseCases ucAPIKey.APIKeyUseCases, args []string, out io.Writer) (err error) {
+	if len(args) == 0 {
+		fmt.Fprint(out, keysUsage)
+		return errUsage
+	}
+	switch args[0] {
+	case "create":
+		flags := newFlagSet("keys create", "")
+		name := flags.String("name", "", "name of the client of the key")
+		scopes := flags.String("scopes", "", "comma separated scopes: files:upload, transactions:read, users:admin")
+		if err = noArguments(flags, args[1:]); err != nil {
+			return
+		}
+		key, token, errCreate := apiKeyUseCases.Create(ctx, *name, splitScopes(*scopes))
+		if errCreate != nil {
+			return errCreate
+		}
+		fmt.Fprintf(out, "created key %s for %s with scopes %s\n", key.ID, key.Name, strings.Join(key.Scopes, ","))
+		fmt.Fprintf(out, "token (shown only once): %s\n", token)
+	case "list":
+		if err = noArguments(newFlagSet("keys list", ""), args[1:]); err != nil {
+			return
+		}
+		keys, errList := apiKeyUseCases.List(ctx)
+		if errList != nil {
+			return errList
+		}
+		for _, key := range keys {
+			status := "active"
+			if key.IsRevoked() {
+				status = "revoked " + key.RevokedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%s  %-20s %-45s %s\n", key.ID, key.Name, strings.Join(key.Scopes, ","), status)
+		}
+	case "revoke":
+		flags := newFlagSet("keys revoke", "<id>")
+		if err = flags.Parse(args[1:]); err != nil {
+			return
+		}
+		if flags.NArg() != 1 {
+			flags.Usage()
+			return errUsage
+		}
+		if err = apiKeyUseCases.Revoke(ctx, flags.Arg(0)); err != nil {
+			return
+		}
+		fmt.Fprintf(out, "revoked key %s\n", flags.Arg(0))
+	default:
+		fmt.Fprint(out, keysUsage)
+		return errUsage
+	}
+	return
+}
+
+// splitScopes returns the scopes of a comma separated list, without blanks.
+func splitScopes(list string) (scopes []string) {
+	for _, scope := range strings.Split(list, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/braejan/go-transactions-summary/internal/app"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+)
+
+// errUsage is the error returned when a command is called with invalid arguments.
+var errUsage = errors.New("invalid arguments")
+
+const usage = `usage: cli <command> [flags] [arguments]
+
+commands:
+  ingest <file.csv>     check the file and store its transactions in the configured database
+  validate <file.csv>   check the file without touching the database
+  summary -user <id>    print the summary of the transactions of a user
+  migrate <up|down|status>
+                        apply, revert or list the schema migrations
+  keys <create|list|revoke>
+                        manage the API keys of the clients
+
+Run "cli <command> -h" to see the flags of a command.
+`
+
+// The cli command lets the ops team ingest files and check summaries from a shell. It reads
+// the same environment variables as the REST API and the Lambdas.
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	err := run(os.Args[1], os.Args[2:], os.Stdout)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	default:
+		log.Println("❌", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command with its arguments, writing its output to out.
+func run(command string, args []string, out io.Writer) (err error) {
+	switch command {
+	case "validate":
+		return runValidate(args, out)
+	case "ingest":
+		application, errApp := app.New(app.NewConfigurationFromEnv())
+		if errApp != nil {
+			return errApp
+		}
+		defer application.Close()
+		return runIngest(application.Tracer, application.FileUseCases, args, out)
+	case "summary":
+		application, errApp := app.New(app.NewConfigurationFromEnv())
+		if errApp != nil {
+			return errApp
+		}
+		defer application.Close()
+		return runSummary(context.Background(), application.AccountUseCases, application.TransactionUseCases, args, out)
+	case "migrate":
+		application, errApp := app.New(app.NewConfigurationFromEnv())
+		if errApp != nil {
+			return errApp
+		}
+		defer application.Close()
+		migrator, errMigrator := migrations.NewMigrator(application.Database, migrations.WithLogger(application.Logger))
+		if errMigrator != nil {
+			return errMigrator
+		}
+		return runMigrate(migrator, args, out)
+	case "keys":
+		application, errApp := app.New(app.NewConfigurationFromEnv())
+		if errApp != nil {
+			return errApp
+		}
+		defer application.Close()
+		return runKeys(context.Background(), application.APIKeyUseCases, args, out)
+	case "help", "-h", "--help":
+		fmt.Fprint(out, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		return errUsage
+	}
+}
+
+// newFlagSet returns the flag set of a command, printing its usage to stderr.
+func newFlagSet(name string, arguments string) (flags *flag.FlagSet) {
+	flags = flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: cli %s [flags] %s\n", name, arguments)
+		flags.PrintDefaults()
+	}
+	return
+}
+
+// fileArgument parses the flags of a command receiving a single file and returns the file.
+func fileArgument(flags *flag.FlagSet, args []string) (filePath string, err error) {
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		err = errUsage
+		return
+	}
+	filePath = flags.Arg(0)
+	return
+}
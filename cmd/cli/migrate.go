@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+)
+
+const migrateUsage = `usage: cli migrate <up|down|status> [flags]
+
+  up                  apply the pending migrations
+  down [-steps <n>]   revert the last n applied migrations, 1 by default
+  status              list the migrations and whether they are applied
+`
+
+// runMigrate applies, reverts or lists the schema migrations.
+func runMigrate(migrator migrations.Migrator, args []string, out io.Writer) (err error) {
+	if len(args) == 0 {
+		fmt.Fprint(out, migrateUsage)
+		return errUsage
+	}
+	switch args[0] {
+	case "up":
+		if err = noArguments(newFlagSet("migrate up", ""), args[1:]); err != nil {
+			return
+		}
+		applied, errUp := migrator.Up()
+		if errUp != nil {
+			return errUp
+		}
+		printMigrations("applied", applied, out)
+	case "down":
+		flags := newFlagSet("migrate down", "")
+		steps := flags.Int("steps", 1, "number of migrations to revert")
+		if err = noArguments(flags, args[1:]); err != nil {
+			return
+		}
+		reverted, errDown := migrator.Down(*steps)
+		if errDown != nil {
+			return errDown
+		}
+		printMigrations("reverted", reverted, out)
+	case "status":
+		if err = noArguments(newFlagSet("migrate status", ""), args[1:]); err != nil {
+			return
+		}
+		statuses, errStatus := migrator.Status()
+		if errStatus != nil {
+			return errStatus
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%04d_%-30s %s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		fmt.Fprint(out, migrateUsage)
+		return errUsage
+	}
+	return
+}
+
+// noArguments parses the flags of a command receiving no arguments.
+func noArguments(flags *flag.FlagSet, args []string) (err error) {
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		err = errUsage
+	}
+	return
+}
+
+// printMigrations writes the versions and names of the migrations.
+func printMigrations(action string, migrationList []migrations.Migration, out io.Writer) {
+	if len(migrationList) == 0 {
+		fmt.Fprintf(out, "no migrations %s\n", action)
+		return
+	}
+	for _, migration := range migrationList {
+		fmt.Fprintf(out, "%s %04d_%s\n", action, migration.Version, migration.Name)
+	}
+}
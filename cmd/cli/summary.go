@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	ucAccount "github.com/braejan/go-transactions-summary/internal/domain/account/usecases"
+	summaryEntity "github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
+	ucTx "github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases"
+)
+
+// runSummary prints the summary of the stored transactions of a user.
+func runSummary(ctx context.Context, accountUseCases ucAccount.AccountUseCases, transactionUseCases ucTx.TransactionUseCases, args []string, out io.Writer) (err error) {
+	flags := newFlagSet("summary", "")
+	userID := flags.Int64("user", -1, "ID of the user")
+	asJSON := flags.Bool("json", false, "print the summary as JSON")
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+	if *userID < 0 || flags.NArg() != 0 {
+		flags.Usage()
+		err = errUsage
+		return
+	}
+	account, err := accountUseCases.GetByUserID(ctx, *userID)
+	if err != nil {
+		return
+	}
+	txs, err := transactionUseCases.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return
+	}
+	summary := summaryEntity.NewSummary(*userID, txs)
+	if *asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summary)
+	}
+	printSummary(summary, out)
+	return
+}
+
+// printSummary writes the summary as text.
+func printSummary(summary *summaryEntity.Summary, out io.Writer) {
+	fmt.Fprintf(out, "user %d\n", summary.UserID)
+	fmt.Fprintf(out, "  balance:        %.2f\n", summary.Balance)
+	fmt.Fprintf(out, "  total credits:  %.2f\n", summary.TotalCredits)
+	fmt.Fprintf(out, "  total debits:   %.2f\n", summary.TotalDebits)
+	fmt.Fprintf(out, "  average credit: %.2f\n", summary.AverageCredit)
+	fmt.Fprintf(out, "  average debit:  %.2f\n", summary.AverageDebit)
+	fmt.Fprintf(out, "  transactions:   %d\n", summary.Transactions)
+	for _, monthly := range summary.MonthlyCounts {
+		fmt.Fprintf(out, "    %-10s %d\n", monthly.Month, monthly.Count)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	ucFile "github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	fileUtil "github.com/braejan/go-transactions-summary/internal/domain/file/util"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+)
+
+// runValidate checks the structure of a file without touching the database.
+func runValidate(args []string, out io.Writer) (err error) {
+	flags := newFlagSet("validate", "<file.csv>")
+	asJSON := flags.Bool("json", false, "print the validation report as JSON")
+	filePath, err := fileArgument(flags, args)
+	if err != nil {
+		return
+	}
+	_, _, err = checkFile(ucFile.NewStructureUseCases(), filePath, *asJSON, out)
+	return
+}
+
+// checkFile opens the file, checks its structure and prints the validation report. The
+// returned file is rewound and must be closed by the caller. An invalid file returns
+// ErrFileHasInvalidLines.
+func checkFile(structureUseCases ucFile.StructureUseCases, filePath string, asJSON bool, out io.Writer) (txFile *fileEntity.TxFile, file *os.File, err error) {
+	file, err = os.Open(filePath)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			file = nil
+		}
+	}()
+	hash, err := fileUtil.HashFile(file)
+	if err != nil {
+		return
+	}
+	txFile = fileEntity.NewTxFile(filepath.Base(filePath), filePath, hash, 0)
+	report, err := structureUseCases.CheckStructure(*txFile, file)
+	if err != nil {
+		return
+	}
+	if err = printReport(report, asJSON, out); err != nil {
+		return
+	}
+	if !report.IsValid() {
+		err = voFile.ErrFileHasInvalidLines
+		return
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	return
+}
+
+// printReport writes the validation report as text or JSON.
+func printReport(report *fileEntity.ValidationReport, asJSON bool, out io.Writer) (err error) {
+	if asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	fmt.Fprintf(out, "%s: %d lines, %d invalid\n", report.Name, report.Lines, report.InvalidLines)
+	for _, problem := range report.Problems {
+		if problem.Column == "" {
+			fmt.Fprintf(out, "  line %d: %s\n", problem.Line, problem.Error)
+			continue
+		}
+		fmt.Fprintf(out, "  line %d, %s %q: %s\n", problem.Line, problem.Column, problem.Value, problem.Error)
+	}
+	if report.Truncated {
+		fmt.Fprintln(out, "  ...more problems were found")
+	}
+	return
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	fileUtil "github.com/braejan/go-transactions-summary/internal/domain/file/util"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/templates"
+	ucNotification "github.com/braejan/go-transactions-summary/internal/domain/notification/usecases"
+	summaryEntity "github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
+	txEntity "github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	userEntity "github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	"github.com/google/uuid"
+)
+
+// The preview command renders the summary email of a CSV file without touching the
+// database and writes the MIME message to disk, so templates can be checked in a mail client.
+func main() {
+	filePath := flag.String("file", "samples/file/csv/txns.csv", "CSV file with the transactions")
+	userID := flag.Int64("user", -1, "only summarize the records of this user ID, -1 summarizes the whole file")
+	userLocale := flag.String("locale", "es", "locale of the recipient")
+	templatesDir := flag.String("templates", "", "directory with templates overriding the embedded ones")
+	name := flag.String("name", "Juana María", "name of the recipient")
+	to := flag.String("to", "juana.maria@amazingemail.com", "email address of the recipient")
+	from := flag.String("from", "no-reply@amazingemail.com", "email address of the sender")
+	out := flag.String("out", "summary.eml", "path of the rendered MIME message")
+	flag.Parse()
+
+	txs, err := readTransactions(*filePath, *userID)
+	fatalAnyErr(err)
+	renderer, err := templates.NewRenderer(*templatesDir)
+	fatalAnyErr(err)
+	notificationUseCases, err := ucNotification.NewNotificationUseCases(renderer, *from)
+	fatalAnyErr(err)
+	user := userEntity.NewUser(*userID, *name, *to)
+	user.Locale = *userLocale
+	message, err := notificationUseCases.SummaryMessage(*user, summaryEntity.NewSummary(user.ID, txs))
+	fatalAnyErr(err)
+	raw, err := message.MIME(time.Now())
+	fatalAnyErr(err)
+	err = os.WriteFile(*out, raw, 0o644)
+	fatalAnyErr(err)
+	log.Printf("📨 summary of %d transactions written to %s", len(txs), *out)
+}
+
+// readTransactions reads the transactions of the CSV file, skipping its header.
+func readTransactions(filePath string, userID int64) (txs []txEntity.Transaction, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	if _, err = reader.Read(); err != nil {
+		return
+	}
+	for {
+		record, errRead := reader.Read()
+		if errRead == io.EOF {
+			break
+		}
+		if errRead != nil {
+			err = errRead
+			return
+		}
+		id, txDate, amount, errParse := fileUtil.ParseRecord(record)
+		if errParse != nil {
+			err = errParse
+			return
+		}
+		if userID >= 0 && id != userID {
+			continue
+		}
+		tx, errTx := txEntity.NewTransaction(uuid.Nil, amount, txDate, filePath)
+		if errTx != nil {
+			err = errTx
+			return
+		}
+		txs = append(txs, *tx)
+	}
+	return
+}
+
+func fatalAnyErr(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,366 @@
+// Package app builds the dependency graph shared by the REST API, the Lambdas and the
+// commands, so every process wires the same repositories and use cases from configuration.
+package app
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+
+	acRepository "github.com/braejan/go-transactions-summary/internal/domain/account/repository"
+	amRepo "github.com/braejan/go-transactions-summary/internal/domain/account/repository/memory"
+	amtRepo "github.com/braejan/go-transactions-summary/internal/domain/account/repository/metrics"
+	asqlRepo "github.com/braejan/go-transactions-summary/internal/domain/account/repository/sqldb"
+	atrRepo "github.com/braejan/go-transactions-summary/internal/domain/account/repository/tracing"
+	ucAccount "github.com/braejan/go-transactions-summary/internal/domain/account/usecases"
+	ucAccountMetrics "github.com/braejan/go-transactions-summary/internal/domain/account/usecases/metrics"
+	akRepository "github.com/braejan/go-transactions-summary/internal/domain/apikey/repository"
+	akmRepo "github.com/braejan/go-transactions-summary/internal/domain/apikey/repository/memory"
+	akmtRepo "github.com/braejan/go-transactions-summary/internal/domain/apikey/repository/metrics"
+	aksqlRepo "github.com/braejan/go-transactions-summary/internal/domain/apikey/repository/sqldb"
+	aktrRepo "github.com/braejan/go-transactions-summary/internal/domain/apikey/repository/tracing"
+	ucAPIKey "github.com/braejan/go-transactions-summary/internal/domain/apikey/usecases"
+	ucFile "github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	ucFileMetrics "github.com/braejan/go-transactions-summary/internal/domain/file/usecases/metrics"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/notifier"
+	ntRepository "github.com/braejan/go-transactions-summary/internal/domain/notification/repository"
+	nmRepo "github.com/braejan/go-transactions-summary/internal/domain/notification/repository/memory"
+	nmtRepo "github.com/braejan/go-transactions-summary/internal/domain/notification/repository/metrics"
+	nsqlRepo "github.com/braejan/go-transactions-summary/internal/domain/notification/repository/sqldb"
+	ntrRepo "github.com/braejan/go-transactions-summary/internal/domain/notification/repository/tracing"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/templates"
+	ucNotification "github.com/braejan/go-transactions-summary/internal/domain/notification/usecases"
+	pcRepository "github.com/braejan/go-transactions-summary/internal/domain/processing/repository"
+	pmRepo "github.com/braejan/go-transactions-summary/internal/domain/processing/repository/memory"
+	pmtRepo "github.com/braejan/go-transactions-summary/internal/domain/processing/repository/metrics"
+	psqlRepo "github.com/braejan/go-transactions-summary/internal/domain/processing/repository/sqldb"
+	ptrRepo "github.com/braejan/go-transactions-summary/internal/domain/processing/repository/tracing"
+	ucProcessing "github.com/braejan/go-transactions-summary/internal/domain/processing/usecases"
+	txRepository "github.com/braejan/go-transactions-summary/internal/domain/transaction/repository"
+	tmRepo "github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/memory"
+	tmtRepo "github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/metrics"
+	tsqlRepo "github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/sqldb"
+	ttrRepo "github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/tracing"
+	ucTx "github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases"
+	ucTxMetrics "github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases/metrics"
+	userRepository "github.com/braejan/go-transactions-summary/internal/domain/user/repository"
+	umRepo "github.com/braejan/go-transactions-summary/internal/domain/user/repository/memory"
+	umtRepo "github.com/braejan/go-transactions-summary/internal/domain/user/repository/metrics"
+	usqlRepo "github.com/braejan/go-transactions-summary/internal/domain/user/repository/sqldb"
+	utrRepo "github.com/braejan/go-transactions-summary/internal/domain/user/repository/tracing"
+	ucUser "github.com/braejan/go-transactions-summary/internal/domain/user/usecases"
+	ucUserMetrics "github.com/braejan/go-transactions-summary/internal/domain/user/usecases/metrics"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/sqlite"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+)
+
+const (
+	// PostgresRepositories stores the users, accounts, transactions, outbox messages and
+	// processing records in PostgreSQL.
+	PostgresRepositories = "postgres"
+	// MemoryRepositories keeps them in memory, for demos and tests without a database.
+	MemoryRepositories = "memory"
+	// SQLiteRepositories stores them in a SQLite file, for local development and small
+	// deployments without a database server.
+	SQLiteRepositories = "sqlite"
+)
+
+// Configuration struct defines the configuration of the application.
+type Configuration struct {
+	// Postgres is the configuration of the database.
+	Postgres *postgres.PostgresConfiguration
+	// SQLite is the configuration of the database file, only required by SQLiteRepositories.
+	SQLite *sqlite.SQLiteConfiguration
+	// Notification is the configuration of the summary emails.
+	Notification *voNotification.NotificationConfiguration
+	// Storage is the configuration of the object store keeping the uploaded files.
+	Storage *storage.StorageConfiguration
+	// Queue is the configuration of the queues referencing the files to ingest.
+	Queue *queue.QueueConfiguration
+	// Logger is the configuration of the log level and the sampling of the row lines.
+	Logger *logger.LoggerConfiguration
+	// Tracing is the configuration of the exporter of the spans.
+	Tracing *tracing.TracingConfiguration
+	// Auth is the configuration of the authentication of the REST API and the upload Lambda.
+	Auth *auth.AuthConfiguration
+	// Quota is the configuration of the rate limit and the upload quotas of the clients.
+	Quota *quota.QuotaConfiguration
+	// Repositories is the backend of the repositories, PostgresRepositories when empty.
+	Repositories string
+}
+
+// NewConfigurationFromEnv returns the configuration of the application from environment variables.
+func NewConfigurationFromEnv() (configuration *Configuration) {
+	configuration = &Configuration{
+		Postgres:     postgres.NewPostgresConfigurationFromEnv(),
+		SQLite:       sqlite.NewSQLiteConfigurationFromEnv(),
+		Notification: voNotification.NewNotificationConfigurationFromEnv(),
+		Storage:      storage.NewStorageConfigurationFromEnv(),
+		Queue:        queue.NewQueueConfigurationFromEnv(),
+		Logger:       logger.NewLoggerConfigurationFromEnv(),
+		Tracing:      tracing.NewTracingConfigurationFromEnv(),
+		Auth:         auth.NewAuthConfigurationFromEnv(),
+		Quota:        quota.NewQuotaConfigurationFromEnv(),
+		Repositories: os.Getenv("REPOSITORY_BACKEND"),
+	}
+	return
+}
+
+// validate returns an error when a part of the configuration is missing.
+func (configuration *Configuration) validate() (err error) {
+	switch {
+	case configuration == nil:
+		err = ErrNilConfiguration
+	case configuration.Postgres == nil:
+		err = ErrNilPostgresConfiguration
+	case configuration.Notification == nil:
+		err = ErrNilNotificationConfiguration
+	case configuration.Storage == nil:
+		err = ErrNilStorageConfiguration
+	case configuration.Queue == nil:
+		err = ErrNilQueueConfiguration
+	case configuration.Logger == nil:
+		err = ErrNilLoggerConfiguration
+	case configuration.Tracing == nil:
+		err = ErrNilTracingConfiguration
+	case configuration.Auth == nil:
+		err = ErrNilAuthConfiguration
+	case configuration.Quota == nil:
+		err = ErrNilQuotaConfiguration
+	case configuration.Repositories != "" && configuration.Repositories != PostgresRepositories &&
+		configuration.Repositories != MemoryRepositories && configuration.Repositories != SQLiteRepositories:
+		err = ErrUnknownRepositoryBackend
+	case configuration.Repositories == SQLiteRepositories && configuration.SQLite == nil:
+		err = ErrNilSQLiteConfiguration
+	}
+	return
+}
+
+// App struct holds the dependency graph of the application. It is built once per process
+// and shared between requests, so warm Lambda invocations reuse the database pool.
+type App struct {
+	// Configuration is the configuration the application was built from.
+	Configuration *Configuration
+	// Logger is the structured logger shared by the use cases and the handlers.
+	Logger logger.Logger
+	// Metrics holds the metrics of the decorated repositories and use cases.
+	Metrics *metrics.Registry
+	// Tracer starts the root spans of the requests and the ingested files.
+	Tracer tracing.Tracer
+	// Database is the connection pool shared by the repositories, nil with the memory
+	// repositories.
+	Database database.Pool
+	// ObjectStore keeps the uploaded files.
+	ObjectStore storage.ObjectStore
+	// IngestionQueue references the stored files to ingest.
+	IngestionQueue queue.Queue
+	// DeadLetterQueue keeps the messages whose files could not be ingested.
+	DeadLetterQueue queue.Queue
+
+	UserRepository        userRepository.UserRepository
+	AccountRepository     acRepository.AccountRepository
+	TransactionRepository txRepository.TransactionRepository
+	OutboxRepository      ntRepository.OutboxRepository
+	ProcessingRepository  pcRepository.ProcessingRepository
+	APIKeyRepository      akRepository.APIKeyRepository
+
+	UserUseCases         ucUser.UserUseCases
+	AccountUseCases      ucAccount.AccountUseCases
+	TransactionUseCases  ucTx.TransactionUseCases
+	NotificationUseCases ucNotification.NotificationUseCases
+	DispatcherUseCases   ucNotification.DispatcherUseCases
+	ProcessingUseCases   ucProcessing.ProcessingUseCases
+	FileUseCases         ucFile.FileUseCases
+	IngestionUseCases    ucProcessing.IngestionUseCases
+	ConsumerUseCases     ucProcessing.ConsumerUseCases
+	APIKeyUseCases       ucAPIKey.APIKeyUseCases
+
+	// draining is set once the process stops taking requests, failing the readiness.
+	draining atomic.Bool
+}
+
+// New builds the dependency graph from the configuration. No connection is opened until
+// a dependency is used, Health checks they are reachable.
+func New(configuration *Configuration) (app *App, err error) {
+	if err = configuration.validate(); err != nil {
+		return
+	}
+	newApp := &App{Configuration: configuration, Metrics: metrics.NewRegistry()}
+	// Create the logger before the dependencies logging with it
+	newApp.Logger, err = logger.NewLogger(configuration.Logger)
+	if err != nil {
+		return
+	}
+	newApp.Tracer, err = tracing.NewTracer(configuration.Tracing, newApp.Logger)
+	if err != nil {
+		return
+	}
+	// Create the repositories
+	if err = newApp.newRepositories(); err != nil {
+		_ = newApp.Close()
+		return
+	}
+	// Close the pool and the tracer when a later dependency cannot be built.
+	defer func() {
+		if err != nil {
+			_ = newApp.Close()
+		}
+	}()
+	newApp.instrumentRepositories()
+	// Create the object store keeping the uploaded files
+	newApp.ObjectStore, err = storage.NewObjectStore(configuration.Storage, storage.WithS3Logger(newApp.Logger))
+	if err != nil {
+		return
+	}
+	// Create the queues referencing the files to ingest
+	newApp.IngestionQueue, newApp.DeadLetterQueue, err = queue.NewQueues(configuration.Queue, queue.WithSQSLogger(newApp.Logger))
+	if err != nil {
+		return
+	}
+	// Create the user, account and transaction usecases
+	newApp.UserUseCases, err = ucUser.NewUserUseCases(newApp.UserRepository)
+	if err != nil {
+		return
+	}
+	newApp.UserUseCases = ucUserMetrics.NewMetricsUserUseCases(newApp.UserUseCases, newApp.Metrics)
+	newApp.AccountUseCases, err = ucAccount.NewAccountUseCases(newApp.AccountRepository, newApp.UserRepository)
+	if err != nil {
+		return
+	}
+	newApp.AccountUseCases = ucAccountMetrics.NewMetricsAccountUseCases(newApp.AccountUseCases, newApp.Metrics)
+	newApp.TransactionUseCases, err = ucTx.NewTransactionUseCases(newApp.TransactionRepository)
+	if err != nil {
+		return
+	}
+	newApp.TransactionUseCases = ucTxMetrics.NewMetricsTransactionUseCases(newApp.TransactionUseCases, newApp.Metrics)
+	// Create the notification usecase and the dispatcher delivering the outbox messages
+	renderer, err := templates.NewRenderer(configuration.Notification.TemplatesDir)
+	if err != nil {
+		return
+	}
+	newApp.NotificationUseCases, err = ucNotification.NewNotificationUseCases(renderer, configuration.Notification.Sender)
+	if err != nil {
+		return
+	}
+	messageNotifier, err := notifier.NewNotifier(configuration.Notification, notifier.WithSESLogger(newApp.Logger))
+	if err != nil {
+		return
+	}
+	newApp.DispatcherUseCases, err = ucNotification.NewDispatcherUseCases(newApp.OutboxRepository,
+		messageNotifier, configuration.Notification, ucNotification.WithDispatcherLogger(newApp.Logger))
+	if err != nil {
+		return
+	}
+	// Create the processing usecase recording the processed files
+	newApp.ProcessingUseCases, err = ucProcessing.NewProcessingUseCases(newApp.ProcessingRepository)
+	if err != nil {
+		return
+	}
+	// Create the file usecase
+	newApp.FileUseCases, err = ucFile.NewFileUseCases(newApp.UserUseCases, newApp.AccountUseCases, newApp.TransactionUseCases,
+		ucFile.WithNotifications(newApp.NotificationUseCases), ucFile.WithLogger(newApp.Logger),
+		ucFile.WithRowSampling(configuration.Logger.RowSampling), ucFile.WithQuotas(configuration.Quota, newApp.ProcessingRepository))
+	if err != nil {
+		return
+	}
+	newApp.FileUseCases = ucFileMetrics.NewMetricsFileUseCases(newApp.FileUseCases, newApp.Metrics)
+	// Create the ingestion usecase and the consumer of the ingestion queue
+	newApp.IngestionUseCases, err = ucProcessing.NewIngestionUseCases(newApp.ObjectStore, newApp.FileUseCases, newApp.ProcessingUseCases,
+		ucProcessing.WithIngestionLogger(newApp.Logger), ucProcessing.WithIngestionTracer(newApp.Tracer))
+	if err != nil {
+		return
+	}
+	newApp.ConsumerUseCases, err = ucProcessing.NewConsumerUseCases(newApp.IngestionUseCases, newApp.IngestionQueue,
+		newApp.DeadLetterQueue, configuration.Queue, ucProcessing.WithConsumerLogger(newApp.Logger))
+	if err != nil {
+		return
+	}
+	// Create the API key usecase authenticating the clients
+	newApp.APIKeyUseCases, err = ucAPIKey.NewAPIKeyUseCases(newApp.APIKeyRepository)
+	if err != nil {
+		return
+	}
+	app = newApp
+	return
+}
+
+// newRepositories creates the repositories of the configured backend. The memory
+// repositories share one in-memory database, so the outbox messages enqueued with the
+// transactions are claimed by the dispatcher.
+func (app *App) newRepositories() (err error) {
+	switch app.Configuration.Repositories {
+	case MemoryRepositories:
+		memoryDB := memory.NewDatabase()
+		app.UserRepository = umRepo.NewMemoryUserRepository(memoryDB)
+		app.AccountRepository = amRepo.NewMemoryAccountRepository(memoryDB)
+		app.TransactionRepository = tmRepo.NewMemoryTransactionRepository(memoryDB)
+		app.OutboxRepository = nmRepo.NewMemoryOutboxRepository(memoryDB)
+		app.ProcessingRepository = pmRepo.NewMemoryProcessingRepository(memoryDB)
+		app.APIKeyRepository = akmRepo.NewMemoryAPIKeyRepository(memoryDB)
+		return
+	case SQLiteRepositories:
+		app.Database, err = sqlite.NewSQLitePool(app.Configuration.SQLite)
+	default:
+		app.Database, err = postgres.NewPostgresPool(app.Configuration.Postgres)
+	}
+	if err != nil {
+		return
+	}
+	app.UserRepository = usqlRepo.NewSQLUserRepository(app.Database)
+	app.AccountRepository = asqlRepo.NewSQLAccountRepository(app.Database)
+	app.TransactionRepository = tsqlRepo.NewSQLTransactionRepository(app.Database)
+	app.OutboxRepository = nsqlRepo.NewSQLOutboxRepository(app.Database, nsqlRepo.WithLogger(app.Logger))
+	app.ProcessingRepository = psqlRepo.NewSQLProcessingRepository(app.Database, psqlRepo.WithLogger(app.Logger))
+	app.APIKeyRepository = aksqlRepo.NewSQLAPIKeyRepository(app.Database, aksqlRepo.WithLogger(app.Logger))
+	return
+}
+
+// instrumentRepositories decorates the repositories with their spans and their latency
+// metrics, and registers the statistics of the database pool.
+func (app *App) instrumentRepositories() {
+	app.UserRepository = utrRepo.NewTracingUserRepository(app.UserRepository)
+	app.AccountRepository = atrRepo.NewTracingAccountRepository(app.AccountRepository)
+	app.TransactionRepository = ttrRepo.NewTracingTransactionRepository(app.TransactionRepository)
+	app.OutboxRepository = ntrRepo.NewTracingOutboxRepository(app.OutboxRepository)
+	app.ProcessingRepository = ptrRepo.NewTracingProcessingRepository(app.ProcessingRepository)
+	app.APIKeyRepository = aktrRepo.NewTracingAPIKeyRepository(app.APIKeyRepository)
+	app.UserRepository = umtRepo.NewMetricsUserRepository(app.UserRepository, app.Metrics)
+	app.AccountRepository = amtRepo.NewMetricsAccountRepository(app.AccountRepository, app.Metrics)
+	app.TransactionRepository = tmtRepo.NewMetricsTransactionRepository(app.TransactionRepository, app.Metrics)
+	app.OutboxRepository = nmtRepo.NewMetricsOutboxRepository(app.OutboxRepository, app.Metrics)
+	app.ProcessingRepository = pmtRepo.NewMetricsProcessingRepository(app.ProcessingRepository, app.Metrics)
+	app.APIKeyRepository = akmtRepo.NewMetricsAPIKeyRepository(app.APIKeyRepository, app.Metrics)
+	if app.Database != nil {
+		metrics.RegisterPoolStats(app.Metrics, app.Database.Stats)
+	}
+}
+
+// tracerShutdownTimeout is the longest time Close waits for the pending spans to be exported.
+const tracerShutdownTimeout = 5 * time.Second
+
+// Close releases the resources of the application. The pending spans are exported before
+// the tracer is shut down.
+func (app *App) Close() (err error) {
+	if app.Database != nil {
+		err = app.Database.Shutdown()
+	}
+	if app.Tracer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), tracerShutdownTimeout)
+		defer cancel()
+		if errTracer := app.Tracer.Shutdown(ctx); errTracer != nil && err == nil {
+			err = errTracer
+		}
+	}
+	return
+}
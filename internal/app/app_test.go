@@ -0,0 +1,264 @@
+package app_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/app"
+	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/sqlite"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+	"github.com/stretchr/testify/assert"
+)
+
+// getTestConfiguration returns a configuration with a local object store and an
+// unreachable database.
+func getTestConfiguration(t *testing.T) *app.Configuration {
+	storageConfig := storage.NewDefaultStorageConfiguration()
+	storageConfig.LocalDir = t.TempDir()
+	return &app.Configuration{
+		Postgres:     postgres.NewPostgresConfiguration("127.0.0.1", 1, "postgres", "postgres", "db"),
+		Notification: voNotification.NewDefaultNotificationConfiguration(),
+		Storage:      storageConfig,
+		Queue:        queue.NewDefaultQueueConfiguration(),
+		Logger:       logger.NewDefaultLoggerConfiguration(),
+		Tracing:      tracing.NewDefaultTracingConfiguration(),
+		Auth:         auth.NewDefaultAuthConfiguration(),
+		Quota:        quota.NewDefaultQuotaConfiguration(),
+	}
+}
+
+// TestNewWithMissingConfiguration tests the New function with a missing configuration.
+func TestNewWithMissingConfiguration(t *testing.T) {
+	cases := map[string]struct {
+		configure func(configuration *app.Configuration) *app.Configuration
+		err       error
+	}{
+		"nil configuration": {func(*app.Configuration) *app.Configuration { return nil }, app.ErrNilConfiguration},
+		"nil postgres": {func(configuration *app.Configuration) *app.Configuration {
+			configuration.Postgres = nil
+			return configuration
+		}, app.ErrNilPostgresConfiguration},
+		"nil notification": {func(configuration *app.Configuration) *app.Configuration {
+			configuration.Notification = nil
+			return configuration
+		}, app.ErrNilNotificationConfiguration},
+		"nil storage": {func(configuration *app.Configuration) *app.Configuration {
+			configuration.Storage = nil
+			return configuration
+		}, app.ErrNilStorageConfiguration},
+		"nil queue": {func(configuration *app.Configuration) *app.Configuration {
+			configuration.Queue = nil
+			return configuration
+		}, app.ErrNilQueueConfiguration},
+		"nil logger": {func(configuration *app.Configuration) *app.Configuration {
+			configuration.Logger = nil
+			return configuration
+		}, app.ErrNilLoggerConfiguration},
+		"nil tracing": {func(configuration *app.Configuration) *app.Configuration {
+			configuration.Tracing = nil
+			return configuration
+		}, app.ErrNilTracingConfiguration},
+		"nil auth": {func(configuration *app.Configuration) *app.Configuration {
+			configuration.Auth = nil
+			return configuration
+		}, app.ErrNilAuthConfiguration},
+		"nil quota": {func(configuration *app.Configuration) *app.Configuration {
+			configuration.Quota = nil
+			return configuration
+		}, app.ErrNilQuotaConfiguration},
+		"unknown span exporter": {func(configuration *app.Configuration) *app.Configuration {
+			configuration.Tracing.Exporter = "zipkin"
+			return configuration
+		}, tracing.ErrUnknownExporter},
+		"unknown log level": {func(configuration *app.Configuration) *app.Configuration {
+			configuration.Logger.Level = "verbose"
+			return configuration
+		}, logger.ErrUnknownLevel},
+		"nil sqlite": {func(configuration *app.Configuration) *app.Configuration {
+			configuration.Repositories = app.SQLiteRepositories
+			return configuration
+		}, app.ErrNilSQLiteConfiguration},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// When New is called with a missing configuration
+			application, err := app.New(tc.configure(getTestConfiguration(t)))
+			// Then the error of the missing part is returned
+			assert.Nil(t, application)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+// TestNewWithInvalidDependency tests the New function when a dependency cannot be built.
+func TestNewWithInvalidDependency(t *testing.T) {
+	// Given a configuration with an unknown notifier
+	configuration := getTestConfiguration(t)
+	configuration.Notification.Notifier = "pigeon"
+	// When New is called
+	application, err := app.New(configuration)
+	// Then the error returned is ErrUnknownNotifier
+	assert.Nil(t, application)
+	assert.Equal(t, voNotification.ErrUnknownNotifier, err)
+}
+
+// TestNewBuildsGraph tests every dependency is built and the pool is shared.
+func TestNewBuildsGraph(t *testing.T) {
+	// When New is called with a valid configuration
+	application, err := app.New(getTestConfiguration(t))
+	// Then every dependency is built
+	assert.Nil(t, err)
+	defer application.Close()
+	assert.NotNil(t, application.ObjectStore)
+	assert.NotNil(t, application.UserUseCases)
+	assert.NotNil(t, application.AccountUseCases)
+	assert.NotNil(t, application.TransactionUseCases)
+	assert.NotNil(t, application.DispatcherUseCases)
+	assert.NotNil(t, application.ProcessingUseCases)
+	assert.NotNil(t, application.FileUseCases)
+	assert.NotNil(t, application.IngestionUseCases)
+	assert.NotNil(t, application.ConsumerUseCases)
+	// And the database pool is reused
+	first, _ := application.Database.Open()
+	second, _ := application.Database.Open()
+	assert.Same(t, first, second)
+}
+
+// TestHealthWithUnreachableDatabase tests the Health method reports every dependency.
+func TestHealthWithUnreachableDatabase(t *testing.T) {
+	// Given an application with an unreachable database
+	application, err := app.New(getTestConfiguration(t))
+	assert.Nil(t, err)
+	defer application.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	// When checking its health
+	statuses, healthy := application.Health(ctx)
+	// Then the application is not healthy
+	assert.False(t, healthy)
+	// And only the database and its migrations are not healthy
+	assert.Len(t, statuses, 3)
+	assert.Equal(t, "postgres", statuses[0].Name)
+	assert.False(t, statuses[0].Healthy)
+	assert.NotEmpty(t, statuses[0].Error)
+	assert.Equal(t, "migrations", statuses[1].Name)
+	assert.False(t, statuses[1].Healthy)
+	assert.Equal(t, app.DependencyStatus{Name: "object_store", Healthy: true}, statuses[2])
+}
+
+// TestNewWithUnknownRepositoryBackend tests the error returned for an unknown repository backend.
+func TestNewWithUnknownRepositoryBackend(t *testing.T) {
+	// Given a configuration with an unknown repository backend
+	configuration := getTestConfiguration(t)
+	configuration.Repositories = "cassandra"
+	// When New is called
+	application, err := app.New(configuration)
+	// Then the error returned is ErrUnknownRepositoryBackend
+	assert.Nil(t, application)
+	assert.Equal(t, app.ErrUnknownRepositoryBackend, err)
+}
+
+// TestNewWithMemoryRepositories tests a file is ingested without a database.
+func TestNewWithMemoryRepositories(t *testing.T) {
+	// Given an application with the memory repositories, exporting its spans to a file
+	configuration := getTestConfiguration(t)
+	configuration.Repositories = app.MemoryRepositories
+	spansPath := filepath.Join(t.TempDir(), "spans.jsonl")
+	configuration.Tracing = &tracing.TracingConfiguration{Exporter: tracing.FileExporter, File: spansPath}
+	application, err := app.New(configuration)
+	assert.Nil(t, err)
+	defer application.Close()
+	// And no database pool
+	assert.Nil(t, application.Database)
+	// When processing a file
+	path := filepath.Join(t.TempDir(), "txns.csv")
+	assert.Nil(t, os.WriteFile(path, []byte("Id,Date,Transaction\n0,7/5,+60.5\n0,7/28,-10.3\n1,7/2,+20\n"), 0o600))
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+	ctx, span := application.Tracer.Start(context.Background(), "test")
+	transactions, err := application.FileUseCases.ProcessFile(ctx, *fileEntity.NewTxFile("txns.csv", path, "hash", 0), file)
+	span.End()
+	// Then the transactions of the users are stored
+	assert.Nil(t, err)
+	assert.Equal(t, 3, transactions)
+	account, err := application.AccountUseCases.GetByUserID(context.Background(), 0)
+	assert.Nil(t, err)
+	txs, err := application.TransactionUseCases.GetByAccountID(context.Background(), account.ID)
+	assert.Nil(t, err)
+	assert.Len(t, txs, 2)
+	// And the summary emails are enqueued
+	claimed, err := application.OutboxRepository.Claim(context.Background(), time.Now(), time.Minute, 10)
+	assert.Nil(t, err)
+	assert.Len(t, claimed, 2)
+	// And the file, its rows and the created users and accounts are counted
+	out := &bytes.Buffer{}
+	assert.Nil(t, application.Metrics.Write(out))
+	assert.Contains(t, out.String(), `files_processed_total{result="succeeded"} 1`)
+	assert.Contains(t, out.String(), "rows_ingested_total 3")
+	assert.Contains(t, out.String(), "users_created_total 2")
+	assert.Contains(t, out.String(), "accounts_created_total 2")
+	assert.Contains(t, out.String(), `repository_query_duration_seconds_count{repository="transaction",method="CreateBatch"} 1`)
+	// And the processing, the parse and the repository calls are traced
+	spans, err := os.ReadFile(spansPath)
+	assert.Nil(t, err)
+	assert.Contains(t, string(spans), `"name":"FileUseCases.ProcessFile"`)
+	assert.Contains(t, string(spans), `"name":"file.parse"`)
+	assert.Contains(t, string(spans), `"name":"TransactionRepository.CreateBatch"`)
+	// And only the object store is checked
+	statuses, healthy := application.Health(context.Background())
+	assert.True(t, healthy)
+	assert.Equal(t, []app.DependencyStatus{{Name: "object_store", Healthy: true}}, statuses)
+}
+
+// TestNewWithSQLiteRepositories tests a file is ingested into a SQLite database.
+func TestNewWithSQLiteRepositories(t *testing.T) {
+	// Given an application with the SQLite repositories
+	configuration := getTestConfiguration(t)
+	configuration.Repositories = app.SQLiteRepositories
+	configuration.SQLite = sqlite.NewSQLiteConfiguration(filepath.Join(t.TempDir(), "transactions.db"))
+	application, err := app.New(configuration)
+	assert.Nil(t, err)
+	defer application.Close()
+	// And the SQLite schema
+	migrator, err := migrations.NewMigrator(application.Database)
+	assert.Nil(t, err)
+	_, err = migrator.Up()
+	assert.Nil(t, err)
+	// When processing a file
+	path := filepath.Join(t.TempDir(), "txns.csv")
+	assert.Nil(t, os.WriteFile(path, []byte("Id,Date,Transaction\n0,7/5,+60.5\n0,7/28,-10.3\n1,7/2,+20\n"), 0o600))
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+	_, err = application.FileUseCases.ProcessFile(context.Background(), *fileEntity.NewTxFile("txns.csv", path, "hash", 0), file)
+	// Then the transactions of the users are stored
+	assert.Nil(t, err)
+	account, err := application.AccountUseCases.GetByUserID(context.Background(), 0)
+	assert.Nil(t, err)
+	txs, err := application.TransactionUseCases.GetByAccountID(context.Background(), account.ID)
+	assert.Nil(t, err)
+	assert.Len(t, txs, 2)
+	// And the summary emails are enqueued
+	claimed, err := application.OutboxRepository.Claim(context.Background(), time.Now(), time.Minute, 10)
+	assert.Nil(t, err)
+	assert.Len(t, claimed, 2)
+	// And the database and its migrations are checked
+	statuses, healthy := application.Health(context.Background())
+	assert.True(t, healthy)
+	assert.Equal(t, app.DependencyStatus{Name: "sqlite", Healthy: true}, statuses[0])
+	assert.Equal(t, app.DependencyStatus{Name: "migrations", Healthy: true}, statuses[1])
+}
@@ -0,0 +1,31 @@
+package app
+
+import "errors"
+
+var (
+	// ErrNilConfiguration is the error returned when the application configuration is nil.
+	ErrNilConfiguration = errors.New("application configuration is nil")
+	// ErrNilPostgresConfiguration is the error returned when the postgres configuration is nil.
+	ErrNilPostgresConfiguration = errors.New("postgres configuration is nil")
+	// ErrNilSQLiteConfiguration is the error returned when the SQLite repositories have no SQLite configuration.
+	ErrNilSQLiteConfiguration = errors.New("sqlite configuration is nil")
+	// ErrNilNotificationConfiguration is the error returned when the notification configuration is nil.
+	ErrNilNotificationConfiguration = errors.New("notification configuration is nil")
+	// ErrNilStorageConfiguration is the error returned when the storage configuration is nil.
+	ErrNilStorageConfiguration = errors.New("storage configuration is nil")
+	// ErrNilQueueConfiguration is the error returned when the queue configuration is nil.
+	ErrNilQueueConfiguration = errors.New("queue configuration is nil")
+	// ErrNilLoggerConfiguration is the error returned when the logger configuration is nil.
+	ErrNilLoggerConfiguration = errors.New("logger configuration is nil")
+	// ErrNilTracingConfiguration is the error returned when the tracing configuration is nil.
+	ErrNilTracingConfiguration = errors.New("tracing configuration is nil")
+	// ErrNilAuthConfiguration is the error returned when the auth configuration is nil.
+	ErrNilAuthConfiguration = errors.New("auth configuration is nil")
+	// ErrNilQuotaConfiguration is the error returned when the quota configuration is nil.
+	ErrNilQuotaConfiguration = errors.New("quota configuration is nil")
+	// ErrUnknownRepositoryBackend is the error returned when the configured repository backend does not exist.
+	ErrUnknownRepositoryBackend = errors.New("unknown repository backend")
+	// ErrPendingMigrations is the error reported when the database schema is older than
+	// the migrations embedded in the binary.
+	ErrPendingMigrations = errors.New("database has pending migrations")
+)
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+)
+
+const (
+	// StatusOK is the status of a live process.
+	StatusOK = "ok"
+	// StatusReady is the status of a process whose dependencies are healthy.
+	StatusReady = "ready"
+	// StatusNotReady is the status of a process with a dependency not healthy.
+	StatusNotReady = "not_ready"
+	// StatusDraining is the status of a process finishing its in-flight requests.
+	StatusDraining = "draining"
+)
+
+// readinessTimeout is the longest time the readiness handler waits for the dependencies.
+const readinessTimeout = 5 * time.Second
+
+// DependencyStatus struct defines the status of a dependency of the application.
+type DependencyStatus struct {
+	// Name identifies the dependency.
+	Name string `json:"name"`
+	// Healthy is true when the dependency is reachable.
+	Healthy bool `json:"healthy"`
+	// Error is the reason the dependency is not healthy.
+	Error string `json:"error,omitempty"`
+}
+
+// HealthResponse struct defines the body of the liveness and readiness responses.
+type HealthResponse struct {
+	// Status is StatusOK, StatusReady, StatusNotReady or StatusDraining.
+	Status string `json:"status"`
+	// Dependencies are the status of every checked dependency, empty for the liveness and
+	// while draining.
+	Dependencies []DependencyStatus `json:"dependencies,omitempty"`
+}
+
+// healthCheckKey is the key looked up to check the object store, it does not need to exist.
+const healthCheckKey = ".healthcheck"
+
+// Health checks the dependencies of the application and returns their status. With a
+// database, its schema must have every embedded migration applied.
+func (app *App) Health(ctx context.Context) (statuses []DependencyStatus, healthy bool) {
+	if app.Database != nil {
+		statuses = append(statuses, newDependencyStatus(string(app.Database.Dialect()), app.Database.Ping(ctx)))
+		statuses = append(statuses, newDependencyStatus("migrations", app.checkMigrations(ctx)))
+	}
+	if app.ObjectStore != nil {
+		statuses = append(statuses, newDependencyStatus("object_store", app.checkObjectStore(ctx)))
+	}
+	healthy = true
+	for _, status := range statuses {
+		if !status.Healthy {
+			app.Logger.Warn("dependency is not healthy", "dependency", status.Name, "error", status.Error)
+			healthy = false
+		}
+	}
+	return
+}
+
+// Drain fails the readiness from now on, so the load balancer stops sending requests
+// before the server shuts down.
+func (app *App) Drain() {
+	app.draining.Store(true)
+}
+
+// Draining returns whether Drain was called.
+func (app *App) Draining() bool {
+	return app.draining.Load()
+}
+
+// LivenessHandler returns the handler answering while the process is running, without
+// checking the dependencies, so an unreachable database does not restart the process.
+func (app *App) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		app.writeHealth(writer, http.StatusOK, HealthResponse{Status: StatusOK})
+	})
+}
+
+// ReadinessHandler returns the handler answering 200 when every dependency is healthy,
+// and 503 when one is not or the process is draining.
+func (app *App) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if app.Draining() {
+			app.writeHealth(writer, http.StatusServiceUnavailable, HealthResponse{Status: StatusDraining})
+			return
+		}
+		ctx, cancel := context.WithTimeout(request.Context(), readinessTimeout)
+		defer cancel()
+		statuses, healthy := app.Health(ctx)
+		if !healthy {
+			app.writeHealth(writer, http.StatusServiceUnavailable, HealthResponse{Status: StatusNotReady, Dependencies: statuses})
+			return
+		}
+		app.writeHealth(writer, http.StatusOK, HealthResponse{Status: StatusReady, Dependencies: statuses})
+	})
+}
+
+// writeHealth writes the response as JSON with the status code.
+func (app *App) writeHealth(writer http.ResponseWriter, status int, response HealthResponse) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	if err := json.NewEncoder(writer).Encode(response); err != nil {
+		app.Logger.Error("error writing the health response", "error", err)
+	}
+}
+
+// checkMigrations returns ErrPendingMigrations when an embedded migration is not applied.
+// The migrations applied by a newer version are accepted, so the instances of the
+// previous version stay ready during a rolling deployment. The check only reads the
+// applied versions, it never writes to the database.
+func (app *App) checkMigrations(ctx context.Context) (err error) {
+	pending, err := migrations.Pending(ctx, app.Database)
+	if err == nil && len(pending) > 0 {
+		err = ErrPendingMigrations
+	}
+	return
+}
+
+// checkObjectStore looks up a key, a missing key means the store is reachable.
+func (app *App) checkObjectStore(ctx context.Context) (err error) {
+	_, err = app.ObjectStore.HeadWithContext(ctx, healthCheckKey)
+	if err == storage.ErrObjectNotFound {
+		err = nil
+	}
+	return
+}
+
+func newDependencyStatus(name string, err error) (status DependencyStatus) {
+	status = DependencyStatus{Name: name, Healthy: err == nil}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	return
+}
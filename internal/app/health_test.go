@@ -0,0 +1,158 @@
+package app_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/app"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/sqlite"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// serveHealth returns the status code and the decoded body of the response of the handler.
+func serveHealth(t *testing.T, handler http.Handler) (status int, response app.HealthResponse) {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	status = recorder.Code
+	return
+}
+
+// TestHealthWithPendingMigrations tests the migrations are not healthy while one is not
+// applied.
+func TestHealthWithPendingMigrations(t *testing.T) {
+	// Given an application with a SQLite database without its last migration
+	configuration := getTestConfiguration(t)
+	configuration.Repositories = app.SQLiteRepositories
+	configuration.SQLite = sqlite.NewSQLiteConfiguration(filepath.Join(t.TempDir(), "transactions.db"))
+	application, err := app.New(configuration)
+	assert.Nil(t, err)
+	defer application.Close()
+	migrator, err := migrations.NewMigrator(application.Database)
+	assert.Nil(t, err)
+	_, err = migrator.Up()
+	assert.Nil(t, err)
+	_, err = migrator.Down(1)
+	assert.Nil(t, err)
+	// When checking its health
+	statuses, healthy := application.Health(context.Background())
+	// Then the database is reachable but its migrations are pending
+	assert.False(t, healthy)
+	assert.Equal(t, []app.DependencyStatus{
+		{Name: "sqlite", Healthy: true},
+		{Name: "migrations", Healthy: false, Error: app.ErrPendingMigrations.Error()},
+		{Name: "object_store", Healthy: true},
+	}, statuses)
+}
+
+// TestHealthWithoutSchema tests the migrations are not healthy without schema, and the
+// check does not create the schema_migrations table.
+func TestHealthWithoutSchema(t *testing.T) {
+	// Given an application with a SQLite database without schema
+	configuration := getTestConfiguration(t)
+	configuration.Repositories = app.SQLiteRepositories
+	configuration.SQLite = sqlite.NewSQLiteConfiguration(filepath.Join(t.TempDir(), "transactions.db"))
+	application, err := app.New(configuration)
+	assert.Nil(t, err)
+	defer application.Close()
+	// When checking its health
+	statuses, healthy := application.Health(context.Background())
+	// Then the applied migrations cannot be read
+	assert.False(t, healthy)
+	assert.Equal(t, "migrations", statuses[1].Name)
+	assert.False(t, statuses[1].Healthy)
+	assert.Contains(t, statuses[1].Error, migrations.ErrReadingMigrations.Error())
+	// And the database is left untouched
+	db, err := application.Database.Open()
+	assert.Nil(t, err)
+	var tables int
+	assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table'").Scan(&tables))
+	assert.Zero(t, tables)
+}
+
+// hangingObjectStore is an object store whose lookups last until their context is done.
+type hangingObjectStore struct {
+	storage.ObjectStore
+}
+
+func (store *hangingObjectStore) HeadWithContext(ctx context.Context, key string) (object storage.ObjectInfo, err error) {
+	<-ctx.Done()
+	err = ctx.Err()
+	return
+}
+
+// TestHealthWithHangingObjectStore tests the check of the object store gives up when the
+// context is done.
+func TestHealthWithHangingObjectStore(t *testing.T) {
+	// Given an application whose object store does not answer
+	configuration := getTestConfiguration(t)
+	configuration.Repositories = app.MemoryRepositories
+	application, err := app.New(configuration)
+	assert.Nil(t, err)
+	defer application.Close()
+	application.ObjectStore = &hangingObjectStore{application.ObjectStore}
+	// When checking its health with a deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	statuses, healthy := application.Health(ctx)
+	// Then the object store is not healthy
+	assert.False(t, healthy)
+	assert.Equal(t, []app.DependencyStatus{
+		{Name: "object_store", Healthy: false, Error: context.DeadlineExceeded.Error()},
+	}, statuses)
+}
+
+// TestHealthHandlers tests the liveness and readiness responses, before and while draining.
+func TestHealthHandlers(t *testing.T) {
+	// Given an application with the memory repositories
+	configuration := getTestConfiguration(t)
+	configuration.Repositories = app.MemoryRepositories
+	application, err := app.New(configuration)
+	assert.Nil(t, err)
+	defer application.Close()
+	// When requesting the liveness
+	status, response := serveHealth(t, application.LivenessHandler())
+	// Then the process is live
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, app.HealthResponse{Status: app.StatusOK}, response)
+	// When requesting the readiness
+	status, response = serveHealth(t, application.ReadinessHandler())
+	// Then the process is ready with the status of its dependencies
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, app.HealthResponse{
+		Status:       app.StatusReady,
+		Dependencies: []app.DependencyStatus{{Name: "object_store", Healthy: true}},
+	}, response)
+	// When draining
+	application.Drain()
+	// Then the process is not ready but still live
+	status, response = serveHealth(t, application.ReadinessHandler())
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, app.HealthResponse{Status: app.StatusDraining}, response)
+	status, _ = serveHealth(t, application.LivenessHandler())
+	assert.Equal(t, http.StatusOK, status)
+}
+
+// TestReadinessHandlerWithUnreachableDatabase tests the readiness fails with the
+// unhealthy dependencies.
+func TestReadinessHandlerWithUnreachableDatabase(t *testing.T) {
+	// Given an application with an unreachable database
+	application, err := app.New(getTestConfiguration(t))
+	assert.Nil(t, err)
+	defer application.Close()
+	// When requesting the readiness
+	status, response := serveHealth(t, application.ReadinessHandler())
+	// Then the process is not ready
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, app.StatusNotReady, response.Status)
+	assert.Len(t, response.Dependencies, 3)
+	assert.False(t, response.Dependencies[0].Healthy)
+}
@@ -6,10 +6,10 @@ import (
 
 // Account struct defines the account entity.
 type Account struct {
-	ID      uuid.UUID
-	Balance float64
-	UserID  int64
-	Active  bool
+	ID      uuid.UUID `json:"id"`
+	Balance float64   `json:"balance"`
+	UserID  int64     `json:"user_id"`
+	Active  bool      `json:"active"`
 }
 
 // NewAccount returns a new Account instance.
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	"github.com/google/uuid"
+)
+
+// accountsTable is the table of the accounts.
+const accountsTable = "accounts"
+
+// memoryAccountRepository struct implements the AccountRepository interface using an
+// in-memory database. Like the accounts table, a user has one account at most.
+type memoryAccountRepository struct {
+	database *memory.Database
+}
+
+// NewMemoryAccountRepository creates a new instance of memoryAccountRepository.
+func NewMemoryAccountRepository(database *memory.Database) (accountRepo repository.AccountRepository) {
+	accountRepo = &memoryAccountRepository{
+		database: database,
+	}
+	return
+}
+
+// GetByID returns an account by its ID.
+func (memoryRepo *memoryAccountRepository) GetByID(ctx context.Context, ID uuid.UUID) (acc *entity.Account, err error) {
+	err = memoryRepo.database.Read(func(tx *memory.Tx) error {
+		row, ok := tx.Get(accountsTable, ID.String())
+		if !ok {
+			return account.ErrAccountNotFound
+		}
+		stored := row.(entity.Account)
+		acc = &stored
+		return nil
+	})
+	return
+}
+
+// GetByUserID returns an account by its user ID.
+func (memoryRepo *memoryAccountRepository) GetByUserID(ctx context.Context, userID int64) (acc *entity.Account, err error) {
+	err = memoryRepo.database.Read(func(tx *memory.Tx) error {
+		acc = findByUserID(tx, userID)
+		if acc == nil {
+			return account.ErrAccountNotFound
+		}
+		return nil
+	})
+	return
+}
+
+// Create creates a new account. Creating an account with the ID of another one or for a
+// user that already has one fails.
+func (memoryRepo *memoryAccountRepository) Create(ctx context.Context, acc *entity.Account) (err error) {
+	if acc == nil {
+		err = account.ErrNilAccount
+		return
+	}
+	err = memoryRepo.database.Write(func(tx *memory.Tx) error {
+		if _, ok := tx.Get(accountsTable, acc.ID.String()); ok {
+			return database.NewConflictError(account.ErrCreatingAccount, database.ErrUniqueViolation, "accounts_pkey")
+		}
+		if findByUserID(tx, acc.UserID) != nil {
+			return database.NewConflictError(account.ErrCreatingAccount, database.ErrUniqueViolation, "accounts_userid_key")
+		}
+		return tx.Put(accountsTable, acc.ID.String(), *acc)
+	})
+	return
+}
+
+// Update updates the balance and the status of an account. Updating an account that does
+// not exist does nothing.
+func (memoryRepo *memoryAccountRepository) Update(ctx context.Context, acc *entity.Account) (err error) {
+	if acc == nil {
+		err = account.ErrNilAccount
+		return
+	}
+	err = memoryRepo.database.Write(func(tx *memory.Tx) error {
+		row, ok := tx.Get(accountsTable, acc.ID.String())
+		if !ok {
+			return nil
+		}
+		stored := row.(entity.Account)
+		stored.Balance = acc.Balance
+		stored.Active = acc.Active
+		return tx.Put(accountsTable, acc.ID.String(), stored)
+	})
+	return
+}
+
+// findByUserID returns a copy of the account of the user, nil when there is none.
+func findByUserID(tx *memory.Tx, userID int64) (acc *entity.Account) {
+	tx.Scan(accountsTable, func(row interface{}) bool {
+		stored := row.(entity.Account)
+		if stored.UserID != userID {
+			return true
+		}
+		acc = &stored
+		return false
+	})
+	return
+}
@@ -0,0 +1,85 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository/memory"
+	voAccount "github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	voMemory "github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCreateAndGetAccount tests a created account is returned by its ID and its user ID.
+func TestCreateAndGetAccount(t *testing.T) {
+	// Given an empty account repository
+	accountRepo := memory.NewMemoryAccountRepository(voMemory.NewDatabase())
+	acc := entity.NewAccount(1)
+	// When creating an account
+	err := accountRepo.Create(context.Background(), acc)
+	// Then it is returned by its ID
+	assert.Nil(t, err)
+	byID, err := accountRepo.GetByID(context.Background(), acc.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, acc, byID)
+	// And by its user ID
+	byUserID, err := accountRepo.GetByUserID(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, acc, byUserID)
+}
+
+// TestGetAccountNotFound tests the error returned when the account does not exist.
+func TestGetAccountNotFound(t *testing.T) {
+	// Given an empty account repository
+	accountRepo := memory.NewMemoryAccountRepository(voMemory.NewDatabase())
+	// When getting an account by ID and by user ID
+	byID, errID := accountRepo.GetByID(context.Background(), uuid.New())
+	byUserID, errUserID := accountRepo.GetByUserID(context.Background(), 1)
+	// Then the errors returned are ErrAccountNotFound
+	assert.Nil(t, byID)
+	assert.Equal(t, voAccount.ErrAccountNotFound, errID)
+	assert.Nil(t, byUserID)
+	assert.Equal(t, voAccount.ErrAccountNotFound, errUserID)
+}
+
+// TestCreateAccountWithNilAccount tests the error returned when the account is nil.
+func TestCreateAccountWithNilAccount(t *testing.T) {
+	// Given an empty account repository
+	accountRepo := memory.NewMemoryAccountRepository(voMemory.NewDatabase())
+	// When creating a nil account
+	err := accountRepo.Create(context.Background(), nil)
+	// Then the error returned is ErrNilAccount
+	assert.Equal(t, voAccount.ErrNilAccount, err)
+}
+
+// TestCreateAccountUniqueUser tests a user cannot have two accounts.
+func TestCreateAccountUniqueUser(t *testing.T) {
+	// Given an account repository with an account of the user
+	accountRepo := memory.NewMemoryAccountRepository(voMemory.NewDatabase())
+	assert.Nil(t, accountRepo.Create(context.Background(), entity.NewAccount(1)))
+	// When creating another account of the user
+	err := accountRepo.Create(context.Background(), entity.NewAccount(1))
+	// Then the error returned is a unique violation creating the account
+	assert.ErrorIs(t, err, voAccount.ErrCreatingAccount)
+	assert.ErrorIs(t, err, database.ErrUniqueViolation)
+}
+
+// TestUpdateAccount tests the balance and the status of the account are updated.
+func TestUpdateAccount(t *testing.T) {
+	// Given an account repository with an account
+	accountRepo := memory.NewMemoryAccountRepository(voMemory.NewDatabase())
+	acc := entity.NewAccount(1)
+	assert.Nil(t, accountRepo.Create(context.Background(), acc))
+	// When updating its balance, its status and its user
+	err := accountRepo.Update(context.Background(), &entity.Account{ID: acc.ID, Balance: 50.2, UserID: 2, Active: true})
+	// Then the balance and the status are updated
+	assert.Nil(t, err)
+	updated, _ := accountRepo.GetByID(context.Background(), acc.ID)
+	assert.Equal(t, 50.2, updated.Balance)
+	assert.True(t, updated.Active)
+	// And the user is not
+	assert.Equal(t, int64(1), updated.UserID)
+}
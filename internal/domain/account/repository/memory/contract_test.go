@@ -0,0 +1,18 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository/memory"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository/repositorytest"
+	voMemory "github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+)
+
+// TestAccountRepositoryContract tests the memory repository against the AccountRepository contract.
+func TestAccountRepositoryContract(t *testing.T) {
+	repositorytest.RunAccountRepositoryContract(t, func(t *testing.T) (repository.AccountRepository, func(int64)) {
+		// The memory accounts do not reference the users.
+		return memory.NewMemoryAccountRepository(voMemory.NewDatabase()), func(int64) {}
+	})
+}
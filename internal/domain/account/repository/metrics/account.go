@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository"
+	voMetrics "github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+	"github.com/google/uuid"
+)
+
+// metricsAccountRepository struct implements the AccountRepository interface observing the latency of every
+// method of the decorated repository.
+type metricsAccountRepository struct {
+	accountRepo repository.AccountRepository
+	latency     *voMetrics.Histogram
+}
+
+// NewMetricsAccountRepository returns the repository decorated with the latency metrics of the registry.
+func NewMetricsAccountRepository(accountRepo repository.AccountRepository, registry *voMetrics.Registry) (decorated repository.AccountRepository) {
+	decorated = &metricsAccountRepository{
+		accountRepo: accountRepo,
+		latency:     voMetrics.RepositoryLatency(registry),
+	}
+	return
+}
+
+// AccountRepository interface implementation
+
+func (metricsRepo *metricsAccountRepository) GetByID(ctx context.Context, ID uuid.UUID) (account *entity.Account, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "account", "GetByID")
+	return metricsRepo.accountRepo.GetByID(ctx, ID)
+}
+
+func (metricsRepo *metricsAccountRepository) GetByUserID(ctx context.Context, userID int64) (account *entity.Account, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "account", "GetByUserID")
+	return metricsRepo.accountRepo.GetByUserID(ctx, userID)
+}
+
+func (metricsRepo *metricsAccountRepository) Create(ctx context.Context, account *entity.Account) (err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "account", "Create")
+	return metricsRepo.accountRepo.Create(ctx, account)
+}
+
+func (metricsRepo *metricsAccountRepository) Update(ctx context.Context, account *entity.Account) (err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "account", "Update")
+	return metricsRepo.accountRepo.Update(ctx, account)
+}
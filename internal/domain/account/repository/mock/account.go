@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"context"
+
 	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -16,13 +18,13 @@ func NewMockAccountRepository() *mockAccountRepository {
 	return &mockAccountRepository{}
 }
 
-// GetByID provides a mock function with given fields: ID uuid.UUID
-func (_m *mockAccountRepository) GetByID(ID uuid.UUID) (acc *entity.Account, err error) {
-	ret := _m.Called(ID)
+// GetByID provides a mock function with given fields: ctx, ID uuid.UUID
+func (_m *mockAccountRepository) GetByID(ctx context.Context, ID uuid.UUID) (acc *entity.Account, err error) {
+	ret := _m.Called(ctx, ID)
 
 	var r0 *entity.Account
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *entity.Account); ok {
-		r0 = rf(ID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Account); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Account)
@@ -30,8 +32,8 @@ func (_m *mockAccountRepository) GetByID(ID uuid.UUID) (acc *entity.Account, err
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(ID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -39,13 +41,13 @@ func (_m *mockAccountRepository) GetByID(ID uuid.UUID) (acc *entity.Account, err
 	return r0, r1
 }
 
-// GetByUserID provides a mock function with given fields: userID
-func (_m *mockAccountRepository) GetByUserID(userID int64) (acc *entity.Account, err error) {
-	ret := _m.Called(userID)
+// GetByUserID provides a mock function with given fields: ctx, userID
+func (_m *mockAccountRepository) GetByUserID(ctx context.Context, userID int64) (acc *entity.Account, err error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 *entity.Account
-	if rf, ok := ret.Get(0).(func(int64) *entity.Account); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.Account); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Account)
@@ -53,8 +55,8 @@ func (_m *mockAccountRepository) GetByUserID(userID int64) (acc *entity.Account,
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,13 +64,13 @@ func (_m *mockAccountRepository) GetByUserID(userID int64) (acc *entity.Account,
 	return r0, r1
 }
 
-// Create provides a mock function with given fields: entity.Account
-func (_m *mockAccountRepository) Create(acc *entity.Account) (err error) {
-	ret := _m.Called(acc)
+// Create provides a mock function with given fields: ctx, entity.Account
+func (_m *mockAccountRepository) Create(ctx context.Context, acc *entity.Account) (err error) {
+	ret := _m.Called(ctx, acc)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entity.Account) error); ok {
-		r0 = rf(acc)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Account) error); ok {
+		r0 = rf(ctx, acc)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -76,13 +78,13 @@ func (_m *mockAccountRepository) Create(acc *entity.Account) (err error) {
 	return r0
 }
 
-// Update provides a mock function with given fields: acc *entity.Account
-func (_m *mockAccountRepository) Update(acc *entity.Account) (err error) {
-	ret := _m.Called(acc)
+// Update provides a mock function with given fields: ctx, acc *entity.Account
+func (_m *mockAccountRepository) Update(ctx context.Context, acc *entity.Account) (err error) {
+	ret := _m.Called(ctx, acc)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entity.Account) error); ok {
-		r0 = rf(acc)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Account) error); ok {
+		r0 = rf(ctx, acc)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
 	"github.com/google/uuid"
 )
@@ -8,11 +10,11 @@ import (
 // AccountRepository interface defines the methods that the account repository must implement.
 type AccountRepository interface {
 	// GetByID returns an account by its ID.
-	GetByID(id uuid.UUID) (account *entity.Account, err error)
+	GetByID(ctx context.Context, id uuid.UUID) (account *entity.Account, err error)
 	// GetByUserID returns an account by its user ID.
-	GetByUserID(userID int64) (account *entity.Account, err error)
+	GetByUserID(ctx context.Context, userID int64) (account *entity.Account, err error)
 	// Create creates a new account.
-	Create(account *entity.Account) (err error)
+	Create(ctx context.Context, account *entity.Account) (err error)
 	// Update updates an account.
-	Update(account *entity.Account) (err error)
+	Update(ctx context.Context, account *entity.Account) (err error)
 }
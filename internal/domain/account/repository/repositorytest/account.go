@@ -0,0 +1,96 @@
+// Package repositorytest holds the contract of the AccountRepository: the accounts are
+// found by their ID and by their user, and a user has a single account.
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository"
+	voAccount "github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// AccountRepositoryFactory returns an empty account repository for a test, and a function
+// creating the user of an account where the accounts reference the users.
+type AccountRepositoryFactory func(t *testing.T) (accountRepo repository.AccountRepository, createUser func(userID int64))
+
+// RunAccountRepositoryContract runs the contract of the AccountRepository interface against
+// the repositories of the factory, one empty repository per subtest.
+func RunAccountRepositoryContract(t *testing.T, newRepository AccountRepositoryFactory) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		// Given an empty repository
+		accountRepo, createUser := newRepository(t)
+		createUser(1)
+		acc := &entity.Account{ID: uuid.New(), Balance: 50, UserID: 1, Active: true}
+		// When creating an account
+		err := accountRepo.Create(context.Background(), acc)
+		// Then it is returned by its ID and by its user ID
+		assert.Nil(t, err)
+		byID, err := accountRepo.GetByID(context.Background(), acc.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, acc, byID)
+		byUserID, err := accountRepo.GetByUserID(context.Background(), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, acc, byUserID)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		// Given a repository with an account
+		accountRepo, createUser := newRepository(t)
+		createUser(1)
+		assert.Nil(t, accountRepo.Create(context.Background(), entity.NewAccount(1)))
+		// When getting another account by ID and by user ID
+		_, errID := accountRepo.GetByID(context.Background(), uuid.New())
+		_, errUserID := accountRepo.GetByUserID(context.Background(), 2)
+		// Then the errors returned are ErrAccountNotFound
+		assert.Equal(t, voAccount.ErrAccountNotFound, errID)
+		assert.Equal(t, voAccount.ErrAccountNotFound, errUserID)
+	})
+	t.Run("CreateNil", func(t *testing.T) {
+		// When creating a nil account
+		accountRepo, _ := newRepository(t)
+		err := accountRepo.Create(context.Background(), nil)
+		// Then the error returned is ErrNilAccount
+		assert.Equal(t, voAccount.ErrNilAccount, err)
+	})
+	t.Run("UniqueUserAccount", func(t *testing.T) {
+		// Given a repository with an account of the user
+		accountRepo, createUser := newRepository(t)
+		createUser(1)
+		first := entity.NewAccount(1)
+		assert.Nil(t, accountRepo.Create(context.Background(), first))
+		// When creating another account of the user
+		err := accountRepo.Create(context.Background(), entity.NewAccount(1))
+		// Then the error returned is a unique violation creating the account
+		assert.ErrorIs(t, err, voAccount.ErrCreatingAccount)
+		assert.ErrorIs(t, err, database.ErrUniqueViolation)
+		// And the account of the user does not change
+		stored, _ := accountRepo.GetByUserID(context.Background(), 1)
+		assert.Equal(t, first.ID, stored.ID)
+	})
+	t.Run("Update", func(t *testing.T) {
+		// Given a repository with an account
+		accountRepo, createUser := newRepository(t)
+		createUser(1)
+		acc := entity.NewAccount(1)
+		assert.Nil(t, accountRepo.Create(context.Background(), acc))
+		// When updating its balance and its status
+		acc.Balance = 120
+		acc.Active = true
+		err := accountRepo.Update(context.Background(), acc)
+		// Then it is returned updated
+		assert.Nil(t, err)
+		stored, _ := accountRepo.GetByID(context.Background(), acc.ID)
+		assert.Equal(t, acc, stored)
+	})
+	t.Run("UpdateNil", func(t *testing.T) {
+		// When updating a nil account
+		accountRepo, _ := newRepository(t)
+		err := accountRepo.Update(context.Background(), nil)
+		// Then the error returned is ErrNilAccount
+		assert.Equal(t, voAccount.ErrNilAccount, err)
+	})
+}
@@ -0,0 +1,167 @@
+package sqldb
+
+import (
+	"context"
+	"log"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/google/uuid"
+)
+
+// sqlAccountRepository struct implements the AccountRepository interface using
+// a PostgreSQL or a SQLite database.
+type sqlAccountRepository struct {
+	baseDB database.Database
+	repository.AccountRepository
+}
+
+// NewSQLAccountRepository creates a new instance of sqlAccountRepository.
+func NewSQLAccountRepository(baseDB database.Database) (accountRepo repository.AccountRepository) {
+	accountRepo = &sqlAccountRepository{
+		baseDB: baseDB,
+	}
+	return
+}
+
+// GetByID returns an account by its ID.
+const (
+	getAccountByID = `SELECT id, balance, userid, active FROM accounts WHERE id = $1`
+)
+
+func (sqlRepo *sqlAccountRepository) GetByID(ctx context.Context, ID uuid.UUID) (acc *entity.Account, err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	tx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(tx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	rows, err := database.Query(ctx, sqlRepo.baseDB, tx, getAccountByID, ID)
+	if err != nil {
+		err = database.Wrap(account.ErrQueryingAccountByID, err)
+		return
+	}
+	acc = &entity.Account{}
+	if rows.Next() {
+		err = rows.Scan(&acc.ID, &acc.Balance, &acc.UserID, &acc.Active)
+		if err != nil {
+			err = database.Wrap(account.ErrScanningAccountByID, err)
+			return
+		}
+	}
+	if acc.UserID == 0 {
+		err = account.ErrAccountNotFound
+	}
+	return
+}
+
+// GetByUserID returns an account by its user ID.
+const (
+	getAccountByUserID = `SELECT id, balance, userid, active FROM accounts WHERE userid = $1`
+)
+
+func (sqlRepo *sqlAccountRepository) GetByUserID(ctx context.Context, userID int64) (acc *entity.Account, err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	tx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(tx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	rows, err := database.Query(ctx, sqlRepo.baseDB, tx, getAccountByUserID, userID)
+	if err != nil {
+		err = database.Wrap(account.ErrQueryingAccountByUserID, err)
+		return
+	}
+	acc = &entity.Account{}
+	if rows.Next() {
+		err = rows.Scan(&acc.ID, &acc.Balance, &acc.UserID, &acc.Active)
+		if err != nil {
+			err = database.Wrap(account.ErrScanningAccountByUserID, err)
+			return
+		}
+	} else {
+		acc = nil
+		err = account.ErrAccountNotFound
+		return
+	}
+	log.Println("Returning account:", acc)
+	return
+}
+
+// Create creates a new account.
+const (
+	createAccount = `INSERT INTO accounts (id, balance, userid, active) VALUES ($1, $2, $3, $4)`
+)
+
+func (sqlRepo *sqlAccountRepository) Create(ctx context.Context, acc *entity.Account) (err error) {
+	if acc == nil {
+		err = account.ErrNilAccount
+		return
+	}
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	tx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(tx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	_, err = database.Exec(ctx, sqlRepo.baseDB, tx, createAccount, acc.ID, acc.Balance, acc.UserID, acc.Active)
+	if err != nil {
+		_ = sqlRepo.baseDB.Rollback(tx)
+		err = database.Wrap(account.ErrCreatingAccount, err)
+		return
+	}
+	err = sqlRepo.baseDB.Commit(tx)
+	return
+}
+
+// Update updates an account.
+const (
+	updateAccount = `UPDATE accounts SET balance = $1, active = $2 WHERE id = $3`
+)
+
+func (sqlRepo *sqlAccountRepository) Update(ctx context.Context, acc *entity.Account) (err error) {
+	if acc == nil {
+		err = account.ErrNilAccount
+		return
+	}
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	tx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(tx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	_, err = database.Exec(ctx, sqlRepo.baseDB, tx, updateAccount, acc.Balance, acc.Active, acc.ID)
+	if err != nil {
+		_ = sqlRepo.baseDB.Rollback(tx)
+		err = database.Wrap(account.ErrUpdatingAccount, err)
+		return
+	}
+	err = sqlRepo.baseDB.Commit(tx)
+	return
+}
@@ -1,11 +1,12 @@
-package postgres_test
+package sqldb_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
-	"github.com/braejan/go-transactions-summary/internal/domain/account/repository/postgres"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository/sqldb"
 	"github.com/braejan/go-transactions-summary/internal/valueobject/account"
 	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
 	mockvoPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres/mock"
@@ -17,9 +18,9 @@ import (
 func TestCreateErrAccountNil(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// When creating a account.
-	err := accountRepo.Create(nil)
+	err := accountRepo.Create(context.Background(), nil)
 	// Then the error returned is ErrNilAccount.
 	assert.NotNil(t, err)
 	assert.Equal(t, account.ErrNilAccount, err)
@@ -30,23 +31,23 @@ func TestCreateErrAccountNil(t *testing.T) {
 func TestCreateErrOpeningDatabase(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account entity.
 	account := entity.NewAccount(int64(1))
 	// And a mocked response when calling Open.
 	dbBase.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
 	// When creating a account.
-	err := accountRepo.Create(account)
+	err := accountRepo.Create(context.Background(), account)
 	// Then the error returned is ErrOpeningDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestCreateErrBeginningTransaction tests the error returned when beginning the transaction.
 func TestCreateErrBeginningTransaction(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account entity.
 	account := entity.NewAccount(int64(1))
 	// And a mocked database.
@@ -61,17 +62,17 @@ func TestCreateErrBeginningTransaction(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBase.On("Rollback", mock.Anything).Return(nil)
 	// When creating a account.
-	err := accountRepo.Create(account)
+	err := accountRepo.Create(context.Background(), account)
 	// Then the error returned is ErrBeginningTransaction.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestCreateErrExec tests the error returned when executing the query.
 func TestCreateErrExec(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account entity.
 	acc := entity.NewAccount(int64(1))
 	// And a mocked database.
@@ -88,17 +89,17 @@ func TestCreateErrExec(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBase.On("Rollback", tx).Return(nil)
 	// When creating a account.
-	err := accountRepo.Create(acc)
+	err := accountRepo.Create(context.Background(), acc)
 	// Then the error returned is ErrExec.
 	assert.NotNil(t, err)
-	assert.Equal(t, account.ErrCreatingAccount, err)
+	assert.ErrorIs(t, err, account.ErrCreatingAccount)
 }
 
 // TestCreateErrCommittingTransaction tests the error returned when committing the transaction.
 func TestCreateErrCommittingTransaction(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account entity.
 	acc := entity.NewAccount(int64(1))
 	// And a mocked database.
@@ -117,7 +118,7 @@ func TestCreateErrCommittingTransaction(t *testing.T) {
 	// And a mocked response when calling Commit.
 	dbBase.On("Commit", tx).Return(voPostgres.ErrCommittingTransaction)
 	// When creating a account.
-	err := accountRepo.Create(acc)
+	err := accountRepo.Create(context.Background(), acc)
 	// Then the error returned is ErrCommittingTransaction.
 	assert.NotNil(t, err)
 	assert.Equal(t, voPostgres.ErrCommittingTransaction, err)
@@ -127,7 +128,7 @@ func TestCreateErrCommittingTransaction(t *testing.T) {
 func TestCreateSuccess(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account entity.
 	acc := entity.NewAccount(int64(1))
 	// And a mocked database.
@@ -146,7 +147,7 @@ func TestCreateSuccess(t *testing.T) {
 	// And a mocked response when calling Commit.
 	dbBase.On("Commit", tx).Return(nil)
 	// When creating a account.
-	err := accountRepo.Create(acc)
+	err := accountRepo.Create(context.Background(), acc)
 	// Then the error returned is nil.
 	assert.Nil(t, err)
 }
@@ -1,4 +1,4 @@
-package postgres_test
+package sqldb_test
 
 import (
 	"context"
@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/braejan/go-transactions-summary/internal/domain/account/repository/postgres"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository/sqldb"
 	"github.com/braejan/go-transactions-summary/internal/valueobject/account"
 	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
 	mockvoPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres/mock"
@@ -19,23 +19,23 @@ import (
 func TestGetByIDErrorOpeningDatabase(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account ID.
 	ID := uuid.New()
 	// And a mocked response when calling Open.
 	dbBase.On("Open").Return(nil, errors.New("postgres: error opening database"))
 	// When GetByID is called.
-	_, err := accountRepo.GetByID(ID)
+	_, err := accountRepo.GetByID(context.Background(), ID)
 	// Then the error returned should be ErrOpeningDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestGetByIDErrorBeginningTransaction tests the GetByID method when an error occurs while beginning a transaction.
 func TestGetByIDErrorBeginningTransaction(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account ID.
 	ID := uuid.New()
 	// And a mocked database.
@@ -50,17 +50,17 @@ func TestGetByIDErrorBeginningTransaction(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBase.On("Rollback", mock.Anything).Return(nil)
 	// When GetByID is called.
-	_, err := accountRepo.GetByID(ID)
+	_, err := accountRepo.GetByID(context.Background(), ID)
 	// Then the error returned should be ErrBeginningTransaction.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestGetByIDErrorQueryingAccountByID tests the GetByID method when an error occurs while querying the account by ID.
 func TestGetByIDErrorQueryingAccountByID(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account ID.
 	ID := uuid.New()
 	// And a mocked database.
@@ -78,10 +78,10 @@ func TestGetByIDErrorQueryingAccountByID(t *testing.T) {
 	// And a mocked response when calling Query.
 	dbBase.On("Query", tx, "SELECT id, balance, userid, active FROM accounts WHERE id = $1", []interface{}{ID}).Return(nil, errors.New("postgres: error querying account by ID"))
 	// When GetByID is called.
-	_, err := accountRepo.GetByID(ID)
+	_, err := accountRepo.GetByID(context.Background(), ID)
 	// Then the error returned should be ErrQueryingAccountByID.
 	assert.NotNil(t, err)
-	assert.Equal(t, account.ErrQueryingAccountByID, err)
+	assert.ErrorIs(t, err, account.ErrQueryingAccountByID)
 }
 
 // TestGetByIDErrorScanningAccountByID tests the GetByID method when an error occurs while scanning the account by ID.
@@ -113,12 +113,12 @@ func TestGetByIDErrorScanningAccountByID(t *testing.T) {
 	assert.Nil(t, err)
 	dbBaseMocked.On("Query", tx, "SELECT id, balance, userid, active FROM accounts WHERE id = $1", []interface{}{ID}).Return(rows, nil)
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresAccountRepository(dbBaseMocked)
+	userRepo := sqldb.NewSQLAccountRepository(dbBaseMocked)
 	// When GetByID is called.
-	_, err = userRepo.GetByID(ID)
+	_, err = userRepo.GetByID(context.Background(), ID)
 	// Then the error returned should be ErrScanningUser.
 	assert.NotNil(t, err)
-	assert.Equal(t, account.ErrScanningAccountByID, err)
+	assert.ErrorIs(t, err, account.ErrScanningAccountByID)
 }
 
 // TestGetByIDSuccess tests the GetByID method when it succeeds.
@@ -150,9 +150,9 @@ func TestGetByIDSuccess(t *testing.T) {
 	assert.Nil(t, err)
 	dbBaseMocked.On("Query", tx, "SELECT id, balance, userid, active FROM accounts WHERE id = $1", []interface{}{ID}).Return(rows, nil)
 	// And a valid user repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBaseMocked)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBaseMocked)
 	// When GetByID is called.
-	account, err := accountRepo.GetByID(ID)
+	account, err := accountRepo.GetByID(context.Background(), ID)
 	// Then the error returned should be nil.
 	assert.Nil(t, err)
 	// And the user returned should be the expected one.
@@ -191,9 +191,9 @@ func TestGetByIDErrEmptyResponse(t *testing.T) {
 	assert.Nil(t, err)
 	dbBaseMocked.On("Query", tx, "SELECT id, balance, userid, active FROM accounts WHERE id = $1", []interface{}{ID}).Return(rows, nil)
 	// And a valid user repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBaseMocked)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBaseMocked)
 	// When GetByID is called.
-	acc, err := accountRepo.GetByID(ID)
+	acc, err := accountRepo.GetByID(context.Background(), ID)
 	// Then the error returned should be ErrAccountNotFound.
 	assert.NotNil(t, err)
 	assert.Equal(t, account.ErrAccountNotFound, err)
@@ -208,23 +208,23 @@ func TestGetByIDErrEmptyResponse(t *testing.T) {
 func TestGetByUserIDErrorOpeningDatabase(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account ID.
 	ID := int64(1)
 	// And a mocked response when calling Open.
 	dbBase.On("Open").Return(nil, errors.New("postgres: error opening database"))
 	// When GetByUserID is called.
-	_, err := accountRepo.GetByUserID(ID)
+	_, err := accountRepo.GetByUserID(context.Background(), ID)
 	// Then the error returned should be ErrOpeningDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestGetByUserIDErrorBeginningTransaction tests the GetByUserID method when the transaction cannot be started.
 func TestGetByUserIDErrorBeginningTransaction(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account ID.
 	ID := int64(1)
 	// And a mocked database.
@@ -239,17 +239,17 @@ func TestGetByUserIDErrorBeginningTransaction(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBase.On("Rollback", mock.Anything).Return(nil)
 	// When GetByUserID is called.
-	_, err := accountRepo.GetByUserID(ID)
+	_, err := accountRepo.GetByUserID(context.Background(), ID)
 	// Then the error returned should be ErrBeginningTransaction.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestGetByUserIDErrorQueryingAccountByID tests the GetByUserID method when the account cannot be queried.
 func TestGetByUserIDErrorQueryingAccountByID(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account ID.
 	ID := int64(1)
 	// And a mocked database.
@@ -269,10 +269,10 @@ func TestGetByUserIDErrorQueryingAccountByID(t *testing.T) {
 	// And a mocked response when calling Query.
 	dbBase.On("Query", tx, "SELECT id, balance, userid, active FROM accounts WHERE userid = $1", []interface{}{ID}).Return(nil, errors.New("postgres: error querying account by id"))
 	// When GetByUserID is called.
-	_, err := accountRepo.GetByUserID(ID)
+	_, err := accountRepo.GetByUserID(context.Background(), ID)
 	// Then the error returned should be ErrQueryingAccountByID.
 	assert.NotNil(t, err)
-	assert.Equal(t, account.ErrQueryingAccountByUserID, err)
+	assert.ErrorIs(t, err, account.ErrQueryingAccountByUserID)
 }
 
 // TestGetByUserIDErrorScanningAccountByID tests the GetByUserID method when the account cannot be scanned.
@@ -304,12 +304,12 @@ func TestGetByUserIDErrorScanningAccountByID(t *testing.T) {
 	assert.Nil(t, err)
 	dbBaseMocked.On("Query", tx, "SELECT id, balance, userid, active FROM accounts WHERE userid = $1", []interface{}{ID}).Return(rows, nil)
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresAccountRepository(dbBaseMocked)
+	userRepo := sqldb.NewSQLAccountRepository(dbBaseMocked)
 	// When GetByUserID is called.
-	_, err = userRepo.GetByUserID(ID)
+	_, err = userRepo.GetByUserID(context.Background(), ID)
 	// Then the error returned should be ErrScanningUser.
 	assert.NotNil(t, err)
-	assert.Equal(t, account.ErrScanningAccountByUserID, err)
+	assert.ErrorIs(t, err, account.ErrScanningAccountByUserID)
 }
 
 // TestGetByUserIDSuccess tests the GetByUserID method when it is successful.
@@ -342,9 +342,9 @@ func TestGetByUserIDSuccess(t *testing.T) {
 	assert.Nil(t, err)
 	dbBaseMocked.On("Query", tx, "SELECT id, balance, userid, active FROM accounts WHERE userid = $1", []interface{}{userID}).Return(rows, nil)
 	// And a valid user repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBaseMocked)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBaseMocked)
 	// When GetByUserID is called.
-	account, err := accountRepo.GetByUserID(userID)
+	account, err := accountRepo.GetByUserID(context.Background(), userID)
 	// Then the error returned should be nil.
 	assert.Nil(t, err)
 	// And the user returned should be the expected one.
@@ -383,9 +383,9 @@ func TestGetByUserIDErrEmptyResponse(t *testing.T) {
 	assert.Nil(t, err)
 	dbBaseMocked.On("Query", tx, "SELECT id, balance, userid, active FROM accounts WHERE userid = $1", []interface{}{userID}).Return(rows, nil)
 	// And a valid user repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBaseMocked)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBaseMocked)
 	// When GetByUserID is called.
-	_, err = accountRepo.GetByUserID(userID)
+	_, err = accountRepo.GetByUserID(context.Background(), userID)
 	// Then the error returned should be ErrAccountNotFound.
 	assert.NotNil(t, err)
 	assert.Equal(t, account.ErrAccountNotFound, err)
@@ -1,11 +1,12 @@
-package postgres_test
+package sqldb_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
-	"github.com/braejan/go-transactions-summary/internal/domain/account/repository/postgres"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository/sqldb"
 	"github.com/braejan/go-transactions-summary/internal/valueobject/account"
 	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
 	mockvoPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres/mock"
@@ -17,9 +18,9 @@ import (
 func TestUpdateErrUserNil(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// When updating a account.
-	err := accountRepo.Update(nil)
+	err := accountRepo.Update(context.Background(), nil)
 	// Then the error returned is ErrNilAccount.
 	assert.NotNil(t, err)
 	assert.Equal(t, account.ErrNilAccount, err)
@@ -29,23 +30,23 @@ func TestUpdateErrUserNil(t *testing.T) {
 func TestUpdateErrOpeningDatabase(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account entity.
 	acc := entity.NewAccount(int64(1))
 	// And a mocked response when calling Open.
 	dbBase.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
 	// When updating a account.
-	err := accountRepo.Update(acc)
+	err := accountRepo.Update(context.Background(), acc)
 	// Then the error returned is ErrOpeningDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestUpdateErrBeginningTransaction tests the error returned when beginning the transaction.
 func TestUpdateErrBeginningTransaction(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account entity.
 	acc := entity.NewAccount(int64(1))
 	// And a mocked database.
@@ -59,17 +60,17 @@ func TestUpdateErrBeginningTransaction(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBase.On("Rollback", mock.Anything).Return(nil)
 	// When updating a account.
-	err := accountRepo.Update(acc)
+	err := accountRepo.Update(context.Background(), acc)
 	// Then the error returned is ErrBeginningTransaction.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestUpdateErrUpdatingUser tests the error returned when updating the user.
 func TestUpdateErrUpdatingUser(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account entity.
 	acc := entity.NewAccount(int64(1))
 	// And a mocked database.
@@ -86,17 +87,17 @@ func TestUpdateErrUpdatingUser(t *testing.T) {
 	// And a mocked response when calling Exec.
 	dbBase.On("Exec", tx, "UPDATE accounts SET balance = $1, active = $2 WHERE id = $3", []interface{}{acc.Balance, acc.Active, acc.ID}).Return(nil, account.ErrUpdatingAccount)
 	// When updating a account.
-	err := accountRepo.Update(acc)
+	err := accountRepo.Update(context.Background(), acc)
 	// Then the error returned is ErrUpdatingAccount.
 	assert.NotNil(t, err)
-	assert.Equal(t, account.ErrUpdatingAccount, err)
+	assert.ErrorIs(t, err, account.ErrUpdatingAccount)
 }
 
 // TestUpdateErrCommittingTransaction tests the error returned when committing the transaction.
 func TestUpdateErrCommittingTransaction(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account entity.
 	acc := entity.NewAccount(int64(1))
 	// And a mocked database.
@@ -115,7 +116,7 @@ func TestUpdateErrCommittingTransaction(t *testing.T) {
 	// And a mocked response when calling Commit.
 	dbBase.On("Commit", tx).Return(voPostgres.ErrCommittingTransaction)
 	// When updating a account.
-	err := accountRepo.Update(acc)
+	err := accountRepo.Update(context.Background(), acc)
 	// Then the error returned is ErrCommittingTransaction.
 	assert.NotNil(t, err)
 	assert.Equal(t, voPostgres.ErrCommittingTransaction, err)
@@ -125,7 +126,7 @@ func TestUpdateErrCommittingTransaction(t *testing.T) {
 func TestUpdateSuccess(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	accountRepo := postgres.NewPostgresAccountRepository(dbBase)
+	accountRepo := sqldb.NewSQLAccountRepository(dbBase)
 	// And a valid account entity.
 	acc := entity.NewAccount(int64(1))
 	// And a mocked database.
@@ -144,7 +145,7 @@ func TestUpdateSuccess(t *testing.T) {
 	// And a mocked response when calling Commit.
 	dbBase.On("Commit", tx).Return(nil)
 	// When updating a account.
-	err := accountRepo.Update(acc)
+	err := accountRepo.Update(context.Background(), acc)
 	// Then the error returned is nil.
 	assert.Nil(t, err)
 }
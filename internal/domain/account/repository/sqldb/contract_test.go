@@ -0,0 +1,30 @@
+package sqldb_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository/repositorytest"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository/sqldb"
+	userEntity "github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	userSQL "github.com/braejan/go-transactions-summary/internal/domain/user/repository/sqldb"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database/databasetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAccountRepositoryContract tests the repository against the AccountRepository
+// contract on every dialect.
+func TestAccountRepositoryContract(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, newDatabase databasetest.Factory) {
+		repositorytest.RunAccountRepositoryContract(t, func(t *testing.T) (repository.AccountRepository, func(int64)) {
+			baseDB := newDatabase(t)
+			userRepo := userSQL.NewSQLUserRepository(baseDB)
+			createUser := func(userID int64) {
+				assert.Nil(t, userRepo.Create(context.Background(), userEntity.NewUser(userID, "User", fmt.Sprintf("user%d@amazingemail.com", userID))))
+			}
+			return sqldb.NewSQLAccountRepository(baseDB), createUser
+		})
+	})
+}
@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/repository"
+	voTracing "github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+	"github.com/google/uuid"
+)
+
+// tracingAccountRepository struct implements the AccountRepository interface starting a span for every
+// method of the decorated repository.
+type tracingAccountRepository struct {
+	accountRepo repository.AccountRepository
+}
+
+// NewTracingAccountRepository returns the repository decorated with the spans of its methods, children
+// of the span carried by their context.
+func NewTracingAccountRepository(accountRepo repository.AccountRepository) (decorated repository.AccountRepository) {
+	decorated = &tracingAccountRepository{
+		accountRepo: accountRepo,
+	}
+	return
+}
+
+// AccountRepository interface implementation
+
+func (tracingRepo *tracingAccountRepository) GetByID(ctx context.Context, ID uuid.UUID) (account *entity.Account, err error) {
+	ctx, span := voTracing.Start(ctx, "AccountRepository.GetByID")
+	defer span.EndWithError(&err)
+	return tracingRepo.accountRepo.GetByID(ctx, ID)
+}
+
+func (tracingRepo *tracingAccountRepository) GetByUserID(ctx context.Context, userID int64) (account *entity.Account, err error) {
+	ctx, span := voTracing.Start(ctx, "AccountRepository.GetByUserID")
+	defer span.EndWithError(&err)
+	return tracingRepo.accountRepo.GetByUserID(ctx, userID)
+}
+
+func (tracingRepo *tracingAccountRepository) Create(ctx context.Context, account *entity.Account) (err error) {
+	ctx, span := voTracing.Start(ctx, "AccountRepository.Create")
+	defer span.EndWithError(&err)
+	return tracingRepo.accountRepo.Create(ctx, account)
+}
+
+func (tracingRepo *tracingAccountRepository) Update(ctx context.Context, account *entity.Account) (err error) {
+	ctx, span := voTracing.Start(ctx, "AccountRepository.Update")
+	defer span.EndWithError(&err)
+	return tracingRepo.accountRepo.Update(ctx, account)
+}
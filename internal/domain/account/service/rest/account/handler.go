@@ -0,0 +1,102 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/usecases"
+	voAccount "github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voUser "github.com/braejan/go-transactions-summary/internal/valueobject/user"
+	"github.com/gorilla/mux"
+)
+
+// AccountHandler serves the accounts. The end users only read their own account, the
+// clients granted the users:admin scope every account.
+type AccountHandler struct {
+	accountUseCases usecases.AccountUseCases
+	// logger logs the failed requests with their request ID.
+	logger logger.Logger
+}
+
+// AccountHandlerOption configures an optional collaborator of the account handler.
+type AccountHandlerOption func(handler *AccountHandler) (err error)
+
+// WithLogger logs the failed requests with the logger instead of the default one.
+func WithLogger(log logger.Logger) AccountHandlerOption {
+	return func(handler *AccountHandler) (err error) {
+		if log == nil {
+			err = logger.ErrNilLogger
+			return
+		}
+		handler.logger = log
+		return
+	}
+}
+
+func NewAccountHandler(accountUseCases usecases.AccountUseCases, options ...AccountHandlerOption) (accountHandler *AccountHandler, err error) {
+	if accountUseCases == nil {
+		err = voAccount.ErrNilAccountUseCases
+		return
+	}
+	handler := &AccountHandler{
+		accountUseCases: accountUseCases,
+		logger:          logger.NewDefaultLogger(),
+	}
+	for _, option := range options {
+		if err = option(handler); err != nil {
+			return
+		}
+	}
+	accountHandler = handler
+	return
+}
+
+func (handler *AccountHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/accounts/{id}", apierror.Handler(handler.logger, handler.GetAccount)).Methods("GET")
+	router.HandleFunc("/users/{id}/account", apierror.Handler(handler.logger, handler.GetUserAccount)).Methods("GET")
+}
+
+// GetAccount responds with the account of the ID.
+func (handler *AccountHandler) GetAccount(writer http.ResponseWriter, request *http.Request) (err error) {
+	account, err := handler.accountUseCases.GetByID(request.Context(), mux.Vars(request)["id"])
+	if err != nil {
+		return
+	}
+	return handler.writeAccount(writer, request, account)
+}
+
+// GetUserAccount responds with the account of the user of the ID.
+func (handler *AccountHandler) GetUserAccount(writer http.ResponseWriter, request *http.Request) (err error) {
+	userID, err := strconv.ParseInt(mux.Vars(request)["id"], 10, 64)
+	if err != nil {
+		return voUser.ErrInvalidUserID
+	}
+	// The access is checked before the query, so the accounts of other users are not probed.
+	if !auth.CanAccessUser(request.Context(), userID) {
+		return auth.ErrForbidden
+	}
+	account, err := handler.accountUseCases.GetByUserID(request.Context(), userID)
+	if err != nil {
+		return
+	}
+	return handler.writeAccount(writer, request, account)
+}
+
+// writeAccount writes the account as JSON, or returns auth.ErrForbidden when the client
+// cannot read it.
+func (handler *AccountHandler) writeAccount(writer http.ResponseWriter, request *http.Request, account entity.Account) (err error) {
+	if !auth.CanAccessUser(request.Context(), account.UserID) {
+		return fmt.Errorf("%w: account of another user", auth.ErrForbidden)
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	if errWrite := json.NewEncoder(writer).Encode(account); errWrite != nil {
+		logger.FromContext(request.Context(), handler.logger).Error("error writing the account", "error", errWrite)
+	}
+	return
+}
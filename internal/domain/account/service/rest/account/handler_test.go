@@ -0,0 +1,121 @@
+package account_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/service/rest/account"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/usecases/mock"
+	voAccount "github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror/envelope"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/openapi/openapitest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+// serve sends a GET request of the principal to the routes of the handler.
+func serve(t *testing.T, handler *account.AccountHandler, path string, principal *auth.Principal) *httptest.ResponseRecorder {
+	router := openapitest.NewRouter(t)
+	handler.RegisterRoutes(router)
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	if principal != nil {
+		request = request.WithContext(auth.WithPrincipal(request.Context(), principal))
+	}
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	return response
+}
+
+// TestNewAccountHandler tests the NewAccountHandler function with nil use cases.
+func TestNewAccountHandler(t *testing.T) {
+	// When NewAccountHandler is called with nil use cases
+	handler, err := account.NewAccountHandler(nil)
+	// Then the error returned is ErrNilAccountUseCases
+	assert.Nil(t, handler)
+	assert.Equal(t, voAccount.ErrNilAccountUseCases, err)
+}
+
+// TestGetAccount tests the end users only read their own account.
+func TestGetAccount(t *testing.T) {
+	// Given an account of the user 42
+	acc := entity.Account{ID: uuid.New(), Balance: 50.2, UserID: 42, Active: true}
+	useCases := mock.NewMockAccountUseCases()
+	useCases.On("GetByID", testifyMock.Anything, acc.ID.String()).Return(acc, nil)
+	handler, err := account.NewAccountHandler(useCases)
+	assert.Nil(t, err)
+	path := "/accounts/" + acc.ID.String()
+	// When the user 42 reads the account
+	response := serve(t, handler, path, &auth.Principal{ID: "42", IsUser: true, UserID: 42})
+	// Then the account is returned as JSON
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	var decoded entity.Account
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&decoded))
+	assert.Equal(t, acc, decoded)
+	// When the user 7 reads the account
+	response = serve(t, handler, path, &auth.Principal{ID: "7", IsUser: true, UserID: 7})
+	// Then the request is forbidden
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	// When an admin reads the account
+	response = serve(t, handler, path, &auth.Principal{ID: "admin", Scopes: []string{auth.ScopeUsersAdmin}})
+	// Then the account is returned
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+// TestGetAccountErrors tests the errors of the query are mapped to their status.
+func TestGetAccountErrors(t *testing.T) {
+	// Given use cases failing to get the accounts
+	useCases := mock.NewMockAccountUseCases()
+	useCases.On("GetByID", testifyMock.Anything, "invalid").Return(entity.Account{}, voAccount.ErrProcessingAccountID)
+	useCases.On("GetByID", testifyMock.Anything, "missing").Return(entity.Account{}, voAccount.ErrAccountNotFound)
+	useCases.On("GetByID", testifyMock.Anything, "failing").Return(entity.Account{}, errors.New("connection refused"))
+	handler, err := account.NewAccountHandler(useCases)
+	assert.Nil(t, err)
+	cases := []struct {
+		path   string
+		status int
+		code   string
+	}{
+		{path: "/accounts/invalid", status: http.StatusBadRequest, code: "ACCOUNT_ID_INVALID"},
+		{path: "/accounts/missing", status: http.StatusNotFound, code: "ACCOUNT_NOT_FOUND"},
+		{path: "/accounts/failing", status: http.StatusInternalServerError, code: "INTERNAL_ERROR"},
+	}
+	for _, tc := range cases {
+		// When reading the account without authentication
+		response := serve(t, handler, tc.path, nil)
+		// Then the error is written as an envelope with its code
+		var body envelope.Envelope
+		assert.Equal(t, tc.status, response.Code, tc.path)
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+		assert.Equal(t, tc.code, body.Code, tc.path)
+	}
+}
+
+// TestGetUserAccount tests the account of another user is not queried.
+func TestGetUserAccount(t *testing.T) {
+	// Given an account of the user 42
+	acc := entity.Account{ID: uuid.New(), UserID: 42}
+	useCases := mock.NewMockAccountUseCases()
+	useCases.On("GetByUserID", testifyMock.Anything, int64(42)).Return(acc, nil)
+	handler, err := account.NewAccountHandler(useCases)
+	assert.Nil(t, err)
+	// When the user 42 reads its account
+	response := serve(t, handler, "/users/42/account", &auth.Principal{ID: "42", IsUser: true, UserID: 42})
+	// Then the account is returned
+	assert.Equal(t, http.StatusOK, response.Code)
+	// When the user 7 reads the account of the user 42
+	response = serve(t, handler, "/users/42/account", &auth.Principal{ID: "7", IsUser: true, UserID: 7})
+	// Then the request is forbidden without querying the account
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	useCases.AssertNumberOfCalls(t, "GetByUserID", 1)
+	// When reading the account of an invalid user ID
+	response = serve(t, handler, "/users/abc/account", nil)
+	// Then the request is bad
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
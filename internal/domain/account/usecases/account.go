@@ -1,7 +1,8 @@
 package usecases
 
 import (
-	"log"
+	"context"
+	"errors"
 
 	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
 	accRepo "github.com/braejan/go-transactions-summary/internal/domain/account/repository"
@@ -37,13 +38,13 @@ func NewAccountUseCases(accountRepo accRepo.AccountRepository, usrRepo userRepo.
 // Usecases interface implementation:
 
 // GetByID implements the AccountUsecases interface method.
-func (u *accountUsecases) GetByID(ID string) (acc entity.Account, err error) {
+func (u *accountUsecases) GetByID(ctx context.Context, ID string) (acc entity.Account, err error) {
 	accID, err := uuid.Parse(ID)
 	if err != nil {
 		err = account.ErrProcessingAccountID
 		return
 	}
-	accAux, err := u.accountRepo.GetByID(accID)
+	accAux, err := u.accountRepo.GetByID(ctx, accID)
 	if err != nil {
 		return
 	}
@@ -52,8 +53,8 @@ func (u *accountUsecases) GetByID(ID string) (acc entity.Account, err error) {
 }
 
 // GetByUserID implements the AccountUsecases interface method.
-func (u *accountUsecases) GetByUserID(userID int64) (acc entity.Account, err error) {
-	accAux, err := u.accountRepo.GetByUserID(userID)
+func (u *accountUsecases) GetByUserID(ctx context.Context, userID int64) (acc entity.Account, err error) {
+	accAux, err := u.accountRepo.GetByUserID(ctx, userID)
 	if err != nil {
 		return
 	}
@@ -62,18 +63,17 @@ func (u *accountUsecases) GetByUserID(userID int64) (acc entity.Account, err err
 }
 
 // Create implements the AccountUsecases interface method.
-func (u *accountUsecases) Create(userID int64) (err error) {
-	log.Println("Creating account for user", userID)
+func (u *accountUsecases) Create(ctx context.Context, userID int64) (err error) {
 	// Check if the user exists.
-	_, err = u.userRepo.GetByID(userID)
+	_, err = u.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		// The user is not created.
 		err = user.ErrUserNotFound
 		return
 	}
 	// Check if the user already has an account.
-	acc, err := u.accountRepo.GetByUserID(userID)
-	if err != nil && err != account.ErrAccountNotFound {
+	acc, err := u.accountRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, account.ErrAccountNotFound) {
 		return
 	}
 	if acc != nil {
@@ -83,25 +83,25 @@ func (u *accountUsecases) Create(userID int64) (err error) {
 	}
 	// Create the account.
 	acc = entity.NewAccount(userID)
-	err = u.accountRepo.Create(acc)
+	err = u.accountRepo.Create(ctx, acc)
 	return
 }
 
 // Update implements the AccountUsecases interface method.
-func (u *accountUsecases) Update(ID string, balance float64, active bool) (err error) {
+func (u *accountUsecases) Update(ctx context.Context, ID string, balance float64, active bool) (err error) {
 	accID, err := uuid.Parse(ID)
 	if err != nil {
 		err = account.ErrProcessingAccountID
 		return
 	}
 	// Check if the account exists.
-	acc, err := u.accountRepo.GetByID(accID)
+	acc, err := u.accountRepo.GetByID(ctx, accID)
 	if err != nil {
 		return
 	}
 	// Update the account.
 	acc.Balance = balance
 	acc.Active = active
-	err = u.accountRepo.Update(acc)
+	err = u.accountRepo.Update(ctx, acc)
 	return
 }
@@ -1,6 +1,7 @@
 package usecases_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -59,7 +60,7 @@ func TestGetByIDWithInvalidID(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, usecases)
 	// When GetByID is called with an invalid ID.
-	_, err = usecases.GetByID("invalid")
+	_, err = usecases.GetByID(context.Background(), "invalid")
 	// Then the error ErrProcessingAccountID is returned.
 	assert.EqualError(t, err, account.ErrProcessingAccountID.Error())
 }
@@ -76,9 +77,9 @@ func TestGetByIDSucess(t *testing.T) {
 	// And a valid account
 	accExpected := entity.NewAccount(int64(1))
 	// And a mocked response when GetByID is called.
-	accRepo.On("GetByID", accExpected.ID).Return(accExpected, nil)
+	accRepo.On("GetByID", mock.Anything, accExpected.ID).Return(accExpected, nil)
 	// When GetByID is called with a valid ID.
-	acc, err := usecases.GetByID(accExpected.ID.String())
+	acc, err := usecases.GetByID(context.Background(), accExpected.ID.String())
 	// Then no error is returned.
 	assert.Nil(t, err)
 	// And the account returned is the expected.
@@ -95,9 +96,9 @@ func TestGetByUserIDWithInvalidUserID(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, usecases)
 	// And a mocked response when GetByUserID is called.
-	accRepo.On("GetByUserID", int64(0)).Return(nil, user.ErrUserNotFound)
+	accRepo.On("GetByUserID", mock.Anything, int64(0)).Return(nil, user.ErrUserNotFound)
 	// When GetByUserID is called with an invalid user ID.
-	_, err = usecases.GetByUserID(int64(0))
+	_, err = usecases.GetByUserID(context.Background(), int64(0))
 	// Then the error ErrProcessingUserID is returned.
 	assert.EqualError(t, err, user.ErrUserNotFound.Error())
 }
@@ -112,9 +113,9 @@ func TestCreateWithInvalidUserID(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, usecases)
 	// And a mocked response when userRepo.GetByID is called.
-	userRepo.On("GetByID", int64(0)).Return(nil, user.ErrUserNotFound)
+	userRepo.On("GetByID", mock.Anything, int64(0)).Return(nil, user.ErrUserNotFound)
 	// When Create is called with an invalid user ID.
-	err = usecases.Create(int64(0))
+	err = usecases.Create(context.Background(), int64(0))
 	// Then the error ErrProcessingUserID is returned.
 	assert.EqualError(t, err, user.ErrUserNotFound.Error())
 }
@@ -130,12 +131,12 @@ func TestCreateWithExistingAccount(t *testing.T) {
 	assert.NotNil(t, usecases)
 	// And a mocked response when userRepo.GetByID is called.
 	user := userEntity.NewUser(int64(1), "John Doe", "john.doe@amazingemail.com")
-	userRepo.On("GetByID", user.ID).Return(user, nil)
+	userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
 	// And a mocked response when accRepo.GetByUserID is called.
 	acc := entity.NewAccount(user.ID)
-	accRepo.On("GetByUserID", user.ID).Return(acc, nil)
+	accRepo.On("GetByUserID", mock.Anything, user.ID).Return(acc, nil)
 	// When Create is called with an existing account.
-	err = usecases.Create(user.ID)
+	err = usecases.Create(context.Background(), user.ID)
 	// Then the error is not nil
 	assert.NotNil(t, err)
 	// Then the error ErrAccountAlreadyExists is returned.
@@ -153,11 +154,11 @@ func TestCreateWithErrorGettingAccount(t *testing.T) {
 	assert.NotNil(t, usecases)
 	// And a mocked response when userRepo.GetByID is called.
 	user := userEntity.NewUser(int64(1), "John Doe", "john.doe@amazinemail.com")
-	userRepo.On("GetByID", user.ID).Return(user, nil)
+	userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
 	// And a mocked response when accRepo.GetByUserID is called.
-	accRepo.On("GetByUserID", user.ID).Return(nil, errors.New("error"))
+	accRepo.On("GetByUserID", mock.Anything, user.ID).Return(nil, errors.New("error"))
 	// When Create is called with an error getting the account.
-	err = usecases.Create(user.ID)
+	err = usecases.Create(context.Background(), user.ID)
 	// Then the error is not nil
 	assert.NotNil(t, err)
 }
@@ -173,13 +174,13 @@ func TestCreateWithRepoError(t *testing.T) {
 	assert.NotNil(t, usecases)
 	// And a mocked response when userRepo.GetByID is called.
 	user := userEntity.NewUser(int64(1), "John Doe", "john.doe@amazinemail.com")
-	userRepo.On("GetByID", user.ID).Return(user, nil)
+	userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
 	// And a mocked response when accRepo.GetByUserID is called.
-	accRepo.On("GetByUserID", user.ID).Return(nil, account.ErrAccountNotFound)
+	accRepo.On("GetByUserID", mock.Anything, user.ID).Return(nil, account.ErrAccountNotFound)
 	// And a mocked response when accRepo.Create is called.
-	accRepo.On("Create", mock.Anything).Return(errors.New("error"))
+	accRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("error"))
 	// When Create is called with an error saving the account.
-	err = usecases.Create(user.ID)
+	err = usecases.Create(context.Background(), user.ID)
 	// Then the error is not nil
 	assert.NotNil(t, err)
 }
@@ -195,13 +196,13 @@ func TestCreateWithSuccess(t *testing.T) {
 	assert.NotNil(t, usecases)
 	// And a mocked response when userRepo.GetByID is called.
 	user := userEntity.NewUser(int64(1), "John Doe", "john.doe@amazingemail.com")
-	userRepo.On("GetByID", user.ID).Return(user, nil)
+	userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
 	// And a mocked response when accRepo.GetByUserID is called.
-	accRepo.On("GetByUserID", user.ID).Return(nil, account.ErrAccountNotFound)
+	accRepo.On("GetByUserID", mock.Anything, user.ID).Return(nil, account.ErrAccountNotFound)
 	// And a mocked response when accRepo.Create is called.
-	accRepo.On("Create", mock.Anything).Return(nil)
+	accRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
 	// When Create is called with success.
-	err = usecases.Create(user.ID)
+	err = usecases.Create(context.Background(), user.ID)
 	// Then the error is nil
 	assert.Nil(t, err)
 }
@@ -216,7 +217,7 @@ func TestUpdateWithInvalidAccountID(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, usecases)
 	// When Update is called with an invalid account ID.
-	err = usecases.Update("invalid", 1.00, true)
+	err = usecases.Update(context.Background(), "invalid", 1.00, true)
 	// Then the error ErrProcessingAccountID is returned.
 	assert.EqualError(t, err, account.ErrProcessingAccountID.Error())
 }
@@ -233,9 +234,9 @@ func TestUpdateWithAccountNotFound(t *testing.T) {
 	// And a valid uuid.UUID accID
 	accID := uuid.New()
 	// And a mocked response when accRepo.GetByID is called.
-	accRepo.On("GetByID", accID).Return(nil, account.ErrAccountNotFound)
+	accRepo.On("GetByID", mock.Anything, accID).Return(nil, account.ErrAccountNotFound)
 	// When Update is called with an account not found.
-	err = usecases.Update(accID.String(), 1.00, true)
+	err = usecases.Update(context.Background(), accID.String(), 1.00, true)
 	// Then the error ErrAccountNotFound is returned.
 	assert.EqualError(t, err, account.ErrAccountNotFound.Error())
 }
@@ -253,11 +254,11 @@ func TestUpdateWithRepoError(t *testing.T) {
 	accID := uuid.New()
 	// And a mocked response when accRepo.GetByID is called.
 	acc := entity.NewAccount(int64(1))
-	accRepo.On("GetByID", accID).Return(acc, nil)
+	accRepo.On("GetByID", mock.Anything, accID).Return(acc, nil)
 	// And a mocked response when accRepo.Update is called.
-	accRepo.On("Update", mock.Anything).Return(errors.New("error"))
+	accRepo.On("Update", mock.Anything, mock.Anything).Return(errors.New("error"))
 	// When Update is called with an error saving the account.
-	err = usecases.Update(accID.String(), 1.00, true)
+	err = usecases.Update(context.Background(), accID.String(), 1.00, true)
 	// Then the error is not nil
 	assert.NotNil(t, err)
 }
@@ -275,11 +276,11 @@ func TestUpdateWithSuccess(t *testing.T) {
 	accID := uuid.New()
 	// And a mocked response when accRepo.GetByID is called.
 	acc := entity.NewAccount(int64(1))
-	accRepo.On("GetByID", accID).Return(acc, nil)
+	accRepo.On("GetByID", mock.Anything, accID).Return(acc, nil)
 	// And a mocked response when accRepo.Update is called.
-	accRepo.On("Update", mock.Anything).Return(nil)
+	accRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
 	// When Update is called with success.
-	err = usecases.Update(accID.String(), 1.00, true)
+	err = usecases.Update(context.Background(), accID.String(), 1.00, true)
 	// Then the error is nil
 	assert.Nil(t, err)
 }
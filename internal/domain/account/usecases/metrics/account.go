@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/account/usecases"
+	voMetrics "github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+)
+
+// metricsAccountUseCases struct implements the AccountUseCases interface counting the
+// accounts created by the decorated use cases.
+type metricsAccountUseCases struct {
+	accountUseCases usecases.AccountUseCases
+	created         *voMetrics.Counter
+}
+
+// NewMetricsAccountUseCases returns the use cases decorated with the account metrics of the registry.
+func NewMetricsAccountUseCases(accountUseCases usecases.AccountUseCases, registry *voMetrics.Registry) (decorated usecases.AccountUseCases) {
+	decorated = &metricsAccountUseCases{
+		accountUseCases: accountUseCases,
+		created:         registry.Counter("accounts_created_total", "Accounts created, for the users of a file without one."),
+	}
+	return
+}
+
+// AccountUseCases interface implementation
+
+func (uc *metricsAccountUseCases) GetByID(ctx context.Context, ID string) (acc entity.Account, err error) {
+	return uc.accountUseCases.GetByID(ctx, ID)
+}
+
+func (uc *metricsAccountUseCases) GetByUserID(ctx context.Context, userID int64) (acc entity.Account, err error) {
+	return uc.accountUseCases.GetByUserID(ctx, userID)
+}
+
+func (uc *metricsAccountUseCases) Create(ctx context.Context, userID int64) (err error) {
+	err = uc.accountUseCases.Create(ctx, userID)
+	if err == nil {
+		uc.created.Inc()
+	}
+	return
+}
+
+func (uc *metricsAccountUseCases) Update(ctx context.Context, ID string, balance float64, active bool) (err error) {
+	return uc.accountUseCases.Update(ctx, ID, balance, active)
+}
@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"context"
+
 	"github.com/braejan/go-transactions-summary/internal/domain/account/entity"
 	"github.com/stretchr/testify/mock"
 )
@@ -15,13 +17,13 @@ func NewMockAccountUseCases() *mockAccountUseCases {
 	return &mockAccountUseCases{}
 }
 
-// GetByID provides a mock function with given fields: ID
-func (_m *mockAccountUseCases) GetByID(ID string) (acc entity.Account, err error) {
-	ret := _m.Called(ID)
+// GetByID provides a mock function with given fields: ctx, ID
+func (_m *mockAccountUseCases) GetByID(ctx context.Context, ID string) (acc entity.Account, err error) {
+	ret := _m.Called(ctx, ID)
 
 	var r0 entity.Account
-	if rf, ok := ret.Get(0).(func(string) entity.Account); ok {
-		r0 = rf(ID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Account); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(entity.Account)
@@ -29,8 +31,8 @@ func (_m *mockAccountUseCases) GetByID(ID string) (acc entity.Account, err error
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(ID)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -38,20 +40,20 @@ func (_m *mockAccountUseCases) GetByID(ID string) (acc entity.Account, err error
 	return r0, r1
 }
 
-// GetByUserID provides a mock function with given fields: userID
-func (_m *mockAccountUseCases) GetByUserID(userID int64) (acc entity.Account, err error) {
-	ret := _m.Called(userID)
+// GetByUserID provides a mock function with given fields: ctx, userID
+func (_m *mockAccountUseCases) GetByUserID(ctx context.Context, userID int64) (acc entity.Account, err error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 entity.Account
-	if rf, ok := ret.Get(0).(func(int64) entity.Account); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) entity.Account); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		r0 = ret.Get(0).(entity.Account)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,13 +61,13 @@ func (_m *mockAccountUseCases) GetByUserID(userID int64) (acc entity.Account, er
 	return r0, r1
 }
 
-// Create provides a mock function with given fields: userID
-func (_m *mockAccountUseCases) Create(userID int64) (err error) {
-	ret := _m.Called(userID)
+// Create provides a mock function with given fields: ctx, userID
+func (_m *mockAccountUseCases) Create(ctx context.Context, userID int64) (err error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -73,13 +75,13 @@ func (_m *mockAccountUseCases) Create(userID int64) (err error) {
 	return r0
 }
 
-// Update provides a mock function with given fields: ID, balance, active
-func (_m *mockAccountUseCases) Update(ID string, balance float64, active bool) (err error) {
-	ret := _m.Called(ID, balance, active)
+// Update provides a mock function with given fields: ctx, ID, balance, active
+func (_m *mockAccountUseCases) Update(ctx context.Context, ID string, balance float64, active bool) (err error) {
+	ret := _m.Called(ctx, ID, balance, active)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, float64, bool) error); ok {
-		r0 = rf(ID, balance, active)
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, bool) error); ok {
+		r0 = rf(ctx, ID, balance, active)
 	} else {
 		r0 = ret.Error(0)
 	}
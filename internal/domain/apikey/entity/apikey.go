@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	voAPIKey "github.com/braejan/go-transactions-summary/internal/valueobject/apikey"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+)
+
+// TokenPrefix starts every API key, so a leaked key is easy to recognize.
+const TokenPrefix = "tsk_"
+
+// APIKey struct defines the API key of a client. Only the hash of its secret is stored,
+// the token is shown once when the key is created.
+type APIKey struct {
+	// ID identifies the key, it is the public part of the token.
+	ID string `json:"id"`
+	// Name describes the client of the key.
+	Name string `json:"name"`
+	// Hash is the hex encoded SHA-256 of the secret of the token.
+	Hash string `json:"-"`
+	// Scopes are the scopes granted to the client.
+	Scopes []string `json:"scopes"`
+	// CreatedAt is the date and time when the key was created.
+	CreatedAt time.Time `json:"created_at"`
+	// RevokedAt is the date and time when the key was revoked, zero while it is active.
+	RevokedAt time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewAPIKey returns a new APIKey instance with random ID and secret, and its token
+// "tsk_<id>_<secret>".
+func NewAPIKey(name string, scopes []string, now time.Time) (key *APIKey, token string, err error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		err = voAPIKey.ErrEmptyAPIKeyName
+		return
+	}
+	if len(scopes) == 0 {
+		err = voAPIKey.ErrEmptyAPIKeyScopes
+		return
+	}
+	if err = auth.ValidateScopes(scopes); err != nil {
+		return
+	}
+	id, err := randomHex(8)
+	if err != nil {
+		return
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return
+	}
+	key = &APIKey{
+		ID:        id,
+		Name:      name,
+		Hash:      hashSecret(secret),
+		Scopes:    scopes,
+		CreatedAt: now,
+	}
+	token = TokenPrefix + id + "_" + secret
+	return
+}
+
+// ParseToken returns the ID and the secret of a token, ErrInvalidAPIKey when it is not
+// an API key.
+func ParseToken(token string) (id string, secret string, err error) {
+	found := strings.HasPrefix(token, TokenPrefix)
+	if found {
+		id, secret, found = strings.Cut(strings.TrimPrefix(token, TokenPrefix), "_")
+	}
+	if !found || id == "" || secret == "" {
+		id, secret = "", ""
+		err = voAPIKey.ErrInvalidAPIKey
+	}
+	return
+}
+
+// Matches returns whether the secret is the secret of the key, in constant time.
+func (key *APIKey) Matches(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(key.Hash)) == 1
+}
+
+// IsRevoked returns whether the key was revoked.
+func (key *APIKey) IsRevoked() bool {
+	return !key.RevokedAt.IsZero()
+}
+
+// Principal returns the authenticated client of the key.
+func (key *APIKey) Principal() *auth.Principal {
+	return &auth.Principal{ID: key.ID, Name: key.Name, Scopes: key.Scopes}
+}
+
+// hashSecret returns the hex encoded SHA-256 of the secret. The secrets are random, so a
+// fast hash is enough to keep them from being recovered.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomHex returns n random bytes hex encoded.
+func randomHex(n int) (value string, err error) {
+	buffer := make([]byte, n)
+	if _, err = rand.Read(buffer); err != nil {
+		return
+	}
+	value = hex.EncodeToString(buffer)
+	return
+}
@@ -0,0 +1,69 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+	voAPIKey "github.com/braejan/go-transactions-summary/internal/valueobject/apikey"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewAPIKey tests a new key matches the secret of its token only.
+func TestNewAPIKey(t *testing.T) {
+	// Given a name and scopes
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	scopes := []string{auth.ScopeFilesUpload}
+	// When calling NewAPIKey
+	key, token, err := entity.NewAPIKey(" uploader ", scopes, now)
+	// Then the key is created with the trimmed name
+	assert.Nil(t, err)
+	assert.Equal(t, "uploader", key.Name)
+	assert.Equal(t, scopes, key.Scopes)
+	assert.Equal(t, now, key.CreatedAt)
+	assert.False(t, key.IsRevoked())
+	// And the token has the prefix and the ID of the key
+	assert.True(t, strings.HasPrefix(token, entity.TokenPrefix+key.ID+"_"))
+	// And the token is parsed back to the ID and a secret matching the key
+	id, secret, err := entity.ParseToken(token)
+	assert.Nil(t, err)
+	assert.Equal(t, key.ID, id)
+	assert.True(t, key.Matches(secret))
+	assert.False(t, key.Matches(secret+"0"))
+	// And the hash is not the secret
+	assert.NotContains(t, key.Hash, secret)
+	// And the principal of the key has its ID and scopes
+	assert.Equal(t, &auth.Principal{ID: key.ID, Name: "uploader", Scopes: scopes}, key.Principal())
+}
+
+// TestNewAPIKeyInvalid tests the errors returned for an invalid name or scopes.
+func TestNewAPIKeyInvalid(t *testing.T) {
+	now := time.Now()
+	// When calling NewAPIKey without name
+	_, _, err := entity.NewAPIKey(" ", []string{auth.ScopeFilesUpload}, now)
+	// Then the error is ErrEmptyAPIKeyName
+	assert.Equal(t, voAPIKey.ErrEmptyAPIKeyName, err)
+	// When calling NewAPIKey without scopes
+	_, _, err = entity.NewAPIKey("uploader", nil, now)
+	// Then the error is ErrEmptyAPIKeyScopes
+	assert.Equal(t, voAPIKey.ErrEmptyAPIKeyScopes, err)
+	// When calling NewAPIKey with an unknown scope
+	_, token, err := entity.NewAPIKey("uploader", []string{"files:delete"}, now)
+	// Then the error is ErrUnknownScope and there is no token
+	assert.Equal(t, auth.ErrUnknownScope, err)
+	assert.Empty(t, token)
+}
+
+// TestParseTokenInvalid tests the tokens not being API keys are rejected.
+func TestParseTokenInvalid(t *testing.T) {
+	for _, token := range []string{"", "tsk_", "tsk_id", "tsk__secret", "tsk_id_", "key_id_secret"} {
+		// When parsing the token
+		id, secret, err := entity.ParseToken(token)
+		// Then the error is ErrInvalidAPIKey
+		assert.Equal(t, voAPIKey.ErrInvalidAPIKey, err, token)
+		assert.Empty(t, id)
+		assert.Empty(t, secret)
+	}
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository"
+	voAPIKey "github.com/braejan/go-transactions-summary/internal/valueobject/apikey"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+)
+
+// apiKeysTable is the table of the API keys.
+const apiKeysTable = "api_keys"
+
+// memoryAPIKeyRepository struct implements the APIKeyRepository interface using an
+// in-memory database. Like the api_keys table, the ID of the keys is unique.
+type memoryAPIKeyRepository struct {
+	database *memory.Database
+}
+
+// NewMemoryAPIKeyRepository creates a new instance of repository.APIKeyRepository.
+func NewMemoryAPIKeyRepository(database *memory.Database) (apiKeyRepo repository.APIKeyRepository) {
+	apiKeyRepo = &memoryAPIKeyRepository{
+		database: database,
+	}
+	return
+}
+
+// GetByID returns an API key by its ID.
+func (memoryRepo *memoryAPIKeyRepository) GetByID(ctx context.Context, ID string) (key *entity.APIKey, err error) {
+	err = memoryRepo.database.Read(func(tx *memory.Tx) error {
+		row, ok := tx.Get(apiKeysTable, ID)
+		if !ok {
+			return voAPIKey.ErrAPIKeyNotFound
+		}
+		key = copyKey(row.(entity.APIKey))
+		return nil
+	})
+	return
+}
+
+// List returns every API key in creation order.
+func (memoryRepo *memoryAPIKeyRepository) List(ctx context.Context) (keys []*entity.APIKey, err error) {
+	err = memoryRepo.database.Read(func(tx *memory.Tx) error {
+		tx.Scan(apiKeysTable, func(row interface{}) bool {
+			keys = append(keys, copyKey(row.(entity.APIKey)))
+			return true
+		})
+		return nil
+	})
+	return
+}
+
+// Create creates a new API key.
+func (memoryRepo *memoryAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (err error) {
+	if key == nil {
+		err = voAPIKey.ErrNilAPIKey
+		return
+	}
+	err = memoryRepo.database.Write(func(tx *memory.Tx) error {
+		if _, exists := tx.Get(apiKeysTable, key.ID); exists {
+			return database.NewConflictError(voAPIKey.ErrCreatingAPIKey, database.ErrUniqueViolation, "api_keys_pkey")
+		}
+		return tx.Put(apiKeysTable, key.ID, *copyKey(*key))
+	})
+	return
+}
+
+// Revoke marks the API key as revoked at the given time. Revoking a revoked key keeps its
+// first revocation.
+func (memoryRepo *memoryAPIKeyRepository) Revoke(ctx context.Context, ID string, revokedAt time.Time) (err error) {
+	err = memoryRepo.database.Write(func(tx *memory.Tx) error {
+		row, ok := tx.Get(apiKeysTable, ID)
+		if !ok {
+			return voAPIKey.ErrAPIKeyNotFound
+		}
+		key := row.(entity.APIKey)
+		if !key.IsRevoked() {
+			key.RevokedAt = revokedAt
+		}
+		return tx.Put(apiKeysTable, ID, key)
+	})
+	return
+}
+
+// copyKey returns a copy of the key not sharing its scopes.
+func copyKey(key entity.APIKey) *entity.APIKey {
+	key.Scopes = append([]string(nil), key.Scopes...)
+	return &key
+}
@@ -0,0 +1,18 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository/memory"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository/repositorytest"
+	voMemory "github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+)
+
+// TestAPIKeyRepositoryContract tests the memory repository against the APIKeyRepository
+// contract.
+func TestAPIKeyRepositoryContract(t *testing.T) {
+	repositorytest.RunAPIKeyRepositoryContract(t, func(t *testing.T) repository.APIKeyRepository {
+		return memory.NewMemoryAPIKeyRepository(voMemory.NewDatabase())
+	})
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository"
+	voMetrics "github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+)
+
+// metricsAPIKeyRepository struct implements the APIKeyRepository interface observing the latency of every
+// method of the decorated repository.
+type metricsAPIKeyRepository struct {
+	apiKeyRepo repository.APIKeyRepository
+	latency    *voMetrics.Histogram
+}
+
+// NewMetricsAPIKeyRepository returns the repository decorated with the latency metrics of the registry.
+func NewMetricsAPIKeyRepository(apiKeyRepo repository.APIKeyRepository, registry *voMetrics.Registry) (decorated repository.APIKeyRepository) {
+	decorated = &metricsAPIKeyRepository{
+		apiKeyRepo: apiKeyRepo,
+		latency:    voMetrics.RepositoryLatency(registry),
+	}
+	return
+}
+
+// APIKeyRepository interface implementation
+
+func (metricsRepo *metricsAPIKeyRepository) GetByID(ctx context.Context, ID string) (key *entity.APIKey, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "apikey", "GetByID")
+	return metricsRepo.apiKeyRepo.GetByID(ctx, ID)
+}
+
+func (metricsRepo *metricsAPIKeyRepository) List(ctx context.Context) (keys []*entity.APIKey, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "apikey", "List")
+	return metricsRepo.apiKeyRepo.List(ctx)
+}
+
+func (metricsRepo *metricsAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "apikey", "Create")
+	return metricsRepo.apiKeyRepo.Create(ctx, key)
+}
+
+func (metricsRepo *metricsAPIKeyRepository) Revoke(ctx context.Context, ID string, revokedAt time.Time) (err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "apikey", "Revoke")
+	return metricsRepo.apiKeyRepo.Revoke(ctx, ID, revokedAt)
+}
@@ -0,0 +1,93 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockAPIKeyRepository is a mock implementation of the API key repository.
+type mockAPIKeyRepository struct {
+	mock.Mock
+}
+
+// NewMockAPIKeyRepository returns a new mock API key repository.
+func NewMockAPIKeyRepository() *mockAPIKeyRepository {
+	return &mockAPIKeyRepository{}
+}
+
+// GetByID provides a mock function with given fields: ctx, ID
+func (_m *mockAPIKeyRepository) GetByID(ctx context.Context, ID string) (key *entity.APIKey, err error) {
+	ret := _m.Called(ctx, ID)
+
+	var r0 *entity.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.APIKey); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *mockAPIKeyRepository) List(ctx context.Context) (keys []*entity.APIKey, err error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.APIKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, key
+func (_m *mockAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (err error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.APIKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Revoke provides a mock function with given fields: ctx, ID, revokedAt
+func (_m *mockAPIKeyRepository) Revoke(ctx context.Context, ID string, revokedAt time.Time) (err error) {
+	ret := _m.Called(ctx, ID, revokedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, ID, revokedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+)
+
+// APIKeyRepository interface defines the methods that the API key repository must implement.
+type APIKeyRepository interface {
+	// GetByID returns an API key by its ID.
+	GetByID(ctx context.Context, ID string) (key *entity.APIKey, err error)
+	// List returns every API key, the oldest first.
+	List(ctx context.Context) (keys []*entity.APIKey, err error)
+	// Create creates a new API key.
+	Create(ctx context.Context, key *entity.APIKey) (err error)
+	// Revoke marks the API key as revoked at the given time.
+	Revoke(ctx context.Context, ID string, revokedAt time.Time) (err error)
+}
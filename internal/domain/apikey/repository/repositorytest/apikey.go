@@ -0,0 +1,93 @@
+// Package repositorytest holds the contract of the APIKeyRepository: the keys are listed
+// oldest first and a revoked key keeps the time of its first revocation.
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository"
+	voAPIKey "github.com/braejan/go-transactions-summary/internal/valueobject/apikey"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// APIKeyRepositoryFactory returns an empty API key repository for a test.
+type APIKeyRepositoryFactory func(t *testing.T) (apiKeyRepo repository.APIKeyRepository)
+
+// RunAPIKeyRepositoryContract runs the contract of the APIKeyRepository interface against
+// the repositories of the factory, one empty repository per subtest.
+func RunAPIKeyRepositoryContract(t *testing.T, newRepository APIKeyRepositoryFactory) {
+	// now is truncated so every database stores it exactly.
+	now := time.Now().UTC().Truncate(time.Second)
+	t.Run("NotFound", func(t *testing.T) {
+		// When getting a missing key
+		_, err := newRepository(t).GetByID(context.Background(), "missing")
+		// Then the error returned is ErrAPIKeyNotFound
+		assert.Equal(t, voAPIKey.ErrAPIKeyNotFound, err)
+	})
+	t.Run("CreateAndGet", func(t *testing.T) {
+		// Given an empty repository
+		apiKeyRepo := newRepository(t)
+		key := &entity.APIKey{ID: "0123456789abcdef", Name: "uploader", Hash: "hash",
+			Scopes: []string{auth.ScopeFilesUpload, auth.ScopeTransactionsRead}, CreatedAt: now}
+		// When creating a key
+		err := apiKeyRepo.Create(context.Background(), key)
+		// Then it is returned by its ID, active
+		assert.Nil(t, err)
+		stored, err := apiKeyRepo.GetByID(context.Background(), key.ID)
+		assert.Nil(t, err)
+		if assert.NotNil(t, stored) {
+			assert.Equal(t, key.Name, stored.Name)
+			assert.Equal(t, key.Hash, stored.Hash)
+			assert.Equal(t, key.Scopes, stored.Scopes)
+			assert.True(t, key.CreatedAt.Equal(stored.CreatedAt))
+			assert.False(t, stored.IsRevoked())
+		}
+		// And creating it again fails
+		err = apiKeyRepo.Create(context.Background(), key)
+		assert.ErrorIs(t, err, voAPIKey.ErrCreatingAPIKey)
+		assert.ErrorIs(t, err, database.ErrUniqueViolation)
+	})
+	t.Run("List", func(t *testing.T) {
+		// Given a repository with two keys
+		apiKeyRepo := newRepository(t)
+		assert.Nil(t, apiKeyRepo.Create(context.Background(), &entity.APIKey{ID: "first", Name: "first", Hash: "hash",
+			Scopes: []string{auth.ScopeFilesUpload}, CreatedAt: now}))
+		assert.Nil(t, apiKeyRepo.Create(context.Background(), &entity.APIKey{ID: "second", Name: "second", Hash: "hash",
+			Scopes: []string{auth.ScopeUsersAdmin}, CreatedAt: now.Add(time.Second)}))
+		// When listing the keys
+		keys, err := apiKeyRepo.List(context.Background())
+		// Then they are returned, the oldest first
+		assert.Nil(t, err)
+		if assert.Len(t, keys, 2) {
+			assert.Equal(t, "first", keys[0].ID)
+			assert.Equal(t, "second", keys[1].ID)
+		}
+	})
+	t.Run("Revoke", func(t *testing.T) {
+		// Given a repository with a key
+		apiKeyRepo := newRepository(t)
+		assert.Nil(t, apiKeyRepo.Create(context.Background(), &entity.APIKey{ID: "key", Name: "key", Hash: "hash",
+			Scopes: []string{auth.ScopeFilesUpload}, CreatedAt: now}))
+		// When revoking it twice
+		err := apiKeyRepo.Revoke(context.Background(), "key", now.Add(time.Minute))
+		assert.Nil(t, err)
+		err = apiKeyRepo.Revoke(context.Background(), "key", now.Add(time.Hour))
+		// Then it keeps its first revocation
+		assert.Nil(t, err)
+		stored, _ := apiKeyRepo.GetByID(context.Background(), "key")
+		assert.True(t, now.Add(time.Minute).Equal(stored.RevokedAt))
+		// And revoking a missing key fails
+		assert.Equal(t, voAPIKey.ErrAPIKeyNotFound, apiKeyRepo.Revoke(context.Background(), "missing", now))
+	})
+	t.Run("CreateNil", func(t *testing.T) {
+		// When creating a nil key
+		err := newRepository(t).Create(context.Background(), nil)
+		// Then the error returned is ErrNilAPIKey
+		assert.Equal(t, voAPIKey.ErrNilAPIKey, err)
+	})
+}
@@ -0,0 +1,171 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository"
+	voAPIKey "github.com/braejan/go-transactions-summary/internal/valueobject/apikey"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+)
+
+// sqlAPIKeyRepository is the implementation of the API key repository using a
+// PostgreSQL or a SQLite database.
+type sqlAPIKeyRepository struct {
+	baseDB database.Database
+	// logger logs the database errors.
+	logger logger.Logger
+}
+
+// SQLAPIKeyRepositoryOption configures an optional collaborator of the API key repository.
+type SQLAPIKeyRepositoryOption func(sqlRepo *sqlAPIKeyRepository)
+
+// WithLogger logs the database errors with the logger instead of the default one. A nil
+// logger keeps the default one.
+func WithLogger(log logger.Logger) SQLAPIKeyRepositoryOption {
+	return func(sqlRepo *sqlAPIKeyRepository) {
+		if log != nil {
+			sqlRepo.logger = log
+		}
+	}
+}
+
+// NewSQLAPIKeyRepository creates a new instance of repository.APIKeyRepository.
+func NewSQLAPIKeyRepository(baseDB database.Database, options ...SQLAPIKeyRepositoryOption) (apiKeyRepo repository.APIKeyRepository) {
+	sqlRepo := &sqlAPIKeyRepository{
+		baseDB: baseDB,
+		logger: logger.NewDefaultLogger(),
+	}
+	for _, option := range options {
+		option(sqlRepo)
+	}
+	apiKeyRepo = sqlRepo
+	return
+}
+
+// repository.APIKeyRepository implementation.
+
+// GetByID returns an API key by its ID.
+const (
+	getAPIKeyByID = `SELECT id, name, hash, scopes, created_at, revoked_at FROM api_keys WHERE id = $1`
+)
+
+func (sqlRepo *sqlAPIKeyRepository) GetByID(ctx context.Context, ID string) (key *entity.APIKey, err error) {
+	keys, err := sqlRepo.query(ctx, getAPIKeyByID, ID)
+	if err != nil {
+		return
+	}
+	if len(keys) == 0 {
+		err = voAPIKey.ErrAPIKeyNotFound
+		return
+	}
+	key = keys[0]
+	return
+}
+
+// List returns every API key, the oldest first.
+const (
+	listAPIKeys = `SELECT id, name, hash, scopes, created_at, revoked_at FROM api_keys ORDER BY created_at, id`
+)
+
+func (sqlRepo *sqlAPIKeyRepository) List(ctx context.Context) (keys []*entity.APIKey, err error) {
+	keys, err = sqlRepo.query(ctx, listAPIKeys)
+	return
+}
+
+// Create creates a new API key.
+const (
+	createAPIKey = `INSERT INTO api_keys (id, name, hash, scopes, created_at) VALUES ($1, $2, $3, $4, $5)`
+)
+
+func (sqlRepo *sqlAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (err error) {
+	if key == nil {
+		err = voAPIKey.ErrNilAPIKey
+		return
+	}
+	err = sqlRepo.exec(ctx, voAPIKey.ErrCreatingAPIKey, createAPIKey, key.ID, key.Name, key.Hash,
+		strings.Join(key.Scopes, " "), sqlRepo.baseDB.Dialect().Time(key.CreatedAt))
+	return
+}
+
+// Revoke marks the API key as revoked at the given time.
+const (
+	revokeAPIKey = `UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+)
+
+func (sqlRepo *sqlAPIKeyRepository) Revoke(ctx context.Context, ID string, revokedAt time.Time) (err error) {
+	if _, err = sqlRepo.GetByID(ctx, ID); err != nil {
+		return
+	}
+	err = sqlRepo.exec(ctx, voAPIKey.ErrRevokingAPIKey, revokeAPIKey, ID, sqlRepo.baseDB.Dialect().Time(revokedAt))
+	return
+}
+
+// query returns the API keys of the query.
+func (sqlRepo *sqlAPIKeyRepository) query(ctx context.Context, query string, args ...interface{}) (keys []*entity.APIKey, err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	rows, err := database.Query(ctx, sqlRepo.baseDB, dbTx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx, sqlRepo.logger).Error("error querying api keys", "error", err)
+		err = database.Wrap(voAPIKey.ErrQueryingAPIKey, err)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		key := &entity.APIKey{}
+		scopes := ""
+		revokedAt := sql.NullTime{}
+		if err = rows.Scan(&key.ID, &key.Name, &key.Hash, &scopes, &key.CreatedAt, &revokedAt); err != nil {
+			logger.FromContext(ctx, sqlRepo.logger).Error("error scanning api key", "error", err)
+			keys = nil
+			err = database.Wrap(voAPIKey.ErrScanningAPIKey, err)
+			return
+		}
+		key.Scopes = strings.Fields(scopes)
+		key.RevokedAt = revokedAt.Time
+		keys = append(keys, key)
+	}
+	if rows.Err() != nil {
+		keys = nil
+		err = database.Wrap(voAPIKey.ErrQueryingAPIKey, rows.Err())
+	}
+	return
+}
+
+// exec runs the statement in a transaction, returning errStatement when it fails.
+func (sqlRepo *sqlAPIKeyRepository) exec(ctx context.Context, errStatement error, statement string, args ...interface{}) (err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	if _, err = database.Exec(ctx, sqlRepo.baseDB, dbTx, statement, args...); err != nil {
+		logger.FromContext(ctx, sqlRepo.logger).Error("error writing api key in database", "error", err)
+		err = database.Wrap(errStatement, err)
+		return
+	}
+	err = sqlRepo.baseDB.Commit(dbTx)
+	return
+}
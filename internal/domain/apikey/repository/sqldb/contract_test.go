@@ -0,0 +1,20 @@
+package sqldb_test
+
+import (
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository/repositorytest"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository/sqldb"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database/databasetest"
+)
+
+// TestAPIKeyRepositoryContract tests the repository against the APIKeyRepository contract
+// on every dialect.
+func TestAPIKeyRepositoryContract(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, newDatabase databasetest.Factory) {
+		repositorytest.RunAPIKeyRepositoryContract(t, func(t *testing.T) repository.APIKeyRepository {
+			return sqldb.NewSQLAPIKeyRepository(newDatabase(t))
+		})
+	})
+}
@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository"
+	voTracing "github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+)
+
+// tracingAPIKeyRepository struct implements the APIKeyRepository interface starting a span for every
+// method of the decorated repository.
+type tracingAPIKeyRepository struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+// NewTracingAPIKeyRepository returns the repository decorated with the spans of its methods, children
+// of the span carried by their context.
+func NewTracingAPIKeyRepository(apiKeyRepo repository.APIKeyRepository) (decorated repository.APIKeyRepository) {
+	decorated = &tracingAPIKeyRepository{
+		apiKeyRepo: apiKeyRepo,
+	}
+	return
+}
+
+// APIKeyRepository interface implementation
+
+func (tracingRepo *tracingAPIKeyRepository) GetByID(ctx context.Context, ID string) (key *entity.APIKey, err error) {
+	ctx, span := voTracing.Start(ctx, "APIKeyRepository.GetByID")
+	defer span.EndWithError(&err)
+	return tracingRepo.apiKeyRepo.GetByID(ctx, ID)
+}
+
+func (tracingRepo *tracingAPIKeyRepository) List(ctx context.Context) (keys []*entity.APIKey, err error) {
+	ctx, span := voTracing.Start(ctx, "APIKeyRepository.List")
+	defer span.EndWithError(&err)
+	return tracingRepo.apiKeyRepo.List(ctx)
+}
+
+func (tracingRepo *tracingAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (err error) {
+	ctx, span := voTracing.Start(ctx, "APIKeyRepository.Create")
+	defer span.EndWithError(&err)
+	return tracingRepo.apiKeyRepo.Create(ctx, key)
+}
+
+func (tracingRepo *tracingAPIKeyRepository) Revoke(ctx context.Context, ID string, revokedAt time.Time) (err error) {
+	ctx, span := voTracing.Start(ctx, "APIKeyRepository.Revoke")
+	defer span.EndWithError(&err)
+	return tracingRepo.apiKeyRepo.Revoke(ctx, ID, revokedAt)
+}
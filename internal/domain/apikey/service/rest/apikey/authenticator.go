@@ -0,0 +1,65 @@
+package apikey
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/usecases"
+	voAPIKey "github.com/braejan/go-transactions-summary/internal/valueobject/apikey"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+)
+
+// APIKeyHeader is the header carrying the API key of a request.
+const APIKeyHeader = "X-API-Key"
+
+// authenticator struct implements the auth.Authenticator interface with the API keys.
+type authenticator struct {
+	apiKeyUseCases usecases.APIKeyUseCases
+}
+
+// NewAuthenticator returns the authenticator of the API keys of the X-API-Key header, or
+// of the Authorization header with the Bearer scheme when the token is an API key.
+func NewAuthenticator(apiKeyUseCases usecases.APIKeyUseCases) (newAuthenticator auth.Authenticator, err error) {
+	if apiKeyUseCases == nil {
+		err = voAPIKey.ErrNilAPIKeyUseCases
+		return
+	}
+	newAuthenticator = &authenticator{
+		apiKeyUseCases: apiKeyUseCases,
+	}
+	return
+}
+
+// Token returns the API key of the request, empty when it carries none.
+func Token(request *http.Request) string {
+	if token := strings.TrimSpace(request.Header.Get(APIKeyHeader)); token != "" {
+		return token
+	}
+	if token := auth.BearerToken(request); strings.HasPrefix(token, entity.TokenPrefix) {
+		return token
+	}
+	return ""
+}
+
+// auth.Authenticator interface implementation
+
+func (authenticator *authenticator) Authenticate(request *http.Request) (principal *auth.Principal, err error) {
+	token := Token(request)
+	if token == "" {
+		err = auth.ErrNoCredentials
+		return
+	}
+	key, err := authenticator.apiKeyUseCases.Authenticate(request.Context(), token)
+	if errors.Is(err, voAPIKey.ErrInvalidAPIKey) || errors.Is(err, voAPIKey.ErrAPIKeyRevoked) {
+		err = fmt.Errorf("%w: %v", auth.ErrUnauthenticated, err)
+		return
+	}
+	if err != nil {
+		return
+	}
+	principal = key.Principal()
+	return
+}
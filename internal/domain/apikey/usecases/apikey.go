@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository"
+	voAPIKey "github.com/braejan/go-transactions-summary/internal/valueobject/apikey"
+)
+
+// apiKeyUseCases struct implements the APIKeyUseCases interface.
+type apiKeyUseCases struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+// NewAPIKeyUseCases returns a new apiKeyUseCases instance.
+func NewAPIKeyUseCases(apiKeyRepo repository.APIKeyRepository) (useCases APIKeyUseCases, err error) {
+	if apiKeyRepo == nil {
+		err = voAPIKey.ErrNilAPIKeyRepository
+		return
+	}
+	useCases = &apiKeyUseCases{
+		apiKeyRepo: apiKeyRepo,
+	}
+	return
+}
+
+// Create implements the APIKeyUseCases interface method.
+func (useCases *apiKeyUseCases) Create(ctx context.Context, name string, scopes []string) (key entity.APIKey, token string, err error) {
+	newKey, token, err := entity.NewAPIKey(name, scopes, time.Now().UTC().Truncate(time.Second))
+	if err != nil {
+		return
+	}
+	if err = useCases.apiKeyRepo.Create(ctx, newKey); err != nil {
+		token = ""
+		return
+	}
+	key = *newKey
+	return
+}
+
+// Authenticate implements the APIKeyUseCases interface method. A missing key and a wrong
+// secret return the same error, so the IDs of the keys cannot be probed.
+func (useCases *apiKeyUseCases) Authenticate(ctx context.Context, token string) (key entity.APIKey, err error) {
+	id, secret, err := entity.ParseToken(token)
+	if err != nil {
+		return
+	}
+	stored, err := useCases.apiKeyRepo.GetByID(ctx, id)
+	if errors.Is(err, voAPIKey.ErrAPIKeyNotFound) || (err == nil && !stored.Matches(secret)) {
+		err = voAPIKey.ErrInvalidAPIKey
+		return
+	}
+	if err != nil {
+		return
+	}
+	if stored.IsRevoked() {
+		err = voAPIKey.ErrAPIKeyRevoked
+		return
+	}
+	key = *stored
+	return
+}
+
+// List implements the APIKeyUseCases interface method.
+func (useCases *apiKeyUseCases) List(ctx context.Context) (keys []entity.APIKey, err error) {
+	stored, err := useCases.apiKeyRepo.List(ctx)
+	if err != nil {
+		return
+	}
+	for _, key := range stored {
+		keys = append(keys, *key)
+	}
+	return
+}
+
+// Revoke implements the APIKeyUseCases interface method.
+func (useCases *apiKeyUseCases) Revoke(ctx context.Context, ID string) (err error) {
+	err = useCases.apiKeyRepo.Revoke(ctx, ID, time.Now().UTC().Truncate(time.Second))
+	return
+}
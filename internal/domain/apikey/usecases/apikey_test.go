@@ -0,0 +1,83 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository/memory"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/repository/mock"
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/usecases"
+	voAPIKey "github.com/braejan/go-transactions-summary/internal/valueobject/apikey"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	voMemory "github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+// TestNewAPIKeyUseCasesWithNilRepository tests the NewAPIKeyUseCases function with a nil repository.
+func TestNewAPIKeyUseCasesWithNilRepository(t *testing.T) {
+	// When calling NewAPIKeyUseCases with a nil repository
+	useCases, err := usecases.NewAPIKeyUseCases(nil)
+	// Then the error returned is ErrNilAPIKeyRepository
+	assert.Nil(t, useCases)
+	assert.Equal(t, voAPIKey.ErrNilAPIKeyRepository, err)
+}
+
+// TestAuthenticate tests a created key authenticates with its token until it is revoked.
+func TestAuthenticate(t *testing.T) {
+	// Given the use cases with an in-memory repository
+	ctx := context.Background()
+	useCases, err := usecases.NewAPIKeyUseCases(memory.NewMemoryAPIKeyRepository(voMemory.NewDatabase()))
+	assert.Nil(t, err)
+	// And a created key
+	created, token, err := useCases.Create(ctx, "uploader", []string{auth.ScopeFilesUpload})
+	assert.Nil(t, err)
+	// When authenticating its token
+	key, err := useCases.Authenticate(ctx, token)
+	// Then the key is returned
+	assert.Nil(t, err)
+	assert.Equal(t, created, key)
+	// And the key is listed
+	keys, err := useCases.List(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{created.ID}, []string{keys[0].ID})
+	// When authenticating a wrong secret, an unknown ID and a malformed token
+	for _, wrong := range []string{token + "0", "tsk_0000000000000000_secret", "secret"} {
+		_, err = useCases.Authenticate(ctx, wrong)
+		// Then the error is ErrInvalidAPIKey
+		assert.Equal(t, voAPIKey.ErrInvalidAPIKey, err, wrong)
+	}
+	// When revoking the key
+	assert.Nil(t, useCases.Revoke(ctx, created.ID))
+	_, err = useCases.Authenticate(ctx, token)
+	// Then the error is ErrAPIKeyRevoked
+	assert.Equal(t, voAPIKey.ErrAPIKeyRevoked, err)
+}
+
+// TestCreateFailingRepository tests no token is returned when the key is not saved.
+func TestCreateFailingRepository(t *testing.T) {
+	// Given a repository failing to create keys
+	apiKeyRepo := mock.NewMockAPIKeyRepository()
+	apiKeyRepo.On("Create", testifyMock.Anything, testifyMock.Anything).Return(voAPIKey.ErrCreatingAPIKey)
+	useCases, err := usecases.NewAPIKeyUseCases(apiKeyRepo)
+	assert.Nil(t, err)
+	// When creating a key
+	_, token, err := useCases.Create(context.Background(), "uploader", []string{auth.ScopeFilesUpload})
+	// Then the error is returned without token
+	assert.Equal(t, voAPIKey.ErrCreatingAPIKey, err)
+	assert.Empty(t, token)
+}
+
+// TestAuthenticateFailingRepository tests the errors of the repository are returned as is.
+func TestAuthenticateFailingRepository(t *testing.T) {
+	// Given a repository failing to get keys
+	apiKeyRepo := mock.NewMockAPIKeyRepository()
+	apiKeyRepo.On("GetByID", testifyMock.Anything, "0123456789abcdef").Return(nil, errors.New("connection refused"))
+	useCases, err := usecases.NewAPIKeyUseCases(apiKeyRepo)
+	assert.Nil(t, err)
+	// When authenticating a token
+	_, err = useCases.Authenticate(context.Background(), "tsk_0123456789abcdef_secret")
+	// Then the error of the repository is returned
+	assert.EqualError(t, err, "connection refused")
+}
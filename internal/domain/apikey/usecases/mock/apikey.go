@@ -0,0 +1,48 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockAPIKeyUseCases is a mock of APIKeyUseCases interface.
+type mockAPIKeyUseCases struct {
+	mock.Mock
+}
+
+// NewMockAPIKeyUseCases returns a new mock instance.
+func NewMockAPIKeyUseCases() *mockAPIKeyUseCases {
+	return &mockAPIKeyUseCases{}
+}
+
+// Create mocks base method.
+func (m *mockAPIKeyUseCases) Create(ctx context.Context, name string, scopes []string) (entity.APIKey, string, error) {
+	ret := m.Called(ctx, name, scopes)
+	return ret.Get(0).(entity.APIKey), ret.String(1), ret.Error(2)
+}
+
+// Authenticate mocks base method.
+func (m *mockAPIKeyUseCases) Authenticate(ctx context.Context, token string) (entity.APIKey, error) {
+	ret := m.Called(ctx, token)
+	return ret.Get(0).(entity.APIKey), ret.Error(1)
+}
+
+// List mocks base method.
+func (m *mockAPIKeyUseCases) List(ctx context.Context) ([]entity.APIKey, error) {
+	ret := m.Called(ctx)
+
+	var r0 []entity.APIKey
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]entity.APIKey)
+	}
+
+	return r0, ret.Error(1)
+}
+
+// Revoke mocks base method.
+func (m *mockAPIKeyUseCases) Revoke(ctx context.Context, ID string) error {
+	ret := m.Called(ctx, ID)
+	return ret.Error(0)
+}
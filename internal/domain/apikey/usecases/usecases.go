@@ -0,0 +1,21 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/apikey/entity"
+)
+
+// APIKeyUseCases interface defines the use cases managing and checking the API keys.
+type APIKeyUseCases interface {
+	// Create creates an API key for the client with the scopes. The token is only returned
+	// here, the key keeps the hash of its secret.
+	Create(ctx context.Context, name string, scopes []string) (key entity.APIKey, token string, err error)
+	// Authenticate returns the key of the token. It returns ErrInvalidAPIKey when the token
+	// is not the token of a key, and ErrAPIKeyRevoked when the key is revoked.
+	Authenticate(ctx context.Context, token string) (key entity.APIKey, err error)
+	// List returns every API key, the oldest first.
+	List(ctx context.Context) (keys []entity.APIKey, err error)
+	// Revoke revokes an API key, its token is rejected from now on.
+	Revoke(ctx context.Context, ID string) (err error)
+}
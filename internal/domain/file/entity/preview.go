@@ -0,0 +1,54 @@
+package entity
+
+// UserPreview struct defines what processing a file would do for one of its users.
+type UserPreview struct {
+	// UserID is the ID of the user.
+	UserID int64 `json:"user_id"`
+	// NewUser is true when the user would be created.
+	NewUser bool `json:"new_user"`
+	// NewAccount is true when the account of the user would be created.
+	NewAccount bool `json:"new_account"`
+	// Transactions is the number of transactions that would be created.
+	Transactions int `json:"transactions"`
+	// TotalCredits is the sum of the credit transactions.
+	TotalCredits float64 `json:"total_credits"`
+	// TotalDebits is the sum of the debit transactions.
+	TotalDebits float64 `json:"total_debits"`
+}
+
+// ValidationPreview struct defines the result of validating a file without processing it.
+type ValidationPreview struct {
+	// Valid is true when the file can be processed.
+	Valid bool `json:"valid"`
+	// Report lists the invalid lines of the file.
+	Report *ValidationReport `json:"report"`
+	// NewUsers are the IDs of the users that would be created.
+	NewUsers []int64 `json:"new_users"`
+	// NewAccounts are the IDs of the users whose account would be created.
+	NewAccounts []int64 `json:"new_accounts"`
+	// Users lists the users of the valid lines, in order of appearance.
+	Users []UserPreview `json:"users"`
+}
+
+// NewValidationPreview returns a new ValidationPreview instance of the report, without users.
+func NewValidationPreview(report *ValidationReport) (preview *ValidationPreview) {
+	preview = &ValidationPreview{
+		Valid:       report.IsValid(),
+		Report:      report,
+		NewUsers:    []int64{},
+		NewAccounts: []int64{},
+		Users:       []UserPreview{},
+	}
+	return
+}
+
+// AddUser lists the user and the records that would be created for it.
+func (preview *ValidationPreview) AddUser(user UserPreview) {
+	if user.NewUser {
+		preview.NewUsers = append(preview.NewUsers, user.UserID)
+	}
+	if user.NewAccount {
+		preview.NewAccounts = append(preview.NewAccounts, user.UserID)
+	}
+	preview.Users = append(preview.Users, user)
+}
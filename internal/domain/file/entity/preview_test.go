@@ -0,0 +1,24 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidationPreviewAddUser tests the new users and accounts are listed.
+func TestValidationPreviewAddUser(t *testing.T) {
+	// Given a preview of a valid report
+	preview := entity.NewValidationPreview(entity.NewValidationReport("txns.csv"))
+	assert.True(t, preview.Valid)
+	// When adding an existing user, a user without account and a new user
+	preview.AddUser(entity.UserPreview{UserID: 1})
+	preview.AddUser(entity.UserPreview{UserID: 2, NewAccount: true})
+	preview.AddUser(entity.UserPreview{UserID: 3, NewUser: true, NewAccount: true})
+	// Then every user is listed
+	assert.Len(t, preview.Users, 3)
+	// And only the new users and accounts are listed as new
+	assert.Equal(t, []int64{3}, preview.NewUsers)
+	assert.Equal(t, []int64{2, 3}, preview.NewAccounts)
+}
@@ -0,0 +1,52 @@
+package entity
+
+// LineProblem struct defines why a line of a file is invalid.
+type LineProblem struct {
+	// Line is the 1-based line of the file, the header is the line 1.
+	Line int `json:"line"`
+	// Column is the name of the invalid column, empty when the whole line is invalid.
+	Column string `json:"column,omitempty"`
+	// Value is the invalid value.
+	Value string `json:"value,omitempty"`
+	// Error describes the problem.
+	Error string `json:"error"`
+}
+
+// ValidationReport struct defines the result of checking the structure of a file.
+type ValidationReport struct {
+	// Name is the name of the file.
+	Name string `json:"name"`
+	// Lines is the number of lines read after the header.
+	Lines int `json:"lines"`
+	// InvalidLines is the number of invalid lines, the header included.
+	InvalidLines int `json:"invalid_lines"`
+	// Problems lists the problems found, up to the limit of the validation.
+	Problems []LineProblem `json:"problems,omitempty"`
+	// Truncated is true when there were more problems than the ones listed.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// NewValidationReport returns a new empty ValidationReport instance.
+func NewValidationReport(name string) (report *ValidationReport) {
+	report = &ValidationReport{
+		Name:     name,
+		Problems: []LineProblem{},
+	}
+	return
+}
+
+// IsValid returns true when no invalid line was found.
+func (report *ValidationReport) IsValid() bool {
+	return report.InvalidLines == 0
+}
+
+// AddProblem counts the line as invalid and lists the problem while there are fewer
+// than maxProblems listed.
+func (report *ValidationReport) AddProblem(problem LineProblem, maxProblems int) {
+	report.InvalidLines++
+	if len(report.Problems) >= maxProblems {
+		report.Truncated = true
+		return
+	}
+	report.Problems = append(report.Problems, problem)
+}
@@ -0,0 +1,26 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidationReportAddProblem tests the problems are listed up to the limit.
+func TestValidationReportAddProblem(t *testing.T) {
+	// Given a new report
+	report := entity.NewValidationReport("txns.csv")
+	assert.True(t, report.IsValid())
+	// When adding three problems with a limit of two
+	for line := 2; line <= 4; line++ {
+		report.AddProblem(entity.LineProblem{Line: line, Error: "file line is invalid"}, 2)
+	}
+	// Then the three lines are counted as invalid
+	assert.False(t, report.IsValid())
+	assert.Equal(t, 3, report.InvalidLines)
+	// And only two problems are listed
+	assert.Len(t, report.Problems, 2)
+	assert.Equal(t, 3, report.Problems[1].Line)
+	assert.True(t, report.Truncated)
+}
@@ -1,62 +1,286 @@
 package file
 
 import (
-	"log"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"time"
 
 	"github.com/braejan/go-transactions-summary/internal/domain/file/entity"
 	"github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	fileUtil "github.com/braejan/go-transactions-summary/internal/domain/file/util"
+	processingEntity "github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	ucProcessing "github.com/braejan/go-transactions-summary/internal/domain/processing/usecases"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
 	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
-	"github.com/google/uuid"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
 	"github.com/gorilla/mux"
 )
 
 type FileHandler struct {
 	fileUsecases usecases.FileUseCases
+	// store keeps a copy of the uploaded files, nil disables it.
+	store storage.ObjectStore
+	// logger logs the failed requests with their request ID.
+	logger logger.Logger
+	// processing records the processing of every upload, nil disables it.
+	processing ucProcessing.ProcessingUseCases
+	// bucket is the bucket of the processing records of the uploads.
+	bucket string
+	// quota limits the size of the uploads and the files of the clients per day, nil
+	// disables the limits.
+	quota *quota.QuotaConfiguration
 }
 
-func NewFileHandler(fileUsecases usecases.FileUseCases) (fileHandler *FileHandler, err error) {
+// multipartOverhead is the room left in the request body for the multipart form around the file.
+const multipartOverhead = 1 << 20
+
+// FileHandlerOption configures an optional collaborator of the file handler.
+type FileHandlerOption func(handler *FileHandler) (err error)
+
+// WithObjectStore stores every uploaded file as "uploads/<hash>.csv" before processing it.
+func WithObjectStore(store storage.ObjectStore) FileHandlerOption {
+	return func(handler *FileHandler) (err error) {
+		if store == nil {
+			err = storage.ErrNilObjectStore
+			return
+		}
+		handler.store = store
+		return
+	}
+}
+
+// WithLogger logs the failed requests with the logger instead of the default one.
+func WithLogger(log logger.Logger) FileHandlerOption {
+	return func(handler *FileHandler) (err error) {
+		if log == nil {
+			err = logger.ErrNilLogger
+			return
+		}
+		handler.logger = log
+		return
+	}
+}
+
+// WithProcessing records the processing of every upload as "uploads/<hash>.csv" in the
+// bucket, with the authenticated client as its uploader. Uploading the same file again
+// processes it again.
+func WithProcessing(processingUseCases ucProcessing.ProcessingUseCases, bucket string) FileHandlerOption {
+	return func(handler *FileHandler) (err error) {
+		if processingUseCases == nil {
+			err = voProcessing.ErrNilProcessingUseCases
+			return
+		}
+		handler.processing = processingUseCases
+		handler.bucket = bucket
+		return
+	}
+}
+
+// WithQuotas rejects the files larger than the max file size and the uploads of the clients
+// beyond their max files per day. The files of the day are reserved with the processing
+// records, so WithProcessing enforces the max files per day of concurrent uploads too.
+func WithQuotas(configuration *quota.QuotaConfiguration) FileHandlerOption {
+	return func(handler *FileHandler) (err error) {
+		if configuration == nil {
+			err = quota.ErrNilQuotaConfiguration
+			return
+		}
+		handler.quota = configuration
+		return
+	}
+}
+
+func NewFileHandler(fileUsecases usecases.FileUseCases, options ...FileHandlerOption) (fileHandler *FileHandler, err error) {
 	if fileUsecases == nil {
 		err = voFile.ErrNilFileUseCases
 		return
 	}
-	fileHandler = &FileHandler{
+	handler := &FileHandler{
 		fileUsecases: fileUsecases,
+		logger:       logger.NewDefaultLogger(),
+	}
+	for _, option := range options {
+		if err = option(handler); err != nil {
+			return
+		}
 	}
+	fileHandler = handler
 	return
 }
 
 func (handler *FileHandler) RegisterRoutes(router *mux.Router) {
-	router.HandleFunc("/loadfile", handler.LoadFile).Methods("POST")
+	router.HandleFunc("/loadfile", apierror.Handler(handler.logger, handler.LoadFile)).Methods("POST")
+	router.HandleFunc("/loadfile/validate", apierror.Handler(handler.logger, handler.ValidateFile)).Methods("POST")
 }
 
-func (handler *FileHandler) LoadFile(writer http.ResponseWriter, request *http.Request) {
-
+func (handler *FileHandler) LoadFile(writer http.ResponseWriter, request *http.Request) (err error) {
+	handler.limitBody(writer, request)
 	// Get file from request
-	file, _, err := request.FormFile("file")
+	file, header, err := request.FormFile("file")
 	if err != nil {
-		log.Printf("Error getting file from request: %v", err)
-		http.Error(writer, "Error getting file from request", http.StatusBadRequest)
+		return formFileError(err)
+	}
+	if handler.isTooLarge(header) {
+		return quota.ErrFileTooLarge
+	}
+	if err = handler.checkDailyQuota(writer, request); err != nil {
 		return
 	}
 	fileName, err := request.FormValue("filename"), request.ParseMultipartForm(32<<20)
 	if err != nil {
-		log.Printf("Error getting file name from request: %v", err)
-		http.Error(writer, "Error getting file from request", http.StatusBadRequest)
+		return formFileError(err)
+	}
+	// The content hash identifies the upload, so uploading the same file again
+	// does not enqueue its summary emails twice.
+	hash, err := fileUtil.HashFile(file)
+	if err != nil {
+		return fmt.Errorf("hashing file %s: %w", fileName, err)
+	}
+	// The hash is the ID of the file in the lines of the request.
+	ctx := logger.WithFileID(request.Context(), hash)
+	log := logger.FromContext(ctx, handler.logger).With("file", fileName)
+	txFile := entity.NewTxFile(fileName, "uploaded", hash, 0)
+	var record *processingEntity.ProcessingRecord
+	if handler.processing != nil {
+		record, err = handler.processing.Start(ctx, handler.bucket, uploadKey(hash), hash, auth.ClientID(ctx), handler.maxFilesPerDay())
+		if errors.Is(err, quota.ErrDailyQuotaExceeded) {
+			// A concurrent upload took the last file of the day after the quota was checked.
+			handler.checkDailyQuota(writer, request)
+			return
+		}
+		if err != nil {
+			return fmt.Errorf("recording the processing of file %s: %w", fileName, err)
+		}
+	}
+	if handler.store != nil {
+		if err = handler.storeFile(hash, file); err != nil {
+			err = fmt.Errorf("storing file %s: %w", fileName, err)
+			handler.finishRecord(ctx, log, record, 0, err)
+			return
+		}
+	}
+	transactions, err := handler.fileUsecases.ProcessMultipartFile(ctx, *txFile, file)
+	handler.finishRecord(ctx, log, record, transactions, err)
+	if err != nil {
 		return
 	}
-	log.Println("File name: ", fileName)
-	txFile := entity.NewTxFile(fileName, "uploaded", uuid.New().String(), 0)
+	writer.WriteHeader(http.StatusCreated)
+	return
+}
+
+// ValidateFile checks the uploaded file and responds with the preview of what loading it
+// would create. Nothing is stored.
+func (handler *FileHandler) ValidateFile(writer http.ResponseWriter, request *http.Request) (err error) {
+	handler.limitBody(writer, request)
+	file, header, err := request.FormFile("file")
+	if err != nil {
+		return formFileError(err)
+	}
+	defer file.Close()
+	if handler.isTooLarge(header) {
+		return quota.ErrFileTooLarge
+	}
+	fileName := request.FormValue("filename")
+	if fileName == "" {
+		fileName = header.Filename
+	}
+	txFile := entity.NewTxFile(fileName, "uploaded", "", 0)
+	preview, err := handler.fileUsecases.ValidateFile(request.Context(), *txFile, file)
 	if err != nil {
-		log.Printf("Error converting multipart file to os file: %v", err)
-		http.Error(writer, "Error converting multipart file to os file", http.StatusInternalServerError)
 		return
 	}
-	err = handler.fileUsecases.ProcessMultipartFile(*txFile, file)
+	writer.Header().Set("Content-Type", "application/json")
+	if errWrite := json.NewEncoder(writer).Encode(preview); errWrite != nil {
+		logger.FromContext(request.Context(), handler.logger).Error("error writing the validation preview", "file", fileName, "error", errWrite)
+	}
+	return
+}
+
+// limitBody limits the body of the request to the max file size and the multipart form
+// around it, nothing without max file size.
+func (handler *FileHandler) limitBody(writer http.ResponseWriter, request *http.Request) {
+	if handler.quota == nil || handler.quota.MaxFileSize <= 0 {
+		return
+	}
+	request.Body = http.MaxBytesReader(writer, request.Body, handler.quota.MaxFileSize+multipartOverhead)
+}
+
+// isTooLarge returns whether the uploaded file exceeds the max file size.
+func (handler *FileHandler) isTooLarge(header *multipart.FileHeader) bool {
+	return handler.quota != nil && handler.quota.MaxFileSize > 0 && header.Size > handler.quota.MaxFileSize
+}
+
+// formFileError returns the domain error of a request whose file cannot be read.
+func formFileError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return fmt.Errorf("%w: body exceeds %d bytes", quota.ErrFileTooLarge, maxBytesErr.Limit)
+	case errors.Is(err, http.ErrMissingFile):
+		return voFile.ErrFileNotInRequest
+	}
+	return fmt.Errorf("%w: %v", apierror.ErrInvalidRequest, err)
+}
+
+// checkDailyQuota sets the quota headers of the authenticated client and returns
+// quota.ErrDailyQuotaExceeded when it cannot upload more files today.
+func (handler *FileHandler) checkDailyQuota(writer http.ResponseWriter, request *http.Request) (err error) {
+	if handler.quota == nil {
+		return
+	}
+	usage, err := handler.fileUsecases.CheckDailyQuota(request.Context(), auth.ClientID(request.Context()))
+	for name, value := range usage.Headers(time.Now()) {
+		writer.Header().Set(name, value)
+	}
+	return
+}
+
+// finishRecord saves the result of the processing of the upload with the number of stored
+// transactions, nothing without record. A processed file has no invalid line, so its lines
+// are its transactions.
+func (handler *FileHandler) finishRecord(ctx context.Context, log logger.Logger, record *processingEntity.ProcessingRecord, transactions int, errProcess error) {
+	if record == nil {
+		return
+	}
+	if errProcess == nil {
+		record.Lines = transactions
+		record.Succeed(transactions, time.Now())
+	} else {
+		record.Fail(errProcess, time.Now())
+	}
+	if err := handler.processing.Finish(ctx, record); err != nil {
+		log.Error("error recording the result of the file", "error", err)
+	}
+}
+
+// maxFilesPerDay returns the max files per day of the clients, zero without quotas.
+func (handler *FileHandler) maxFilesPerDay() int {
+	if handler.quota == nil {
+		return 0
+	}
+	return handler.quota.MaxFilesPerDay
+}
+
+// uploadKey returns the key of an upload by its content hash.
+func uploadKey(hash string) string {
+	return "uploads/" + hash + ".csv"
+}
+
+// storeFile stores the uploaded file by its content hash and rewinds it.
+func (handler *FileHandler) storeFile(hash string, file io.ReadSeeker) (err error) {
+	err = handler.store.Put(uploadKey(hash), file)
 	if err != nil {
-		log.Printf("Error processing file: %v", err)
-		http.Error(writer, "Error processing file", http.StatusInternalServerError)
 		return
 	}
-	writer.WriteHeader(http.StatusCreated)
+	_, err = file.Seek(0, io.SeekStart)
+	return
 }
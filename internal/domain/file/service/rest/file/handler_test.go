@@ -2,17 +2,31 @@ package file_test
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/braejan/go-transactions-summary/internal/domain/file/entity"
 	"github.com/braejan/go-transactions-summary/internal/domain/file/service/rest/file"
 	fileMock "github.com/braejan/go-transactions-summary/internal/domain/file/usecases/mock"
-	"github.com/gorilla/mux"
+	processingEntity "github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	processingMock "github.com/braejan/go-transactions-summary/internal/domain/processing/usecases/mock"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror/envelope"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/openapi/openapitest"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -44,7 +58,7 @@ func TestLoadFile_Fail_FormFile(t *testing.T) {
 	// And a HTTP response recorder
 	responseRecorder := httptest.NewRecorder()
 	// And a registered route
-	router := mux.NewRouter()
+	router := openapitest.NewRouter(t)
 	fileHandler.RegisterRoutes(router)
 	// When send the request to /loadfile
 	router.ServeHTTP(responseRecorder, request)
@@ -56,7 +70,7 @@ func TestLoadFile_Fail_FormFile(t *testing.T) {
 func TestLoadFile_Fail_ProcessFile(t *testing.T) {
 	// Given a valid FileHandler
 	mockFileUseCases := fileMock.NewMockFileUseCases()
-	mockFileUseCases.On("ProcessMultipartFile", mock.Anything, mock.Anything).Return(errors.New("error processing file"))
+	mockFileUseCases.On("ProcessMultipartFile", mock.Anything, mock.Anything, mock.Anything).Return(0, errors.New("error processing file"))
 	fileHandler, err := file.NewFileHandler(mockFileUseCases)
 	assert.Nil(t, err)
 	// And a valid file
@@ -82,7 +96,7 @@ func TestLoadFile_Fail_ProcessFile(t *testing.T) {
 	// And a HTTP response recorder
 	responseRecorder := httptest.NewRecorder()
 	// And a registered route
-	router := mux.NewRouter()
+	router := openapitest.NewRouter(t)
 	fileHandler.RegisterRoutes(router)
 	// When send the request to /loadfile
 	router.ServeHTTP(responseRecorder, request)
@@ -94,7 +108,7 @@ func TestLoadFile_Fail_ProcessFile(t *testing.T) {
 func TestLoadFile_Success(t *testing.T) {
 	// Given a valid FileHandler
 	mockFileUseCases := fileMock.NewMockFileUseCases()
-	mockFileUseCases.On("ProcessMultipartFile", mock.Anything, mock.Anything).Return(nil)
+	mockFileUseCases.On("ProcessMultipartFile", mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
 	fileHandler, err := file.NewFileHandler(mockFileUseCases)
 	assert.Nil(t, err)
 	// And a valid file
@@ -120,10 +134,360 @@ func TestLoadFile_Success(t *testing.T) {
 	// And a HTTP response recorder
 	responseRecorder := httptest.NewRecorder()
 	// And a registered route
-	router := mux.NewRouter()
+	router := openapitest.NewRouter(t)
 	fileHandler.RegisterRoutes(router)
 	// When send the request to /loadfile
 	router.ServeHTTP(responseRecorder, request)
 	// Then the returned status is BadRequest
 	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
 }
+
+// TestNewFileHandlerWithNilObjectStore tests the NewFileHandler function with a nil object store.
+func TestNewFileHandlerWithNilObjectStore(t *testing.T) {
+	// Given a valid FileUseCases
+	mockFileUseCases := fileMock.NewMockFileUseCases()
+	// When NewFileHandler is called with a nil object store
+	fileHandler, err := file.NewFileHandler(mockFileUseCases, file.WithObjectStore(nil))
+	// Then the returned error is ErrNilObjectStore
+	assert.Nil(t, fileHandler)
+	assert.Equal(t, storage.ErrNilObjectStore, err)
+}
+
+// TestLoadFile_StoresUpload tests the uploaded file is stored by its hash before being processed.
+func TestLoadFile_StoresUpload(t *testing.T) {
+	// Given a local object store
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	// And a valid FileHandler with the object store
+	mockFileUseCases := fileMock.NewMockFileUseCases()
+	var processed entity.TxFile
+	var processedCtx context.Context
+	mockFileUseCases.On("ProcessMultipartFile", mock.Anything, mock.Anything, mock.Anything).Return(0, nil).Run(func(args mock.Arguments) {
+		processedCtx = args.Get(0).(context.Context)
+		processed = args.Get(1).(entity.TxFile)
+	})
+	fileHandler, err := file.NewFileHandler(mockFileUseCases, file.WithObjectStore(store))
+	assert.Nil(t, err)
+	// And a multipart body with a valid file
+	currentDir, _ := os.Getwd()
+	fileBytes, err := os.ReadFile(fmt.Sprintf("%s/%s", currentDir, "test/files/txns_simple.csv"))
+	assert.Nil(t, err)
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "txns_simple.csv")
+	assert.Nil(t, err)
+	_, err = part.Write(fileBytes)
+	assert.Nil(t, err)
+	err = writer.Close()
+	assert.Nil(t, err)
+	// And a POST request
+	request, err := http.NewRequest("POST", "/loadfile", body)
+	assert.Nil(t, err)
+	request.Header.Add("Content-Type", writer.FormDataContentType())
+	responseRecorder := httptest.NewRecorder()
+	router := openapitest.NewRouter(t)
+	fileHandler.RegisterRoutes(router)
+	// When send the request to /loadfile
+	router.ServeHTTP(responseRecorder, request)
+	// Then the returned status is Created
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	// And the file is processed with its hash as file ID
+	assert.Equal(t, processed.Hash, logger.FileID(processedCtx))
+	// And the upload is stored under its hash
+	stored, err := store.Get("uploads/" + processed.Hash + ".csv")
+	assert.Nil(t, err)
+	content, _ := io.ReadAll(stored)
+	stored.Close()
+	assert.Equal(t, fileBytes, content)
+}
+
+// getLoadRequest returns a POST request to /loadfile with the content as file.
+func getLoadRequest(t *testing.T, content []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "txns_simple.csv")
+	assert.Nil(t, err)
+	_, err = part.Write(content)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+	request, err := http.NewRequest("POST", "/loadfile", body)
+	assert.Nil(t, err)
+	request.Header.Add("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+// TestNewFileHandlerWithNilProcessing tests the NewFileHandler function with nil processing use cases.
+func TestNewFileHandlerWithNilProcessing(t *testing.T) {
+	// Given a valid FileUseCases
+	mockFileUseCases := fileMock.NewMockFileUseCases()
+	// When NewFileHandler is called with nil processing use cases
+	fileHandler, err := file.NewFileHandler(mockFileUseCases, file.WithProcessing(nil, "bucket"))
+	// Then the returned error is ErrNilProcessingUseCases
+	assert.Nil(t, fileHandler)
+	assert.Equal(t, voProcessing.ErrNilProcessingUseCases, err)
+}
+
+// TestLoadFile_RecordsUploader tests the processing of the upload is recorded with its uploader.
+func TestLoadFile_RecordsUploader(t *testing.T) {
+	// Given a FileUseCases storing 3 transactions of the file
+	mockFileUseCases := fileMock.NewMockFileUseCases()
+	var processed entity.TxFile
+	mockFileUseCases.On("ProcessMultipartFile", mock.Anything, mock.Anything, mock.Anything).Return(3, nil).Run(func(args mock.Arguments) {
+		processed = args.Get(1).(entity.TxFile)
+	})
+	// And a ProcessingUseCases starting and finishing the record
+	record, err := processingEntity.NewProcessingRecord("bucket", "key", "etag", time.Now())
+	assert.Nil(t, err)
+	mockProcessingUseCases := processingMock.NewMockProcessingUseCases()
+	mockProcessingUseCases.On("Start", mock.Anything, "bucket", mock.Anything, mock.Anything, mock.Anything, 0).Return(record, nil)
+	mockProcessingUseCases.On("Finish", mock.Anything, record).Return(nil)
+	fileHandler, err := file.NewFileHandler(mockFileUseCases, file.WithProcessing(mockProcessingUseCases, "bucket"))
+	assert.Nil(t, err)
+	// And a request of an authenticated client
+	content, err := os.ReadFile("test/files/txns_simple.csv")
+	assert.Nil(t, err)
+	request := getLoadRequest(t, content)
+	request = request.WithContext(auth.WithPrincipal(request.Context(), &auth.Principal{ID: "0123456789abcdef"}))
+	responseRecorder := httptest.NewRecorder()
+	router := openapitest.NewRouter(t)
+	fileHandler.RegisterRoutes(router)
+	// When send the request to /loadfile
+	router.ServeHTTP(responseRecorder, request)
+	// Then the returned status is Created
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	// And the record is started with the key of the upload and its uploader
+	mockProcessingUseCases.AssertCalled(t, "Start", mock.Anything, "bucket", "uploads/"+processed.Hash+".csv", processed.Hash, "0123456789abcdef", 0)
+	// And the record is finished with the stored transactions
+	assert.Equal(t, processingEntity.ProcessingStatusSucceeded, record.Status)
+	assert.Equal(t, 3, record.Transactions)
+	assert.Equal(t, 3, record.Lines)
+	// And the file is parsed once
+	mockFileUseCases.AssertNotCalled(t, "CheckStructure", mock.Anything, mock.Anything)
+}
+
+// TestLoadFile_Reupload tests an upload already processed is processed again.
+func TestLoadFile_Reupload(t *testing.T) {
+	// Given a FileUseCases processing the file
+	mockFileUseCases := fileMock.NewMockFileUseCases()
+	mockFileUseCases.On("ProcessMultipartFile", mock.Anything, mock.Anything, mock.Anything).Return(3, nil)
+	// And a ProcessingUseCases starting a new record for the upload
+	record, err := processingEntity.NewProcessingRecord("bucket", "key", "etag", time.Now())
+	assert.Nil(t, err)
+	mockProcessingUseCases := processingMock.NewMockProcessingUseCases()
+	mockProcessingUseCases.On("Start", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(record, nil)
+	mockProcessingUseCases.On("Finish", mock.Anything, record).Return(nil)
+	fileHandler, err := file.NewFileHandler(mockFileUseCases, file.WithProcessing(mockProcessingUseCases, "bucket"))
+	assert.Nil(t, err)
+	// And a request with a file
+	content, err := os.ReadFile("test/files/txns_simple.csv")
+	assert.Nil(t, err)
+	router := openapitest.NewRouter(t)
+	fileHandler.RegisterRoutes(router)
+	// When send the request to /loadfile twice
+	for i := 0; i < 2; i++ {
+		responseRecorder := httptest.NewRecorder()
+		router.ServeHTTP(responseRecorder, getLoadRequest(t, content))
+		// Then the returned status is Created
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	}
+	// And the file is processed twice
+	mockFileUseCases.AssertNumberOfCalls(t, "ProcessMultipartFile", 2)
+	mockProcessingUseCases.AssertNotCalled(t, "Begin", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestLoadFile_FailedRecord tests the record of a failed upload has its error.
+func TestLoadFile_FailedRecord(t *testing.T) {
+	// Given a FileUseCases failing to process the file
+	mockFileUseCases := fileMock.NewMockFileUseCases()
+	mockFileUseCases.On("ProcessMultipartFile", mock.Anything, mock.Anything, mock.Anything).Return(0, errors.New("error processing file"))
+	// And a ProcessingUseCases starting and finishing the record
+	record, err := processingEntity.NewProcessingRecord("bucket", "key", "etag", time.Now())
+	assert.Nil(t, err)
+	mockProcessingUseCases := processingMock.NewMockProcessingUseCases()
+	mockProcessingUseCases.On("Start", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "", mock.Anything).Return(record, nil)
+	mockProcessingUseCases.On("Finish", mock.Anything, record).Return(nil)
+	fileHandler, err := file.NewFileHandler(mockFileUseCases, file.WithProcessing(mockProcessingUseCases, "bucket"))
+	assert.Nil(t, err)
+	responseRecorder := httptest.NewRecorder()
+	router := openapitest.NewRouter(t)
+	fileHandler.RegisterRoutes(router)
+	// When send the request to /loadfile
+	router.ServeHTTP(responseRecorder, getLoadRequest(t, []byte("Id,Date,Transaction\n")))
+	// Then the returned status is InternalServerError
+	assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+	// And the record is failed with the error, without transactions
+	assert.Equal(t, processingEntity.ProcessingStatusFailed, record.Status)
+	assert.Equal(t, "error processing file", record.Error)
+	assert.Zero(t, record.Transactions)
+}
+
+// TestNewFileHandlerWithNilQuotas tests the NewFileHandler function with a nil quota configuration.
+func TestNewFileHandlerWithNilQuotas(t *testing.T) {
+	// When NewFileHandler is called with a nil quota configuration
+	fileHandler, err := file.NewFileHandler(fileMock.NewMockFileUseCases(), file.WithQuotas(nil))
+	// Then the returned error is ErrNilQuotaConfiguration
+	assert.Nil(t, fileHandler)
+	assert.Equal(t, quota.ErrNilQuotaConfiguration, err)
+}
+
+// TestLoadFile_Quotas tests the uploads beyond the quotas of the client are rejected.
+func TestLoadFile_Quotas(t *testing.T) {
+	content, err := os.ReadFile("test/files/txns_simple.csv")
+	assert.Nil(t, err)
+	// The multipart form of the large content exceeds the limit of the body.
+	large := bytes.Repeat([]byte("0,7/5,+60.5\n"), 200000)
+	resetAt := quota.DayStart(time.Now()).Add(24 * time.Hour)
+	cases := map[string]struct {
+		content     []byte
+		maxFileSize int64
+		usage       quota.Usage
+		quotaErr    error
+		processErr  error
+		status      int
+		remaining   string
+	}{
+		"within the quotas":   {content, int64(len(content)), quota.Usage{Limit: 3, Used: 1, ResetAt: resetAt}, nil, nil, http.StatusCreated, "2"},
+		"too large":           {content, int64(len(content)) - 1, quota.Usage{}, nil, nil, http.StatusRequestEntityTooLarge, ""},
+		"body too large":      {large, 1, quota.Usage{}, nil, nil, http.StatusRequestEntityTooLarge, ""},
+		"daily quota":         {content, 0, quota.Usage{Limit: 3, Used: 3, ResetAt: resetAt}, quota.ErrDailyQuotaExceeded, nil, http.StatusTooManyRequests, "0"},
+		"too many rows":       {content, 0, quota.Usage{}, nil, quota.ErrTooManyRows, http.StatusRequestEntityTooLarge, ""},
+		"quota check failure": {content, 0, quota.Usage{}, errors.New("connection refused"), nil, http.StatusInternalServerError, ""},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Given a FileUseCases with the usage of the client
+			mockFileUseCases := fileMock.NewMockFileUseCases()
+			mockFileUseCases.On("CheckDailyQuota", mock.Anything, "0123456789abcdef").Return(tc.usage, tc.quotaErr)
+			mockFileUseCases.On("ProcessMultipartFile", mock.Anything, mock.Anything, mock.Anything).Return(0, tc.processErr)
+			// And a FileHandler with the max file size
+			fileHandler, err := file.NewFileHandler(mockFileUseCases, file.WithQuotas(&quota.QuotaConfiguration{MaxFileSize: tc.maxFileSize}))
+			assert.Nil(t, err)
+			// And a request of an authenticated client
+			request := getLoadRequest(t, tc.content)
+			request = request.WithContext(auth.WithPrincipal(request.Context(), &auth.Principal{ID: "0123456789abcdef"}))
+			responseRecorder := httptest.NewRecorder()
+			router := openapitest.NewRouter(t)
+			fileHandler.RegisterRoutes(router)
+			// When send the request to /loadfile
+			router.ServeHTTP(responseRecorder, request)
+			// Then the upload is rejected beyond the quotas
+			assert.Equal(t, tc.status, responseRecorder.Code)
+			// And the remaining files of the client are returned
+			assert.Equal(t, tc.remaining, responseRecorder.Header().Get(quota.RemainingHeader))
+		})
+	}
+}
+
+// TestLoadFile_ConcurrentDailyQuota tests an upload is rejected when a concurrent upload took the last file of the day.
+func TestLoadFile_ConcurrentDailyQuota(t *testing.T) {
+	// Given a FileUseCases with one file left for the client, taken before the upload is recorded
+	resetAt := quota.DayStart(time.Now()).Add(24 * time.Hour)
+	mockFileUseCases := fileMock.NewMockFileUseCases()
+	mockFileUseCases.On("CheckDailyQuota", mock.Anything, "0123456789abcdef").Return(quota.Usage{Limit: 3, Used: 2, ResetAt: resetAt}, nil).Once()
+	mockFileUseCases.On("CheckDailyQuota", mock.Anything, "0123456789abcdef").Return(quota.Usage{Limit: 3, Used: 3, ResetAt: resetAt}, quota.ErrDailyQuotaExceeded)
+	// And a ProcessingUseCases without files left for the client
+	mockProcessingUseCases := processingMock.NewMockProcessingUseCases()
+	mockProcessingUseCases.On("Start", mock.Anything, "bucket", mock.Anything, mock.Anything, "0123456789abcdef", 3).Return(nil, quota.ErrDailyQuotaExceeded)
+	fileHandler, err := file.NewFileHandler(mockFileUseCases, file.WithProcessing(mockProcessingUseCases, "bucket"),
+		file.WithQuotas(&quota.QuotaConfiguration{MaxFilesPerDay: 3}))
+	assert.Nil(t, err)
+	// And a request of an authenticated client
+	content, err := os.ReadFile("test/files/txns_simple.csv")
+	assert.Nil(t, err)
+	request := getLoadRequest(t, content)
+	request = request.WithContext(auth.WithPrincipal(request.Context(), &auth.Principal{ID: "0123456789abcdef"}))
+	responseRecorder := httptest.NewRecorder()
+	router := openapitest.NewRouter(t)
+	fileHandler.RegisterRoutes(router)
+	// When send the request to /loadfile
+	router.ServeHTTP(responseRecorder, request)
+	// Then the returned status is TooManyRequests without files left
+	assert.Equal(t, http.StatusTooManyRequests, responseRecorder.Code)
+	assert.Equal(t, "0", responseRecorder.Header().Get(quota.RemainingHeader))
+	// And the file is not processed
+	mockFileUseCases.AssertNotCalled(t, "ProcessMultipartFile", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// getValidateRequest returns a POST request to /loadfile/validate with the content as file.
+func getValidateRequest(t *testing.T, content []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "txns_simple.csv")
+	assert.Nil(t, err)
+	_, err = part.Write(content)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+	request, err := http.NewRequest("POST", "/loadfile/validate", body)
+	assert.Nil(t, err)
+	request.Header.Add("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+// TestValidateFile_Success tests the preview of the file is returned as JSON.
+func TestValidateFile_Success(t *testing.T) {
+	// Given a valid FileHandler previewing a new user
+	mockFileUseCases := fileMock.NewMockFileUseCases()
+	preview := entity.NewValidationPreview(entity.NewValidationReport("txns_simple.csv"))
+	preview.AddUser(entity.UserPreview{UserID: 1, NewUser: true, NewAccount: true, Transactions: 1, TotalCredits: 60.5})
+	var validated entity.TxFile
+	mockFileUseCases.On("ValidateFile", mock.Anything, mock.Anything, mock.Anything).Return(preview, nil).Run(func(args mock.Arguments) {
+		validated = args.Get(1).(entity.TxFile)
+	})
+	fileHandler, err := file.NewFileHandler(mockFileUseCases)
+	assert.Nil(t, err)
+	router := openapitest.NewRouter(t)
+	fileHandler.RegisterRoutes(router)
+	responseRecorder := httptest.NewRecorder()
+	// When send a file to /loadfile/validate
+	router.ServeHTTP(responseRecorder, getValidateRequest(t, []byte("Id,Date,Transaction\n1,7/5,+60.5\n")))
+	// Then the returned status is OK
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "application/json", responseRecorder.Header().Get("Content-Type"))
+	// And the body is the preview
+	response := entity.ValidationPreview{}
+	assert.Nil(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.Equal(t, preview.NewUsers, response.NewUsers)
+	assert.Equal(t, preview.Users, response.Users)
+	assert.True(t, response.Valid)
+	// And the file is validated with its name
+	assert.Equal(t, "txns_simple.csv", validated.Name)
+	// And nothing is processed
+	mockFileUseCases.AssertNotCalled(t, "ProcessMultipartFile", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestValidateFile_Fail_EmptyFile tests the validation of an empty file is a bad request.
+func TestValidateFile_Fail_EmptyFile(t *testing.T) {
+	// Given a FileHandler failing to validate an empty file
+	mockFileUseCases := fileMock.NewMockFileUseCases()
+	mockFileUseCases.On("ValidateFile", mock.Anything, mock.Anything, mock.Anything).Return(nil, voFile.ErrFileIsEmpty)
+	fileHandler, err := file.NewFileHandler(mockFileUseCases)
+	assert.Nil(t, err)
+	router := openapitest.NewRouter(t)
+	fileHandler.RegisterRoutes(router)
+	responseRecorder := httptest.NewRecorder()
+	// When send an empty file to /loadfile/validate
+	router.ServeHTTP(responseRecorder, getValidateRequest(t, []byte{}))
+	// Then the returned status is BadRequest
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	// And the error is written as an envelope with its code
+	var body envelope.Envelope
+	assert.Nil(t, json.NewDecoder(responseRecorder.Body).Decode(&body))
+	assert.Equal(t, "FILE_EMPTY", body.Code)
+	assert.Equal(t, voFile.ErrFileIsEmpty.Error(), body.Message)
+}
+
+// TestValidateFile_Fail_ResolvingUsers tests the validation fails when the users cannot be looked up.
+func TestValidateFile_Fail_ResolvingUsers(t *testing.T) {
+	// Given a FileHandler failing to resolve the users
+	mockFileUseCases := fileMock.NewMockFileUseCases()
+	mockFileUseCases.On("ValidateFile", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("database is down"))
+	fileHandler, err := file.NewFileHandler(mockFileUseCases)
+	assert.Nil(t, err)
+	router := openapitest.NewRouter(t)
+	fileHandler.RegisterRoutes(router)
+	responseRecorder := httptest.NewRecorder()
+	// When send a file to /loadfile/validate
+	router.ServeHTTP(responseRecorder, getValidateRequest(t, []byte("Id,Date,Transaction\n1,7/5,+60.5\n")))
+	// Then the returned status is InternalServerError
+	assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+}
@@ -1,34 +1,89 @@
 package usecases
 
 import (
+	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"mime/multipart"
 	"os"
-	"regexp"
-	"strconv"
 	"time"
 
 	acEntity "github.com/braejan/go-transactions-summary/internal/domain/account/entity"
 	acUsecases "github.com/braejan/go-transactions-summary/internal/domain/account/usecases"
 	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	fileUtil "github.com/braejan/go-transactions-summary/internal/domain/file/util"
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	ntUsecases "github.com/braejan/go-transactions-summary/internal/domain/notification/usecases"
+	summaryEntity "github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
 	txEntity "github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
 	txUsecases "github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases"
 	txUtil "github.com/braejan/go-transactions-summary/internal/domain/transaction/util"
 	userUsecases "github.com/braejan/go-transactions-summary/internal/domain/user/usecases"
 	voAccount "github.com/braejan/go-transactions-summary/internal/valueobject/account"
 	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
 	voTransaction "github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
 	voUser "github.com/braejan/go-transactions-summary/internal/valueobject/user"
 )
 
 // localFileUseCases struct implements the FileUseCases interface.
 type localFileUseCases struct {
+	StructureUseCases
 	userUseCases        userUsecases.UserUseCases
 	accountUseCases     acUsecases.AccountUseCases
 	transactionUseCases txUsecases.TransactionUseCases
+	// notificationUseCases builds the summary emails, nil disables them.
+	notificationUseCases ntUsecases.NotificationUseCases
+	// logger logs the progress of the files.
+	logger logger.Logger
+	// rowSampling logs one of every rowSampling rows of a file at debug level.
+	rowSampling int
+	// quota limits the files, nil disables the limits.
+	quota *quota.QuotaConfiguration
+	// usageCounter counts the files of the uploaders for their daily quota.
+	usageCounter quota.UsageCounter
+}
+
+// FileUseCasesOption configures an optional collaborator of the file use cases.
+type FileUseCasesOption func(useCases *localFileUseCases) (err error)
+
+// WithNotifications enqueues a summary email for every user of a processed file.
+// The emails are stored in the outbox together with the transactions.
+func WithNotifications(notificationUseCases ntUsecases.NotificationUseCases) FileUseCasesOption {
+	return func(useCases *localFileUseCases) (err error) {
+		if notificationUseCases == nil {
+			err = voNotification.ErrNilNotificationUseCases
+			return
+		}
+		useCases.notificationUseCases = notificationUseCases
+		return
+	}
+}
+
+// WithLogger logs the progress of the files with the logger instead of the default one.
+func WithLogger(log logger.Logger) FileUseCasesOption {
+	return func(useCases *localFileUseCases) (err error) {
+		if log == nil {
+			err = logger.ErrNilLogger
+			return
+		}
+		useCases.logger = log
+		return
+	}
+}
+
+// WithRowSampling logs one of every rowSampling rows of a file at debug level, 0 disables
+// the row lines.
+func WithRowSampling(rowSampling int) FileUseCasesOption {
+	return func(useCases *localFileUseCases) (err error) {
+		useCases.rowSampling = rowSampling
+		return
+	}
 }
 
 // NewFileUseCases returns a new localFileUseCases instance.
@@ -36,6 +91,7 @@ func NewFileUseCases(
 	userUseCases userUsecases.UserUseCases,
 	accountUseCases acUsecases.AccountUseCases,
 	transactionUseCases txUsecases.TransactionUseCases,
+	options ...FileUseCasesOption,
 ) (useCases FileUseCases, err error) {
 	if userUseCases == nil {
 		err = voUser.ErrNilUserUseCases
@@ -49,16 +105,27 @@ func NewFileUseCases(
 		err = voTransaction.ErrNilTransactionUseCases
 		return
 	}
-	useCases = &localFileUseCases{
+	localUseCases := &localFileUseCases{
+		StructureUseCases:   NewStructureUseCases(),
 		userUseCases:        userUseCases,
 		accountUseCases:     accountUseCases,
 		transactionUseCases: transactionUseCases,
+		logger:              logger.NewDefaultLogger(),
+		rowSampling:         logger.NewDefaultLoggerConfiguration().RowSampling,
+	}
+	for _, option := range options {
+		if err = option(localUseCases); err != nil {
+			return
+		}
 	}
+	useCases = localUseCases
 	return
 }
 
 // ReadFile reads the file from the given path.
-func (useCases *localFileUseCases) ReadAndProcessFile(file fileEntity.TxFile, isS3 bool) (err error) {
+func (useCases *localFileUseCases) ReadAndProcessFile(ctx context.Context, file fileEntity.TxFile, isS3 bool) (err error) {
+	ctx, span := tracing.Start(ctx, "FileUseCases.ReadAndProcessFile", "file.name", file.Name, "file.id", file.Hash)
+	defer span.EndWithError(&err)
 	err = useCases.CheckFile(file, isS3)
 	if err != nil {
 		return
@@ -67,14 +134,9 @@ func (useCases *localFileUseCases) ReadAndProcessFile(file fileEntity.TxFile, is
 	osFile, _ := useCases.openOSFile(file.Path)
 	defer osFile.Close()
 	// Create a new reader.
-	reader := csv.NewReader(osFile)
+	reader := useCases.newReader(osFile)
 	// Read the file registers.
-	txsAux, err := useCases.readFileRegisters(reader, file.Name)
-	if err != nil {
-		return
-	}
-	txs := txUtil.ArrayTxMemoryToArrayValue(txsAux)
-	err = useCases.createTransactions(txs)
+	_, err = useCases.ingest(logger.WithFileID(ctx, file.Hash), reader, file)
 	return
 }
 
@@ -91,35 +153,33 @@ func (useCases *localFileUseCases) CheckFile(file fileEntity.TxFile, isS3 bool)
 
 }
 
-// ProcessFile processes the file.
-func (useCases *localFileUseCases) ProcessFile(file fileEntity.TxFile, osFile *os.File) (err error) {
-	log.Println("Processing file:", file.Name)
+// ProcessFile processes the file and returns the number of stored transactions.
+func (useCases *localFileUseCases) ProcessFile(ctx context.Context, file fileEntity.TxFile, osFile *os.File) (transactions int, err error) {
+	ctx, span := tracing.Start(ctx, "FileUseCases.ProcessFile", "file.name", file.Name, "file.id", file.Hash)
+	defer span.EndWithError(&err)
 	// Create a new reader.
-	reader := csv.NewReader(osFile)
+	reader := useCases.newReader(osFile)
 	// Read the file registers.
-	log.Println("Reading file:", file.Name)
-	txsAux, err := useCases.readFileRegisters(reader, file.Name)
-	if err != nil {
-		return
-	}
-	txs := txUtil.ArrayTxMemoryToArrayValue(txsAux)
-	err = useCases.createTransactions(txs)
+	transactions, err = useCases.ingest(ctx, reader, file)
 	return
 }
 
-// ProcessMultipartFile processes the file.
-func (useCases *localFileUseCases) ProcessMultipartFile(txFile fileEntity.TxFile, file multipart.File) (err error) {
-	log.Println("Processing multipart file:", txFile.Name)
+// ProcessMultipartFile processes the file and returns the number of stored transactions.
+func (useCases *localFileUseCases) ProcessMultipartFile(ctx context.Context, txFile fileEntity.TxFile, file multipart.File) (transactions int, err error) {
+	ctx, span := tracing.Start(ctx, "FileUseCases.ProcessMultipartFile", "file.name", txFile.Name, "file.id", txFile.Hash)
+	defer span.EndWithError(&err)
 	// Create a new reader.
-	reader := csv.NewReader(file)
+	reader := useCases.newReader(file)
 	// Read the file registers.
-	log.Println("Reading file:", txFile.Name)
-	txsAux, err := useCases.readFileRegisters(reader, txFile.Name)
-	if err != nil {
-		return
-	}
-	txs := txUtil.ArrayTxMemoryToArrayValue(txsAux)
-	err = useCases.createTransactions(txs)
+	transactions, err = useCases.ingest(ctx, reader, txFile)
+	return
+}
+
+// newReader returns the CSV reader of the file limited to the max file size. The number of
+// columns of every line is checked with the line, so a wrong count is a *voFile.LineError.
+func (useCases *localFileUseCases) newReader(file io.Reader) (reader *csv.Reader) {
+	reader = csv.NewReader(useCases.limitReader(file))
+	reader.FieldsPerRecord = -1
 	return
 }
 
@@ -138,45 +198,102 @@ func (useCases *localFileUseCases) openOSFile(path string) (file *os.File, err e
 	return
 }
 
-func (useCases *localFileUseCases) readFileRegisters(reader *csv.Reader, fileName string) (txs []*txEntity.Transaction, err error) {
-	//Read the first line and ignore it.
-	// TODO: Check if is a valid header.
-	_, err = reader.Read()
+// ingest stores the transactions of the file and their summary emails in a single batch,
+// and returns the number of stored transactions. The lines are logged with the request and
+// the file IDs carried by the context.
+func (useCases *localFileUseCases) ingest(ctx context.Context, reader *csv.Reader, txFile fileEntity.TxFile) (transactions int, err error) {
+	log := logger.FromContext(ctx, useCases.logger).With("file", txFile.Name)
+	log.Info("processing file")
+	defer func() {
+		if err != nil {
+			log.Error("file could not be processed", "error", err)
+		}
+	}()
+	txsAux, owners, err := useCases.readFileRegisters(ctx, log, reader, txFile.Name)
+	if err != nil {
+		return
+	}
+	messages, err := useCases.summaryMessages(ctx, txFile, txsAux, owners)
+	if err != nil {
+		return
+	}
+	err = useCases.transactionUseCases.CreateBatch(ctx, txUtil.ArrayTxMemoryToArrayValue(txsAux), messages)
+	if err != nil {
+		return
+	}
+	transactions = len(txsAux)
+	log.Info("file processed", "transactions", transactions, "notifications", len(messages))
+	return
+}
+
+// readFileRegisters returns the transactions of the file and the ID of the user owning each one.
+// The parse is traced with the lookups of the users and the accounts of its lines.
+func (useCases *localFileUseCases) readFileRegisters(ctx context.Context, log logger.Logger, reader *csv.Reader, fileName string) (txs []*txEntity.Transaction, owners []int64, err error) {
+	ctx, span := tracing.Start(ctx, "file.parse", "file.name", fileName)
+	defer span.EndWithError(&err)
+	// Read the header and check its columns.
+	header, err := reader.Read()
 	if err != nil {
 		if err == io.EOF {
 			err = voFile.ErrFileIsEmpty
 			return
 		}
+		if errors.Is(err, quota.ErrFileTooLarge) {
+			err = quota.ErrFileTooLarge
+			return
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			err = lineParseError(parseErr)
+			return
+		}
 		err = voFile.ErrFileCouldNotBeRead
 		return
 	}
+	if err = fileUtil.CheckHeader(header); err != nil {
+		return
+	}
 	lineCounter := 1
 	for {
-		log.Println("Reading line:", lineCounter)
 		lineCounter++
 		record, errRead := reader.Read()
 		if errRead != nil && errRead == io.EOF {
 			break
-		} else if err != nil {
+		} else if errRead != nil {
 			txs = nil
 			err = errRead
+			var parseErr *csv.ParseError
+			if errors.As(errRead, &parseErr) {
+				err = lineParseError(parseErr)
+			}
+			if errors.Is(errRead, quota.ErrFileTooLarge) {
+				err = quota.ErrFileTooLarge
+			}
+			break
+		}
+		if useCases.tooManyRows(lineCounter - 1) {
+			txs = nil
+			err = quota.ErrTooManyRows
 			break
 		}
+		if log.Enabled(logger.LevelDebug) && logger.Sampled(lineCounter-1, useCases.rowSampling) {
+			log.Debug("reading row", "line", lineCounter)
+		}
 		// Validate the line.
-		userID, txDate, amount, errCheck := useCases.checkValidLine(record)
+		userID, txDate, amount, errCheck := useCases.checkValidLine(lineCounter, record)
 		if errCheck != nil {
 			txs = nil
 			err = errCheck
 			break
 		}
-		errCheck = useCases.checkUser(userID)
+		errCheck = useCases.checkUser(ctx, userID)
 		if errCheck != nil {
 			txs = nil
 			err = errCheck
 			break
 		}
 		// Check if the account exists.
-		acc, errAcc := useCases.checkAccountByUserID(userID)
+		acc, errAcc := useCases.checkAccountByUserID(ctx, userID)
 		if errAcc != nil {
 			txs = nil
 			err = errAcc
@@ -190,56 +307,44 @@ func (useCases *localFileUseCases) readFileRegisters(reader *csv.Reader, fileNam
 			break
 		}
 		txs = append(txs, tx)
+		owners = append(owners, userID)
 	}
-	if txs == nil {
-		log.Printf("Error reading the file %s at line %d", fileName, lineCounter)
+	if err != nil {
+		log.Warn("file has an invalid line", "line", lineCounter, "error", err)
+		span.SetAttributes("file.invalid_line", lineCounter)
 	} else {
-		log.Printf("File %s readed successfully", fileName)
+		log.Debug("file read", "lines", lineCounter-1)
+		span.SetAttributes("file.transactions", len(txs))
 	}
 	return
 }
 
-func (useCases *localFileUseCases) checkValidLine(record []string) (id int64, txDate time.Time, amount float64, err error) {
-	if len(record) != 3 {
-		err = voFile.ErrFileLineIsInvalid
-		return
-	}
-	// Validate the position 0 as a valid int64.
-	id, err = strconv.ParseInt(record[0], 10, 64)
-	if err != nil {
-		err = voFile.ErrFileLineIsInvalid
-		return
-	}
-	// Validate the position 1 as a valid date format "1/2".
-	txDate, err = time.Parse("1/2", record[1])
-	if err != nil {
-		err = voFile.ErrFileLineIsInvalid
-		return
-	}
-	regex := `^[-|+]+[0-9]+(\.[0-9]*)?$`
-	match, _ := regexp.MatchString(regex, record[2])
-	if !match {
-		err = voFile.ErrFileLineIsInvalid
-		return
-	}
-	// Validate the position 2 as a valid float64.
-	amount, err = strconv.ParseFloat(record[2], 64)
-	if err != nil {
-		err = voFile.ErrFileLineIsInvalid
+// lineParseError returns the error of a line that is not valid CSV, with its line.
+func lineParseError(parseErr *csv.ParseError) *voFile.LineError {
+	return &voFile.LineError{Line: parseErr.StartLine, Err: voFile.ErrFileLineCouldNotBeParsed, Cause: parseErr.Err}
+}
+
+// checkValidLine parses the record of the line, the error is a *voFile.LineError with the
+// line, the invalid column and the cause.
+func (useCases *localFileUseCases) checkValidLine(line int, record []string) (id int64, txDate time.Time, amount float64, err error) {
+	id, txDate, amount, err = fileUtil.ParseRecord(record)
+	var lineErr *voFile.LineError
+	if errors.As(err, &lineErr) {
+		lineErr.Line = line
 	}
 	return
 }
 
-func (useCases *localFileUseCases) checkUser(ID int64) (err error) {
+func (useCases *localFileUseCases) checkUser(ctx context.Context, ID int64) (err error) {
 	// Check if the user exists.
-	_, err = useCases.userUseCases.GetByID(ID)
-	if err != nil && err == voUser.ErrUserNotFound {
+	_, err = useCases.userUseCases.GetByID(ctx, ID)
+	if errors.Is(err, voUser.ErrUserNotFound) {
 		// Create a new user.
-		err = useCases.userUseCases.Create(ID, fmt.Sprintf("User Name %d", ID), fmt.Sprintf("user.email%d@amazingemail.com", ID))
+		err = useCases.userUseCases.Create(ctx, ID, fmt.Sprintf("User Name %d", ID), fmt.Sprintf("user.email%d@amazingemail.com", ID))
 		if err != nil {
 			return
 		}
-		_, err = useCases.userUseCases.GetByID(ID)
+		_, err = useCases.userUseCases.GetByID(ctx, ID)
 	} else {
 		return
 	}
@@ -247,18 +352,17 @@ func (useCases *localFileUseCases) checkUser(ID int64) (err error) {
 
 }
 
-func (useCases *localFileUseCases) checkAccountByUserID(userID int64) (account *acEntity.Account, err error) {
-	log.Println("Checking account for user:", userID)
+func (useCases *localFileUseCases) checkAccountByUserID(ctx context.Context, userID int64) (account *acEntity.Account, err error) {
 	// Check if the account exists.
-	accAux, err := useCases.accountUseCases.GetByUserID(userID)
-	if err != nil && err == voAccount.ErrAccountNotFound {
+	accAux, err := useCases.accountUseCases.GetByUserID(ctx, userID)
+	if errors.Is(err, voAccount.ErrAccountNotFound) {
 		// Create a new account.
-		err = useCases.accountUseCases.Create(userID)
+		err = useCases.accountUseCases.Create(ctx, userID)
 		if err != nil {
 			account = nil
 			return
 		}
-		accAux, err = useCases.accountUseCases.GetByUserID(userID)
+		accAux, err = useCases.accountUseCases.GetByUserID(ctx, userID)
 	} else if err != nil {
 		account = nil
 		return
@@ -267,14 +371,46 @@ func (useCases *localFileUseCases) checkAccountByUserID(userID int64) (account *
 	return
 }
 
-func (useCases *localFileUseCases) createTransactions(txs []txEntity.Transaction) (err error) {
-	// TODO: If process fails at some point, the file should processed partially.
-	// Insert the transactions.
-	for _, tx := range txs {
-		err = useCases.transactionUseCases.Create(tx)
-		if err != nil {
+// summaryMessages returns the summary email of every user in the file, in order of appearance.
+// The delivery ID depends on the file hash and the user, so processing the same file
+// again does not enqueue the emails twice.
+func (useCases *localFileUseCases) summaryMessages(ctx context.Context, txFile fileEntity.TxFile, txs []*txEntity.Transaction, owners []int64) (messages []notificationEntity.OutboxMessage, err error) {
+	if useCases.notificationUseCases == nil {
+		return
+	}
+	fileKey := txFile.Hash
+	if fileKey == "" {
+		fileKey = txFile.Name
+	}
+	userIDs := []int64{}
+	userTxs := map[int64][]txEntity.Transaction{}
+	for i, tx := range txs {
+		if _, ok := userTxs[owners[i]]; !ok {
+			userIDs = append(userIDs, owners[i])
+		}
+		userTxs[owners[i]] = append(userTxs[owners[i]], *tx)
+	}
+	now := time.Now()
+	for _, userID := range userIDs {
+		user, errUser := useCases.userUseCases.GetByID(ctx, userID)
+		if errUser != nil {
+			messages = nil
+			err = errUser
+			return
+		}
+		message, errMessage := useCases.notificationUseCases.SummaryMessage(user, summaryEntity.NewSummary(userID, userTxs[userID]))
+		if errMessage != nil {
+			messages = nil
+			err = errMessage
+			return
+		}
+		outboxMessage, errOutbox := notificationEntity.NewOutboxMessage(notificationEntity.NewDeliveryID(fileKey, userID), message, now)
+		if errOutbox != nil {
+			messages = nil
+			err = errOutbox
 			return
 		}
+		messages = append(messages, *outboxMessage)
 	}
 	return
 }
@@ -1,20 +1,33 @@
 package usecases_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 
 	acEntity "github.com/braejan/go-transactions-summary/internal/domain/account/entity"
 	accMockUseCases "github.com/braejan/go-transactions-summary/internal/domain/account/usecases/mock"
 	"github.com/braejan/go-transactions-summary/internal/domain/file/entity"
 	"github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	ntMockUseCases "github.com/braejan/go-transactions-summary/internal/domain/notification/usecases/mock"
 	txMockUseCases "github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases/mock"
 	userEntity "github.com/braejan/go-transactions-summary/internal/domain/user/entity"
 	userMockUseCases "github.com/braejan/go-transactions-summary/internal/domain/user/usecases/mock"
 	voAccount "github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror"
 	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing/tracingtest"
 	voTransaction "github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
 	voUser "github.com/braejan/go-transactions-summary/internal/valueobject/user"
 	"github.com/google/uuid"
@@ -103,7 +116,7 @@ func TestReadAndProcessFileWithEmtyFile(t *testing.T) {
 	// And a valid useCases
 	useCases, _ := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases)
 	// When ReadAndProcessFile is called with an empty file entity
-	err := useCases.ReadAndProcessFile(entity.TxFile{}, false)
+	err := useCases.ReadAndProcessFile(context.Background(), entity.TxFile{}, false)
 	// Then the returned error should be ErrFilePathIsEmpty
 	assert.Equal(t, voFile.ErrFilePathIsEmpty, err)
 }
@@ -119,7 +132,7 @@ func TestReadAndProcessFileWithNonExistingFile(t *testing.T) {
 	// And a valid useCases
 	useCases, _ := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases)
 	// When ReadAndProcessFile is called with a non existing file entity
-	err := useCases.ReadAndProcessFile(entity.TxFile{Path: "non-existing-file"}, false)
+	err := useCases.ReadAndProcessFile(context.Background(), entity.TxFile{Path: "non-existing-file"}, false)
 	// Then the returned error should be ErrFileCouldNotBeOpened
 	assert.Equal(t, voFile.ErrFileCouldNotBeOpened, err)
 }
@@ -140,7 +153,7 @@ func TestReadAndProcessFileWithEmptyFile(t *testing.T) {
 	fmt.Printf("filePath: %s\n", filePath)
 	fileEntity := entity.NewTxFile("txns_empty.csv", filePath, uuid.New().String(), 0)
 	// When ReadAndProcessFile is called with an empty file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
 	// Then the returned error should be ErrFileIsEmpty
 	assert.Equal(t, voFile.ErrFileIsEmpty, err)
 }
@@ -161,9 +174,9 @@ func TestReadAndProcessFileWithInvalidColumns(t *testing.T) {
 	fmt.Printf("filePath: %s\n", filePath)
 	fileEntity := entity.NewTxFile("txns_invalid.csv", filePath, uuid.New().String(), 0)
 	// When ReadAndProcessFile is called with an invalid file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
-	// Then the returned error should be ErrFileLineIsInvalid
-	assert.Equal(t, voFile.ErrFileLineIsInvalid, err)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
+	// Then the returned error is ErrFileHeaderIsInvalid
+	assert.Equal(t, voFile.ErrFileHeaderIsInvalid, err)
 }
 
 // TestReadAndProcessFileWithInvalidID tests the ReadAndProcessFile function with an invalid file entry id.
@@ -182,9 +195,18 @@ func TestReadAndProcessFileWithInvalidID(t *testing.T) {
 	fmt.Printf("filePath: %s\n", filePath)
 	fileEntity := entity.NewTxFile("txns_invalid.csv", filePath, uuid.New().String(), 0)
 	// When ReadAndProcessFile is called with an invalid file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
-	// Then the returned error should be ErrFileLineIsInvalid
-	assert.Equal(t, voFile.ErrFileLineIsInvalid, err)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
+	// Then the returned error is the line error of the invalid column
+	assert.ErrorIs(t, err, voFile.ErrFileLineIsInvalid)
+	assert.ErrorIs(t, err, voFile.ErrFileIDIsInvalid)
+	var lineErr *voFile.LineError
+	if assert.ErrorAs(t, err, &lineErr) {
+		assert.Equal(t, 2, lineErr.Line)
+		assert.Equal(t, "Id", lineErr.Column)
+	}
+	// And the error parsing the value is kept
+	var numErr *strconv.NumError
+	assert.ErrorAs(t, err, &numErr)
 }
 
 // TestReadAndProcessFileWithInvalidDate tests the ReadAndProcessFile function with an invalid file entry date.
@@ -203,9 +225,15 @@ func TestReadAndProcessFileWithInvalidDate(t *testing.T) {
 	fmt.Printf("filePath: %s\n", filePath)
 	fileEntity := entity.NewTxFile("txns_invalid.csv", filePath, uuid.New().String(), 0)
 	// When ReadAndProcessFile is called with an invalid file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
-	// Then the returned error should be ErrFileLineIsInvalid
-	assert.Equal(t, voFile.ErrFileLineIsInvalid, err)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
+	// Then the returned error is the line error of the invalid column
+	assert.ErrorIs(t, err, voFile.ErrFileLineIsInvalid)
+	assert.ErrorIs(t, err, voFile.ErrFileDateIsInvalid)
+	var lineErr *voFile.LineError
+	if assert.ErrorAs(t, err, &lineErr) {
+		assert.Equal(t, 2, lineErr.Line)
+		assert.Equal(t, "Date", lineErr.Column)
+	}
 }
 
 // TestReadAndProcessFileWithInvalidAmount tests the ReadAndProcessFile function with an invalid file entry amount.
@@ -224,9 +252,9 @@ func TestReadAndProcessFileWithInvalidAmount(t *testing.T) {
 	fmt.Printf("filePath: %s\n", filePath)
 	fileEntity := entity.NewTxFile("txns_invalid.csv", filePath, uuid.New().String(), 0)
 	// When ReadAndProcessFile is called with an invalid file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
 	// Then the returned error should be ErrFileLineIsInvalid
-	assert.Equal(t, voFile.ErrFileLineIsInvalid, err)
+	assert.ErrorIs(t, err, voFile.ErrFileLineIsInvalid)
 }
 
 // TestReadAndProcessErrGettingUserByID tests the ReadAndProcessFile function with an error getting the user by id.
@@ -237,7 +265,7 @@ func TestReadAndProcessErrGettingUserByID(t *testing.T) {
 	accountUseCases := accMockUseCases.NewMockAccountUseCases()
 	// And a valid transactionUseCases
 	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
-	userUseCases.On("GetByID", mock.Anything).Return(nil, errors.New("error getting user by id"))
+	userUseCases.On("GetByID", mock.Anything, mock.Anything).Return(nil, errors.New("error getting user by id"))
 	// And a valid useCases
 	useCases, _ := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases)
 	// And a valid file entity
@@ -246,7 +274,7 @@ func TestReadAndProcessErrGettingUserByID(t *testing.T) {
 	fmt.Printf("filePath: %s\n", filePath)
 	fileEntity := entity.NewTxFile("txns.csv", filePath, uuid.New().String(), 0)
 	// When ReadAndProcessFile is called with an invalid file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
 	// Then the returned error should be not nil
 	assert.NotNil(t, err)
 }
@@ -255,8 +283,8 @@ func TestReadAndProcessErrGettingUserByID(t *testing.T) {
 func TestReadAndProcessErrCreatingUser(t *testing.T) {
 	// Given a valid userUseCases
 	userUseCases := userMockUseCases.NewMockUserUseCases()
-	userUseCases.On("GetByID", mock.Anything).Return(nil, voUser.ErrUserNotFound)
-	userUseCases.On("Create", int64(0), "User Name 0", "user.email0@amazingemail.com").Return(nil, errors.New("error creating user"))
+	userUseCases.On("GetByID", mock.Anything, mock.Anything).Return(nil, voUser.ErrUserNotFound)
+	userUseCases.On("Create", mock.Anything, int64(0), "User Name 0", "user.email0@amazingemail.com").Return(nil, errors.New("error creating user"))
 	// And a valid accountUseCases
 	accountUseCases := accMockUseCases.NewMockAccountUseCases()
 	// And a valid transactionUseCases
@@ -272,7 +300,7 @@ func TestReadAndProcessErrCreatingUser(t *testing.T) {
 	fileEntity := entity.NewTxFile("txns.csv", filePath, uuid.New().String(), 0)
 
 	// When ReadAndProcessFile is called with an invalid file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
 
 	// Then the returned error should be not nil
 	assert.NotNil(t, err)
@@ -282,14 +310,14 @@ func TestReadAndProcessErrCreatingUser(t *testing.T) {
 func TestReadAndProcessErrCheckAccountByUserID_GetByUserID(t *testing.T) {
 	// Given a valid userUseCases
 	userUseCases := userMockUseCases.NewMockUserUseCases()
-	userUseCases.On("GetByID", mock.Anything).Return(nil, voUser.ErrUserNotFound).Once()
-	userUseCases.On("Create", int64(0), "User Name 0", "user.email0@amazingemail.com").Return(nil)
+	userUseCases.On("GetByID", mock.Anything, mock.Anything).Return(nil, voUser.ErrUserNotFound).Once()
+	userUseCases.On("Create", mock.Anything, int64(0), "User Name 0", "user.email0@amazingemail.com").Return(nil)
 	// And a valid user with id 0
 	user := userEntity.NewUser(0, "User Name 0", "user.email0@amazingemail.com")
-	userUseCases.On("GetByID", mock.Anything).Return(*user, nil)
+	userUseCases.On("GetByID", mock.Anything, mock.Anything).Return(*user, nil)
 	// And a valid accountUseCases
 	accountUseCases := accMockUseCases.NewMockAccountUseCases()
-	accountUseCases.On("GetByUserID", int64(0)).Return(acEntity.Account{}, errors.New("error checking account by user id"))
+	accountUseCases.On("GetByUserID", mock.Anything, int64(0)).Return(acEntity.Account{}, errors.New("error checking account by user id"))
 	// And a valid transactionUseCases
 	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
 	// And a valid useCases
@@ -300,7 +328,7 @@ func TestReadAndProcessErrCheckAccountByUserID_GetByUserID(t *testing.T) {
 	fmt.Printf("filePath: %s\n", filePath)
 	fileEntity := entity.NewTxFile("txns.csv", filePath, uuid.New().String(), 0)
 	// When ReadAndProcessFile is called with an invalid file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
 
 	// Then the returned error should be not nil
 	assert.NotNil(t, err)
@@ -310,15 +338,15 @@ func TestReadAndProcessErrCheckAccountByUserID_GetByUserID(t *testing.T) {
 func TestReadAndProcessErrCheckAccountByUserID_Create(t *testing.T) {
 	// Given a valid userUseCases
 	userUseCases := userMockUseCases.NewMockUserUseCases()
-	userUseCases.On("GetByID", mock.Anything).Return(nil, voUser.ErrUserNotFound).Once()
-	userUseCases.On("Create", int64(0), "User Name 0", "user.email0@amazingemail.com").Return(nil)
+	userUseCases.On("GetByID", mock.Anything, mock.Anything).Return(nil, voUser.ErrUserNotFound).Once()
+	userUseCases.On("Create", mock.Anything, int64(0), "User Name 0", "user.email0@amazingemail.com").Return(nil)
 	// And a valid user with id 0
 	user := userEntity.NewUser(0, "User Name 0", "user.email0@amazingemail.com")
-	userUseCases.On("GetByID", mock.Anything).Return(*user, nil)
+	userUseCases.On("GetByID", mock.Anything, mock.Anything).Return(*user, nil)
 	// And a valid accountUseCases
 	accountUseCases := accMockUseCases.NewMockAccountUseCases()
-	accountUseCases.On("GetByUserID", int64(0)).Return(acEntity.Account{}, voAccount.ErrAccountNotFound).Once()
-	accountUseCases.On("Create", int64(0)).Return(errors.New("error checking account by user id"))
+	accountUseCases.On("GetByUserID", mock.Anything, int64(0)).Return(acEntity.Account{}, voAccount.ErrAccountNotFound).Once()
+	accountUseCases.On("Create", mock.Anything, int64(0)).Return(errors.New("error checking account by user id"))
 	// And a valid transactionUseCases
 	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
 	// And a valid useCases
@@ -329,7 +357,7 @@ func TestReadAndProcessErrCheckAccountByUserID_Create(t *testing.T) {
 	fmt.Printf("filePath: %s\n", filePath)
 	fileEntity := entity.NewTxFile("txns.csv", filePath, uuid.New().String(), 0)
 	// When ReadAndProcessFile is called with an invalid file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
 	// Then the returned error should be not nil
 	assert.NotNil(t, err)
 }
@@ -338,16 +366,16 @@ func TestReadAndProcessErrCheckAccountByUserID_Create(t *testing.T) {
 func TestReadAndProcessErrCheckAccountByUserID_2GetByUserID(t *testing.T) {
 	// Given a valid userUseCases
 	userUseCases := userMockUseCases.NewMockUserUseCases()
-	userUseCases.On("GetByID", mock.Anything).Return(nil, voUser.ErrUserNotFound).Once()
-	userUseCases.On("Create", int64(0), "User Name 0", "user.email0@amazingemail.com").Return(nil)
+	userUseCases.On("GetByID", mock.Anything, mock.Anything).Return(nil, voUser.ErrUserNotFound).Once()
+	userUseCases.On("Create", mock.Anything, int64(0), "User Name 0", "user.email0@amazingemail.com").Return(nil)
 	// And a valid user with id 0
 	user := userEntity.NewUser(0, "User Name 0", "user.email0@amazingemail.com")
-	userUseCases.On("GetByID", mock.Anything).Return(*user, nil)
+	userUseCases.On("GetByID", mock.Anything, mock.Anything).Return(*user, nil)
 	// And a valid accountUseCases
 	accountUseCases := accMockUseCases.NewMockAccountUseCases()
-	accountUseCases.On("GetByUserID", int64(0)).Return(acEntity.Account{}, voAccount.ErrAccountNotFound).Once()
-	accountUseCases.On("Create", int64(0)).Return(nil)
-	accountUseCases.On("GetByUserID", int64(0)).Return(acEntity.Account{}, voAccount.ErrQueryingAccountByUserID).Once()
+	accountUseCases.On("GetByUserID", mock.Anything, int64(0)).Return(acEntity.Account{}, voAccount.ErrAccountNotFound).Once()
+	accountUseCases.On("Create", mock.Anything, int64(0)).Return(nil)
+	accountUseCases.On("GetByUserID", mock.Anything, int64(0)).Return(acEntity.Account{}, voAccount.ErrQueryingAccountByUserID).Once()
 	// And a valid transactionUseCases
 	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
 	// And a valid useCases
@@ -357,7 +385,7 @@ func TestReadAndProcessErrCheckAccountByUserID_2GetByUserID(t *testing.T) {
 	filePath := fmt.Sprintf("%s/%s", currentDir, "test/files/txns_simple.csv")
 	fileEntity := entity.NewTxFile("txns.csv", filePath, uuid.New().String(), 0)
 	// When ReadAndProcessFile is called with an invalid file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
 	// Then the returned error should be ErrQueryingAccountByUserID
 	assert.NotNil(t, err)
 	assert.Equal(t, voAccount.ErrQueryingAccountByUserID, err)
@@ -372,15 +400,15 @@ func TestReadAndProcessErrCreatingTransactions(t *testing.T) {
 	// And a valid accountUseCases
 	accountUseCases := accMockUseCases.NewMockAccountUseCases()
 	for _, user := range users {
-		userUseCases.On("Create", user.ID, user.Name, user.Email).Return(nil)
-		userUseCases.On("GetByID", user.ID).Return(*user, nil)
+		userUseCases.On("Create", mock.Anything, user.ID, user.Name, user.Email).Return(nil)
+		userUseCases.On("GetByID", mock.Anything, user.ID).Return(*user, nil)
 		// And a valid user account
 		account := acEntity.NewAccount(user.ID)
-		accountUseCases.On("GetByUserID", user.ID).Return(*account, nil)
+		accountUseCases.On("GetByUserID", mock.Anything, user.ID).Return(*account, nil)
 	}
 	// And a valid transactionUseCases
 	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
-	transactionUseCases.On("Create", mock.Anything).Return(errors.New("error creating transaction"))
+	transactionUseCases.On("CreateBatch", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error creating transaction"))
 	// And a valid useCases
 	useCases, _ := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases)
 	// And a valid file entity
@@ -388,7 +416,7 @@ func TestReadAndProcessErrCreatingTransactions(t *testing.T) {
 	filePath := fmt.Sprintf("%s/%s", currentDir, "test/files/txns_simple.csv")
 	fileEntity := entity.NewTxFile("txns.csv", filePath, uuid.New().String(), 0)
 	// When ReadAndProcessFile is called with an invalid file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
 	// Then the returned error should be ErrQueryingAccountByUserID
 	assert.NotNil(t, err)
 	assert.Equal(t, voTransaction.ErrCreatingTransaction, err)
@@ -403,15 +431,15 @@ func TestReadAndProcessSucess(t *testing.T) {
 	// And a valid accountUseCases
 	accountUseCases := accMockUseCases.NewMockAccountUseCases()
 	for _, user := range users {
-		userUseCases.On("Create", user.ID, user.Name, user.Email).Return(nil)
-		userUseCases.On("GetByID", user.ID).Return(*user, nil)
+		userUseCases.On("Create", mock.Anything, user.ID, user.Name, user.Email).Return(nil)
+		userUseCases.On("GetByID", mock.Anything, user.ID).Return(*user, nil)
 		// And a valid user account
 		account := acEntity.NewAccount(user.ID)
-		accountUseCases.On("GetByUserID", user.ID).Return(*account, nil)
+		accountUseCases.On("GetByUserID", mock.Anything, user.ID).Return(*account, nil)
 	}
 	// And a valid transactionUseCases
 	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
-	transactionUseCases.On("Create", mock.Anything).Return(nil)
+	transactionUseCases.On("CreateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	// And a valid useCases
 	useCases, _ := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases)
 	// And a valid file entity
@@ -419,7 +447,7 @@ func TestReadAndProcessSucess(t *testing.T) {
 	filePath := fmt.Sprintf("%s/%s", currentDir, "test/files/txns_simple.csv")
 	fileEntity := entity.NewTxFile("txns.csv", filePath, uuid.New().String(), 0)
 	// When ReadAndProcessFile is called with an invalid file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
 	// Then the returned error should be nil
 	assert.Nil(t, err)
 }
@@ -433,15 +461,15 @@ func TestReadAndProcessErrFileLastRecord(t *testing.T) {
 	// And a valid accountUseCases
 	accountUseCases := accMockUseCases.NewMockAccountUseCases()
 	for _, user := range users {
-		userUseCases.On("Create", user.ID, user.Name, user.Email).Return(nil)
-		userUseCases.On("GetByID", user.ID).Return(*user, nil)
+		userUseCases.On("Create", mock.Anything, user.ID, user.Name, user.Email).Return(nil)
+		userUseCases.On("GetByID", mock.Anything, user.ID).Return(*user, nil)
 		// And a valid user account
 		account := acEntity.NewAccount(user.ID)
-		accountUseCases.On("GetByUserID", user.ID).Return(*account, nil)
+		accountUseCases.On("GetByUserID", mock.Anything, user.ID).Return(*account, nil)
 	}
 	// And a valid transactionUseCases
 	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
-	transactionUseCases.On("Create", mock.Anything).Return(nil)
+	transactionUseCases.On("CreateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	// And a valid useCases
 	useCases, _ := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases)
 	// And a valid file entity
@@ -449,7 +477,218 @@ func TestReadAndProcessErrFileLastRecord(t *testing.T) {
 	filePath := fmt.Sprintf("%s/%s", currentDir, "test/files/txns_invalid_last_record.csv")
 	fileEntity := entity.NewTxFile("txns.csv", filePath, uuid.New().String(), 0)
 	// When ReadAndProcessFile is called with an invalid file entity
-	err := useCases.ReadAndProcessFile(*fileEntity, false)
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
 	// Then the returned error should be not nil
 	assert.NotNil(t, err)
 }
+
+// TestNewFileUseCasesWithNilNotificationUseCases tests the WithNotifications option with a nil notificationUseCases.
+func TestNewFileUseCasesWithNilNotificationUseCases(t *testing.T) {
+	// Given valid userUseCases, accountUseCases and transactionUseCases
+	userUseCases := userMockUseCases.NewMockUserUseCases()
+	accountUseCases := accMockUseCases.NewMockAccountUseCases()
+	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
+	// When NewFileUseCases is called with a nil notificationUseCases
+	useCases, err := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases, usecases.WithNotifications(nil))
+	// Then the returned useCases should be nil
+	assert.Nil(t, useCases)
+	// And the returned error should be ErrNilNotificationUseCases
+	assert.Equal(t, voNotification.ErrNilNotificationUseCases, err)
+}
+
+// TestReadAndProcessWithNotifications tests a summary email per user is enqueued with the transactions.
+func TestReadAndProcessWithNotifications(t *testing.T) {
+	// Given a valid user array
+	users := getTestUsers()
+	// Given a valid userUseCases
+	userUseCases := userMockUseCases.NewMockUserUseCases()
+	// And a valid accountUseCases
+	accountUseCases := accMockUseCases.NewMockAccountUseCases()
+	// And a valid notificationUseCases
+	notificationUseCases := ntMockUseCases.NewMockNotificationUseCases()
+	for _, user := range users {
+		userUseCases.On("GetByID", mock.Anything, user.ID).Return(*user, nil)
+		account := acEntity.NewAccount(user.ID)
+		accountUseCases.On("GetByUserID", mock.Anything, user.ID).Return(*account, nil)
+		message := notificationEntity.NewMessage("no-reply@amazingemail.com", user.Email, "Resumen", "texto", "<p>texto</p>")
+		notificationUseCases.On("SummaryMessage", *user, mock.Anything).Return(*message, nil)
+	}
+	// And a valid transactionUseCases
+	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
+	var messages []notificationEntity.OutboxMessage
+	transactionUseCases.On("CreateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		messages = args.Get(2).([]notificationEntity.OutboxMessage)
+	})
+	// And a valid useCases with notifications
+	useCases, err := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases, usecases.WithNotifications(notificationUseCases))
+	assert.Nil(t, err)
+	// And a valid file entity
+	currentDir, _ := os.Getwd()
+	filePath := fmt.Sprintf("%s/%s", currentDir, "test/files/txns_simple.csv")
+	fileEntity := entity.NewTxFile("txns.csv", filePath, "file-hash", 0)
+	// When ReadAndProcessFile is called
+	err = useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
+	// Then the returned error should be nil
+	assert.Nil(t, err)
+	// And a pending message per user is enqueued with a delivery ID derived from the file hash
+	assert.Equal(t, len(users), len(messages))
+	for i, user := range users {
+		assert.Equal(t, notificationEntity.NewDeliveryID("file-hash", user.ID), messages[i].ID)
+		assert.Equal(t, user.Email, messages[i].Recipient)
+		assert.Equal(t, notificationEntity.OutboxStatusPending, messages[i].Status)
+	}
+}
+
+// TestReadAndProcessErrBuildingSummaryMessage tests nothing is stored when a summary email cannot be built.
+func TestReadAndProcessErrBuildingSummaryMessage(t *testing.T) {
+	// Given a valid user array
+	users := getTestUsers()
+	// Given a valid userUseCases
+	userUseCases := userMockUseCases.NewMockUserUseCases()
+	// And a valid accountUseCases
+	accountUseCases := accMockUseCases.NewMockAccountUseCases()
+	for _, user := range users {
+		userUseCases.On("GetByID", mock.Anything, user.ID).Return(*user, nil)
+		account := acEntity.NewAccount(user.ID)
+		accountUseCases.On("GetByUserID", mock.Anything, user.ID).Return(*account, nil)
+	}
+	// And a notificationUseCases failing to render the email
+	notificationUseCases := ntMockUseCases.NewMockNotificationUseCases()
+	notificationUseCases.On("SummaryMessage", mock.Anything, mock.Anything).Return(nil, voNotification.ErrRenderingTemplate)
+	// And a valid transactionUseCases
+	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
+	// And a valid useCases with notifications
+	useCases, _ := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases, usecases.WithNotifications(notificationUseCases))
+	// And a valid file entity
+	currentDir, _ := os.Getwd()
+	filePath := fmt.Sprintf("%s/%s", currentDir, "test/files/txns_simple.csv")
+	fileEntity := entity.NewTxFile("txns.csv", filePath, uuid.New().String(), 0)
+	// When ReadAndProcessFile is called
+	err := useCases.ReadAndProcessFile(context.Background(), *fileEntity, false)
+	// Then the returned error should be ErrRenderingTemplate
+	assert.Equal(t, voNotification.ErrRenderingTemplate, err)
+	// And no transaction is stored
+	transactionUseCases.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestProcessFileLogsWithContextIDs tests the lines of a processed file carry the request
+// and the file IDs of the context, and only the sampled rows are logged.
+func TestProcessFileLogsWithContextIDs(t *testing.T) {
+	// Given valid users with an account
+	userUseCases := userMockUseCases.NewMockUserUseCases()
+	accountUseCases := accMockUseCases.NewMockAccountUseCases()
+	for _, user := range getTestUsers() {
+		userUseCases.On("GetByID", mock.Anything, user.ID).Return(*user, nil)
+		accountUseCases.On("GetByUserID", mock.Anything, user.ID).Return(*acEntity.NewAccount(user.ID), nil)
+	}
+	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
+	transactionUseCases.On("CreateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	// And use cases logging at debug level one of every two rows
+	buffer := &bytes.Buffer{}
+	useCases, err := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases,
+		usecases.WithLogger(logger.NewJSONLogger(buffer, logger.LevelDebug)), usecases.WithRowSampling(2))
+	assert.Nil(t, err)
+	// And a context with a request and a file ID
+	ctx := logger.WithFileID(logger.WithRequestID(context.Background(), "request-1"), "hash-1")
+	file, err := os.Open("test/files/txns_simple.csv")
+	assert.Nil(t, err)
+	defer file.Close()
+	// When ProcessFile is called
+	_, err = useCases.ProcessFile(ctx, *entity.NewTxFile("txns.csv", file.Name(), "hash-1", 0), file)
+	// Then every line has the IDs
+	assert.Nil(t, err)
+	rows := 0
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		fields := map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal([]byte(line), &fields))
+		assert.Equal(t, "request-1", fields["request_id"])
+		assert.Equal(t, "hash-1", fields["file_id"])
+		if fields["msg"] == "reading row" {
+			rows++
+		}
+	}
+	// And one of every two rows is logged
+	assert.Equal(t, 2, rows)
+}
+
+// TestProcessMultipartFileTraced tests the processing, the parse and the batch are traced.
+func TestProcessMultipartFileTraced(t *testing.T) {
+	// Given valid users with an account
+	userUseCases := userMockUseCases.NewMockUserUseCases()
+	accountUseCases := accMockUseCases.NewMockAccountUseCases()
+	for _, user := range getTestUsers() {
+		userUseCases.On("GetByID", mock.Anything, user.ID).Return(*user, nil)
+		accountUseCases.On("GetByUserID", mock.Anything, user.ID).Return(*acEntity.NewAccount(user.ID), nil)
+	}
+	// And a transactionUseCases keeping the context of the batch
+	var batchCtx context.Context
+	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
+	transactionUseCases.On("CreateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		batchCtx = args.Get(0).(context.Context)
+	})
+	useCases, err := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases)
+	assert.Nil(t, err)
+	// And a context carrying the span of the request
+	tracer, recorder := tracingtest.NewTracer()
+	ctx, root := tracer.Start(context.Background(), "POST /loadfile")
+	file, err := os.Open("test/files/txns_simple.csv")
+	assert.Nil(t, err)
+	defer file.Close()
+	// When ProcessMultipartFile is called
+	transactions, err := useCases.ProcessMultipartFile(ctx, *entity.NewTxFile("txns.csv", file.Name(), "hash-1", 0), file)
+	root.End()
+	// Then the processing is a child of the request, with the number of stored transactions
+	assert.Nil(t, err)
+	assert.Equal(t, 4, transactions)
+	assert.Equal(t, []string{"file.parse", "FileUseCases.ProcessMultipartFile", "POST /loadfile"}, recorder.Names())
+	spans := recorder.Spans()
+	assert.Equal(t, root.SpanContext().SpanID, spans[1].ParentSpanID)
+	assert.Equal(t, "hash-1", spans[1].Attributes["file.id"])
+	// And the parse is a child of the processing, with the transactions of the file
+	assert.Equal(t, spans[1].SpanContext.SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, 4, spans[0].Attributes["file.transactions"])
+	// And the batch is stored within the processing
+	assert.Equal(t, spans[1].SpanContext, tracing.SpanFromContext(batchCtx).SpanContext())
+}
+
+// TestProcessFileReadErrors tests the lines that cannot be read are line errors answered with 422.
+func TestProcessFileReadErrors(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		err     error
+		line    int
+		code    string
+	}{
+		"column count":    {"Id,Date,Transaction\n0,7/5,+60.5,extra\n", voFile.ErrFileColumnCountIsInvalid, 2, "FILE_COLUMN_COUNT_INVALID"},
+		"missing column":  {"Id,Date,Transaction\n0,7/5\n", voFile.ErrFileColumnCountIsInvalid, 2, "FILE_COLUMN_COUNT_INVALID"},
+		"bare quote":      {"Id,Date,Transaction\n0,7/5,+6\"0.5\n", voFile.ErrFileLineCouldNotBeParsed, 2, "FILE_LINE_UNPARSABLE"},
+		"quote in header": {"Id,\"Date,Transaction\n", voFile.ErrFileLineCouldNotBeParsed, 1, "FILE_LINE_UNPARSABLE"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Given a file with a line that cannot be read
+			useCases, err := usecases.NewFileUseCases(userMockUseCases.NewMockUserUseCases(), accMockUseCases.NewMockAccountUseCases(),
+				txMockUseCases.NewMockTransactionUseCases())
+			assert.Nil(t, err)
+			file, err := os.CreateTemp(t.TempDir(), "txns-*.csv")
+			assert.Nil(t, err)
+			defer file.Close()
+			_, err = file.WriteString(tc.content)
+			assert.Nil(t, err)
+			_, err = file.Seek(0, 0)
+			assert.Nil(t, err)
+			// When ProcessFile is called
+			_, err = useCases.ProcessFile(context.Background(), *entity.NewTxFile("txns.csv", file.Name(), "hash-1", 0), file)
+			// Then the error is the line error of the line
+			assert.ErrorIs(t, err, tc.err)
+			var lineErr *voFile.LineError
+			if assert.ErrorAs(t, err, &lineErr) {
+				assert.Equal(t, tc.line, lineErr.Line)
+			}
+			// And it is answered with the code of the line error
+			entry := apierror.Lookup(err)
+			assert.Equal(t, tc.code, entry.Code)
+			assert.Equal(t, http.StatusUnprocessableEntity, entry.Status)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"os"
+
+	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	voMetrics "github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+)
+
+const (
+	// resultSucceeded is the result of a processed file.
+	resultSucceeded = "succeeded"
+	// resultFailed is the result of a file that could not be processed.
+	resultFailed = "failed"
+	// resultRejected is the result of a file with invalid lines, it is not processed.
+	resultRejected = "rejected"
+	// unlistedProblem is the error of the invalid lines beyond the problems of a report.
+	unlistedProblem = "unlisted"
+)
+
+// metricsFileUseCases struct implements the FileUseCases interface counting the processed
+// files and the rejected rows of the decorated use cases.
+type metricsFileUseCases struct {
+	fileUseCases usecases.FileUseCases
+	files        *voMetrics.Counter
+	rejectedRows *voMetrics.Counter
+}
+
+// NewMetricsFileUseCases returns the use cases decorated with the file metrics of the registry.
+func NewMetricsFileUseCases(fileUseCases usecases.FileUseCases, registry *voMetrics.Registry) (decorated usecases.FileUseCases) {
+	decorated = &metricsFileUseCases{
+		fileUseCases: fileUseCases,
+		files:        registry.Counter("files_processed_total", "Files processed, by result.", "result"),
+		rejectedRows: registry.Counter("rows_rejected_total", "Rows of the files rejected, by error.", "error"),
+	}
+	return
+}
+
+// FileUseCases interface implementation
+
+// CheckStructure counts the rejected file and its invalid rows when the report is not valid.
+func (uc *metricsFileUseCases) CheckStructure(txFile fileEntity.TxFile, reader io.Reader) (report *fileEntity.ValidationReport, err error) {
+	report, err = uc.fileUseCases.CheckStructure(txFile, reader)
+	if err != nil || report.IsValid() {
+		return
+	}
+	uc.files.Inc(resultRejected)
+	for _, problem := range report.Problems {
+		uc.rejectedRows.Inc(problem.Error)
+	}
+	uc.rejectedRows.Add(float64(report.InvalidLines-len(report.Problems)), unlistedProblem)
+	return
+}
+
+func (uc *metricsFileUseCases) ReadAndProcessFile(ctx context.Context, txFile fileEntity.TxFile, isS3 bool) (err error) {
+	err = uc.fileUseCases.ReadAndProcessFile(ctx, txFile, isS3)
+	uc.countFile(err)
+	return
+}
+
+func (uc *metricsFileUseCases) CheckFile(txFile fileEntity.TxFile, isS3 bool) (err error) {
+	return uc.fileUseCases.CheckFile(txFile, isS3)
+}
+
+func (uc *metricsFileUseCases) ProcessFile(ctx context.Context, txFile fileEntity.TxFile, file *os.File) (transactions int, err error) {
+	transactions, err = uc.fileUseCases.ProcessFile(ctx, txFile, file)
+	uc.countFile(err)
+	return
+}
+
+func (uc *metricsFileUseCases) ProcessMultipartFile(ctx context.Context, txFile fileEntity.TxFile, file multipart.File) (transactions int, err error) {
+	transactions, err = uc.fileUseCases.ProcessMultipartFile(ctx, txFile, file)
+	uc.countFile(err)
+	return
+}
+
+// ValidateFile only previews the file, it is not counted.
+func (uc *metricsFileUseCases) ValidateFile(ctx context.Context, txFile fileEntity.TxFile, reader io.Reader) (preview *fileEntity.ValidationPreview, err error) {
+	return uc.fileUseCases.ValidateFile(ctx, txFile, reader)
+}
+
+func (uc *metricsFileUseCases) CheckDailyQuota(ctx context.Context, uploader string) (usage quota.Usage, err error) {
+	return uc.fileUseCases.CheckDailyQuota(ctx, uploader)
+}
+
+// countFile counts the result of processing a file, and the row that stopped it.
+func (uc *metricsFileUseCases) countFile(err error) {
+	if err == nil {
+		uc.files.Inc(resultSucceeded)
+		return
+	}
+	uc.files.Inc(resultFailed)
+	if errors.Is(err, voFile.ErrFileLineIsInvalid) {
+		uc.rejectedRows.Inc(voFile.ErrFileLineIsInvalid.Error())
+	}
+}
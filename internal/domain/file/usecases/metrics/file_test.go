@@ -0,0 +1,50 @@
+package metrics_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	"github.com/braejan/go-transactions-summary/internal/domain/file/usecases/metrics"
+	fileMock "github.com/braejan/go-transactions-summary/internal/domain/file/usecases/mock"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	voMetrics "github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestMetricsFileUseCases tests the processed files and the rejected rows are counted.
+func TestMetricsFileUseCases(t *testing.T) {
+	// Given file use cases failing on the second file
+	fileUseCases := fileMock.NewMockFileUseCases()
+	fileUseCases.On("ProcessFile", mock.Anything, mock.Anything, mock.Anything).Return(3, nil).Once()
+	fileUseCases.On("ProcessFile", mock.Anything, mock.Anything, mock.Anything).Return(0, voFile.ErrFileLineIsInvalid).Once()
+	// And a file with an invalid Id and an invalid Date
+	invalid, err := usecases.NewStructureUseCases().CheckStructure(entity.TxFile{},
+		strings.NewReader("Id,Date,Transaction\nx,7/5,+1\n0,13/5,+1\n0,7/5,+1\n"))
+	assert.Nil(t, err)
+	fileUseCases.On("CheckStructure", mock.Anything, mock.Anything).Return(invalid, nil)
+	registry := voMetrics.NewRegistry()
+	decorated := metrics.NewMetricsFileUseCases(fileUseCases, registry)
+	// When processing two files and checking one with invalid lines
+	transactions, err := decorated.ProcessFile(context.Background(), entity.TxFile{}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, transactions)
+	_, err = decorated.ProcessFile(context.Background(), entity.TxFile{}, nil)
+	assert.Equal(t, voFile.ErrFileLineIsInvalid, err)
+	report, err := decorated.CheckStructure(entity.TxFile{}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, report.InvalidLines)
+	// Then every result and every rejected row is counted
+	out := &bytes.Buffer{}
+	assert.Nil(t, registry.Write(out))
+	assert.Contains(t, out.String(), `files_processed_total{result="succeeded"} 1`)
+	assert.Contains(t, out.String(), `files_processed_total{result="failed"} 1`)
+	assert.Contains(t, out.String(), `files_processed_total{result="rejected"} 1`)
+	assert.Contains(t, out.String(), `rows_rejected_total{error="file line is invalid"} 1`)
+	assert.Contains(t, out.String(), `rows_rejected_total{error="id must be an integer"} 1`)
+	assert.Contains(t, out.String(), `rows_rejected_total{error="date must have the format month/day"} 1`)
+}
@@ -1,10 +1,13 @@
 package mock
 
 import (
+	"context"
+	"io"
 	"mime/multipart"
 	"os"
 
 	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -21,12 +24,12 @@ func NewMockFileUseCases() *mockFileUseCases {
 // FileUseCases interface implementation.
 
 // ReadAndProcessFile mocks base method.
-func (m *mockFileUseCases) ReadAndProcessFile(txFile fileEntity.TxFile, isS3 bool) error {
-	ret := m.Called(txFile, isS3)
+func (m *mockFileUseCases) ReadAndProcessFile(ctx context.Context, txFile fileEntity.TxFile, isS3 bool) error {
+	ret := m.Called(ctx, txFile, isS3)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(fileEntity.TxFile, bool) error); ok {
-		r0 = rf(txFile, isS3)
+	if rf, ok := ret.Get(0).(func(context.Context, fileEntity.TxFile, bool) error); ok {
+		r0 = rf(ctx, txFile, isS3)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -49,29 +52,106 @@ func (m *mockFileUseCases) CheckFile(txFile fileEntity.TxFile, isS3 bool) error
 }
 
 // ProcessFile mocks base method.
-func (m *mockFileUseCases) ProcessFile(txFile fileEntity.TxFile, file *os.File) error {
-	ret := m.Called(txFile, file)
+func (m *mockFileUseCases) ProcessFile(ctx context.Context, txFile fileEntity.TxFile, file *os.File) (int, error) {
+	ret := m.Called(ctx, txFile, file)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(fileEntity.TxFile, *os.File) error); ok {
-		r0 = rf(txFile, file)
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, fileEntity.TxFile, *os.File) int); ok {
+		r0 = rf(ctx, txFile, file)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Int(0)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, fileEntity.TxFile, *os.File) error); ok {
+		r1 = rf(ctx, txFile, file)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // ProcessMultipartFile mocks base method.
-func (m *mockFileUseCases) ProcessMultipartFile(txFile fileEntity.TxFile, file multipart.File) error {
-	ret := m.Called(txFile, file)
+func (m *mockFileUseCases) ProcessMultipartFile(ctx context.Context, txFile fileEntity.TxFile, file multipart.File) (int, error) {
+	ret := m.Called(ctx, txFile, file)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(fileEntity.TxFile, multipart.File) error); ok {
-		r0 = rf(txFile, file)
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, fileEntity.TxFile, multipart.File) int); ok {
+		r0 = rf(ctx, txFile, file)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Int(0)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, fileEntity.TxFile, multipart.File) error); ok {
+		r1 = rf(ctx, txFile, file)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckStructure mocks base method.
+func (m *mockFileUseCases) CheckStructure(txFile fileEntity.TxFile, reader io.Reader) (*fileEntity.ValidationReport, error) {
+	ret := m.Called(txFile, reader)
+
+	var r0 *fileEntity.ValidationReport
+	if rf, ok := ret.Get(0).(func(fileEntity.TxFile, io.Reader) *fileEntity.ValidationReport); ok {
+		r0 = rf(txFile, reader)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*fileEntity.ValidationReport)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(fileEntity.TxFile, io.Reader) error); ok {
+		r1 = rf(txFile, reader)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateFile mocks base method.
+func (m *mockFileUseCases) ValidateFile(ctx context.Context, txFile fileEntity.TxFile, reader io.Reader) (*fileEntity.ValidationPreview, error) {
+	ret := m.Called(ctx, txFile, reader)
+
+	var r0 *fileEntity.ValidationPreview
+	if rf, ok := ret.Get(0).(func(context.Context, fileEntity.TxFile, io.Reader) *fileEntity.ValidationPreview); ok {
+		r0 = rf(ctx, txFile, reader)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*fileEntity.ValidationPreview)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, fileEntity.TxFile, io.Reader) error); ok {
+		r1 = rf(ctx, txFile, reader)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckDailyQuota mocks base method.
+func (m *mockFileUseCases) CheckDailyQuota(ctx context.Context, uploader string) (quota.Usage, error) {
+	ret := m.Called(ctx, uploader)
+
+	var r0 quota.Usage
+	if rf, ok := ret.Get(0).(func(context.Context, string) quota.Usage); ok {
+		r0 = rf(ctx, uploader)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(quota.Usage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uploader)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
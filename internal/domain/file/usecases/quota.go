@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"io"
+	"time"
+
+	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+)
+
+// WithQuotas limits the size and the rows of the files and the files an uploader uploads
+// per day. The counter returns the files already uploaded.
+func WithQuotas(configuration *quota.QuotaConfiguration, counter quota.UsageCounter) FileUseCasesOption {
+	return func(useCases *localFileUseCases) (err error) {
+		if configuration == nil {
+			err = quota.ErrNilQuotaConfiguration
+			return
+		}
+		if counter == nil {
+			err = quota.ErrNilUsageCounter
+			return
+		}
+		useCases.quota = configuration
+		useCases.usageCounter = counter
+		return
+	}
+}
+
+// CheckStructure checks the structure of the file, which cannot exceed the max file size
+// nor the max rows per file.
+func (useCases *localFileUseCases) CheckStructure(txFile fileEntity.TxFile, reader io.Reader) (report *fileEntity.ValidationReport, err error) {
+	limited := useCases.limitReader(reader)
+	report, err = useCases.StructureUseCases.CheckStructure(txFile, limited)
+	if err == voFile.ErrFileCouldNotBeRead && isSizeExceeded(limited) {
+		err = quota.ErrFileTooLarge
+	}
+	if err != nil {
+		report = nil
+		return
+	}
+	if useCases.tooManyRows(report.Lines) {
+		report = nil
+		err = quota.ErrTooManyRows
+	}
+	return
+}
+
+// CheckDailyQuota returns the files the uploader uploaded today. The error is
+// ErrDailyQuotaExceeded when the uploader cannot upload more files today. Without
+// uploader there is no quota.
+func (useCases *localFileUseCases) CheckDailyQuota(ctx context.Context, uploader string) (usage quota.Usage, err error) {
+	if useCases.quota == nil || useCases.quota.MaxFilesPerDay <= 0 || uploader == "" {
+		return
+	}
+	dayStart := quota.DayStart(time.Now())
+	used, err := useCases.usageCounter.CountByUploaderSince(ctx, uploader, dayStart)
+	if err != nil {
+		return
+	}
+	usage = quota.Usage{Limit: useCases.quota.MaxFilesPerDay, Used: used, ResetAt: dayStart.Add(24 * time.Hour)}
+	if usage.Remaining() == 0 {
+		err = quota.ErrDailyQuotaExceeded
+	}
+	return
+}
+
+// limitReader returns the reader limited to the max file size, or the reader itself
+// without limit.
+func (useCases *localFileUseCases) limitReader(reader io.Reader) io.Reader {
+	if reader == nil || useCases.quota == nil || useCases.quota.MaxFileSize <= 0 {
+		return reader
+	}
+	return quota.NewSizeLimitedReader(reader, useCases.quota.MaxFileSize)
+}
+
+// tooManyRows returns whether the rows read exceed the max rows per file.
+func (useCases *localFileUseCases) tooManyRows(rows int) bool {
+	return useCases.quota != nil && useCases.quota.MaxRowsPerFile > 0 && rows > useCases.quota.MaxRowsPerFile
+}
+
+// isSizeExceeded returns whether the reader is a size limited reader beyond its max.
+func isSizeExceeded(reader io.Reader) bool {
+	limited, ok := reader.(*quota.SizeLimitedReader)
+	return ok && limited.Exceeded()
+}
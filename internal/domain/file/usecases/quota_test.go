@@ -0,0 +1,131 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	acEntity "github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	accMockUseCases "github.com/braejan/go-transactions-summary/internal/domain/account/usecases/mock"
+	"github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	processingMock "github.com/braejan/go-transactions-summary/internal/domain/processing/repository/mock"
+	txMockUseCases "github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases/mock"
+	userMockUseCases "github.com/braejan/go-transactions-summary/internal/domain/user/usecases/mock"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// getQuotaUseCases returns file use cases with the quotas and a usage counter, every user of the file exists.
+func getQuotaUseCases(t *testing.T, configuration *quota.QuotaConfiguration, counter quota.UsageCounter) usecases.FileUseCases {
+	userUseCases := userMockUseCases.NewMockUserUseCases()
+	accountUseCases := accMockUseCases.NewMockAccountUseCases()
+	for _, user := range getTestUsers() {
+		userUseCases.On("GetByID", mock.Anything, user.ID).Return(*user, nil)
+		accountUseCases.On("GetByUserID", mock.Anything, user.ID).Return(*acEntity.NewAccount(user.ID), nil)
+	}
+	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
+	transactionUseCases.On("CreateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	useCases, err := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases, usecases.WithQuotas(configuration, counter))
+	assert.Nil(t, err)
+	return useCases
+}
+
+// TestWithQuotasNil tests the errors returned without quota configuration or usage counter.
+func TestWithQuotasNil(t *testing.T) {
+	newUseCases := func(option usecases.FileUseCasesOption) error {
+		_, err := usecases.NewFileUseCases(userMockUseCases.NewMockUserUseCases(), accMockUseCases.NewMockAccountUseCases(),
+			txMockUseCases.NewMockTransactionUseCases(), option)
+		return err
+	}
+	assert.Equal(t, quota.ErrNilQuotaConfiguration, newUseCases(usecases.WithQuotas(nil, processingMock.NewMockProcessingRepository())))
+	assert.Equal(t, quota.ErrNilUsageCounter, newUseCases(usecases.WithQuotas(quota.NewDefaultQuotaConfiguration(), nil)))
+}
+
+// TestCheckStructureQuotas tests the files beyond the max file size or the max rows per file are rejected.
+func TestCheckStructureQuotas(t *testing.T) {
+	content := "Id,Date,Transaction\n0,7/5,+60.5\n1,7/28,-10.3\n"
+	cases := map[string]struct {
+		maxFileSize int64
+		maxRows     int
+		err         error
+	}{
+		"within the quotas": {int64(len(content)), 2, nil},
+		"too large":         {int64(len(content)) - 1, 2, quota.ErrFileTooLarge},
+		"too many rows":     {int64(len(content)), 1, quota.ErrTooManyRows},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Given file use cases with the quotas
+			useCases := getQuotaUseCases(t, &quota.QuotaConfiguration{MaxFileSize: tc.maxFileSize, MaxRowsPerFile: tc.maxRows},
+				processingMock.NewMockProcessingRepository())
+			// When checking the structure of the file
+			report, err := useCases.CheckStructure(*entity.NewTxFile("txns.csv", "", "", 0), strings.NewReader(content))
+			// Then the file is rejected beyond the quotas
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.err == nil, report != nil)
+		})
+	}
+}
+
+// TestReadAndProcessFileTooManyRows tests a file beyond the max rows per file is not processed.
+func TestReadAndProcessFileTooManyRows(t *testing.T) {
+	// Given file use cases allowing 2 rows per file
+	useCases := getQuotaUseCases(t, &quota.QuotaConfiguration{MaxRowsPerFile: 2}, processingMock.NewMockProcessingRepository())
+	// And a file with 3 rows
+	currentDir, _ := os.Getwd()
+	txFile := entity.NewTxFile("txns.csv", fmt.Sprintf("%s/%s", currentDir, "test/files/txns_simple.csv"), uuid.New().String(), 0)
+	// When processing the file
+	err := useCases.ReadAndProcessFile(context.Background(), *txFile, false)
+	// Then the error returned is ErrTooManyRows
+	assert.Equal(t, quota.ErrTooManyRows, err)
+}
+
+// TestReadAndProcessFileTooLarge tests a file beyond the max file size is not processed.
+func TestReadAndProcessFileTooLarge(t *testing.T) {
+	// Given file use cases allowing files of 30 bytes
+	useCases := getQuotaUseCases(t, &quota.QuotaConfiguration{MaxFileSize: 30}, processingMock.NewMockProcessingRepository())
+	// And a larger file
+	currentDir, _ := os.Getwd()
+	txFile := entity.NewTxFile("txns.csv", fmt.Sprintf("%s/%s", currentDir, "test/files/txns_simple.csv"), uuid.New().String(), 0)
+	// When processing the file
+	err := useCases.ReadAndProcessFile(context.Background(), *txFile, false)
+	// Then the error returned is ErrFileTooLarge
+	assert.Equal(t, quota.ErrFileTooLarge, err)
+}
+
+// TestCheckDailyQuota tests the files of an uploader are counted since the UTC midnight.
+func TestCheckDailyQuota(t *testing.T) {
+	dayStart := quota.DayStart(time.Now())
+	counter := processingMock.NewMockProcessingRepository()
+	counter.On("CountByUploaderSince", mock.Anything, "client", dayStart).Return(1, nil)
+	counter.On("CountByUploaderSince", mock.Anything, "exhausted", dayStart).Return(3, nil)
+	counter.On("CountByUploaderSince", mock.Anything, "failing", dayStart).Return(0, errors.New("connection refused"))
+	// Given file use cases allowing 3 files per day
+	useCases := getQuotaUseCases(t, &quota.QuotaConfiguration{MaxFilesPerDay: 3}, counter)
+	// When checking the quota of a client with files left
+	usage, err := useCases.CheckDailyQuota(context.Background(), "client")
+	// Then its usage is returned
+	assert.Nil(t, err)
+	assert.Equal(t, quota.Usage{Limit: 3, Used: 1, ResetAt: dayStart.Add(24 * time.Hour)}, usage)
+	// When checking the quota of a client without files left
+	usage, err = useCases.CheckDailyQuota(context.Background(), "exhausted")
+	// Then the error returned is ErrDailyQuotaExceeded
+	assert.Equal(t, quota.ErrDailyQuotaExceeded, err)
+	assert.Equal(t, 0, usage.Remaining())
+	// When the files cannot be counted
+	_, err = useCases.CheckDailyQuota(context.Background(), "failing")
+	// Then the error is returned
+	assert.EqualError(t, err, "connection refused")
+	// When checking the quota of an anonymous upload
+	usage, err = useCases.CheckDailyQuota(context.Background(), "")
+	// Then there is no quota
+	assert.Nil(t, err)
+	assert.Equal(t, quota.Usage{}, usage)
+}
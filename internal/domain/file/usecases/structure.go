@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+
+	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	fileUtil "github.com/braejan/go-transactions-summary/internal/domain/file/util"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+)
+
+// MaxReportedProblems is the max number of problems listed in a validation report.
+const MaxReportedProblems = 100
+
+// localStructureUseCases struct implements the StructureUseCases interface.
+type localStructureUseCases struct{}
+
+// NewStructureUseCases returns a new localStructureUseCases instance. It has no
+// dependencies, so it can run where there is no database.
+func NewStructureUseCases() (useCases StructureUseCases) {
+	useCases = &localStructureUseCases{}
+	return
+}
+
+// CheckStructure checks the header and every line of the file and reports the invalid lines.
+func (useCases *localStructureUseCases) CheckStructure(txFile fileEntity.TxFile, reader io.Reader) (report *fileEntity.ValidationReport, err error) {
+	if reader == nil {
+		err = voFile.ErrFileReaderIsEmpty
+		return
+	}
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	header, err := csvReader.Read()
+	if err != nil {
+		if err == io.EOF {
+			err = voFile.ErrFileIsEmpty
+			return
+		}
+		var parseErr *csv.ParseError
+		if !errors.As(err, &parseErr) {
+			err = voFile.ErrFileCouldNotBeRead
+			return
+		}
+		header = nil
+	}
+	report = fileEntity.NewValidationReport(txFile.Name)
+	if errHeader := fileUtil.CheckHeader(header); errHeader != nil {
+		report.AddProblem(fileEntity.LineProblem{Line: 1, Error: errHeader.Error()}, MaxReportedProblems)
+	}
+	for {
+		record, errRead := csvReader.Read()
+		if errRead == io.EOF {
+			break
+		}
+		if errRead != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(errRead, &parseErr) {
+				report = nil
+				err = voFile.ErrFileCouldNotBeRead
+				return
+			}
+			report.Lines++
+			report.AddProblem(fileEntity.LineProblem{Line: parseErr.StartLine, Error: voFile.ErrFileLineCouldNotBeParsed.Error()}, MaxReportedProblems)
+			continue
+		}
+		report.Lines++
+		var lineErr *voFile.LineError
+		if !errors.As(fileUtil.CheckRecord(record), &lineErr) {
+			continue
+		}
+		line, _ := csvReader.FieldPos(0)
+		report.AddProblem(fileEntity.LineProblem{Line: line, Column: lineErr.Column, Value: lineErr.Value, Error: lineErr.Err.Error()}, MaxReportedProblems)
+	}
+	return
+}
@@ -0,0 +1,100 @@
+package usecases_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/stretchr/testify/assert"
+)
+
+func checkTestFile(t *testing.T, name string) (report *entity.ValidationReport, err error) {
+	osFile, err := os.Open(fmt.Sprintf("test/files/%s", name))
+	assert.Nil(t, err)
+	defer osFile.Close()
+	return usecases.NewStructureUseCases().CheckStructure(*entity.NewTxFile(name, "", "", 0), osFile)
+}
+
+// TestCheckStructureWithValidFile tests the CheckStructure method with a valid file.
+func TestCheckStructureWithValidFile(t *testing.T) {
+	// When CheckStructure is called with a valid file
+	report, err := checkTestFile(t, "txns_simple.csv")
+	// Then the report is valid
+	assert.Nil(t, err)
+	assert.True(t, report.IsValid())
+	assert.Equal(t, "txns_simple.csv", report.Name)
+	assert.Equal(t, 4, report.Lines)
+	assert.Empty(t, report.Problems)
+}
+
+// TestCheckStructureWithEmptyFile tests the CheckStructure method with an empty file.
+func TestCheckStructureWithEmptyFile(t *testing.T) {
+	// When CheckStructure is called with an empty file
+	report, err := checkTestFile(t, "txns_empty.csv")
+	// Then the error returned is ErrFileIsEmpty
+	assert.Nil(t, report)
+	assert.Equal(t, voFile.ErrFileIsEmpty, err)
+}
+
+// TestCheckStructureWithNilReader tests the CheckStructure method with a nil reader.
+func TestCheckStructureWithNilReader(t *testing.T) {
+	// When CheckStructure is called with a nil reader
+	report, err := usecases.NewStructureUseCases().CheckStructure(entity.TxFile{}, nil)
+	// Then the error returned is ErrFileReaderIsEmpty
+	assert.Nil(t, report)
+	assert.Equal(t, voFile.ErrFileReaderIsEmpty, err)
+}
+
+// TestCheckStructureWithInvalidFiles tests the CheckStructure method reports the invalid column of each file.
+func TestCheckStructureWithInvalidFiles(t *testing.T) {
+	cases := map[string][]entity.LineProblem{
+		"txns_invalid_id.csv":          {{Line: 2, Column: "Id", Value: "stori-challenge", Error: voFile.ErrFileIDIsInvalid.Error()}},
+		"txns_invalid_date.csv":        {{Line: 2, Column: "Date", Value: "24/7", Error: voFile.ErrFileDateIsInvalid.Error()}},
+		"txns_invalid_amount.csv":      {{Line: 2, Column: "Date", Value: "24/7", Error: voFile.ErrFileDateIsInvalid.Error()}},
+		"txns_invalid_last_record.csv": {{Line: 5, Column: "Transaction", Value: "10", Error: voFile.ErrFileAmountIsInvalid.Error()}},
+		"txns_invalid_columns.csv": {
+			{Line: 1, Error: voFile.ErrFileHeaderIsInvalid.Error()},
+			{Line: 2, Error: voFile.ErrFileColumnCountIsInvalid.Error()},
+		},
+	}
+	for name, problems := range cases {
+		t.Run(name, func(t *testing.T) {
+			// When CheckStructure is called with an invalid file
+			report, err := checkTestFile(t, name)
+			// Then the report lists the problems of the file
+			assert.Nil(t, err)
+			assert.False(t, report.IsValid())
+			assert.Equal(t, problems, report.Problems)
+		})
+	}
+}
+
+// TestCheckStructureWithMalformedCSV tests the CheckStructure method keeps checking after a malformed line.
+func TestCheckStructureWithMalformedCSV(t *testing.T) {
+	// Given a file with a bare quote in the second line and a header with a byte order mark
+	content := "\ufeffid, date ,TRANSACTION\r\n0,7/5,+60.5\r\n1,7\"/28,-10.3\r\n2,8/2,-20.46\r\n"
+	// When CheckStructure is called
+	report, err := usecases.NewStructureUseCases().CheckStructure(entity.TxFile{Name: "txns.csv"}, strings.NewReader(content))
+	// Then only the malformed line is reported
+	assert.Nil(t, err)
+	assert.Equal(t, 3, report.Lines)
+	assert.Equal(t, []entity.LineProblem{{Line: 3, Error: voFile.ErrFileLineCouldNotBeParsed.Error()}}, report.Problems)
+}
+
+// TestCheckStructureTruncatesProblems tests the CheckStructure method limits the listed problems.
+func TestCheckStructureTruncatesProblems(t *testing.T) {
+	// Given a file with more invalid lines than the reported problems
+	content := "Id,Date,Transaction\n" + strings.Repeat("x,7/5,+1\n", usecases.MaxReportedProblems+5)
+	// When CheckStructure is called
+	report, err := usecases.NewStructureUseCases().CheckStructure(entity.TxFile{}, strings.NewReader(content))
+	// Then every invalid line is counted
+	assert.Nil(t, err)
+	assert.Equal(t, usecases.MaxReportedProblems+5, report.InvalidLines)
+	// And the listed problems are truncated
+	assert.Len(t, report.Problems, usecases.MaxReportedProblems)
+	assert.True(t, report.Truncated)
+}
@@ -1,20 +1,39 @@
 package usecases
 
 import (
+	"context"
+	"io"
 	"mime/multipart"
 	"os"
 
 	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
 )
 
+// StructureUseCases interface defines the file checks that do not use the database.
+type StructureUseCases interface {
+	// CheckStructure checks the header, the column count and the Id, Date and Transaction
+	// formats of every line, and reports the invalid lines.
+	CheckStructure(txFile fileEntity.TxFile, reader io.Reader) (report *fileEntity.ValidationReport, err error)
+}
+
 // FileUseCases interface defines the file use cases.
 type FileUseCases interface {
+	StructureUseCases
 	// ReadFile reads the file from the given path or S3 bucket.
-	ReadAndProcessFile(txFile fileEntity.TxFile, isS3 bool) (err error)
+	ReadAndProcessFile(ctx context.Context, txFile fileEntity.TxFile, isS3 bool) (err error)
 	// CheckFile checks if is a valid structured file.
 	CheckFile(txFile fileEntity.TxFile, isS3 bool) (err error)
-	// ProcessFile processes the file.
-	ProcessFile(txFile fileEntity.TxFile, file *os.File) (err error)
-	// ProcessMultipartFile processes the file.
-	ProcessMultipartFile(txFile fileEntity.TxFile, file multipart.File) (err error)
+	// ProcessFile processes the file and returns the number of stored transactions, logging
+	// with the request and the file IDs of the context.
+	ProcessFile(ctx context.Context, txFile fileEntity.TxFile, file *os.File) (transactions int, err error)
+	// ProcessMultipartFile processes the file and returns the number of stored transactions,
+	// logging with the request and the file IDs of the context.
+	ProcessMultipartFile(ctx context.Context, txFile fileEntity.TxFile, file multipart.File) (transactions int, err error)
+	// ValidateFile checks the file and previews the users, accounts and transactions that
+	// processing it would create, without creating them.
+	ValidateFile(ctx context.Context, txFile fileEntity.TxFile, reader io.Reader) (preview *fileEntity.ValidationPreview, err error)
+	// CheckDailyQuota returns the files the uploader uploaded today, with
+	// quota.ErrDailyQuotaExceeded when it cannot upload more.
+	CheckDailyQuota(ctx context.Context, uploader string) (usage quota.Usage, err error)
 }
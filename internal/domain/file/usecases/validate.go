@@ -0,0 +1,128 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+
+	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	fileUtil "github.com/braejan/go-transactions-summary/internal/domain/file/util"
+	voAccount "github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+	voUser "github.com/braejan/go-transactions-summary/internal/valueobject/user"
+)
+
+// ValidateFile checks the structure of the file and resolves the users and accounts of its
+// valid lines without creating anything.
+func (useCases *localFileUseCases) ValidateFile(ctx context.Context, txFile fileEntity.TxFile, reader io.Reader) (preview *fileEntity.ValidationPreview, err error) {
+	ctx, span := tracing.Start(ctx, "FileUseCases.ValidateFile", "file.name", txFile.Name)
+	defer span.EndWithError(&err)
+	if reader == nil {
+		err = voFile.ErrFileReaderIsEmpty
+		return
+	}
+	// The file is read twice, once to check its structure and once to resolve its users.
+	content, err := io.ReadAll(useCases.limitReader(reader))
+	if errors.Is(err, quota.ErrFileTooLarge) {
+		return
+	}
+	if err != nil {
+		err = voFile.ErrFileCouldNotBeRead
+		return
+	}
+	report, err := useCases.CheckStructure(txFile, bytes.NewReader(content))
+	if err != nil {
+		return
+	}
+	users, err := useCases.previewUsers(ctx, bytes.NewReader(content))
+	if err != nil {
+		return
+	}
+	preview = fileEntity.NewValidationPreview(report)
+	for _, user := range users {
+		preview.AddUser(*user)
+	}
+	return
+}
+
+// previewUsers returns the users of the valid lines in order of appearance, with the
+// transactions that would be created for them. The invalid lines are skipped, they are
+// already listed in the validation report.
+func (useCases *localFileUseCases) previewUsers(ctx context.Context, reader io.Reader) (users []*fileEntity.UserPreview, err error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.ReuseRecord = true
+	byID := map[int64]*fileEntity.UserPreview{}
+	// Skip the header, CheckStructure already checked it.
+	if _, err = csvReader.Read(); err != nil {
+		err = voFile.ErrFileCouldNotBeRead
+		return
+	}
+	for {
+		record, errRead := csvReader.Read()
+		if errRead == io.EOF {
+			break
+		}
+		if errRead != nil {
+			continue
+		}
+		userID, _, amount, errCheck := fileUtil.ParseRecord(record)
+		if errCheck != nil {
+			continue
+		}
+		// A zero amount is neither a credit nor a debit, processing the file rejects it
+		// with ErrTransactionAmountIsZero, so it is not previewed as a transaction.
+		if amount == 0 {
+			continue
+		}
+		user, ok := byID[userID]
+		if !ok {
+			user, err = useCases.resolveUser(ctx, userID)
+			if err != nil {
+				users = nil
+				return
+			}
+			byID[userID] = user
+			users = append(users, user)
+		}
+		user.Transactions++
+		if amount > 0 {
+			user.TotalCredits += amount
+		} else {
+			user.TotalDebits += amount
+		}
+	}
+	return
+}
+
+// resolveUser looks up the user and its account, as processing the file would, without
+// creating them.
+func (useCases *localFileUseCases) resolveUser(ctx context.Context, userID int64) (user *fileEntity.UserPreview, err error) {
+	user = &fileEntity.UserPreview{UserID: userID}
+	_, err = useCases.userUseCases.GetByID(ctx, userID)
+	if errors.Is(err, voUser.ErrUserNotFound) {
+		// A new user has no account yet.
+		user.NewUser = true
+		user.NewAccount = true
+		err = nil
+		return
+	}
+	if err != nil {
+		user = nil
+		return
+	}
+	_, err = useCases.accountUseCases.GetByUserID(ctx, userID)
+	if errors.Is(err, voAccount.ErrAccountNotFound) {
+		user.NewAccount = true
+		err = nil
+		return
+	}
+	if err != nil {
+		user = nil
+	}
+	return
+}
@@ -0,0 +1,102 @@
+package usecases_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	acEntity "github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	accMockUseCases "github.com/braejan/go-transactions-summary/internal/domain/account/usecases/mock"
+	"github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	txMockUseCases "github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases/mock"
+	userEntity "github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	userMockUseCases "github.com/braejan/go-transactions-summary/internal/domain/user/usecases/mock"
+	voAccount "github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	voUser "github.com/braejan/go-transactions-summary/internal/valueobject/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestValidateFilePreview tests the users, accounts and totals that would be created are previewed.
+func TestValidateFilePreview(t *testing.T) {
+	// Given an existing user 0 with an account, an existing user 1 without account and a new user 2
+	userUseCases := userMockUseCases.NewMockUserUseCases()
+	userUseCases.On("GetByID", mock.Anything, int64(0)).Return(*userEntity.NewUser(0, "User Name 0", "user.email0@amazingemail.com"), nil)
+	userUseCases.On("GetByID", mock.Anything, int64(1)).Return(*userEntity.NewUser(1, "User Name 1", "user.email1@amazingemail.com"), nil)
+	userUseCases.On("GetByID", mock.Anything, int64(2)).Return(userEntity.User{}, voUser.ErrUserNotFound)
+	accountUseCases := accMockUseCases.NewMockAccountUseCases()
+	accountUseCases.On("GetByUserID", mock.Anything, int64(0)).Return(*acEntity.NewAccount(0), nil)
+	accountUseCases.On("GetByUserID", mock.Anything, int64(1)).Return(acEntity.Account{}, voAccount.ErrAccountNotFound)
+	transactionUseCases := txMockUseCases.NewMockTransactionUseCases()
+	useCases, _ := usecases.NewFileUseCases(userUseCases, accountUseCases, transactionUseCases)
+	// And a file with an invalid line
+	content := "Id,Date,Transaction\n0,7/5,+60.5\n1,7/28,-10.3\n0,8/2,-20.5\n2,13/45,+10\n2,8/13,+10\n"
+	// When validating the file
+	preview, err := useCases.ValidateFile(context.Background(), *entity.NewTxFile("txns.csv", "", "", 0), strings.NewReader(content))
+	// Then the report lists the invalid line
+	assert.Nil(t, err)
+	assert.False(t, preview.Valid)
+	assert.Equal(t, 5, preview.Report.Lines)
+	assert.Equal(t, 1, preview.Report.InvalidLines)
+	// And the new users and accounts are previewed
+	assert.Equal(t, []int64{2}, preview.NewUsers)
+	assert.Equal(t, []int64{1, 2}, preview.NewAccounts)
+	// And the totals of the valid lines are previewed per user
+	assert.Equal(t, []entity.UserPreview{
+		{UserID: 0, Transactions: 2, TotalCredits: 60.5, TotalDebits: -20.5},
+		{UserID: 1, NewAccount: true, Transactions: 1, TotalDebits: -10.3},
+		{UserID: 2, NewUser: true, NewAccount: true, Transactions: 1, TotalCredits: 10},
+	}, preview.Users)
+	// And nothing is created
+	userUseCases.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	accountUseCases.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	transactionUseCases.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestValidateFileEmpty tests the error returned when the file is empty.
+func TestValidateFileEmpty(t *testing.T) {
+	// Given valid file use cases
+	useCases, _ := usecases.NewFileUseCases(userMockUseCases.NewMockUserUseCases(), accMockUseCases.NewMockAccountUseCases(), txMockUseCases.NewMockTransactionUseCases())
+	// When validating an empty file
+	preview, err := useCases.ValidateFile(context.Background(), *entity.NewTxFile("txns.csv", "", "", 0), strings.NewReader(""))
+	// Then the error returned is ErrFileIsEmpty
+	assert.Nil(t, preview)
+	assert.Equal(t, voFile.ErrFileIsEmpty, err)
+	// And a nil reader returns ErrFileReaderIsEmpty
+	_, err = useCases.ValidateFile(context.Background(), *entity.NewTxFile("txns.csv", "", "", 0), nil)
+	assert.Equal(t, voFile.ErrFileReaderIsEmpty, err)
+}
+
+// TestValidateFileErrResolvingUser tests the error returned when a user cannot be looked up.
+func TestValidateFileErrResolvingUser(t *testing.T) {
+	// Given user use cases failing to look up the users
+	userUseCases := userMockUseCases.NewMockUserUseCases()
+	userUseCases.On("GetByID", mock.Anything, int64(0)).Return(userEntity.User{}, voPostgres.ErrOpeningDatabase)
+	useCases, _ := usecases.NewFileUseCases(userUseCases, accMockUseCases.NewMockAccountUseCases(), txMockUseCases.NewMockTransactionUseCases())
+	// When validating a file
+	preview, err := useCases.ValidateFile(context.Background(), *entity.NewTxFile("txns.csv", "", "", 0), strings.NewReader("Id,Date,Transaction\n0,7/5,+60.5\n"))
+	// Then the error is returned
+	assert.Nil(t, preview)
+	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+}
+
+// TestValidateFileZeroAmount tests a zero amount is not previewed as a debit.
+func TestValidateFileZeroAmount(t *testing.T) {
+	// Given an existing user 0 with an account
+	userUseCases := userMockUseCases.NewMockUserUseCases()
+	userUseCases.On("GetByID", mock.Anything, int64(0)).Return(*userEntity.NewUser(0, "User Name 0", "user.email0@amazingemail.com"), nil)
+	accountUseCases := accMockUseCases.NewMockAccountUseCases()
+	accountUseCases.On("GetByUserID", mock.Anything, int64(0)).Return(*acEntity.NewAccount(0), nil)
+	useCases, _ := usecases.NewFileUseCases(userUseCases, accountUseCases, txMockUseCases.NewMockTransactionUseCases())
+	// When validating a file with a zero amount line
+	content := "Id,Date,Transaction\n0,7/5,+60.5\n0,7/28,0\n"
+	preview, err := useCases.ValidateFile(context.Background(), *entity.NewTxFile("txns.csv", "", "", 0), strings.NewReader(content))
+	// Then the zero amount is neither a transaction nor a debit
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.UserPreview{
+		{UserID: 0, Transactions: 1, TotalCredits: 60.5},
+	}, preview.Users)
+}
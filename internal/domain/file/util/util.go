@@ -1 +1,92 @@
 package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+)
+
+// amountRegex validates the amount has an explicit sign.
+var amountRegex = regexp.MustCompile(`^[-|+]+[0-9]+(\.[0-9]*)?$`)
+
+// Header is the expected header of a transactions file.
+var Header = []string{"Id", "Date", "Transaction"}
+
+// CheckHeader checks the header has the columns of Header, ignoring case, spaces and a
+// leading byte order mark.
+func CheckHeader(record []string) (err error) {
+	if len(record) != len(Header) {
+		err = voFile.ErrFileHeaderIsInvalid
+		return
+	}
+	for i, column := range record {
+		if !strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")), Header[i]) {
+			err = voFile.ErrFileHeaderIsInvalid
+			return
+		}
+	}
+	return
+}
+
+// ParseRecord parses a CSV record with the columns Id, Date (month/day) and a signed Transaction
+// amount. The error is a *voFile.LineError with the invalid column and the cause, without line.
+func ParseRecord(record []string) (id int64, txDate time.Time, amount float64, err error) {
+	if len(record) != len(Header) {
+		err = &voFile.LineError{Err: voFile.ErrFileColumnCountIsInvalid}
+		return
+	}
+	// Validate the position 0 as a valid int64.
+	id, err = strconv.ParseInt(record[0], 10, 64)
+	if err != nil {
+		err = columnError(record, 0, voFile.ErrFileIDIsInvalid, err)
+		return
+	}
+	// Validate the position 1 as a valid date format "1/2".
+	txDate, err = time.Parse("1/2", record[1])
+	if err != nil {
+		err = columnError(record, 1, voFile.ErrFileDateIsInvalid, err)
+		return
+	}
+	// Validate the position 2 as a valid float64.
+	if !amountRegex.MatchString(record[2]) {
+		err = columnError(record, 2, voFile.ErrFileAmountIsInvalid, nil)
+		return
+	}
+	amount, err = strconv.ParseFloat(record[2], 64)
+	if err != nil {
+		err = columnError(record, 2, voFile.ErrFileAmountIsInvalid, err)
+	}
+	return
+}
+
+// CheckRecord checks the columns of a record in order and returns the *voFile.LineError of
+// the first invalid one.
+func CheckRecord(record []string) (err error) {
+	_, _, _, err = ParseRecord(record)
+	return
+}
+
+// columnError returns the error of the invalid column of the record.
+func columnError(record []string, column int, err error, cause error) *voFile.LineError {
+	return &voFile.LineError{Column: Header[column], Value: record[column], Err: err, Cause: cause}
+}
+
+// HashFile returns the hex encoded SHA-256 of the file content and rewinds the file,
+// so the same upload always gets the same hash.
+func HashFile(file io.ReadSeeker) (hash string, err error) {
+	hasher := sha256.New()
+	if _, err = io.Copy(hasher, file); err != nil {
+		return
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	hash = hex.EncodeToString(hasher.Sum(nil))
+	return
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"time"
+)
+
+// Message struct defines an email message with a plain text and an HTML alternative.
+type Message struct {
+	// ID identifies the message, it is written as the Message-ID header when it is not empty.
+	ID string
+	// From is the sender address.
+	From string
+	// To is the recipient address.
+	To string
+	// Subject is the subject of the message.
+	Subject string
+	// Text is the plain text body.
+	Text string
+	// HTML is the HTML body.
+	HTML string
+}
+
+// NewMessage returns a new Message instance.
+func NewMessage(from, to, subject, text, html string) (message *Message) {
+	message = &Message{
+		From:    from,
+		To:      to,
+		Subject: subject,
+		Text:    text,
+		HTML:    html,
+	}
+	return
+}
+
+// MIME returns the message encoded as a multipart/alternative MIME message.
+func (message *Message) MIME(date time.Time) (raw []byte, err error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{contentType: "text/plain; charset=UTF-8", content: message.Text},
+		{contentType: "text/html; charset=UTF-8", content: message.HTML},
+	}
+	for _, part := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		partWriter, errPart := writer.CreatePart(header)
+		if errPart != nil {
+			err = errPart
+			return
+		}
+		encoder := quotedprintable.NewWriter(partWriter)
+		if _, err = encoder.Write([]byte(part.content)); err != nil {
+			return
+		}
+		if err = encoder.Close(); err != nil {
+			return
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return
+	}
+	headers := &bytes.Buffer{}
+	fmt.Fprintf(headers, "From: %s\r\n", message.From)
+	fmt.Fprintf(headers, "To: %s\r\n", message.To)
+	fmt.Fprintf(headers, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", message.Subject))
+	fmt.Fprintf(headers, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	if message.ID != "" {
+		fmt.Fprintf(headers, "Message-ID: <%s@transactions-summary>\r\n", message.ID)
+	}
+	fmt.Fprintf(headers, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(headers, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", writer.Boundary())
+	raw = append(headers.Bytes(), body.Bytes()...)
+	return
+}
@@ -0,0 +1,58 @@
+package entity_test
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewMessage tests the NewMessage function.
+func TestNewMessage(t *testing.T) {
+	// When call NewMessage
+	message := entity.NewMessage("from@amazingemail.com", "to@amazingemail.com", "Resumen", "texto", "<p>texto</p>")
+	// Then the message has the given fields
+	assert.Equal(t, "from@amazingemail.com", message.From)
+	assert.Equal(t, "to@amazingemail.com", message.To)
+	assert.Equal(t, "Resumen", message.Subject)
+	assert.Equal(t, "texto", message.Text)
+	assert.Equal(t, "<p>texto</p>", message.HTML)
+}
+
+// TestMIME tests the MIME method builds a parseable multipart/alternative message.
+func TestMIME(t *testing.T) {
+	// Given a message with non ASCII characters
+	message := entity.NewMessage("from@amazingemail.com", "to@amazingemail.com", "Resumen de tus transacciones ✅", "Saldo: $10,50", "<p>Crédito</p>")
+	// When call MIME
+	raw, err := message.MIME(time.Date(2023, time.June, 1, 10, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	// Then the message can be parsed
+	parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+	assert.Nil(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	assert.Nil(t, err)
+	assert.Equal(t, "Resumen de tus transacciones ✅", subject)
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	assert.Nil(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+	// And it has the plain text part followed by the HTML part
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	textPart, err := reader.NextPart()
+	assert.Nil(t, err)
+	assert.Equal(t, "text/plain; charset=UTF-8", textPart.Header.Get("Content-Type"))
+	text, _ := io.ReadAll(textPart)
+	assert.Equal(t, "Saldo: $10,50", string(text))
+	htmlPart, err := reader.NextPart()
+	assert.Nil(t, err)
+	assert.Equal(t, "text/html; charset=UTF-8", htmlPart.Header.Get("Content-Type"))
+	html, _ := io.ReadAll(htmlPart)
+	assert.Equal(t, "<p>Crédito</p>", string(html))
+	_, err = reader.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// OutboxStatusPending is the status of a message waiting to be delivered.
+	OutboxStatusPending = "pending"
+	// OutboxStatusDelivered is the status of a delivered message.
+	OutboxStatusDelivered = "delivered"
+	// OutboxStatusDead is the status of a message that reached the max delivery attempts.
+	OutboxStatusDead = "dead"
+)
+
+// deliveryNamespace is the namespace of the delivery IDs.
+var deliveryNamespace = uuid.MustParse("6f1d4a3e-2b7c-4f55-9a0e-8c3b1d2e4f60")
+
+// OutboxMessage struct defines a message stored in the outbox until it is delivered.
+type OutboxMessage struct {
+	// ID is the delivery ID. It is derived from the file and the user so the same
+	// summary is enqueued and delivered only once.
+	ID uuid.UUID
+	// Recipient is the recipient address.
+	Recipient string
+	// Subject is the subject of the message.
+	Subject string
+	// Payload is the MIME encoded message.
+	Payload []byte
+	// Status is the delivery status of the message.
+	Status string
+	// Attempts is the number of delivery attempts.
+	Attempts int
+	// NextAttemptAt is the date and time when the message can be delivered.
+	NextAttemptAt time.Time
+	// LastError is the error of the last failed delivery attempt.
+	LastError string
+	// CreatedAt is the date and time when the message was enqueued.
+	CreatedAt time.Time
+	// DeliveredAt is the date and time when the message was delivered.
+	DeliveredAt time.Time
+}
+
+// NewDeliveryID returns the delivery ID of the summary of a user in a file.
+func NewDeliveryID(fileHash string, userID int64) (deliveryID uuid.UUID) {
+	deliveryID = uuid.NewSHA1(deliveryNamespace, []byte(fmt.Sprintf("%s/%d", fileHash, userID)))
+	return
+}
+
+// NewOutboxMessage returns a new pending OutboxMessage with the MIME encoded message.
+func NewOutboxMessage(deliveryID uuid.UUID, message Message, now time.Time) (outboxMessage *OutboxMessage, err error) {
+	message.ID = deliveryID.String()
+	payload, err := message.MIME(now)
+	if err != nil {
+		return
+	}
+	outboxMessage = &OutboxMessage{
+		ID:            deliveryID,
+		Recipient:     message.To,
+		Subject:       message.Subject,
+		Payload:       payload,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+	return
+}
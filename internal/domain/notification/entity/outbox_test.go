@@ -0,0 +1,46 @@
+package entity_test
+
+import (
+	"bytes"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewDeliveryID tests the NewDeliveryID function is deterministic.
+func TestNewDeliveryID(t *testing.T) {
+	// When call NewDeliveryID twice with the same file and user
+	first := entity.NewDeliveryID("file-hash", int64(1))
+	second := entity.NewDeliveryID("file-hash", int64(1))
+	// Then the delivery IDs are the same
+	assert.Equal(t, first, second)
+	// And they are different for other users and files
+	assert.NotEqual(t, first, entity.NewDeliveryID("file-hash", int64(2)))
+	assert.NotEqual(t, first, entity.NewDeliveryID("other-hash", int64(1)))
+}
+
+// TestNewOutboxMessage tests the NewOutboxMessage function.
+func TestNewOutboxMessage(t *testing.T) {
+	// Given a delivery ID and a message
+	deliveryID := entity.NewDeliveryID("file-hash", int64(1))
+	message := entity.NewMessage("from@amazingemail.com", "to@amazingemail.com", "Resumen", "texto", "<p>texto</p>")
+	now := time.Date(2023, time.June, 1, 10, 0, 0, 0, time.UTC)
+	// When call NewOutboxMessage
+	outboxMessage, err := entity.NewOutboxMessage(deliveryID, *message, now)
+	// Then the message is pending and can be delivered now
+	assert.Nil(t, err)
+	assert.Equal(t, deliveryID, outboxMessage.ID)
+	assert.Equal(t, "to@amazingemail.com", outboxMessage.Recipient)
+	assert.Equal(t, "Resumen", outboxMessage.Subject)
+	assert.Equal(t, entity.OutboxStatusPending, outboxMessage.Status)
+	assert.Equal(t, 0, outboxMessage.Attempts)
+	assert.Equal(t, now, outboxMessage.NextAttemptAt)
+	assert.True(t, outboxMessage.DeliveredAt.IsZero())
+	// And the payload has the delivery ID as Message-ID
+	parsed, err := mail.ReadMessage(bytes.NewReader(outboxMessage.Payload))
+	assert.Nil(t, err)
+	assert.Equal(t, "<"+deliveryID.String()+"@transactions-summary>", parsed.Header.Get("Message-ID"))
+}
@@ -0,0 +1,56 @@
+package notifier
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+)
+
+// fileNotifier struct implements the Notifier interface writing the messages as .eml files.
+type fileNotifier struct {
+	// dir is the directory where the messages are written.
+	dir string
+}
+
+// NewFileNotifier returns a Notifier that writes each message to "<dir>/<id>.eml".
+func NewFileNotifier(dir string) (notifier Notifier, err error) {
+	if dir == "" {
+		err = voNotification.ErrNotifierIsInvalid
+		return
+	}
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		err = voNotification.ErrNotifierIsInvalid
+		return
+	}
+	notifier = &fileNotifier{
+		dir: dir,
+	}
+	return
+}
+
+// Notify implements the Notifier interface method.
+// A message already written is not written again.
+func (notifier *fileNotifier) Notify(message entity.OutboxMessage) (err error) {
+	path := filepath.Join(notifier.dir, message.ID.String()+".eml")
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = voNotification.ErrDeliveringMessage
+		return
+	}
+	_, err = file.Write(message.Payload)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		err = voNotification.ErrDeliveringMessage
+	}
+	return
+}
@@ -0,0 +1,22 @@
+package notifier
+
+import (
+	"log"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+)
+
+// logNotifier struct implements the Notifier interface writing the messages to the log.
+type logNotifier struct{}
+
+// NewLogNotifier returns a Notifier that only logs the messages, useful in local environments.
+func NewLogNotifier() (notifier Notifier) {
+	notifier = &logNotifier{}
+	return
+}
+
+// Notify implements the Notifier interface method.
+func (notifier *logNotifier) Notify(message entity.OutboxMessage) (err error) {
+	log.Printf("📧 message %s to %s: %s (%d bytes)", message.ID, message.Recipient, message.Subject, len(message.Payload))
+	return
+}
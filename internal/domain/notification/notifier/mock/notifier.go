@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockNotifier is a mock of the Notifier interface implementation.
+type mockNotifier struct {
+	mock.Mock
+}
+
+// NewMockNotifier returns a new mock instance.
+func NewMockNotifier() *mockNotifier {
+	return &mockNotifier{}
+}
+
+// Notify provides a mock function with given fields: message
+func (_m *mockNotifier) Notify(message entity.OutboxMessage) (err error) {
+	ret := _m.Called(message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(entity.OutboxMessage) error); ok {
+		r0 = rf(message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,39 @@
+package notifier
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+)
+
+// Notifier interface defines the methods that a message delivery channel must implement.
+// The delivery is at least once: the dispatcher marks a message as delivered after Notify
+// returns, so a crash or a failed update in between calls Notify again for the same
+// message. Implementations able to detect a message already delivered by its ID should
+// skip it, the others deliver it again.
+type Notifier interface {
+	// Notify delivers the message.
+	Notify(message entity.OutboxMessage) (err error)
+}
+
+// NewNotifier returns the notifier named in the configuration. The options configure the
+// SES notifier.
+func NewNotifier(configuration *voNotification.NotificationConfiguration, options ...SESNotifierOption) (notifier Notifier, err error) {
+	switch configuration.Notifier {
+	case voNotification.LogNotifier:
+		notifier = NewLogNotifier()
+	case voNotification.FileNotifier:
+		notifier, err = NewFileNotifier(configuration.NotifierDir)
+	case voNotification.SESNotifier:
+		sess, errSession := session.NewSession()
+		if errSession != nil {
+			err = errSession
+			return
+		}
+		notifier, err = NewSESNotifier(ses.New(sess), options...)
+	default:
+		err = voNotification.ErrUnknownNotifier
+	}
+	return
+}
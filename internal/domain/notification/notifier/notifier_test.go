@@ -0,0 +1,142 @@
+package notifier_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/aws/aws-sdk-go/service/ses/sesiface"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/notifier"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSES records the raw messages sent to SES.
+type fakeSES struct {
+	sesiface.SESAPI
+	inputs []*ses.SendRawEmailInput
+	err    error
+}
+
+func (fake *fakeSES) SendRawEmail(input *ses.SendRawEmailInput) (*ses.SendRawEmailOutput, error) {
+	fake.inputs = append(fake.inputs, input)
+	return &ses.SendRawEmailOutput{}, fake.err
+}
+
+func getTestOutboxMessage(t *testing.T) entity.OutboxMessage {
+	message := entity.NewMessage("from@amazingemail.com", "to@amazingemail.com", "Resumen", "texto", "<p>texto</p>")
+	outboxMessage, err := entity.NewOutboxMessage(entity.NewDeliveryID("hash", int64(1)), *message, time.Now())
+	assert.Nil(t, err)
+	return *outboxMessage
+}
+
+// TestNewNotifier tests the NewNotifier function.
+func TestNewNotifier(t *testing.T) {
+	// Given the default configuration
+	configuration := voNotification.NewDefaultNotificationConfiguration()
+	// When call NewNotifier
+	logNotifier, err := notifier.NewNotifier(configuration)
+	// Then the log notifier is returned
+	assert.Nil(t, err)
+	assert.NotNil(t, logNotifier)
+	// And an unknown notifier returns an error
+	configuration.Notifier = "pigeon"
+	_, err = notifier.NewNotifier(configuration)
+	assert.Equal(t, voNotification.ErrUnknownNotifier, err)
+}
+
+// TestLogNotifier tests the log notifier never fails.
+func TestLogNotifier(t *testing.T) {
+	// Given a log notifier
+	logNotifier := notifier.NewLogNotifier()
+	// When call Notify
+	err := logNotifier.Notify(getTestOutboxMessage(t))
+	// Then the error is nil
+	assert.Nil(t, err)
+}
+
+// TestNewFileNotifierWithEmptyDir tests the error returned when the directory is empty.
+func TestNewFileNotifierWithEmptyDir(t *testing.T) {
+	// When call NewFileNotifier with an empty directory
+	_, err := notifier.NewFileNotifier("")
+	// Then the error is ErrNotifierIsInvalid
+	assert.Equal(t, voNotification.ErrNotifierIsInvalid, err)
+}
+
+// TestFileNotifierIsIdempotent tests a message is written once.
+func TestFileNotifierIsIdempotent(t *testing.T) {
+	// Given a file notifier
+	dir := filepath.Join(t.TempDir(), "outbox")
+	fileNotifier, err := notifier.NewFileNotifier(dir)
+	assert.Nil(t, err)
+	// And a message
+	message := getTestOutboxMessage(t)
+	// When call Notify twice
+	err = fileNotifier.Notify(message)
+	assert.Nil(t, err)
+	second := message
+	second.Payload = []byte("duplicated")
+	err = fileNotifier.Notify(second)
+	assert.Nil(t, err)
+	// Then the first payload is kept
+	content, err := os.ReadFile(filepath.Join(dir, message.ID.String()+".eml"))
+	assert.Nil(t, err)
+	assert.Equal(t, message.Payload, content)
+}
+
+// TestNewSESNotifierWithNilClient tests the error returned when the client is nil.
+func TestNewSESNotifierWithNilClient(t *testing.T) {
+	// When call NewSESNotifier with a nil client
+	_, err := notifier.NewSESNotifier(nil)
+	// Then the error is ErrNotifierIsInvalid
+	assert.Equal(t, voNotification.ErrNotifierIsInvalid, err)
+}
+
+// TestSESNotifier tests the raw message is sent to the recipient.
+func TestSESNotifier(t *testing.T) {
+	// Given a SES notifier
+	client := &fakeSES{}
+	sesNotifier, err := notifier.NewSESNotifier(client)
+	assert.Nil(t, err)
+	// When call Notify
+	message := getTestOutboxMessage(t)
+	err = sesNotifier.Notify(message)
+	// Then the raw message is sent
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(client.inputs))
+	assert.Equal(t, "to@amazingemail.com", *client.inputs[0].Destinations[0])
+	assert.Equal(t, message.Payload, client.inputs[0].RawMessage.Data)
+	// And a SES error returns ErrDeliveringMessage
+	client.err = errors.New("throttled")
+	err = sesNotifier.Notify(message)
+	assert.Equal(t, voNotification.ErrDeliveringMessage, err)
+}
+
+// TestSESNotifierLogsErrors tests the errors of SES are logged with the message ID.
+func TestSESNotifierLogsErrors(t *testing.T) {
+	// When call NewSESNotifier with a nil logger
+	_, err := notifier.NewSESNotifier(&fakeSES{}, notifier.WithSESLogger(nil))
+	// Then the error is ErrNilLogger
+	assert.Equal(t, logger.ErrNilLogger, err)
+	// Given a SES notifier failing to send and logging to a buffer
+	output := &bytes.Buffer{}
+	sesNotifier, err := notifier.NewSESNotifier(&fakeSES{err: errors.New("throttled")},
+		notifier.WithSESLogger(logger.NewJSONLogger(output, logger.LevelInfo)))
+	assert.Nil(t, err)
+	// When call Notify
+	message := getTestOutboxMessage(t)
+	_ = sesNotifier.Notify(message)
+	// Then the error is logged with the message ID
+	line := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(output.Bytes(), &line))
+	assert.Equal(t, "error sending message with SES", line["msg"])
+	assert.Equal(t, message.ID.String(), line["message_id"])
+	assert.Equal(t, "throttled", line["error"])
+}
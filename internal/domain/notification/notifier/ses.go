@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/aws/aws-sdk-go/service/ses/sesiface"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+)
+
+// sesNotifier struct implements the Notifier interface sending the messages with Amazon SES.
+type sesNotifier struct {
+	client sesiface.SESAPI
+	// logger logs the errors of SES.
+	logger logger.Logger
+}
+
+// SESNotifierOption configures an optional collaborator of the SES notifier.
+type SESNotifierOption func(notifier *sesNotifier) (err error)
+
+// WithSESLogger logs the errors of SES with the logger instead of the default one.
+func WithSESLogger(log logger.Logger) SESNotifierOption {
+	return func(notifier *sesNotifier) (err error) {
+		if log == nil {
+			err = logger.ErrNilLogger
+			return
+		}
+		notifier.logger = log
+		return
+	}
+}
+
+// NewSESNotifier returns a Notifier that sends the raw MIME messages with Amazon SES.
+func NewSESNotifier(client sesiface.SESAPI, options ...SESNotifierOption) (notifier Notifier, err error) {
+	if client == nil {
+		err = voNotification.ErrNotifierIsInvalid
+		return
+	}
+	newNotifier := &sesNotifier{
+		client: client,
+		logger: logger.NewDefaultLogger(),
+	}
+	for _, option := range options {
+		if err = option(newNotifier); err != nil {
+			return
+		}
+	}
+	notifier = newNotifier
+	return
+}
+
+// Notify implements the Notifier interface method.
+// SES does not deduplicate, a message notified again is sent again.
+func (notifier *sesNotifier) Notify(message entity.OutboxMessage) (err error) {
+	_, err = notifier.client.SendRawEmail(&ses.SendRawEmailInput{
+		Destinations: []*string{aws.String(message.Recipient)},
+		RawMessage: &ses.RawMessage{
+			Data: message.Payload,
+		},
+	})
+	if err != nil {
+		notifier.logger.Error("error sending message with SES", "message_id", message.ID, "error", err)
+		err = voNotification.ErrDeliveringMessage
+	}
+	return
+}
@@ -0,0 +1,26 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository/memory"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository/repositorytest"
+	txMemory "github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/memory"
+	voMemory "github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOutboxRepositoryContract tests the memory repository against the OutboxRepository contract.
+func TestOutboxRepositoryContract(t *testing.T) {
+	repositorytest.RunOutboxRepositoryContract(t, func(t *testing.T) (repository.OutboxRepository, func(...*entity.OutboxMessage)) {
+		database := voMemory.NewDatabase()
+		transactionRepo := txMemory.NewMemoryTransactionRepository(database)
+		enqueue := func(messages ...*entity.OutboxMessage) {
+			assert.Nil(t, transactionRepo.CreateBatch(context.Background(), nil, messages))
+		}
+		return memory.NewMemoryOutboxRepository(database), enqueue
+	})
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+)
+
+// OutboxTable is the table of the outbox messages. The memory transaction repository
+// enqueues the messages in it.
+const OutboxTable = "outbox"
+
+// memoryOutboxRepository is the in-memory implementation of the outbox repository.
+type memoryOutboxRepository struct {
+	database *memory.Database
+}
+
+// NewMemoryOutboxRepository creates a new instance of repository.OutboxRepository.
+func NewMemoryOutboxRepository(database *memory.Database) (outboxRepo repository.OutboxRepository) {
+	outboxRepo = &memoryOutboxRepository{
+		database: database,
+	}
+	return
+}
+
+// Claim returns the pending messages ready to be delivered, the oldest first, and
+// postpones their next attempt by lease.
+func (memoryRepo *memoryOutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) (messages []*entity.OutboxMessage, err error) {
+	err = memoryRepo.database.Write(func(tx *memory.Tx) error {
+		var ready []*entity.OutboxMessage
+		tx.Scan(OutboxTable, func(row interface{}) bool {
+			message := CopyOutboxMessage(row.(entity.OutboxMessage))
+			if message.Status == entity.OutboxStatusPending && !message.NextAttemptAt.After(now) {
+				ready = append(ready, message)
+			}
+			return true
+		})
+		sort.SliceStable(ready, func(i, j int) bool {
+			return ready[i].NextAttemptAt.Before(ready[j].NextAttemptAt)
+		})
+		if len(ready) > limit {
+			ready = ready[:limit]
+		}
+		for _, message := range ready {
+			message.NextAttemptAt = now.Add(lease)
+			if err := tx.Put(OutboxTable, message.ID.String(), *CopyOutboxMessage(*message)); err != nil {
+				return err
+			}
+		}
+		messages = ready
+		return nil
+	})
+	if err != nil {
+		messages = nil
+	}
+	return
+}
+
+// Update updates the delivery state of a message. Updating a message that does not exist
+// does nothing.
+func (memoryRepo *memoryOutboxRepository) Update(ctx context.Context, message *entity.OutboxMessage) (err error) {
+	if message == nil {
+		err = voNotification.ErrNilOutboxMessage
+		return
+	}
+	err = memoryRepo.database.Write(func(tx *memory.Tx) error {
+		row, ok := tx.Get(OutboxTable, message.ID.String())
+		if !ok {
+			return nil
+		}
+		stored := row.(entity.OutboxMessage)
+		stored.Status = message.Status
+		stored.Attempts = message.Attempts
+		stored.NextAttemptAt = message.NextAttemptAt
+		stored.LastError = message.LastError
+		stored.DeliveredAt = message.DeliveredAt
+		return tx.Put(OutboxTable, message.ID.String(), stored)
+	})
+	return
+}
+
+// CopyOutboxMessage returns a copy of the message that does not share its payload.
+func CopyOutboxMessage(message entity.OutboxMessage) (copied *entity.OutboxMessage) {
+	message.Payload = append([]byte(nil), message.Payload...)
+	copied = &message
+	return
+}
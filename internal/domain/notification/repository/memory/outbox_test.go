@@ -0,0 +1,75 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository/memory"
+	txEntity "github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	txMemory "github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/memory"
+	voMemory "github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// getTestOutbox returns an outbox repository with the messages enqueued.
+func getTestOutbox(t *testing.T, messages ...*entity.OutboxMessage) repository.OutboxRepository {
+	database := voMemory.NewDatabase()
+	assert.Nil(t, txMemory.NewMemoryTransactionRepository(database).CreateBatch(context.Background(), []*txEntity.Transaction{}, messages))
+	return memory.NewMemoryOutboxRepository(database)
+}
+
+// TestClaimReadyMessages tests only the pending messages ready at now are claimed, the oldest first.
+func TestClaimReadyMessages(t *testing.T) {
+	// Given an outbox with two ready messages, a future one and a delivered one
+	now := time.Now()
+	newer := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusPending, NextAttemptAt: now.Add(-time.Minute)}
+	older := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusPending, NextAttemptAt: now.Add(-time.Hour)}
+	future := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusPending, NextAttemptAt: now.Add(time.Hour)}
+	delivered := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusDelivered, NextAttemptAt: now.Add(-time.Hour)}
+	outboxRepo := getTestOutbox(t, newer, older, future, delivered)
+	// When claiming one message
+	claimed, err := outboxRepo.Claim(context.Background(), now, time.Minute, 1)
+	// Then the older ready message is claimed
+	assert.Nil(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, older.ID, claimed[0].ID)
+	// And its next attempt is postponed by the lease
+	assert.Equal(t, now.Add(time.Minute), claimed[0].NextAttemptAt)
+	// And claiming again returns the newer one
+	claimed, _ = outboxRepo.Claim(context.Background(), now, time.Minute, 10)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, newer.ID, claimed[0].ID)
+}
+
+// TestUpdateMessage tests the delivery state of a message is updated.
+func TestUpdateMessage(t *testing.T) {
+	// Given an outbox with a ready message
+	now := time.Now()
+	message := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusPending, NextAttemptAt: now}
+	outboxRepo := getTestOutbox(t, message)
+	claimed, _ := outboxRepo.Claim(context.Background(), now, time.Minute, 10)
+	// When marking it as delivered
+	claimed[0].Status = entity.OutboxStatusDelivered
+	claimed[0].Attempts = 1
+	claimed[0].DeliveredAt = now
+	err := outboxRepo.Update(context.Background(), claimed[0])
+	// Then it is not claimed again
+	assert.Nil(t, err)
+	again, _ := outboxRepo.Claim(context.Background(), now.Add(time.Hour), time.Minute, 10)
+	assert.Empty(t, again)
+}
+
+// TestUpdateWithNilMessage tests the error returned when the message is nil.
+func TestUpdateWithNilMessage(t *testing.T) {
+	// Given an empty outbox
+	outboxRepo := getTestOutbox(t)
+	// When updating a nil message
+	err := outboxRepo.Update(context.Background(), nil)
+	// Then the error returned is ErrNilOutboxMessage
+	assert.Equal(t, voNotification.ErrNilOutboxMessage, err)
+}
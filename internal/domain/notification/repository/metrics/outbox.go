@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository"
+	voMetrics "github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+)
+
+// metricsOutboxRepository struct implements the OutboxRepository interface observing the latency of every
+// method of the decorated repository.
+type metricsOutboxRepository struct {
+	outboxRepo repository.OutboxRepository
+	latency    *voMetrics.Histogram
+}
+
+// NewMetricsOutboxRepository returns the repository decorated with the latency metrics of the registry.
+func NewMetricsOutboxRepository(outboxRepo repository.OutboxRepository, registry *voMetrics.Registry) (decorated repository.OutboxRepository) {
+	decorated = &metricsOutboxRepository{
+		outboxRepo: outboxRepo,
+		latency:    voMetrics.RepositoryLatency(registry),
+	}
+	return
+}
+
+// OutboxRepository interface implementation
+
+func (metricsRepo *metricsOutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) (messages []*entity.OutboxMessage, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "outbox", "Claim")
+	return metricsRepo.outboxRepo.Claim(ctx, now, lease, limit)
+}
+
+func (metricsRepo *metricsOutboxRepository) Update(ctx context.Context, message *entity.OutboxMessage) (err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "outbox", "Update")
+	return metricsRepo.outboxRepo.Update(ctx, message)
+}
@@ -0,0 +1,56 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockOutboxRepository is a mock implementation of the outbox repository.
+type mockOutboxRepository struct {
+	mock.Mock
+}
+
+// NewMockOutboxRepository returns a new mock outbox repository.
+func NewMockOutboxRepository() *mockOutboxRepository {
+	return &mockOutboxRepository{}
+}
+
+// Claim provides a mock function with given fields: ctx, now, lease, limit
+func (_m *mockOutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) (messages []*entity.OutboxMessage, err error) {
+	ret := _m.Called(ctx, now, lease, limit)
+
+	var r0 []*entity.OutboxMessage
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) []*entity.OutboxMessage); ok {
+		r0 = rf(ctx, now, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.OutboxMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration, int) error); ok {
+		r1 = rf(ctx, now, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, message
+func (_m *mockOutboxRepository) Update(ctx context.Context, message *entity.OutboxMessage) (err error) {
+	ret := _m.Called(ctx, message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OutboxMessage) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
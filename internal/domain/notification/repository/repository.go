@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+)
+
+// OutboxRepository interface defines the methods that the outbox repository must implement.
+// Messages are enqueued by the transaction repository in the same database transaction as
+// the ingested rows, this repository only takes care of their delivery state.
+type OutboxRepository interface {
+	// Claim returns up to limit pending messages ready to be delivered at now and postpones
+	// their next attempt by lease, so concurrent dispatchers do not deliver them twice.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) (messages []*entity.OutboxMessage, err error)
+	// Update updates the delivery state of a message.
+	Update(ctx context.Context, message *entity.OutboxMessage) (err error)
+}
@@ -0,0 +1,105 @@
+// Package repositorytest holds the contract of the OutboxRepository: a message is enqueued
+// once and only the ready, undelivered messages are claimed, the oldest first.
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// OutboxRepositoryFactory returns an empty outbox repository for a test, and a function
+// enqueuing messages in it the way the transaction repository does.
+type OutboxRepositoryFactory func(t *testing.T) (outboxRepo repository.OutboxRepository, enqueue func(messages ...*entity.OutboxMessage))
+
+// RunOutboxRepositoryContract runs the contract of the OutboxRepository interface against
+// the repositories of the factory, one empty repository per subtest.
+func RunOutboxRepositoryContract(t *testing.T, newRepository OutboxRepositoryFactory) {
+	// now is truncated so every database stores it exactly.
+	now := time.Now().UTC().Truncate(time.Second)
+	t.Run("ClaimReadyMessages", func(t *testing.T) {
+		// Given an outbox with two ready messages, a future one and a delivered one
+		outboxRepo, enqueue := newRepository(t)
+		newer := newMessage(now.Add(-time.Minute))
+		older := newMessage(now.Add(-time.Hour))
+		future := newMessage(now.Add(time.Hour))
+		delivered := newMessage(now.Add(-time.Hour))
+		delivered.Status = entity.OutboxStatusDelivered
+		enqueue(newer, older, future, delivered)
+		// When claiming one message
+		claimed, err := outboxRepo.Claim(context.Background(), now, time.Minute, 1)
+		// Then the older ready message is claimed with its content
+		assert.Nil(t, err)
+		if assert.Len(t, claimed, 1) {
+			assert.Equal(t, older.ID, claimed[0].ID)
+			assert.Equal(t, older.Recipient, claimed[0].Recipient)
+			assert.Equal(t, older.Payload, claimed[0].Payload)
+			// And its next attempt is postponed by the lease
+			assert.True(t, now.Add(time.Minute).Equal(claimed[0].NextAttemptAt))
+		}
+		// And claiming again returns only the newer one
+		claimed, err = outboxRepo.Claim(context.Background(), now, time.Minute, 10)
+		assert.Nil(t, err)
+		if assert.Len(t, claimed, 1) {
+			assert.Equal(t, newer.ID, claimed[0].ID)
+		}
+	})
+	t.Run("EnqueueOnce", func(t *testing.T) {
+		// Given a message enqueued twice
+		outboxRepo, enqueue := newRepository(t)
+		message := newMessage(now)
+		enqueue(message)
+		enqueue(message)
+		// When claiming the messages
+		claimed, err := outboxRepo.Claim(context.Background(), now, time.Minute, 10)
+		// Then it is claimed once
+		assert.Nil(t, err)
+		assert.Len(t, claimed, 1)
+	})
+	t.Run("Update", func(t *testing.T) {
+		// Given a claimed message
+		outboxRepo, enqueue := newRepository(t)
+		enqueue(newMessage(now))
+		claimed, _ := outboxRepo.Claim(context.Background(), now, time.Minute, 10)
+		if !assert.Len(t, claimed, 1) {
+			return
+		}
+		// When marking it as delivered
+		claimed[0].Status = entity.OutboxStatusDelivered
+		claimed[0].Attempts = 1
+		claimed[0].DeliveredAt = now
+		err := outboxRepo.Update(context.Background(), claimed[0])
+		// Then it is not claimed again
+		assert.Nil(t, err)
+		again, err := outboxRepo.Claim(context.Background(), now.Add(time.Hour), time.Minute, 10)
+		assert.Nil(t, err)
+		assert.Empty(t, again)
+	})
+	t.Run("UpdateNil", func(t *testing.T) {
+		// When updating a nil message
+		outboxRepo, _ := newRepository(t)
+		err := outboxRepo.Update(context.Background(), nil)
+		// Then the error returned is ErrNilOutboxMessage
+		assert.Equal(t, voNotification.ErrNilOutboxMessage, err)
+	})
+}
+
+// newMessage returns a pending message ready at the given time.
+func newMessage(nextAttemptAt time.Time) (message *entity.OutboxMessage) {
+	message = &entity.OutboxMessage{
+		ID:            uuid.New(),
+		Recipient:     "juana@amazingemail.com",
+		Subject:       "Resumen de transacciones",
+		Payload:       []byte("Subject: Resumen de transacciones\r\n\r\nHola"),
+		Status:        entity.OutboxStatusPending,
+		NextAttemptAt: nextAttemptAt,
+		CreatedAt:     nextAttemptAt,
+	}
+	return
+}
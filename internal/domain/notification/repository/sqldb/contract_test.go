@@ -0,0 +1,29 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository/repositorytest"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository/sqldb"
+	txSQL "github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/sqldb"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database/databasetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOutboxRepositoryContract tests the repository against the OutboxRepository contract
+// on every dialect.
+func TestOutboxRepositoryContract(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, newDatabase databasetest.Factory) {
+		repositorytest.RunOutboxRepositoryContract(t, func(t *testing.T) (repository.OutboxRepository, func(...*entity.OutboxMessage)) {
+			baseDB := newDatabase(t)
+			transactionRepo := txSQL.NewSQLTransactionRepository(baseDB)
+			enqueue := func(messages ...*entity.OutboxMessage) {
+				assert.Nil(t, transactionRepo.CreateBatch(context.Background(), nil, messages))
+			}
+			return sqldb.NewSQLOutboxRepository(baseDB), enqueue
+		})
+	})
+}
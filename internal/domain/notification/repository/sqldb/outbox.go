@@ -0,0 +1,145 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+)
+
+// sqlOutboxRepository is the implementation of the outbox repository using a
+// PostgreSQL or a SQLite database.
+type sqlOutboxRepository struct {
+	baseDB database.Database
+	// logger logs the database errors.
+	logger logger.Logger
+}
+
+// SQLOutboxRepositoryOption configures an optional collaborator of the outbox repository.
+type SQLOutboxRepositoryOption func(sqlRepo *sqlOutboxRepository)
+
+// WithLogger logs the database errors with the logger instead of the default one. A nil
+// logger keeps the default one.
+func WithLogger(log logger.Logger) SQLOutboxRepositoryOption {
+	return func(sqlRepo *sqlOutboxRepository) {
+		if log != nil {
+			sqlRepo.logger = log
+		}
+	}
+}
+
+// NewSQLOutboxRepository creates a new instance of repository.OutboxRepository.
+func NewSQLOutboxRepository(baseDB database.Database, options ...SQLOutboxRepositoryOption) (outboxRepo repository.OutboxRepository) {
+	sqlRepo := &sqlOutboxRepository{
+		baseDB: baseDB,
+		logger: logger.NewDefaultLogger(),
+	}
+	for _, option := range options {
+		option(sqlRepo)
+	}
+	outboxRepo = sqlRepo
+	return
+}
+
+// repository.OutboxRepository implementation.
+
+// Claim returns the pending messages ready to be delivered. Rows locked by another
+// dispatcher are skipped. SQLite has no row locks, but its transactions hold the write
+// lock of the database, so two dispatchers never claim the same message either.
+const (
+	claimOutboxMessages       = `UPDATE outbox SET next_attempt_at = $1 WHERE id IN (SELECT id FROM outbox WHERE status = 'pending' AND next_attempt_at <= $2 ORDER BY next_attempt_at LIMIT $3 FOR UPDATE SKIP LOCKED) RETURNING id, recipient, subject, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at`
+	claimSQLiteOutboxMessages = `UPDATE outbox SET next_attempt_at = $1 WHERE id IN (SELECT id FROM outbox WHERE status = 'pending' AND next_attempt_at <= $2 ORDER BY next_attempt_at LIMIT $3) RETURNING id, recipient, subject, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at`
+)
+
+func (sqlRepo *sqlOutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) (messages []*entity.OutboxMessage, err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	dialect := sqlRepo.baseDB.Dialect()
+	claim := claimOutboxMessages
+	if dialect == database.SQLite {
+		claim = claimSQLiteOutboxMessages
+	}
+	rows, err := database.Query(ctx, sqlRepo.baseDB, dbTx, claim, dialect.Time(now.Add(lease)), dialect.Time(now), limit)
+	if err != nil {
+		logger.FromContext(ctx, sqlRepo.logger).Error("error claiming outbox messages", "error", err)
+		err = database.Wrap(voNotification.ErrClaimingOutboxMessages, err)
+		return
+	}
+	messages, err = rows2OutboxMessages(rows)
+	if err != nil {
+		return
+	}
+	err = sqlRepo.baseDB.Commit(dbTx)
+	if err != nil {
+		messages = nil
+	}
+	return
+}
+
+// Update updates the delivery state of a message.
+const (
+	updateOutboxMessage = `UPDATE outbox SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4, delivered_at = $5 WHERE id = $6`
+)
+
+func (sqlRepo *sqlOutboxRepository) Update(ctx context.Context, message *entity.OutboxMessage) (err error) {
+	if message == nil {
+		err = voNotification.ErrNilOutboxMessage
+		return
+	}
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	dialect := sqlRepo.baseDB.Dialect()
+	deliveredAt := sql.NullTime{Time: dialect.Time(message.DeliveredAt), Valid: !message.DeliveredAt.IsZero()}
+	_, err = database.Exec(ctx, sqlRepo.baseDB, dbTx, updateOutboxMessage, message.Status, message.Attempts,
+		dialect.Time(message.NextAttemptAt), message.LastError, deliveredAt, message.ID)
+	if err != nil {
+		logger.FromContext(ctx, sqlRepo.logger).Error("error updating outbox message in database", "message_id", message.ID, "error", err)
+		err = database.Wrap(voNotification.ErrUpdatingOutboxMessage, err)
+		return
+	}
+	err = sqlRepo.baseDB.Commit(dbTx)
+	return
+}
+
+func rows2OutboxMessages(rows *sql.Rows) (messages []*entity.OutboxMessage, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		message := &entity.OutboxMessage{}
+		deliveredAt := sql.NullTime{}
+		err = rows.Scan(&message.ID, &message.Recipient, &message.Subject, &message.Payload, &message.Status,
+			&message.Attempts, &message.NextAttemptAt, &message.LastError, &message.CreatedAt, &deliveredAt)
+		if err != nil {
+			messages = nil
+			err = database.Wrap(voNotification.ErrScanningOutboxMessage, err)
+			return
+		}
+		message.DeliveredAt = deliveredAt.Time
+		messages = append(messages, message)
+	}
+	return
+}
@@ -0,0 +1,195 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository/sqldb"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	mockvoPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	claimQuery  = "UPDATE outbox SET next_attempt_at = $1 WHERE id IN (SELECT id FROM outbox WHERE status = 'pending' AND next_attempt_at <= $2 ORDER BY next_attempt_at LIMIT $3 FOR UPDATE SKIP LOCKED) RETURNING id, recipient, subject, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at"
+	updateQuery = "UPDATE outbox SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4, delivered_at = $5 WHERE id = $6"
+)
+
+// TestClaimErrOpeningDatabase tests the error returned when the database cannot be opened.
+func TestClaimErrOpeningDatabase(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid outbox repository.
+	outboxRepo := sqldb.NewSQLOutboxRepository(dbBaseMocked)
+	// And a mocked response calling Open.
+	dbBaseMocked.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
+	// When claiming the pending messages.
+	messages, err := outboxRepo.Claim(context.Background(), time.Now(), time.Minute, 10)
+	// Then the error returned is ErrOpeningDatabase.
+	assert.Nil(t, messages)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
+}
+
+// TestClaimErrBeginningTransaction tests the error returned when the transaction cannot be started.
+func TestClaimErrBeginningTransaction(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid outbox repository.
+	outboxRepo := sqldb.NewSQLOutboxRepository(dbBaseMocked)
+	// And a sqlmock database.
+	db, _, _ := sqlmock.New()
+	// And mocked responses calling Open, Close, BeginTx and Rollback.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(nil, voPostgres.ErrBeginningTransaction)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	// When claiming the pending messages.
+	_, err := outboxRepo.Claim(context.Background(), time.Now(), time.Minute, 10)
+	// Then the error returned is ErrBeginningTransaction.
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
+}
+
+// TestClaimErrQuerying tests the error returned when the query fails.
+func TestClaimErrQuerying(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid outbox repository.
+	outboxRepo := sqldb.NewSQLOutboxRepository(dbBaseMocked)
+	// And a sqlmock database.
+	db, dbMocked, _ := sqlmock.New()
+	dbMocked.ExpectBegin()
+	defer db.Close()
+	dbTx, _ := db.BeginTx(context.Background(), nil)
+	// And mocked responses calling Open, Close, BeginTx and Rollback.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	// And a mocked response calling Query.
+	now := time.Now()
+	dbBaseMocked.On("Query", dbTx, claimQuery, []interface{}{now.Add(time.Minute), now, 10}).Return(nil, voPostgres.ErrQueryingDatabase)
+	// When claiming the pending messages.
+	_, err := outboxRepo.Claim(context.Background(), now, time.Minute, 10)
+	// Then the error returned is ErrClaimingOutboxMessages.
+	assert.ErrorIs(t, err, voNotification.ErrClaimingOutboxMessages)
+}
+
+// TestClaimSuccess tests the pending messages are returned and the claim is committed.
+func TestClaimSuccess(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	dbBase := voPostgres.NewBasePostgresDatabase(voPostgres.NewDefaultPostgresConfiguration())
+	// And a valid outbox repository.
+	outboxRepo := sqldb.NewSQLOutboxRepository(dbBaseMocked)
+	// And a sqlmock database.
+	db, dbMocked, _ := sqlmock.New()
+	dbMocked.ExpectBegin()
+	defer db.Close()
+	dbTx, _ := db.BeginTx(context.Background(), nil)
+	// And mocked responses calling Open, Close, BeginTx, Rollback and Commit.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	dbBaseMocked.On("Commit", dbTx).Return(nil)
+	// And two claimable messages, one of them with a failed attempt.
+	now := time.Now()
+	first, second := uuid.New(), uuid.New()
+	expected := sqlmock.NewRows([]string{"id", "recipient", "subject", "payload", "status", "attempts", "next_attempt_at", "last_error", "created_at", "delivered_at"})
+	expected.AddRow(first, "a@amazingemail.com", "Resumen", []byte("raw"), entity.OutboxStatusPending, 0, now, "", now, nil)
+	expected.AddRow(second, "b@amazingemail.com", "Summary", []byte("raw"), entity.OutboxStatusPending, 2, now, "timeout", now, nil)
+	dbMocked.ExpectQuery("UPDATE outbox (.+) RETURNING (.+)").WillReturnRows(expected)
+	rows, err := dbBase.Query(dbTx, claimQuery, now.Add(time.Minute), now, 10)
+	assert.Nil(t, err)
+	dbBaseMocked.On("Query", dbTx, claimQuery, []interface{}{now.Add(time.Minute), now, 10}).Return(rows, nil)
+	// When claiming the pending messages.
+	messages, err := outboxRepo.Claim(context.Background(), now, time.Minute, 10)
+	// Then the messages are returned.
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(messages))
+	assert.Equal(t, first, messages[0].ID)
+	assert.Equal(t, 2, messages[1].Attempts)
+	assert.Equal(t, "timeout", messages[1].LastError)
+	assert.True(t, messages[1].DeliveredAt.IsZero())
+}
+
+// TestUpdateWithNilMessage tests the error returned when the message is nil.
+func TestUpdateWithNilMessage(t *testing.T) {
+	// Given a valid outbox repository.
+	outboxRepo := sqldb.NewSQLOutboxRepository(mockvoPostgres.NewMockBasePostgresDatabase())
+	// When updating a nil message.
+	err := outboxRepo.Update(context.Background(), nil)
+	// Then the error returned is ErrNilOutboxMessage.
+	assert.Equal(t, voNotification.ErrNilOutboxMessage, err)
+}
+
+// TestUpdateErrExecuting tests the error returned when the update fails.
+func TestUpdateErrExecuting(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid outbox repository.
+	outboxRepo := sqldb.NewSQLOutboxRepository(dbBaseMocked)
+	// And a sqlmock database.
+	db, _, _ := sqlmock.New()
+	dbTx, _ := db.Begin()
+	// And mocked responses calling Open, Close, BeginTx and Rollback.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", dbTx).Return(nil)
+	// And a pending message.
+	message := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusPending, Attempts: 1, NextAttemptAt: time.Now(), LastError: "timeout"}
+	// And a mocked response calling Exec.
+	dbBaseMocked.On("Exec", dbTx, updateQuery, []interface{}{
+		message.Status,
+		message.Attempts,
+		message.NextAttemptAt,
+		message.LastError,
+		sql.NullTime{},
+		message.ID,
+	}).Return(nil, voPostgres.ErrExec)
+	// When updating the message.
+	err := outboxRepo.Update(context.Background(), message)
+	// Then the error returned is ErrUpdatingOutboxMessage.
+	assert.ErrorIs(t, err, voNotification.ErrUpdatingOutboxMessage)
+}
+
+// TestUpdateSuccess tests a delivered message is updated.
+func TestUpdateSuccess(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid outbox repository.
+	outboxRepo := sqldb.NewSQLOutboxRepository(dbBaseMocked)
+	// And a sqlmock database.
+	db, _, _ := sqlmock.New()
+	dbTx, _ := db.Begin()
+	// And mocked responses calling Open, Close, BeginTx, Rollback and Commit.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", dbTx).Return(nil)
+	dbBaseMocked.On("Commit", dbTx).Return(nil)
+	// And a delivered message.
+	now := time.Now()
+	message := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusDelivered, Attempts: 1, NextAttemptAt: now, DeliveredAt: now}
+	// And a mocked response calling Exec.
+	dbBaseMocked.On("Exec", dbTx, updateQuery, []interface{}{
+		message.Status,
+		message.Attempts,
+		message.NextAttemptAt,
+		message.LastError,
+		sql.NullTime{Time: now, Valid: true},
+		message.ID,
+	}).Return(nil, nil)
+	// When updating the message.
+	err := outboxRepo.Update(context.Background(), message)
+	// Then the error returned is nil.
+	assert.Nil(t, err)
+}
@@ -0,0 +1,39 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository"
+	voTracing "github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+)
+
+// tracingOutboxRepository struct implements the OutboxRepository interface starting a span for every
+// method of the decorated repository.
+type tracingOutboxRepository struct {
+	outboxRepo repository.OutboxRepository
+}
+
+// NewTracingOutboxRepository returns the repository decorated with the spans of its methods, children
+// of the span carried by their context.
+func NewTracingOutboxRepository(outboxRepo repository.OutboxRepository) (decorated repository.OutboxRepository) {
+	decorated = &tracingOutboxRepository{
+		outboxRepo: outboxRepo,
+	}
+	return
+}
+
+// OutboxRepository interface implementation
+
+func (tracingRepo *tracingOutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) (messages []*entity.OutboxMessage, err error) {
+	ctx, span := voTracing.Start(ctx, "OutboxRepository.Claim", "limit", limit)
+	defer span.EndWithError(&err)
+	return tracingRepo.outboxRepo.Claim(ctx, now, lease, limit)
+}
+
+func (tracingRepo *tracingOutboxRepository) Update(ctx context.Context, message *entity.OutboxMessage) (err error) {
+	ctx, span := voTracing.Start(ctx, "OutboxRepository.Update")
+	defer span.EndWithError(&err)
+	return tracingRepo.outboxRepo.Update(ctx, message)
+}
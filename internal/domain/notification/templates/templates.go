@@ -0,0 +1,145 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	htmlTemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	textTemplate "text/template"
+	"time"
+
+	summaryEntity "github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/locale"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+)
+
+// defaults are the templates shipped with the binary.
+//
+//go:embed defaults
+var defaults embed.FS
+
+const (
+	// textExtension is the extension of the plain text templates.
+	textExtension = ".txt"
+	// htmlExtension is the extension of the HTML templates.
+	htmlExtension = ".html"
+)
+
+// Renderer interface defines the methods that a template renderer must implement.
+type Renderer interface {
+	// Render renders the plain text and the HTML parts of the named template.
+	Render(name string, loc *locale.Locale, data interface{}) (text string, html string, err error)
+}
+
+// fsRenderer struct implements the Renderer interface looking up the templates
+// in a list of file systems.
+type fsRenderer struct {
+	// sources are the file systems ordered by priority.
+	sources []fs.FS
+}
+
+// NewRenderer returns a new Renderer. Templates found in overrideDir take precedence
+// over the embedded ones; an empty overrideDir only uses the embedded templates.
+func NewRenderer(overrideDir string) (renderer Renderer, err error) {
+	embedded, err := fs.Sub(defaults, "defaults")
+	if err != nil {
+		return
+	}
+	sources := []fs.FS{embedded}
+	if overrideDir != "" {
+		info, errStat := os.Stat(overrideDir)
+		if errStat != nil || !info.IsDir() {
+			err = voNotification.ErrTemplatesDirIsInvalid
+			return
+		}
+		sources = append([]fs.FS{os.DirFS(overrideDir)}, sources...)
+	}
+	renderer = &fsRenderer{
+		sources: sources,
+	}
+	return
+}
+
+// Render implements the Renderer interface method.
+// A template is looked up as "<locale>/<name>.<ext>" and then as "<name>.<ext>"
+// in every source, so a directory can override a single language or all of them.
+func (renderer *fsRenderer) Render(name string, loc *locale.Locale, data interface{}) (text string, html string, err error) {
+	if loc == nil {
+		loc = locale.Get(locale.DefaultTag)
+	}
+	funcs := templateFuncs(loc)
+	textSource, err := renderer.lookup(name+textExtension, loc.Tag)
+	if err != nil {
+		return
+	}
+	textTmpl, err := textTemplate.New(name + textExtension).Funcs(funcs).Parse(textSource)
+	if err != nil {
+		err = voNotification.ErrParsingTemplate
+		return
+	}
+	htmlSource, err := renderer.lookup(name+htmlExtension, loc.Tag)
+	if err != nil {
+		return
+	}
+	htmlTmpl, err := htmlTemplate.New(name + htmlExtension).Funcs(funcs).Parse(htmlSource)
+	if err != nil {
+		err = voNotification.ErrParsingTemplate
+		return
+	}
+	textBuffer := &bytes.Buffer{}
+	if err = textTmpl.Execute(textBuffer, data); err != nil {
+		err = voNotification.ErrRenderingTemplate
+		return
+	}
+	htmlBuffer := &bytes.Buffer{}
+	if err = htmlTmpl.Execute(htmlBuffer, data); err != nil {
+		err = voNotification.ErrRenderingTemplate
+		return
+	}
+	text = textBuffer.String()
+	html = htmlBuffer.String()
+	return
+}
+
+func (renderer *fsRenderer) lookup(fileName string, tag string) (source string, err error) {
+	for _, source := range renderer.sources {
+		for _, candidate := range []string{path.Join(tag, fileName), fileName} {
+			content, errRead := fs.ReadFile(source, candidate)
+			if errRead == nil {
+				return string(content), nil
+			}
+		}
+	}
+	err = voNotification.ErrTemplateNotFound
+	return
+}
+
+// templateFuncs returns the functions available in the templates for the given locale.
+func templateFuncs(loc *locale.Locale) map[string]interface{} {
+	return map[string]interface{}{
+		"t":      loc.Translate,
+		"money":  loc.FormatMoney,
+		"number": loc.FormatNumber,
+		"month": func(month time.Month) string {
+			return loc.MonthName(month)
+		},
+		"lang": func() string {
+			return loc.Tag
+		},
+	}
+}
+
+// SummaryTemplate is the name of the transactions summary template.
+const SummaryTemplate = "summary"
+
+// SummaryData struct defines the data available in the summary template.
+type SummaryData struct {
+	// Name is the name of the recipient.
+	Name string
+	// Email is the email address of the recipient.
+	Email string
+	// Summary is the summary of the recipient transactions.
+	Summary summaryEntity.Summary
+}
@@ -0,0 +1,86 @@
+package templates_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/templates"
+	summaryEntity "github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/locale"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewRendererWithInvalidDir tests the NewRenderer function with a directory that does not exist.
+func TestNewRendererWithInvalidDir(t *testing.T) {
+	// When call NewRenderer with a non existing directory
+	renderer, err := templates.NewRenderer("non-existing-dir")
+	// Then the error returned is ErrTemplatesDirIsInvalid
+	assert.Nil(t, renderer)
+	assert.Equal(t, voNotification.ErrTemplatesDirIsInvalid, err)
+}
+
+// TestRenderWithUnknownTemplate tests the Render method with an unknown template.
+func TestRenderWithUnknownTemplate(t *testing.T) {
+	// Given a renderer with the embedded templates
+	renderer, err := templates.NewRenderer("")
+	assert.Nil(t, err)
+	// When call Render with an unknown template
+	_, _, err = renderer.Render("unknown", locale.Get(locale.Spanish), nil)
+	// Then the error returned is ErrTemplateNotFound
+	assert.Equal(t, voNotification.ErrTemplateNotFound, err)
+}
+
+// TestRenderEscapesHTML tests the Render method escapes the data only in the HTML part.
+func TestRenderEscapesHTML(t *testing.T) {
+	// Given a renderer with the embedded templates
+	renderer, err := templates.NewRenderer("")
+	assert.Nil(t, err)
+	// And a name with HTML characters
+	data := templates.SummaryData{Name: "<b>Juana</b>", Summary: summaryEntity.Summary{}}
+	// When call Render
+	text, html, err := renderer.Render(templates.SummaryTemplate, locale.Get(locale.Spanish), data)
+	// Then only the HTML part is escaped
+	assert.Nil(t, err)
+	assert.Contains(t, text, "Hola <b>Juana</b>")
+	assert.Contains(t, html, "Hola &lt;b&gt;Juana&lt;/b&gt;")
+	assert.Contains(t, text, "No hay transacciones para mostrar.")
+}
+
+// TestRenderWithOverrides tests the Render method prefers the templates of the override directory.
+func TestRenderWithOverrides(t *testing.T) {
+	// Given an override directory with a generic text template and an english HTML template
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "summary.txt"), []byte(`{{t "summary.balance"}}={{money .Summary.Balance}}`), 0o600))
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, locale.English), 0o700))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, locale.English, "summary.html"), []byte(`<p>{{.Name}}</p>`), 0o600))
+	renderer, err := templates.NewRenderer(dir)
+	assert.Nil(t, err)
+	data := templates.SummaryData{Name: "Jane", Summary: summaryEntity.Summary{Balance: 1500}}
+	// When call Render in english
+	text, html, err := renderer.Render(templates.SummaryTemplate, locale.Get(locale.English), data)
+	// Then both parts come from the override directory
+	assert.Nil(t, err)
+	assert.Equal(t, "Total balance=$1,500.00", text)
+	assert.Equal(t, "<p>Jane</p>", html)
+	// When call Render in spanish
+	text, html, err = renderer.Render(templates.SummaryTemplate, locale.Get(locale.Spanish), data)
+	// Then the HTML part falls back to the embedded template
+	assert.Nil(t, err)
+	assert.Equal(t, "Saldo total=$1.500,00", text)
+	assert.Contains(t, html, "<!DOCTYPE html>")
+}
+
+// TestRenderWithInvalidTemplate tests the Render method with a template that cannot be parsed.
+func TestRenderWithInvalidTemplate(t *testing.T) {
+	// Given an override directory with an invalid template
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "summary.txt"), []byte(`{{.Name`), 0o600))
+	renderer, err := templates.NewRenderer(dir)
+	assert.Nil(t, err)
+	// When call Render
+	_, _, err = renderer.Render(templates.SummaryTemplate, nil, templates.SummaryData{})
+	// Then the error returned is ErrParsingTemplate
+	assert.Equal(t, voNotification.ErrParsingTemplate, err)
+}
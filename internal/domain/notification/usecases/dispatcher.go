@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/notifier"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+)
+
+// dispatcherUseCases struct implements the DispatcherUseCases interface.
+type dispatcherUseCases struct {
+	// outboxRepo is the outbox repository.
+	outboxRepo repository.OutboxRepository
+	// notifier delivers the messages.
+	notifier notifier.Notifier
+	// configuration holds the polling and retry settings.
+	configuration voNotification.NotificationConfiguration
+	// logger logs the failed deliveries and updates.
+	logger logger.Logger
+}
+
+// DispatcherUseCasesOption configures an optional collaborator of the dispatcher use cases.
+type DispatcherUseCasesOption func(usecases *dispatcherUseCases) (err error)
+
+// WithDispatcherLogger logs the deliveries with the logger instead of the default one.
+func WithDispatcherLogger(log logger.Logger) DispatcherUseCasesOption {
+	return func(usecases *dispatcherUseCases) (err error) {
+		if log == nil {
+			err = logger.ErrNilLogger
+			return
+		}
+		usecases.logger = log
+		return
+	}
+}
+
+// NewDispatcherUseCases returns a new dispatcher use cases.
+func NewDispatcherUseCases(
+	outboxRepo repository.OutboxRepository,
+	notifier notifier.Notifier,
+	configuration *voNotification.NotificationConfiguration,
+	options ...DispatcherUseCasesOption,
+) (usecases DispatcherUseCases, err error) {
+	if outboxRepo == nil {
+		err = voNotification.ErrNilOutboxRepository
+		return
+	}
+	if notifier == nil {
+		err = voNotification.ErrNilNotifier
+		return
+	}
+	if configuration == nil {
+		configuration = voNotification.NewDefaultNotificationConfiguration()
+	}
+	dispatcher := &dispatcherUseCases{
+		outboxRepo:    outboxRepo,
+		notifier:      notifier,
+		configuration: *configuration,
+		logger:        logger.NewDefaultLogger(),
+	}
+	for _, option := range options {
+		if err = option(dispatcher); err != nil {
+			return
+		}
+	}
+	usecases = dispatcher
+	return
+}
+
+// DispatcherUseCases interface implementation
+
+// DispatchPending delivers the pending messages ready to be delivered.
+// A failed delivery is retried with exponential backoff until the max attempts are
+// reached, then the message is marked as dead and never delivered again.
+func (uc *dispatcherUseCases) DispatchPending(ctx context.Context) (delivered int, err error) {
+	now := time.Now()
+	messages, err := uc.outboxRepo.Claim(ctx, now, uc.configuration.Lease, uc.configuration.BatchSize)
+	if err != nil {
+		return
+	}
+	for _, message := range messages {
+		message.Attempts++
+		errNotify := uc.notifier.Notify(*message)
+		if errNotify == nil {
+			message.Status = entity.OutboxStatusDelivered
+			message.DeliveredAt = time.Now()
+			message.LastError = ""
+			delivered++
+		} else {
+			uc.logger.Warn("error delivering message", "message_id", message.ID, "attempt", message.Attempts, "error", errNotify)
+			message.LastError = errNotify.Error()
+			if message.Attempts >= uc.configuration.MaxAttempts {
+				message.Status = entity.OutboxStatusDead
+			} else {
+				message.NextAttemptAt = now.Add(uc.backoff(message.Attempts))
+			}
+		}
+		// If the update fails the claim lease expires and the message is delivered
+		// again, the rest of the batch is still updated.
+		if errUpdate := uc.outboxRepo.Update(ctx, message); errUpdate != nil {
+			uc.logger.Error("error updating message", "message_id", message.ID, "error", errUpdate)
+		}
+	}
+	return
+}
+
+// Run dispatches the pending messages every configured interval until the context is done.
+func (uc *dispatcherUseCases) Run(ctx context.Context) {
+	ticker := time.NewTicker(uc.configuration.Interval)
+	defer ticker.Stop()
+	for {
+		delivered, err := uc.DispatchPending(ctx)
+		if err != nil {
+			uc.logger.Error("error dispatching outbox messages", "error", err)
+		} else if delivered > 0 {
+			uc.logger.Info("messages delivered", "delivered", delivered)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// backoff returns the wait before the next attempt: the base backoff doubled on every
+// failed attempt, capped at the max backoff.
+func (uc *dispatcherUseCases) backoff(attempts int) (wait time.Duration) {
+	wait = uc.configuration.MaxBackoff
+	if attempts < 1 || attempts > 32 {
+		return
+	}
+	if next := uc.configuration.BaseBackoff << (attempts - 1); next > 0 && next < wait {
+		wait = next
+	}
+	return
+}
@@ -0,0 +1,166 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	notifierMock "github.com/braejan/go-transactions-summary/internal/domain/notification/notifier/mock"
+	outboxMock "github.com/braejan/go-transactions-summary/internal/domain/notification/repository/mock"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/usecases"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func getTestConfiguration() *voNotification.NotificationConfiguration {
+	configuration := voNotification.NewDefaultNotificationConfiguration()
+	configuration.MaxAttempts = 3
+	configuration.BaseBackoff = time.Minute
+	configuration.MaxBackoff = 90 * time.Second
+	return configuration
+}
+
+// TestNewDispatcherUseCasesWithNilRepository tests the NewDispatcherUseCases function with a nil repository.
+func TestNewDispatcherUseCasesWithNilRepository(t *testing.T) {
+	// When call NewDispatcherUseCases with a nil repository
+	_, err := usecases.NewDispatcherUseCases(nil, notifierMock.NewMockNotifier(), nil)
+	// Then the error returned is ErrNilOutboxRepository
+	assert.Equal(t, voNotification.ErrNilOutboxRepository, err)
+}
+
+// TestNewDispatcherUseCasesWithNilNotifier tests the NewDispatcherUseCases function with a nil notifier.
+func TestNewDispatcherUseCasesWithNilNotifier(t *testing.T) {
+	// When call NewDispatcherUseCases with a nil notifier
+	_, err := usecases.NewDispatcherUseCases(outboxMock.NewMockOutboxRepository(), nil, nil)
+	// Then the error returned is ErrNilNotifier
+	assert.Equal(t, voNotification.ErrNilNotifier, err)
+}
+
+// TestDispatchPendingWithClaimError tests the error returned when the messages cannot be claimed.
+func TestDispatchPendingWithClaimError(t *testing.T) {
+	// Given an outbox repository failing to claim
+	outboxRepo := outboxMock.NewMockOutboxRepository()
+	outboxRepo.On("Claim", mock.Anything, mock.Anything, time.Minute, 10).Return(nil, voNotification.ErrClaimingOutboxMessages)
+	// And a dispatcher
+	dispatcher, err := usecases.NewDispatcherUseCases(outboxRepo, notifierMock.NewMockNotifier(), getTestConfiguration())
+	assert.Nil(t, err)
+	// When call DispatchPending
+	delivered, err := dispatcher.DispatchPending(context.Background())
+	// Then the error returned is ErrClaimingOutboxMessages
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, voNotification.ErrClaimingOutboxMessages, err)
+}
+
+// TestDispatchPendingDelivered tests a delivered message is marked as delivered.
+func TestDispatchPendingDelivered(t *testing.T) {
+	// Given a pending message
+	message := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusPending, LastError: "timeout", Attempts: 1}
+	outboxRepo := outboxMock.NewMockOutboxRepository()
+	outboxRepo.On("Claim", mock.Anything, mock.Anything, time.Minute, 10).Return([]*entity.OutboxMessage{message}, nil)
+	outboxRepo.On("Update", mock.Anything, message).Return(nil)
+	// And a notifier delivering it
+	notifier := notifierMock.NewMockNotifier()
+	notifier.On("Notify", mock.Anything).Return(nil)
+	dispatcher, _ := usecases.NewDispatcherUseCases(outboxRepo, notifier, getTestConfiguration())
+	// When call DispatchPending
+	delivered, err := dispatcher.DispatchPending(context.Background())
+	// Then the message is delivered
+	assert.Nil(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, entity.OutboxStatusDelivered, message.Status)
+	assert.Equal(t, 2, message.Attempts)
+	assert.Empty(t, message.LastError)
+	assert.False(t, message.DeliveredAt.IsZero())
+	outboxRepo.AssertExpectations(t)
+}
+
+// TestDispatchPendingBackoff tests a failed delivery is retried with exponential backoff.
+func TestDispatchPendingBackoff(t *testing.T) {
+	// Given two pending messages that already failed zero and one times
+	first := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusPending}
+	second := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusPending, Attempts: 1}
+	outboxRepo := outboxMock.NewMockOutboxRepository()
+	outboxRepo.On("Claim", mock.Anything, mock.Anything, time.Minute, 10).Return([]*entity.OutboxMessage{first, second}, nil)
+	outboxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	// And a notifier failing to deliver them
+	notifier := notifierMock.NewMockNotifier()
+	notifier.On("Notify", mock.Anything).Return(errors.New("smtp unavailable"))
+	dispatcher, _ := usecases.NewDispatcherUseCases(outboxRepo, notifier, getTestConfiguration())
+	// When call DispatchPending
+	before := time.Now()
+	delivered, err := dispatcher.DispatchPending(context.Background())
+	// Then no message is delivered
+	assert.Nil(t, err)
+	assert.Equal(t, 0, delivered)
+	// And the messages are still pending with the error
+	assert.Equal(t, entity.OutboxStatusPending, first.Status)
+	assert.Equal(t, "smtp unavailable", first.LastError)
+	// And the first one waits the base backoff
+	assert.WithinDuration(t, before.Add(time.Minute), first.NextAttemptAt, time.Second)
+	// And the second one waits the max backoff instead of two minutes
+	assert.WithinDuration(t, before.Add(90*time.Second), second.NextAttemptAt, time.Second)
+}
+
+// TestDispatchPendingDead tests a message reaching the max attempts is marked as dead.
+func TestDispatchPendingDead(t *testing.T) {
+	// Given a pending message with one attempt left
+	message := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusPending, Attempts: 2}
+	outboxRepo := outboxMock.NewMockOutboxRepository()
+	outboxRepo.On("Claim", mock.Anything, mock.Anything, time.Minute, 10).Return([]*entity.OutboxMessage{message}, nil)
+	outboxRepo.On("Update", mock.Anything, message).Return(nil)
+	// And a notifier failing to deliver it
+	notifier := notifierMock.NewMockNotifier()
+	notifier.On("Notify", mock.Anything).Return(voNotification.ErrDeliveringMessage)
+	dispatcher, _ := usecases.NewDispatcherUseCases(outboxRepo, notifier, getTestConfiguration())
+	// When call DispatchPending
+	_, err := dispatcher.DispatchPending(context.Background())
+	// Then the message is dead
+	assert.Nil(t, err)
+	assert.Equal(t, 3, message.Attempts)
+	assert.Equal(t, entity.OutboxStatusDead, message.Status)
+}
+
+// TestDispatchPendingWithUpdateError tests a message that cannot be updated does not stop
+// the delivery of the rest of the batch.
+func TestDispatchPendingWithUpdateError(t *testing.T) {
+	// Given two pending messages
+	first := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusPending}
+	second := &entity.OutboxMessage{ID: uuid.New(), Status: entity.OutboxStatusPending}
+	outboxRepo := outboxMock.NewMockOutboxRepository()
+	outboxRepo.On("Claim", mock.Anything, mock.Anything, time.Minute, 10).Return([]*entity.OutboxMessage{first, second}, nil)
+	// And an outbox repository failing to update the first one
+	outboxRepo.On("Update", mock.Anything, first).Return(voNotification.ErrUpdatingOutboxMessage)
+	outboxRepo.On("Update", mock.Anything, second).Return(nil)
+	notifier := notifierMock.NewMockNotifier()
+	notifier.On("Notify", mock.Anything).Return(nil)
+	dispatcher, _ := usecases.NewDispatcherUseCases(outboxRepo, notifier, getTestConfiguration(),
+		usecases.WithDispatcherLogger(logger.NewJSONLogger(&bytes.Buffer{}, logger.LevelInfo)))
+	// When call DispatchPending
+	delivered, err := dispatcher.DispatchPending(context.Background())
+	// Then both messages are delivered and updated
+	assert.Nil(t, err)
+	assert.Equal(t, 2, delivered)
+	notifier.AssertNumberOfCalls(t, "Notify", 2)
+	outboxRepo.AssertNumberOfCalls(t, "Update", 2)
+}
+
+// TestRunStopsWhenContextIsDone tests the Run method returns when the context is cancelled.
+func TestRunStopsWhenContextIsDone(t *testing.T) {
+	// Given a dispatcher with no pending messages
+	outboxRepo := outboxMock.NewMockOutboxRepository()
+	outboxRepo.On("Claim", mock.Anything, mock.Anything, time.Minute, 10).Return(nil, nil)
+	dispatcher, _ := usecases.NewDispatcherUseCases(outboxRepo, notifierMock.NewMockNotifier(), getTestConfiguration())
+	// And a cancelled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// When call Run
+	dispatcher.Run(ctx)
+	// Then the outbox is polled once
+	outboxRepo.AssertNumberOfCalls(t, "Claim", 1)
+}
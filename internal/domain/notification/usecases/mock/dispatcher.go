@@ -0,0 +1,28 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// mockDispatcherUseCases is a mock of the DispatcherUseCases interface implementation.
+type mockDispatcherUseCases struct {
+	mock.Mock
+}
+
+// NewMockDispatcherUseCases returns a new mock instance.
+func NewMockDispatcherUseCases() *mockDispatcherUseCases {
+	return &mockDispatcherUseCases{}
+}
+
+// DispatchPending provides a mock function with given fields:
+func (_m *mockDispatcherUseCases) DispatchPending(ctx context.Context) (delivered int, err error) {
+	ret := _m.Called(ctx)
+	return ret.Int(0), ret.Error(1)
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *mockDispatcherUseCases) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	summaryEntity "github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
+	userEntity "github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockNotificationUseCases is a mock of the NotificationUseCases interface implementation.
+type mockNotificationUseCases struct {
+	mock.Mock
+}
+
+// NewMockNotificationUseCases returns a new mock instance.
+func NewMockNotificationUseCases() *mockNotificationUseCases {
+	return &mockNotificationUseCases{}
+}
+
+// SummaryMessage provides a mock function with given fields: user, summary
+func (_m *mockNotificationUseCases) SummaryMessage(user userEntity.User, summary *summaryEntity.Summary) (message entity.Message, err error) {
+	ret := _m.Called(user, summary)
+
+	var r0 entity.Message
+	if rf, ok := ret.Get(0).(func(userEntity.User, *summaryEntity.Summary) entity.Message); ok {
+		r0 = rf(user, summary)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(entity.Message)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(userEntity.User, *summaryEntity.Summary) error); ok {
+		r1 = rf(user, summary)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
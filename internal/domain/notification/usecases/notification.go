@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/templates"
+	summaryEntity "github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
+	userEntity "github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/locale"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+)
+
+// notificationUseCases struct implements the NotificationUseCases interface.
+type notificationUseCases struct {
+	// renderer renders the message templates.
+	renderer templates.Renderer
+	// sender is the address used as the sender of the messages.
+	sender string
+}
+
+// NewNotificationUseCases returns a new notification use cases.
+func NewNotificationUseCases(renderer templates.Renderer, sender string) (usecases NotificationUseCases, err error) {
+	if renderer == nil {
+		err = voNotification.ErrNilRenderer
+		return
+	}
+	if sender == "" {
+		err = voNotification.ErrEmptySender
+		return
+	}
+	usecases = &notificationUseCases{
+		renderer: renderer,
+		sender:   sender,
+	}
+	return
+}
+
+// NotificationUseCases interface implementation
+
+// SummaryMessage builds the summary email of the user in the user locale.
+func (uc *notificationUseCases) SummaryMessage(user userEntity.User, summary *summaryEntity.Summary) (message entity.Message, err error) {
+	if summary == nil {
+		err = voNotification.ErrNilSummary
+		return
+	}
+	if user.Email == "" {
+		err = voNotification.ErrEmptyRecipient
+		return
+	}
+	loc := locale.Get(user.Locale)
+	data := templates.SummaryData{
+		Name:    user.Name,
+		Email:   user.Email,
+		Summary: *summary,
+	}
+	text, html, err := uc.renderer.Render(templates.SummaryTemplate, loc, data)
+	if err != nil {
+		return
+	}
+	message = *entity.NewMessage(uc.sender, user.Email, loc.Translate("summary.subject"), text, html)
+	return
+}
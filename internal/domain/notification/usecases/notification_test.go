@@ -0,0 +1,115 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/templates"
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/usecases"
+	summaryEntity "github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
+	userEntity "github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/locale"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestSummary() *summaryEntity.Summary {
+	return &summaryEntity.Summary{
+		UserID:        int64(1),
+		Balance:       1039.74,
+		TotalCredits:  1070.5,
+		TotalDebits:   -30.76,
+		Transactions:  4,
+		AverageCredit: 535.25,
+		AverageDebit:  -15.38,
+		MonthlyCounts: []summaryEntity.MonthlyCount{
+			{Month: time.July, Count: 2},
+			{Month: time.August, Count: 2},
+		},
+	}
+}
+
+// TestNewNotificationUseCasesWithNilRenderer tests the NewNotificationUseCases function with a nil renderer.
+func TestNewNotificationUseCasesWithNilRenderer(t *testing.T) {
+	// When call NewNotificationUseCases with a nil renderer
+	useCases, err := usecases.NewNotificationUseCases(nil, "no-reply@amazingemail.com")
+	// Then the error returned is ErrNilRenderer
+	assert.Nil(t, useCases)
+	assert.Equal(t, voNotification.ErrNilRenderer, err)
+}
+
+// TestNewNotificationUseCasesWithEmptySender tests the NewNotificationUseCases function with an empty sender.
+func TestNewNotificationUseCasesWithEmptySender(t *testing.T) {
+	// Given a valid renderer
+	renderer, err := templates.NewRenderer("")
+	assert.Nil(t, err)
+	// When call NewNotificationUseCases with an empty sender
+	useCases, err := usecases.NewNotificationUseCases(renderer, "")
+	// Then the error returned is ErrEmptySender
+	assert.Nil(t, useCases)
+	assert.Equal(t, voNotification.ErrEmptySender, err)
+}
+
+// TestSummaryMessageWithNilSummary tests the SummaryMessage method with a nil summary.
+func TestSummaryMessageWithNilSummary(t *testing.T) {
+	// Given a valid notification use cases
+	renderer, _ := templates.NewRenderer("")
+	useCases, _ := usecases.NewNotificationUseCases(renderer, "no-reply@amazingemail.com")
+	// When call SummaryMessage with a nil summary
+	_, err := useCases.SummaryMessage(*userEntity.NewUser(1, "Juana María", "juana.maria@amazingemail.com"), nil)
+	// Then the error returned is ErrNilSummary
+	assert.Equal(t, voNotification.ErrNilSummary, err)
+}
+
+// TestSummaryMessageWithoutEmail tests the SummaryMessage method with a user without email.
+func TestSummaryMessageWithoutEmail(t *testing.T) {
+	// Given a valid notification use cases
+	renderer, _ := templates.NewRenderer("")
+	useCases, _ := usecases.NewNotificationUseCases(renderer, "no-reply@amazingemail.com")
+	// When call SummaryMessage with a user without email
+	_, err := useCases.SummaryMessage(*userEntity.NewUser(1, "Juana María", ""), getTestSummary())
+	// Then the error returned is ErrEmptyRecipient
+	assert.Equal(t, voNotification.ErrEmptyRecipient, err)
+}
+
+// TestSummaryMessageSpanish tests the SummaryMessage method for a spanish speaking user.
+func TestSummaryMessageSpanish(t *testing.T) {
+	// Given a valid notification use cases
+	renderer, _ := templates.NewRenderer("")
+	useCases, _ := usecases.NewNotificationUseCases(renderer, "no-reply@amazingemail.com")
+	// And a spanish speaking user
+	user := userEntity.NewUser(1, "Juana María", "juana.maria@amazingemail.com")
+	// When call SummaryMessage
+	message, err := useCases.SummaryMessage(*user, getTestSummary())
+	// Then the message is written in spanish
+	assert.Nil(t, err)
+	assert.Equal(t, "no-reply@amazingemail.com", message.From)
+	assert.Equal(t, "juana.maria@amazingemail.com", message.To)
+	assert.Equal(t, "Resumen de tus transacciones", message.Subject)
+	assert.Contains(t, message.Text, "Saldo total: $1.039,74")
+	assert.Contains(t, message.Text, "- julio: 2")
+	assert.Contains(t, message.Text, "Crédito promedio: $535,25")
+	assert.Contains(t, message.Text, "Débito promedio: -$15,38")
+	assert.Contains(t, message.HTML, `<html lang="es">`)
+	assert.Contains(t, message.HTML, "Hola Juana María")
+	assert.Contains(t, message.HTML, "$1.039,74")
+	assert.Contains(t, message.HTML, "agosto")
+}
+
+// TestSummaryMessageEnglish tests the SummaryMessage method for an english speaking user.
+func TestSummaryMessageEnglish(t *testing.T) {
+	// Given a valid notification use cases
+	renderer, _ := templates.NewRenderer("")
+	useCases, _ := usecases.NewNotificationUseCases(renderer, "no-reply@amazingemail.com")
+	// And an english speaking user
+	user := userEntity.NewUser(1, "Jane Doe", "jane.doe@amazingemail.com")
+	user.Locale = locale.English
+	// When call SummaryMessage
+	message, err := useCases.SummaryMessage(*user, getTestSummary())
+	// Then the message is written in english
+	assert.Nil(t, err)
+	assert.Equal(t, "Your transactions summary", message.Subject)
+	assert.Contains(t, message.Text, "Total balance: $1,039.74")
+	assert.Contains(t, message.Text, "- July: 2")
+	assert.Contains(t, message.HTML, "August")
+}
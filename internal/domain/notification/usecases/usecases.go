@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	summaryEntity "github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
+	userEntity "github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+)
+
+// NotificationUseCases interface defines the notification use cases.
+type NotificationUseCases interface {
+	// SummaryMessage builds the summary email of the user in the user locale.
+	SummaryMessage(user userEntity.User, summary *summaryEntity.Summary) (message entity.Message, err error)
+}
+
+// DispatcherUseCases interface defines the use cases delivering the outbox messages.
+type DispatcherUseCases interface {
+	// DispatchPending delivers the pending messages ready to be delivered and returns
+	// how many of them were delivered.
+	DispatchPending(ctx context.Context) (delivered int, err error)
+	// Run dispatches the pending messages periodically until the context is done.
+	Run(ctx context.Context)
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"time"
+
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+)
+
+const (
+	// ProcessingStatusProcessing is the status of an object being processed.
+	ProcessingStatusProcessing = "processing"
+	// ProcessingStatusSucceeded is the status of an object whose transactions were stored.
+	ProcessingStatusSucceeded = "succeeded"
+	// ProcessingStatusFailed is the status of an object that could not be processed.
+	ProcessingStatusFailed = "failed"
+)
+
+// ProcessingRecord struct defines the result of processing a stored object. An object is
+// identified by its bucket, key and ETag, so a new upload to the same key gets a new record.
+type ProcessingRecord struct {
+	// Bucket is the bucket of the object.
+	Bucket string `json:"bucket"`
+	// Key is the key of the object.
+	Key string `json:"key"`
+	// ETag is the ETag of the object.
+	ETag string `json:"etag"`
+	// Status is processing, succeeded or failed.
+	Status string `json:"status"`
+	// Error is the reason of the failure.
+	Error string `json:"error,omitempty"`
+	// Lines is the number of lines of the file after the header.
+	Lines int `json:"lines"`
+	// InvalidLines is the number of invalid lines of the file.
+	InvalidLines int `json:"invalid_lines"`
+	// Transactions is the number of stored transactions.
+	Transactions int `json:"transactions"`
+	// StartedAt is the date and time when the processing started.
+	StartedAt time.Time `json:"started_at"`
+	// FinishedAt is the date and time when the processing finished, zero while processing.
+	FinishedAt time.Time `json:"finished_at"`
+	// Uploader is the ID of the client that uploaded the file, empty when it is unknown.
+	Uploader string `json:"uploader,omitempty"`
+}
+
+// NewProcessingRecord returns a new ProcessingRecord instance in the processing status.
+func NewProcessingRecord(bucket, key, etag string, now time.Time) (record *ProcessingRecord, err error) {
+	if key == "" {
+		err = voProcessing.ErrEmptyObjectKey
+		return
+	}
+	record = &ProcessingRecord{
+		Bucket:    bucket,
+		Key:       key,
+		ETag:      etag,
+		Status:    ProcessingStatusProcessing,
+		StartedAt: now,
+	}
+	return
+}
+
+// Succeed marks the object as processed with the given number of stored transactions.
+func (record *ProcessingRecord) Succeed(transactions int, now time.Time) {
+	record.Status = ProcessingStatusSucceeded
+	record.Error = ""
+	record.Transactions = transactions
+	record.FinishedAt = now
+}
+
+// Fail marks the object as failed. No transaction is stored for a failed object.
+func (record *ProcessingRecord) Fail(reason error, now time.Time) {
+	record.Status = ProcessingStatusFailed
+	record.Error = reason.Error()
+	record.Transactions = 0
+	record.FinishedAt = now
+}
@@ -0,0 +1,73 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewProcessingRecordWithEmptyKey tests the NewProcessingRecord function with an empty key.
+func TestNewProcessingRecordWithEmptyKey(t *testing.T) {
+	// When calling NewProcessingRecord with an empty key
+	record, err := entity.NewProcessingRecord("bucket", "", "etag", time.Now())
+	// Then the error returned is ErrEmptyObjectKey
+	assert.Nil(t, record)
+	assert.Equal(t, voProcessing.ErrEmptyObjectKey, err)
+}
+
+// TestProcessingRecordSucceed tests a record moves from processing to succeeded.
+func TestProcessingRecordSucceed(t *testing.T) {
+	// Given a new record
+	now := time.Now()
+	record, err := entity.NewProcessingRecord("bucket", "txns.csv", "etag", now)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.ProcessingStatusProcessing, record.Status)
+	assert.True(t, record.FinishedAt.IsZero())
+	// When the object is processed
+	record.Succeed(4, now.Add(time.Second))
+	// Then the record is succeeded with the stored transactions
+	assert.Equal(t, entity.ProcessingStatusSucceeded, record.Status)
+	assert.Equal(t, 4, record.Transactions)
+	assert.Equal(t, now.Add(time.Second), record.FinishedAt)
+}
+
+// TestProcessingRecordFail tests a failed record keeps the reason and has no transactions.
+func TestProcessingRecordFail(t *testing.T) {
+	// Given a new record
+	now := time.Now()
+	record, _ := entity.NewProcessingRecord("bucket", "txns.csv", "etag", now)
+	record.Transactions = 4
+	// When the processing fails
+	record.Fail(errors.New("timeout"), now)
+	// Then the record is failed with the reason
+	assert.Equal(t, entity.ProcessingStatusFailed, record.Status)
+	assert.Equal(t, "timeout", record.Error)
+	assert.Equal(t, 0, record.Transactions)
+}
+
+// TestObjectReferenceRoundTrip tests a reference is parsed from its message body.
+func TestObjectReferenceRoundTrip(t *testing.T) {
+	// Given a reference
+	object := entity.ObjectReference{Bucket: "bucket", Key: "uploads/txns.csv", ETag: "etag"}
+	// When encoding and parsing it
+	body, err := object.Body()
+	assert.Nil(t, err)
+	parsed, err := entity.ParseObjectReference(body)
+	// Then the same reference is returned
+	assert.Nil(t, err)
+	assert.Equal(t, object, parsed)
+}
+
+// TestParseObjectReferenceInvalid tests the error returned for bodies without a key.
+func TestParseObjectReferenceInvalid(t *testing.T) {
+	for _, body := range []string{"", "not json", `{"bucket":"bucket"}`} {
+		// When parsing an invalid body
+		_, err := entity.ParseObjectReference([]byte(body))
+		// Then the error returned is ErrInvalidIngestionMessage
+		assert.Equal(t, voProcessing.ErrInvalidIngestionMessage, err)
+	}
+}
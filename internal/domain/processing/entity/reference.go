@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"encoding/json"
+
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+)
+
+// ObjectReference struct defines a stored file to ingest. It is the body of the ingestion
+// queue messages.
+type ObjectReference struct {
+	// Bucket is the bucket of the file.
+	Bucket string `json:"bucket"`
+	// Key is the key of the file.
+	Key string `json:"key"`
+	// ETag is the ETag of the file, looked up in the store when empty.
+	ETag string `json:"etag,omitempty"`
+	// Uploader is the ID of the client that uploaded the file, recorded with its result.
+	Uploader string `json:"uploader,omitempty"`
+}
+
+// ParseObjectReference returns the reference in the body of a queue message.
+func ParseObjectReference(body []byte) (object ObjectReference, err error) {
+	if err = json.Unmarshal(body, &object); err != nil || object.Key == "" {
+		object = ObjectReference{}
+		err = voProcessing.ErrInvalidIngestionMessage
+	}
+	return
+}
+
+// Body returns the reference encoded as the body of a queue message.
+func (object ObjectReference) Body() (body []byte, err error) {
+	body, err = json.Marshal(object)
+	return
+}
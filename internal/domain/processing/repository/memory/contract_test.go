@@ -0,0 +1,18 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository/memory"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository/repositorytest"
+	voMemory "github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+)
+
+// TestProcessingRepositoryContract tests the memory repository against the
+// ProcessingRepository contract.
+func TestProcessingRepositoryContract(t *testing.T) {
+	repositorytest.RunProcessingRepositoryContract(t, func(t *testing.T) repository.ProcessingRepository {
+		return memory.NewMemoryProcessingRepository(voMemory.NewDatabase())
+	})
+}
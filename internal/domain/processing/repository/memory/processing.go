@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+)
+
+// processingTable is the table of the processing records.
+const processingTable = "processing_records"
+
+// memoryProcessingRepository is the in-memory implementation of the processing repository.
+type memoryProcessingRepository struct {
+	database *memory.Database
+}
+
+// NewMemoryProcessingRepository creates a new instance of repository.ProcessingRepository.
+func NewMemoryProcessingRepository(database *memory.Database) (processingRepo repository.ProcessingRepository) {
+	processingRepo = &memoryProcessingRepository{
+		database: database,
+	}
+	return
+}
+
+// GetByObject returns the processing record of an object.
+func (memoryRepo *memoryProcessingRepository) GetByObject(ctx context.Context, bucket, key, etag string) (record *entity.ProcessingRecord, err error) {
+	err = memoryRepo.database.Read(func(tx *memory.Tx) error {
+		row, ok := tx.Get(processingTable, recordKey(bucket, key, etag))
+		if !ok {
+			return voProcessing.ErrProcessingRecordNotFound
+		}
+		stored := row.(entity.ProcessingRecord)
+		record = &stored
+		return nil
+	})
+	return
+}
+
+// Save creates the processing record of the object or replaces the existing one.
+func (memoryRepo *memoryProcessingRepository) Save(ctx context.Context, record *entity.ProcessingRecord) (err error) {
+	if record == nil {
+		err = voProcessing.ErrNilProcessingRecord
+		return
+	}
+	err = memoryRepo.database.Write(func(tx *memory.Tx) error {
+		return tx.Put(processingTable, recordKey(record.Bucket, record.Key, record.ETag), *record)
+	})
+	return
+}
+
+// CountByUploaderSince returns the number of records of the uploader started since the
+// time, without the failed ones.
+func (memoryRepo *memoryProcessingRepository) CountByUploaderSince(ctx context.Context, uploader string, since time.Time) (count int, err error) {
+	err = memoryRepo.database.Read(func(tx *memory.Tx) error {
+		count = countByUploaderSince(tx, uploader, since)
+		return nil
+	})
+	return
+}
+
+// Reserve saves the record when its uploader has less than limit records started since
+// the time, without the failed ones.
+func (memoryRepo *memoryProcessingRepository) Reserve(ctx context.Context, record *entity.ProcessingRecord, limit int, since time.Time) (err error) {
+	if record == nil {
+		err = voProcessing.ErrNilProcessingRecord
+		return
+	}
+	err = memoryRepo.database.Write(func(tx *memory.Tx) error {
+		if limit > 0 && countByUploaderSince(tx, record.Uploader, since) >= limit {
+			return quota.ErrDailyQuotaExceeded
+		}
+		return tx.Put(processingTable, recordKey(record.Bucket, record.Key, record.ETag), *record)
+	})
+	return
+}
+
+// countByUploaderSince returns the number of records of the uploader started since the
+// time in the transaction, without the failed ones.
+func countByUploaderSince(tx *memory.Tx, uploader string, since time.Time) (count int) {
+	tx.Scan(processingTable, func(row interface{}) bool {
+		record := row.(entity.ProcessingRecord)
+		if record.Uploader == uploader && !record.StartedAt.Before(since) && record.Status != entity.ProcessingStatusFailed {
+			count++
+		}
+		return true
+	})
+	return
+}
+
+// recordKey returns the primary key of the record of an object.
+func recordKey(bucket, key, etag string) string {
+	return strings.Join([]string{bucket, key, etag}, "\x00")
+}
@@ -0,0 +1,53 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository/memory"
+	voMemory "github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetByObjectNotFound tests the error returned when the object has no record.
+func TestGetByObjectNotFound(t *testing.T) {
+	// Given an empty processing repository
+	processingRepo := memory.NewMemoryProcessingRepository(voMemory.NewDatabase())
+	// When getting the record of an object
+	record, err := processingRepo.GetByObject(context.Background(), "bucket", "txns.csv", "etag")
+	// Then the error returned is ErrProcessingRecordNotFound
+	assert.Nil(t, record)
+	assert.Equal(t, voProcessing.ErrProcessingRecordNotFound, err)
+}
+
+// TestSaveReplacesRecord tests saving the record of an object replaces the previous one.
+func TestSaveReplacesRecord(t *testing.T) {
+	// Given a processing repository with the record of an object
+	processingRepo := memory.NewMemoryProcessingRepository(voMemory.NewDatabase())
+	assert.Nil(t, processingRepo.Save(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: "txns.csv", ETag: "etag", Status: entity.ProcessingStatusProcessing}))
+	// And the record of another version of the object
+	assert.Nil(t, processingRepo.Save(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: "txns.csv", ETag: "other", Status: entity.ProcessingStatusFailed}))
+	// When saving the record of the object again
+	err := processingRepo.Save(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: "txns.csv", ETag: "etag", Status: entity.ProcessingStatusSucceeded, Lines: 4})
+	// Then the record is replaced
+	assert.Nil(t, err)
+	record, err := processingRepo.GetByObject(context.Background(), "bucket", "txns.csv", "etag")
+	assert.Nil(t, err)
+	assert.Equal(t, entity.ProcessingStatusSucceeded, record.Status)
+	assert.Equal(t, 4, record.Lines)
+	// And the record of the other version is kept
+	other, _ := processingRepo.GetByObject(context.Background(), "bucket", "txns.csv", "other")
+	assert.Equal(t, entity.ProcessingStatusFailed, other.Status)
+}
+
+// TestSaveWithNilRecord tests the error returned when the record is nil.
+func TestSaveWithNilRecord(t *testing.T) {
+	// Given an empty processing repository
+	processingRepo := memory.NewMemoryProcessingRepository(voMemory.NewDatabase())
+	// When saving a nil record
+	err := processingRepo.Save(context.Background(), nil)
+	// Then the error returned is ErrNilProcessingRecord
+	assert.Equal(t, voProcessing.ErrNilProcessingRecord, err)
+}
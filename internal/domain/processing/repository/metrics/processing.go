@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository"
+	voMetrics "github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+)
+
+// metricsProcessingRepository struct implements the ProcessingRepository interface observing the latency of every
+// method of the decorated repository.
+type metricsProcessingRepository struct {
+	processingRepo repository.ProcessingRepository
+	latency        *voMetrics.Histogram
+}
+
+// NewMetricsProcessingRepository returns the repository decorated with the latency metrics of the registry.
+func NewMetricsProcessingRepository(processingRepo repository.ProcessingRepository, registry *voMetrics.Registry) (decorated repository.ProcessingRepository) {
+	decorated = &metricsProcessingRepository{
+		processingRepo: processingRepo,
+		latency:        voMetrics.RepositoryLatency(registry),
+	}
+	return
+}
+
+// ProcessingRepository interface implementation
+
+func (metricsRepo *metricsProcessingRepository) GetByObject(ctx context.Context, bucket, key, etag string) (record *entity.ProcessingRecord, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "processing", "GetByObject")
+	return metricsRepo.processingRepo.GetByObject(ctx, bucket, key, etag)
+}
+
+func (metricsRepo *metricsProcessingRepository) Save(ctx context.Context, record *entity.ProcessingRecord) (err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "processing", "Save")
+	return metricsRepo.processingRepo.Save(ctx, record)
+}
+
+func (metricsRepo *metricsProcessingRepository) CountByUploaderSince(ctx context.Context, uploader string, since time.Time) (count int, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "processing", "CountByUploaderSince")
+	return metricsRepo.processingRepo.CountByUploaderSince(ctx, uploader, since)
+}
+
+func (metricsRepo *metricsProcessingRepository) Reserve(ctx context.Context, record *entity.ProcessingRecord, limit int, since time.Time) (err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "processing", "Reserve")
+	return metricsRepo.processingRepo.Reserve(ctx, record, limit, since)
+}
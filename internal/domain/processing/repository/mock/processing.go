@@ -0,0 +1,91 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockProcessingRepository is a mock implementation of the processing repository.
+type mockProcessingRepository struct {
+	mock.Mock
+}
+
+// NewMockProcessingRepository returns a new mock processing repository.
+func NewMockProcessingRepository() *mockProcessingRepository {
+	return &mockProcessingRepository{}
+}
+
+// GetByObject provides a mock function with given fields: ctx, bucket, key, etag
+func (_m *mockProcessingRepository) GetByObject(ctx context.Context, bucket, key, etag string) (record *entity.ProcessingRecord, err error) {
+	ret := _m.Called(ctx, bucket, key, etag)
+
+	var r0 *entity.ProcessingRecord
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *entity.ProcessingRecord); ok {
+		r0 = rf(ctx, bucket, key, etag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProcessingRecord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, bucket, key, etag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, record
+func (_m *mockProcessingRepository) Save(ctx context.Context, record *entity.ProcessingRecord) (err error) {
+	ret := _m.Called(ctx, record)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ProcessingRecord) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountByUploaderSince provides a mock function with given fields: ctx, uploader, since
+func (_m *mockProcessingRepository) CountByUploaderSince(ctx context.Context, uploader string, since time.Time) (count int, err error) {
+	ret := _m.Called(ctx, uploader, since)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) int); ok {
+		r0 = rf(ctx, uploader, since)
+	} else {
+		r0 = ret.Int(0)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, uploader, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reserve provides a mock function with given fields: ctx, record, limit, since
+func (_m *mockProcessingRepository) Reserve(ctx context.Context, record *entity.ProcessingRecord, limit int, since time.Time) (err error) {
+	ret := _m.Called(ctx, record, limit, since)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ProcessingRecord, int, time.Time) error); ok {
+		r0 = rf(ctx, record, limit, since)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
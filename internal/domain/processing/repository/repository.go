@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+)
+
+// ProcessingRepository interface defines the methods that the processing repository must implement.
+type ProcessingRepository interface {
+	// GetByObject returns the processing record of an object.
+	GetByObject(ctx context.Context, bucket, key, etag string) (record *entity.ProcessingRecord, err error)
+	// Save creates the processing record of the object or replaces the existing one.
+	Save(ctx context.Context, record *entity.ProcessingRecord) (err error)
+	// CountByUploaderSince returns the number of records of the uploader started since the
+	// time, without the failed ones.
+	CountByUploaderSince(ctx context.Context, uploader string, since time.Time) (count int, err error)
+	// Reserve saves the record like Save when its uploader has less than limit records
+	// started since the time, without the failed ones, or returns quota.ErrDailyQuotaExceeded.
+	// The count and the save are atomic. A zero limit saves the record.
+	Reserve(ctx context.Context, record *entity.ProcessingRecord, limit int, since time.Time) (err error)
+}
@@ -0,0 +1,162 @@
+// Package repositorytest holds the contract of the ProcessingRepository: a record is kept
+// per object, saving it again replaces it, the uploads not failed are counted and a record
+// is reserved only within the limit of its uploader, even by concurrent uploads.
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/stretchr/testify/assert"
+)
+
+// ProcessingRepositoryFactory returns an empty processing repository for a test.
+type ProcessingRepositoryFactory func(t *testing.T) (processingRepo repository.ProcessingRepository)
+
+// RunProcessingRepositoryContract runs the contract of the ProcessingRepository interface
+// against the repositories of the factory, one empty repository per subtest.
+func RunProcessingRepositoryContract(t *testing.T, newRepository ProcessingRepositoryFactory) {
+	// now is truncated so every database stores it exactly.
+	now := time.Now().UTC().Truncate(time.Second)
+	t.Run("NotFound", func(t *testing.T) {
+		// When getting the record of an object without records
+		_, err := newRepository(t).GetByObject(context.Background(), "bucket", "txns.csv", "etag")
+		// Then the error returned is ErrProcessingRecordNotFound
+		assert.Equal(t, voProcessing.ErrProcessingRecordNotFound, err)
+	})
+	t.Run("SaveAndGet", func(t *testing.T) {
+		// Given an empty repository
+		processingRepo := newRepository(t)
+		record := &entity.ProcessingRecord{Bucket: "bucket", Key: "txns.csv", ETag: "etag",
+			Status: entity.ProcessingStatusFailed, Error: "file has invalid lines", Lines: 4, InvalidLines: 1,
+			StartedAt: now, FinishedAt: now.Add(time.Second), Uploader: "0123456789abcdef"}
+		// When saving a record
+		err := processingRepo.Save(context.Background(), record)
+		// Then it is returned by its object
+		assert.Nil(t, err)
+		stored, err := processingRepo.GetByObject(context.Background(), "bucket", "txns.csv", "etag")
+		assert.Nil(t, err)
+		if assert.NotNil(t, stored) {
+			assert.Equal(t, record.Status, stored.Status)
+			assert.Equal(t, record.Error, stored.Error)
+			assert.Equal(t, record.Lines, stored.Lines)
+			assert.Equal(t, record.InvalidLines, stored.InvalidLines)
+			assert.True(t, record.StartedAt.Equal(stored.StartedAt))
+			assert.True(t, record.FinishedAt.Equal(stored.FinishedAt))
+			assert.Equal(t, record.Uploader, stored.Uploader)
+		}
+	})
+	t.Run("SaveReplaces", func(t *testing.T) {
+		// Given a repository with the records of two versions of an object
+		processingRepo := newRepository(t)
+		assert.Nil(t, processingRepo.Save(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: "txns.csv", ETag: "etag",
+			Status: entity.ProcessingStatusProcessing, StartedAt: now}))
+		assert.Nil(t, processingRepo.Save(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: "txns.csv", ETag: "other",
+			Status: entity.ProcessingStatusFailed, StartedAt: now}))
+		// When saving the record of the first version again
+		err := processingRepo.Save(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: "txns.csv", ETag: "etag",
+			Status: entity.ProcessingStatusSucceeded, Transactions: 3, StartedAt: now, FinishedAt: now})
+		// Then it is replaced
+		assert.Nil(t, err)
+		record, _ := processingRepo.GetByObject(context.Background(), "bucket", "txns.csv", "etag")
+		assert.Equal(t, entity.ProcessingStatusSucceeded, record.Status)
+		assert.Equal(t, 3, record.Transactions)
+		// And the record of the other version is kept
+		other, _ := processingRepo.GetByObject(context.Background(), "bucket", "txns.csv", "other")
+		assert.Equal(t, entity.ProcessingStatusFailed, other.Status)
+	})
+	t.Run("SaveNil", func(t *testing.T) {
+		// When saving a nil record
+		err := newRepository(t).Save(context.Background(), nil)
+		// Then the error returned is ErrNilProcessingRecord
+		assert.Equal(t, voProcessing.ErrNilProcessingRecord, err)
+	})
+	t.Run("CountByUploaderSince", func(t *testing.T) {
+		// Given a repository with the records of two uploaders
+		processingRepo := newRepository(t)
+		records := []*entity.ProcessingRecord{
+			{Bucket: "bucket", Key: "a.csv", ETag: "etag", Status: entity.ProcessingStatusSucceeded, StartedAt: now, Uploader: "client"},
+			{Bucket: "bucket", Key: "b.csv", ETag: "etag", Status: entity.ProcessingStatusProcessing, StartedAt: now.Add(time.Minute), Uploader: "client"},
+			{Bucket: "bucket", Key: "c.csv", ETag: "etag", Status: entity.ProcessingStatusFailed, StartedAt: now, Uploader: "client"},
+			{Bucket: "bucket", Key: "d.csv", ETag: "etag", Status: entity.ProcessingStatusSucceeded, StartedAt: now.Add(-time.Hour), Uploader: "client"},
+			{Bucket: "bucket", Key: "e.csv", ETag: "etag", Status: entity.ProcessingStatusSucceeded, StartedAt: now, Uploader: "other"},
+		}
+		for _, record := range records {
+			assert.Nil(t, processingRepo.Save(context.Background(), record))
+		}
+		// When counting the records of the uploader since now
+		count, err := processingRepo.CountByUploaderSince(context.Background(), "client", now)
+		// Then only the records started since now and not failed are counted
+		assert.Nil(t, err)
+		assert.Equal(t, 2, count)
+	})
+	t.Run("Reserve", func(t *testing.T) {
+		// Given a repository with a succeeded and a failed record of the uploader
+		processingRepo := newRepository(t)
+		assert.Nil(t, processingRepo.Save(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: "a.csv", ETag: "etag",
+			Status: entity.ProcessingStatusSucceeded, StartedAt: now, Uploader: "client"}))
+		assert.Nil(t, processingRepo.Save(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: "b.csv", ETag: "etag",
+			Status: entity.ProcessingStatusFailed, StartedAt: now, Uploader: "client"}))
+		// When reserving a record within the limit of 2
+		err := processingRepo.Reserve(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: "c.csv", ETag: "etag",
+			Status: entity.ProcessingStatusProcessing, StartedAt: now, Uploader: "client"}, 2, now)
+		// Then it is saved
+		assert.Nil(t, err)
+		_, err = processingRepo.GetByObject(context.Background(), "bucket", "c.csv", "etag")
+		assert.Nil(t, err)
+		// When reserving a record beyond the limit
+		err = processingRepo.Reserve(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: "d.csv", ETag: "etag",
+			Status: entity.ProcessingStatusProcessing, StartedAt: now, Uploader: "client"}, 2, now)
+		// Then the error returned is ErrDailyQuotaExceeded and it is not saved
+		assert.Equal(t, quota.ErrDailyQuotaExceeded, err)
+		_, err = processingRepo.GetByObject(context.Background(), "bucket", "d.csv", "etag")
+		assert.Equal(t, voProcessing.ErrProcessingRecordNotFound, err)
+		// When reserving a record without limit
+		err = processingRepo.Reserve(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: "d.csv", ETag: "etag",
+			Status: entity.ProcessingStatusProcessing, StartedAt: now, Uploader: "client"}, 0, now)
+		// Then it is saved
+		assert.Nil(t, err)
+	})
+	t.Run("ReserveConcurrently", func(t *testing.T) {
+		// Given an empty repository
+		processingRepo := newRepository(t)
+		// When reserving 10 records of the uploader at once within the limit of 3
+		var wg sync.WaitGroup
+		errs := make([]error, 10)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = processingRepo.Reserve(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: fmt.Sprintf("%d.csv", i),
+					ETag: "etag", Status: entity.ProcessingStatusProcessing, StartedAt: now, Uploader: "client"}, 3, now)
+			}(i)
+		}
+		wg.Wait()
+		// Then only 3 records are reserved
+		reserved := 0
+		for _, err := range errs {
+			if err == nil {
+				reserved++
+			} else {
+				assert.Equal(t, quota.ErrDailyQuotaExceeded, err)
+			}
+		}
+		assert.Equal(t, 3, reserved)
+		count, err := processingRepo.CountByUploaderSince(context.Background(), "client", now)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, count)
+	})
+	t.Run("ReserveNil", func(t *testing.T) {
+		// When reserving a nil record
+		err := newRepository(t).Reserve(context.Background(), nil, 1, now)
+		// Then the error returned is ErrNilProcessingRecord
+		assert.Equal(t, voProcessing.ErrNilProcessingRecord, err)
+	})
+}
@@ -0,0 +1,20 @@
+package sqldb_test
+
+import (
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository/repositorytest"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository/sqldb"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database/databasetest"
+)
+
+// TestProcessingRepositoryContract tests the repository against the ProcessingRepository
+// contract on every dialect.
+func TestProcessingRepositoryContract(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, newDatabase databasetest.Factory) {
+		repositorytest.RunProcessingRepositoryContract(t, func(t *testing.T) repository.ProcessingRepository {
+			return sqldb.NewSQLProcessingRepository(newDatabase(t))
+		})
+	})
+}
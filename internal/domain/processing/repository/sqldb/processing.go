@@ -0,0 +1,229 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+)
+
+// sqlProcessingRepository is the implementation of the processing repository using a
+// PostgreSQL or a SQLite database.
+type sqlProcessingRepository struct {
+	baseDB database.Database
+	// logger logs the database errors.
+	logger logger.Logger
+}
+
+// SQLProcessingRepositoryOption configures an optional collaborator of the processing repository.
+type SQLProcessingRepositoryOption func(sqlRepo *sqlProcessingRepository)
+
+// WithLogger logs the database errors with the logger instead of the default one. A nil
+// logger keeps the default one.
+func WithLogger(log logger.Logger) SQLProcessingRepositoryOption {
+	return func(sqlRepo *sqlProcessingRepository) {
+		if log != nil {
+			sqlRepo.logger = log
+		}
+	}
+}
+
+// NewSQLProcessingRepository creates a new instance of repository.ProcessingRepository.
+func NewSQLProcessingRepository(baseDB database.Database, options ...SQLProcessingRepositoryOption) (processingRepo repository.ProcessingRepository) {
+	sqlRepo := &sqlProcessingRepository{
+		baseDB: baseDB,
+		logger: logger.NewDefaultLogger(),
+	}
+	for _, option := range options {
+		option(sqlRepo)
+	}
+	processingRepo = sqlRepo
+	return
+}
+
+// repository.ProcessingRepository implementation.
+
+// GetByObject returns the processing record of an object.
+const (
+	getProcessingRecord = `SELECT bucket, object_key, etag, status, error, lines, invalid_lines, transactions, started_at, finished_at, uploader FROM processing_records WHERE bucket = $1 AND object_key = $2 AND etag = $3`
+)
+
+func (sqlRepo *sqlProcessingRepository) GetByObject(ctx context.Context, bucket, key, etag string) (record *entity.ProcessingRecord, err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	rows, err := database.Query(ctx, sqlRepo.baseDB, dbTx, getProcessingRecord, bucket, key, etag)
+	if err != nil {
+		logger.FromContext(ctx, sqlRepo.logger).Error("error querying processing record", "key", key, "error", err)
+		err = database.Wrap(voProcessing.ErrQueryingProcessingRecord, err)
+		return
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		err = voProcessing.ErrProcessingRecordNotFound
+		if rows.Err() != nil {
+			err = database.Wrap(voProcessing.ErrQueryingProcessingRecord, rows.Err())
+		}
+		return
+	}
+	record = &entity.ProcessingRecord{}
+	finishedAt := sql.NullTime{}
+	err = rows.Scan(&record.Bucket, &record.Key, &record.ETag, &record.Status, &record.Error, &record.Lines,
+		&record.InvalidLines, &record.Transactions, &record.StartedAt, &finishedAt, &record.Uploader)
+	if err != nil {
+		record = nil
+		err = database.Wrap(voProcessing.ErrScanningProcessingRecord, err)
+		return
+	}
+	record.FinishedAt = finishedAt.Time
+	return
+}
+
+// Save creates the processing record of the object or replaces the existing one.
+const (
+	saveProcessingRecord = `INSERT INTO processing_records (bucket, object_key, etag, status, error, lines, invalid_lines, transactions, started_at, finished_at, uploader) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (bucket, object_key, etag) DO UPDATE SET status = EXCLUDED.status, error = EXCLUDED.error, lines = EXCLUDED.lines, invalid_lines = EXCLUDED.invalid_lines, transactions = EXCLUDED.transactions, started_at = EXCLUDED.started_at, finished_at = EXCLUDED.finished_at, uploader = EXCLUDED.uploader`
+)
+
+func (sqlRepo *sqlProcessingRepository) Save(ctx context.Context, record *entity.ProcessingRecord) (err error) {
+	if record == nil {
+		err = voProcessing.ErrNilProcessingRecord
+		return
+	}
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	if err = sqlRepo.save(ctx, dbTx, record); err != nil {
+		return
+	}
+	err = sqlRepo.baseDB.Commit(dbTx)
+	return
+}
+
+// save saves the record in the transaction.
+func (sqlRepo *sqlProcessingRepository) save(ctx context.Context, dbTx *sql.Tx, record *entity.ProcessingRecord) (err error) {
+	dialect := sqlRepo.baseDB.Dialect()
+	finishedAt := sql.NullTime{Time: dialect.Time(record.FinishedAt), Valid: !record.FinishedAt.IsZero()}
+	_, err = database.Exec(ctx, sqlRepo.baseDB, dbTx, saveProcessingRecord, record.Bucket, record.Key, record.ETag, record.Status,
+		record.Error, record.Lines, record.InvalidLines, record.Transactions, dialect.Time(record.StartedAt), finishedAt, record.Uploader)
+	if err != nil {
+		logger.FromContext(ctx, sqlRepo.logger).Error("error saving processing record in database", "key", record.Key, "error", err)
+		err = database.Wrap(voProcessing.ErrSavingProcessingRecord, err)
+	}
+	return
+}
+
+// CountByUploaderSince returns the number of records of the uploader started since the
+// time, without the failed ones.
+const (
+	countProcessingRecordsByUploader = `SELECT COUNT(*) FROM processing_records WHERE uploader = $1 AND started_at >= $2 AND status <> $3`
+)
+
+func (sqlRepo *sqlProcessingRepository) CountByUploaderSince(ctx context.Context, uploader string, since time.Time) (count int, err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	count, err = sqlRepo.count(ctx, dbTx, uploader, since)
+	return
+}
+
+// count returns the number of records of the uploader in the transaction.
+func (sqlRepo *sqlProcessingRepository) count(ctx context.Context, dbTx *sql.Tx, uploader string, since time.Time) (count int, err error) {
+	rows, err := database.Query(ctx, sqlRepo.baseDB, dbTx, countProcessingRecordsByUploader, uploader, sqlRepo.baseDB.Dialect().Time(since), entity.ProcessingStatusFailed)
+	if err != nil {
+		logger.FromContext(ctx, sqlRepo.logger).Error("error counting processing records", "uploader", uploader, "error", err)
+		err = database.Wrap(voProcessing.ErrQueryingProcessingRecord, err)
+		return
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		err = voProcessing.ErrQueryingProcessingRecord
+		return
+	}
+	if err = rows.Scan(&count); err != nil {
+		err = database.Wrap(voProcessing.ErrScanningProcessingRecord, err)
+	}
+	return
+}
+
+// Reserve saves the record when its uploader has less than limit records started since the
+// time. The uploads of an uploader wait for each other on an advisory lock in PostgreSQL. In
+// SQLite every transaction begins immediate, taking the write lock of the file, and waits up
+// to the busy timeout for it, see SQLiteConfiguration.GetDataSourceName, so the count and the save are
+// atomic in both.
+const (
+	lockProcessingUploader = `SELECT pg_advisory_xact_lock(hashtext($1))`
+)
+
+func (sqlRepo *sqlProcessingRepository) Reserve(ctx context.Context, record *entity.ProcessingRecord, limit int, since time.Time) (err error) {
+	if record == nil {
+		err = voProcessing.ErrNilProcessingRecord
+		return
+	}
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	if limit > 0 {
+		if sqlRepo.baseDB.Dialect() == database.Postgres {
+			if _, err = database.Exec(ctx, sqlRepo.baseDB, dbTx, lockProcessingUploader, record.Uploader); err != nil {
+				logger.FromContext(ctx, sqlRepo.logger).Error("error locking the processing records of the uploader", "uploader", record.Uploader, "error", err)
+				err = database.Wrap(voProcessing.ErrSavingProcessingRecord, err)
+				return
+			}
+		}
+		var count int
+		if count, err = sqlRepo.count(ctx, dbTx, record.Uploader, since); err != nil {
+			return
+		}
+		if count >= limit {
+			err = quota.ErrDailyQuotaExceeded
+			return
+		}
+	}
+	if err = sqlRepo.save(ctx, dbTx, record); err != nil {
+		return
+	}
+	err = sqlRepo.baseDB.Commit(dbTx)
+	return
+}
@@ -0,0 +1,331 @@
+package sqldb_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository/sqldb"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	mockvoPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres/mock"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	getQuery   = "SELECT bucket, object_key, etag, status, error, lines, invalid_lines, transactions, started_at, finished_at, uploader FROM processing_records WHERE bucket = $1 AND object_key = $2 AND etag = $3"
+	countQuery = "SELECT COUNT(*) FROM processing_records WHERE uploader = $1 AND started_at >= $2 AND status <> $3"
+	saveQuery  = "INSERT INTO processing_records (bucket, object_key, etag, status, error, lines, invalid_lines, transactions, started_at, finished_at, uploader) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (bucket, object_key, etag) DO UPDATE SET status = EXCLUDED.status, error = EXCLUDED.error, lines = EXCLUDED.lines, invalid_lines = EXCLUDED.invalid_lines, transactions = EXCLUDED.transactions, started_at = EXCLUDED.started_at, finished_at = EXCLUDED.finished_at, uploader = EXCLUDED.uploader"
+)
+
+var processingColumns = []string{"bucket", "object_key", "etag", "status", "error", "lines", "invalid_lines", "transactions", "started_at", "finished_at", "uploader"}
+
+// TestGetByObjectErrOpeningDatabase tests the error returned when the database cannot be opened.
+func TestGetByObjectErrOpeningDatabase(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid processing repository.
+	processingRepo := sqldb.NewSQLProcessingRepository(dbBaseMocked)
+	// And a mocked response calling Open.
+	dbBaseMocked.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
+	// When getting the record of an object.
+	record, err := processingRepo.GetByObject(context.Background(), "bucket", "txns.csv", "etag")
+	// Then the error returned is ErrOpeningDatabase.
+	assert.Nil(t, record)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
+}
+
+// TestGetByObjectErrQuerying tests the error returned when the query fails.
+func TestGetByObjectErrQuerying(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid processing repository.
+	processingRepo := sqldb.NewSQLProcessingRepository(dbBaseMocked)
+	// And a sqlmock database.
+	db, _, _ := sqlmock.New()
+	dbTx, _ := db.Begin()
+	// And mocked responses calling Open, Close, BeginTx, Rollback and Query.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	dbBaseMocked.On("Query", dbTx, getQuery, []interface{}{"bucket", "txns.csv", "etag"}).Return(nil, voPostgres.ErrQueryingDatabase)
+	// When getting the record of an object.
+	_, err := processingRepo.GetByObject(context.Background(), "bucket", "txns.csv", "etag")
+	// Then the error returned is ErrQueryingProcessingRecord.
+	assert.ErrorIs(t, err, voProcessing.ErrQueryingProcessingRecord)
+}
+
+// TestGetByObjectNotFound tests the error returned when the object has no record.
+func TestGetByObjectNotFound(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	dbBase := voPostgres.NewBasePostgresDatabase(voPostgres.NewDefaultPostgresConfiguration())
+	// And a valid processing repository.
+	processingRepo := sqldb.NewSQLProcessingRepository(dbBaseMocked)
+	// And a sqlmock database.
+	db, dbMocked, _ := sqlmock.New()
+	dbMocked.ExpectBegin()
+	defer db.Close()
+	dbTx, _ := db.BeginTx(context.Background(), nil)
+	// And mocked responses calling Open, Close, BeginTx and Rollback.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	// And an empty result.
+	dbMocked.ExpectQuery("SELECT (.+) FROM processing_records (.+)").WillReturnRows(sqlmock.NewRows(processingColumns))
+	rows, err := dbBase.Query(dbTx, getQuery, "bucket", "txns.csv", "etag")
+	assert.Nil(t, err)
+	dbBaseMocked.On("Query", dbTx, getQuery, []interface{}{"bucket", "txns.csv", "etag"}).Return(rows, nil)
+	// When getting the record of an object.
+	record, err := processingRepo.GetByObject(context.Background(), "bucket", "txns.csv", "etag")
+	// Then the error returned is ErrProcessingRecordNotFound.
+	assert.Nil(t, record)
+	assert.Equal(t, voProcessing.ErrProcessingRecordNotFound, err)
+}
+
+// TestGetByObjectSuccess tests the record of the object is returned.
+func TestGetByObjectSuccess(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	dbBase := voPostgres.NewBasePostgresDatabase(voPostgres.NewDefaultPostgresConfiguration())
+	// And a valid processing repository.
+	processingRepo := sqldb.NewSQLProcessingRepository(dbBaseMocked)
+	// And a sqlmock database.
+	db, dbMocked, _ := sqlmock.New()
+	dbMocked.ExpectBegin()
+	defer db.Close()
+	dbTx, _ := db.BeginTx(context.Background(), nil)
+	// And mocked responses calling Open, Close, BeginTx and Rollback.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	// And a failed record.
+	now := time.Now()
+	expected := sqlmock.NewRows(processingColumns)
+	expected.AddRow("bucket", "txns.csv", "etag", entity.ProcessingStatusFailed, "file has invalid lines", 4, 1, 0, now, now, "0123456789abcdef")
+	dbMocked.ExpectQuery("SELECT (.+) FROM processing_records (.+)").WillReturnRows(expected)
+	rows, err := dbBase.Query(dbTx, getQuery, "bucket", "txns.csv", "etag")
+	assert.Nil(t, err)
+	dbBaseMocked.On("Query", dbTx, getQuery, []interface{}{"bucket", "txns.csv", "etag"}).Return(rows, nil)
+	// When getting the record of an object.
+	record, err := processingRepo.GetByObject(context.Background(), "bucket", "txns.csv", "etag")
+	// Then the record is returned.
+	assert.Nil(t, err)
+	assert.Equal(t, entity.ProcessingStatusFailed, record.Status)
+	assert.Equal(t, "file has invalid lines", record.Error)
+	assert.Equal(t, 4, record.Lines)
+	assert.Equal(t, 1, record.InvalidLines)
+	assert.Equal(t, now, record.FinishedAt)
+	assert.Equal(t, "0123456789abcdef", record.Uploader)
+}
+
+// TestSaveWithNilRecord tests the error returned when the record is nil.
+func TestSaveWithNilRecord(t *testing.T) {
+	// Given a valid processing repository.
+	processingRepo := sqldb.NewSQLProcessingRepository(mockvoPostgres.NewMockBasePostgresDatabase())
+	// When saving a nil record.
+	err := processingRepo.Save(context.Background(), nil)
+	// Then the error returned is ErrNilProcessingRecord.
+	assert.Equal(t, voProcessing.ErrNilProcessingRecord, err)
+}
+
+// TestSaveErrExecuting tests the error returned when the upsert fails.
+func TestSaveErrExecuting(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid processing repository.
+	processingRepo := sqldb.NewSQLProcessingRepository(dbBaseMocked)
+	// And a sqlmock database.
+	db, _, _ := sqlmock.New()
+	dbTx, _ := db.Begin()
+	// And mocked responses calling Open, Close, BeginTx and Rollback.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", dbTx).Return(nil)
+	// And a record being processed.
+	record, _ := entity.NewProcessingRecord("bucket", "txns.csv", "etag", time.Now())
+	// And a mocked response calling Exec.
+	dbBaseMocked.On("Exec", dbTx, saveQuery, []interface{}{
+		"bucket", "txns.csv", "etag", entity.ProcessingStatusProcessing, "", 0, 0, 0, record.StartedAt, sql.NullTime{}, "",
+	}).Return(nil, voPostgres.ErrExec)
+	// When saving the record.
+	err := processingRepo.Save(context.Background(), record)
+	// Then the error returned is ErrSavingProcessingRecord.
+	assert.ErrorIs(t, err, voProcessing.ErrSavingProcessingRecord)
+}
+
+// TestSaveSuccess tests a finished record is saved.
+func TestSaveSuccess(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid processing repository.
+	processingRepo := sqldb.NewSQLProcessingRepository(dbBaseMocked)
+	// And a sqlmock database.
+	db, _, _ := sqlmock.New()
+	dbTx, _ := db.Begin()
+	// And mocked responses calling Open, Close, BeginTx, Rollback and Commit.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", dbTx).Return(nil)
+	dbBaseMocked.On("Commit", dbTx).Return(nil)
+	// And a succeeded record.
+	now := time.Now()
+	record, _ := entity.NewProcessingRecord("bucket", "txns.csv", "etag", now)
+	record.Lines = 4
+	record.Succeed(4, now)
+	record.Uploader = "0123456789abcdef"
+	// And a mocked response calling Exec.
+	dbBaseMocked.On("Exec", dbTx, saveQuery, []interface{}{
+		"bucket", "txns.csv", "etag", entity.ProcessingStatusSucceeded, "", 4, 0, 4, now, sql.NullTime{Time: now, Valid: true}, "0123456789abcdef",
+	}).Return(nil, nil)
+	// When saving the record.
+	err := processingRepo.Save(context.Background(), record)
+	// Then the error returned is nil.
+	assert.Nil(t, err)
+}
+
+// TestCountByUploaderSinceSuccess tests the records of the uploader are counted.
+func TestCountByUploaderSinceSuccess(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	dbBase := voPostgres.NewBasePostgresDatabase(voPostgres.NewDefaultPostgresConfiguration())
+	// And a valid processing repository.
+	processingRepo := sqldb.NewSQLProcessingRepository(dbBaseMocked)
+	// And a sqlmock database.
+	db, dbMocked, _ := sqlmock.New()
+	dbMocked.ExpectBegin()
+	defer db.Close()
+	dbTx, _ := db.BeginTx(context.Background(), nil)
+	// And mocked responses calling Open, Close, BeginTx and Rollback.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	// And three records of the uploader.
+	since := time.Now()
+	dbMocked.ExpectQuery("SELECT COUNT(.+) FROM processing_records (.+)").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	rows, err := dbBase.Query(dbTx, countQuery, "client", since, entity.ProcessingStatusFailed)
+	assert.Nil(t, err)
+	dbBaseMocked.On("Query", dbTx, countQuery, []interface{}{"client", since, entity.ProcessingStatusFailed}).Return(rows, nil)
+	// When counting the records of the uploader.
+	count, err := processingRepo.CountByUploaderSince(context.Background(), "client", since)
+	// Then the count is returned.
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+}
+
+// TestCountByUploaderSinceErrQuerying tests the error returned when the count query fails.
+func TestCountByUploaderSinceErrQuerying(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid processing repository.
+	processingRepo := sqldb.NewSQLProcessingRepository(dbBaseMocked)
+	// And a sqlmock database.
+	db, _, _ := sqlmock.New()
+	dbTx, _ := db.Begin()
+	// And mocked responses calling Open, Close, BeginTx, Rollback and Query.
+	since := time.Now()
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	dbBaseMocked.On("Query", dbTx, countQuery, []interface{}{"client", since, entity.ProcessingStatusFailed}).Return(nil, voPostgres.ErrQueryingDatabase)
+	// When counting the records of the uploader.
+	_, err := processingRepo.CountByUploaderSince(context.Background(), "client", since)
+	// Then the error returned is ErrQueryingProcessingRecord.
+	assert.ErrorIs(t, err, voProcessing.ErrQueryingProcessingRecord)
+}
+
+// TestReserveErrLocking tests the error returned when the records of the uploader cannot be locked.
+func TestReserveErrLocking(t *testing.T) {
+	// Given a mocked database.
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid processing repository logging to a buffer.
+	output := &bytes.Buffer{}
+	processingRepo := sqldb.NewSQLProcessingRepository(dbBaseMocked, sqldb.WithLogger(logger.NewJSONLogger(output, logger.LevelInfo)))
+	// And a sqlmock database.
+	db, _, _ := sqlmock.New()
+	dbTx, _ := db.Begin()
+	// And mocked responses calling Open, Close, BeginTx, Rollback and Exec.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	dbBaseMocked.On("Exec", dbTx, "SELECT pg_advisory_xact_lock(hashtext($1))", []interface{}{"client"}).Return(nil, voPostgres.ErrExec)
+	// And a record of the uploader.
+	record, _ := entity.NewProcessingRecord("bucket", "txns.csv", "etag", time.Now())
+	record.Uploader = "client"
+	// When reserving the record within a request.
+	err := processingRepo.Reserve(logger.WithRequestID(context.Background(), "request-1"), record, 3, time.Now())
+	// Then the error returned is ErrSavingProcessingRecord.
+	assert.ErrorIs(t, err, voProcessing.ErrSavingProcessingRecord)
+	dbBaseMocked.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+	// And the error is logged with the request ID and the uploader.
+	line := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(output.Bytes(), &line))
+	assert.Equal(t, "error locking the processing records of the uploader", line["msg"])
+	assert.Equal(t, "request-1", line["request_id"])
+	assert.Equal(t, "client", line["uploader"])
+}
+
+// TestReserveConcurrentlyOnSQLiteFile tests the reservations of two processes sharing a
+// SQLite file wait for each other instead of failing with SQLITE_BUSY.
+func TestReserveConcurrentlyOnSQLiteFile(t *testing.T) {
+	// Given two pools of the same migrated database file, as two processes
+	path := filepath.Join(t.TempDir(), "test.db")
+	repos := []repository.ProcessingRepository{}
+	for i := 0; i < 2; i++ {
+		pool, err := sqlite.NewSQLitePool(sqlite.NewSQLiteConfiguration(path))
+		assert.Nil(t, err)
+		t.Cleanup(func() { _ = pool.Shutdown() })
+		if i == 0 {
+			migrator, err := migrations.NewSQLiteMigrator(pool)
+			assert.Nil(t, err)
+			_, err = migrator.Up()
+			assert.Nil(t, err)
+		}
+		repos = append(repos, sqldb.NewSQLProcessingRepository(pool))
+	}
+	// When both reserve 10 records of the uploader at once within the limit of 3
+	now := time.Now().UTC().Truncate(time.Second)
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repos[i%2].Reserve(context.Background(), &entity.ProcessingRecord{Bucket: "bucket", Key: fmt.Sprintf("%d.csv", i),
+				ETag: "etag", Status: entity.ProcessingStatusProcessing, StartedAt: now, Uploader: "client"}, 3, now)
+		}(i)
+	}
+	wg.Wait()
+	// Then only 3 records are reserved and the others exceed the quota
+	reserved := 0
+	for _, err := range errs {
+		if err == nil {
+			reserved++
+		} else {
+			assert.Equal(t, quota.ErrDailyQuotaExceeded, err)
+		}
+	}
+	assert.Equal(t, 3, reserved)
+}
@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository"
+	voTracing "github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+)
+
+// tracingProcessingRepository struct implements the ProcessingRepository interface starting a span for every
+// method of the decorated repository.
+type tracingProcessingRepository struct {
+	processingRepo repository.ProcessingRepository
+}
+
+// NewTracingProcessingRepository returns the repository decorated with the spans of its methods, children
+// of the span carried by their context.
+func NewTracingProcessingRepository(processingRepo repository.ProcessingRepository) (decorated repository.ProcessingRepository) {
+	decorated = &tracingProcessingRepository{
+		processingRepo: processingRepo,
+	}
+	return
+}
+
+// ProcessingRepository interface implementation
+
+func (tracingRepo *tracingProcessingRepository) GetByObject(ctx context.Context, bucket, key, etag string) (record *entity.ProcessingRecord, err error) {
+	ctx, span := voTracing.Start(ctx, "ProcessingRepository.GetByObject")
+	defer span.EndWithError(&err)
+	return tracingRepo.processingRepo.GetByObject(ctx, bucket, key, etag)
+}
+
+func (tracingRepo *tracingProcessingRepository) Save(ctx context.Context, record *entity.ProcessingRecord) (err error) {
+	ctx, span := voTracing.Start(ctx, "ProcessingRepository.Save")
+	defer span.EndWithError(&err)
+	return tracingRepo.processingRepo.Save(ctx, record)
+}
+
+func (tracingRepo *tracingProcessingRepository) CountByUploaderSince(ctx context.Context, uploader string, since time.Time) (count int, err error) {
+	ctx, span := voTracing.Start(ctx, "ProcessingRepository.CountByUploaderSince")
+	defer span.EndWithError(&err)
+	return tracingRepo.processingRepo.CountByUploaderSince(ctx, uploader, since)
+}
+
+func (tracingRepo *tracingProcessingRepository) Reserve(ctx context.Context, record *entity.ProcessingRecord, limit int, since time.Time) (err error) {
+	ctx, span := voTracing.Start(ctx, "ProcessingRepository.Reserve")
+	defer span.EndWithError(&err)
+	return tracingRepo.processingRepo.Reserve(ctx, record, limit, since)
+}
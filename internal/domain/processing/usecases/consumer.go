@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+)
+
+// consumerUseCases struct implements the ConsumerUseCases interface.
+type consumerUseCases struct {
+	ingestion      IngestionUseCases
+	ingestionQueue queue.Queue
+	deadLetter     queue.Queue
+	configuration  *queue.QueueConfiguration
+	// logger logs the handled messages.
+	logger logger.Logger
+}
+
+// ConsumerUseCasesOption configures an optional collaborator of the consumer use cases.
+type ConsumerUseCasesOption func(useCases *consumerUseCases) (err error)
+
+// WithConsumerLogger logs the handled messages with the logger instead of the default one.
+func WithConsumerLogger(log logger.Logger) ConsumerUseCasesOption {
+	return func(useCases *consumerUseCases) (err error) {
+		if log == nil {
+			err = logger.ErrNilLogger
+			return
+		}
+		useCases.logger = log
+		return
+	}
+}
+
+// NewConsumerUseCases returns a new consumerUseCases instance.
+func NewConsumerUseCases(ingestion IngestionUseCases, ingestionQueue, deadLetter queue.Queue, configuration *queue.QueueConfiguration, options ...ConsumerUseCasesOption) (useCases ConsumerUseCases, err error) {
+	if ingestion == nil {
+		err = voProcessing.ErrNilIngestionUseCases
+		return
+	}
+	if ingestionQueue == nil || deadLetter == nil {
+		err = voProcessing.ErrNilQueue
+		return
+	}
+	if configuration == nil {
+		err = queue.ErrNilConfiguration
+		return
+	}
+	consumer := &consumerUseCases{
+		ingestion:      ingestion,
+		ingestionQueue: ingestionQueue,
+		deadLetter:     deadLetter,
+		configuration:  configuration,
+		logger:         logger.NewDefaultLogger(),
+	}
+	for _, option := range options {
+		if err = option(consumer); err != nil {
+			return
+		}
+	}
+	useCases = consumer
+	return
+}
+
+// Handle implements the ConsumerUseCases interface method.
+func (uc *consumerUseCases) Handle(ctx context.Context, message queue.Message) (err error) {
+	log := logger.FromContext(ctx, uc.logger).With("message_id", message.ID, "attempt", message.ReceiveCount)
+	object, err := entity.ParseObjectReference(message.Body)
+	if err != nil {
+		// Retrying a malformed message never succeeds.
+		log.Warn("message is invalid, sending it to the dead-letter queue", "error", err)
+		_, err = uc.deadLetter.Send(message.Body)
+		return
+	}
+	lastAttempt := message.ReceiveCount >= uc.configuration.MaxReceives
+	log = log.With("key", object.Key)
+	record, err := uc.ingestion.Ingest(ctx, object, lastAttempt)
+	if errors.Is(err, voProcessing.ErrIngestionAttemptsExhausted) {
+		log.Error("file failed on every attempt, sending it to the dead-letter queue")
+		err = uc.sendFailed(object)
+		return
+	}
+	if err != nil {
+		log.Warn("file failed, it is retried", "error", err)
+		return
+	}
+	if record != nil {
+		log.Info("file finished", "status", record.Status, "lines", record.Lines, "invalid_lines", record.InvalidLines)
+	}
+	return
+}
+
+// sendFailed sends the reference to the file moved to FailedPrefix by its last attempt to
+// the dead-letter queue, since the original key no longer exists.
+func (uc *consumerUseCases) sendFailed(object entity.ObjectReference) (err error) {
+	object.Key = FailedPrefix + object.Key
+	body, err := object.Body()
+	if err != nil {
+		return
+	}
+	_, err = uc.deadLetter.Send(body)
+	return
+}
+
+// ConsumeOnce implements the ConsumerUseCases interface method.
+func (uc *consumerUseCases) ConsumeOnce(ctx context.Context) (handled int, err error) {
+	messages, err := uc.ingestionQueue.Receive(uc.configuration.BatchSize, uc.configuration.Visibility)
+	if err != nil {
+		return
+	}
+	for _, message := range messages {
+		// A failed message stays in the queue and is delivered again after its visibility.
+		if uc.Handle(ctx, message) != nil {
+			continue
+		}
+		if err = uc.ingestionQueue.Delete(message.ReceiptHandle); err != nil {
+			return
+		}
+		handled++
+	}
+	return
+}
+
+// Run consumes the ingestion queue until the context is done. It waits the configured
+// interval only when there is nothing to consume.
+func (uc *consumerUseCases) Run(ctx context.Context) {
+	for {
+		handled, err := uc.ConsumeOnce(ctx)
+		if err != nil {
+			uc.logger.Error("error consuming the ingestion queue", "error", err)
+		} else if handled > 0 {
+			uc.logger.Info("messages handled", "handled", handled)
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(uc.configuration.Interval):
+		}
+	}
+}
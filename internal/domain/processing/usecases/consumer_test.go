@@ -0,0 +1,191 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/usecases"
+	processingMock "github.com/braejan/go-transactions-summary/internal/domain/processing/usecases/mock"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	queueMock "github.com/braejan/go-transactions-summary/internal/valueobject/queue/mock"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+// getTestBody returns the body of a message referencing the key.
+func getTestBody(key string) []byte {
+	body, _ := entity.ObjectReference{Bucket: "bucket", Key: key}.Body()
+	return body
+}
+
+// getTestMessage returns a message referencing the key received the given times.
+func getTestMessage(key string, receiveCount int) queue.Message {
+	return queue.Message{
+		ID:            key,
+		Body:          getTestBody(key),
+		ReceiptHandle: "receipt-" + key,
+		ReceiveCount:  receiveCount,
+	}
+}
+
+// TestNewConsumerUseCasesValidations tests the NewConsumerUseCases function validations.
+func TestNewConsumerUseCasesValidations(t *testing.T) {
+	ingestion := processingMock.NewMockIngestionUseCases()
+	configuration := queue.NewDefaultQueueConfiguration()
+	// When calling NewConsumerUseCases with nil ingestion use cases
+	_, err := usecases.NewConsumerUseCases(nil, queue.NewMemoryQueue(), queue.NewMemoryQueue(), configuration)
+	// Then the error returned is ErrNilIngestionUseCases
+	assert.Equal(t, voProcessing.ErrNilIngestionUseCases, err)
+	// And a nil queue returns ErrNilQueue
+	_, err = usecases.NewConsumerUseCases(ingestion, queue.NewMemoryQueue(), nil, configuration)
+	assert.Equal(t, voProcessing.ErrNilQueue, err)
+	// And a nil configuration returns ErrNilConfiguration
+	_, err = usecases.NewConsumerUseCases(ingestion, queue.NewMemoryQueue(), queue.NewMemoryQueue(), nil)
+	assert.Equal(t, queue.ErrNilConfiguration, err)
+}
+
+// TestHandleIngestsTheFile tests the referenced file is ingested before its last attempt.
+func TestHandleIngestsTheFile(t *testing.T) {
+	// Given ingestion use cases ingesting the file
+	ingestion := processingMock.NewMockIngestionUseCases()
+	object := entity.ObjectReference{Bucket: "bucket", Key: "txns.csv"}
+	ingestion.On("Ingest", testifyMock.Anything, object, false).Return(&entity.ProcessingRecord{Status: entity.ProcessingStatusSucceeded}, nil)
+	consumer, _ := usecases.NewConsumerUseCases(ingestion, queue.NewMemoryQueue(), queue.NewMemoryQueue(), queue.NewDefaultQueueConfiguration())
+	// When handling the first delivery of the message
+	err := consumer.Handle(context.Background(), getTestMessage("txns.csv", 1))
+	// Then the message is handled
+	assert.Nil(t, err)
+	ingestion.AssertExpectations(t)
+}
+
+// TestHandleTransientFailure tests a failing message is left to be delivered again.
+func TestHandleTransientFailure(t *testing.T) {
+	// Given ingestion use cases failing to ingest the file
+	ingestion := processingMock.NewMockIngestionUseCases()
+	ingestion.On("Ingest", testifyMock.Anything, testifyMock.Anything, false).Return(nil, voPostgres.ErrOpeningDatabase)
+	deadLetter := queueMock.NewMockQueue()
+	consumer, _ := usecases.NewConsumerUseCases(ingestion, queue.NewMemoryQueue(), deadLetter, queue.NewDefaultQueueConfiguration())
+	// When handling the second delivery of the message
+	err := consumer.Handle(context.Background(), getTestMessage("txns.csv", 2))
+	// Then the error is returned
+	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	// And nothing is sent to the dead-letter queue
+	deadLetter.AssertNotCalled(t, "Send", testifyMock.Anything)
+}
+
+// TestHandleLastAttempt tests a message failing on its last delivery goes to the dead-letter queue.
+func TestHandleLastAttempt(t *testing.T) {
+	// Given ingestion use cases exhausting the attempts of the file
+	ingestion := processingMock.NewMockIngestionUseCases()
+	ingestion.On("Ingest", testifyMock.Anything, testifyMock.Anything, true).Return(nil, voProcessing.ErrIngestionAttemptsExhausted)
+	deadLetter := queue.NewMemoryQueue()
+	consumer, _ := usecases.NewConsumerUseCases(ingestion, queue.NewMemoryQueue(), deadLetter, queue.NewDefaultQueueConfiguration())
+	message := getTestMessage("txns.csv", 3)
+	// When handling the last delivery of the message
+	err := consumer.Handle(context.Background(), message)
+	// Then the message is handled
+	assert.Nil(t, err)
+	// And the reference to the failed file is sent to the dead-letter queue
+	messages, _ := deadLetter.Receive(10, time.Minute)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, getTestBody(usecases.FailedPrefix+"txns.csv"), messages[0].Body)
+}
+
+// TestHandleLogsRequestID tests the lines of a handled message carry the request ID of its context.
+func TestHandleLogsRequestID(t *testing.T) {
+	// Given consumer use cases logging to a buffer
+	ingestion := processingMock.NewMockIngestionUseCases()
+	ingestion.On("Ingest", testifyMock.Anything, testifyMock.Anything, false).Return(nil, voPostgres.ErrOpeningDatabase)
+	output := &bytes.Buffer{}
+	consumer, _ := usecases.NewConsumerUseCases(ingestion, queue.NewMemoryQueue(), queue.NewMemoryQueue(), queue.NewDefaultQueueConfiguration(),
+		usecases.WithConsumerLogger(logger.NewJSONLogger(output, logger.LevelInfo)))
+	// When handling a failing message within a request
+	ctx := logger.WithRequestID(context.Background(), "request-1")
+	_ = consumer.Handle(ctx, getTestMessage("txns.csv", 1))
+	// Then its line carries the request ID and the key
+	line := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(output.Bytes(), &line))
+	assert.Equal(t, "file failed, it is retried", line["msg"])
+	assert.Equal(t, "request-1", line["request_id"])
+	assert.Equal(t, "txns.csv", line["key"])
+}
+
+// TestHandleInvalidMessage tests a message without a file goes to the dead-letter queue at once.
+func TestHandleInvalidMessage(t *testing.T) {
+	// Given consumer use cases
+	ingestion := processingMock.NewMockIngestionUseCases()
+	deadLetter := queue.NewMemoryQueue()
+	consumer, _ := usecases.NewConsumerUseCases(ingestion, queue.NewMemoryQueue(), deadLetter, queue.NewDefaultQueueConfiguration())
+	// When handling a message that is not an object reference
+	err := consumer.Handle(context.Background(), queue.Message{ID: "1", Body: []byte("not json"), ReceiveCount: 1})
+	// Then the message is handled
+	assert.Nil(t, err)
+	ingestion.AssertNotCalled(t, "Ingest", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything)
+	// And it is sent to the dead-letter queue
+	messages, _ := deadLetter.Receive(10, time.Minute)
+	assert.Len(t, messages, 1)
+}
+
+// TestConsumeOnce tests the handled messages are deleted and the failing ones are kept.
+func TestConsumeOnce(t *testing.T) {
+	// Given an ingestion queue with two messages
+	ingestionQueue := queue.NewMemoryQueue()
+	ingestionQueue.Send(getTestBody("valid.csv"))
+	ingestionQueue.Send(getTestBody("failing.csv"))
+	// And ingestion use cases failing the second file
+	ingestion := processingMock.NewMockIngestionUseCases()
+	ingestion.On("Ingest", testifyMock.Anything, entity.ObjectReference{Bucket: "bucket", Key: "valid.csv"}, false).Return(nil, nil)
+	ingestion.On("Ingest", testifyMock.Anything, entity.ObjectReference{Bucket: "bucket", Key: "failing.csv"}, false).Return(nil, voPostgres.ErrOpeningDatabase)
+	configuration := queue.NewDefaultQueueConfiguration()
+	configuration.Visibility = 0
+	consumer, _ := usecases.NewConsumerUseCases(ingestion, ingestionQueue, queue.NewMemoryQueue(), configuration)
+	// When consuming the queue
+	handled, err := consumer.ConsumeOnce(context.Background())
+	// Then the valid message is handled
+	assert.Nil(t, err)
+	assert.Equal(t, 1, handled)
+	// And the failing message is delivered again
+	messages, _ := ingestionQueue.Receive(10, time.Minute)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, 2, messages[0].ReceiveCount)
+}
+
+// TestConsumeOnceErrReceiving tests the error returned when the queue cannot be read.
+func TestConsumeOnceErrReceiving(t *testing.T) {
+	// Given an ingestion queue failing to receive
+	ingestionQueue := queueMock.NewMockQueue()
+	ingestionQueue.On("Receive", 10, 5*time.Minute).Return(nil, queue.ErrReceivingMessages)
+	consumer, _ := usecases.NewConsumerUseCases(processingMock.NewMockIngestionUseCases(), ingestionQueue, queue.NewMemoryQueue(), queue.NewDefaultQueueConfiguration())
+	// When consuming the queue
+	handled, err := consumer.ConsumeOnce(context.Background())
+	// Then the error is returned
+	assert.Equal(t, 0, handled)
+	assert.Equal(t, queue.ErrReceivingMessages, err)
+}
+
+// TestConsumerRunStops tests Run returns when the context is done.
+func TestConsumerRunStops(t *testing.T) {
+	// Given consumer use cases over an empty queue
+	consumer, _ := usecases.NewConsumerUseCases(processingMock.NewMockIngestionUseCases(), queue.NewMemoryQueue(), queue.NewMemoryQueue(), queue.NewDefaultQueueConfiguration())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// When running the consumer with a done context
+	done := make(chan struct{})
+	go func() {
+		consumer.Run(ctx)
+		close(done)
+	}()
+	// Then it returns
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the consumer did not stop")
+	}
+}
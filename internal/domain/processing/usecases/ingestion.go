@@ -0,0 +1,253 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	ucFile "github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	fileUtil "github.com/braejan/go-transactions-summary/internal/domain/file/util"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+)
+
+// FailedPrefix is the prefix where the files that could not be ingested are moved.
+const FailedPrefix = "failed/"
+
+// Failure struct defines the error sidecar stored next to a failed file.
+type Failure struct {
+	// Record is the processing record of the file.
+	Record *entity.ProcessingRecord `json:"record"`
+	// Report lists the invalid lines of the file.
+	Report *fileEntity.ValidationReport `json:"report,omitempty"`
+}
+
+// permanentErrors are the failures that happen again on every attempt.
+var permanentErrors = []error{
+	storage.ErrObjectNotFound,
+	voFile.ErrFileHasInvalidLines,
+	voFile.ErrFileIsEmpty,
+	voFile.ErrFileCouldNotBeRead,
+	voFile.ErrFileLineIsInvalid,
+	quota.ErrFileTooLarge,
+	quota.ErrTooManyRows,
+}
+
+// ingestionUseCases struct implements the IngestionUseCases interface.
+type ingestionUseCases struct {
+	store              storage.ObjectStore
+	fileUseCases       ucFile.FileUseCases
+	processingUseCases ProcessingUseCases
+	// logger logs the result of the ingested files.
+	logger logger.Logger
+	// tracer starts the span of every ingested file.
+	tracer tracing.Tracer
+}
+
+// IngestionUseCasesOption configures an optional collaborator of the ingestion use cases.
+type IngestionUseCasesOption func(useCases *ingestionUseCases) (err error)
+
+// WithIngestionLogger logs the result of the ingested files with the logger instead of the
+// default one.
+func WithIngestionLogger(log logger.Logger) IngestionUseCasesOption {
+	return func(useCases *ingestionUseCases) (err error) {
+		if log == nil {
+			err = logger.ErrNilLogger
+			return
+		}
+		useCases.logger = log
+		return
+	}
+}
+
+// WithIngestionTracer traces every ingested file with the tracer, its span is the parent
+// of the spans of the processing and the repositories.
+func WithIngestionTracer(tracer tracing.Tracer) IngestionUseCasesOption {
+	return func(useCases *ingestionUseCases) (err error) {
+		if tracer == nil {
+			err = tracing.ErrNilTracer
+			return
+		}
+		useCases.tracer = tracer
+		return
+	}
+}
+
+// NewIngestionUseCases returns a new ingestionUseCases instance.
+func NewIngestionUseCases(store storage.ObjectStore, fileUseCases ucFile.FileUseCases, processingUseCases ProcessingUseCases, options ...IngestionUseCasesOption) (useCases IngestionUseCases, err error) {
+	if store == nil {
+		err = storage.ErrNilObjectStore
+		return
+	}
+	if fileUseCases == nil {
+		err = voFile.ErrNilFileUseCases
+		return
+	}
+	if processingUseCases == nil {
+		err = voProcessing.ErrNilProcessingUseCases
+		return
+	}
+	ingestion := &ingestionUseCases{
+		store:              store,
+		fileUseCases:       fileUseCases,
+		processingUseCases: processingUseCases,
+		logger:             logger.NewDefaultLogger(),
+		tracer:             tracing.NewNoopTracer(),
+	}
+	for _, option := range options {
+		if err = option(ingestion); err != nil {
+			return
+		}
+	}
+	useCases = ingestion
+	return
+}
+
+// Ingest implements the IngestionUseCases interface method.
+func (useCases *ingestionUseCases) Ingest(ctx context.Context, object entity.ObjectReference, lastAttempt bool) (record *entity.ProcessingRecord, err error) {
+	// The failed files and their sidecars are stored in the same bucket.
+	if strings.HasPrefix(object.Key, FailedPrefix) {
+		return
+	}
+	ctx, span := useCases.tracer.Start(ctx, "IngestionUseCases.Ingest", "object.bucket", object.Bucket, "object.key", object.Key)
+	defer span.EndWithError(&err)
+	if object.ETag == "" {
+		if info, errHead := useCases.store.Head(object.Key); errHead == nil {
+			object.ETag = info.ETag
+		}
+	}
+	record, err = useCases.processingUseCases.Begin(ctx, object.Bucket, object.Key, object.ETag)
+	log := logger.FromContext(ctx, useCases.logger).With("key", object.Key, "etag", object.ETag)
+	if errors.Is(err, voProcessing.ErrObjectAlreadyProcessed) {
+		log.Info("file already processed")
+		record = nil
+		err = nil
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to record the processing of %q: %w", object.Key, err)
+		return
+	}
+	// In the S3 event mode the object has no uploader, the record keeps the accepted one.
+	if object.Uploader != "" {
+		record.Uploader = object.Uploader
+	}
+	report, transactions, errProcess := useCases.process(ctx, log, record)
+	if errProcess == nil {
+		record.Succeed(transactions, time.Now())
+	} else {
+		record.Fail(errProcess, time.Now())
+		permanent := isPermanent(errProcess)
+		switch {
+		case errors.Is(errProcess, storage.ErrObjectNotFound):
+			// There is no file to move.
+		case permanent || lastAttempt:
+			err = useCases.moveToFailed(Failure{Record: record, Report: report})
+		}
+		if err == nil && !permanent {
+			err = errProcess
+			if lastAttempt {
+				err = voProcessing.ErrIngestionAttemptsExhausted
+			}
+		}
+	}
+	span.SetAttributes("processing.status", record.Status)
+	if errFinish := useCases.processingUseCases.Finish(ctx, record); errFinish != nil && err == nil {
+		err = fmt.Errorf("failed to record the result of %q: %w", object.Key, errFinish)
+	}
+	return
+}
+
+// process copies the file to a temporary file, checks its structure and processes it, and
+// returns the number of stored transactions. The line counts of the record are updated from
+// the structure report. The file is logged by its content hash, the ID of the upload that
+// stored it.
+func (useCases *ingestionUseCases) process(ctx context.Context, log logger.Logger, record *entity.ProcessingRecord) (report *fileEntity.ValidationReport, transactions int, err error) {
+	body, err := useCases.store.Get(record.Key)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+	// Create a temporary file to write the object contents to.
+	f, err := os.CreateTemp("", "ingest-*.csv")
+	if err != nil {
+		return
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if _, err = io.Copy(f, body); err != nil {
+		return
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	// Redelivered files carry the same content, so the hash keeps the emails idempotent.
+	hash, err := fileUtil.HashFile(f)
+	if err != nil {
+		return
+	}
+	txFile := fileEntity.NewTxFile(record.Key, f.Name(), hash, 0)
+	report, err = useCases.fileUseCases.CheckStructure(*txFile, f)
+	if err != nil {
+		return
+	}
+	record.Lines = report.Lines
+	record.InvalidLines = report.InvalidLines
+	if !report.IsValid() {
+		log.Warn("file has invalid lines", "file_id", hash, "lines", report.Lines, "invalid_lines", report.InvalidLines)
+		err = voFile.ErrFileHasInvalidLines
+		return
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	transactions, err = useCases.fileUseCases.ProcessFile(logger.WithFileID(ctx, hash), *txFile, f)
+	return
+}
+
+// moveToFailed copies the file of the record to FailedPrefix, stores the failure as a JSON
+// sidecar next to it and deletes the original file.
+func (useCases *ingestionUseCases) moveToFailed(failure Failure) (err error) {
+	key := failure.Record.Key
+	body, err := useCases.store.Get(key)
+	if err != nil {
+		return fmt.Errorf("failed to read %q: %w", key, err)
+	}
+	defer body.Close()
+	if err = useCases.store.Put(FailedPrefix+key, body); err != nil {
+		return fmt.Errorf("failed to move %q: %w", key, err)
+	}
+	sidecar, err := json.MarshalIndent(failure, "", "  ")
+	if err != nil {
+		return
+	}
+	if err = useCases.store.Put(FailedPrefix+key+".error.json", bytes.NewReader(sidecar)); err != nil {
+		return fmt.Errorf("failed to store the error of %q: %w", key, err)
+	}
+	if err = useCases.store.Delete(key); err != nil {
+		return fmt.Errorf("failed to delete %q: %w", key, err)
+	}
+	return
+}
+
+// isPermanent returns true when retrying the file fails again.
+func isPermanent(err error) bool {
+	for _, permanent := range permanentErrors {
+		if errors.Is(err, permanent) {
+			return true
+		}
+	}
+	return false
+}
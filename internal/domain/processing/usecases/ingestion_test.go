@@ -0,0 +1,287 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	fileEntity "github.com/braejan/go-transactions-summary/internal/domain/file/entity"
+	ucFile "github.com/braejan/go-transactions-summary/internal/domain/file/usecases"
+	fileMock "github.com/braejan/go-transactions-summary/internal/domain/file/usecases/mock"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	repoMock "github.com/braejan/go-transactions-summary/internal/domain/processing/repository/mock"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/usecases"
+	processingMock "github.com/braejan/go-transactions-summary/internal/domain/processing/usecases/mock"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+const (
+	validContent   = "Id,Date,Transaction\n0,7/5,+60.5\n1,7/28,-10.3\n"
+	invalidContent = "Id,Date,Transaction\n0,7/5,+60.5\n1,13/45,-10.3\n"
+	// storedTransactions is the number of transactions the file use cases store.
+	storedTransactions = 2
+)
+
+// getTestStore returns a local object store with the given objects.
+func getTestStore(t *testing.T, objects map[string]string) storage.ObjectStore {
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	for key, content := range objects {
+		assert.Nil(t, store.Put(key, strings.NewReader(content)))
+	}
+	return store
+}
+
+// getTestFileUseCases returns file use cases checking the real structure of the files and
+// storing the content of the processed ones by name. ProcessFile returns storedTransactions
+// and processErr.
+func getTestFileUseCases(processed map[string]string, processErr error) ucFile.FileUseCases {
+	structure := ucFile.NewStructureUseCases()
+	fileUseCases := fileMock.NewMockFileUseCases()
+	fileUseCases.On("CheckStructure", testifyMock.Anything, testifyMock.Anything).Return(
+		func(txFile fileEntity.TxFile, reader io.Reader) *fileEntity.ValidationReport {
+			report, _ := structure.CheckStructure(txFile, reader)
+			return report
+		}, nil)
+	fileUseCases.On("ProcessFile", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything).Return(storedTransactions, processErr).Run(func(args testifyMock.Arguments) {
+		raw, _ := io.ReadAll(args.Get(2).(*os.File))
+		processed[args.Get(1).(fileEntity.TxFile).Name] = string(raw)
+	})
+	return fileUseCases
+}
+
+// getTestIngestionUseCases returns ingestion use cases over a repository without records.
+func getTestIngestionUseCases(t *testing.T, store storage.ObjectStore, fileUseCases ucFile.FileUseCases) usecases.IngestionUseCases {
+	processingRepo := repoMock.NewMockProcessingRepository()
+	processingRepo.On("GetByObject", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything, testifyMock.Anything).Return(nil, voProcessing.ErrProcessingRecordNotFound)
+	processingRepo.On("Save", testifyMock.Anything, testifyMock.Anything).Return(nil)
+	processingUseCases, err := usecases.NewProcessingUseCases(processingRepo)
+	assert.Nil(t, err)
+	ingestionUseCases, err := usecases.NewIngestionUseCases(store, fileUseCases, processingUseCases)
+	assert.Nil(t, err)
+	return ingestionUseCases
+}
+
+func getStoredContent(t *testing.T, store storage.ObjectStore, key string) string {
+	body, err := store.Get(key)
+	assert.Nil(t, err)
+	defer body.Close()
+	content, _ := io.ReadAll(body)
+	return string(content)
+}
+
+// TestNewIngestionUseCasesValidations tests the NewIngestionUseCases function validations.
+func TestNewIngestionUseCasesValidations(t *testing.T) {
+	store := getTestStore(t, nil)
+	fileUseCases := fileMock.NewMockFileUseCases()
+	processingUseCases := processingMock.NewMockProcessingUseCases()
+	// When calling NewIngestionUseCases with a nil store
+	_, err := usecases.NewIngestionUseCases(nil, fileUseCases, processingUseCases)
+	// Then the error returned is ErrNilObjectStore
+	assert.Equal(t, storage.ErrNilObjectStore, err)
+	// And nil file use cases return ErrNilFileUseCases
+	_, err = usecases.NewIngestionUseCases(store, nil, processingUseCases)
+	assert.Equal(t, voFile.ErrNilFileUseCases, err)
+	// And nil processing use cases return ErrNilProcessingUseCases
+	_, err = usecases.NewIngestionUseCases(store, fileUseCases, nil)
+	assert.Equal(t, voProcessing.ErrNilProcessingUseCases, err)
+	// And a nil tracer returns ErrNilTracer
+	_, err = usecases.NewIngestionUseCases(store, fileUseCases, processingUseCases, usecases.WithIngestionTracer(nil))
+	assert.Equal(t, tracing.ErrNilTracer, err)
+}
+
+// TestIngestValidFile tests a valid file is processed and recorded.
+func TestIngestValidFile(t *testing.T) {
+	// Given a local object store with an uploaded file
+	store := getTestStore(t, map[string]string{"txns.csv": validContent})
+	// And a temporary directory for the downloaded files
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+	processed := map[string]string{}
+	ingestionUseCases := getTestIngestionUseCases(t, store, getTestFileUseCases(processed, nil))
+	// When ingesting the file uploaded by a client
+	record, err := ingestionUseCases.Ingest(context.Background(),
+		entity.ObjectReference{Bucket: "bucket", Key: "txns.csv", Uploader: "0123456789abcdef"}, false)
+	// Then the whole file is processed
+	assert.Nil(t, err)
+	assert.Equal(t, validContent, processed["txns.csv"])
+	// And the processing record has the counts of the file
+	assert.Equal(t, "bucket", record.Bucket)
+	assert.Equal(t, entity.ProcessingStatusSucceeded, record.Status)
+	assert.Equal(t, 2, record.Lines)
+	assert.Equal(t, storedTransactions, record.Transactions)
+	assert.NotEmpty(t, record.ETag)
+	assert.False(t, record.FinishedAt.IsZero())
+	// And the processing record has the uploader of the file
+	assert.Equal(t, "0123456789abcdef", record.Uploader)
+	// And the temporary file is removed
+	entries, _ := os.ReadDir(tmpDir)
+	assert.Empty(t, entries)
+}
+
+// TestIngestInvalidFile tests an invalid file fails at once and is moved with an error sidecar.
+func TestIngestInvalidFile(t *testing.T) {
+	// Given a local object store with an invalid file
+	store := getTestStore(t, map[string]string{"invalid.csv": invalidContent})
+	processed := map[string]string{}
+	ingestionUseCases := getTestIngestionUseCases(t, store, getTestFileUseCases(processed, nil))
+	// When ingesting the file before its last attempt
+	record, err := ingestionUseCases.Ingest(context.Background(), entity.ObjectReference{Bucket: "bucket", Key: "invalid.csv"}, false)
+	// Then the failure is not retried
+	assert.Nil(t, err)
+	assert.Empty(t, processed)
+	assert.Equal(t, entity.ProcessingStatusFailed, record.Status)
+	assert.Equal(t, voFile.ErrFileHasInvalidLines.Error(), record.Error)
+	assert.Equal(t, 1, record.InvalidLines)
+	// And the file is moved to the failed prefix
+	_, err = store.Head("invalid.csv")
+	assert.Equal(t, storage.ErrObjectNotFound, err)
+	assert.Equal(t, invalidContent, getStoredContent(t, store, usecases.FailedPrefix+"invalid.csv"))
+	// And the sidecar has the error and the invalid lines
+	sidecar := usecases.Failure{}
+	assert.Nil(t, json.Unmarshal([]byte(getStoredContent(t, store, usecases.FailedPrefix+"invalid.csv.error.json")), &sidecar))
+	assert.Equal(t, "invalid.csv", sidecar.Record.Key)
+	assert.Equal(t, voFile.ErrFileHasInvalidLines.Error(), sidecar.Record.Error)
+	assert.Equal(t, 3, sidecar.Report.Problems[0].Line)
+}
+
+// TestIngestMissingFile tests a missing file fails at once without moving anything.
+func TestIngestMissingFile(t *testing.T) {
+	// Given an empty local object store
+	store := getTestStore(t, nil)
+	ingestionUseCases := getTestIngestionUseCases(t, store, getTestFileUseCases(map[string]string{}, nil))
+	// When ingesting a missing file
+	record, err := ingestionUseCases.Ingest(context.Background(), entity.ObjectReference{Bucket: "bucket", Key: "missing.csv"}, false)
+	// Then the record failed and the failure is not retried
+	assert.Nil(t, err)
+	assert.Equal(t, entity.ProcessingStatusFailed, record.Status)
+	assert.Equal(t, storage.ErrObjectNotFound.Error(), record.Error)
+	objects, _ := store.List("")
+	assert.Empty(t, objects)
+}
+
+// TestIngestTransientFailure tests a transient failure is returned to be retried.
+func TestIngestTransientFailure(t *testing.T) {
+	// Given a local object store with an uploaded file
+	store := getTestStore(t, map[string]string{"txns.csv": validContent})
+	// And file use cases failing to process it
+	ingestionUseCases := getTestIngestionUseCases(t, store, getTestFileUseCases(map[string]string{}, voPostgres.ErrOpeningDatabase))
+	// When ingesting the file before its last attempt
+	record, err := ingestionUseCases.Ingest(context.Background(), entity.ObjectReference{Bucket: "bucket", Key: "txns.csv"}, false)
+	// Then the error is returned
+	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.Equal(t, entity.ProcessingStatusFailed, record.Status)
+	// And the file stays in place for the next attempt
+	assert.Equal(t, validContent, getStoredContent(t, store, "txns.csv"))
+}
+
+// TestIngestTransientFailureOnLastAttempt tests the file is moved when its last attempt fails.
+func TestIngestTransientFailureOnLastAttempt(t *testing.T) {
+	// Given a local object store with an uploaded file
+	store := getTestStore(t, map[string]string{"txns.csv": validContent})
+	// And file use cases failing to process it
+	ingestionUseCases := getTestIngestionUseCases(t, store, getTestFileUseCases(map[string]string{}, voPostgres.ErrOpeningDatabase))
+	// When ingesting the file on its last attempt
+	record, err := ingestionUseCases.Ingest(context.Background(), entity.ObjectReference{Bucket: "bucket", Key: "txns.csv"}, true)
+	// Then the error returned is ErrIngestionAttemptsExhausted
+	assert.Equal(t, voProcessing.ErrIngestionAttemptsExhausted, err)
+	assert.Equal(t, voPostgres.ErrOpeningDatabase.Error(), record.Error)
+	// And the file is moved to the failed prefix
+	_, err = store.Head("txns.csv")
+	assert.Equal(t, storage.ErrObjectNotFound, err)
+	assert.Equal(t, validContent, getStoredContent(t, store, usecases.FailedPrefix+"txns.csv"))
+}
+
+// TestIngestSkipsFailedPrefix tests the moved files are not ingested again.
+func TestIngestSkipsFailedPrefix(t *testing.T) {
+	// Given a local object store with a moved file
+	store := getTestStore(t, map[string]string{"failed/invalid.csv": invalidContent})
+	processingUseCases := processingMock.NewMockProcessingUseCases()
+	ingestionUseCases, _ := usecases.NewIngestionUseCases(store, getTestFileUseCases(map[string]string{}, nil), processingUseCases)
+	// When ingesting the moved file
+	record, err := ingestionUseCases.Ingest(context.Background(), entity.ObjectReference{Bucket: "bucket", Key: "failed/invalid.csv"}, false)
+	// Then nothing is ingested
+	assert.Nil(t, err)
+	assert.Nil(t, record)
+	processingUseCases.AssertNotCalled(t, "Begin", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything, testifyMock.Anything)
+}
+
+// TestIngestSkipsProcessedObjects tests an object processed successfully is not ingested again.
+func TestIngestSkipsProcessedObjects(t *testing.T) {
+	// Given a local object store with an uploaded file
+	store := getTestStore(t, map[string]string{"txns.csv": validContent})
+	// And processing use cases where the file was already processed
+	processingUseCases := processingMock.NewMockProcessingUseCases()
+	processingUseCases.On("Begin", testifyMock.Anything, "bucket", "txns.csv", "etag").Return(nil, voProcessing.ErrObjectAlreadyProcessed)
+	processed := map[string]string{}
+	ingestionUseCases, _ := usecases.NewIngestionUseCases(store, getTestFileUseCases(processed, nil), processingUseCases)
+	// When ingesting the file
+	record, err := ingestionUseCases.Ingest(context.Background(), entity.ObjectReference{Bucket: "bucket", Key: "txns.csv", ETag: "etag"}, false)
+	// Then the file is not processed
+	assert.Nil(t, err)
+	assert.Nil(t, record)
+	assert.Empty(t, processed)
+}
+
+// TestIngestErrRecording tests the error returned when the processing cannot be recorded.
+func TestIngestErrRecording(t *testing.T) {
+	// Given a local object store with an uploaded file
+	store := getTestStore(t, map[string]string{"txns.csv": validContent})
+	// And processing use cases failing to record its result
+	processingUseCases := processingMock.NewMockProcessingUseCases()
+	record, _ := entity.NewProcessingRecord("bucket", "txns.csv", "etag", time.Now())
+	processingUseCases.On("Begin", testifyMock.Anything, "bucket", "txns.csv", "etag").Return(record, nil)
+	processingUseCases.On("Finish", testifyMock.Anything, record).Return(voPostgres.ErrOpeningDatabase)
+	ingestionUseCases, _ := usecases.NewIngestionUseCases(store, getTestFileUseCases(map[string]string{}, nil), processingUseCases)
+	// When ingesting the file
+	_, err := ingestionUseCases.Ingest(context.Background(), entity.ObjectReference{Bucket: "bucket", Key: "txns.csv", ETag: "etag"}, false)
+	// Then the database error is returned wrapped so the file is retried
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
+	assert.ErrorContains(t, err, `failed to record the result of "txns.csv"`)
+}
+
+// TestIngestTraced tests the file is processed within the span of its ingestion.
+func TestIngestTraced(t *testing.T) {
+	// Given a local object store with an uploaded file
+	store := getTestStore(t, map[string]string{"txns.csv": validContent})
+	// And file use cases keeping the context of the processed file
+	var processedCtx context.Context
+	fileUseCases := fileMock.NewMockFileUseCases()
+	fileUseCases.On("CheckStructure", testifyMock.Anything, testifyMock.Anything).Return(
+		fileEntity.NewValidationReport("txns.csv"), nil)
+	fileUseCases.On("ProcessFile", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything).Return(0, nil).Run(func(args testifyMock.Arguments) {
+		processedCtx = args.Get(0).(context.Context)
+	})
+	// And ingestion use cases with a tracer
+	processingRepo := repoMock.NewMockProcessingRepository()
+	processingRepo.On("GetByObject", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything, testifyMock.Anything).Return(nil, voProcessing.ErrProcessingRecordNotFound)
+	processingRepo.On("Save", testifyMock.Anything, testifyMock.Anything).Return(nil)
+	processingUseCases, err := usecases.NewProcessingUseCases(processingRepo)
+	assert.Nil(t, err)
+	tracer, recorder := tracingtest.NewTracer()
+	ingestionUseCases, err := usecases.NewIngestionUseCases(store, fileUseCases, processingUseCases, usecases.WithIngestionTracer(tracer))
+	assert.Nil(t, err)
+	// When ingesting the file
+	_, err = ingestionUseCases.Ingest(context.Background(), entity.ObjectReference{Bucket: "bucket", Key: "txns.csv"}, false)
+	assert.Nil(t, err)
+	// Then the ingestion is traced with the key and the status of the file
+	span, found := recorder.Span("IngestionUseCases.Ingest")
+	assert.True(t, found)
+	assert.Equal(t, "txns.csv", span.Attributes["object.key"])
+	assert.Equal(t, entity.ProcessingStatusSucceeded, span.Attributes["processing.status"])
+	// And the file is processed within its span, with the file ID
+	assert.Equal(t, span.SpanContext, tracing.SpanFromContext(processedCtx).SpanContext())
+	assert.NotEmpty(t, logger.FileID(processedCtx))
+}
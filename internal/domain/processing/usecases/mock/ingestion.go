@@ -0,0 +1,75 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockIngestionUseCases is a mock of IngestionUseCases interface.
+type mockIngestionUseCases struct {
+	mock.Mock
+}
+
+// NewMockIngestionUseCases returns a new mock instance.
+func NewMockIngestionUseCases() *mockIngestionUseCases {
+	return &mockIngestionUseCases{}
+}
+
+// Ingest mocks base method.
+func (m *mockIngestionUseCases) Ingest(ctx context.Context, object entity.ObjectReference, lastAttempt bool) (*entity.ProcessingRecord, error) {
+	ret := m.Called(ctx, object, lastAttempt)
+
+	var r0 *entity.ProcessingRecord
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ObjectReference, bool) *entity.ProcessingRecord); ok {
+		r0 = rf(ctx, object, lastAttempt)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*entity.ProcessingRecord)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ObjectReference, bool) error); ok {
+		r1 = rf(ctx, object, lastAttempt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockConsumerUseCases is a mock of ConsumerUseCases interface.
+type mockConsumerUseCases struct {
+	mock.Mock
+}
+
+// NewMockConsumerUseCases returns a new mock instance.
+func NewMockConsumerUseCases() *mockConsumerUseCases {
+	return &mockConsumerUseCases{}
+}
+
+// Handle mocks base method.
+func (m *mockConsumerUseCases) Handle(ctx context.Context, message queue.Message) error {
+	ret := m.Called(ctx, message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, queue.Message) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConsumeOnce mocks base method.
+func (m *mockConsumerUseCases) ConsumeOnce(ctx context.Context) (int, error) {
+	ret := m.Called(ctx)
+	return ret.Int(0), ret.Error(1)
+}
+
+// Run mocks base method.
+func (m *mockConsumerUseCases) Run(ctx context.Context) {
+	m.Called(ctx)
+}
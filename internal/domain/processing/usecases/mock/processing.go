@@ -0,0 +1,95 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockProcessingUseCases is a mock of ProcessingUseCases interface.
+type mockProcessingUseCases struct {
+	mock.Mock
+}
+
+// NewMockProcessingUseCases returns a new mock instance.
+func NewMockProcessingUseCases() *mockProcessingUseCases {
+	return &mockProcessingUseCases{}
+}
+
+// Begin mocks base method.
+func (m *mockProcessingUseCases) Begin(ctx context.Context, bucket, key, etag string) (*entity.ProcessingRecord, error) {
+	ret := m.Called(ctx, bucket, key, etag)
+
+	var r0 *entity.ProcessingRecord
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *entity.ProcessingRecord); ok {
+		r0 = rf(ctx, bucket, key, etag)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*entity.ProcessingRecord)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, bucket, key, etag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Start mocks base method.
+func (m *mockProcessingUseCases) Start(ctx context.Context, bucket, key, etag, uploader string, maxFilesPerDay int) (*entity.ProcessingRecord, error) {
+	ret := m.Called(ctx, bucket, key, etag, uploader, maxFilesPerDay)
+
+	var r0 *entity.ProcessingRecord
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, int) *entity.ProcessingRecord); ok {
+		r0 = rf(ctx, bucket, key, etag, uploader, maxFilesPerDay)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*entity.ProcessingRecord)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, int) error); ok {
+		r1 = rf(ctx, bucket, key, etag, uploader, maxFilesPerDay)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Accept mocks base method.
+func (m *mockProcessingUseCases) Accept(ctx context.Context, bucket, key, etag, uploader string, maxFilesPerDay int) (*entity.ProcessingRecord, error) {
+	ret := m.Called(ctx, bucket, key, etag, uploader, maxFilesPerDay)
+
+	var r0 *entity.ProcessingRecord
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, int) *entity.ProcessingRecord); ok {
+		r0 = rf(ctx, bucket, key, etag, uploader, maxFilesPerDay)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*entity.ProcessingRecord)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, int) error); ok {
+		r1 = rf(ctx, bucket, key, etag, uploader, maxFilesPerDay)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Finish mocks base method.
+func (m *mockProcessingUseCases) Finish(ctx context.Context, record *entity.ProcessingRecord) error {
+	ret := m.Called(ctx, record)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ProcessingRecord) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+)
+
+// processingUseCases struct implements the ProcessingUseCases interface.
+type processingUseCases struct {
+	processingRepo repository.ProcessingRepository
+}
+
+// NewProcessingUseCases returns a new processingUseCases instance.
+func NewProcessingUseCases(processingRepo repository.ProcessingRepository) (useCases ProcessingUseCases, err error) {
+	if processingRepo == nil {
+		err = voProcessing.ErrNilProcessingRepository
+		return
+	}
+	useCases = &processingUseCases{
+		processingRepo: processingRepo,
+	}
+	return
+}
+
+// Begin implements the ProcessingUseCases interface method.
+func (useCases *processingUseCases) Begin(ctx context.Context, bucket, key, etag string) (record *entity.ProcessingRecord, err error) {
+	previous, err := useCases.processingRepo.GetByObject(ctx, bucket, key, etag)
+	if err == nil && previous.Status == entity.ProcessingStatusSucceeded {
+		err = voProcessing.ErrObjectAlreadyProcessed
+		return
+	}
+	if err != nil && !errors.Is(err, voProcessing.ErrProcessingRecordNotFound) {
+		return
+	}
+	record, err = entity.NewProcessingRecord(bucket, key, etag, time.Now())
+	if err != nil {
+		return
+	}
+	// The record accepted with the upload keeps its uploader.
+	if previous != nil {
+		record.Uploader = previous.Uploader
+	}
+	err = useCases.processingRepo.Save(ctx, record)
+	if err != nil {
+		record = nil
+	}
+	return
+}
+
+// Start implements the ProcessingUseCases interface method.
+func (useCases *processingUseCases) Start(ctx context.Context, bucket, key, etag, uploader string, maxFilesPerDay int) (record *entity.ProcessingRecord, err error) {
+	record, err = useCases.reserve(ctx, bucket, key, etag, uploader, maxFilesPerDay)
+	return
+}
+
+// Accept implements the ProcessingUseCases interface method.
+func (useCases *processingUseCases) Accept(ctx context.Context, bucket, key, etag, uploader string, maxFilesPerDay int) (record *entity.ProcessingRecord, err error) {
+	previous, err := useCases.processingRepo.GetByObject(ctx, bucket, key, etag)
+	if err == nil && previous.Status == entity.ProcessingStatusSucceeded {
+		err = voProcessing.ErrObjectAlreadyProcessed
+		return
+	}
+	if err != nil && !errors.Is(err, voProcessing.ErrProcessingRecordNotFound) {
+		return
+	}
+	record, err = useCases.reserve(ctx, bucket, key, etag, uploader, maxFilesPerDay)
+	return
+}
+
+// reserve saves a new processing record for the object uploaded by the uploader, within
+// its max files per day since the start of the UTC day.
+func (useCases *processingUseCases) reserve(ctx context.Context, bucket, key, etag, uploader string, maxFilesPerDay int) (record *entity.ProcessingRecord, err error) {
+	now := time.Now()
+	record, err = entity.NewProcessingRecord(bucket, key, etag, now)
+	if err != nil {
+		return
+	}
+	record.Uploader = uploader
+	if uploader == "" {
+		maxFilesPerDay = 0
+	}
+	err = useCases.processingRepo.Reserve(ctx, record, maxFilesPerDay, quota.DayStart(now))
+	if err != nil {
+		record = nil
+	}
+	return
+}
+
+// Finish implements the ProcessingUseCases interface method.
+func (useCases *processingUseCases) Finish(ctx context.Context, record *entity.ProcessingRecord) (err error) {
+	if record == nil {
+		err = voProcessing.ErrNilProcessingRecord
+		return
+	}
+	if record.FinishedAt.IsZero() {
+		record.FinishedAt = time.Now()
+	}
+	err = useCases.processingRepo.Save(ctx, record)
+	return
+}
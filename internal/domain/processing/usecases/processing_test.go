@@ -0,0 +1,193 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/repository/mock"
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/usecases"
+	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+// TestNewProcessingUseCasesWithNilRepository tests the NewProcessingUseCases function with a nil repository.
+func TestNewProcessingUseCasesWithNilRepository(t *testing.T) {
+	// When calling NewProcessingUseCases with a nil repository
+	useCases, err := usecases.NewProcessingUseCases(nil)
+	// Then the error returned is ErrNilProcessingRepository
+	assert.Nil(t, useCases)
+	assert.Equal(t, voProcessing.ErrNilProcessingRepository, err)
+}
+
+// TestBeginNewObject tests a processing record is saved for a new object.
+func TestBeginNewObject(t *testing.T) {
+	// Given a repository without a record for the object
+	processingRepo := mock.NewMockProcessingRepository()
+	processingRepo.On("GetByObject", testifyMock.Anything, "bucket", "txns.csv", "etag").Return(nil, voProcessing.ErrProcessingRecordNotFound)
+	processingRepo.On("Save", testifyMock.Anything, testifyMock.Anything).Return(nil)
+	useCases, _ := usecases.NewProcessingUseCases(processingRepo)
+	// When beginning the processing of the object
+	record, err := useCases.Begin(context.Background(), "bucket", "txns.csv", "etag")
+	// Then a record in the processing status is saved
+	assert.Nil(t, err)
+	assert.Equal(t, entity.ProcessingStatusProcessing, record.Status)
+	assert.False(t, record.StartedAt.IsZero())
+	processingRepo.AssertCalled(t, "Save", testifyMock.Anything, record)
+}
+
+// TestBeginFailedObject tests an object that failed before is processed again.
+func TestBeginFailedObject(t *testing.T) {
+	// Given a repository with a failed record for the object
+	processingRepo := mock.NewMockProcessingRepository()
+	processingRepo.On("GetByObject", testifyMock.Anything, "bucket", "txns.csv", "etag").Return(&entity.ProcessingRecord{Status: entity.ProcessingStatusFailed}, nil)
+	processingRepo.On("Save", testifyMock.Anything, testifyMock.Anything).Return(nil)
+	useCases, _ := usecases.NewProcessingUseCases(processingRepo)
+	// When beginning the processing of the object
+	record, err := useCases.Begin(context.Background(), "bucket", "txns.csv", "etag")
+	// Then the record is processing again
+	assert.Nil(t, err)
+	assert.Equal(t, entity.ProcessingStatusProcessing, record.Status)
+}
+
+// TestBeginSucceededObject tests an object processed successfully is not processed again.
+func TestBeginSucceededObject(t *testing.T) {
+	// Given a repository with a succeeded record for the object
+	processingRepo := mock.NewMockProcessingRepository()
+	processingRepo.On("GetByObject", testifyMock.Anything, "bucket", "txns.csv", "etag").Return(&entity.ProcessingRecord{Status: entity.ProcessingStatusSucceeded}, nil)
+	useCases, _ := usecases.NewProcessingUseCases(processingRepo)
+	// When beginning the processing of the object
+	record, err := useCases.Begin(context.Background(), "bucket", "txns.csv", "etag")
+	// Then the error returned is ErrObjectAlreadyProcessed
+	assert.Nil(t, record)
+	assert.Equal(t, voProcessing.ErrObjectAlreadyProcessed, err)
+	processingRepo.AssertNotCalled(t, "Save", testifyMock.Anything, testifyMock.Anything)
+}
+
+// TestBeginErrGettingRecord tests the error returned when the record cannot be read.
+func TestBeginErrGettingRecord(t *testing.T) {
+	// Given a repository failing to read the record
+	processingRepo := mock.NewMockProcessingRepository()
+	processingRepo.On("GetByObject", testifyMock.Anything, "bucket", "txns.csv", "etag").Return(nil, voPostgres.ErrOpeningDatabase)
+	useCases, _ := usecases.NewProcessingUseCases(processingRepo)
+	// When beginning the processing of the object
+	record, err := useCases.Begin(context.Background(), "bucket", "txns.csv", "etag")
+	// Then the error is returned
+	assert.Nil(t, record)
+	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+}
+
+// TestStartReservesDailyQuota tests the record of an upload is reserved within the max files per day of its uploader.
+func TestStartReservesDailyQuota(t *testing.T) {
+	// Given a repository reserving records
+	processingRepo := mock.NewMockProcessingRepository()
+	processingRepo.On("Reserve", testifyMock.Anything, testifyMock.Anything, 3, quota.DayStart(time.Now())).Return(nil)
+	useCases, _ := usecases.NewProcessingUseCases(processingRepo)
+	// When starting the processing of an upload
+	record, err := useCases.Start(context.Background(), "bucket", "txns.csv", "etag", "client", 3)
+	// Then a record in the processing status is reserved with the uploader
+	assert.Nil(t, err)
+	assert.Equal(t, entity.ProcessingStatusProcessing, record.Status)
+	assert.Equal(t, "client", record.Uploader)
+	processingRepo.AssertCalled(t, "Reserve", testifyMock.Anything, record, 3, quota.DayStart(time.Now()))
+	// And the previous record of the object is not read, so a processed upload is processed again
+	processingRepo.AssertNotCalled(t, "GetByObject", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything, testifyMock.Anything)
+}
+
+// TestStartWithoutUploader tests the record of an upload without uploader has no quota.
+func TestStartWithoutUploader(t *testing.T) {
+	// Given a repository reserving records
+	processingRepo := mock.NewMockProcessingRepository()
+	processingRepo.On("Reserve", testifyMock.Anything, testifyMock.Anything, 0, testifyMock.Anything).Return(nil)
+	useCases, _ := usecases.NewProcessingUseCases(processingRepo)
+	// When starting the processing of an upload without uploader
+	record, err := useCases.Start(context.Background(), "bucket", "txns.csv", "etag", "", 3)
+	// Then the record is reserved without limit
+	assert.Nil(t, err)
+	assert.NotNil(t, record)
+}
+
+// TestStartDailyQuotaExceeded tests the error returned when the uploader reached its max files per day.
+func TestStartDailyQuotaExceeded(t *testing.T) {
+	// Given a repository without files left for the uploader
+	processingRepo := mock.NewMockProcessingRepository()
+	processingRepo.On("Reserve", testifyMock.Anything, testifyMock.Anything, 3, testifyMock.Anything).Return(quota.ErrDailyQuotaExceeded)
+	useCases, _ := usecases.NewProcessingUseCases(processingRepo)
+	// When starting the processing of an upload
+	record, err := useCases.Start(context.Background(), "bucket", "txns.csv", "etag", "client", 3)
+	// Then the error returned is ErrDailyQuotaExceeded
+	assert.Nil(t, record)
+	assert.Equal(t, quota.ErrDailyQuotaExceeded, err)
+}
+
+// TestAcceptNewObject tests the record of a new upload is reserved.
+func TestAcceptNewObject(t *testing.T) {
+	// Given a repository without a record for the object
+	processingRepo := mock.NewMockProcessingRepository()
+	processingRepo.On("GetByObject", testifyMock.Anything, "bucket", "txns.csv", "etag").Return(nil, voProcessing.ErrProcessingRecordNotFound)
+	processingRepo.On("Reserve", testifyMock.Anything, testifyMock.Anything, 3, testifyMock.Anything).Return(nil)
+	useCases, _ := usecases.NewProcessingUseCases(processingRepo)
+	// When accepting the upload
+	record, err := useCases.Accept(context.Background(), "bucket", "txns.csv", "etag", "client", 3)
+	// Then the record is reserved with the uploader
+	assert.Nil(t, err)
+	assert.Equal(t, "client", record.Uploader)
+}
+
+// TestAcceptSucceededObject tests an upload already processed successfully is not reserved.
+func TestAcceptSucceededObject(t *testing.T) {
+	// Given a repository with a succeeded record for the object
+	processingRepo := mock.NewMockProcessingRepository()
+	processingRepo.On("GetByObject", testifyMock.Anything, "bucket", "txns.csv", "etag").Return(&entity.ProcessingRecord{Status: entity.ProcessingStatusSucceeded}, nil)
+	useCases, _ := usecases.NewProcessingUseCases(processingRepo)
+	// When accepting the upload
+	record, err := useCases.Accept(context.Background(), "bucket", "txns.csv", "etag", "client", 3)
+	// Then the error returned is ErrObjectAlreadyProcessed
+	assert.Nil(t, record)
+	assert.Equal(t, voProcessing.ErrObjectAlreadyProcessed, err)
+	processingRepo.AssertNotCalled(t, "Reserve", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything, testifyMock.Anything)
+}
+
+// TestBeginAcceptedObject tests the record of an accepted upload keeps its uploader.
+func TestBeginAcceptedObject(t *testing.T) {
+	// Given a repository with the record of an accepted upload
+	processingRepo := mock.NewMockProcessingRepository()
+	processingRepo.On("GetByObject", testifyMock.Anything, "bucket", "txns.csv", "etag").Return(&entity.ProcessingRecord{Status: entity.ProcessingStatusProcessing, Uploader: "client"}, nil)
+	processingRepo.On("Save", testifyMock.Anything, testifyMock.Anything).Return(nil)
+	useCases, _ := usecases.NewProcessingUseCases(processingRepo)
+	// When beginning the processing of the object
+	record, err := useCases.Begin(context.Background(), "bucket", "txns.csv", "etag")
+	// Then the record keeps the uploader
+	assert.Nil(t, err)
+	assert.Equal(t, "client", record.Uploader)
+}
+
+// TestFinishWithNilRecord tests the Finish method with a nil record.
+func TestFinishWithNilRecord(t *testing.T) {
+	// Given a valid processing use cases
+	useCases, _ := usecases.NewProcessingUseCases(mock.NewMockProcessingRepository())
+	// When finishing a nil record
+	err := useCases.Finish(context.Background(), nil)
+	// Then the error returned is ErrNilProcessingRecord
+	assert.Equal(t, voProcessing.ErrNilProcessingRecord, err)
+}
+
+// TestFinishSavesRecord tests the final status of the record is saved.
+func TestFinishSavesRecord(t *testing.T) {
+	// Given a repository saving records
+	processingRepo := mock.NewMockProcessingRepository()
+	processingRepo.On("Save", testifyMock.Anything, testifyMock.Anything).Return(nil)
+	useCases, _ := usecases.NewProcessingUseCases(processingRepo)
+	// And a record without a finish time
+	record := &entity.ProcessingRecord{Key: "txns.csv", Status: entity.ProcessingStatusSucceeded}
+	// When finishing the record
+	err := useCases.Finish(context.Background(), record)
+	// Then the record is saved with the finish time
+	assert.Nil(t, err)
+	assert.False(t, record.FinishedAt.IsZero())
+	processingRepo.AssertCalled(t, "Save", testifyMock.Anything, record)
+}
@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/processing/entity"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+)
+
+// ProcessingUseCases interface defines the use cases tracking the processing of stored objects.
+type ProcessingUseCases interface {
+	// Begin saves a new processing record for the object, with the uploader of its previous
+	// record. It returns ErrObjectAlreadyProcessed when the same object was already processed
+	// successfully.
+	Begin(ctx context.Context, bucket, key, etag string) (record *entity.ProcessingRecord, err error)
+	// Start saves a new processing record for the object uploaded by the uploader, within its
+	// max files per day, or returns quota.ErrDailyQuotaExceeded. Unlike Begin, an object
+	// already processed successfully is processed again. Without uploader or max files per
+	// day there is no quota.
+	Start(ctx context.Context, bucket, key, etag, uploader string, maxFilesPerDay int) (record *entity.ProcessingRecord, err error)
+	// Accept saves the record of an object uploaded by the uploader, to be processed later by
+	// Begin, within its max files per day like Start. It returns ErrObjectAlreadyProcessed
+	// when the same object was already processed successfully.
+	Accept(ctx context.Context, bucket, key, etag, uploader string, maxFilesPerDay int) (record *entity.ProcessingRecord, err error)
+	// Finish saves the final status of the record.
+	Finish(ctx context.Context, record *entity.ProcessingRecord) (err error)
+}
+
+// IngestionUseCases interface defines the use cases ingesting the stored files.
+type IngestionUseCases interface {
+	// Ingest checks and processes a stored file and records the result. Invalid and missing
+	// files fail at once and the other failures are returned to be retried. On the last
+	// attempt they return ErrIngestionAttemptsExhausted instead. The failed files are moved
+	// to FailedPrefix with an error sidecar. A file already ingested or stored under
+	// FailedPrefix returns a nil record.
+	Ingest(ctx context.Context, object entity.ObjectReference, lastAttempt bool) (record *entity.ProcessingRecord, err error)
+}
+
+// ConsumerUseCases interface defines the use cases ingesting the files referenced by the
+// messages of the ingestion queue.
+type ConsumerUseCases interface {
+	// Handle ingests the file of a message. An error means the message must be delivered
+	// again. Messages failing on their last delivery are sent to the dead-letter queue with
+	// the key of the file moved to FailedPrefix.
+	Handle(ctx context.Context, message queue.Message) (err error)
+	// ConsumeOnce receives a batch of messages, handles them and deletes the handled ones.
+	ConsumeOnce(ctx context.Context) (handled int, err error)
+	// Run consumes the queue until the context is done.
+	Run(ctx context.Context)
+}
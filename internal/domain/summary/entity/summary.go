@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"sort"
+	"time"
+
+	txEntity "github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+)
+
+// MonthlyCount struct defines the number of transactions of a month.
+type MonthlyCount struct {
+	// Month is the month of the transactions.
+	Month time.Month `json:"month"`
+	// Count is the number of transactions in the month.
+	Count int `json:"count"`
+}
+
+// Summary struct defines the summary of the transactions of a user.
+type Summary struct {
+	// UserID is the ID of the user that owns the transactions.
+	UserID int64 `json:"user_id"`
+	// Balance is the sum of all the transactions.
+	Balance float64 `json:"balance"`
+	// TotalCredits is the sum of the credit transactions.
+	TotalCredits float64 `json:"total_credits"`
+	// TotalDebits is the sum of the debit transactions.
+	TotalDebits float64 `json:"total_debits"`
+	// Transactions is the number of transactions.
+	Transactions int `json:"transactions"`
+	// MonthlyCounts are the number of transactions per month, ordered by month.
+	MonthlyCounts []MonthlyCount `json:"monthly_counts"`
+	// AverageCredit is the average amount of the credit transactions.
+	AverageCredit float64 `json:"average_credit"`
+	// AverageDebit is the average amount of the debit transactions.
+	AverageDebit float64 `json:"average_debit"`
+}
+
+// NewSummary returns the Summary of the given transactions.
+func NewSummary(userID int64, txs []txEntity.Transaction) (summary *Summary) {
+	summary = &Summary{
+		UserID:        userID,
+		MonthlyCounts: []MonthlyCount{},
+	}
+	credits, debits := 0, 0
+	months := map[time.Month]int{}
+	for _, tx := range txs {
+		summary.Balance += tx.Amount
+		summary.Transactions++
+		months[tx.Date.Month()]++
+		if tx.Amount < 0 {
+			summary.TotalDebits += tx.Amount
+			debits++
+			continue
+		}
+		summary.TotalCredits += tx.Amount
+		credits++
+	}
+	if credits > 0 {
+		summary.AverageCredit = summary.TotalCredits / float64(credits)
+	}
+	if debits > 0 {
+		summary.AverageDebit = summary.TotalDebits / float64(debits)
+	}
+	for month, count := range months {
+		summary.MonthlyCounts = append(summary.MonthlyCounts, MonthlyCount{Month: month, Count: count})
+	}
+	sort.Slice(summary.MonthlyCounts, func(i, j int) bool {
+		return summary.MonthlyCounts[i].Month < summary.MonthlyCounts[j].Month
+	})
+	return
+}
@@ -0,0 +1,58 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
+	txEntity "github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTransaction(t *testing.T, amount float64, date string) txEntity.Transaction {
+	txDate, err := time.Parse("1/2", date)
+	assert.Nil(t, err)
+	tx, err := txEntity.NewTransaction(uuid.New(), amount, txDate, "txns.csv")
+	assert.Nil(t, err)
+	return *tx
+}
+
+// TestNewSummary tests the NewSummary function.
+func TestNewSummary(t *testing.T) {
+	// Given the transactions of the README example
+	txs := []txEntity.Transaction{
+		newTestTransaction(t, 60.5, "7/15"),
+		newTestTransaction(t, -10.3, "7/28"),
+		newTestTransaction(t, -20.46, "8/2"),
+		newTestTransaction(t, 10, "8/13"),
+	}
+	// When call NewSummary
+	summary := entity.NewSummary(int64(1), txs)
+	// Then the totals are computed
+	assert.Equal(t, int64(1), summary.UserID)
+	assert.InDelta(t, 39.74, summary.Balance, 0.0001)
+	assert.InDelta(t, 70.5, summary.TotalCredits, 0.0001)
+	assert.InDelta(t, -30.76, summary.TotalDebits, 0.0001)
+	assert.Equal(t, 4, summary.Transactions)
+	// And the averages are computed
+	assert.InDelta(t, 35.25, summary.AverageCredit, 0.0001)
+	assert.InDelta(t, -15.38, summary.AverageDebit, 0.0001)
+	// And the monthly counts are ordered by month
+	assert.Equal(t, []entity.MonthlyCount{
+		{Month: time.July, Count: 2},
+		{Month: time.August, Count: 2},
+	}, summary.MonthlyCounts)
+}
+
+// TestNewSummaryWithoutTransactions tests the NewSummary function without transactions.
+func TestNewSummaryWithoutTransactions(t *testing.T) {
+	// Given no transactions
+	// When call NewSummary
+	summary := entity.NewSummary(int64(1), nil)
+	// Then the summary is empty
+	assert.Equal(t, 0, summary.Transactions)
+	assert.Equal(t, float64(0), summary.AverageCredit)
+	assert.Equal(t, float64(0), summary.AverageDebit)
+	assert.Empty(t, summary.MonthlyCounts)
+}
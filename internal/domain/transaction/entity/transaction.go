@@ -9,19 +9,19 @@ import (
 
 // Transaction struct defines the transaction entity.
 type Transaction struct {
-	ID uuid.UUID
+	ID uuid.UUID `json:"id"`
 	// AccountID is the ID of the account that the transaction belongs to.
-	AccountID uuid.UUID
+	AccountID uuid.UUID `json:"account_id"`
 	// Amount is the amount of the transaction.
-	Amount float64
+	Amount float64 `json:"amount"`
 	// Operation is the operation of the transaction.
-	Operation string
+	Operation string `json:"operation"`
 	// Date is the date of the transaction.
-	Date time.Time
+	Date time.Time `json:"date"`
 	// CreatedAt is the date and time when the transaction was created.
-	CreatedAt time.Time
+	CreatedAt time.Time `json:"created_at"`
 	// Origin is the origin of the transaction.
-	Origin string
+	Origin string `json:"origin"`
 }
 
 // NewTransaction returns a new Transaction instance.
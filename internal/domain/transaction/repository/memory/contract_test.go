@@ -0,0 +1,20 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/memory"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/repositorytest"
+	voMemory "github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	"github.com/google/uuid"
+)
+
+// TestTransactionRepositoryContract tests the memory repository against the
+// TransactionRepository contract.
+func TestTransactionRepositoryContract(t *testing.T) {
+	repositorytest.RunTransactionRepositoryContract(t, func(t *testing.T) (repository.TransactionRepository, func() uuid.UUID) {
+		// The memory transactions do not reference the accounts.
+		return memory.NewMemoryTransactionRepository(voMemory.NewDatabase()), uuid.New
+	})
+}
@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	ntMemory "github.com/braejan/go-transactions-summary/internal/domain/notification/repository/memory"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
+	"github.com/google/uuid"
+)
+
+// transactionsTable is the table of the transactions.
+const transactionsTable = "transactions"
+
+// memoryTransactionRepository is the in-memory implementation of the transaction repository.
+type memoryTransactionRepository struct {
+	database *memory.Database
+}
+
+// NewMemoryTransactionRepository creates a new instance of repository.TransactionRepository.
+// The outbox messages of CreateBatch are enqueued in the same database, where the memory
+// outbox repository claims them.
+func NewMemoryTransactionRepository(database *memory.Database) (transactionRepo repository.TransactionRepository) {
+	transactionRepo = &memoryTransactionRepository{
+		database: database,
+	}
+	return
+}
+
+// GetByID returns a transaction by its ID.
+func (memoryRepo *memoryTransactionRepository) GetByID(ctx context.Context, ID uuid.UUID) (tx *entity.Transaction, err error) {
+	err = memoryRepo.database.Read(func(dbTx *memory.Tx) error {
+		row, ok := dbTx.Get(transactionsTable, ID.String())
+		if !ok {
+			return transaction.ErrTransactionNotFound
+		}
+		stored := row.(entity.Transaction)
+		tx = &stored
+		return nil
+	})
+	return
+}
+
+// GetByAccountID returns all transactions for an account.
+func (memoryRepo *memoryTransactionRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	txs, err = memoryRepo.filter(func(tx entity.Transaction) bool {
+		return tx.AccountID == accountID
+	})
+	return
+}
+
+// GetCreditsByAccountID returns the credits of an account.
+func (memoryRepo *memoryTransactionRepository) GetCreditsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	txs, err = memoryRepo.filter(func(tx entity.Transaction) bool {
+		return tx.AccountID == accountID && tx.Operation == "credit"
+	})
+	return
+}
+
+// GetDebitsByAccountID returns the debits of an account.
+func (memoryRepo *memoryTransactionRepository) GetDebitsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	txs, err = memoryRepo.filter(func(tx entity.Transaction) bool {
+		return tx.AccountID == accountID && tx.Operation == "debit"
+	})
+	return
+}
+
+// GetTransactionsByOrigin returns all transactions for an origin.
+func (memoryRepo *memoryTransactionRepository) GetTransactionsByOrigin(ctx context.Context, origin string) (txs []*entity.Transaction, err error) {
+	if origin == "" {
+		err = transaction.ErrEmptyOrigin
+		return
+	}
+	txs, err = memoryRepo.filter(func(tx entity.Transaction) bool {
+		return tx.Origin == origin
+	})
+	return
+}
+
+// Create creates a new transaction.
+func (memoryRepo *memoryTransactionRepository) Create(ctx context.Context, tx *entity.Transaction) (err error) {
+	if tx == nil {
+		err = transaction.ErrNilTransaction
+		return
+	}
+	err = memoryRepo.database.Write(func(dbTx *memory.Tx) error {
+		return putTransaction(dbTx, tx)
+	})
+	return
+}
+
+// CreateBatch creates the transactions and enqueues the outbox messages in the same
+// write, so a message is only delivered if its transactions were stored. Messages already
+// enqueued by a previous ingestion of the same file are ignored.
+func (memoryRepo *memoryTransactionRepository) CreateBatch(ctx context.Context, txs []*entity.Transaction, messages []*notificationEntity.OutboxMessage) (err error) {
+	for _, tx := range txs {
+		if tx == nil {
+			err = transaction.ErrNilTransaction
+			return
+		}
+	}
+	for _, message := range messages {
+		if message == nil {
+			err = voNotification.ErrNilOutboxMessage
+			return
+		}
+	}
+	err = memoryRepo.database.Write(func(dbTx *memory.Tx) error {
+		for _, tx := range txs {
+			if err := putTransaction(dbTx, tx); err != nil {
+				return err
+			}
+		}
+		for _, message := range messages {
+			if _, ok := dbTx.Get(ntMemory.OutboxTable, message.ID.String()); ok {
+				continue
+			}
+			if err := dbTx.Put(ntMemory.OutboxTable, message.ID.String(), *ntMemory.CopyOutboxMessage(*message)); err != nil {
+				return voNotification.ErrEnqueuingOutboxMessage
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// filter returns copies of the transactions matching the condition, in creation order.
+func (memoryRepo *memoryTransactionRepository) filter(matches func(tx entity.Transaction) bool) (txs []*entity.Transaction, err error) {
+	err = memoryRepo.database.Read(func(dbTx *memory.Tx) error {
+		dbTx.Scan(transactionsTable, func(row interface{}) bool {
+			stored := row.(entity.Transaction)
+			if matches(stored) {
+				txs = append(txs, &stored)
+			}
+			return true
+		})
+		return nil
+	})
+	return
+}
+
+// putTransaction stores a copy of the transaction with the operation of its amount.
+// Creating a transaction with the ID of another one fails.
+func putTransaction(dbTx *memory.Tx, tx *entity.Transaction) (err error) {
+	if _, ok := dbTx.Get(transactionsTable, tx.ID.String()); ok {
+		err = database.NewConflictError(transaction.ErrCreatingTransaction, database.ErrUniqueViolation, "transactions_pkey")
+		return
+	}
+	stored := *tx
+	stored.Operation = "credit"
+	if stored.Amount < 0 {
+		stored.Operation = "debit"
+	}
+	if err = dbTx.Put(transactionsTable, stored.ID.String(), stored); err != nil {
+		err = transaction.ErrCreatingTransaction
+	}
+	return
+}
@@ -0,0 +1,147 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	ntMemory "github.com/braejan/go-transactions-summary/internal/domain/notification/repository/memory"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/memory"
+	voDatabase "github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	voMemory "github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	voTransaction "github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// getTestTransaction returns a new transaction of the account.
+func getTestTransaction(t *testing.T, accountID uuid.UUID, amount float64, origin string) *entity.Transaction {
+	tx, err := entity.NewTransaction(accountID, amount, time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC), origin)
+	assert.Nil(t, err)
+	return tx
+}
+
+// TestCreateAndGetTransaction tests a created transaction is returned by its ID.
+func TestCreateAndGetTransaction(t *testing.T) {
+	// Given an empty transaction repository
+	transactionRepo := memory.NewMemoryTransactionRepository(voMemory.NewDatabase())
+	tx := getTestTransaction(t, uuid.New(), 60.5, "txns.csv")
+	// When creating a transaction
+	err := transactionRepo.Create(context.Background(), tx)
+	// Then it is returned by its ID
+	assert.Nil(t, err)
+	stored, err := transactionRepo.GetByID(context.Background(), tx.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, tx, stored)
+	// And creating it again fails
+	err = transactionRepo.Create(context.Background(), tx)
+	assert.ErrorIs(t, err, voTransaction.ErrCreatingTransaction)
+	assert.ErrorIs(t, err, voDatabase.ErrUniqueViolation)
+}
+
+// TestGetTransactionNotFound tests the error returned when the transaction does not exist.
+func TestGetTransactionNotFound(t *testing.T) {
+	// Given an empty transaction repository
+	transactionRepo := memory.NewMemoryTransactionRepository(voMemory.NewDatabase())
+	// When getting a transaction by ID
+	tx, err := transactionRepo.GetByID(context.Background(), uuid.New())
+	// Then the error returned is ErrTransactionNotFound
+	assert.Nil(t, tx)
+	assert.Equal(t, voTransaction.ErrTransactionNotFound, err)
+}
+
+// TestGetCreditsAndDebits tests the transactions of an account are split by operation.
+func TestGetCreditsAndDebits(t *testing.T) {
+	// Given a transaction repository with a credit and a debit of an account
+	transactionRepo := memory.NewMemoryTransactionRepository(voMemory.NewDatabase())
+	accountID := uuid.New()
+	credit := getTestTransaction(t, accountID, 60.5, "txns.csv")
+	debit := getTestTransaction(t, accountID, -10.3, "txns.csv")
+	// And a credit of another account
+	other := getTestTransaction(t, uuid.New(), 20, "txns.csv")
+	assert.Nil(t, transactionRepo.CreateBatch(context.Background(), []*entity.Transaction{credit, debit, other}, nil))
+	// When getting the transactions, the credits and the debits of the account
+	txs, errTxs := transactionRepo.GetByAccountID(context.Background(), accountID)
+	credits, errCredits := transactionRepo.GetCreditsByAccountID(context.Background(), accountID)
+	debits, errDebits := transactionRepo.GetDebitsByAccountID(context.Background(), accountID)
+	// Then only the transactions of the account are returned in creation order
+	assert.Nil(t, errTxs)
+	assert.Equal(t, []*entity.Transaction{credit, debit}, txs)
+	// And they are split by operation
+	assert.Nil(t, errCredits)
+	assert.Equal(t, []*entity.Transaction{credit}, credits)
+	assert.Nil(t, errDebits)
+	assert.Equal(t, []*entity.Transaction{debit}, debits)
+}
+
+// TestGetTransactionsByOrigin tests the transactions are filtered by origin.
+func TestGetTransactionsByOrigin(t *testing.T) {
+	// Given a transaction repository with transactions of two files
+	transactionRepo := memory.NewMemoryTransactionRepository(voMemory.NewDatabase())
+	first := getTestTransaction(t, uuid.New(), 60.5, "first.csv")
+	second := getTestTransaction(t, uuid.New(), 10, "second.csv")
+	assert.Nil(t, transactionRepo.CreateBatch(context.Background(), []*entity.Transaction{first, second}, nil))
+	// When getting the transactions of the first file
+	txs, err := transactionRepo.GetTransactionsByOrigin(context.Background(), "first.csv")
+	// Then only its transaction is returned
+	assert.Nil(t, err)
+	assert.Equal(t, []*entity.Transaction{first}, txs)
+	// And an empty origin is rejected
+	_, err = transactionRepo.GetTransactionsByOrigin(context.Background(), "")
+	assert.Equal(t, voTransaction.ErrEmptyOrigin, err)
+}
+
+// TestCreateBatchIsAtomic tests nothing is stored when a transaction of the batch fails.
+func TestCreateBatchIsAtomic(t *testing.T) {
+	// Given a transaction repository with a transaction
+	database := voMemory.NewDatabase()
+	transactionRepo := memory.NewMemoryTransactionRepository(database)
+	accountID := uuid.New()
+	existing := getTestTransaction(t, accountID, 60.5, "txns.csv")
+	assert.Nil(t, transactionRepo.Create(context.Background(), existing))
+	// And an outbox message
+	message := &notificationEntity.OutboxMessage{ID: uuid.New(), Status: notificationEntity.OutboxStatusPending}
+	// When creating a batch repeating the transaction
+	err := transactionRepo.CreateBatch(context.Background(), []*entity.Transaction{getTestTransaction(t, accountID, 5, "txns.csv"), existing},
+		[]*notificationEntity.OutboxMessage{message})
+	// Then the error returned is a unique violation creating the transaction
+	assert.ErrorIs(t, err, voTransaction.ErrCreatingTransaction)
+	assert.ErrorIs(t, err, voDatabase.ErrUniqueViolation)
+	// And neither the new transaction nor the message are stored
+	txs, _ := transactionRepo.GetByAccountID(context.Background(), accountID)
+	assert.Len(t, txs, 1)
+	claimed, _ := ntMemory.NewMemoryOutboxRepository(database).Claim(context.Background(), time.Now(), time.Minute, 10)
+	assert.Empty(t, claimed)
+}
+
+// TestCreateBatchEnqueuesMessagesOnce tests the messages are enqueued in the outbox once.
+func TestCreateBatchEnqueuesMessagesOnce(t *testing.T) {
+	// Given a transaction repository and an outbox repository sharing the database
+	database := voMemory.NewDatabase()
+	transactionRepo := memory.NewMemoryTransactionRepository(database)
+	outboxRepo := ntMemory.NewMemoryOutboxRepository(database)
+	now := time.Now()
+	message := &notificationEntity.OutboxMessage{ID: uuid.New(), Status: notificationEntity.OutboxStatusPending, NextAttemptAt: now}
+	// When creating two batches with the same message
+	assert.Nil(t, transactionRepo.CreateBatch(context.Background(), []*entity.Transaction{getTestTransaction(t, uuid.New(), 1, "txns.csv")},
+		[]*notificationEntity.OutboxMessage{message}))
+	assert.Nil(t, transactionRepo.CreateBatch(context.Background(), nil, []*notificationEntity.OutboxMessage{message}))
+	// Then the message is claimed once
+	claimed, err := outboxRepo.Claim(context.Background(), now, time.Minute, 10)
+	assert.Nil(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, message.ID, claimed[0].ID)
+}
+
+// TestCreateBatchWithNilMessage tests the error returned when a message is nil.
+func TestCreateBatchWithNilMessage(t *testing.T) {
+	// Given an empty transaction repository
+	transactionRepo := memory.NewMemoryTransactionRepository(voMemory.NewDatabase())
+	// When creating a batch with a nil message
+	err := transactionRepo.CreateBatch(context.Background(), nil, []*notificationEntity.OutboxMessage{nil})
+	// Then the error returned is ErrNilOutboxMessage
+	assert.Equal(t, voNotification.ErrNilOutboxMessage, err)
+}
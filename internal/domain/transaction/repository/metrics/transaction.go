@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository"
+	voMetrics "github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+	"github.com/google/uuid"
+)
+
+// metricsTransactionRepository struct implements the TransactionRepository interface observing the latency of every
+// method of the decorated repository.
+type metricsTransactionRepository struct {
+	transactionRepo repository.TransactionRepository
+	latency         *voMetrics.Histogram
+}
+
+// NewMetricsTransactionRepository returns the repository decorated with the latency metrics of the registry.
+func NewMetricsTransactionRepository(transactionRepo repository.TransactionRepository, registry *voMetrics.Registry) (decorated repository.TransactionRepository) {
+	decorated = &metricsTransactionRepository{
+		transactionRepo: transactionRepo,
+		latency:         voMetrics.RepositoryLatency(registry),
+	}
+	return
+}
+
+// TransactionRepository interface implementation
+
+func (metricsRepo *metricsTransactionRepository) GetByID(ctx context.Context, ID uuid.UUID) (tx *entity.Transaction, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "transaction", "GetByID")
+	return metricsRepo.transactionRepo.GetByID(ctx, ID)
+}
+
+func (metricsRepo *metricsTransactionRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "transaction", "GetByAccountID")
+	return metricsRepo.transactionRepo.GetByAccountID(ctx, accountID)
+}
+
+func (metricsRepo *metricsTransactionRepository) GetCreditsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "transaction", "GetCreditsByAccountID")
+	return metricsRepo.transactionRepo.GetCreditsByAccountID(ctx, accountID)
+}
+
+func (metricsRepo *metricsTransactionRepository) GetDebitsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "transaction", "GetDebitsByAccountID")
+	return metricsRepo.transactionRepo.GetDebitsByAccountID(ctx, accountID)
+}
+
+func (metricsRepo *metricsTransactionRepository) GetTransactionsByOrigin(ctx context.Context, origin string) (txs []*entity.Transaction, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "transaction", "GetTransactionsByOrigin")
+	return metricsRepo.transactionRepo.GetTransactionsByOrigin(ctx, origin)
+}
+
+func (metricsRepo *metricsTransactionRepository) Create(ctx context.Context, tx *entity.Transaction) (err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "transaction", "Create")
+	return metricsRepo.transactionRepo.Create(ctx, tx)
+}
+
+func (metricsRepo *metricsTransactionRepository) CreateBatch(ctx context.Context, txs []*entity.Transaction, messages []*notificationEntity.OutboxMessage) (err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "transaction", "CreateBatch")
+	return metricsRepo.transactionRepo.CreateBatch(ctx, txs, messages)
+}
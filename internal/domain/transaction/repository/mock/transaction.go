@@ -1,6 +1,9 @@
 package mock
 
 import (
+	"context"
+
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
 	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -17,12 +20,12 @@ func NewMockTransactionRepository() *mockTransactionRepository {
 }
 
 // GetByID returns a transaction by its ID.
-func (m *mockTransactionRepository) GetByID(ID uuid.UUID) (tx *entity.Transaction, err error) {
-	args := m.Called(ID)
+func (m *mockTransactionRepository) GetByID(ctx context.Context, ID uuid.UUID) (tx *entity.Transaction, err error) {
+	args := m.Called(ctx, ID)
 
 	var r0 *entity.Transaction
-	if rf, ok := args.Get(0).(func(uuid.UUID) *entity.Transaction); ok {
-		r0 = rf(ID)
+	if rf, ok := args.Get(0).(func(context.Context, uuid.UUID) *entity.Transaction); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if args.Get(0) != nil {
 			r0 = args.Get(0).(*entity.Transaction)
@@ -30,8 +33,8 @@ func (m *mockTransactionRepository) GetByID(ID uuid.UUID) (tx *entity.Transactio
 	}
 
 	var r1 error
-	if rf, ok := args.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(ID)
+	if rf, ok := args.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
 	} else {
 		r1 = args.Error(1)
 	}
@@ -40,12 +43,12 @@ func (m *mockTransactionRepository) GetByID(ID uuid.UUID) (tx *entity.Transactio
 }
 
 // GetByAccountID returns a list of transactions by its account ID.
-func (m *mockTransactionRepository) GetByAccountID(accountID uuid.UUID) (txs []*entity.Transaction, err error) {
-	args := m.Called(accountID)
+func (m *mockTransactionRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	args := m.Called(ctx, accountID)
 
 	var r0 []*entity.Transaction
-	if rf, ok := args.Get(0).(func(uuid.UUID) []*entity.Transaction); ok {
-		r0 = rf(accountID)
+	if rf, ok := args.Get(0).(func(context.Context, uuid.UUID) []*entity.Transaction); ok {
+		r0 = rf(ctx, accountID)
 	} else {
 		if args.Get(0) != nil {
 			r0 = args.Get(0).([]*entity.Transaction)
@@ -53,8 +56,8 @@ func (m *mockTransactionRepository) GetByAccountID(accountID uuid.UUID) (txs []*
 	}
 
 	var r1 error
-	if rf, ok := args.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(accountID)
+	if rf, ok := args.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
 	} else {
 		r1 = args.Error(1)
 	}
@@ -63,12 +66,12 @@ func (m *mockTransactionRepository) GetByAccountID(accountID uuid.UUID) (txs []*
 }
 
 // GetCreditsByAccountID returns the credits of an account.
-func (m *mockTransactionRepository) GetCreditsByAccountID(accountID uuid.UUID) (txs []*entity.Transaction, err error) {
-	args := m.Called(accountID)
+func (m *mockTransactionRepository) GetCreditsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	args := m.Called(ctx, accountID)
 
 	var r0 []*entity.Transaction
-	if rf, ok := args.Get(0).(func(uuid.UUID) []*entity.Transaction); ok {
-		r0 = rf(accountID)
+	if rf, ok := args.Get(0).(func(context.Context, uuid.UUID) []*entity.Transaction); ok {
+		r0 = rf(ctx, accountID)
 	} else {
 		if args.Get(0) != nil {
 			r0 = args.Get(0).([]*entity.Transaction)
@@ -76,8 +79,8 @@ func (m *mockTransactionRepository) GetCreditsByAccountID(accountID uuid.UUID) (
 	}
 
 	var r1 error
-	if rf, ok := args.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(accountID)
+	if rf, ok := args.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
 	} else {
 		r1 = args.Error(1)
 	}
@@ -86,12 +89,12 @@ func (m *mockTransactionRepository) GetCreditsByAccountID(accountID uuid.UUID) (
 }
 
 // GetDebitsByAccountID returns the debits of an account.
-func (m *mockTransactionRepository) GetDebitsByAccountID(accountID uuid.UUID) (txs []*entity.Transaction, err error) {
-	args := m.Called(accountID)
+func (m *mockTransactionRepository) GetDebitsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	args := m.Called(ctx, accountID)
 
 	var r0 []*entity.Transaction
-	if rf, ok := args.Get(0).(func(uuid.UUID) []*entity.Transaction); ok {
-		r0 = rf(accountID)
+	if rf, ok := args.Get(0).(func(context.Context, uuid.UUID) []*entity.Transaction); ok {
+		r0 = rf(ctx, accountID)
 	} else {
 		if args.Get(0) != nil {
 			r0 = args.Get(0).([]*entity.Transaction)
@@ -99,8 +102,8 @@ func (m *mockTransactionRepository) GetDebitsByAccountID(accountID uuid.UUID) (t
 	}
 
 	var r1 error
-	if rf, ok := args.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(accountID)
+	if rf, ok := args.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
 	} else {
 		r1 = args.Error(1)
 	}
@@ -109,12 +112,12 @@ func (m *mockTransactionRepository) GetDebitsByAccountID(accountID uuid.UUID) (t
 }
 
 // GetTransactionsByOrigin returns the transactions of an account by origin.
-func (m *mockTransactionRepository) GetTransactionsByOrigin(origin string) (txs []*entity.Transaction, err error) {
-	args := m.Called(origin)
+func (m *mockTransactionRepository) GetTransactionsByOrigin(ctx context.Context, origin string) (txs []*entity.Transaction, err error) {
+	args := m.Called(ctx, origin)
 
 	var r0 []*entity.Transaction
-	if rf, ok := args.Get(0).(func(string) []*entity.Transaction); ok {
-		r0 = rf(origin)
+	if rf, ok := args.Get(0).(func(context.Context, string) []*entity.Transaction); ok {
+		r0 = rf(ctx, origin)
 	} else {
 		if args.Get(0) != nil {
 			r0 = args.Get(0).([]*entity.Transaction)
@@ -122,8 +125,8 @@ func (m *mockTransactionRepository) GetTransactionsByOrigin(origin string) (txs
 	}
 
 	var r1 error
-	if rf, ok := args.Get(1).(func(string) error); ok {
-		r1 = rf(origin)
+	if rf, ok := args.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, origin)
 	} else {
 		r1 = args.Error(1)
 	}
@@ -132,12 +135,26 @@ func (m *mockTransactionRepository) GetTransactionsByOrigin(origin string) (txs
 }
 
 // Create creates a new transaction.
-func (m *mockTransactionRepository) Create(tx *entity.Transaction) (err error) {
-	args := m.Called(tx)
+func (m *mockTransactionRepository) Create(ctx context.Context, tx *entity.Transaction) (err error) {
+	args := m.Called(ctx, tx)
+
+	var r0 error
+	if rf, ok := args.Get(0).(func(context.Context, *entity.Transaction) error); ok {
+		r0 = rf(ctx, tx)
+	} else {
+		r0 = args.Error(0)
+	}
+
+	return r0
+}
+
+// CreateBatch creates the transactions and enqueues the outbox messages.
+func (m *mockTransactionRepository) CreateBatch(ctx context.Context, txs []*entity.Transaction, messages []*notificationEntity.OutboxMessage) (err error) {
+	args := m.Called(ctx, txs, messages)
 
 	var r0 error
-	if rf, ok := args.Get(0).(func(*entity.Transaction) error); ok {
-		r0 = rf(tx)
+	if rf, ok := args.Get(0).(func(context.Context, []*entity.Transaction, []*notificationEntity.OutboxMessage) error); ok {
+		r0 = rf(ctx, txs, messages)
 	} else {
 		r0 = args.Error(0)
 	}
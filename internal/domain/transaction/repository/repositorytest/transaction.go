@@ -0,0 +1,175 @@
+// Package repositorytest holds the contract of the TransactionRepository: the transactions
+// are found by account and by origin file, and a batch is created atomically.
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	voTransaction "github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TransactionRepositoryFactory returns an empty transaction repository for a test, and a
+// function creating an account the transactions can reference.
+type TransactionRepositoryFactory func(t *testing.T) (transactionRepo repository.TransactionRepository, newAccount func() uuid.UUID)
+
+// RunTransactionRepositoryContract runs the contract of the TransactionRepository interface
+// against the repositories of the factory, one empty repository per subtest.
+func RunTransactionRepositoryContract(t *testing.T, newRepository TransactionRepositoryFactory) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		// Given an empty repository
+		transactionRepo, newAccount := newRepository(t)
+		tx := newTransaction(t, newAccount(), 60.5, "txns.csv")
+		// When creating a transaction
+		err := transactionRepo.Create(context.Background(), tx)
+		// Then it is returned by its ID
+		assert.Nil(t, err)
+		stored, err := transactionRepo.GetByID(context.Background(), tx.ID)
+		assert.Nil(t, err)
+		assertSameTransaction(t, tx, stored)
+	})
+	t.Run("GetByIDNotFound", func(t *testing.T) {
+		// When getting a transaction that does not exist
+		transactionRepo, _ := newRepository(t)
+		stored, err := transactionRepo.GetByID(context.Background(), uuid.New())
+		// Then the error returned is ErrTransactionNotFound
+		assert.Nil(t, stored)
+		assert.Equal(t, voTransaction.ErrTransactionNotFound, err)
+	})
+	t.Run("CreateNil", func(t *testing.T) {
+		// When creating a nil transaction
+		transactionRepo, _ := newRepository(t)
+		err := transactionRepo.Create(context.Background(), nil)
+		// Then the error returned is ErrNilTransaction
+		assert.Equal(t, voTransaction.ErrNilTransaction, err)
+	})
+	t.Run("GetByAccountID", func(t *testing.T) {
+		// Given a repository with transactions of two accounts
+		transactionRepo, newAccount := newRepository(t)
+		accountID, otherID := newAccount(), newAccount()
+		credit := newTransaction(t, accountID, 60.5, "txns.csv")
+		debit := newTransaction(t, accountID, -10.3, "txns.csv")
+		other := newTransaction(t, otherID, 20, "txns.csv")
+		assert.Nil(t, transactionRepo.CreateBatch(context.Background(), []*entity.Transaction{credit, debit, other}, nil))
+		// When getting the transactions of the first account
+		txs, err := transactionRepo.GetByAccountID(context.Background(), accountID)
+		// Then only its transactions are returned
+		assert.Nil(t, err)
+		assertSameTransactions(t, []*entity.Transaction{credit, debit}, txs)
+		// And an account without transactions has none
+		txs, err = transactionRepo.GetByAccountID(context.Background(), uuid.New())
+		assert.Nil(t, err)
+		assert.Empty(t, txs)
+	})
+	t.Run("CreditsAndDebits", func(t *testing.T) {
+		// Given a repository with two credits and a debit of an account
+		transactionRepo, newAccount := newRepository(t)
+		accountID := newAccount()
+		first := newTransaction(t, accountID, 60.5, "txns.csv")
+		second := newTransaction(t, accountID, 0.01, "txns.csv")
+		debit := newTransaction(t, accountID, -10.3, "txns.csv")
+		// And a debit of another account
+		other := newTransaction(t, newAccount(), -20, "txns.csv")
+		assert.Nil(t, transactionRepo.CreateBatch(context.Background(), []*entity.Transaction{first, debit, second, other}, nil))
+		// When getting the credits and the debits of the account
+		credits, errCredits := transactionRepo.GetCreditsByAccountID(context.Background(), accountID)
+		debits, errDebits := transactionRepo.GetDebitsByAccountID(context.Background(), accountID)
+		// Then the positive amounts are the credits
+		assert.Nil(t, errCredits)
+		assertSameTransactions(t, []*entity.Transaction{first, second}, credits)
+		// And the negative amounts are the debits
+		assert.Nil(t, errDebits)
+		assertSameTransactions(t, []*entity.Transaction{debit}, debits)
+	})
+	t.Run("GetByOrigin", func(t *testing.T) {
+		// Given a repository with transactions of two files
+		transactionRepo, newAccount := newRepository(t)
+		accountID := newAccount()
+		first := newTransaction(t, accountID, 60.5, "first.csv")
+		second := newTransaction(t, newAccount(), -5, "first.csv")
+		other := newTransaction(t, accountID, 10, "second.csv")
+		assert.Nil(t, transactionRepo.CreateBatch(context.Background(), []*entity.Transaction{first, other, second}, nil))
+		// When getting the transactions of the first file
+		txs, err := transactionRepo.GetTransactionsByOrigin(context.Background(), "first.csv")
+		// Then only its transactions are returned
+		assert.Nil(t, err)
+		assertSameTransactions(t, []*entity.Transaction{first, second}, txs)
+		// And a file without transactions has none
+		txs, err = transactionRepo.GetTransactionsByOrigin(context.Background(), "missing.csv")
+		assert.Nil(t, err)
+		assert.Empty(t, txs)
+		// And an empty origin is rejected
+		_, err = transactionRepo.GetTransactionsByOrigin(context.Background(), "")
+		assert.Equal(t, voTransaction.ErrEmptyOrigin, err)
+	})
+	t.Run("CreateBatchIsAtomic", func(t *testing.T) {
+		// Given a repository with a transaction
+		transactionRepo, newAccount := newRepository(t)
+		accountID := newAccount()
+		existing := newTransaction(t, accountID, 60.5, "txns.csv")
+		assert.Nil(t, transactionRepo.Create(context.Background(), existing))
+		// When creating a batch with a new transaction and the existing one
+		err := transactionRepo.CreateBatch(context.Background(), []*entity.Transaction{newTransaction(t, accountID, 5, "txns.csv"), existing}, nil)
+		// Then the error returned is a unique violation creating the transaction
+		assert.ErrorIs(t, err, voTransaction.ErrCreatingTransaction)
+		assert.ErrorIs(t, err, database.ErrUniqueViolation)
+		// And the new transaction is not stored
+		txs, _ := transactionRepo.GetByAccountID(context.Background(), accountID)
+		assertSameTransactions(t, []*entity.Transaction{existing}, txs)
+	})
+	t.Run("CreateBatchNil", func(t *testing.T) {
+		// When creating a batch with a nil transaction and another one with a nil message
+		transactionRepo, _ := newRepository(t)
+		errTx := transactionRepo.CreateBatch(context.Background(), []*entity.Transaction{nil}, nil)
+		errMessage := transactionRepo.CreateBatch(context.Background(), nil, []*notificationEntity.OutboxMessage{nil})
+		// Then the errors returned are ErrNilTransaction and ErrNilOutboxMessage
+		assert.Equal(t, voTransaction.ErrNilTransaction, errTx)
+		assert.Equal(t, voNotification.ErrNilOutboxMessage, errMessage)
+	})
+}
+
+// newTransaction returns a new transaction of the account on a date every database stores
+// exactly.
+func newTransaction(t *testing.T, accountID uuid.UUID, amount float64, origin string) *entity.Transaction {
+	tx, err := entity.NewTransaction(accountID, amount, time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC), origin)
+	assert.Nil(t, err)
+	return tx
+}
+
+// assertSameTransaction asserts the stored transaction has the fields of the created one.
+func assertSameTransaction(t *testing.T, expected *entity.Transaction, actual *entity.Transaction) {
+	t.Helper()
+	if !assert.NotNil(t, actual) {
+		return
+	}
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.AccountID, actual.AccountID)
+	assert.Equal(t, expected.Amount, actual.Amount)
+	assert.Equal(t, expected.Operation, actual.Operation)
+	assert.False(t, actual.CreatedAt.IsZero(), "created at is not read")
+	assert.True(t, expected.Date.Equal(actual.Date), "date %v, got %v", expected.Date, actual.Date)
+	assert.Equal(t, expected.Origin, actual.Origin)
+}
+
+// assertSameTransactions asserts the stored transactions are the created ones, in any order.
+func assertSameTransactions(t *testing.T, expected []*entity.Transaction, actual []*entity.Transaction) {
+	t.Helper()
+	if !assert.Len(t, actual, len(expected)) {
+		return
+	}
+	byID := map[uuid.UUID]*entity.Transaction{}
+	for _, tx := range actual {
+		byID[tx.ID] = tx
+	}
+	for _, tx := range expected {
+		assertSameTransaction(t, tx, byID[tx.ID])
+	}
+}
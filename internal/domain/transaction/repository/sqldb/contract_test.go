@@ -0,0 +1,39 @@
+package sqldb_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	accountEntity "github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	accountSQL "github.com/braejan/go-transactions-summary/internal/domain/account/repository/sqldb"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/repositorytest"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/sqldb"
+	userEntity "github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	userSQL "github.com/braejan/go-transactions-summary/internal/domain/user/repository/sqldb"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database/databasetest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTransactionRepositoryContract tests the repository against the TransactionRepository
+// contract on every dialect.
+func TestTransactionRepositoryContract(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, newDatabase databasetest.Factory) {
+		repositorytest.RunTransactionRepositoryContract(t, func(t *testing.T) (repository.TransactionRepository, func() uuid.UUID) {
+			baseDB := newDatabase(t)
+			userRepo := userSQL.NewSQLUserRepository(baseDB)
+			accountRepo := accountSQL.NewSQLAccountRepository(baseDB)
+			userID := int64(0)
+			newAccount := func() uuid.UUID {
+				userID++
+				assert.Nil(t, userRepo.Create(context.Background(), userEntity.NewUser(userID, "User", fmt.Sprintf("user%d@amazingemail.com", userID))))
+				account := accountEntity.NewAccount(userID)
+				assert.Nil(t, accountRepo.Create(context.Background(), account))
+				return account.ID
+			}
+			return sqldb.NewSQLTransactionRepository(baseDB), newAccount
+		})
+	})
+}
@@ -0,0 +1,302 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
+	"github.com/google/uuid"
+)
+
+// sqlTransactionRepository is the implementation of the transaction repository using a
+// PostgreSQL or a SQLite database.
+type sqlTransactionRepository struct {
+	baseDB database.Database
+	repository.TransactionRepository
+}
+
+// NewSQLTransactionRepository creates a new instance of repository.TransactionRepository.
+func NewSQLTransactionRepository(baseDB database.Database) (transactionRepo repository.TransactionRepository) {
+	transactionRepo = &sqlTransactionRepository{
+		baseDB: baseDB,
+	}
+	return
+}
+
+// repository.TransactionRepository implementation.
+
+// selectTransactions selects the columns scanned by rows2Transactions.
+const selectTransactions = `SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions`
+
+// GetByID returns a transaction by its ID.
+const (
+	getTransactionByID = selectTransactions + ` WHERE id = $1`
+)
+
+func (sqlRepo *sqlTransactionRepository) GetByID(ctx context.Context, ID uuid.UUID) (tx *entity.Transaction, err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	rows, err := database.Query(ctx, sqlRepo.baseDB, dbTx, getTransactionByID, ID)
+	if err != nil {
+		err = database.Wrap(transaction.ErrQueryingTransactionByID, err)
+		return
+	}
+	txs, err := rows2Transactions(rows, transaction.ErrQueryingTransactionByID, transaction.ErrScanningTransactionByID)
+	if err != nil {
+		return
+	}
+	if len(txs) == 0 {
+		err = transaction.ErrTransactionNotFound
+		return
+	}
+	tx = txs[0]
+	return
+}
+
+// GetByAccountID returns all transactions for an account.
+const (
+	getTransactionsByAccountID = selectTransactions + ` WHERE accountid = $1`
+)
+
+func (sqlRepo *sqlTransactionRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	rows, err := database.Query(ctx, sqlRepo.baseDB, dbTx, getTransactionsByAccountID, accountID)
+	if err != nil {
+		err = database.Wrap(transaction.ErrQueryingTransactionsByAccountID, err)
+		return
+	}
+	txs, err = rows2Transactions(rows, transaction.ErrQueryingTransactionsByAccountID, transaction.ErrScanningTransactionsByAccountID)
+	return
+}
+
+// GetCreditsByAccountID returns the credits of an account.
+const (
+	getCreditsByAccountID = selectTransactions + ` WHERE accountid = $1 AND operation = 'credit'`
+)
+
+func (sqlRepo *sqlTransactionRepository) GetCreditsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	rows, err := database.Query(ctx, sqlRepo.baseDB, dbTx, getCreditsByAccountID, accountID)
+	if err != nil {
+		err = database.Wrap(transaction.ErrQueryingCreditsByAccountID, err)
+		return
+	}
+	txs, err = rows2Transactions(rows, transaction.ErrQueryingCreditsByAccountID, transaction.ErrScanningCreditsByAccountID)
+	return
+}
+
+// GetDebitsByAccountID returns the debits of an account.
+const (
+	getDebitsByAccountID = selectTransactions + ` WHERE accountid = $1 AND operation = 'debit'`
+)
+
+func (sqlRepo *sqlTransactionRepository) GetDebitsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	rows, err := database.Query(ctx, sqlRepo.baseDB, dbTx, getDebitsByAccountID, accountID)
+	if err != nil {
+		err = database.Wrap(transaction.ErrQueryingDebitsByAccountID, err)
+		return
+	}
+	txs, err = rows2Transactions(rows, transaction.ErrQueryingDebitsByAccountID, transaction.ErrScanningDebitsByAccountID)
+	return
+}
+
+// GetTransactionsByOrigin returns all transactions for an origin.
+const (
+	getTransactionsByOrigin = selectTransactions + ` WHERE origin = $1`
+)
+
+func (sqlRepo *sqlTransactionRepository) GetTransactionsByOrigin(ctx context.Context, origin string) (txs []*entity.Transaction, err error) {
+	if origin == "" {
+		err = transaction.ErrEmptyOrigin
+		return
+	}
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	rows, err := database.Query(ctx, sqlRepo.baseDB, dbTx, getTransactionsByOrigin, origin)
+	if err != nil {
+		err = database.Wrap(transaction.ErrQueryingTransactionsByOrigin, err)
+		return
+	}
+	txs, err = rows2Transactions(rows, transaction.ErrQueryingTransactionsByOrigin, transaction.ErrScanningTransactionsByAccountID)
+	return
+}
+
+// Create creates a new transaction.
+const (
+	createTransaction = `INSERT INTO transactions (id, accountid, amount, date, origin, operation) VALUES ($1, $2, $3, $4, $5, $6)`
+)
+
+func (sqlRepo *sqlTransactionRepository) Create(ctx context.Context, tx *entity.Transaction) (err error) {
+	if tx == nil {
+		err = transaction.ErrNilTransaction
+		return
+	}
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	_, err = database.Exec(ctx, sqlRepo.baseDB, dbTx, createTransaction, tx.ID, tx.AccountID, tx.Amount, sqlRepo.baseDB.Dialect().Time(tx.Date), tx.Origin, txOperation(tx))
+	if err != nil {
+		log.Println("Error creating transaction in database", err)
+		_ = sqlRepo.baseDB.Rollback(dbTx)
+		err = database.Wrap(transaction.ErrCreatingTransaction, err)
+		return
+	}
+	err = sqlRepo.baseDB.Commit(dbTx)
+	return
+}
+
+// CreateBatch creates the transactions and enqueues the outbox messages in the same
+// database transaction, so a message is only delivered if its transactions were stored.
+// Messages already enqueued by a previous ingestion of the same file are ignored.
+const (
+	enqueueOutboxMessage = `INSERT INTO outbox (id, recipient, subject, payload, status, attempts, next_attempt_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (id) DO NOTHING`
+)
+
+func (sqlRepo *sqlTransactionRepository) CreateBatch(ctx context.Context, txs []*entity.Transaction, messages []*notificationEntity.OutboxMessage) (err error) {
+	for _, tx := range txs {
+		if tx == nil {
+			err = transaction.ErrNilTransaction
+			return
+		}
+	}
+	for _, message := range messages {
+		if message == nil {
+			err = voNotification.ErrNilOutboxMessage
+			return
+		}
+	}
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	dbTx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	dialect := sqlRepo.baseDB.Dialect()
+	for _, tx := range txs {
+		_, err = database.Exec(ctx, sqlRepo.baseDB, dbTx, createTransaction, tx.ID, tx.AccountID, tx.Amount, dialect.Time(tx.Date), tx.Origin, txOperation(tx))
+		if err != nil {
+			log.Println("Error creating transaction in database", err)
+			err = database.Wrap(transaction.ErrCreatingTransaction, err)
+			return
+		}
+	}
+	for _, message := range messages {
+		_, err = database.Exec(ctx, sqlRepo.baseDB, dbTx, enqueueOutboxMessage, message.ID, message.Recipient, message.Subject,
+			message.Payload, message.Status, message.Attempts, dialect.Time(message.NextAttemptAt), dialect.Time(message.CreatedAt))
+		if err != nil {
+			log.Println("Error enqueuing outbox message in database", err)
+			err = database.Wrap(voNotification.ErrEnqueuingOutboxMessage, err)
+			return
+		}
+	}
+	err = sqlRepo.baseDB.Commit(dbTx)
+	return
+}
+
+// txOperation returns the operation stored for the transaction amount.
+func txOperation(tx *entity.Transaction) (operation string) {
+	operation = "credit"
+	if tx.Amount < 0 {
+		operation = "debit"
+	}
+	return
+}
+
+// rows2Transactions scans and closes the rows. errScanning is returned when a row cannot
+// be scanned and errQuerying when the iteration fails, so a broken result is never
+// returned as a shorter one.
+func rows2Transactions(rows *sql.Rows, errQuerying, errScanning error) (txs []*entity.Transaction, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		tx := &entity.Transaction{}
+		err = rows.Scan(&tx.ID, &tx.AccountID, &tx.Amount, &tx.Operation, &tx.Date, &tx.CreatedAt, &tx.Origin)
+		if err != nil {
+			log.Println("Error scanning transaction", err)
+			txs = nil
+			err = database.Wrap(errScanning, err)
+			return
+		}
+		txs = append(txs, tx)
+	}
+	if rows.Err() != nil {
+		log.Println("Error reading transactions", rows.Err())
+		txs = nil
+		err = database.Wrap(errQuerying, rows.Err())
+	}
+	return
+}
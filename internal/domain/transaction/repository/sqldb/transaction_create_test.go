@@ -1,12 +1,15 @@
-package postgres_test
+package sqldb_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
 	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
-	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/postgres"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/sqldb"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
 	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
 	mockvoPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres/mock"
 	"github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
@@ -19,9 +22,9 @@ import (
 func TestCreateWithInvalidTransaction(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	transactionRepo := postgres.NewPostgresTransactionRepository(dbBase)
+	transactionRepo := sqldb.NewSQLTransactionRepository(dbBase)
 	// When creating a account .
-	err := transactionRepo.Create(nil)
+	err := transactionRepo.Create(context.Background(), nil)
 	// Then the error returned is ErrBeginningTransaction.
 	assert.NotNil(t, err)
 	assert.Equal(t, transaction.ErrNilTransaction, err)
@@ -31,21 +34,21 @@ func TestCreateWithInvalidTransaction(t *testing.T) {
 func TestCreateErrOpeningDatabase(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	transactionRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	transactionRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a mocked response calling Open.
 	dbBaseMocked.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
 	// When creating a account .
-	err := transactionRepo.Create(&entity.Transaction{})
+	err := transactionRepo.Create(context.Background(), &entity.Transaction{})
 	// Then the error returned is ErrOpeningDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestCreateErrBeginningTransaction tests the error returned when the transaction cannot be started.
 func TestCreateErrBeginningTransaction(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	transactionRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	transactionRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a mocked database.
 	db, _, _ := sqlmock.New()
 	// And a mocked response calling Open.
@@ -57,17 +60,17 @@ func TestCreateErrBeginningTransaction(t *testing.T) {
 	// And a mocked response calling Rollback.
 	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
 	// When creating a account .
-	err := transactionRepo.Create(&entity.Transaction{})
+	err := transactionRepo.Create(context.Background(), &entity.Transaction{})
 	// Then the error returned is ErrBeginningTransaction.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestCreateErrExecutingQuery tests the error returned when the query cannot be executed.
 func TestCreateErrExecutingQuery(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	transactionRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	transactionRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid origin.
 	origin := "txns.csv"
 	// And a mocked database.
@@ -99,17 +102,17 @@ func TestCreateErrExecutingQuery(t *testing.T) {
 			tx.Operation,
 		}).Return(nil, voPostgres.ErrExec)
 	// When creating a account .
-	err = transactionRepo.Create(tx)
+	err = transactionRepo.Create(context.Background(), tx)
 	// Then the error returned is ErrExecutingQuery.
 	assert.NotNil(t, err)
-	assert.Equal(t, transaction.ErrCreatingTransaction, err)
+	assert.ErrorIs(t, err, transaction.ErrCreatingTransaction)
 }
 
 // TestCreateErrCommittingTransaction tests the error returned when the transaction cannot be committed.
 func TestCreateErrCommittingTransaction(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	transactionRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	transactionRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid origin.
 	origin := "txns.csv"
 	// And a mocked database.
@@ -143,7 +146,7 @@ func TestCreateErrCommittingTransaction(t *testing.T) {
 	// And a mocked response calling Commit.
 	dbBaseMocked.On("Commit", dbTx).Return(voPostgres.ErrCommittingTransaction)
 	// When creating a account .
-	err = transactionRepo.Create(tx)
+	err = transactionRepo.Create(context.Background(), tx)
 	// Then the error returned is ErrCommittingTransaction.
 	assert.NotNil(t, err)
 	assert.Equal(t, voPostgres.ErrCommittingTransaction, err)
@@ -153,7 +156,7 @@ func TestCreateErrCommittingTransaction(t *testing.T) {
 func TestCreateSuccess(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid account repository.
-	transactionRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	transactionRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid origin.
 	origin := "txns.csv"
 	// And a mocked database.
@@ -189,7 +192,102 @@ func TestCreateSuccess(t *testing.T) {
 	// And a mocked response calling Commit.
 	dbBaseMocked.On("Commit", dbTx).Return(nil)
 	// When creating a account .
-	err = transactionRepo.Create(tx)
+	err = transactionRepo.Create(context.Background(), tx)
 	// Then the error returned is nil.
 	assert.Nil(t, err)
 }
+
+// getTestOutboxMessage returns a pending outbox message.
+func getTestOutboxMessage(t *testing.T) *notificationEntity.OutboxMessage {
+	message := notificationEntity.NewMessage("from@amazingemail.com", "to@amazingemail.com", "Resumen", "texto", "<p>texto</p>")
+	outboxMessage, err := notificationEntity.NewOutboxMessage(notificationEntity.NewDeliveryID("hash", int64(1)), *message, time.Now())
+	assert.Nil(t, err)
+	return outboxMessage
+}
+
+// TestCreateBatchWithNilOutboxMessage tests the error returned when an outbox message is nil.
+func TestCreateBatchWithNilOutboxMessage(t *testing.T) {
+	// Given a valid transaction repository.
+	transactionRepo := sqldb.NewSQLTransactionRepository(mockvoPostgres.NewMockBasePostgresDatabase())
+	// When creating a batch with a nil message.
+	err := transactionRepo.CreateBatch(context.Background(), []*entity.Transaction{{}}, []*notificationEntity.OutboxMessage{nil})
+	// Then the error returned is ErrNilOutboxMessage.
+	assert.Equal(t, voNotification.ErrNilOutboxMessage, err)
+}
+
+// TestCreateBatchErrEnqueuing tests nothing is committed when an outbox message cannot be enqueued.
+func TestCreateBatchErrEnqueuing(t *testing.T) {
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid transaction repository.
+	transactionRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
+	// And a mocked database.
+	db, _, _ := sqlmock.New()
+	dbTx, _ := db.Begin()
+	// And mocked responses calling Open, Close, BeginTx and Rollback.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", dbTx).Return(nil)
+	// And a transaction and an outbox message.
+	tx, err := entity.NewTransaction(uuid.New(), -20.46, time.Now(), "txns.csv")
+	assert.Nil(t, err)
+	message := getTestOutboxMessage(t)
+	// And a mocked response calling Exec with the transaction.
+	dbBaseMocked.On(
+		"Exec",
+		dbTx,
+		"INSERT INTO transactions (id, accountid, amount, date, origin, operation) VALUES ($1, $2, $3, $4, $5, $6)",
+		[]interface{}{tx.ID, tx.AccountID, tx.Amount, tx.Date, tx.Origin, "debit"}).Return(nil, nil)
+	// And a failing response calling Exec with the outbox message.
+	dbBaseMocked.On(
+		"Exec",
+		dbTx,
+		"INSERT INTO outbox (id, recipient, subject, payload, status, attempts, next_attempt_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (id) DO NOTHING",
+		[]interface{}{
+			message.ID,
+			message.Recipient,
+			message.Subject,
+			message.Payload,
+			message.Status,
+			message.Attempts,
+			message.NextAttemptAt,
+			message.CreatedAt,
+		}).Return(nil, voPostgres.ErrExec)
+	// When creating the batch.
+	err = transactionRepo.CreateBatch(context.Background(), []*entity.Transaction{tx}, []*notificationEntity.OutboxMessage{message})
+	// Then the error returned is ErrEnqueuingOutboxMessage.
+	assert.ErrorIs(t, err, voNotification.ErrEnqueuingOutboxMessage)
+	// And the database transaction is not committed.
+	dbBaseMocked.AssertNotCalled(t, "Commit", dbTx)
+}
+
+// TestCreateBatchSuccess tests the transactions and the outbox messages are committed together.
+func TestCreateBatchSuccess(t *testing.T) {
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a valid transaction repository.
+	transactionRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
+	// And a mocked database.
+	db, _, _ := sqlmock.New()
+	dbTx, _ := db.Begin()
+	// And mocked responses calling Open, Close, BeginTx, Rollback and Commit.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", dbTx).Return(nil)
+	dbBaseMocked.On("Commit", dbTx).Return(nil)
+	// And two transactions and an outbox message.
+	credit, err := entity.NewTransaction(uuid.New(), 60.5, time.Now(), "txns.csv")
+	assert.Nil(t, err)
+	debit, err := entity.NewTransaction(uuid.New(), -10.3, time.Now(), "txns.csv")
+	assert.Nil(t, err)
+	message := getTestOutboxMessage(t)
+	// And mocked responses calling Exec.
+	dbBaseMocked.On("Exec", dbTx, mock.Anything, mock.Anything).Return(nil, nil)
+	// When creating the batch.
+	err = transactionRepo.CreateBatch(context.Background(), []*entity.Transaction{credit, debit}, []*notificationEntity.OutboxMessage{message})
+	// Then the error returned is nil.
+	assert.Nil(t, err)
+	// And every row is written in the same database transaction.
+	dbBaseMocked.AssertNumberOfCalls(t, "Exec", 3)
+	dbBaseMocked.AssertNumberOfCalls(t, "Commit", 1)
+}
@@ -1,4 +1,4 @@
-package postgres_test
+package sqldb_test
 
 import (
 	"context"
@@ -6,7 +6,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/postgres"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/sqldb"
 	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
 	mockvoPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres/mock"
 	"github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
@@ -19,23 +19,23 @@ import (
 func TestGetByIDErrOpeningDatabase(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBase)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBase)
 	// And a valid uuid.UUID txID.
 	accID := uuid.New()
 	// And a mocked response when calling Open.
 	dbBase.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
 	// When getting a account by ID.
-	_, err := txRepo.GetByID(accID)
+	_, err := txRepo.GetByID(context.Background(), accID)
 	// Then the error returned is ErrOpeningDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestGetByIDErrOpeningTransaction tests the error returned when opening the transaction.
 func TestGetByIDErrOpeningTransaction(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBase)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBase)
 	// And a valid uuid.UUID txID.
 	accID := uuid.New()
 	// And a mocked database.
@@ -49,17 +49,17 @@ func TestGetByIDErrOpeningTransaction(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBase.On("Rollback", mock.Anything).Return(nil)
 	// When getting a account by ID.
-	_, err := txRepo.GetByID(accID)
+	_, err := txRepo.GetByID(context.Background(), accID)
 	// Then the error returned is ErrBeginningTransaction.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestGetByIDErrQueryingDatabase tests the error returned when querying the database.
 func TestGetByIDErrQueryingDatabase(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBase)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBase)
 	// And a valid uuid.UUID txID.
 	txID := uuid.New()
 	// And a mocked database.
@@ -74,12 +74,12 @@ func TestGetByIDErrQueryingDatabase(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBase.On("Rollback", mock.Anything).Return(nil)
 	// And a mocked response when querying the database.
-	dbBase.On("Query", tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE id = $1", []interface{}{txID}).Return(nil, voPostgres.ErrQueryingDatabase)
+	dbBase.On("Query", tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE id = $1", []interface{}{txID}).Return(nil, voPostgres.ErrQueryingDatabase)
 	// When getting a account by ID.
-	_, err := txRepo.GetByID(txID)
+	_, err := txRepo.GetByID(context.Background(), txID)
 	// Then the error returned is ErrQueryingDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, transaction.ErrQueryingTransactionByID, err)
+	assert.ErrorIs(t, err, transaction.ErrQueryingTransactionByID)
 }
 
 // TestGetByIDErrScanningRow tests the error returned when scanning the row.
@@ -107,17 +107,17 @@ func TestGetByIDErrScanningRow(t *testing.T) {
 	// And a mocked response when calling Query.
 	expected := sqlmock.NewRows([]string{"column1", "column2", "column3"}).AddRow(true, false, false)
 	dbMocked.ExpectQuery("SELECT (.+) FROM transactions WHERE id = (.+)").WithArgs(txID).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE id = $1", txID)
+	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE id = $1", txID)
 	assert.Nil(t, err)
-	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE id = $1", []interface{}{txID}).Return(rows, nil)
+	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE id = $1", []interface{}{txID}).Return(rows, nil)
 	// And a valid transaction repository
-	transactionRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	transactionRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	assert.Nil(t, err)
 	// When GetByID is called.
-	_, err = transactionRepo.GetByID(txID)
+	_, err = transactionRepo.GetByID(context.Background(), txID)
 	// Then the error returned should be ErrScanningUser.
 	assert.NotNil(t, err)
-	assert.Equal(t, transaction.ErrScanningTransactionByID, err)
+	assert.ErrorIs(t, err, transaction.ErrScanningTransactionByID)
 }
 
 // TestGetByIDSucess tests the success when getting a transaction by ID.
@@ -143,45 +143,80 @@ func TestGetByIDSucess(t *testing.T) {
 	// And a mocked response when calling Close.
 	dbBaseMocked.On("Close", db).Return(nil)
 	// And a mocked response when calling Query.
-	expected := sqlmock.NewRows([]string{"id", "accountid", "amount", "date", "origin"}).AddRow(txID, uuid.New(), 100.0, time.Now(), "txns.csv")
+	expected := sqlmock.NewRows([]string{"id", "accountid", "amount", "operation", "date", "created_at", "origin"}).AddRow(txID, uuid.New(), 100.0, "credit", time.Now(), time.Now(), "txns.csv")
 	dbMocked.ExpectQuery("SELECT (.+) FROM transactions WHERE id = (.+)").WithArgs(txID).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE id = $1", txID)
+	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE id = $1", txID)
 	assert.Nil(t, err)
-	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE id = $1", []interface{}{txID}).Return(rows, nil)
+	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE id = $1", []interface{}{txID}).Return(rows, nil)
 	// And a valid transaction repository
-	transactionRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	transactionRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	assert.Nil(t, err)
 	// When GetByID is called.
-	transaction, err := transactionRepo.GetByID(txID)
+	transaction, err := transactionRepo.GetByID(context.Background(), txID)
 	// Then the error returned should be ErrScanningUser.
 	assert.Nil(t, err)
 	assert.NotNil(t, transaction)
 	assert.Equal(t, txID, transaction.ID)
 	assert.Equal(t, 100.0, transaction.Amount)
 	assert.Equal(t, "txns.csv", transaction.Origin)
+	// And the operation and the creation date are read.
+	assert.Equal(t, "credit", transaction.Operation)
+	assert.False(t, transaction.CreatedAt.IsZero())
+}
+
+// TestGetByIDNotFound tests the error returned when the transaction does not exist.
+func TestGetByIDNotFound(t *testing.T) {
+	// Given a valid configuration.
+	configuration := voPostgres.NewPostgresConfigurationFromEnv()
+	dbBase := voPostgres.NewBasePostgresDatabase(configuration)
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	// And a mocked database.
+	db, dbMocked, _ := sqlmock.New()
+	defer db.Close()
+	dbMocked.ExpectBegin()
+	// And a valid uuid.UUID txID.
+	txID := uuid.New()
+	// And mocked responses when calling Open, BeginTx, Rollback and Close.
+	dbBaseMocked.On("Open").Return(db, nil)
+	tx, _ := db.BeginTx(context.Background(), nil)
+	dbBaseMocked.On("BeginTx", db).Return(tx, nil)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	// And a query without rows.
+	expected := sqlmock.NewRows([]string{"id", "accountid", "amount", "operation", "date", "created_at", "origin"})
+	dbMocked.ExpectQuery("SELECT (.+) FROM transactions WHERE id = (.+)").WithArgs(txID).WillReturnRows(expected)
+	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE id = $1", txID)
+	assert.Nil(t, err)
+	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE id = $1", []interface{}{txID}).Return(rows, nil)
+	transactionRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
+	// When GetByID is called.
+	found, err := transactionRepo.GetByID(context.Background(), txID)
+	// Then the error returned is ErrTransactionNotFound.
+	assert.Nil(t, found)
+	assert.Equal(t, transaction.ErrTransactionNotFound, err)
 }
 
 // TestGetByAccountIDErrOpeningDatabase tests the error returned when opening the database.
 func TestGetByAccountIDErrOpeningDatabase(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBase)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBase)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a mocked response when calling Open.
 	dbBase.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
 	// When getting a account by ID.
-	_, err := txRepo.GetByAccountID(accountID)
+	_, err := txRepo.GetByAccountID(context.Background(), accountID)
 	// Then the error returned is ErrOpeningDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestGetByAccountIDErrBeginningTransaction tests the error returned when beginning the transaction.
 func TestGetByAccountIDErrBeginningTransaction(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBase)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBase)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a sqlmock database.
@@ -195,17 +230,17 @@ func TestGetByAccountIDErrBeginningTransaction(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBase.On("Rollback", mock.Anything).Return(nil)
 	// When getting a account by ID.
-	_, err := txRepo.GetByAccountID(accountID)
+	_, err := txRepo.GetByAccountID(context.Background(), accountID)
 	// Then the error returned is ErrBeginningTransaction.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestGetByAccountIDErrQuerying tests the error returned when querying the database.
 func TestGetByAccountIDErrQuerying(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a sqlmock database.
@@ -220,12 +255,12 @@ func TestGetByAccountIDErrQuerying(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
 	// And a mocked response when calling Query.
-	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1", []interface{}{accountID}).Return(nil, voPostgres.ErrQueryingDatabase)
+	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1", []interface{}{accountID}).Return(nil, voPostgres.ErrQueryingDatabase)
 	// When getting a account by ID.
-	_, err := txRepo.GetByAccountID(accountID)
+	_, err := txRepo.GetByAccountID(context.Background(), accountID)
 	// Then the error returned is ErrQuerying.
 	assert.NotNil(t, err)
-	assert.Equal(t, transaction.ErrQueryingTransactionsByAccountID, err)
+	assert.ErrorIs(t, err, transaction.ErrQueryingTransactionsByAccountID)
 }
 
 // TestGetByAccountIDErrScanning tests the error returned when scanning the database.
@@ -235,7 +270,7 @@ func TestGetByAccountIDErrScanning(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	dbBase := voPostgres.NewBasePostgresDatabase(configuration)
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a sqlmock database.
@@ -254,14 +289,14 @@ func TestGetByAccountIDErrScanning(t *testing.T) {
 	// And a mocked response when calling Query.
 	expected := sqlmock.NewRows([]string{"column1", "column2"}).AddRow("100.0", "txns.csv")
 	dbMocked.ExpectQuery("SELECT (.+) FROM transactions WHERE accountid = (.+)").WithArgs(accountID).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1", accountID)
+	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1", accountID)
 	assert.Nil(t, err)
-	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1", []interface{}{accountID}).Return(rows, nil)
+	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1", []interface{}{accountID}).Return(rows, nil)
 	// When getting a account by ID.
-	_, err = txRepo.GetByAccountID(accountID)
+	_, err = txRepo.GetByAccountID(context.Background(), accountID)
 	// Then the error returned is ErrScanning.
 	assert.NotNil(t, err)
-	assert.Equal(t, transaction.ErrScanningTransactionsByAccountID, err)
+	assert.ErrorIs(t, err, transaction.ErrScanningTransactionsByAccountID)
 }
 
 // TestGetByAccountIDSucess tests the success when getting a transaction by account ID.
@@ -271,7 +306,7 @@ func TestGetByAccountIDSucess(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	dbBase := voPostgres.NewBasePostgresDatabase(configuration)
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a sqlmock database.
@@ -288,16 +323,16 @@ func TestGetByAccountIDSucess(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
 	// And a mocked response when calling Query.
-	expected := sqlmock.NewRows([]string{"id", "accountid", "amount", "date", "origin"})
-	expected.AddRow(uuid.New(), accountID, 100.0, time.Now(), "txns.csv")
-	expected.AddRow(uuid.New(), accountID, -200.0, time.Now(), "txns.csv")
-	expected.AddRow(uuid.New(), accountID, 300.0, time.Now(), "txns.csv")
+	expected := sqlmock.NewRows([]string{"id", "accountid", "amount", "operation", "date", "created_at", "origin"})
+	expected.AddRow(uuid.New(), accountID, 100.0, "credit", time.Now(), time.Now(), "txns.csv")
+	expected.AddRow(uuid.New(), accountID, -200.0, "debit", time.Now(), time.Now(), "txns.csv")
+	expected.AddRow(uuid.New(), accountID, 300.0, "credit", time.Now(), time.Now(), "txns.csv")
 	dbMocked.ExpectQuery("SELECT (.+) FROM transactions WHERE accountid = (.+)").WithArgs(accountID).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1", accountID)
+	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1", accountID)
 	assert.Nil(t, err)
-	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1", []interface{}{accountID}).Return(rows, nil)
+	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1", []interface{}{accountID}).Return(rows, nil)
 	// When getting a account by ID.
-	transactions, err := txRepo.GetByAccountID(accountID)
+	transactions, err := txRepo.GetByAccountID(context.Background(), accountID)
 	// Then the error returned is nil.
 	assert.Nil(t, err)
 	// And the transactions returned are not nil.
@@ -306,27 +341,61 @@ func TestGetByAccountIDSucess(t *testing.T) {
 	assert.Equal(t, 3, len(transactions))
 }
 
+// TestGetByAccountIDErrIterating tests the error returned when reading the rows fails.
+func TestGetByAccountIDErrIterating(t *testing.T) {
+	// Given a valid configuration.
+	configuration := voPostgres.NewPostgresConfigurationFromEnv()
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	dbBase := voPostgres.NewBasePostgresDatabase(configuration)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
+	accountID := uuid.New()
+	// And a sqlmock database.
+	db, dbMocked, _ := sqlmock.New()
+	defer db.Close()
+	dbMocked.ExpectBegin()
+	// And mocked responses when calling Open, Close, BeginTx and Rollback.
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	tx, _ := db.BeginTx(context.Background(), nil)
+	dbBaseMocked.On("BeginTx", db).Return(tx, nil)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	// And rows failing after the first one.
+	expected := sqlmock.NewRows([]string{"id", "accountid", "amount", "operation", "date", "created_at", "origin"})
+	expected.AddRow(uuid.New(), accountID, 100.0, "credit", time.Now(), time.Now(), "txns.csv")
+	expected.AddRow(uuid.New(), accountID, -200.0, "debit", time.Now(), time.Now(), "txns.csv")
+	expected.RowError(1, assert.AnError)
+	dbMocked.ExpectQuery("SELECT (.+) FROM transactions WHERE accountid = (.+)").WithArgs(accountID).WillReturnRows(expected)
+	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1", accountID)
+	assert.Nil(t, err)
+	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1", []interface{}{accountID}).Return(rows, nil)
+	// When getting the transactions of the account.
+	transactions, err := txRepo.GetByAccountID(context.Background(), accountID)
+	// Then the error returned is ErrQueryingTransactionsByAccountID instead of a partial result.
+	assert.Nil(t, transactions)
+	assert.ErrorIs(t, err, transaction.ErrQueryingTransactionsByAccountID)
+}
+
 // TestGetCreditsByAccountIDErrOpening tests the error returned when opening the database.
 func TestGetCreditsByAccountIDErrOpening(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a mocked response when calling Open.
 	dbBaseMocked.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
 	// When getting a account by ID.
-	_, err := txRepo.GetCreditsByAccountID(accountID)
+	_, err := txRepo.GetCreditsByAccountID(context.Background(), accountID)
 	// Then the error returned is ErrOpening.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestGetCreditsByAccountIDErrBeginningTx tests the error returned when beginning the transaction.
 func TestGetCreditsByAccountIDErrBeginningTx(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a sqlmock database.
@@ -340,17 +409,17 @@ func TestGetCreditsByAccountIDErrBeginningTx(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
 	// When getting a account by ID.
-	_, err := txRepo.GetCreditsByAccountID(accountID)
+	_, err := txRepo.GetCreditsByAccountID(context.Background(), accountID)
 	// Then the error returned is ErrBeginningTx.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestGetCreditsByAccountIDErrQuerying tests the error returned when querying the database.
 func TestGetCreditsByAccountIDErrQuerying(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a sqlmock database.
@@ -369,13 +438,13 @@ func TestGetCreditsByAccountIDErrQuerying(t *testing.T) {
 	dbBaseMocked.On(
 		"Query",
 		tx,
-		"SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1 AND operation = 'credit'",
+		"SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1 AND operation = 'credit'",
 		[]interface{}{accountID}).Return(nil, voPostgres.ErrQueryingDatabase)
 	// When getting a account by ID.
-	_, err := txRepo.GetCreditsByAccountID(accountID)
+	_, err := txRepo.GetCreditsByAccountID(context.Background(), accountID)
 	// Then the error returned is ErrQuerying.
 	assert.NotNil(t, err)
-	assert.Equal(t, transaction.ErrQueryingCreditsByAccountID, err)
+	assert.ErrorIs(t, err, transaction.ErrQueryingCreditsByAccountID)
 }
 
 // TestGetCreditsByAccountIDErrScanning tests the error returned when scanning the database.
@@ -385,7 +454,7 @@ func TestGetCreditsByAccountIDErrScanning(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	dbBase := voPostgres.NewBasePostgresDatabase(configuration)
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID txID.
 	txID := uuid.New()
 	// And a sqlmock database.
@@ -406,18 +475,18 @@ func TestGetCreditsByAccountIDErrScanning(t *testing.T) {
 	expected.AddRow(200.0, "txns.csv")
 	expected.AddRow(-300.0, "txns.csv")
 	dbMocked.ExpectQuery("SELECT (.+) FROM transactions WHERE accountid = (.+) AND operation = 'credit'").WithArgs(txID).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1 AND operation = 'credit'", txID)
+	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1 AND operation = 'credit'", txID)
 	assert.Nil(t, err)
 	dbBaseMocked.On(
 		"Query",
 		tx,
-		"SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1 AND operation = 'credit'",
+		"SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1 AND operation = 'credit'",
 		[]interface{}{txID}).Return(rows, nil)
 	// When getting a account by ID.
-	_, err = txRepo.GetCreditsByAccountID(txID)
+	_, err = txRepo.GetCreditsByAccountID(context.Background(), txID)
 	// Then the error returned is ErrScanning.
 	assert.NotNil(t, err)
-	assert.Equal(t, transaction.ErrScanningCreditsByAccountID, err)
+	assert.ErrorIs(t, err, transaction.ErrScanningCreditsByAccountID)
 }
 
 // TestGetCreditsByAccountIDSucess tests the success when getting the credits by account ID.
@@ -427,7 +496,7 @@ func TestGetCreditsByAccountIDSucess(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	dbBase := voPostgres.NewBasePostgresDatabase(configuration)
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID txID.
 	txID := uuid.New()
 	// And a sqlmock database.
@@ -443,20 +512,20 @@ func TestGetCreditsByAccountIDSucess(t *testing.T) {
 	dbBaseMocked.On("BeginTx", db).Return(tx, nil)
 	// And a mocked response when calling Rollback.
 	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
-	expected := sqlmock.NewRows([]string{"id", "accountid", "amount", "date", "origin"})
-	expected.AddRow(uuid.New(), txID, 100.0, time.Now(), "txns.csv")
-	expected.AddRow(uuid.New(), txID, 200.0, time.Now(), "txns.csv")
-	expected.AddRow(uuid.New(), txID, -300.0, time.Now(), "txns.csv")
+	expected := sqlmock.NewRows([]string{"id", "accountid", "amount", "operation", "date", "created_at", "origin"})
+	expected.AddRow(uuid.New(), txID, 100.0, "credit", time.Now(), time.Now(), "txns.csv")
+	expected.AddRow(uuid.New(), txID, 200.0, "credit", time.Now(), time.Now(), "txns.csv")
+	expected.AddRow(uuid.New(), txID, -300.0, "debit", time.Now(), time.Now(), "txns.csv")
 	dbMocked.ExpectQuery("SELECT (.+) FROM transactions WHERE accountid = (.+) AND operation = 'credit'").WithArgs(txID).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1 AND operation = 'credit'", txID)
+	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1 AND operation = 'credit'", txID)
 	assert.Nil(t, err)
 	dbBaseMocked.On(
 		"Query",
 		tx,
-		"SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1 AND operation = 'credit'",
+		"SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1 AND operation = 'credit'",
 		[]interface{}{txID}).Return(rows, nil)
 	// When getting a account by ID.
-	txs, err := txRepo.GetCreditsByAccountID(txID)
+	txs, err := txRepo.GetCreditsByAccountID(context.Background(), txID)
 	// Then the error returned is Nil
 	assert.Nil(t, err)
 	assert.NotNil(t, txs)
@@ -468,23 +537,23 @@ func TestGetCreditsByAccountIDSucess(t *testing.T) {
 func TestGetDebitsByAccountIDErrOpening(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a mocked response when calling Open.
 	dbBaseMocked.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
 	// When getting a account by ID.
-	_, err := txRepo.GetDebitsByAccountID(accountID)
+	_, err := txRepo.GetDebitsByAccountID(context.Background(), accountID)
 	// Then the error returned is ErrOpening.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestGetDebitsByAccountErrBeginTx tests the error returned when beginning the transaction.
 func TestGetDebitsByAccountErrBeginTx(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a sqlmock database.
@@ -499,17 +568,17 @@ func TestGetDebitsByAccountErrBeginTx(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
 	// When getting a account by ID.
-	_, err := txRepo.GetDebitsByAccountID(accountID)
+	_, err := txRepo.GetDebitsByAccountID(context.Background(), accountID)
 	// Then the error returned is ErrBeginningTransaction.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestGetDebitsByAccountIDErrQuerying tests the error returned when querying the database.
 func TestGetDebitsByAccountIDErrQuerying(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a sqlmock database.
@@ -528,13 +597,13 @@ func TestGetDebitsByAccountIDErrQuerying(t *testing.T) {
 	dbBaseMocked.On(
 		"Query",
 		tx,
-		"SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1 AND operation = 'debit'",
+		"SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1 AND operation = 'debit'",
 		[]interface{}{accountID}).Return(nil, voPostgres.ErrQueryingDatabase)
 	// When getting a account by ID.
-	_, err := txRepo.GetDebitsByAccountID(accountID)
+	_, err := txRepo.GetDebitsByAccountID(context.Background(), accountID)
 	// Then the error returned is ErrQueryingDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, transaction.ErrQueryingDebitsByAccountID, err)
+	assert.ErrorIs(t, err, transaction.ErrQueryingDebitsByAccountID)
 }
 
 // TestGetDebitsByAccountIDErrScanning tests the error returned when scanning the database response.
@@ -544,7 +613,7 @@ func TestGetDebitsByAccountIDErrScanning(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	dbBase := voPostgres.NewBasePostgresDatabase(configuration)
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a sqlmock database.
@@ -563,18 +632,18 @@ func TestGetDebitsByAccountIDErrScanning(t *testing.T) {
 	expected := sqlmock.NewRows([]string{"column1", "column2"})
 	expected.AddRow("invalid", "txns.csv")
 	dbMocked.ExpectQuery("SELECT (.+) FROM transactions WHERE accountid = (.+) AND operation = 'debit'").WithArgs(accountID).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1 AND operation = 'debit'", accountID)
+	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1 AND operation = 'debit'", accountID)
 	assert.Nil(t, err)
 	dbBaseMocked.On(
 		"Query",
 		tx,
-		"SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1 AND operation = 'debit'",
+		"SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1 AND operation = 'debit'",
 		[]interface{}{accountID}).Return(rows, nil)
 	// When getting a account by ID.
-	_, err = txRepo.GetDebitsByAccountID(accountID)
+	_, err = txRepo.GetDebitsByAccountID(context.Background(), accountID)
 	// Then the error returned is ErrScanning.
 	assert.NotNil(t, err)
-	assert.Equal(t, transaction.ErrScanningDebitsByAccountID, err)
+	assert.ErrorIs(t, err, transaction.ErrScanningDebitsByAccountID)
 }
 
 // TestGetDebitsByAccountIDSucess tests the success in getting the debits by account ID.
@@ -584,7 +653,7 @@ func TestGetDebitsByAccountIDSucess(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	dbBase := voPostgres.NewBasePostgresDatabase(configuration)
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid uuid.UUID accountID.
 	accountID := uuid.New()
 	// And a sqlmock database.
@@ -600,20 +669,20 @@ func TestGetDebitsByAccountIDSucess(t *testing.T) {
 	dbBaseMocked.On("BeginTx", db).Return(tx, nil)
 	// And a mocked response when calling Rollback.
 	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
-	expected := sqlmock.NewRows([]string{"id", "accountid", "amount", "date", "origin"})
-	expected.AddRow(uuid.New(), accountID, 100.00, time.Now(), "txns.csv")
-	expected.AddRow(uuid.New(), accountID, 200.00, time.Now(), "txns.csv")
-	expected.AddRow(uuid.New(), accountID, -300.00, time.Now(), "txns.csv")
+	expected := sqlmock.NewRows([]string{"id", "accountid", "amount", "operation", "date", "created_at", "origin"})
+	expected.AddRow(uuid.New(), accountID, 100.00, "credit", time.Now(), time.Now(), "txns.csv")
+	expected.AddRow(uuid.New(), accountID, 200.00, "credit", time.Now(), time.Now(), "txns.csv")
+	expected.AddRow(uuid.New(), accountID, -300.00, "debit", time.Now(), time.Now(), "txns.csv")
 	dbMocked.ExpectQuery("SELECT (.+) FROM transactions WHERE accountid = (.+) AND operation = 'debit'").WithArgs(accountID).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1 AND operation = 'debit'", accountID)
+	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1 AND operation = 'debit'", accountID)
 	assert.Nil(t, err)
 	dbBaseMocked.On(
 		"Query",
 		tx,
-		"SELECT id, accountid, amount, date, origin FROM transactions WHERE accountid = $1 AND operation = 'debit'",
+		"SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE accountid = $1 AND operation = 'debit'",
 		[]interface{}{accountID}).Return(rows, nil)
 	// When getting a account by ID.
-	txs, err := txRepo.GetDebitsByAccountID(accountID)
+	txs, err := txRepo.GetDebitsByAccountID(context.Background(), accountID)
 	// Then the error returned is Nil.
 	assert.Nil(t, err)
 	assert.NotNil(t, txs)
@@ -626,11 +695,11 @@ func TestGetDebitsByAccountIDSucess(t *testing.T) {
 func TestGetTransactionsByOriginInvalidOrigin(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a invalid origin.
 	origin := ""
 	// When getting a account by ID.
-	_, err := txRepo.GetTransactionsByOrigin(origin)
+	_, err := txRepo.GetTransactionsByOrigin(context.Background(), origin)
 	// Then the error returned is ErrEmptyOrigin.
 	assert.NotNil(t, err)
 	assert.Equal(t, transaction.ErrEmptyOrigin, err)
@@ -640,23 +709,23 @@ func TestGetTransactionsByOriginInvalidOrigin(t *testing.T) {
 func TestGetTransactionsByOriginErrOpening(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid origin.
 	origin := "txns.csv"
 	// And a mocked response when calling Open.
 	dbBaseMocked.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
 	// When getting a account by ID.
-	_, err := txRepo.GetTransactionsByOrigin(origin)
+	_, err := txRepo.GetTransactionsByOrigin(context.Background(), origin)
 	// Then the error returned is ErrOpeningDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestGetTransactionsByOriginErrBegginingTx tests the error returned when beginning the transaction.
 func TestGetTransactionsByOriginErrBegginingTx(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid origin.
 	origin := "txns.csv"
 	// And a sqlmock database.
@@ -671,17 +740,17 @@ func TestGetTransactionsByOriginErrBegginingTx(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
 	// When getting a account by ID.
-	_, err := txRepo.GetTransactionsByOrigin(origin)
+	_, err := txRepo.GetTransactionsByOrigin(context.Background(), origin)
 	// Then the error returned is ErrBeginningTx.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestGetTransactionsByOriginErrQuerying tests the error returned when querying the database.
 func TestGetTransactionsByOriginErrQuerying(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid origin.
 	origin := "txns.csv"
 	// And a sqlmock database.
@@ -697,12 +766,12 @@ func TestGetTransactionsByOriginErrQuerying(t *testing.T) {
 	dbBaseMocked.On("BeginTx", db).Return(tx, nil)
 	// And a mocked response when calling Rollback.
 	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
-	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE origin = $1", []interface{}{origin}).Return(nil, voPostgres.ErrQueryingDatabase)
+	dbBaseMocked.On("Query", tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE origin = $1", []interface{}{origin}).Return(nil, voPostgres.ErrQueryingDatabase)
 	// When getting a account by ID.
-	_, err := txRepo.GetTransactionsByOrigin(origin)
+	_, err := txRepo.GetTransactionsByOrigin(context.Background(), origin)
 	// Then the error returned is ErrQueryingDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, transaction.ErrQueryingTransactionsByOrigin, err)
+	assert.ErrorIs(t, err, transaction.ErrQueryingTransactionsByOrigin)
 }
 
 // TestGetTransactionsByOriginSuccess tests the success when querying the database.
@@ -712,7 +781,7 @@ func TestGetTransactionsByOriginSuccess(t *testing.T) {
 	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
 	dbBase := voPostgres.NewBasePostgresDatabase(configuration)
 	// And a valid transaction repository.
-	txRepo := postgres.NewPostgresTransactionRepository(dbBaseMocked)
+	txRepo := sqldb.NewSQLTransactionRepository(dbBaseMocked)
 	// And a valid origin.
 	origin := "txns.csv"
 	// And a sqlmock database.
@@ -729,21 +798,21 @@ func TestGetTransactionsByOriginSuccess(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
 	// And a mocked response when calling Query.
-	expected := sqlmock.NewRows([]string{"id", "accountid", "amount", "date", "origin"})
+	expected := sqlmock.NewRows([]string{"id", "accountid", "amount", "operation", "date", "created_at", "origin"})
 	accountID := uuid.New()
-	expected.AddRow(uuid.New(), accountID, 100.00, time.Now(), "txns.csv")
-	expected.AddRow(uuid.New(), accountID, 200.00, time.Now(), "txns.csv")
-	expected.AddRow(uuid.New(), accountID, -300.00, time.Now(), "txns.csv")
+	expected.AddRow(uuid.New(), accountID, 100.00, "credit", time.Now(), time.Now(), "txns.csv")
+	expected.AddRow(uuid.New(), accountID, 200.00, "credit", time.Now(), time.Now(), "txns.csv")
+	expected.AddRow(uuid.New(), accountID, -300.00, "debit", time.Now(), time.Now(), "txns.csv")
 	dbMocked.ExpectQuery("SELECT (.+) FROM transactions WHERE origin = (.+)").WithArgs(origin).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, date, origin FROM transactions WHERE origin = $1", origin)
+	rows, err := dbBase.Query(tx, "SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE origin = $1", origin)
 	assert.Nil(t, err)
 	dbBaseMocked.On(
 		"Query",
 		tx,
-		"SELECT id, accountid, amount, date, origin FROM transactions WHERE origin = $1",
+		"SELECT id, accountid, amount, operation, date, created_at, origin FROM transactions WHERE origin = $1",
 		[]interface{}{origin}).Return(rows, nil)
 	// When getting a account by ID.
-	txs, err := txRepo.GetTransactionsByOrigin(origin)
+	txs, err := txRepo.GetTransactionsByOrigin(context.Background(), origin)
 	// Then the error returned is Nil.
 	assert.Nil(t, err)
 	assert.NotNil(t, txs)
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository"
+	voTracing "github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+	"github.com/google/uuid"
+)
+
+// tracingTransactionRepository struct implements the TransactionRepository interface starting a span for every
+// method of the decorated repository.
+type tracingTransactionRepository struct {
+	transactionRepo repository.TransactionRepository
+}
+
+// NewTracingTransactionRepository returns the repository decorated with the spans of its methods, children
+// of the span carried by their context.
+func NewTracingTransactionRepository(transactionRepo repository.TransactionRepository) (decorated repository.TransactionRepository) {
+	decorated = &tracingTransactionRepository{
+		transactionRepo: transactionRepo,
+	}
+	return
+}
+
+// TransactionRepository interface implementation
+
+func (tracingRepo *tracingTransactionRepository) GetByID(ctx context.Context, ID uuid.UUID) (tx *entity.Transaction, err error) {
+	ctx, span := voTracing.Start(ctx, "TransactionRepository.GetByID")
+	defer span.EndWithError(&err)
+	return tracingRepo.transactionRepo.GetByID(ctx, ID)
+}
+
+func (tracingRepo *tracingTransactionRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	ctx, span := voTracing.Start(ctx, "TransactionRepository.GetByAccountID")
+	defer span.EndWithError(&err)
+	return tracingRepo.transactionRepo.GetByAccountID(ctx, accountID)
+}
+
+func (tracingRepo *tracingTransactionRepository) GetCreditsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	ctx, span := voTracing.Start(ctx, "TransactionRepository.GetCreditsByAccountID")
+	defer span.EndWithError(&err)
+	return tracingRepo.transactionRepo.GetCreditsByAccountID(ctx, accountID)
+}
+
+func (tracingRepo *tracingTransactionRepository) GetDebitsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []*entity.Transaction, err error) {
+	ctx, span := voTracing.Start(ctx, "TransactionRepository.GetDebitsByAccountID")
+	defer span.EndWithError(&err)
+	return tracingRepo.transactionRepo.GetDebitsByAccountID(ctx, accountID)
+}
+
+func (tracingRepo *tracingTransactionRepository) GetTransactionsByOrigin(ctx context.Context, origin string) (txs []*entity.Transaction, err error) {
+	ctx, span := voTracing.Start(ctx, "TransactionRepository.GetTransactionsByOrigin")
+	defer span.EndWithError(&err)
+	return tracingRepo.transactionRepo.GetTransactionsByOrigin(ctx, origin)
+}
+
+func (tracingRepo *tracingTransactionRepository) Create(ctx context.Context, tx *entity.Transaction) (err error) {
+	ctx, span := voTracing.Start(ctx, "TransactionRepository.Create")
+	defer span.EndWithError(&err)
+	return tracingRepo.transactionRepo.Create(ctx, tx)
+}
+
+func (tracingRepo *tracingTransactionRepository) CreateBatch(ctx context.Context, txs []*entity.Transaction, messages []*notificationEntity.OutboxMessage) (err error) {
+	ctx, span := voTracing.Start(ctx, "TransactionRepository.CreateBatch",
+		"transactions", len(txs), "messages", len(messages))
+	defer span.EndWithError(&err)
+	return tracingRepo.transactionRepo.CreateBatch(ctx, txs, messages)
+}
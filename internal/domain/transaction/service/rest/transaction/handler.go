@@ -0,0 +1,133 @@
+package transaction
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	acEntity "github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	ucAccount "github.com/braejan/go-transactions-summary/internal/domain/account/usecases"
+	summaryEntity "github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases"
+	voAccount "github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voTransaction "github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
+	voUser "github.com/braejan/go-transactions-summary/internal/valueobject/user"
+	"github.com/gorilla/mux"
+)
+
+// TransactionHandler serves the transactions and the summaries of the accounts. The end
+// users only read their own account, the clients granted the users:admin scope every account.
+type TransactionHandler struct {
+	accountUseCases     ucAccount.AccountUseCases
+	transactionUseCases usecases.TransactionUseCases
+	// logger logs the failed requests with their request ID.
+	logger logger.Logger
+}
+
+// TransactionHandlerOption configures an optional collaborator of the transaction handler.
+type TransactionHandlerOption func(handler *TransactionHandler) (err error)
+
+// WithLogger logs the failed requests with the logger instead of the default one.
+func WithLogger(log logger.Logger) TransactionHandlerOption {
+	return func(handler *TransactionHandler) (err error) {
+		if log == nil {
+			err = logger.ErrNilLogger
+			return
+		}
+		handler.logger = log
+		return
+	}
+}
+
+func NewTransactionHandler(accountUseCases ucAccount.AccountUseCases, transactionUseCases usecases.TransactionUseCases,
+	options ...TransactionHandlerOption) (transactionHandler *TransactionHandler, err error) {
+	if accountUseCases == nil {
+		err = voAccount.ErrNilAccountUseCases
+		return
+	}
+	if transactionUseCases == nil {
+		err = voTransaction.ErrNilTransactionUseCases
+		return
+	}
+	handler := &TransactionHandler{
+		accountUseCases:     accountUseCases,
+		transactionUseCases: transactionUseCases,
+		logger:              logger.NewDefaultLogger(),
+	}
+	for _, option := range options {
+		if err = option(handler); err != nil {
+			return
+		}
+	}
+	transactionHandler = handler
+	return
+}
+
+func (handler *TransactionHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/accounts/{id}/transactions", apierror.Handler(handler.logger, handler.GetTransactions)).Methods("GET")
+	router.HandleFunc("/users/{id}/summary", apierror.Handler(handler.logger, handler.GetSummary)).Methods("GET")
+}
+
+// GetTransactions responds with the transactions of the account of the ID.
+func (handler *TransactionHandler) GetTransactions(writer http.ResponseWriter, request *http.Request) (err error) {
+	account, err := handler.accountUseCases.GetByID(request.Context(), mux.Vars(request)["id"])
+	if err = checkAccount(request, account, err); err != nil {
+		return
+	}
+	txs, err := handler.transactionUseCases.GetByAccountID(request.Context(), account.ID)
+	if err != nil {
+		return fmt.Errorf("getting the transactions of account %s: %w", account.ID, err)
+	}
+	if txs == nil {
+		txs = []entity.Transaction{}
+	}
+	handler.writeJSON(writer, request, txs)
+	return
+}
+
+// GetSummary responds with the summary of the transactions of the user of the ID.
+func (handler *TransactionHandler) GetSummary(writer http.ResponseWriter, request *http.Request) (err error) {
+	userID, err := strconv.ParseInt(mux.Vars(request)["id"], 10, 64)
+	if err != nil {
+		return voUser.ErrInvalidUserID
+	}
+	// The access is checked before the query, so the accounts of other users are not probed.
+	if !auth.CanAccessUser(request.Context(), userID) {
+		return auth.ErrForbidden
+	}
+	account, err := handler.accountUseCases.GetByUserID(request.Context(), userID)
+	if err = checkAccount(request, account, err); err != nil {
+		return
+	}
+	txs, err := handler.transactionUseCases.GetByAccountID(request.Context(), account.ID)
+	if err != nil {
+		return fmt.Errorf("getting the transactions of account %s: %w", account.ID, err)
+	}
+	handler.writeJSON(writer, request, summaryEntity.NewSummary(userID, txs))
+	return
+}
+
+// checkAccount returns the error of the query of the account, or auth.ErrForbidden when
+// the client cannot read it.
+func checkAccount(request *http.Request, account acEntity.Account, err error) error {
+	if err != nil {
+		return err
+	}
+	if !auth.CanAccessUser(request.Context(), account.UserID) {
+		return fmt.Errorf("%w: account of another user", auth.ErrForbidden)
+	}
+	return nil
+}
+
+// writeJSON writes the value as JSON.
+func (handler *TransactionHandler) writeJSON(writer http.ResponseWriter, request *http.Request, value interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(value); err != nil {
+		logger.FromContext(request.Context(), handler.logger).Error("error writing the response", "error", err)
+	}
+}
@@ -0,0 +1,124 @@
+package transaction_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	acEntity "github.com/braejan/go-transactions-summary/internal/domain/account/entity"
+	accountMock "github.com/braejan/go-transactions-summary/internal/domain/account/usecases/mock"
+	summaryEntity "github.com/braejan/go-transactions-summary/internal/domain/summary/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/service/rest/transaction"
+	txMock "github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases/mock"
+	voAccount "github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/openapi/openapitest"
+	voTransaction "github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// serve sends a GET request of the principal to the routes of the handler.
+func serve(t *testing.T, handler *transaction.TransactionHandler, path string, principal *auth.Principal) *httptest.ResponseRecorder {
+	router := openapitest.NewRouter(t)
+	handler.RegisterRoutes(router)
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	if principal != nil {
+		request = request.WithContext(auth.WithPrincipal(request.Context(), principal))
+	}
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	return response
+}
+
+// getTransactions returns a credit and a debit of the account.
+func getTransactions(accountID uuid.UUID) []entity.Transaction {
+	date := time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)
+	return []entity.Transaction{
+		{ID: uuid.New(), AccountID: accountID, Amount: 60.5, Operation: "credit", Date: date, Origin: "txns.csv"},
+		{ID: uuid.New(), AccountID: accountID, Amount: -10.3, Operation: "debit", Date: date, Origin: "txns.csv"},
+	}
+}
+
+// TestNewTransactionHandler tests the NewTransactionHandler function with nil use cases.
+func TestNewTransactionHandler(t *testing.T) {
+	// When NewTransactionHandler is called with nil use cases
+	_, errAccount := transaction.NewTransactionHandler(nil, txMock.NewMockTransactionUseCases())
+	_, errTransaction := transaction.NewTransactionHandler(accountMock.NewMockAccountUseCases(), nil)
+	// Then the errors returned are the nil use cases errors
+	assert.Equal(t, voAccount.ErrNilAccountUseCases, errAccount)
+	assert.Equal(t, voTransaction.ErrNilTransactionUseCases, errTransaction)
+}
+
+// TestGetTransactions tests the end users only read the transactions of their own account.
+func TestGetTransactions(t *testing.T) {
+	// Given an account of the user 42 with transactions
+	acc := acEntity.Account{ID: uuid.New(), UserID: 42}
+	txs := getTransactions(acc.ID)
+	accountUseCases := accountMock.NewMockAccountUseCases()
+	accountUseCases.On("GetByID", mock.Anything, acc.ID.String()).Return(acc, nil)
+	transactionUseCases := txMock.NewMockTransactionUseCases()
+	transactionUseCases.On("GetByAccountID", mock.Anything, acc.ID).Return(txs, nil)
+	handler, err := transaction.NewTransactionHandler(accountUseCases, transactionUseCases)
+	assert.Nil(t, err)
+	path := "/accounts/" + acc.ID.String() + "/transactions"
+	// When the user 42 reads the transactions
+	response := serve(t, handler, path, &auth.Principal{ID: "42", IsUser: true, UserID: 42})
+	// Then the transactions are returned as JSON
+	assert.Equal(t, http.StatusOK, response.Code)
+	var decoded []entity.Transaction
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&decoded))
+	assert.Equal(t, txs, decoded)
+	// When the user 7 reads the transactions
+	response = serve(t, handler, path, &auth.Principal{ID: "7", IsUser: true, UserID: 7})
+	// Then the request is forbidden without querying the transactions
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	transactionUseCases.AssertNumberOfCalls(t, "GetByAccountID", 1)
+}
+
+// TestGetTransactionsErrors tests the errors of the queries are mapped to their status.
+func TestGetTransactionsErrors(t *testing.T) {
+	// Given use cases failing to get an account and the transactions of another
+	acc := acEntity.Account{ID: uuid.New(), UserID: 42}
+	accountUseCases := accountMock.NewMockAccountUseCases()
+	accountUseCases.On("GetByID", mock.Anything, "missing").Return(acEntity.Account{}, voAccount.ErrAccountNotFound)
+	accountUseCases.On("GetByID", mock.Anything, acc.ID.String()).Return(acc, nil)
+	transactionUseCases := txMock.NewMockTransactionUseCases()
+	transactionUseCases.On("GetByAccountID", mock.Anything, acc.ID).Return([]entity.Transaction(nil), errors.New("connection refused"))
+	handler, err := transaction.NewTransactionHandler(accountUseCases, transactionUseCases)
+	assert.Nil(t, err)
+	// When reading the transactions without authentication
+	// Then the errors are mapped to their status
+	assert.Equal(t, http.StatusNotFound, serve(t, handler, "/accounts/missing/transactions", nil).Code)
+	assert.Equal(t, http.StatusInternalServerError, serve(t, handler, "/accounts/"+acc.ID.String()+"/transactions", nil).Code)
+}
+
+// TestGetSummary tests the summary of the transactions of the user is returned to itself only.
+func TestGetSummary(t *testing.T) {
+	// Given an account of the user 42 with transactions
+	acc := acEntity.Account{ID: uuid.New(), UserID: 42}
+	txs := getTransactions(acc.ID)
+	accountUseCases := accountMock.NewMockAccountUseCases()
+	accountUseCases.On("GetByUserID", mock.Anything, int64(42)).Return(acc, nil)
+	transactionUseCases := txMock.NewMockTransactionUseCases()
+	transactionUseCases.On("GetByAccountID", mock.Anything, acc.ID).Return(txs, nil)
+	handler, err := transaction.NewTransactionHandler(accountUseCases, transactionUseCases)
+	assert.Nil(t, err)
+	// When the user 42 reads its summary
+	response := serve(t, handler, "/users/42/summary", &auth.Principal{ID: "42", IsUser: true, UserID: 42})
+	// Then the summary of the transactions is returned
+	assert.Equal(t, http.StatusOK, response.Code)
+	var decoded summaryEntity.Summary
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&decoded))
+	assert.Equal(t, *summaryEntity.NewSummary(42, txs), decoded)
+	// When the user 7 reads the summary of the user 42
+	response = serve(t, handler, "/users/42/summary", &auth.Principal{ID: "7", IsUser: true, UserID: 7})
+	// Then the request is forbidden without querying the account
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	accountUseCases.AssertNumberOfCalls(t, "GetByUserID", 1)
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"context"
+
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases"
+	voMetrics "github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+	"github.com/google/uuid"
+)
+
+// metricsTransactionUseCases struct implements the TransactionUseCases interface counting
+// the transactions stored by the decorated use cases.
+type metricsTransactionUseCases struct {
+	transactionUseCases usecases.TransactionUseCases
+	ingested            *voMetrics.Counter
+}
+
+// NewMetricsTransactionUseCases returns the use cases decorated with the transaction
+// metrics of the registry.
+func NewMetricsTransactionUseCases(transactionUseCases usecases.TransactionUseCases, registry *voMetrics.Registry) (decorated usecases.TransactionUseCases) {
+	decorated = &metricsTransactionUseCases{
+		transactionUseCases: transactionUseCases,
+		ingested:            registry.Counter("rows_ingested_total", "Rows of the files stored as transactions."),
+	}
+	return
+}
+
+// TransactionUseCases interface implementation
+
+func (uc *metricsTransactionUseCases) GetByID(ctx context.Context, ID uuid.UUID) (tx entity.Transaction, err error) {
+	return uc.transactionUseCases.GetByID(ctx, ID)
+}
+
+func (uc *metricsTransactionUseCases) GetByAccountID(ctx context.Context, accountID uuid.UUID) (txs []entity.Transaction, err error) {
+	return uc.transactionUseCases.GetByAccountID(ctx, accountID)
+}
+
+func (uc *metricsTransactionUseCases) GetCreditsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []entity.Transaction, err error) {
+	return uc.transactionUseCases.GetCreditsByAccountID(ctx, accountID)
+}
+
+func (uc *metricsTransactionUseCases) GetDebitsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []entity.Transaction, err error) {
+	return uc.transactionUseCases.GetDebitsByAccountID(ctx, accountID)
+}
+
+func (uc *metricsTransactionUseCases) GetTransactionsByOrigin(ctx context.Context, origin string) (txs []entity.Transaction, err error) {
+	return uc.transactionUseCases.GetTransactionsByOrigin(ctx, origin)
+}
+
+func (uc *metricsTransactionUseCases) Create(ctx context.Context, tx entity.Transaction) (err error) {
+	err = uc.transactionUseCases.Create(ctx, tx)
+	if err == nil {
+		uc.ingested.Inc()
+	}
+	return
+}
+
+func (uc *metricsTransactionUseCases) CreateBatch(ctx context.Context, txs []entity.Transaction, messages []notificationEntity.OutboxMessage) (err error) {
+	err = uc.transactionUseCases.CreateBatch(ctx, txs, messages)
+	if err == nil {
+		uc.ingested.Add(float64(len(txs)))
+	}
+	return
+}
@@ -1,6 +1,9 @@
 package mock
 
 import (
+	"context"
+
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
 	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -20,48 +23,55 @@ func NewMockTransactionUseCases() (usecases *mockTransactionUseCases) {
 // TransactionUseCases interface implementation:
 
 // GetByID implements the TransactionUseCases interface method.
-func (m *mockTransactionUseCases) GetByID(ID uuid.UUID) (tx entity.Transaction, err error) {
-	args := m.Called(ID)
+func (m *mockTransactionUseCases) GetByID(ctx context.Context, ID uuid.UUID) (tx entity.Transaction, err error) {
+	args := m.Called(ctx, ID)
 	tx = args.Get(0).(entity.Transaction)
 	err = args.Error(1)
 	return
 }
 
 // GetByAccountID implements the TransactionUseCases interface method.
-func (m *mockTransactionUseCases) GetByAccountID(accountID uuid.UUID) (txs []entity.Transaction, err error) {
-	args := m.Called(accountID)
+func (m *mockTransactionUseCases) GetByAccountID(ctx context.Context, accountID uuid.UUID) (txs []entity.Transaction, err error) {
+	args := m.Called(ctx, accountID)
 	txs = args.Get(0).([]entity.Transaction)
 	err = args.Error(1)
 	return
 }
 
 // GetCreditsByAccountID implements the TransactionUseCases interface method.
-func (m *mockTransactionUseCases) GetCreditsByAccountID(accountID uuid.UUID) (txs []entity.Transaction, err error) {
-	args := m.Called(accountID)
+func (m *mockTransactionUseCases) GetCreditsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []entity.Transaction, err error) {
+	args := m.Called(ctx, accountID)
 	txs = args.Get(0).([]entity.Transaction)
 	err = args.Error(1)
 	return
 }
 
 // GetDebitsByAccountID implements the TransactionUseCases interface method.
-func (m *mockTransactionUseCases) GetDebitsByAccountID(accountID uuid.UUID) (txs []entity.Transaction, err error) {
-	args := m.Called(accountID)
+func (m *mockTransactionUseCases) GetDebitsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []entity.Transaction, err error) {
+	args := m.Called(ctx, accountID)
 	txs = args.Get(0).([]entity.Transaction)
 	err = args.Error(1)
 	return
 }
 
 // GetTransactionsByOrigin implements the TransactionUseCases interface method.
-func (m *mockTransactionUseCases) GetTransactionsByOrigin(origin string) (txs []entity.Transaction, err error) {
-	args := m.Called(origin)
+func (m *mockTransactionUseCases) GetTransactionsByOrigin(ctx context.Context, origin string) (txs []entity.Transaction, err error) {
+	args := m.Called(ctx, origin)
 	txs = args.Get(0).([]entity.Transaction)
 	err = args.Error(1)
 	return
 }
 
 // Create implements the TransactionUseCases interface method.
-func (m *mockTransactionUseCases) Create(tx entity.Transaction) (err error) {
-	args := m.Called(tx)
+func (m *mockTransactionUseCases) Create(ctx context.Context, tx entity.Transaction) (err error) {
+	args := m.Called(ctx, tx)
+	err = args.Error(0)
+	return
+}
+
+// CreateBatch implements the TransactionUseCases interface method.
+func (m *mockTransactionUseCases) CreateBatch(ctx context.Context, txs []entity.Transaction, messages []notificationEntity.OutboxMessage) (err error) {
+	args := m.Called(ctx, txs, messages)
 	err = args.Error(0)
 	return
 }
@@ -1,6 +1,9 @@
 package usecases
 
 import (
+	"context"
+
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
 	txEntity "github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
 	"github.com/braejan/go-transactions-summary/internal/domain/transaction/repository"
 	"github.com/braejan/go-transactions-summary/internal/domain/transaction/util"
@@ -29,8 +32,8 @@ func NewTransactionUseCases(transactionRepo repository.TransactionRepository) (u
 // TransactionUseCases interface implementation
 
 // GetByID returns a transaction by its ID.
-func (uc *transactionUseCases) GetByID(txID uuid.UUID) (tx txEntity.Transaction, err error) {
-	txAux, err := uc.transactionRepo.GetByID(txID)
+func (uc *transactionUseCases) GetByID(ctx context.Context, txID uuid.UUID) (tx txEntity.Transaction, err error) {
+	txAux, err := uc.transactionRepo.GetByID(ctx, txID)
 	if err != nil {
 		return
 	}
@@ -39,8 +42,8 @@ func (uc *transactionUseCases) GetByID(txID uuid.UUID) (tx txEntity.Transaction,
 }
 
 // GetByAccountID returns a transaction by its account ID.
-func (uc *transactionUseCases) GetByAccountID(accountID uuid.UUID) (txs []txEntity.Transaction, err error) {
-	accAux, err := uc.transactionRepo.GetByAccountID(accountID)
+func (uc *transactionUseCases) GetByAccountID(ctx context.Context, accountID uuid.UUID) (txs []txEntity.Transaction, err error) {
+	accAux, err := uc.transactionRepo.GetByAccountID(ctx, accountID)
 	if err != nil {
 		txs = nil
 		return
@@ -50,8 +53,8 @@ func (uc *transactionUseCases) GetByAccountID(accountID uuid.UUID) (txs []txEnti
 }
 
 // GetCreditsByAccountID returns the credits of an account.
-func (uc *transactionUseCases) GetCreditsByAccountID(accountID uuid.UUID) (txs []txEntity.Transaction, err error) {
-	accAux, err := uc.transactionRepo.GetCreditsByAccountID(accountID)
+func (uc *transactionUseCases) GetCreditsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []txEntity.Transaction, err error) {
+	accAux, err := uc.transactionRepo.GetCreditsByAccountID(ctx, accountID)
 	if err != nil {
 		txs = nil
 		return
@@ -61,8 +64,8 @@ func (uc *transactionUseCases) GetCreditsByAccountID(accountID uuid.UUID) (txs [
 }
 
 // GetDebitsByAccountID returns the debits of an account.
-func (uc *transactionUseCases) GetDebitsByAccountID(accountID uuid.UUID) (txs []txEntity.Transaction, err error) {
-	accAux, err := uc.transactionRepo.GetDebitsByAccountID(accountID)
+func (uc *transactionUseCases) GetDebitsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []txEntity.Transaction, err error) {
+	accAux, err := uc.transactionRepo.GetDebitsByAccountID(ctx, accountID)
 	if err != nil {
 		txs = nil
 		return
@@ -72,8 +75,8 @@ func (uc *transactionUseCases) GetDebitsByAccountID(accountID uuid.UUID) (txs []
 }
 
 // GetTransactionsByOrigin returns the transactions of an account by origin.
-func (uc *transactionUseCases) GetTransactionsByOrigin(origin string) (txs []txEntity.Transaction, err error) {
-	accAux, err := uc.transactionRepo.GetTransactionsByOrigin(origin)
+func (uc *transactionUseCases) GetTransactionsByOrigin(ctx context.Context, origin string) (txs []txEntity.Transaction, err error) {
+	accAux, err := uc.transactionRepo.GetTransactionsByOrigin(ctx, origin)
 	if err != nil {
 		txs = nil
 		return
@@ -83,7 +86,21 @@ func (uc *transactionUseCases) GetTransactionsByOrigin(origin string) (txs []txE
 }
 
 // Create creates a new transaction.
-func (uc *transactionUseCases) Create(tx txEntity.Transaction) (err error) {
-	err = uc.transactionRepo.Create(&tx)
+func (uc *transactionUseCases) Create(ctx context.Context, tx txEntity.Transaction) (err error) {
+	err = uc.transactionRepo.Create(ctx, &tx)
+	return
+}
+
+// CreateBatch creates the transactions and enqueues the outbox messages atomically.
+func (uc *transactionUseCases) CreateBatch(ctx context.Context, txs []txEntity.Transaction, messages []notificationEntity.OutboxMessage) (err error) {
+	txsAux := make([]*txEntity.Transaction, 0, len(txs))
+	for i := range txs {
+		txsAux = append(txsAux, &txs[i])
+	}
+	messagesAux := make([]*notificationEntity.OutboxMessage, 0, len(messages))
+	for i := range messages {
+		messagesAux = append(messagesAux, &messages[i])
+	}
+	err = uc.transactionRepo.CreateBatch(ctx, txsAux, messagesAux)
 	return
 }
@@ -1,15 +1,19 @@
 package usecases_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
 	txEntity "github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
 	txMock "github.com/braejan/go-transactions-summary/internal/domain/transaction/repository/mock"
 	"github.com/braejan/go-transactions-summary/internal/domain/transaction/usecases"
+	"github.com/braejan/go-transactions-summary/internal/domain/transaction/util"
 	voTransaction "github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func getTestTransactions() (transactions []*txEntity.Transaction) {
@@ -67,14 +71,14 @@ func TestTransactionUseCases_GetByID(t *testing.T) {
 	// And a valid transaction entity
 	txToTest, err := txEntity.NewTransaction(uuid.New(), 100.50, time.Now(), "txns.csv")
 	assert.Nil(t, err)
-	mockTransactionRepo.On("GetByID", txToTest.ID).Return(nil, voTransaction.ErrQueryingTransactionByID)
+	mockTransactionRepo.On("GetByID", mock.Anything, txToTest.ID).Return(nil, voTransaction.ErrQueryingTransactionByID)
 	// And a valid transaction use cases
 	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
 	assert.Nil(t, err)
 	assert.NotNil(t, uc)
 	// When calling GetByID with a valid transaction ID
 	// Then it should return a transaction.
-	_, err = uc.GetByID(txToTest.ID)
+	_, err = uc.GetByID(context.Background(), txToTest.ID)
 	assert.NotNil(t, err)
 	assert.Equal(t, voTransaction.ErrQueryingTransactionByID, err)
 }
@@ -86,14 +90,14 @@ func TestTransactionUseCasesSuccess_GetByID(t *testing.T) {
 	// And a valid transaction entity
 	txToTest, err := txEntity.NewTransaction(uuid.New(), 100.50, time.Now(), "txns.csv")
 	assert.Nil(t, err)
-	mockTransactionRepo.On("GetByID", txToTest.ID).Return(txToTest, nil)
+	mockTransactionRepo.On("GetByID", mock.Anything, txToTest.ID).Return(txToTest, nil)
 	// And a valid transaction use cases
 	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
 	assert.Nil(t, err)
 	assert.NotNil(t, uc)
 	// When calling GetByID with a valid transaction ID
 	// Then it should return a transaction.
-	tx, err := uc.GetByID(txToTest.ID)
+	tx, err := uc.GetByID(context.Background(), txToTest.ID)
 	assert.Nil(t, err)
 	assert.Equal(t, *txToTest, tx)
 }
@@ -105,14 +109,14 @@ func TestTransactionUseCases_GetByAccountID_Err(t *testing.T) {
 	// And a valid transaction entity
 	txToTest, err := txEntity.NewTransaction(uuid.New(), 100.50, time.Now(), "txns.csv")
 	assert.Nil(t, err)
-	mockTransactionRepo.On("GetByAccountID", txToTest.AccountID).Return(nil, voTransaction.ErrQueryingTransactionsByAccountID)
+	mockTransactionRepo.On("GetByAccountID", mock.Anything, txToTest.AccountID).Return(nil, voTransaction.ErrQueryingTransactionsByAccountID)
 	// And a valid transaction use cases
 	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
 	assert.Nil(t, err)
 	assert.NotNil(t, uc)
 	// When calling GetByAccountID with a valid transaction ID
 	// Then it should return a transaction.
-	_, err = uc.GetByAccountID(txToTest.AccountID)
+	_, err = uc.GetByAccountID(context.Background(), txToTest.AccountID)
 	assert.NotNil(t, err)
 	assert.Equal(t, voTransaction.ErrQueryingTransactionsByAccountID, err)
 }
@@ -123,7 +127,7 @@ func TestTransactionUseCases_GetByAccountID_Success(t *testing.T) {
 	mockTransactionRepo := txMock.NewMockTransactionRepository()
 	txToTest := getTestTransactions()
 	for _, transaction := range txToTest {
-		mockTransactionRepo.On("GetByAccountID", transaction.AccountID).Return(getTestTransactions(), nil)
+		mockTransactionRepo.On("GetByAccountID", mock.Anything, transaction.AccountID).Return(getTestTransactions(), nil)
 	}
 	// And a valid transaction use cases
 	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
@@ -131,7 +135,7 @@ func TestTransactionUseCases_GetByAccountID_Success(t *testing.T) {
 	assert.NotNil(t, uc)
 	// When calling GetByAccountID with a valid transaction ID
 	// Then it should return a transaction.
-	txs, err := uc.GetByAccountID(txToTest[0].AccountID)
+	txs, err := uc.GetByAccountID(context.Background(), txToTest[0].AccountID)
 	assert.Nil(t, err)
 	assert.Equal(t, 10, len(txs))
 }
@@ -142,14 +146,14 @@ func TestGetCreditsByAccountID_GetCreditsByAccountID(t *testing.T) {
 	mockTransactionRepo := txMock.NewMockTransactionRepository()
 	txToTest := getTestTransactions()
 	for _, transaction := range txToTest {
-		mockTransactionRepo.On("GetCreditsByAccountID", transaction.AccountID).Return(nil, voTransaction.ErrQueryingCreditsByAccountID)
+		mockTransactionRepo.On("GetCreditsByAccountID", mock.Anything, transaction.AccountID).Return(nil, voTransaction.ErrQueryingCreditsByAccountID)
 	}
 	// And a valid transaction use cases
 	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
 	assert.Nil(t, err)
 	assert.NotNil(t, uc)
 	// When calling GetByAccountID with a valid transaction ID
-	txs, err := uc.GetCreditsByAccountID(txToTest[0].AccountID)
+	txs, err := uc.GetCreditsByAccountID(context.Background(), txToTest[0].AccountID)
 	// Then it should return a error ErrQueryingCreditsByAccountID
 	assert.NotNil(t, err)
 	assert.Nil(t, txs)
@@ -163,14 +167,14 @@ func TestGetCreditsByAccountID_Success(t *testing.T) {
 	mockTransactionRepo := txMock.NewMockTransactionRepository()
 	txToTest := getTestTransactions()
 	for _, transaction := range txToTest {
-		mockTransactionRepo.On("GetCreditsByAccountID", transaction.AccountID).Return(getTestTransactions(), nil)
+		mockTransactionRepo.On("GetCreditsByAccountID", mock.Anything, transaction.AccountID).Return(getTestTransactions(), nil)
 	}
 	// And a valid transaction use cases
 	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
 	assert.Nil(t, err)
 	assert.NotNil(t, uc)
 	// When calling GetByAccountID with a valid transaction ID
-	txs, err := uc.GetCreditsByAccountID(txToTest[0].AccountID)
+	txs, err := uc.GetCreditsByAccountID(context.Background(), txToTest[0].AccountID)
 	// Then it should return a error ErrQueryingCreditsByAccountID
 	assert.Nil(t, err)
 	assert.Equal(t, 10, len(txs))
@@ -182,14 +186,14 @@ func TestGetDebitsByAccountID_GetDebitsByAccountID(t *testing.T) {
 	mockTransactionRepo := txMock.NewMockTransactionRepository()
 	txToTest := getTestTransactions()
 	for _, transaction := range txToTest {
-		mockTransactionRepo.On("GetDebitsByAccountID", transaction.AccountID).Return(nil, voTransaction.ErrQueryingDebitsByAccountID)
+		mockTransactionRepo.On("GetDebitsByAccountID", mock.Anything, transaction.AccountID).Return(nil, voTransaction.ErrQueryingDebitsByAccountID)
 	}
 	// And a valid transaction use cases
 	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
 	assert.Nil(t, err)
 	assert.NotNil(t, uc)
 	// When calling GetByAccountID with a valid transaction ID
-	txs, err := uc.GetDebitsByAccountID(txToTest[0].AccountID)
+	txs, err := uc.GetDebitsByAccountID(context.Background(), txToTest[0].AccountID)
 	// Then it should return a error ErrQueryingCreditsByAccountID
 	assert.NotNil(t, err)
 	assert.Nil(t, txs)
@@ -203,14 +207,14 @@ func TestGetDebitsByAccountID_Success(t *testing.T) {
 	mockTransactionRepo := txMock.NewMockTransactionRepository()
 	txToTest := getTestTransactions()
 	for _, transaction := range txToTest {
-		mockTransactionRepo.On("GetDebitsByAccountID", transaction.AccountID).Return(getTestTransactions(), nil)
+		mockTransactionRepo.On("GetDebitsByAccountID", mock.Anything, transaction.AccountID).Return(getTestTransactions(), nil)
 	}
 	// And a valid transaction use cases
 	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
 	assert.Nil(t, err)
 	assert.NotNil(t, uc)
 	// When calling GetByAccountID with a valid transaction ID
-	txs, err := uc.GetDebitsByAccountID(txToTest[0].AccountID)
+	txs, err := uc.GetDebitsByAccountID(context.Background(), txToTest[0].AccountID)
 	// Then it should return a error ErrQueryingCreditsByAccountID
 	assert.Nil(t, err)
 	assert.Equal(t, 10, len(txs))
@@ -222,14 +226,14 @@ func TestGetTransactionsByOrigin_GetTransactionsByOrigin(t *testing.T) {
 	mockTransactionRepo := txMock.NewMockTransactionRepository()
 	txToTest := getTestTransactions()
 	for _, transaction := range txToTest {
-		mockTransactionRepo.On("GetTransactionsByOrigin", transaction.Origin).Return(nil, voTransaction.ErrQueryingTransactionsByOrigin)
+		mockTransactionRepo.On("GetTransactionsByOrigin", mock.Anything, transaction.Origin).Return(nil, voTransaction.ErrQueryingTransactionsByOrigin)
 	}
 	// And a valid transaction use cases
 	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
 	assert.Nil(t, err)
 	assert.NotNil(t, uc)
 	// When calling GetByAccountID with a valid transaction ID
-	txs, err := uc.GetTransactionsByOrigin(txToTest[0].Origin)
+	txs, err := uc.GetTransactionsByOrigin(context.Background(), txToTest[0].Origin)
 	// Then it should return a error ErrQueryingCreditsByAccountID
 	assert.NotNil(t, err)
 	assert.Nil(t, txs)
@@ -242,14 +246,14 @@ func TestGetTransactionsByOrigin_Success(t *testing.T) {
 	mockTransactionRepo := txMock.NewMockTransactionRepository()
 	txToTest := getTestTransactions()
 	for _, transaction := range txToTest {
-		mockTransactionRepo.On("GetTransactionsByOrigin", transaction.Origin).Return(getTestTransactions(), nil)
+		mockTransactionRepo.On("GetTransactionsByOrigin", mock.Anything, transaction.Origin).Return(getTestTransactions(), nil)
 	}
 	// And a valid transaction use cases
 	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
 	assert.Nil(t, err)
 	assert.NotNil(t, uc)
 	// When calling GetByAccountID with a valid transaction ID
-	txs, err := uc.GetTransactionsByOrigin(txToTest[0].Origin)
+	txs, err := uc.GetTransactionsByOrigin(context.Background(), txToTest[0].Origin)
 	// Then it should return a error ErrQueryingCreditsByAccountID
 	assert.Nil(t, err)
 	assert.Equal(t, 10, len(txs))
@@ -260,12 +264,12 @@ func TestCreate_Create(t *testing.T) {
 	// Given a valid transaction repository
 	mockTransactionRepo := txMock.NewMockTransactionRepository()
 	transactionToTest := getTestTransactions()[0]
-	mockTransactionRepo.On("Create", transactionToTest).Return(voTransaction.ErrCreatingTransaction)
+	mockTransactionRepo.On("Create", mock.Anything, transactionToTest).Return(voTransaction.ErrCreatingTransaction)
 	// And a valid transaction use cases
 	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
 	assert.Nil(t, err)
 	assert.NotNil(t, uc)
-	err = uc.Create(*transactionToTest)
+	err = uc.Create(context.Background(), *transactionToTest)
 	// Then it should return a error ErrCreatingTransaction
 	assert.NotNil(t, err)
 	assert.Equal(t, voTransaction.ErrCreatingTransaction, err)
@@ -276,12 +280,30 @@ func TestCreate_Success(t *testing.T) {
 	// Given a valid transaction repository
 	mockTransactionRepo := txMock.NewMockTransactionRepository()
 	transactionToTest := getTestTransactions()[0]
-	mockTransactionRepo.On("Create", transactionToTest).Return(nil)
+	mockTransactionRepo.On("Create", mock.Anything, transactionToTest).Return(nil)
 	// And a valid transaction use cases
 	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
 	assert.Nil(t, err)
 	assert.NotNil(t, uc)
-	err = uc.Create(*transactionToTest)
+	err = uc.Create(context.Background(), *transactionToTest)
 	// Then it should return a error ErrCreatingTransaction
 	assert.Nil(t, err)
 }
+
+// TestCreateBatch_Success
+func TestCreateBatch_Success(t *testing.T) {
+	// Given a valid transaction repository
+	mockTransactionRepo := txMock.NewMockTransactionRepository()
+	transactionsToTest := getTestTransactions()
+	// And an outbox message
+	message := notificationEntity.OutboxMessage{ID: uuid.New(), Status: notificationEntity.OutboxStatusPending}
+	mockTransactionRepo.On("CreateBatch", mock.Anything, transactionsToTest, []*notificationEntity.OutboxMessage{&message}).Return(nil)
+	// And a valid transaction use cases
+	uc, err := usecases.NewTransactionUseCases(mockTransactionRepo)
+	assert.Nil(t, err)
+	// When calling CreateBatch
+	err = uc.CreateBatch(context.Background(), util.ArrayTxMemoryToArrayValue(transactionsToTest), []notificationEntity.OutboxMessage{message})
+	// Then the transactions and the message are passed to the repository
+	assert.Nil(t, err)
+	mockTransactionRepo.AssertExpectations(t)
+}
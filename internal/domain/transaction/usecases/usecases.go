@@ -1,6 +1,9 @@
 package usecases
 
 import (
+	"context"
+
+	notificationEntity "github.com/braejan/go-transactions-summary/internal/domain/notification/entity"
 	"github.com/braejan/go-transactions-summary/internal/domain/transaction/entity"
 	"github.com/google/uuid"
 )
@@ -8,15 +11,17 @@ import (
 // TransactionUseCases interface defines the transaction use cases.
 type TransactionUseCases interface {
 	// GetByID returns a transaction by its ID.
-	GetByID(ID uuid.UUID) (tx entity.Transaction, err error)
+	GetByID(ctx context.Context, ID uuid.UUID) (tx entity.Transaction, err error)
 	// GetByAccountID returns a transaction by its account ID.
-	GetByAccountID(accountID uuid.UUID) (txs []entity.Transaction, err error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) (txs []entity.Transaction, err error)
 	// GetCreditsByAccountID returns the credits of an account.
-	GetCreditsByAccountID(accountID uuid.UUID) (txs []entity.Transaction, err error)
+	GetCreditsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []entity.Transaction, err error)
 	// GetDebitsByAccountID returns the debits of an account.
-	GetDebitsByAccountID(accountID uuid.UUID) (txs []entity.Transaction, err error)
+	GetDebitsByAccountID(ctx context.Context, accountID uuid.UUID) (txs []entity.Transaction, err error)
 	// GetTransactionsByOrigin returns the transactions of an account by origin.
-	GetTransactionsByOrigin(origin string) (txs []entity.Transaction, err error)
+	GetTransactionsByOrigin(ctx context.Context, origin string) (txs []entity.Transaction, err error)
 	// Create creates a new transaction.
-	Create(tx entity.Transaction) (err error)
+	Create(ctx context.Context, tx entity.Transaction) (err error)
+	// CreateBatch creates the transactions and enqueues the outbox messages atomically.
+	CreateBatch(ctx context.Context, txs []entity.Transaction, messages []notificationEntity.OutboxMessage) (err error)
 }
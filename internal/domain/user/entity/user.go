@@ -1,17 +1,22 @@
 package entity
 
+import "github.com/braejan/go-transactions-summary/internal/valueobject/locale"
+
 // User struct represent the card user entity.
 type User struct {
 	ID    int64  `json:"id"`
 	Name  string `json:"name"`
 	Email string `json:"email"`
+	// Locale is the language tag used to write messages to the user.
+	Locale string `json:"locale"`
 }
 
 // NewUser creates a new user instance using factory pattern.
 func NewUser(ID int64, name string, email string) (user *User) {
 	return &User{
-		ID:    ID,
-		Name:  name,
-		Email: email,
+		ID:     ID,
+		Name:   name,
+		Email:  email,
+		Locale: locale.DefaultTag,
 	}
 }
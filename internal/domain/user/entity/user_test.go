@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/locale"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,3 +16,10 @@ func Test_NewUser(t *testing.T) {
 	assert.Equal(t, "Juana María", user.Name)
 	assert.Equal(t, "juana.maria@amazingemail.com", user.Email)
 }
+
+// Test_NewUserDefaultLocale tests the NewUser function sets the default locale.
+func Test_NewUserDefaultLocale(t *testing.T) {
+	// Create a new user instance.
+	user := entity.NewUser(1, "Juana María", "juana.maria@amazingemail.com")
+	assert.Equal(t, locale.DefaultTag, user.Locale)
+}
@@ -0,0 +1,17 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository/memory"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository/repositorytest"
+	voMemory "github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+)
+
+// TestUserRepositoryContract tests the memory repository against the UserRepository contract.
+func TestUserRepositoryContract(t *testing.T) {
+	repositorytest.RunUserRepositoryContract(t, func(t *testing.T) repository.UserRepository {
+		return memory.NewMemoryUserRepository(voMemory.NewDatabase())
+	})
+}
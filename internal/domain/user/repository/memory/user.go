@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	userErrors "github.com/braejan/go-transactions-summary/internal/valueobject/user"
+)
+
+// usersTable is the table of the users.
+const usersTable = "users"
+
+// memoryUserRepository struct implements the UserRepository interface using an in-memory
+// database. Like the users table, the ID and the email of the users are unique.
+type memoryUserRepository struct {
+	database *memory.Database
+}
+
+// NewMemoryUserRepository creates a new instance of memoryUserRepository.
+func NewMemoryUserRepository(database *memory.Database) (userRepo repository.UserRepository) {
+	userRepo = &memoryUserRepository{
+		database: database,
+	}
+	return
+}
+
+// GetByID returns a user by its ID.
+func (memoryRepo *memoryUserRepository) GetByID(ctx context.Context, ID int64) (user *entity.User, err error) {
+	err = memoryRepo.database.Read(func(tx *memory.Tx) error {
+		row, ok := tx.Get(usersTable, userKey(ID))
+		if !ok {
+			return userErrors.ErrUserNotFound
+		}
+		stored := row.(entity.User)
+		user = &stored
+		return nil
+	})
+	return
+}
+
+// GetByEmail returns a user by its email.
+func (memoryRepo *memoryUserRepository) GetByEmail(ctx context.Context, email string) (user *entity.User, err error) {
+	err = memoryRepo.database.Read(func(tx *memory.Tx) error {
+		user = findByEmail(tx, email)
+		if user == nil {
+			return userErrors.ErrUserNotFound
+		}
+		return nil
+	})
+	return
+}
+
+// Create creates a new user. Creating a user with the ID or the email of another one fails.
+func (memoryRepo *memoryUserRepository) Create(ctx context.Context, user *entity.User) (err error) {
+	if user == nil {
+		err = userErrors.ErrNilUser
+		return
+	}
+	err = memoryRepo.database.Write(func(tx *memory.Tx) error {
+		if _, ok := tx.Get(usersTable, userKey(user.ID)); ok {
+			return database.NewConflictError(userErrors.ErrCreatingUser, database.ErrUniqueViolation, "users_pkey")
+		}
+		if findByEmail(tx, user.Email) != nil {
+			return database.NewConflictError(userErrors.ErrCreatingUser, database.ErrUniqueViolation, "users_email_key")
+		}
+		return tx.Put(usersTable, userKey(user.ID), *user)
+	})
+	return
+}
+
+// Update updates a user. Updating a user that does not exist does nothing, and updating the
+// email to the one of another user fails.
+func (memoryRepo *memoryUserRepository) Update(ctx context.Context, user *entity.User) (err error) {
+	if user == nil {
+		err = userErrors.ErrNilUser
+		return
+	}
+	err = memoryRepo.database.Write(func(tx *memory.Tx) error {
+		if _, ok := tx.Get(usersTable, userKey(user.ID)); !ok {
+			return nil
+		}
+		if other := findByEmail(tx, user.Email); other != nil && other.ID != user.ID {
+			return database.NewConflictError(userErrors.ErrUpdatingUser, database.ErrUniqueViolation, "users_email_key")
+		}
+		return tx.Put(usersTable, userKey(user.ID), *user)
+	})
+	return
+}
+
+// findByEmail returns a copy of the user with the given email, nil when there is none.
+func findByEmail(tx *memory.Tx, email string) (user *entity.User) {
+	tx.Scan(usersTable, func(row interface{}) bool {
+		stored := row.(entity.User)
+		if stored.Email != email {
+			return true
+		}
+		user = &stored
+		return false
+	})
+	return
+}
+
+func userKey(ID int64) string {
+	return strconv.FormatInt(ID, 10)
+}
@@ -0,0 +1,107 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository/memory"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	voMemory "github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	voUser "github.com/braejan/go-transactions-summary/internal/valueobject/user"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCreateAndGetUser tests a created user is returned by its ID and its email.
+func TestCreateAndGetUser(t *testing.T) {
+	// Given an empty user repository
+	userRepo := memory.NewMemoryUserRepository(voMemory.NewDatabase())
+	// When creating a user
+	err := userRepo.Create(context.Background(), entity.NewUser(1, "Juana María", "juana@amazingemail.com"))
+	// Then it is returned by its ID
+	assert.Nil(t, err)
+	byID, err := userRepo.GetByID(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "Juana María", byID.Name)
+	// And by its email
+	byEmail, err := userRepo.GetByEmail(context.Background(), "juana@amazingemail.com")
+	assert.Nil(t, err)
+	assert.Equal(t, byID, byEmail)
+}
+
+// TestGetUserNotFound tests the error returned when the user does not exist.
+func TestGetUserNotFound(t *testing.T) {
+	// Given an empty user repository
+	userRepo := memory.NewMemoryUserRepository(voMemory.NewDatabase())
+	// When getting a user by ID and by email
+	byID, errID := userRepo.GetByID(context.Background(), 1)
+	byEmail, errEmail := userRepo.GetByEmail(context.Background(), "juana@amazingemail.com")
+	// Then the errors returned are ErrUserNotFound
+	assert.Nil(t, byID)
+	assert.Equal(t, voUser.ErrUserNotFound, errID)
+	assert.Nil(t, byEmail)
+	assert.Equal(t, voUser.ErrUserNotFound, errEmail)
+}
+
+// TestCreateUserWithNilUser tests the error returned when the user is nil.
+func TestCreateUserWithNilUser(t *testing.T) {
+	// Given an empty user repository
+	userRepo := memory.NewMemoryUserRepository(voMemory.NewDatabase())
+	// When creating a nil user
+	err := userRepo.Create(context.Background(), nil)
+	// Then the error returned is ErrNilUser
+	assert.Equal(t, voUser.ErrNilUser, err)
+}
+
+// TestCreateUserUniqueIDAndEmail tests users with the ID or the email of another one are not created.
+func TestCreateUserUniqueIDAndEmail(t *testing.T) {
+	// Given a user repository with a user
+	userRepo := memory.NewMemoryUserRepository(voMemory.NewDatabase())
+	assert.Nil(t, userRepo.Create(context.Background(), entity.NewUser(1, "Juana", "juana@amazingemail.com")))
+	// When creating a user with the same ID and another one with the same email
+	errID := userRepo.Create(context.Background(), entity.NewUser(1, "Pedro", "pedro@amazingemail.com"))
+	errEmail := userRepo.Create(context.Background(), entity.NewUser(2, "Pedro", "juana@amazingemail.com"))
+	// Then the errors returned are unique violations creating the user
+	assert.ErrorIs(t, errID, voUser.ErrCreatingUser)
+	assert.ErrorIs(t, errEmail, voUser.ErrCreatingUser)
+	assert.ErrorIs(t, errID, database.ErrUniqueViolation)
+	assert.ErrorIs(t, errEmail, database.ErrUniqueViolation)
+	// And the second user was not created
+	_, err := userRepo.GetByID(context.Background(), 2)
+	assert.Equal(t, voUser.ErrUserNotFound, err)
+}
+
+// TestUpdateUser tests the user is updated and keeps its email unique.
+func TestUpdateUser(t *testing.T) {
+	// Given a user repository with two users
+	userRepo := memory.NewMemoryUserRepository(voMemory.NewDatabase())
+	assert.Nil(t, userRepo.Create(context.Background(), entity.NewUser(1, "Juana", "juana@amazingemail.com")))
+	assert.Nil(t, userRepo.Create(context.Background(), entity.NewUser(2, "Pedro", "pedro@amazingemail.com")))
+	// When updating the name of the first one
+	err := userRepo.Update(context.Background(), &entity.User{ID: 1, Name: "Juana María", Email: "juana@amazingemail.com", Locale: "en"})
+	// Then it is updated
+	assert.Nil(t, err)
+	user, _ := userRepo.GetByID(context.Background(), 1)
+	assert.Equal(t, "Juana María", user.Name)
+	assert.Equal(t, "en", user.Locale)
+	// And updating its email to the one of the second user fails
+	err = userRepo.Update(context.Background(), &entity.User{ID: 1, Name: "Juana María", Email: "pedro@amazingemail.com"})
+	assert.ErrorIs(t, err, voUser.ErrUpdatingUser)
+	assert.ErrorIs(t, err, database.ErrUniqueViolation)
+}
+
+// TestReturnedUserIsACopy tests changing a returned user does not change the stored one.
+func TestReturnedUserIsACopy(t *testing.T) {
+	// Given a user repository with a user
+	userRepo := memory.NewMemoryUserRepository(voMemory.NewDatabase())
+	created := entity.NewUser(1, "Juana", "juana@amazingemail.com")
+	assert.Nil(t, userRepo.Create(context.Background(), created))
+	// When changing the created and the returned users
+	created.Name = "Pedro"
+	returned, _ := userRepo.GetByID(context.Background(), 1)
+	returned.Email = "pedro@amazingemail.com"
+	// Then the stored user does not change
+	stored, _ := userRepo.GetByID(context.Background(), 1)
+	assert.Equal(t, "Juana", stored.Name)
+	assert.Equal(t, "juana@amazingemail.com", stored.Email)
+}
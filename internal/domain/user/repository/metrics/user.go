@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository"
+	voMetrics "github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+)
+
+// metricsUserRepository struct implements the UserRepository interface observing the latency of every
+// method of the decorated repository.
+type metricsUserRepository struct {
+	userRepo repository.UserRepository
+	latency  *voMetrics.Histogram
+}
+
+// NewMetricsUserRepository returns the repository decorated with the latency metrics of the registry.
+func NewMetricsUserRepository(userRepo repository.UserRepository, registry *voMetrics.Registry) (decorated repository.UserRepository) {
+	decorated = &metricsUserRepository{
+		userRepo: userRepo,
+		latency:  voMetrics.RepositoryLatency(registry),
+	}
+	return
+}
+
+// UserRepository interface implementation
+
+func (metricsRepo *metricsUserRepository) GetByID(ctx context.Context, ID int64) (user *entity.User, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "user", "GetByID")
+	return metricsRepo.userRepo.GetByID(ctx, ID)
+}
+
+func (metricsRepo *metricsUserRepository) GetByEmail(ctx context.Context, email string) (user *entity.User, err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "user", "GetByEmail")
+	return metricsRepo.userRepo.GetByEmail(ctx, email)
+}
+
+func (metricsRepo *metricsUserRepository) Create(ctx context.Context, user *entity.User) (err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "user", "Create")
+	return metricsRepo.userRepo.Create(ctx, user)
+}
+
+func (metricsRepo *metricsUserRepository) Update(ctx context.Context, user *entity.User) (err error) {
+	defer metricsRepo.latency.ObserveSince(time.Now(), "user", "Update")
+	return metricsRepo.userRepo.Update(ctx, user)
+}
@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"context"
+
 	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
 	"github.com/stretchr/testify/mock"
 )
@@ -15,13 +17,13 @@ func NewMockUserRepository() *mockUserRepository {
 	return &mockUserRepository{}
 }
 
-// GetByID provides a mock function with given fields: ID
-func (_m *mockUserRepository) GetByID(ID int64) (user *entity.User, err error) {
-	ret := _m.Called(ID)
+// GetByID provides a mock function with given fields: ctx, ID
+func (_m *mockUserRepository) GetByID(ctx context.Context, ID int64) (user *entity.User, err error) {
+	ret := _m.Called(ctx, ID)
 
 	var r0 *entity.User
-	if rf, ok := ret.Get(0).(func(int64) *entity.User); ok {
-		r0 = rf(ID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.User); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.User)
@@ -29,8 +31,8 @@ func (_m *mockUserRepository) GetByID(ID int64) (user *entity.User, err error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(ID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, ID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -38,13 +40,13 @@ func (_m *mockUserRepository) GetByID(ID int64) (user *entity.User, err error) {
 	return r0, r1
 }
 
-// GetByEmail provides a mock function with given fields: email
-func (_m *mockUserRepository) GetByEmail(email string) (user *entity.User, err error) {
-	ret := _m.Called(email)
+// GetByEmail provides a mock function with given fields: ctx, email
+func (_m *mockUserRepository) GetByEmail(ctx context.Context, email string) (user *entity.User, err error) {
+	ret := _m.Called(ctx, email)
 
 	var r0 *entity.User
-	if rf, ok := ret.Get(0).(func(string) *entity.User); ok {
-		r0 = rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, email)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.User)
@@ -52,8 +54,8 @@ func (_m *mockUserRepository) GetByEmail(email string) (user *entity.User, err e
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(email)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -61,13 +63,13 @@ func (_m *mockUserRepository) GetByEmail(email string) (user *entity.User, err e
 	return r0, r1
 }
 
-// Create provides a mock function with given fields: user
-func (_m *mockUserRepository) Create(user *entity.User) (err error) {
-	ret := _m.Called(user)
+// Create provides a mock function with given fields: ctx, user
+func (_m *mockUserRepository) Create(ctx context.Context, user *entity.User) (err error) {
+	ret := _m.Called(ctx, user)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entity.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -75,13 +77,13 @@ func (_m *mockUserRepository) Create(user *entity.User) (err error) {
 	return r0
 }
 
-// Update provides a mock function with given fields: user
-func (_m *mockUserRepository) Update(user *entity.User) (err error) {
-	ret := _m.Called(user)
+// Update provides a mock function with given fields: ctx, user
+func (_m *mockUserRepository) Update(ctx context.Context, user *entity.User) (err error) {
+	ret := _m.Called(ctx, user)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entity.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
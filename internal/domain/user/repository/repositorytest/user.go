@@ -0,0 +1,95 @@
+// Package repositorytest holds the contract of the UserRepository: the users are found by
+// their ID and by their email, both unique.
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	voUser "github.com/braejan/go-transactions-summary/internal/valueobject/user"
+	"github.com/stretchr/testify/assert"
+)
+
+// UserRepositoryFactory returns an empty user repository for a test.
+type UserRepositoryFactory func(t *testing.T) (userRepo repository.UserRepository)
+
+// RunUserRepositoryContract runs the contract of the UserRepository interface against the
+// repositories of the factory, one empty repository per subtest.
+func RunUserRepositoryContract(t *testing.T, newRepository UserRepositoryFactory) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		// Given an empty repository
+		userRepo := newRepository(t)
+		user := &entity.User{ID: 1, Name: "Juana María", Email: "juana@amazingemail.com", Locale: "en"}
+		// When creating a user
+		err := userRepo.Create(context.Background(), user)
+		// Then it is returned by its ID and by its email
+		assert.Nil(t, err)
+		byID, err := userRepo.GetByID(context.Background(), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, user, byID)
+		byEmail, err := userRepo.GetByEmail(context.Background(), "juana@amazingemail.com")
+		assert.Nil(t, err)
+		assert.Equal(t, user, byEmail)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		// Given a repository with a user
+		userRepo := newRepository(t)
+		assert.Nil(t, userRepo.Create(context.Background(), entity.NewUser(1, "Juana", "juana@amazingemail.com")))
+		// When getting another user by ID and by email
+		_, errID := userRepo.GetByID(context.Background(), 2)
+		_, errEmail := userRepo.GetByEmail(context.Background(), "pedro@amazingemail.com")
+		// Then the errors returned are ErrUserNotFound
+		assert.Equal(t, voUser.ErrUserNotFound, errID)
+		assert.Equal(t, voUser.ErrUserNotFound, errEmail)
+	})
+	t.Run("CreateNil", func(t *testing.T) {
+		// When creating a nil user
+		err := newRepository(t).Create(context.Background(), nil)
+		// Then the error returned is ErrNilUser
+		assert.Equal(t, voUser.ErrNilUser, err)
+	})
+	t.Run("UniqueIDAndEmail", func(t *testing.T) {
+		// Given a repository with a user
+		userRepo := newRepository(t)
+		assert.Nil(t, userRepo.Create(context.Background(), entity.NewUser(1, "Juana", "juana@amazingemail.com")))
+		// When creating a user with the same ID and another one with the same email
+		errID := userRepo.Create(context.Background(), entity.NewUser(1, "Pedro", "pedro@amazingemail.com"))
+		errEmail := userRepo.Create(context.Background(), entity.NewUser(2, "Pedro", "juana@amazingemail.com"))
+		// Then the errors returned are unique violations creating the user
+		assert.ErrorIs(t, errID, voUser.ErrCreatingUser)
+		assert.ErrorIs(t, errEmail, voUser.ErrCreatingUser)
+		assert.ErrorIs(t, errID, database.ErrUniqueViolation)
+		assert.ErrorIs(t, errEmail, database.ErrUniqueViolation)
+		// And the stored user does not change
+		stored, _ := userRepo.GetByID(context.Background(), 1)
+		assert.Equal(t, "Juana", stored.Name)
+		_, err := userRepo.GetByID(context.Background(), 2)
+		assert.Equal(t, voUser.ErrUserNotFound, err)
+	})
+	t.Run("Update", func(t *testing.T) {
+		// Given a repository with two users
+		userRepo := newRepository(t)
+		assert.Nil(t, userRepo.Create(context.Background(), entity.NewUser(1, "Juana", "juana@amazingemail.com")))
+		assert.Nil(t, userRepo.Create(context.Background(), entity.NewUser(2, "Pedro", "pedro@amazingemail.com")))
+		// When updating the first one
+		updated := &entity.User{ID: 1, Name: "Juana María", Email: "juana.maria@amazingemail.com", Locale: "en"}
+		err := userRepo.Update(context.Background(), updated)
+		// Then it is returned updated
+		assert.Nil(t, err)
+		stored, _ := userRepo.GetByID(context.Background(), 1)
+		assert.Equal(t, updated, stored)
+		// And updating its email to the one of the second user fails
+		err = userRepo.Update(context.Background(), &entity.User{ID: 1, Name: "Juana María", Email: "pedro@amazingemail.com", Locale: "en"})
+		assert.ErrorIs(t, err, voUser.ErrUpdatingUser)
+		assert.ErrorIs(t, err, database.ErrUniqueViolation)
+	})
+	t.Run("UpdateNil", func(t *testing.T) {
+		// When updating a nil user
+		err := newRepository(t).Update(context.Background(), nil)
+		// Then the error returned is ErrNilUser
+		assert.Equal(t, voUser.ErrNilUser, err)
+	})
+}
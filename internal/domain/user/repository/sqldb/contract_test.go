@@ -0,0 +1,20 @@
+package sqldb_test
+
+import (
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository/repositorytest"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository/sqldb"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database/databasetest"
+)
+
+// TestUserRepositoryContract tests the repository against the UserRepository contract on
+// every dialect.
+func TestUserRepositoryContract(t *testing.T) {
+	databasetest.Run(t, func(t *testing.T, newDatabase databasetest.Factory) {
+		repositorytest.RunUserRepositoryContract(t, func(t *testing.T) repository.UserRepository {
+			return sqldb.NewSQLUserRepository(newDatabase(t))
+		})
+	})
+}
@@ -0,0 +1,171 @@
+package sqldb
+
+import (
+	"context"
+	"log"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	userErrors "github.com/braejan/go-transactions-summary/internal/valueobject/user"
+)
+
+// sqlUserRepository struct implements the UserRepository interface using
+// a PostgreSQL or a SQLite database.
+type sqlUserRepository struct {
+	baseDB database.Database
+	repository.UserRepository
+}
+
+// NewSQLUserRepository creates a new instance of sqlUserRepository.
+func NewSQLUserRepository(baseDB database.Database) (userRepo repository.UserRepository) {
+	userRepo = &sqlUserRepository{
+		baseDB: baseDB,
+	}
+	return
+}
+
+// GetByID returns a user by its ID.
+const (
+	getUserByID = `SELECT id, name, email, locale FROM users WHERE id = $1`
+)
+
+func (sqlRepo *sqlUserRepository) GetByID(ctx context.Context, ID int64) (user *entity.User, err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	tx, err := sqlRepo.baseDB.BeginTx(db)
+	log.Println("sqlRepo.baseDB.BeginTx(db)", err)
+	defer sqlRepo.baseDB.Rollback(tx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	rows, err := database.Query(ctx, sqlRepo.baseDB, tx, getUserByID, ID)
+	if err != nil {
+		err = database.Wrap(userErrors.ErrQueryingUserByID, err)
+		return
+	}
+	user = &entity.User{}
+	if rows.Next() {
+		err = rows.Scan(&user.ID, &user.Name, &user.Email, &user.Locale)
+		if err != nil {
+			log.Printf("error scanning user row: %v", err)
+			user = nil
+			err = database.Wrap(userErrors.ErrScanningUserByID, err)
+			return
+		}
+	}
+	if user.ID == 0 && user.Name == "" && user.Email == "" {
+		user = nil
+		err = userErrors.ErrUserNotFound
+		return
+	}
+	return
+}
+
+// GetByEmail returns a user by its email.
+const (
+	getUserByEmail = `SELECT id, name, email, locale FROM users WHERE email = $1`
+)
+
+func (sqlRepo *sqlUserRepository) GetByEmail(ctx context.Context, email string) (user *entity.User, err error) {
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	tx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(tx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	rows, err := database.Query(ctx, sqlRepo.baseDB, tx, getUserByEmail, email)
+	if err != nil {
+		err = database.Wrap(userErrors.ErrQueryingUserByEmail, err)
+		return
+	}
+	user = &entity.User{}
+	if rows.Next() {
+		err = rows.Scan(&user.ID, &user.Name, &user.Email, &user.Locale)
+		if err != nil {
+			user = nil
+			err = database.Wrap(userErrors.ErrScanningUserByEmail, err)
+			return
+		}
+	}
+	if user.ID == 0 && user.Name == "" && user.Email == "" {
+		user = nil
+		err = userErrors.ErrUserNotFound
+	}
+	return
+}
+
+// Create creates a new user.
+const (
+	createUser = `INSERT INTO users (id, name, email, locale) VALUES ($1, $2, $3, $4)`
+)
+
+func (sqlRepo *sqlUserRepository) Create(ctx context.Context, user *entity.User) (err error) {
+	if user == nil {
+		err = userErrors.ErrNilUser
+		return
+	}
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	tx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(tx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	_, err = database.Exec(ctx, sqlRepo.baseDB, tx, createUser, user.ID, user.Name, user.Email, user.Locale)
+	if err != nil {
+		_ = sqlRepo.baseDB.Rollback(tx)
+		err = database.Wrap(userErrors.ErrCreatingUser, err)
+		return
+	}
+	err = sqlRepo.baseDB.Commit(tx)
+	return
+}
+
+// Update updates a user.
+const (
+	updateUser = `UPDATE users SET name = $1, email = $2, locale = $3 WHERE id = $4`
+)
+
+func (sqlRepo *sqlUserRepository) Update(ctx context.Context, user *entity.User) (err error) {
+	if user == nil {
+		err = userErrors.ErrNilUser
+		return
+	}
+	db, err := sqlRepo.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer sqlRepo.baseDB.Close(db)
+	tx, err := sqlRepo.baseDB.BeginTx(db)
+	defer sqlRepo.baseDB.Rollback(tx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	_, err = database.Exec(ctx, sqlRepo.baseDB, tx, updateUser, user.Name, user.Email, user.Locale, user.ID)
+	if err != nil {
+		_ = sqlRepo.baseDB.Rollback(tx)
+		err = database.Wrap(userErrors.ErrUpdatingUser, err)
+		return
+	}
+	err = sqlRepo.baseDB.Commit(tx)
+	return
+}
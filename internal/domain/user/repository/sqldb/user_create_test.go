@@ -1,4 +1,4 @@
-package postgres_test
+package sqldb_test
 
 import (
 	"context"
@@ -6,7 +6,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
-	"github.com/braejan/go-transactions-summary/internal/domain/user/repository/postgres"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository/sqldb"
 	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
 	mockvoPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres/mock"
 	"github.com/braejan/go-transactions-summary/internal/valueobject/user"
@@ -18,9 +18,9 @@ import (
 func TestCreateErrUserNil(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// When creating a user.
-	err := userRepo.Create(nil)
+	err := userRepo.Create(context.Background(), nil)
 	// Then the error returned is ErrNilUser.
 	assert.NotNil(t, err)
 	assert.Equal(t, user.ErrNilUser, err)
@@ -30,16 +30,16 @@ func TestCreateErrUserNil(t *testing.T) {
 func TestCreateErrOpeningDatabase(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// And a valid user entity.
 	user := entity.NewUser(int64(1), "John Doe", "john.doe@amazingemail.com")
 	// And a mocked response when calling Open.
 	dbBase.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
 	// When creating a user.
-	err := userRepo.Create(user)
+	err := userRepo.Create(context.Background(), user)
 	// Then the error returned is ErrOpeningDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 
 }
 
@@ -47,7 +47,7 @@ func TestCreateErrOpeningDatabase(t *testing.T) {
 func TestCreateErrBeginningTransaction(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// And a valid user entity.
 	user := entity.NewUser(int64(1), "John Doe", "john.doe@amazingemail.com")
 	// And a mocked database.
@@ -61,17 +61,17 @@ func TestCreateErrBeginningTransaction(t *testing.T) {
 	// And a mocked response when calling Close.
 	dbBase.On("Close", db).Return(nil)
 	// When creating a user.
-	err := userRepo.Create(user)
+	err := userRepo.Create(context.Background(), user)
 	// Then the error returned is ErrBeginningTransaction.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestCreateErrExec tests the error returned when executing the query.
 func TestCreateErrExec(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// And a valid user entity.
 	userToTest := entity.NewUser(int64(1), "John Doe", "john.doe@amazingemail.com")
 	// And a mocked database.
@@ -86,22 +86,22 @@ func TestCreateErrExec(t *testing.T) {
 	dbBase.On("Rollback", mock.Anything).Return(nil)
 	mockedDB.ExpectBegin()
 	// And a mocked response when calling Exec.
-	dbBase.On("Exec", tx, "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)", []interface{}{int64(1), "John Doe", "john.doe@amazingemail.com"}).Return(nil, voPostgres.ErrExec)
+	dbBase.On("Exec", tx, "INSERT INTO users (id, name, email, locale) VALUES ($1, $2, $3, $4)", []interface{}{int64(1), "John Doe", "john.doe@amazingemail.com", "es"}).Return(nil, voPostgres.ErrExec)
 	mockedDB.ExpectRollback()
 	// And a mocked response when calling Close.
 	dbBase.On("Close", db).Return(nil)
 	// When creating a user.
-	err := userRepo.Create(userToTest)
+	err := userRepo.Create(context.Background(), userToTest)
 	// Then the error returned is ErrExec.
 	assert.NotNil(t, err)
-	assert.Equal(t, user.ErrCreatingUser, err)
+	assert.ErrorIs(t, err, user.ErrCreatingUser)
 }
 
 // TestCreateSuccess tests the success of creating a user.
 func TestCreateSuccess(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// And a valid user entity.
 	userToTest := entity.NewUser(int64(1), "John Doe", "john.doe@amazingemail.com")
 	// And a mocked database.
@@ -116,14 +116,14 @@ func TestCreateSuccess(t *testing.T) {
 	dbBase.On("Rollback", mock.Anything).Return(nil)
 	mockedDB.ExpectBegin()
 	// And a mocked response when calling Exec.
-	dbBase.On("Exec", tx, "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)", []interface{}{int64(1), "John Doe", "john.doe@amazingemail.com"}).Return(nil, nil)
+	dbBase.On("Exec", tx, "INSERT INTO users (id, name, email, locale) VALUES ($1, $2, $3, $4)", []interface{}{int64(1), "John Doe", "john.doe@amazingemail.com", "es"}).Return(nil, nil)
 	// And a mocked response when calling Commit.
 	dbBase.On("Commit", tx).Return(nil)
 	mockedDB.ExpectCommit()
 	// And a mocked response when calling Close.
 	dbBase.On("Close", db).Return(nil)
 	// When creating a user.
-	err := userRepo.Create(userToTest)
+	err := userRepo.Create(context.Background(), userToTest)
 	// Then the error returned is nil.
 	assert.Nil(t, err)
 }
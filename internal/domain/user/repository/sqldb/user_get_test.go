@@ -1,4 +1,4 @@
-package postgres_test
+package sqldb_test
 
 import (
 	"context"
@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/braejan/go-transactions-summary/internal/domain/user/repository/postgres"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository/sqldb"
 	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
 	mockvoPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres/mock"
 	"github.com/braejan/go-transactions-summary/internal/valueobject/user"
@@ -19,16 +19,16 @@ import (
 func TestGetByIDErrorOpeningDatabase(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// And a valid user ID.
 	ID := int64(1)
 	// And a mocked response when calling Open.
 	dbBase.On("Open").Return(nil, errors.New("postgres: error opening database"))
 	// When GetByID is called.
-	_, err := userRepo.GetByID(ID)
+	_, err := userRepo.GetByID(context.Background(), ID)
 	// Then the error returned should be ErrOpeningDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestGetByIDErrorBeginningTransaction tests the GetByID function
@@ -36,7 +36,7 @@ func TestGetByIDErrorOpeningDatabase(t *testing.T) {
 func TestGetByIDErrorBeginningTransaction(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// And a valid user ID.
 	ID := int64(1)
 	// And a mocked database.
@@ -51,10 +51,10 @@ func TestGetByIDErrorBeginningTransaction(t *testing.T) {
 	// And a mocked response when calling Close.
 	dbBase.On("Close", db).Return(nil)
 	// When GetByID is called.
-	_, err := userRepo.GetByID(ID)
+	_, err := userRepo.GetByID(context.Background(), ID)
 	// Then the error returned should be ErrBeginningTransaction.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 	assert.True(t, dbBase.AssertExpectations(t))
 }
 
@@ -63,7 +63,7 @@ func TestGetByIDErrorBeginningTransaction(t *testing.T) {
 func TestGetByIDErrorQuerying(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// And a valid user ID.
 	ID := int64(1)
 	// And a mocked database.
@@ -79,11 +79,11 @@ func TestGetByIDErrorQuerying(t *testing.T) {
 	// And a mocked response when calling Close.
 	dbBase.On("Close", db).Return(nil)
 	// And a mocked response when calling Query.
-	dbBase.On("Query", tx, "SELECT id, name, email FROM users WHERE id = $1", []interface{}{ID}).Return(nil, errors.New("postgres: error querying"))
-	_, err := userRepo.GetByID(ID)
+	dbBase.On("Query", tx, "SELECT id, name, email, locale FROM users WHERE id = $1", []interface{}{ID}).Return(nil, errors.New("postgres: error querying"))
+	_, err := userRepo.GetByID(context.Background(), ID)
 	// Then the error returned should be ErrQuerying.
 	assert.NotNil(t, err)
-	assert.Equal(t, user.ErrQueryingUserByID, err)
+	assert.ErrorIs(t, err, user.ErrQueryingUserByID)
 	assert.True(t, dbBase.AssertExpectations(t))
 }
 
@@ -113,16 +113,16 @@ func TestGetByIDErrorScanning(t *testing.T) {
 	// And a mocked response when calling Query.
 	expected := sqlmock.NewRows([]string{"column1", "column2", "column3"}).AddRow(true, false, false)
 	dbMocked.ExpectQuery("SELECT (.+) FROM users WHERE id = (.+)").WithArgs(ID).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, name, email FROM users WHERE id = $1", ID)
+	rows, err := dbBase.Query(tx, "SELECT id, name, email, locale FROM users WHERE id = $1", ID)
 	assert.Nil(t, err)
-	dbBaseMocked.On("Query", tx, "SELECT id, name, email FROM users WHERE id = $1", []interface{}{ID}).Return(rows, nil)
+	dbBaseMocked.On("Query", tx, "SELECT id, name, email, locale FROM users WHERE id = $1", []interface{}{ID}).Return(rows, nil)
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBaseMocked)
+	userRepo := sqldb.NewSQLUserRepository(dbBaseMocked)
 	// When GetByID is called.
-	_, err = userRepo.GetByID(ID)
+	_, err = userRepo.GetByID(context.Background(), ID)
 	// Then the error returned should be ErrScanningUser.
 	assert.NotNil(t, err)
-	assert.Equal(t, user.ErrScanningUserByID, err)
+	assert.ErrorIs(t, err, user.ErrScanningUserByID)
 }
 
 // TestGetByIDSucess tests the GetByID function
@@ -149,21 +149,22 @@ func TestGetByIDSuccess(t *testing.T) {
 	// And a mocked response when calling Close.
 	dbBaseMocked.On("Close", db).Return(nil)
 	// And a mocked response when calling Query.
-	expected := sqlmock.NewRows([]string{"id", "name", "email"}).AddRow(ID, "John Doe", "john.doe@amazingemail.com")
+	expected := sqlmock.NewRows([]string{"id", "name", "email", "locale"}).AddRow(ID, "John Doe", "john.doe@amazingemail.com", "es")
 	dbMocked.ExpectQuery("SELECT (.+) FROM users WHERE id = (.+)").WithArgs(ID).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, name, email FROM users WHERE id = $1", ID)
+	rows, err := dbBase.Query(tx, "SELECT id, name, email, locale FROM users WHERE id = $1", ID)
 	assert.Nil(t, err)
-	dbBaseMocked.On("Query", tx, "SELECT id, name, email FROM users WHERE id = $1", []interface{}{ID}).Return(rows, nil)
+	dbBaseMocked.On("Query", tx, "SELECT id, name, email, locale FROM users WHERE id = $1", []interface{}{ID}).Return(rows, nil)
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBaseMocked)
+	userRepo := sqldb.NewSQLUserRepository(dbBaseMocked)
 	// When GetByID is called.
-	user, err := userRepo.GetByID(ID)
+	user, err := userRepo.GetByID(context.Background(), ID)
 	// Then the error returned should be nil.
 	assert.Nil(t, err)
 	// And the user returned should be the expected one.
 	assert.Equal(t, ID, user.ID)
 	assert.Equal(t, "John Doe", user.Name)
 	assert.Equal(t, "john.doe@amazingemail.com", user.Email)
+	assert.Equal(t, "es", user.Locale)
 }
 
 // TestGetByIDErrEmptyResponse results in an error when the user is not found.
@@ -189,15 +190,15 @@ func TestGetByIDErrEmptyResponse(t *testing.T) {
 	// And a mocked response when calling Close.
 	dbBaseMocked.On("Close", db).Return(nil)
 	// And a mocked response when calling Query.
-	expected := sqlmock.NewRows([]string{"id", "name", "email"})
+	expected := sqlmock.NewRows([]string{"id", "name", "email", "locale"})
 	dbMocked.ExpectQuery("SELECT (.+) FROM users WHERE id = (.+)").WithArgs(ID).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, name, email FROM users WHERE id = $1", ID)
+	rows, err := dbBase.Query(tx, "SELECT id, name, email, locale FROM users WHERE id = $1", ID)
 	assert.Nil(t, err)
-	dbBaseMocked.On("Query", tx, "SELECT id, name, email FROM users WHERE id = $1", []interface{}{ID}).Return(rows, nil)
+	dbBaseMocked.On("Query", tx, "SELECT id, name, email, locale FROM users WHERE id = $1", []interface{}{ID}).Return(rows, nil)
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBaseMocked)
+	userRepo := sqldb.NewSQLUserRepository(dbBaseMocked)
 	// When GetByID is called.
-	_, err = userRepo.GetByID(ID)
+	_, err = userRepo.GetByID(context.Background(), ID)
 	// Then the error returned should be ErrEmpty.
 	assert.NotNil(t, err)
 	assert.Equal(t, user.ErrUserNotFound, err)
@@ -211,12 +212,12 @@ func TestGetByEmailErrorOpeningDatabase(t *testing.T) {
 	// And a mocked response when calling Open.
 	dbBaseMocked.On("Open").Return(nil, errors.New("error opening database"))
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBaseMocked)
+	userRepo := sqldb.NewSQLUserRepository(dbBaseMocked)
 	// When GetByEmail is called.
-	_, err := userRepo.GetByEmail(email)
+	_, err := userRepo.GetByEmail(context.Background(), email)
 	// Then the error returned should be ErrOpeningDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestGetByEmailErrorBeginningTransaction results in an error when the transaction cannot be started.
@@ -236,19 +237,19 @@ func TestGetByEmailErrorBeginningTransaction(t *testing.T) {
 	// And a mocked response when calling Close.
 	dbBaseMocked.On("Close", db).Return(nil)
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBaseMocked)
+	userRepo := sqldb.NewSQLUserRepository(dbBaseMocked)
 	// When GetByEmail is called.
-	_, err := userRepo.GetByEmail(email)
+	_, err := userRepo.GetByEmail(context.Background(), email)
 	// Then the error returned should be ErrBeginningTransaction.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestGetByEmailErrorQuerying results in an error when the query cannot be executed.
 func TestGetByEmailErrorQuerying(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// And a valid email.
 	email := "john.doe@amazingemail.com"
 	// And a mocked database.
@@ -264,11 +265,11 @@ func TestGetByEmailErrorQuerying(t *testing.T) {
 	// And a mocked response when calling Close.
 	dbBase.On("Close", db).Return(nil)
 	// And a mocked response when calling Query.
-	dbBase.On("Query", tx, "SELECT id, name, email FROM users WHERE email = $1", []interface{}{email}).Return(nil, errors.New("postgres: error querying"))
-	_, err := userRepo.GetByEmail(email)
+	dbBase.On("Query", tx, "SELECT id, name, email, locale FROM users WHERE email = $1", []interface{}{email}).Return(nil, errors.New("postgres: error querying"))
+	_, err := userRepo.GetByEmail(context.Background(), email)
 	// Then the error returned should be ErrQuerying.
 	assert.NotNil(t, err)
-	assert.Equal(t, user.ErrQueryingUserByEmail, err)
+	assert.ErrorIs(t, err, user.ErrQueryingUserByEmail)
 	assert.True(t, dbBase.AssertExpectations(t))
 }
 
@@ -297,16 +298,16 @@ func TestGetByEmailErrorScanning(t *testing.T) {
 	// And a mocked response when calling Query.
 	expected := sqlmock.NewRows([]string{"column1", "column2", "column3"}).AddRow(true, false, false)
 	dbMocked.ExpectQuery("SELECT (.+) FROM users WHERE email = (.+)").WithArgs(email).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, name, email FROM users WHERE email = $1", email)
+	rows, err := dbBase.Query(tx, "SELECT id, name, email, locale FROM users WHERE email = $1", email)
 	assert.Nil(t, err)
-	dbBaseMocked.On("Query", tx, "SELECT id, name, email FROM users WHERE email = $1", []interface{}{email}).Return(rows, nil)
+	dbBaseMocked.On("Query", tx, "SELECT id, name, email, locale FROM users WHERE email = $1", []interface{}{email}).Return(rows, nil)
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBaseMocked)
+	userRepo := sqldb.NewSQLUserRepository(dbBaseMocked)
 	// When GetByEmail is called.
-	_, err = userRepo.GetByEmail(email)
+	_, err = userRepo.GetByEmail(context.Background(), email)
 	// Then the error returned should be ErrScanningUser.
 	assert.NotNil(t, err)
-	assert.Equal(t, user.ErrScanningUserByEmail, err)
+	assert.ErrorIs(t, err, user.ErrScanningUserByEmail)
 }
 
 // TestGetByEmailSuccess results in a user when the query is successful.
@@ -332,15 +333,15 @@ func TestGetByEmailSuccess(t *testing.T) {
 	// And a mocked response when calling Close.
 	dbBaseMocked.On("Close", db).Return(nil)
 	// And a mocked response when calling Query.
-	expected := sqlmock.NewRows([]string{"id", "name", "email"}).AddRow(1, "John Doe", email)
+	expected := sqlmock.NewRows([]string{"id", "name", "email", "locale"}).AddRow(1, "John Doe", email, "en")
 	dbMocked.ExpectQuery("SELECT (.+) FROM users WHERE email = (.+)").WithArgs(email).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, name, email FROM users WHERE email = $1", email)
+	rows, err := dbBase.Query(tx, "SELECT id, name, email, locale FROM users WHERE email = $1", email)
 	assert.Nil(t, err)
-	dbBaseMocked.On("Query", tx, "SELECT id, name, email FROM users WHERE email = $1", []interface{}{email}).Return(rows, nil)
+	dbBaseMocked.On("Query", tx, "SELECT id, name, email, locale FROM users WHERE email = $1", []interface{}{email}).Return(rows, nil)
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBaseMocked)
+	userRepo := sqldb.NewSQLUserRepository(dbBaseMocked)
 	// When GetByEmail is called.
-	user, err := userRepo.GetByEmail(email)
+	user, err := userRepo.GetByEmail(context.Background(), email)
 	// Then the error returned should be nil.
 	assert.Nil(t, err)
 	// And the user returned should be valid.
@@ -348,6 +349,7 @@ func TestGetByEmailSuccess(t *testing.T) {
 	assert.Equal(t, int64(1), user.ID)
 	assert.Equal(t, "John Doe", user.Name)
 	assert.Equal(t, email, user.Email)
+	assert.Equal(t, "en", user.Locale)
 }
 
 // TestGetByEmailErrEmptyResponse results in an error when the email is empty.
@@ -373,15 +375,15 @@ func TestGetByEmailErrEmptyResponse(t *testing.T) {
 	// And a mocked response when calling Close.
 	dbBaseMocked.On("Close", db).Return(nil)
 	// And a mocked response when calling Query.
-	expected := sqlmock.NewRows([]string{"id", "name", "email"})
+	expected := sqlmock.NewRows([]string{"id", "name", "email", "locale"})
 	dbMocked.ExpectQuery("SELECT (.+) FROM users WHERE email = (.+)").WithArgs(email).WillReturnRows(expected)
-	rows, err := dbBase.Query(tx, "SELECT id, name, email FROM users WHERE email = $1", email)
+	rows, err := dbBase.Query(tx, "SELECT id, name, email, locale FROM users WHERE email = $1", email)
 	assert.Nil(t, err)
-	dbBaseMocked.On("Query", tx, "SELECT id, name, email FROM users WHERE email = $1", []interface{}{email}).Return(rows, nil)
+	dbBaseMocked.On("Query", tx, "SELECT id, name, email, locale FROM users WHERE email = $1", []interface{}{email}).Return(rows, nil)
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBaseMocked)
+	userRepo := sqldb.NewSQLUserRepository(dbBaseMocked)
 	// When GetByEmail is called.
-	_, err = userRepo.GetByEmail(email)
+	_, err = userRepo.GetByEmail(context.Background(), email)
 	// Then the error returned should be ErrEmpty.
 	assert.NotNil(t, err)
 	assert.Equal(t, user.ErrUserNotFound, err)
@@ -1,4 +1,4 @@
-package postgres_test
+package sqldb_test
 
 import (
 	"context"
@@ -6,7 +6,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
-	"github.com/braejan/go-transactions-summary/internal/domain/user/repository/postgres"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository/sqldb"
 	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
 	mockvoPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres/mock"
 	"github.com/braejan/go-transactions-summary/internal/valueobject/user"
@@ -18,9 +18,9 @@ import (
 func TestUpdateErrUserNil(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// When updating a user.
-	err := userRepo.Update(nil)
+	err := userRepo.Update(context.Background(), nil)
 	// Then the error returned is ErrNilUser.
 	assert.NotNil(t, err)
 	assert.Equal(t, user.ErrNilUser, err)
@@ -30,23 +30,23 @@ func TestUpdateErrUserNil(t *testing.T) {
 func TestUpdateErrOpeningDatabase(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// And a valid user entity.
 	user := entity.NewUser(int64(1), "John Doe", "john.doe@amazingemail.com")
 	// And a mocked response when calling Open.
 	dbBase.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
 	// When updating a user.
-	err := userRepo.Update(user)
+	err := userRepo.Update(context.Background(), user)
 	// Then the error returned is ErrOpeningDatabase.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrOpeningDatabase, err)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
 }
 
 // TestUpdateErrBeginningTransaction tests the error returned when beginning the transaction.
 func TestUpdateErrBeginningTransaction(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// And a valid user entity.
 	userToTest := entity.NewUser(int64(1), "John Doe", "john.doe@amazingemail.com")
 	// And a mocked database.
@@ -61,17 +61,17 @@ func TestUpdateErrBeginningTransaction(t *testing.T) {
 	// And a mocked response when calling Rollback.
 	dbBase.On("Rollback", mock.Anything).Return(nil)
 	// When updating a user.
-	err := userRepo.Update(userToTest)
+	err := userRepo.Update(context.Background(), userToTest)
 	// Then the error returned is ErrBeginningTransaction.
 	assert.NotNil(t, err)
-	assert.Equal(t, voPostgres.ErrBeginningTransaction, err)
+	assert.ErrorIs(t, err, voPostgres.ErrBeginningTransaction)
 }
 
 // TestUpdateErrUpdatingUser tests the error returned when updating the user.
 func TestUpdateErrUpdatingUser(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// And a valid user entity.
 	userToTest := entity.NewUser(int64(1), "John Doe", "john.doe@amazingemail.com")
 	// And a mocked database.
@@ -87,22 +87,22 @@ func TestUpdateErrUpdatingUser(t *testing.T) {
 	dbBase.On("BeginTx", db).Return(tx, nil)
 	dbMocked.ExpectBegin()
 	// And a mocked response when calling Exec.
-	dbBase.On("Exec", tx, "UPDATE users SET name = $1, email = $2 WHERE id = $3", []interface{}{"John Doe", "john.doe@amazingemail.com", int64(1)}).Return(nil, user.ErrUpdatingUser)
+	dbBase.On("Exec", tx, "UPDATE users SET name = $1, email = $2, locale = $3 WHERE id = $4", []interface{}{"John Doe", "john.doe@amazingemail.com", "es", int64(1)}).Return(nil, user.ErrUpdatingUser)
 	// And a mocked response when calling Rollback.
 	dbBase.On("Rollback", mock.Anything).Return(nil)
 	dbMocked.ExpectRollback()
 	// When updating a user.
-	err := userRepo.Update(userToTest)
+	err := userRepo.Update(context.Background(), userToTest)
 	// Then the error returned is ErrUpdatingUser.
 	assert.NotNil(t, err)
-	assert.Equal(t, user.ErrUpdatingUser, err)
+	assert.ErrorIs(t, err, user.ErrUpdatingUser)
 }
 
 // TestUpdateSuccess tests the success of updating a user.
 func TestUpdateSuccess(t *testing.T) {
 	dbBase := mockvoPostgres.NewMockBasePostgresDatabase()
 	// And a valid user repository.
-	userRepo := postgres.NewPostgresUserRepository(dbBase)
+	userRepo := sqldb.NewSQLUserRepository(dbBase)
 	// And a valid user entity.
 	userToTest := entity.NewUser(int64(1), "John Doe", "john.doe@amazingemail.com")
 	// And a mocked database.
@@ -120,12 +120,12 @@ func TestUpdateSuccess(t *testing.T) {
 	dbBase.On("Rollback", mock.Anything).Return(nil)
 	dbMocked.ExpectBegin()
 	// And a mocked response when calling Update.
-	dbBase.On("Exec", tx, "UPDATE users SET name = $1, email = $2 WHERE id = $3", []interface{}{"John Doe", "john.doe@amazingemail.com", int64(1)}).Return(nil, nil)
+	dbBase.On("Exec", tx, "UPDATE users SET name = $1, email = $2, locale = $3 WHERE id = $4", []interface{}{"John Doe", "john.doe@amazingemail.com", "es", int64(1)}).Return(nil, nil)
 	// And a mocked response when calling Commit.
 	dbBase.On("Commit", tx).Return(nil)
 	dbMocked.ExpectCommit()
 	// When updating a user.
-	err := userRepo.Update(userToTest)
+	err := userRepo.Update(context.Background(), userToTest)
 	// Then the error returned is nil.
 	assert.Nil(t, err)
 }
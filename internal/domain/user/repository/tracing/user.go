@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/repository"
+	voTracing "github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+)
+
+// tracingUserRepository struct implements the UserRepository interface starting a span for every
+// method of the decorated repository.
+type tracingUserRepository struct {
+	userRepo repository.UserRepository
+}
+
+// NewTracingUserRepository returns the repository decorated with the spans of its methods, children
+// of the span carried by their context.
+func NewTracingUserRepository(userRepo repository.UserRepository) (decorated repository.UserRepository) {
+	decorated = &tracingUserRepository{
+		userRepo: userRepo,
+	}
+	return
+}
+
+// UserRepository interface implementation
+
+func (tracingRepo *tracingUserRepository) GetByID(ctx context.Context, ID int64) (user *entity.User, err error) {
+	ctx, span := voTracing.Start(ctx, "UserRepository.GetByID")
+	defer span.EndWithError(&err)
+	return tracingRepo.userRepo.GetByID(ctx, ID)
+}
+
+func (tracingRepo *tracingUserRepository) GetByEmail(ctx context.Context, email string) (user *entity.User, err error) {
+	ctx, span := voTracing.Start(ctx, "UserRepository.GetByEmail")
+	defer span.EndWithError(&err)
+	return tracingRepo.userRepo.GetByEmail(ctx, email)
+}
+
+func (tracingRepo *tracingUserRepository) Create(ctx context.Context, user *entity.User) (err error) {
+	ctx, span := voTracing.Start(ctx, "UserRepository.Create")
+	defer span.EndWithError(&err)
+	return tracingRepo.userRepo.Create(ctx, user)
+}
+
+func (tracingRepo *tracingUserRepository) Update(ctx context.Context, user *entity.User) (err error) {
+	ctx, span := voTracing.Start(ctx, "UserRepository.Update")
+	defer span.EndWithError(&err)
+	return tracingRepo.userRepo.Update(ctx, user)
+}
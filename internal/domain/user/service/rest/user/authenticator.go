@@ -0,0 +1,59 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+)
+
+// authenticator struct implements the auth.Authenticator interface with the JWT bearer
+// tokens of the end users.
+type authenticator struct {
+	verifier auth.JWTVerifier
+}
+
+// NewAuthenticator returns the authenticator of the JWT of the Authorization header with
+// the Bearer scheme. The subject of the token is the ID of the user.
+func NewAuthenticator(verifier auth.JWTVerifier) (newAuthenticator auth.Authenticator, err error) {
+	if verifier == nil {
+		err = auth.ErrNilJWTVerifier
+		return
+	}
+	newAuthenticator = &authenticator{
+		verifier: verifier,
+	}
+	return
+}
+
+// auth.Authenticator interface implementation
+
+func (authenticator *authenticator) Authenticate(request *http.Request) (principal *auth.Principal, err error) {
+	token := auth.BearerToken(request)
+	// The bearer tokens that are not JWT, like the API keys, are left to other authenticators.
+	if strings.Count(token, ".") != 2 {
+		err = auth.ErrNoCredentials
+		return
+	}
+	claims, err := authenticator.verifier.Verify(token, time.Now())
+	if err != nil {
+		err = fmt.Errorf("%w: %v", auth.ErrUnauthenticated, err)
+		return
+	}
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || userID < 0 {
+		err = fmt.Errorf("%w: subject %q is not a user ID", auth.ErrUnauthenticated, claims.Subject)
+		return
+	}
+	principal = &auth.Principal{
+		ID:     claims.Subject,
+		Name:   "user " + claims.Subject,
+		Scopes: claims.Scopes,
+		IsUser: true,
+		UserID: userID,
+	}
+	return
+}
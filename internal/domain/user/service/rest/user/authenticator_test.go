@@ -0,0 +1,70 @@
+package user_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/user/service/rest/user"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+// verifier returns the claims of its tokens, and ErrInvalidSignature for the others.
+type verifier map[string]auth.Claims
+
+func (tokens verifier) Verify(token string, now time.Time) (auth.Claims, error) {
+	claims, found := tokens[token]
+	if !found {
+		return auth.Claims{}, auth.ErrInvalidSignature
+	}
+	return claims, nil
+}
+
+// TestNewAuthenticatorWithNilVerifier tests the NewAuthenticator function with a nil verifier.
+func TestNewAuthenticatorWithNilVerifier(t *testing.T) {
+	// When calling NewAuthenticator with a nil verifier
+	authenticator, err := user.NewAuthenticator(nil)
+	// Then the error returned is ErrNilJWTVerifier
+	assert.Nil(t, authenticator)
+	assert.Equal(t, auth.ErrNilJWTVerifier, err)
+}
+
+// TestAuthenticate tests the subject of the token is the ID of the user.
+func TestAuthenticate(t *testing.T) {
+	// Given an authenticator with a token of the user 42 and a token of a service
+	authenticator, err := user.NewAuthenticator(verifier{
+		"header.user.signature":    {Subject: "42", Scopes: []string{auth.ScopeTransactionsRead}},
+		"header.service.signature": {Subject: "reporting-service"},
+	})
+	assert.Nil(t, err)
+	authenticate := func(authorization string) (*auth.Principal, error) {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Authorization", authorization)
+		return authenticator.Authenticate(request)
+	}
+	// When authenticating the token of the user
+	principal, err := authenticate("Bearer header.user.signature")
+	// Then the principal is the user with the scopes of the token
+	assert.Nil(t, err)
+	assert.Equal(t, &auth.Principal{
+		ID:     "42",
+		Name:   "user 42",
+		Scopes: []string{auth.ScopeTransactionsRead},
+		IsUser: true,
+		UserID: 42,
+	}, principal)
+	// When authenticating a token whose subject is not a user ID, or an invalid token
+	for _, authorization := range []string{"Bearer header.service.signature", "Bearer header.forged.signature"} {
+		_, err = authenticate(authorization)
+		// Then the error wraps ErrUnauthenticated
+		assert.ErrorIs(t, err, auth.ErrUnauthenticated, authorization)
+	}
+	// When authenticating without token or with an API key
+	for _, authorization := range []string{"", "Bearer tsk_id_secret"} {
+		_, err = authenticate(authorization)
+		// Then the error is ErrNoCredentials
+		assert.Equal(t, auth.ErrNoCredentials, err, authorization)
+	}
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
+	"github.com/braejan/go-transactions-summary/internal/domain/user/usecases"
+	voMetrics "github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+)
+
+// metricsUserUseCases struct implements the UserUseCases interface counting the users
+// created by the decorated use cases.
+type metricsUserUseCases struct {
+	userUseCases usecases.UserUseCases
+	created      *voMetrics.Counter
+}
+
+// NewMetricsUserUseCases returns the use cases decorated with the user metrics of the registry.
+func NewMetricsUserUseCases(userUseCases usecases.UserUseCases, registry *voMetrics.Registry) (decorated usecases.UserUseCases) {
+	decorated = &metricsUserUseCases{
+		userUseCases: userUseCases,
+		created:      registry.Counter("users_created_total", "Users created, the owners of a file that did not exist."),
+	}
+	return
+}
+
+// UserUseCases interface implementation
+
+func (uc *metricsUserUseCases) GetByID(ctx context.Context, ID int64) (user entity.User, err error) {
+	return uc.userUseCases.GetByID(ctx, ID)
+}
+
+func (uc *metricsUserUseCases) GetByEmail(ctx context.Context, email string) (user entity.User, err error) {
+	return uc.userUseCases.GetByEmail(ctx, email)
+}
+
+func (uc *metricsUserUseCases) Create(ctx context.Context, ID int64, name string, email string) (err error) {
+	err = uc.userUseCases.Create(ctx, ID, name, email)
+	if err == nil {
+		uc.created.Inc()
+	}
+	return
+}
+
+func (uc *metricsUserUseCases) Update(ctx context.Context, ID int64, name string, email string) (err error) {
+	return uc.userUseCases.Update(ctx, ID, name, email)
+}
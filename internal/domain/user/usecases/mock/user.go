@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"context"
+
 	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
 	"github.com/stretchr/testify/mock"
 )
@@ -15,13 +17,13 @@ func NewMockUserUseCases() *mockUserUseCases {
 	return &mockUserUseCases{}
 }
 
-// GetByID provides a mock function with given fields: ID
-func (_m *mockUserUseCases) GetByID(ID int64) (user entity.User, err error) {
-	ret := _m.Called(ID)
+// GetByID provides a mock function with given fields: ctx, ID
+func (_m *mockUserUseCases) GetByID(ctx context.Context, ID int64) (user entity.User, err error) {
+	ret := _m.Called(ctx, ID)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(int64) entity.User); ok {
-		r0 = rf(ID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) entity.User); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(entity.User)
@@ -29,8 +31,8 @@ func (_m *mockUserUseCases) GetByID(ID int64) (user entity.User, err error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(ID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, ID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -38,13 +40,13 @@ func (_m *mockUserUseCases) GetByID(ID int64) (user entity.User, err error) {
 	return r0, r1
 }
 
-// GetByEmail provides a mock function with given fields: email
-func (_m *mockUserUseCases) GetByEmail(email string) (user entity.User, err error) {
-	ret := _m.Called(email)
+// GetByEmail provides a mock function with given fields: ctx, email
+func (_m *mockUserUseCases) GetByEmail(ctx context.Context, email string) (user entity.User, err error) {
+	ret := _m.Called(ctx, email)
 
 	var r0 entity.User
-	if rf, ok := ret.Get(0).(func(string) entity.User); ok {
-		r0 = rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.User); ok {
+		r0 = rf(ctx, email)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(entity.User)
@@ -52,8 +54,8 @@ func (_m *mockUserUseCases) GetByEmail(email string) (user entity.User, err erro
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(email)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -61,13 +63,13 @@ func (_m *mockUserUseCases) GetByEmail(email string) (user entity.User, err erro
 	return r0, r1
 }
 
-// Create provides a mock function with given fields: ID, name, email
-func (_m *mockUserUseCases) Create(ID int64, name string, email string) (err error) {
-	ret := _m.Called(ID, name, email)
+// Create provides a mock function with given fields: ctx, ID, name, email
+func (_m *mockUserUseCases) Create(ctx context.Context, ID int64, name string, email string) (err error) {
+	ret := _m.Called(ctx, ID, name, email)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, string, string) error); ok {
-		r0 = rf(ID, name, email)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = rf(ctx, ID, name, email)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -75,13 +77,13 @@ func (_m *mockUserUseCases) Create(ID int64, name string, email string) (err err
 	return r0
 }
 
-// Update provides a mock function with given fields: ID, name, email
-func (_m *mockUserUseCases) Update(ID int64, name string, email string) (err error) {
-	ret := _m.Called(ID, name, email)
+// Update provides a mock function with given fields: ctx, ID, name, email
+func (_m *mockUserUseCases) Update(ctx context.Context, ID int64, name string, email string) (err error) {
+	ret := _m.Called(ctx, ID, name, email)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, string, string) error); ok {
-		r0 = rf(ID, name, email)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = rf(ctx, ID, name, email)
 	} else {
 		r0 = ret.Error(0)
 	}
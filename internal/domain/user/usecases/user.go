@@ -1,6 +1,9 @@
 package usecases
 
 import (
+	"context"
+	"errors"
+
 	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
 	"github.com/braejan/go-transactions-summary/internal/domain/user/repository"
 	"github.com/braejan/go-transactions-summary/internal/valueobject/user"
@@ -26,8 +29,8 @@ func NewUserUseCases(userRepo repository.UserRepository) (usecases UserUseCases,
 }
 
 // GetByID implements the UserUseCases interface method.
-func (u *userUsecases) GetByID(ID int64) (user entity.User, err error) {
-	userAux, err := u.userRepo.GetByID(ID)
+func (u *userUsecases) GetByID(ctx context.Context, ID int64) (user entity.User, err error) {
+	userAux, err := u.userRepo.GetByID(ctx, ID)
 	if err != nil {
 		return
 	}
@@ -36,8 +39,8 @@ func (u *userUsecases) GetByID(ID int64) (user entity.User, err error) {
 }
 
 // GetByEmail implements the UserUseCases interface method.
-func (u *userUsecases) GetByEmail(email string) (user entity.User, err error) {
-	userAux, err := u.userRepo.GetByEmail(email)
+func (u *userUsecases) GetByEmail(ctx context.Context, email string) (user entity.User, err error) {
+	userAux, err := u.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		return
 	}
@@ -46,11 +49,11 @@ func (u *userUsecases) GetByEmail(email string) (user entity.User, err error) {
 }
 
 // Create implements the UserUseCases interface method.
-func (u *userUsecases) Create(ID int64, name string, email string) (err error) {
-	_, err = u.userRepo.GetByID(ID)
-	if err == user.ErrUserNotFound {
+func (u *userUsecases) Create(ctx context.Context, ID int64, name string, email string) (err error) {
+	_, err = u.userRepo.GetByID(ctx, ID)
+	if errors.Is(err, user.ErrUserNotFound) {
 		// The user is not created.
-		err = u.userRepo.Create(entity.NewUser(ID, name, email))
+		err = u.userRepo.Create(ctx, entity.NewUser(ID, name, email))
 	} else if err == nil {
 		// The user is already created.
 		err = user.ErrUserAlreadyCreated
@@ -60,13 +63,16 @@ func (u *userUsecases) Create(ID int64, name string, email string) (err error) {
 }
 
 // Update implements the UserUseCases interface method.
-func (u *userUsecases) Update(ID int64, name string, email string) (err error) {
-	_, err = u.userRepo.GetByID(ID)
+func (u *userUsecases) Update(ctx context.Context, ID int64, name string, email string) (err error) {
+	userAux, err := u.userRepo.GetByID(ctx, ID)
 	if err != nil {
 		// The user is not created.
 		err = user.ErrUserNotFound
 		return
 	}
-	err = u.userRepo.Update(entity.NewUser(ID, name, email))
+	// Keep the fields that are not updated, like the locale.
+	userAux.Name = name
+	userAux.Email = email
+	err = u.userRepo.Update(ctx, userAux)
 	return
 }
@@ -1,14 +1,17 @@
 package usecases_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/braejan/go-transactions-summary/internal/domain/user/entity"
 	"github.com/braejan/go-transactions-summary/internal/domain/user/repository/mock"
 	"github.com/braejan/go-transactions-summary/internal/domain/user/usecases"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/locale"
 	"github.com/braejan/go-transactions-summary/internal/valueobject/user"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 )
 
 // TestNewUserUseCasesWithouUserRepository tests the NewUserUseCases function
@@ -43,9 +46,9 @@ func TestGetByIDWithError(t *testing.T) {
 	// And a user ID
 	ID := int64(1)
 	// And a mocked error calling GetByID
-	mockedUserRepo.On("GetByID", ID).Return(nil, user.ErrUserNotFound)
+	mockedUserRepo.On("GetByID", testifyMock.Anything, ID).Return(nil, user.ErrUserNotFound)
 	// When call GetByID with an invalid ID
-	userTest, err := userUsecases.GetByID(ID)
+	userTest, err := userUsecases.GetByID(context.Background(), ID)
 	// Then get next errors
 	assert.NotNil(t, err)
 	assert.Equal(t, user.ErrUserNotFound, err)
@@ -67,9 +70,9 @@ func TestGetByIDSucess(t *testing.T) {
 	// And a user ID
 	ID := int64(1)
 	// And a mocked entity.User response calling GetByID
-	mockedUserRepo.On("GetByID", ID).Return(&user, nil)
+	mockedUserRepo.On("GetByID", testifyMock.Anything, ID).Return(&user, nil)
 	// When call GetByID with a valid ID
-	user, err := userUsecases.GetByID(ID)
+	user, err := userUsecases.GetByID(context.Background(), ID)
 	// Then get no errors
 	assert.Nil(t, err)
 	assert.NotNil(t, user)
@@ -87,9 +90,9 @@ func TestGetByEmailWithError(t *testing.T) {
 	// And a user email
 	email := "john.doe@amazinemail.com"
 	// And a mocked error calling GetByEmail
-	mockedUserRepo.On("GetByEmail", email).Return(nil, user.ErrUserNotFound)
+	mockedUserRepo.On("GetByEmail", testifyMock.Anything, email).Return(nil, user.ErrUserNotFound)
 	// When call GetByEmail with an invalid email
-	userTest, err := userUsecases.GetByEmail(email)
+	userTest, err := userUsecases.GetByEmail(context.Background(), email)
 	// Then get next errors
 	assert.NotNil(t, err)
 	assert.Equal(t, user.ErrUserNotFound, err)
@@ -111,9 +114,9 @@ func TestGetByEmailSucess(t *testing.T) {
 	// And a user email
 	email := "john.doe@amazinemail.com"
 	// And a mocked entity.User response calling GetByEmail
-	mockedUserRepo.On("GetByEmail", email).Return(&user, nil)
+	mockedUserRepo.On("GetByEmail", testifyMock.Anything, email).Return(&user, nil)
 	// When call GetByEmail with a valid email
-	user, err := userUsecases.GetByEmail(email)
+	user, err := userUsecases.GetByEmail(context.Background(), email)
 	// Then get no errors
 	assert.Nil(t, err)
 	assert.NotNil(t, user)
@@ -136,9 +139,9 @@ func TestCreateWithErrorGetByID(t *testing.T) {
 		Email: "john.doe@amazinemail.com",
 	}
 	// And a mocked entity.User response calling GetByID
-	mockedUserRepo.On("GetByID", ID).Return(userTest, nil)
+	mockedUserRepo.On("GetByID", testifyMock.Anything, ID).Return(userTest, nil)
 	// When call Create with an invalid user
-	err := userUsecases.Create(ID, "John Doe", "john.doe@amazinemail.com")
+	err := userUsecases.Create(context.Background(), ID, "John Doe", "john.doe@amazinemail.com")
 	// Then get next errors
 	assert.NotNil(t, err)
 	assert.Equal(t, user.ErrUserAlreadyCreated, err)
@@ -153,16 +156,17 @@ func TestCreateWithError(t *testing.T) {
 	// And a entity.User
 	ID := int64(1)
 	userTest := &entity.User{
-		ID:    int64(1),
-		Name:  "John Doe",
-		Email: "john.doe@amazinemail.com",
+		ID:     int64(1),
+		Name:   "John Doe",
+		Email:  "john.doe@amazinemail.com",
+		Locale: locale.DefaultTag,
 	}
 	// And a mocked entity.User response calling GetByID
-	mockedUserRepo.On("GetByID", ID).Return(nil, user.ErrUserNotFound)
+	mockedUserRepo.On("GetByID", testifyMock.Anything, ID).Return(nil, user.ErrUserNotFound)
 	// And a mocked error calling Create
-	mockedUserRepo.On("Create", userTest).Return(errors.New("new mocked error"))
+	mockedUserRepo.On("Create", testifyMock.Anything, userTest).Return(errors.New("new mocked error"))
 	// When call Create with an invalid user
-	err := userUsecases.Create(ID, "John Doe", "john.doe@amazinemail.com")
+	err := userUsecases.Create(context.Background(), ID, "John Doe", "john.doe@amazinemail.com")
 	// Then get next errors
 	assert.NotNil(t, err)
 	assert.Equal(t, errors.New("new mocked error"), err)
@@ -177,16 +181,17 @@ func TestCreateSucess(t *testing.T) {
 	// And a entity.User
 	ID := int64(1)
 	userTest := &entity.User{
-		ID:    int64(1),
-		Name:  "John Doe",
-		Email: "john.doe@amazinemail.com",
+		ID:     int64(1),
+		Name:   "John Doe",
+		Email:  "john.doe@amazinemail.com",
+		Locale: locale.DefaultTag,
 	}
 	// And a mocked entity.User response calling GetByID
-	mockedUserRepo.On("GetByID", ID).Return(nil, user.ErrUserNotFound)
+	mockedUserRepo.On("GetByID", testifyMock.Anything, ID).Return(nil, user.ErrUserNotFound)
 	// And a mocked entity.User response calling Create
-	mockedUserRepo.On("Create", userTest).Return(nil)
+	mockedUserRepo.On("Create", testifyMock.Anything, userTest).Return(nil)
 	// When call Create with a valid user
-	err := userUsecases.Create(ID, "John Doe", "john.doe@amazinemail.com")
+	err := userUsecases.Create(context.Background(), ID, "John Doe", "john.doe@amazinemail.com")
 	// Then get no errors
 	assert.Nil(t, err)
 }
@@ -199,9 +204,9 @@ func TestUpdateWithErrorGetByID(t *testing.T) {
 	userUsecases, _ := usecases.NewUserUseCases(mockedUserRepo)
 	ID := int64(1)
 	// And a mocked error response calling GetByID
-	mockedUserRepo.On("GetByID", ID).Return(nil, user.ErrUserNotFound)
+	mockedUserRepo.On("GetByID", testifyMock.Anything, ID).Return(nil, user.ErrUserNotFound)
 	// When call Update with an invalid user
-	err := userUsecases.Update(ID, "John Doe", "john.doe@amazinemail.com")
+	err := userUsecases.Update(context.Background(), ID, "John Doe", "john.doe@amazinemail.com")
 	// Then get next errors
 	assert.NotNil(t, err)
 	assert.Equal(t, user.ErrUserNotFound, err)
@@ -220,11 +225,11 @@ func TestUpdateWithError(t *testing.T) {
 		Name:  "John Doe",
 		Email: "john.doe@amazinemail.com",
 	}
-	mockedUserRepo.On("GetByID", ID).Return(user, nil)
+	mockedUserRepo.On("GetByID", testifyMock.Anything, ID).Return(user, nil)
 	// And a mocked error response calling Update
-	mockedUserRepo.On("Update", user).Return(errors.New("new mocked error"))
+	mockedUserRepo.On("Update", testifyMock.Anything, user).Return(errors.New("new mocked error"))
 	// When call Update with an invalid user
-	err := userUsecases.Update(ID, "John Doe", "john.doe@amazinemail.com")
+	err := userUsecases.Update(context.Background(), ID, "John Doe", "john.doe@amazinemail.com")
 	// Then get next errors
 	assert.NotNil(t, err)
 	assert.Equal(t, errors.New("new mocked error"), err)
@@ -243,11 +248,11 @@ func TestUpdateSucess(t *testing.T) {
 		Name:  "John Doe",
 		Email: "john.doe@amazinemail.com",
 	}
-	mockedUserRepo.On("GetByID", ID).Return(user, nil)
+	mockedUserRepo.On("GetByID", testifyMock.Anything, ID).Return(user, nil)
 	// And a mocked entity.User response calling Update
-	mockedUserRepo.On("Update", user).Return(nil)
+	mockedUserRepo.On("Update", testifyMock.Anything, user).Return(nil)
 	// When call Update with a valid user
-	err := userUsecases.Update(ID, "John Doe", "john.doe@amazinemail.com")
+	err := userUsecases.Update(context.Background(), ID, "John Doe", "john.doe@amazinemail.com")
 	// Then get no errors
 	assert.Nil(t, err)
 }
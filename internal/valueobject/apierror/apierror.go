@@ -0,0 +1,110 @@
+// Package apierror renders the errors of the REST API as a JSON envelope with the stable
+// code and the HTTP status of the domain error in the catalogue.
+package apierror
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror/envelope"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+)
+
+// internalMessage is the message of the errors missing from the catalogue, their text is
+// only logged.
+const internalMessage = "internal error"
+
+// HandlerFunc is an HTTP handler returning the error of the request instead of writing it.
+type HandlerFunc func(writer http.ResponseWriter, request *http.Request) (err error)
+
+// Handler returns the handler writing the error of the handle function, if any, as an
+// envelope. The errors are logged with the request ID, the 5xx ones at error level.
+func Handler(log logger.Logger, handle HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if err := handle(writer, request); err != nil {
+			Write(writer, request, log, err)
+		}
+	}
+}
+
+// Write logs the error and writes its envelope with the code and the status of its entry.
+func Write(writer http.ResponseWriter, request *http.Request, log logger.Logger, err error) {
+	entry := Lookup(err)
+	requestLog := logger.FromContext(request.Context(), log).With("method", request.Method, "path", request.URL.Path)
+	message := internalMessage
+	if entry.Err != nil {
+		message = entry.Err.Error()
+	}
+	if entry.Status >= http.StatusInternalServerError {
+		requestLog.Error("request failed", "code", entry.Code, "error", err)
+	} else {
+		requestLog.Warn("request rejected", "code", entry.Code, "error", err)
+	}
+	envelope.Write(writer, request, entry.Status, entry.Code, message, Details(err))
+}
+
+// detailedError struct defines an error with the details of its envelope.
+type detailedError struct {
+	err     error
+	details interface{}
+}
+
+func (detailed *detailedError) Error() string {
+	return detailed.err.Error()
+}
+
+func (detailed *detailedError) Unwrap() error {
+	return detailed.err
+}
+
+// WithDetails returns the error with the details written in its envelope, such as the
+// report of the invalid lines of a file.
+func WithDetails(err error, details interface{}) error {
+	return &detailedError{err: err, details: details}
+}
+
+// lineDetails struct defines the details of an invalid line of a file.
+type lineDetails struct {
+	Line   int    `json:"line,omitempty"`
+	Column string `json:"column,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
+// conflictDetails struct defines the details of a violated constraint.
+type conflictDetails struct {
+	Constraint string `json:"constraint"`
+}
+
+// Details returns the details of the error: the ones given to WithDetails, the line of an
+// invalid line of a file or the constraint of a conflict. It is nil without details.
+func Details(err error) interface{} {
+	var detailed *detailedError
+	if errors.As(err, &detailed) {
+		return detailed.details
+	}
+	var lineErr *voFile.LineError
+	if errors.As(err, &lineErr) {
+		return lineDetails{Line: lineErr.Line, Column: lineErr.Column, Value: lineErr.Value}
+	}
+	var dbErr *database.Error
+	if errors.As(err, &dbErr) && dbErr.Conflict != nil && dbErr.Constraint != "" {
+		return conflictDetails{Constraint: dbErr.Constraint}
+	}
+	return nil
+}
+
+// NotFoundHandler returns the handler of the paths without route.
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		envelope.Write(writer, request, http.StatusNotFound, envelope.CodeNotFound, "route not found", nil)
+	})
+}
+
+// MethodNotAllowedHandler returns the handler of the methods a route does not serve.
+func MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		envelope.Write(writer, request, http.StatusMethodNotAllowed, envelope.CodeMethodNotAllowed, "method not allowed", nil)
+	})
+}
@@ -0,0 +1,155 @@
+package apierror_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror/envelope"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	voTransaction "github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
+	voUser "github.com/braejan/go-transactions-summary/internal/valueobject/user"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve returns the response and the envelope of the handler returning the error.
+func serve(t *testing.T, log logger.Logger, err error) (recorder *httptest.ResponseRecorder, body envelope.Envelope) {
+	handler := apierror.Handler(log, func(writer http.ResponseWriter, request *http.Request) error {
+		return err
+	})
+	request := httptest.NewRequest(http.MethodGet, "/files", nil)
+	request = request.WithContext(logger.WithRequestID(request.Context(), "request-id"))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	return
+}
+
+// TestHandler tests the error of the handler is written as an envelope with the request ID.
+func TestHandler(t *testing.T) {
+	// Given a logger
+	buffer := &bytes.Buffer{}
+	log := logger.NewJSONLogger(buffer, logger.LevelDebug)
+	// When the handler fails with a wrapped domain error
+	recorder, body := serve(t, log, fmt.Errorf("line 2: %w", voFile.ErrFileDateIsInvalid))
+	// Then the envelope of the error is written
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Equal(t, envelope.Envelope{
+		Code:      "FILE_DATE_INVALID",
+		Message:   voFile.ErrFileDateIsInvalid.Error(),
+		RequestID: "request-id",
+	}, body)
+	// And the error is logged with the request ID
+	assert.Contains(t, buffer.String(), `"request_id":"request-id"`)
+	assert.Contains(t, buffer.String(), "line 2")
+}
+
+// TestHandlerInternalError tests the text of the errors missing from the catalogue is not written.
+func TestHandlerInternalError(t *testing.T) {
+	// Given a logger
+	buffer := &bytes.Buffer{}
+	log := logger.NewJSONLogger(buffer, logger.LevelDebug)
+	// When the handler fails with an unknown error
+	recorder, body := serve(t, log, fmt.Errorf("connection to 10.0.0.1 refused"))
+	// Then an internal error is written without the text of the error
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, "INTERNAL_ERROR", body.Code)
+	assert.Equal(t, "internal error", body.Message)
+	assert.NotContains(t, recorder.Body.String(), "10.0.0.1")
+	// And the error is logged at error level
+	assert.Contains(t, buffer.String(), `"level":"error"`)
+	assert.Contains(t, buffer.String(), "10.0.0.1")
+}
+
+// TestWithDetails tests the details of the error are written in its envelope.
+func TestWithDetails(t *testing.T) {
+	// Given an error with details
+	err := apierror.WithDetails(voFile.ErrFileHasInvalidLines, map[string]int{"invalid_lines": 2})
+	// When the handler fails with the error
+	recorder, body := serve(t, logger.NewJSONLogger(io.Discard, logger.LevelDebug), fmt.Errorf("validating: %w", err))
+	// Then the details are written
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Equal(t, "FILE_HAS_INVALID_LINES", body.Code)
+	assert.Equal(t, map[string]interface{}{"invalid_lines": float64(2)}, body.Details)
+	// And the error still matches its sentinel
+	assert.ErrorIs(t, err, voFile.ErrFileHasInvalidLines)
+	assert.Nil(t, apierror.Details(voFile.ErrFileHasInvalidLines))
+}
+
+// TestTypedErrorDetails tests the fields of the line and conflict errors are the details of
+// their envelope, without their cause.
+func TestTypedErrorDetails(t *testing.T) {
+	cases := []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		details interface{}
+	}{
+		{
+			name:    "invalid line",
+			err:     &voFile.LineError{Line: 3, Column: "Date", Value: "13/45", Err: voFile.ErrFileDateIsInvalid, Cause: errors.New("month out of range")},
+			status:  http.StatusUnprocessableEntity,
+			code:    "FILE_DATE_INVALID",
+			details: map[string]interface{}{"line": float64(3), "column": "Date", "value": "13/45"},
+		},
+		{
+			name:    "unique violation",
+			err:     database.NewConflictError(voUser.ErrCreatingUser, database.ErrUniqueViolation, "users_email_key"),
+			status:  http.StatusConflict,
+			code:    "ALREADY_EXISTS",
+			details: map[string]interface{}{"constraint": "users_email_key"},
+		},
+		{
+			name:   "foreign key violation",
+			err:    database.NewConflictError(voTransaction.ErrCreatingTransaction, database.ErrForeignKeyViolation, ""),
+			status: http.StatusConflict,
+			code:   "REFERENCE_CONFLICT",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// When the handler fails with the error
+			recorder, body := serve(t, logger.NewJSONLogger(io.Discard, logger.LevelDebug), fmt.Errorf("processing: %w", tc.err))
+			// Then its code, its message and its details are written
+			assert.Equal(t, tc.status, recorder.Code)
+			assert.Equal(t, tc.code, body.Code)
+			assert.Equal(t, tc.details, body.Details)
+			assert.NotContains(t, recorder.Body.String(), "month out of range")
+		})
+	}
+}
+
+// TestRouteHandlers tests the requests without route are answered with an envelope.
+func TestRouteHandlers(t *testing.T) {
+	cases := []struct {
+		name    string
+		handler http.Handler
+		status  int
+		code    string
+	}{
+		{name: "not found", handler: apierror.NotFoundHandler(), status: http.StatusNotFound, code: "NOT_FOUND"},
+		{name: "method not allowed", handler: apierror.MethodNotAllowedHandler(), status: http.StatusMethodNotAllowed, code: "METHOD_NOT_ALLOWED"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// When serving a request
+			recorder := httptest.NewRecorder()
+			tc.handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/unknown", nil))
+			// Then the envelope is written
+			var body envelope.Envelope
+			assert.Equal(t, tc.status, recorder.Code)
+			assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+			assert.Equal(t, tc.code, body.Code)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package apierror
+
+import (
+	"errors"
+	"net/http"
+
+	voAccount "github.com/braejan/go-transactions-summary/internal/valueobject/account"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror/envelope"
+	voAPIKey "github.com/braejan/go-transactions-summary/internal/valueobject/apikey"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	voNotification "github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	voProcessing "github.com/braejan/go-transactions-summary/internal/valueobject/processing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	voTransaction "github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
+	voUser "github.com/braejan/go-transactions-summary/internal/valueobject/user"
+)
+
+// Entry struct defines the code and the HTTP status of a domain error.
+type Entry struct {
+	// Err is the sentinel of the error.
+	Err error
+	// Code identifies the error in the responses, it does not change between versions.
+	Code string
+	// Status is the HTTP status of the responses of the error.
+	Status int
+}
+
+// internalEntry is the entry of the errors missing from the catalogue.
+var internalEntry = Entry{Code: envelope.CodeInternal, Status: http.StatusInternalServerError}
+
+// catalogue lists the domain errors a request can fail with. The first entry the error
+// matches is used, so an error wrapping several sentinels gets the most specific one.
+// Several sentinels share a code when the client cannot tell them apart.
+var catalogue = []Entry{
+	{ErrInvalidRequest, envelope.CodeInvalidRequest, http.StatusBadRequest},
+	// Conflicts, before the write errors they are wrapped in
+	{database.ErrUniqueViolation, "ALREADY_EXISTS", http.StatusConflict},
+	{database.ErrForeignKeyViolation, "REFERENCE_CONFLICT", http.StatusConflict},
+	// Files
+	{voFile.ErrFileNotInRequest, "FILE_MISSING", http.StatusBadRequest},
+	{voFile.ErrFileReaderIsEmpty, "FILE_MISSING", http.StatusBadRequest},
+	{voFile.ErrFileIsEmpty, "FILE_EMPTY", http.StatusBadRequest},
+	{voFile.ErrTxFileIsEmpty, "FILE_EMPTY", http.StatusBadRequest},
+	{voFile.ErrFileCouldNotBeRead, "FILE_UNREADABLE", http.StatusBadRequest},
+	{voFile.ErrFileHeaderIsInvalid, "FILE_HEADER_INVALID", http.StatusUnprocessableEntity},
+	{voFile.ErrFileColumnCountIsInvalid, "FILE_COLUMN_COUNT_INVALID", http.StatusUnprocessableEntity},
+	{voFile.ErrFileLineCouldNotBeParsed, "FILE_LINE_UNPARSABLE", http.StatusUnprocessableEntity},
+	{voFile.ErrFileIDIsInvalid, "FILE_ID_INVALID", http.StatusUnprocessableEntity},
+	{voFile.ErrFileDateIsInvalid, "FILE_DATE_INVALID", http.StatusUnprocessableEntity},
+	{voFile.ErrFileAmountIsInvalid, "FILE_AMOUNT_INVALID", http.StatusUnprocessableEntity},
+	{voFile.ErrFileLineIsInvalid, "FILE_LINE_INVALID", http.StatusUnprocessableEntity},
+	{voFile.ErrFileHasInvalidLines, "FILE_HAS_INVALID_LINES", http.StatusUnprocessableEntity},
+	{voFile.ErrFilePathIsEmpty, "FILE_UNAVAILABLE", http.StatusInternalServerError},
+	{voFile.ErrFileCouldNotBeOpened, "FILE_UNAVAILABLE", http.StatusInternalServerError},
+	// Quotas
+	{quota.ErrFileTooLarge, "FILE_TOO_LARGE", http.StatusRequestEntityTooLarge},
+	{quota.ErrTooManyRows, "FILE_TOO_MANY_ROWS", http.StatusRequestEntityTooLarge},
+	{quota.ErrDailyQuotaExceeded, "DAILY_QUOTA_EXCEEDED", http.StatusTooManyRequests},
+	// Authentication
+	{auth.ErrNoCredentials, envelope.CodeUnauthenticated, http.StatusUnauthorized},
+	{auth.ErrUnauthenticated, envelope.CodeUnauthenticated, http.StatusUnauthorized},
+	{auth.ErrForbidden, envelope.CodeForbidden, http.StatusForbidden},
+	{auth.ErrUnknownScope, "SCOPE_UNKNOWN", http.StatusBadRequest},
+	// API keys
+	{voAPIKey.ErrInvalidAPIKey, "API_KEY_INVALID", http.StatusUnauthorized},
+	{voAPIKey.ErrAPIKeyRevoked, "API_KEY_REVOKED", http.StatusUnauthorized},
+	{voAPIKey.ErrAPIKeyNotFound, "API_KEY_NOT_FOUND", http.StatusNotFound},
+	{voAPIKey.ErrEmptyAPIKeyName, "API_KEY_NAME_EMPTY", http.StatusBadRequest},
+	{voAPIKey.ErrEmptyAPIKeyScopes, "API_KEY_SCOPES_EMPTY", http.StatusBadRequest},
+	{voAPIKey.ErrQueryingAPIKey, "API_KEY_QUERY_FAILED", http.StatusInternalServerError},
+	{voAPIKey.ErrScanningAPIKey, "API_KEY_QUERY_FAILED", http.StatusInternalServerError},
+	{voAPIKey.ErrCreatingAPIKey, "API_KEY_WRITE_FAILED", http.StatusInternalServerError},
+	{voAPIKey.ErrRevokingAPIKey, "API_KEY_WRITE_FAILED", http.StatusInternalServerError},
+	// Users
+	{voUser.ErrInvalidUserID, "USER_ID_INVALID", http.StatusBadRequest},
+	{voUser.ErrUserNotFound, "USER_NOT_FOUND", http.StatusNotFound},
+	{voUser.ErrUserAlreadyCreated, "USER_ALREADY_EXISTS", http.StatusConflict},
+	{voUser.ErrQueryingUserByID, "USER_QUERY_FAILED", http.StatusInternalServerError},
+	{voUser.ErrQueryingUserByEmail, "USER_QUERY_FAILED", http.StatusInternalServerError},
+	{voUser.ErrScanningUserByID, "USER_QUERY_FAILED", http.StatusInternalServerError},
+	{voUser.ErrScanningUserByEmail, "USER_QUERY_FAILED", http.StatusInternalServerError},
+	{voUser.ErrScanningUserRow, "USER_QUERY_FAILED", http.StatusInternalServerError},
+	{voUser.ErrCreatingUser, "USER_WRITE_FAILED", http.StatusInternalServerError},
+	{voUser.ErrUpdatingUser, "USER_WRITE_FAILED", http.StatusInternalServerError},
+	// Accounts
+	{voAccount.ErrProcessingAccountID, "ACCOUNT_ID_INVALID", http.StatusBadRequest},
+	{voAccount.ErrAccountNotFound, "ACCOUNT_NOT_FOUND", http.StatusNotFound},
+	{voAccount.ErrAccountAlreadyCreated, "ACCOUNT_ALREADY_EXISTS", http.StatusConflict},
+	{voAccount.ErrQueryingAccountByID, "ACCOUNT_QUERY_FAILED", http.StatusInternalServerError},
+	{voAccount.ErrScanningAccountByID, "ACCOUNT_QUERY_FAILED", http.StatusInternalServerError},
+	{voAccount.ErrQueryingAccountByUserID, "ACCOUNT_QUERY_FAILED", http.StatusInternalServerError},
+	{voAccount.ErrScanningAccountByUserID, "ACCOUNT_QUERY_FAILED", http.StatusInternalServerError},
+	{voAccount.ErrScanningAccount, "ACCOUNT_QUERY_FAILED", http.StatusInternalServerError},
+	{voAccount.ErrCreatingAccount, "ACCOUNT_WRITE_FAILED", http.StatusInternalServerError},
+	{voAccount.ErrUpdatingAccount, "ACCOUNT_WRITE_FAILED", http.StatusInternalServerError},
+	// Transactions
+	{voTransaction.ErrTransactionNotFound, "TRANSACTION_NOT_FOUND", http.StatusNotFound},
+	{voTransaction.ErrTransactionAmountIsZero, "TRANSACTION_AMOUNT_ZERO", http.StatusUnprocessableEntity},
+	{voTransaction.ErrTransactionDateIsInvalid, "TRANSACTION_DATE_INVALID", http.StatusUnprocessableEntity},
+	{voTransaction.ErrTransactionOriginIsEmpty, "TRANSACTION_ORIGIN_EMPTY", http.StatusUnprocessableEntity},
+	{voTransaction.ErrEmptyOrigin, "TRANSACTION_ORIGIN_EMPTY", http.StatusUnprocessableEntity},
+	{voTransaction.ErrQueryingTransactionByID, "TRANSACTION_QUERY_FAILED", http.StatusInternalServerError},
+	{voTransaction.ErrScanningTransactionByID, "TRANSACTION_QUERY_FAILED", http.StatusInternalServerError},
+	{voTransaction.ErrQueryingTransactionsByAccountID, "TRANSACTION_QUERY_FAILED", http.StatusInternalServerError},
+	{voTransaction.ErrScanningTransactionsByAccountID, "TRANSACTION_QUERY_FAILED", http.StatusInternalServerError},
+	{voTransaction.ErrQueryingCreditsByAccountID, "TRANSACTION_QUERY_FAILED", http.StatusInternalServerError},
+	{voTransaction.ErrScanningCreditsByAccountID, "TRANSACTION_QUERY_FAILED", http.StatusInternalServerError},
+	{voTransaction.ErrQueryingDebitsByAccountID, "TRANSACTION_QUERY_FAILED", http.StatusInternalServerError},
+	{voTransaction.ErrScanningDebitsByAccountID, "TRANSACTION_QUERY_FAILED", http.StatusInternalServerError},
+	{voTransaction.ErrQueryingTransactionsByOrigin, "TRANSACTION_QUERY_FAILED", http.StatusInternalServerError},
+	{voTransaction.ErrCreatingTransaction, "TRANSACTION_WRITE_FAILED", http.StatusInternalServerError},
+	// Processing
+	{voProcessing.ErrObjectAlreadyProcessed, "FILE_ALREADY_PROCESSED", http.StatusConflict},
+	{voProcessing.ErrProcessingRecordNotFound, "PROCESSING_RECORD_NOT_FOUND", http.StatusNotFound},
+	{voProcessing.ErrEmptyObjectKey, "OBJECT_KEY_EMPTY", http.StatusBadRequest},
+	{voProcessing.ErrInvalidIngestionMessage, "INGESTION_MESSAGE_INVALID", http.StatusBadRequest},
+	{voProcessing.ErrQueryingProcessingRecord, "PROCESSING_RECORD_FAILED", http.StatusInternalServerError},
+	{voProcessing.ErrScanningProcessingRecord, "PROCESSING_RECORD_FAILED", http.StatusInternalServerError},
+	{voProcessing.ErrSavingProcessingRecord, "PROCESSING_RECORD_FAILED", http.StatusInternalServerError},
+	// Notifications
+	{voNotification.ErrEnqueuingOutboxMessage, "NOTIFICATION_FAILED", http.StatusInternalServerError},
+	{voNotification.ErrTemplateNotFound, "NOTIFICATION_FAILED", http.StatusInternalServerError},
+	{voNotification.ErrRenderingTemplate, "NOTIFICATION_FAILED", http.StatusInternalServerError},
+	// Storage
+	{storage.ErrObjectNotFound, "OBJECT_NOT_FOUND", http.StatusNotFound},
+	{storage.ErrInvalidKey, "OBJECT_KEY_INVALID", http.StatusBadRequest},
+	{storage.ErrPuttingObject, "STORAGE_FAILED", http.StatusInternalServerError},
+	{storage.ErrGettingObject, "STORAGE_FAILED", http.StatusInternalServerError},
+	{storage.ErrDeletingObject, "STORAGE_FAILED", http.StatusInternalServerError},
+	{storage.ErrListingObjects, "STORAGE_FAILED", http.StatusInternalServerError},
+	// Queues
+	{queue.ErrSendingMessage, "QUEUE_FAILED", http.StatusInternalServerError},
+	{queue.ErrReceivingMessages, "QUEUE_FAILED", http.StatusInternalServerError},
+	{queue.ErrDeletingMessage, "QUEUE_FAILED", http.StatusInternalServerError},
+	// Database
+	{database.ErrOpeningDatabase, "DATABASE_UNAVAILABLE", http.StatusServiceUnavailable},
+	{database.ErrBeginningTransaction, "DATABASE_UNAVAILABLE", http.StatusServiceUnavailable},
+	{database.ErrPoolIsShutDown, "DATABASE_UNAVAILABLE", http.StatusServiceUnavailable},
+	{database.ErrQueryingDatabase, "DATABASE_FAILED", http.StatusInternalServerError},
+	{database.ErrExec, "DATABASE_FAILED", http.StatusInternalServerError},
+	{database.ErrCommittingTransaction, "DATABASE_FAILED", http.StatusInternalServerError},
+	{database.ErrRollingBackTransaction, "DATABASE_FAILED", http.StatusInternalServerError},
+}
+
+// Lookup returns the entry of the first sentinel the error matches with errors.Is, or the
+// entry of INTERNAL_ERROR when it matches none.
+func Lookup(err error) (entry Entry) {
+	for _, candidate := range catalogue {
+		if errors.Is(err, candidate.Err) {
+			return candidate
+		}
+	}
+	return internalEntry
+}
+
+// Catalogue returns the entries of the catalogue in order.
+func Catalogue() (entries []Entry) {
+	entries = make([]Entry, len(catalogue))
+	copy(entries, catalogue)
+	return
+}
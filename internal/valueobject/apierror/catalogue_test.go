@@ -0,0 +1,88 @@
+package apierror_test
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	voTransaction "github.com/braejan/go-transactions-summary/internal/valueobject/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLookup tests the domain errors, even wrapped, get the code and the status of their entry.
+func TestLookup(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		code   string
+		status int
+	}{
+		{name: "invalid line", err: voFile.ErrFileDateIsInvalid, code: "FILE_DATE_INVALID", status: http.StatusUnprocessableEntity},
+		{name: "wrapped", err: fmt.Errorf("line 3: %w", voFile.ErrFileAmountIsInvalid), code: "FILE_AMOUNT_INVALID", status: http.StatusUnprocessableEntity},
+		{name: "not found", err: voTransaction.ErrTransactionNotFound, code: "TRANSACTION_NOT_FOUND", status: http.StatusNotFound},
+		{name: "forbidden", err: auth.ErrForbidden, code: "FORBIDDEN", status: http.StatusForbidden},
+		{name: "too large", err: quota.ErrFileTooLarge, code: "FILE_TOO_LARGE", status: http.StatusRequestEntityTooLarge},
+		{name: "database unavailable", err: database.ErrOpeningDatabase, code: "DATABASE_UNAVAILABLE", status: http.StatusServiceUnavailable},
+		{name: "unknown", err: fmt.Errorf("unexpected"), code: "INTERNAL_ERROR", status: http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// When looking up the error
+			entry := apierror.Lookup(tc.err)
+			// Then the code and the status of its entry are returned
+			assert.Equal(t, tc.code, entry.Code)
+			assert.Equal(t, tc.status, entry.Status)
+		})
+	}
+}
+
+// bothError is an error matching two sentinels.
+type bothError struct {
+	first  error
+	second error
+}
+
+func (both bothError) Error() string {
+	return both.first.Error() + ": " + both.second.Error()
+}
+
+func (both bothError) Is(target error) bool {
+	return target == both.first || target == both.second
+}
+
+// TestLookupFirstMatch tests an error matching several sentinels gets the first entry.
+func TestLookupFirstMatch(t *testing.T) {
+	// Given errors matching a request error and a file error
+	err := bothError{first: voFile.ErrFileDateIsInvalid, second: apierror.ErrInvalidRequest}
+	reversed := bothError{first: apierror.ErrInvalidRequest, second: voFile.ErrFileDateIsInvalid}
+	// When looking up the errors
+	// Then the entry listed first is used whatever the order of the sentinels
+	assert.Equal(t, "INVALID_REQUEST", apierror.Lookup(err).Code)
+	assert.Equal(t, "INVALID_REQUEST", apierror.Lookup(reversed).Code)
+}
+
+// TestCatalogue tests every entry has a sentinel, an upper snake case code and an error status.
+func TestCatalogue(t *testing.T) {
+	// Given the catalogue
+	entries := apierror.Catalogue()
+	code := regexp.MustCompile(`^[A-Z]+(_[A-Z]+)*$`)
+	seen := make(map[error]bool)
+	for _, entry := range entries {
+		// Then every entry is complete
+		assert.NotNil(t, entry.Err)
+		assert.Regexp(t, code, entry.Code)
+		assert.GreaterOrEqual(t, entry.Status, http.StatusBadRequest)
+		// And every sentinel is listed once
+		assert.False(t, seen[entry.Err], entry.Err)
+		seen[entry.Err] = true
+	}
+	// And the catalogue cannot be changed by the caller
+	entries[0].Code = "CHANGED"
+	assert.NotEqual(t, "CHANGED", apierror.Catalogue()[0].Code)
+}
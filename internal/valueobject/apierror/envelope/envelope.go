@@ -0,0 +1,58 @@
+// Package envelope writes the JSON body of the failed responses of the REST API. It has no
+// dependency on the domain, so the middlewares write their errors like the handlers.
+package envelope
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+)
+
+const (
+	// CodeInternal is the code of the unexpected errors.
+	CodeInternal = "INTERNAL_ERROR"
+	// CodeInvalidRequest is the code of the requests that cannot be read.
+	CodeInvalidRequest = "INVALID_REQUEST"
+	// CodeNotFound is the code of the requests to a path without route.
+	CodeNotFound = "NOT_FOUND"
+	// CodeMethodNotAllowed is the code of the requests with a method the route does not serve.
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	// CodeUnauthenticated is the code of the requests without valid credentials.
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	// CodeForbidden is the code of the requests of a client lacking the scope of the route.
+	CodeForbidden = "FORBIDDEN"
+	// CodeRateLimited is the code of the requests beyond the rate limit of the client.
+	CodeRateLimited = "RATE_LIMITED"
+)
+
+// Envelope struct defines the body of a failed response.
+type Envelope struct {
+	// Code identifies the error, it does not change between versions.
+	Code string `json:"code"`
+	// Message describes the error.
+	Message string `json:"message"`
+	// Details has the data of the error, such as the invalid lines of a file.
+	Details interface{} `json:"details,omitempty"`
+	// RequestID is the ID of the request in the logs.
+	RequestID string `json:"request_id"`
+}
+
+// Write writes the envelope of the error with the status and the ID of the request.
+func Write(writer http.ResponseWriter, request *http.Request, status int, code string, message string, details interface{}) {
+	body, err := json.Marshal(Envelope{
+		Code:      code,
+		Message:   message,
+		Details:   details,
+		RequestID: logger.RequestID(request.Context()),
+	})
+	if err != nil {
+		body, _ = json.Marshal(Envelope{Code: CodeInternal, Message: http.StatusText(http.StatusInternalServerError),
+			RequestID: logger.RequestID(request.Context())})
+		status = http.StatusInternalServerError
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("X-Content-Type-Options", "nosniff")
+	writer.WriteHeader(status)
+	writer.Write(append(body, '\n'))
+}
@@ -0,0 +1,9 @@
+package apierror
+
+import "errors"
+
+var (
+	// ErrInvalidRequest is the error returned when the request cannot be read, such as a
+	// malformed multipart form.
+	ErrInvalidRequest = errors.New("request is not valid")
+)
@@ -0,0 +1,31 @@
+package apikey
+
+import "errors"
+
+var (
+	// ErrEmptyAPIKeyName is the error returned when the name of an API key is empty.
+	ErrEmptyAPIKeyName = errors.New("api key name is empty")
+	// ErrEmptyAPIKeyScopes is the error returned when an API key has no scope.
+	ErrEmptyAPIKeyScopes = errors.New("api key has no scope")
+	// ErrInvalidAPIKey is the error returned when a token is not an API key, or its secret
+	// does not match.
+	ErrInvalidAPIKey = errors.New("api key is invalid")
+	// ErrAPIKeyRevoked is the error returned when authenticating with a revoked API key.
+	ErrAPIKeyRevoked = errors.New("api key is revoked")
+	// ErrAPIKeyNotFound is the error returned when an API key is not found.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrNilAPIKey is the error returned when the API key is nil.
+	ErrNilAPIKey = errors.New("api key is nil")
+	// ErrQueryingAPIKey is the error returned when querying the API keys.
+	ErrQueryingAPIKey = errors.New("error querying api key")
+	// ErrScanningAPIKey is the error returned when scanning an API key.
+	ErrScanningAPIKey = errors.New("error scanning api key")
+	// ErrCreatingAPIKey is the error returned when the API key cannot be created.
+	ErrCreatingAPIKey = errors.New("error creating api key")
+	// ErrRevokingAPIKey is the error returned when the API key cannot be revoked.
+	ErrRevokingAPIKey = errors.New("error revoking api key")
+	// ErrNilAPIKeyRepository is the error returned when the API key repository is nil.
+	ErrNilAPIKeyRepository = errors.New("api key repository is nil")
+	// ErrNilAPIKeyUseCases is the error returned when the API key use cases is nil.
+	ErrNilAPIKeyUseCases = errors.New("api key use cases is nil")
+)
@@ -0,0 +1,145 @@
+// Package auth authenticates the clients of the REST API and authorizes them by the scopes
+// of the routes. The credentials are checked by the Authenticator implementations of the
+// domains, like the API keys and the JWT bearer tokens of the end users.
+package auth
+
+import (
+	"context"
+	"os"
+	"strconv"
+)
+
+const (
+	// ScopeFilesUpload allows uploading and validating files.
+	ScopeFilesUpload = "files:upload"
+	// ScopeTransactionsRead allows reading the accounts, the transactions and the summaries.
+	ScopeTransactionsRead = "transactions:read"
+	// ScopeUsersAdmin allows managing the users and reading the data of every user.
+	ScopeUsersAdmin = "users:admin"
+)
+
+// KnownScopes are the scopes a client can be granted.
+var KnownScopes = []string{ScopeFilesUpload, ScopeTransactionsRead, ScopeUsersAdmin}
+
+// ValidateScopes returns ErrUnknownScope when a scope is not one of the KnownScopes.
+func ValidateScopes(scopes []string) (err error) {
+	for _, scope := range scopes {
+		if !contains(KnownScopes, scope) {
+			err = ErrUnknownScope
+			return
+		}
+	}
+	return
+}
+
+// Principal struct defines the authenticated client of a request.
+type Principal struct {
+	// ID identifies the client, it is recorded as the uploader of its files.
+	ID string
+	// Name describes the client.
+	Name string
+	// Scopes are the scopes granted to the client.
+	Scopes []string
+	// IsUser is true for the end users authenticated by a token, false for the clients
+	// like the API keys.
+	IsUser bool
+	// UserID is the ID of the end user, the subject of its token.
+	UserID int64
+}
+
+// HasScope returns whether the scope is granted to the client.
+func (principal *Principal) HasScope(scope string) bool {
+	return principal != nil && contains(principal.Scopes, scope)
+}
+
+// CanAccessUser returns whether the client can read the data of the user. The clients
+// granted ScopeUsersAdmin read every user, the end users only themselves.
+func (principal *Principal) CanAccessUser(userID int64) bool {
+	if principal == nil {
+		return false
+	}
+	return principal.HasScope(ScopeUsersAdmin) || (principal.IsUser && principal.UserID == userID)
+}
+
+// CanAccessUser returns whether the client carried by the context can read the data of the
+// user. Without client, when the authentication is not required, every user is readable.
+func CanAccessUser(ctx context.Context, userID int64) bool {
+	principal := PrincipalFromContext(ctx)
+	return principal == nil || principal.CanAccessUser(userID)
+}
+
+// contextKey is the type of the key of the principal stored in a context.
+type contextKey struct{}
+
+// WithPrincipal returns a copy of the context carrying the authenticated client.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated client carried by the context, nil when
+// the request was not authenticated.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(contextKey{}).(*Principal)
+	return principal
+}
+
+// ClientID returns the ID of the authenticated client carried by the context, empty when
+// there is none.
+func ClientID(ctx context.Context) string {
+	if principal := PrincipalFromContext(ctx); principal != nil {
+		return principal.ID
+	}
+	return ""
+}
+
+// AuthConfiguration struct defines the configuration of the authentication.
+type AuthConfiguration struct {
+	// Required rejects the requests without valid credentials. When false the routes are
+	// open, for local environments.
+	Required bool
+	// JWTSecret is the shared secret of the HS256 tokens, empty disables them.
+	JWTSecret string
+	// JWKSFile is the path of the JWKS file with the public keys of the RS256 tokens, empty
+	// disables them.
+	JWKSFile string
+	// JWTIssuer is the required issuer of the tokens, any issuer when empty.
+	JWTIssuer string
+	// JWTAudience is the required audience of the tokens, any audience when empty.
+	JWTAudience string
+}
+
+// JWTEnabled returns whether the bearer tokens are accepted.
+func (configuration *AuthConfiguration) JWTEnabled() bool {
+	return configuration.JWTSecret != "" || configuration.JWKSFile != ""
+}
+
+// NewDefaultAuthConfiguration returns the configuration used when nothing is set, the
+// credentials are required.
+func NewDefaultAuthConfiguration() (configuration *AuthConfiguration) {
+	configuration = &AuthConfiguration{
+		Required: true,
+	}
+	return
+}
+
+// NewAuthConfigurationFromEnv returns the configuration from the environment variables.
+func NewAuthConfigurationFromEnv() (configuration *AuthConfiguration) {
+	configuration = NewDefaultAuthConfiguration()
+	if required, err := strconv.ParseBool(os.Getenv("AUTH_REQUIRED")); err == nil {
+		configuration.Required = required
+	}
+	configuration.JWTSecret = os.Getenv("JWT_SECRET")
+	configuration.JWKSFile = os.Getenv("JWT_JWKS_FILE")
+	configuration.JWTIssuer = os.Getenv("JWT_ISSUER")
+	configuration.JWTAudience = os.Getenv("JWT_AUDIENCE")
+	return
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
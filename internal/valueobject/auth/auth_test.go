@@ -0,0 +1,48 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCanAccessUser tests the end users only access themselves and the admins every user.
+func TestCanAccessUser(t *testing.T) {
+	// Given an end user, an admin and a client
+	endUser := &auth.Principal{ID: "42", IsUser: true, UserID: 42, Scopes: []string{auth.ScopeTransactionsRead}}
+	admin := &auth.Principal{ID: "admin", Scopes: []string{auth.ScopeUsersAdmin}}
+	client := &auth.Principal{ID: "reader", Scopes: []string{auth.ScopeTransactionsRead}}
+	// Then the end user only accesses itself
+	assert.True(t, endUser.CanAccessUser(42))
+	assert.False(t, endUser.CanAccessUser(7))
+	// And the admin accesses every user
+	assert.True(t, admin.CanAccessUser(7))
+	// And the client without admin scope accesses no user
+	assert.False(t, client.CanAccessUser(42))
+	// And a context without client accesses every user, the authentication is not required
+	assert.True(t, auth.CanAccessUser(context.Background(), 7))
+	assert.False(t, auth.CanAccessUser(auth.WithPrincipal(context.Background(), endUser), 7))
+}
+
+// TestNewAuthConfigurationFromEnv tests the configuration is read from the environment.
+func TestNewAuthConfigurationFromEnv(t *testing.T) {
+	// Given the authentication environment variables
+	t.Setenv("AUTH_REQUIRED", "false")
+	t.Setenv("JWT_SECRET", "secret")
+	t.Setenv("JWT_JWKS_FILE", "jwks.json")
+	t.Setenv("JWT_ISSUER", "https://issuer.example")
+	t.Setenv("JWT_AUDIENCE", "transactions-api")
+	// When reading the configuration
+	configuration := auth.NewAuthConfigurationFromEnv()
+	// Then the configuration has the values of the variables
+	assert.Equal(t, &auth.AuthConfiguration{
+		Required:    false,
+		JWTSecret:   "secret",
+		JWKSFile:    "jwks.json",
+		JWTIssuer:   "https://issuer.example",
+		JWTAudience: "transactions-api",
+	}, configuration)
+	assert.True(t, configuration.JWTEnabled())
+}
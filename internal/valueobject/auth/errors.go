@@ -0,0 +1,46 @@
+package auth
+
+import "errors"
+
+var (
+	// ErrNilAuthenticator is the error returned when an authenticator of the middleware is nil.
+	ErrNilAuthenticator = errors.New("authenticator is nil")
+	// ErrNoCredentials is the error returned by an authenticator when the request does not
+	// carry its credentials, so the next authenticator is tried.
+	ErrNoCredentials = errors.New("request has no credentials")
+	// ErrUnauthenticated is the error returned when the credentials of a request are not valid.
+	ErrUnauthenticated = errors.New("credentials are not valid")
+	// ErrForbidden is the error returned when the client lacks the scope of the route.
+	ErrForbidden = errors.New("client lacks the scope of the route")
+	// ErrUnknownScope is the error returned when a scope is not one of the known scopes.
+	ErrUnknownScope = errors.New("unknown scope")
+)
+
+var (
+	// ErrNilJWTVerifier is the error returned when the JWT verifier is nil.
+	ErrNilJWTVerifier = errors.New("JWT verifier is nil")
+	// ErrMissingJWTKeys is the error returned when neither a JWT secret nor a JWKS file is configured.
+	ErrMissingJWTKeys = errors.New("no JWT secret or JWKS file configured")
+	// ErrReadingJWKS is the error returned when the JWKS file cannot be read.
+	ErrReadingJWKS = errors.New("error reading JWKS file")
+	// ErrInvalidJWKS is the error returned when the JWKS file has no valid RSA key.
+	ErrInvalidJWKS = errors.New("JWKS file is not valid")
+	// ErrMalformedToken is the error returned when a token is not a JWT.
+	ErrMalformedToken = errors.New("token is malformed")
+	// ErrUnsupportedAlgorithm is the error returned when the algorithm of a token is not configured.
+	ErrUnsupportedAlgorithm = errors.New("token algorithm is not supported")
+	// ErrUnknownKey is the error returned when the key of a token is not in the JWKS file.
+	ErrUnknownKey = errors.New("token key is unknown")
+	// ErrInvalidSignature is the error returned when the signature of a token is not valid.
+	ErrInvalidSignature = errors.New("token signature is not valid")
+	// ErrInvalidClaims is the error returned when a token lacks its subject or its expiration.
+	ErrInvalidClaims = errors.New("token claims are not valid")
+	// ErrTokenExpired is the error returned when a token is expired.
+	ErrTokenExpired = errors.New("token is expired")
+	// ErrTokenNotYetValid is the error returned when a token is used before its not before time.
+	ErrTokenNotYetValid = errors.New("token is not yet valid")
+	// ErrInvalidIssuer is the error returned when a token was not issued by the configured issuer.
+	ErrInvalidIssuer = errors.New("token issuer is not valid")
+	// ErrInvalidAudience is the error returned when a token is not meant for the configured audience.
+	ErrInvalidAudience = errors.New("token audience is not valid")
+)
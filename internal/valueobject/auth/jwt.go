@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// AlgorithmHS256 signs the tokens with HMAC SHA-256 and the shared secret.
+	AlgorithmHS256 = "HS256"
+	// AlgorithmRS256 signs the tokens with RSA SHA-256, verified with the keys of the JWKS file.
+	AlgorithmRS256 = "RS256"
+	// clockSkew is the tolerance of the expiration and not before times.
+	clockSkew = time.Minute
+)
+
+// Claims struct defines the verified claims of a token.
+type Claims struct {
+	// Subject identifies the user of the token.
+	Subject string
+	// Issuer identifies the issuer of the token.
+	Issuer string
+	// Audience are the recipients the token is meant for.
+	Audience []string
+	// Scopes are the scopes of the "scope" claim, or of the "scp" claim.
+	Scopes []string
+	// ExpiresAt is the date and time after which the token is not valid.
+	ExpiresAt time.Time
+	// NotBefore is the date and time before which the token is not valid, zero when unset.
+	NotBefore time.Time
+}
+
+// JWTVerifier interface defines the verification of the bearer tokens.
+type JWTVerifier interface {
+	// Verify checks the signature and the times of the token, and its issuer and audience
+	// when configured, and returns its claims.
+	Verify(token string, now time.Time) (claims Claims, err error)
+}
+
+// jwtVerifier struct implements the JWTVerifier interface with the HS256 secret and the
+// RS256 keys of the configuration.
+type jwtVerifier struct {
+	secret   []byte
+	keys     map[string]*rsa.PublicKey
+	issuer   string
+	audience string
+}
+
+// NewJWTVerifier returns the verifier of the tokens signed with the JWT secret or with the
+// keys of the JWKS file of the configuration. Only the algorithms with a key are accepted.
+func NewJWTVerifier(configuration *AuthConfiguration) (verifier JWTVerifier, err error) {
+	if configuration.JWTSecret == "" && configuration.JWKSFile == "" {
+		err = ErrMissingJWTKeys
+		return
+	}
+	newVerifier := &jwtVerifier{
+		secret:   []byte(configuration.JWTSecret),
+		issuer:   configuration.JWTIssuer,
+		audience: configuration.JWTAudience,
+	}
+	if configuration.JWKSFile != "" {
+		newVerifier.keys, err = LoadJWKS(configuration.JWKSFile)
+		if err != nil {
+			return
+		}
+	}
+	verifier = newVerifier
+	return
+}
+
+// jsonWebKey struct defines the fields of a RSA key of a JWKS file.
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// LoadJWKS returns the RSA signing keys of the JWKS file by their key ID. The keys of other
+// types or uses are skipped.
+func LoadJWKS(path string) (keys map[string]*rsa.PublicKey, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		err = ErrReadingJWKS
+		return
+	}
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err = json.Unmarshal(content, &jwks); err != nil {
+		err = ErrInvalidJWKS
+		return
+	}
+	keys = map[string]*rsa.PublicKey{}
+	for _, key := range jwks.Keys {
+		if key.KeyType != "RSA" || (key.Use != "" && key.Use != "sig") || (key.Algorithm != "" && key.Algorithm != AlgorithmRS256) {
+			continue
+		}
+		modulus, errModulus := base64.RawURLEncoding.DecodeString(key.Modulus)
+		exponent, errExponent := base64.RawURLEncoding.DecodeString(key.Exponent)
+		if errModulus != nil || errExponent != nil || len(modulus) == 0 || len(exponent) == 0 || len(exponent) > 4 {
+			keys, err = nil, ErrInvalidJWKS
+			return
+		}
+		keys[key.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(modulus),
+			E: int(new(big.Int).SetBytes(exponent).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		keys, err = nil, ErrInvalidJWKS
+	}
+	return
+}
+
+// JWTVerifier interface implementation
+
+func (verifier *jwtVerifier) Verify(token string, now time.Time) (claims Claims, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		err = ErrMalformedToken
+		return
+	}
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err = decodeSegment(parts[0], &header); err != nil {
+		return
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		err = ErrMalformedToken
+		return
+	}
+	if err = verifier.verifySignature(header.Algorithm, header.KeyID, parts[0]+"."+parts[1], signature); err != nil {
+		return
+	}
+	var payload struct {
+		Subject   string   `json:"sub"`
+		Issuer    string   `json:"iss"`
+		Audience  audience `json:"aud"`
+		Scope     string   `json:"scope"`
+		Scp       []string `json:"scp"`
+		ExpiresAt *float64 `json:"exp"`
+		NotBefore *float64 `json:"nbf"`
+	}
+	if err = decodeSegment(parts[1], &payload); err != nil {
+		return
+	}
+	if payload.Subject == "" || payload.ExpiresAt == nil {
+		err = ErrInvalidClaims
+		return
+	}
+	claims = Claims{
+		Subject:   payload.Subject,
+		Issuer:    payload.Issuer,
+		Audience:  payload.Audience,
+		Scopes:    strings.Fields(payload.Scope),
+		ExpiresAt: numericDate(*payload.ExpiresAt),
+	}
+	if len(claims.Scopes) == 0 {
+		claims.Scopes = payload.Scp
+	}
+	if payload.NotBefore != nil {
+		claims.NotBefore = numericDate(*payload.NotBefore)
+	}
+	err = verifier.verifyClaims(claims, now)
+	if err != nil {
+		claims = Claims{}
+	}
+	return
+}
+
+// verifySignature checks the signature of the signed part of a token with the key of its
+// algorithm.
+func (verifier *jwtVerifier) verifySignature(algorithm string, keyID string, signed string, signature []byte) (err error) {
+	switch {
+	case algorithm == AlgorithmHS256 && len(verifier.secret) > 0:
+		mac := hmac.New(sha256.New, verifier.secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			err = ErrInvalidSignature
+		}
+	case algorithm == AlgorithmRS256 && len(verifier.keys) > 0:
+		key := verifier.keys[keyID]
+		if key == nil && keyID == "" && len(verifier.keys) == 1 {
+			for _, onlyKey := range verifier.keys {
+				key = onlyKey
+			}
+		}
+		if key == nil {
+			err = ErrUnknownKey
+			return
+		}
+		digest := sha256.Sum256([]byte(signed))
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) != nil {
+			err = ErrInvalidSignature
+		}
+	default:
+		err = ErrUnsupportedAlgorithm
+	}
+	return
+}
+
+// verifyClaims checks the times of the claims, and their issuer and audience when configured.
+func (verifier *jwtVerifier) verifyClaims(claims Claims, now time.Time) (err error) {
+	switch {
+	case now.After(claims.ExpiresAt.Add(clockSkew)):
+		err = ErrTokenExpired
+	case !claims.NotBefore.IsZero() && now.Add(clockSkew).Before(claims.NotBefore):
+		err = ErrTokenNotYetValid
+	case verifier.issuer != "" && claims.Issuer != verifier.issuer:
+		err = ErrInvalidIssuer
+	case verifier.audience != "" && !contains(claims.Audience, verifier.audience):
+		err = ErrInvalidAudience
+	}
+	return
+}
+
+// decodeSegment decodes a base64url JSON segment of a token into value.
+func decodeSegment(segment string, value interface{}) (err error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err == nil {
+		err = json.Unmarshal(decoded, value)
+	}
+	if err != nil {
+		err = ErrMalformedToken
+	}
+	return
+}
+
+// numericDate returns the time of a JWT numeric date, in seconds since the epoch.
+func numericDate(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second))).UTC()
+}
+
+// audience is the "aud" claim, a single string or an array of strings.
+type audience []string
+
+func (value *audience) UnmarshalJSON(data []byte) (err error) {
+	var single string
+	if err = json.Unmarshal(data, &single); err == nil {
+		*value = audience{single}
+		return
+	}
+	var multiple []string
+	err = json.Unmarshal(data, &multiple)
+	*value = multiple
+	return
+}
@@ -0,0 +1,159 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2023, 7, 5, 10, 30, 0, 0, time.UTC)
+
+// encodeSegment returns the base64url JSON of the value.
+func encodeSegment(t *testing.T, value interface{}) string {
+	encoded, err := json.Marshal(value)
+	assert.Nil(t, err)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// signHS256 returns a HS256 token of the claims signed with the secret.
+func signHS256(t *testing.T, secret string, claims map[string]interface{}) string {
+	signed := encodeSegment(t, map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// signRS256 returns a RS256 token of the claims signed with the key.
+func signRS256(t *testing.T, key *rsa.PrivateKey, keyID string, claims map[string]interface{}) string {
+	signed := encodeSegment(t, map[string]string{"alg": "RS256", "kid": keyID}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	assert.Nil(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// writeJWKS writes a JWKS file with the public key and returns its path.
+func writeJWKS(t *testing.T, key *rsa.PrivateKey, keyID string) string {
+	jwks := map[string]interface{}{"keys": []map[string]string{
+		{"kty": "EC", "kid": "ec", "crv": "P-256"},
+		{
+			"kty": "RSA", "kid": keyID, "use": "sig", "alg": "RS256",
+			"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		},
+	}}
+	content, err := json.Marshal(jwks)
+	assert.Nil(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.Nil(t, os.WriteFile(path, content, 0o600))
+	return path
+}
+
+// getClaims returns valid claims of the user 42.
+func getClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub":   "42",
+		"iss":   "https://issuer.example",
+		"aud":   []string{"transactions-api", "other"},
+		"scope": "transactions:read files:upload",
+		"exp":   now.Add(time.Hour).Unix(),
+		"nbf":   now.Add(-time.Hour).Unix(),
+	}
+}
+
+// TestVerifyHS256 tests the HS256 tokens are verified with the secret and their claims.
+func TestVerifyHS256(t *testing.T) {
+	// Given a verifier with a secret, an issuer and an audience
+	verifier, err := auth.NewJWTVerifier(&auth.AuthConfiguration{
+		JWTSecret:   "secret",
+		JWTIssuer:   "https://issuer.example",
+		JWTAudience: "transactions-api",
+	})
+	assert.Nil(t, err)
+	// When verifying a valid token
+	claims, err := verifier.Verify(signHS256(t, "secret", getClaims()), now)
+	// Then the claims are returned
+	assert.Nil(t, err)
+	assert.Equal(t, "42", claims.Subject)
+	assert.Equal(t, []string{"transactions:read", "files:upload"}, claims.Scopes)
+	assert.Equal(t, now.Add(time.Hour), claims.ExpiresAt)
+	// And the invalid tokens are rejected with their error
+	withClaim := func(name string, value interface{}) map[string]interface{} {
+		claims := getClaims()
+		claims[name] = value
+		return claims
+	}
+	withoutSubject := getClaims()
+	delete(withoutSubject, "sub")
+	for expected, token := range map[error]string{
+		auth.ErrInvalidSignature:     signHS256(t, "other", getClaims()),
+		auth.ErrTokenExpired:         signHS256(t, "secret", withClaim("exp", now.Add(-2*time.Minute).Unix())),
+		auth.ErrTokenNotYetValid:     signHS256(t, "secret", withClaim("nbf", now.Add(2*time.Minute).Unix())),
+		auth.ErrInvalidIssuer:        signHS256(t, "secret", withClaim("iss", "https://other.example")),
+		auth.ErrInvalidAudience:      signHS256(t, "secret", withClaim("aud", "other")),
+		auth.ErrInvalidClaims:        signHS256(t, "secret", withoutSubject),
+		auth.ErrMalformedToken:       "not.a.token",
+		auth.ErrUnsupportedAlgorithm: encodeSegment(t, map[string]string{"alg": "none"}) + "." + encodeSegment(t, getClaims()) + ".",
+	} {
+		_, err = verifier.Verify(token, now)
+		assert.Equal(t, expected, err, expected.Error())
+	}
+}
+
+// TestVerifyRS256 tests the RS256 tokens are verified with the keys of the JWKS file.
+func TestVerifyRS256(t *testing.T) {
+	// Given a verifier with a JWKS file
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	verifier, err := auth.NewJWTVerifier(&auth.AuthConfiguration{JWKSFile: writeJWKS(t, key, "key-1")})
+	assert.Nil(t, err)
+	// When verifying a token signed with the key
+	claims, err := verifier.Verify(signRS256(t, key, "key-1", getClaims()), now)
+	// Then the claims are returned
+	assert.Nil(t, err)
+	assert.Equal(t, "42", claims.Subject)
+	// And a token without key ID is verified with the only key
+	_, err = verifier.Verify(signRS256(t, key, "", getClaims()), now)
+	assert.Nil(t, err)
+	// And a token of an unknown key is rejected
+	_, err = verifier.Verify(signRS256(t, key, "key-2", getClaims()), now)
+	assert.Equal(t, auth.ErrUnknownKey, err)
+	// And a token signed with another key is rejected
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	_, err = verifier.Verify(signRS256(t, other, "key-1", getClaims()), now)
+	assert.Equal(t, auth.ErrInvalidSignature, err)
+	// And a HS256 token is rejected without secret
+	_, err = verifier.Verify(signHS256(t, "", getClaims()), now)
+	assert.Equal(t, auth.ErrUnsupportedAlgorithm, err)
+}
+
+// TestNewJWTVerifierInvalid tests the errors returned for missing or invalid keys.
+func TestNewJWTVerifierInvalid(t *testing.T) {
+	// When creating a verifier without keys
+	_, err := auth.NewJWTVerifier(&auth.AuthConfiguration{})
+	// Then the error is ErrMissingJWTKeys
+	assert.Equal(t, auth.ErrMissingJWTKeys, err)
+	// When creating a verifier with a missing JWKS file
+	_, err = auth.NewJWTVerifier(&auth.AuthConfiguration{JWKSFile: filepath.Join(t.TempDir(), "missing.json")})
+	// Then the error is ErrReadingJWKS
+	assert.Equal(t, auth.ErrReadingJWKS, err)
+	// When creating a verifier with a JWKS file without RSA keys
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.Nil(t, os.WriteFile(path, []byte(`{"keys":[{"kty":"EC"}]}`), 0o600))
+	_, err = auth.NewJWTVerifier(&auth.AuthConfiguration{JWKSFile: path})
+	// Then the error is ErrInvalidJWKS
+	assert.Equal(t, auth.ErrInvalidJWKS, err)
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror/envelope"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+	"github.com/gorilla/mux"
+)
+
+// Authenticator interface defines a kind of credentials of the requests.
+type Authenticator interface {
+	// Authenticate returns the client of the credentials of the request. It returns
+	// ErrNoCredentials when the request does not carry them, and an error wrapping
+	// ErrUnauthenticated when they are not valid. Other errors fail the request with 500.
+	Authenticate(request *http.Request) (principal *Principal, err error)
+}
+
+// Policy struct defines the scope required by every route, identified by its method and
+// its route template, e.g. "POST /loadfile".
+type Policy struct {
+	// Scopes are the scopes required by the routes. The routes not listed only require an
+	// authenticated client.
+	Scopes map[string]string
+	// Public are the routes served without credentials.
+	Public []string
+}
+
+// BearerToken returns the token of the Authorization header with the Bearer scheme, empty
+// when there is none.
+func BearerToken(request *http.Request) string {
+	scheme, token, found := strings.Cut(request.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// Middleware returns a middleware authenticating every request with the first
+// authenticator whose credentials it carries, and authorizing it with the policy. The
+// requests without valid credentials get 401 and the clients lacking the scope of the
+// route 403. The client is carried by the context of the request.
+func Middleware(policy Policy, log logger.Logger, authenticators ...Authenticator) (middleware func(next http.Handler) http.Handler, err error) {
+	if log == nil {
+		err = logger.ErrNilLogger
+		return
+	}
+	for _, authenticator := range authenticators {
+		if authenticator == nil {
+			err = ErrNilAuthenticator
+			return
+		}
+	}
+	middleware = func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			route := routeName(request)
+			if contains(policy.Public, route) {
+				next.ServeHTTP(writer, request)
+				return
+			}
+			requestLog := logger.FromContext(request.Context(), log)
+			principal, errAuth := authenticate(request, authenticators)
+			if errAuth != nil && errAuth != ErrNoCredentials && !errors.Is(errAuth, ErrUnauthenticated) {
+				requestLog.Error("error authenticating request", "route", route, "error", errAuth)
+				envelope.Write(writer, request, http.StatusInternalServerError, envelope.CodeInternal, "error authenticating request", nil)
+				return
+			}
+			if errAuth != nil {
+				requestLog.Warn("request not authenticated", "route", route, "error", errAuth)
+				writer.Header().Set("WWW-Authenticate", `Bearer realm="go-transactions-summary"`)
+				envelope.Write(writer, request, http.StatusUnauthorized, envelope.CodeUnauthenticated, "credentials are missing or not valid", nil)
+				return
+			}
+			if scope, ok := policy.Scopes[route]; ok && !principal.HasScope(scope) {
+				requestLog.Warn("request not authorized", "route", route, "client", principal.ID, "scope", scope)
+				envelope.Write(writer, request, http.StatusForbidden, envelope.CodeForbidden, ErrForbidden.Error(), map[string]string{"scope": scope})
+				return
+			}
+			tracing.SpanFromContext(request.Context()).SetAttributes("client.id", principal.ID)
+			next.ServeHTTP(writer, request.WithContext(WithPrincipal(request.Context(), principal)))
+		})
+	}
+	return
+}
+
+// authenticate returns the client of the first authenticator whose credentials the
+// request carries.
+func authenticate(request *http.Request, authenticators []Authenticator) (principal *Principal, err error) {
+	for _, authenticator := range authenticators {
+		principal, err = authenticator.Authenticate(request)
+		if err == ErrNoCredentials {
+			continue
+		}
+		if err == nil && principal == nil {
+			err = ErrUnauthenticated
+		}
+		return
+	}
+	err = ErrNoCredentials
+	return
+}
+
+// routeName returns the method and the route template of the request.
+func routeName(request *http.Request) string {
+	template := ""
+	if current := mux.CurrentRoute(request); current != nil {
+		template, _ = current.GetPathTemplate()
+	}
+	return request.Method + " " + template
+}
@@ -0,0 +1,112 @@
+package auth_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror/envelope"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// tokenAuthenticator authenticates the Bearer tokens of its clients.
+type tokenAuthenticator map[string]*auth.Principal
+
+func (clients tokenAuthenticator) Authenticate(request *http.Request) (*auth.Principal, error) {
+	token := auth.BearerToken(request)
+	switch {
+	case token == "":
+		return nil, auth.ErrNoCredentials
+	case token == "failing":
+		return nil, errors.New("connection refused")
+	case clients[token] == nil:
+		return nil, fmt.Errorf("%w: unknown token", auth.ErrUnauthenticated)
+	}
+	return clients[token], nil
+}
+
+// TestMiddleware tests the requests are authenticated and authorized by the policy of their route.
+func TestMiddleware(t *testing.T) {
+	// Given a router with the middleware and a policy
+	clients := tokenAuthenticator{
+		"uploader": {ID: "uploader", Scopes: []string{auth.ScopeFilesUpload}},
+		"reader":   {ID: "reader", Scopes: []string{auth.ScopeTransactionsRead}},
+	}
+	policy := auth.Policy{
+		Scopes: map[string]string{"POST /loadfile": auth.ScopeFilesUpload},
+		Public: []string{"GET /metrics"},
+	}
+	middleware, err := auth.Middleware(policy, logger.NewJSONLogger(&bytes.Buffer{}, logger.LevelInfo), clients)
+	assert.Nil(t, err)
+	router := mux.NewRouter()
+	router.Use(middleware)
+	var clientID string
+	handler := func(writer http.ResponseWriter, request *http.Request) {
+		clientID = auth.ClientID(request.Context())
+	}
+	router.HandleFunc("/loadfile", handler).Methods("POST")
+	router.HandleFunc("/accounts/{id}", handler).Methods("GET")
+	router.HandleFunc("/metrics", handler).Methods("GET")
+	serve := func(method, path, token string) *httptest.ResponseRecorder {
+		clientID = ""
+		request := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		return response
+	}
+	// When requesting a public route without credentials
+	response := serve("GET", "/metrics", "")
+	// Then the request is served
+	assert.Equal(t, http.StatusOK, response.Code)
+	// When requesting a route without credentials or with unknown ones
+	for _, token := range []string{"", "unknown"} {
+		response = serve("POST", "/loadfile", token)
+		// Then the request is unauthorized with a challenge
+		assert.Equal(t, http.StatusUnauthorized, response.Code, token)
+		assert.Equal(t, `Bearer realm="go-transactions-summary"`, response.Header().Get("WWW-Authenticate"))
+		assert.Contains(t, response.Body.String(), `"code":"UNAUTHENTICATED"`)
+	}
+	// When the authenticator fails
+	response = serve("POST", "/loadfile", "failing")
+	// Then the request fails
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+	// When requesting a route without the scope of the client
+	response = serve("POST", "/loadfile", "reader")
+	// Then the request is forbidden with the missing scope in the envelope
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	assert.Empty(t, clientID)
+	var body envelope.Envelope
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&body))
+	assert.Equal(t, envelope.CodeForbidden, body.Code)
+	assert.Equal(t, map[string]interface{}{"scope": auth.ScopeFilesUpload}, body.Details)
+	// When requesting routes with the scope or without required scope
+	response = serve("POST", "/loadfile", "uploader")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "uploader", clientID)
+	response = serve("GET", "/accounts/1", "reader")
+	// Then the requests are served with the client in the context
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "reader", clientID)
+}
+
+// TestMiddlewareInvalid tests the errors returned for a nil logger or authenticator.
+func TestMiddlewareInvalid(t *testing.T) {
+	// When calling Middleware without logger
+	_, err := auth.Middleware(auth.Policy{}, nil)
+	// Then the error is ErrNilLogger
+	assert.Equal(t, logger.ErrNilLogger, err)
+	// When calling Middleware with a nil authenticator
+	_, err = auth.Middleware(auth.Policy{}, logger.NewDefaultLogger(), nil)
+	// Then the error is ErrNilAuthenticator
+	assert.Equal(t, auth.ErrNilAuthenticator, err)
+}
@@ -0,0 +1,136 @@
+// Package database defines the SQL databases used by the repositories. A database knows
+// its Dialect, so the code shared by several engines, like the migrations, can use the
+// statements of each one.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Dialect identifies the SQL engine of a database.
+type Dialect string
+
+const (
+	// Postgres is the dialect of PostgreSQL.
+	Postgres Dialect = "postgres"
+	// SQLite is the dialect of SQLite.
+	SQLite Dialect = "sqlite"
+)
+
+// DriverName returns the name of the database/sql driver of the dialect. The driver is
+// registered by the package of the engine.
+func (dialect Dialect) DriverName() (driverName string) {
+	driverName = string(dialect)
+	return
+}
+
+// Time returns the time to write in a column of the dialect. SQLite stores the times as
+// text, so they are written in UTC to compare and sort them as instants. PostgreSQL
+// receives them unchanged.
+func (dialect Dialect) Time(value time.Time) time.Time {
+	if dialect == SQLite {
+		return value.UTC()
+	}
+	return value
+}
+
+// valid returns true when the dialect is supported.
+func (dialect Dialect) valid() bool {
+	return dialect == Postgres || dialect == SQLite
+}
+
+// Database interface defines the operations of a SQL database. Every repository method
+// opens the database, runs its statements in a transaction and closes it.
+type Database interface {
+	Open() (db *sql.DB, err error)
+	Close(db *sql.DB) (err error)
+	BeginTx(db *sql.DB) (tx *sql.Tx, err error)
+	Commit(tx *sql.Tx) (err error)
+	Rollback(tx *sql.Tx) (err error)
+	Exec(tx *sql.Tx, query string, args ...interface{}) (result sql.Result, err error)
+	Query(tx *sql.Tx, query string, args ...interface{}) (rows *sql.Rows, err error)
+	// Dialect returns the SQL engine of the database.
+	Dialect() (dialect Dialect)
+}
+
+// Configuration struct defines how to connect to a database.
+type Configuration struct {
+	// Dialect is the SQL engine of the database.
+	Dialect Dialect
+	// DataSourceName is the connection string of the driver.
+	DataSourceName string
+	// MaxOpenConns is the max number of open connections of a pool, 0 is unlimited.
+	MaxOpenConns int
+	// MaxIdleConns is the max number of idle connections kept by a pool.
+	MaxIdleConns int
+	// ConnMaxLifetime is the max time a connection of a pool is reused, 0 is forever.
+	ConnMaxLifetime time.Duration
+}
+
+// baseDatabase is the base implementation of Database interface, opening a new
+// connection pool on every Open.
+type baseDatabase struct {
+	configuration *Configuration
+}
+
+// NewBaseDatabase creates a new instance of Database interface implementation.
+func NewBaseDatabase(configuration *Configuration) Database {
+	return &baseDatabase{
+		configuration: configuration,
+	}
+}
+
+// Database interface implementation.
+
+// Open opens a new database connection.
+func (base *baseDatabase) Open() (db *sql.DB, err error) {
+	db, err = sql.Open(base.configuration.Dialect.DriverName(), base.configuration.DataSourceName)
+	return
+}
+
+// Close closes a database connection.
+func (base *baseDatabase) Close(db *sql.DB) (err error) {
+	err = db.Close()
+	return
+}
+
+// BeginTx begins a transaction.
+func (base *baseDatabase) BeginTx(db *sql.DB) (tx *sql.Tx, err error) {
+	tx, err = db.BeginTx(context.Background(), nil)
+	return
+}
+
+// Commit commits a transaction.
+func (base *baseDatabase) Commit(tx *sql.Tx) (err error) {
+	err = tx.Commit()
+	return
+}
+
+// Rollback rollbacks a transaction.
+func (base *baseDatabase) Rollback(tx *sql.Tx) (err error) {
+	if tx == nil {
+		return
+	}
+	err = tx.Rollback()
+	return
+}
+
+// Exec executes a sql instruction.
+func (base *baseDatabase) Exec(tx *sql.Tx, dml string, args ...interface{}) (result sql.Result, err error) {
+	result, err = tx.ExecContext(context.Background(), dml, args...)
+	return
+}
+
+// Query executes a sql query.
+func (base *baseDatabase) Query(tx *sql.Tx, query string, args ...interface{}) (rows *sql.Rows, err error) {
+	rows, err = tx.QueryContext(context.Background(), query, args...)
+	return
+}
+
+// Dialect returns the SQL engine of the database.
+func (base *baseDatabase) Dialect() (dialect Dialect) {
+	dialect = base.configuration.Dialect
+	return
+}
@@ -0,0 +1,49 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewPoolWithNilConfiguration tests the error returned when the configuration is nil.
+func TestNewPoolWithNilConfiguration(t *testing.T) {
+	// When creating a pool without configuration
+	pool, err := database.NewPool(nil)
+	// Then the error returned is ErrNilConfiguration
+	assert.Nil(t, pool)
+	assert.Equal(t, database.ErrNilConfiguration, err)
+}
+
+// TestNewPoolWithUnknownDialect tests the error returned when the dialect is not supported.
+func TestNewPoolWithUnknownDialect(t *testing.T) {
+	// When creating a pool of an unknown dialect
+	pool, err := database.NewPool(&database.Configuration{Dialect: "oracle"})
+	// Then the error returned is ErrUnknownDialect
+	assert.Nil(t, pool)
+	assert.Equal(t, database.ErrUnknownDialect, err)
+}
+
+// TestDatabaseDialect tests the databases return the dialect of their configuration.
+func TestDatabaseDialect(t *testing.T) {
+	// Given the configuration of a SQLite database
+	configuration := &database.Configuration{Dialect: database.SQLite, DataSourceName: "file::memory:"}
+	// When creating a database and a pool
+	base := database.NewBaseDatabase(configuration)
+	pool, err := database.NewPool(configuration)
+	// Then both are SQLite databases
+	assert.Nil(t, err)
+	assert.Equal(t, database.SQLite, base.Dialect())
+	assert.Equal(t, database.SQLite, pool.Dialect())
+	assert.Equal(t, "sqlite", database.SQLite.DriverName())
+}
+
+// TestDialectTime tests the times are written in UTC only for SQLite.
+func TestDialectTime(t *testing.T) {
+	value := time.Date(2023, 7, 15, 10, 30, 0, 0, time.FixedZone("COT", -5*60*60))
+	assert.Equal(t, value, database.Postgres.Time(value))
+	assert.Equal(t, time.UTC, database.SQLite.Time(value).Location())
+	assert.True(t, value.Equal(database.SQLite.Time(value)))
+}
@@ -0,0 +1,25 @@
+// Package databasetest runs the tests of the SQL repositories against every dialect.
+package databasetest
+
+import (
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/postgres/postgrestest"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/sqlite/sqlitetest"
+)
+
+// Factory returns a new database with the migrations applied for the test.
+type Factory func(t *testing.T) database.Database
+
+// Run runs the test once per dialect, with the factory of its databases. The PostgreSQL
+// tests are skipped unless postgrestest.DSNVariable is set.
+func Run(t *testing.T, test func(t *testing.T, newDatabase Factory)) {
+	t.Helper()
+	t.Run("SQLite", func(t *testing.T) {
+		test(t, func(t *testing.T) database.Database { return sqlitetest.NewDatabase(t) })
+	})
+	t.Run("Postgres", func(t *testing.T) {
+		test(t, func(t *testing.T) database.Database { return postgrestest.NewDatabase(t) })
+	})
+}
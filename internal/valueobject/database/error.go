@@ -0,0 +1,83 @@
+package database
+
+import "sync"
+
+// Error struct defines the error of a statement of a repository. It is the sentinel of the
+// repository operation wrapping the error of the driver, so errors.Is matches the sentinel
+// and the conflict while the cause is kept for the logs.
+type Error struct {
+	// Err is the sentinel of the operation, such as the error creating an account.
+	Err error
+	// Conflict is ErrUniqueViolation or ErrForeignKeyViolation when the statement violated
+	// a constraint, nil otherwise.
+	Conflict error
+	// Constraint is the name of the violated constraint, when the driver reports it.
+	Constraint string
+	// Cause is the error of the driver.
+	Cause error
+}
+
+func (dbErr *Error) Error() (message string) {
+	message = dbErr.Err.Error()
+	if dbErr.Conflict != nil {
+		message += ": " + dbErr.Conflict.Error()
+		if dbErr.Constraint != "" {
+			message += " (" + dbErr.Constraint + ")"
+		}
+	}
+	if dbErr.Cause != nil {
+		message += ": " + dbErr.Cause.Error()
+	}
+	return
+}
+
+// Is returns true for the sentinel and the conflict of the error.
+func (dbErr *Error) Is(target error) bool {
+	return target == dbErr.Err || (dbErr.Conflict != nil && target == dbErr.Conflict)
+}
+
+func (dbErr *Error) Unwrap() error {
+	return dbErr.Cause
+}
+
+// ConflictClassifier returns the conflict and the constraint of an error of a driver, and
+// false when the error is not a constraint violation.
+type ConflictClassifier func(cause error) (conflict error, constraint string, ok bool)
+
+var (
+	classifiersMutex sync.RWMutex
+	classifiers      []ConflictClassifier
+)
+
+// RegisterConflictClassifier registers the classifier of the errors of a driver. Like the
+// driver, it is registered by the package of the engine.
+func RegisterConflictClassifier(classifier ConflictClassifier) {
+	classifiersMutex.Lock()
+	defer classifiersMutex.Unlock()
+	classifiers = append(classifiers, classifier)
+}
+
+// Wrap returns the sentinel of the operation wrapping the error of the driver, classified
+// as a conflict when it violates a constraint. Without cause the sentinel is returned.
+func Wrap(err error, cause error) error {
+	if cause == nil {
+		return err
+	}
+	wrapped := &Error{Err: err, Cause: cause}
+	classifiersMutex.RLock()
+	defer classifiersMutex.RUnlock()
+	for _, classifier := range classifiers {
+		if conflict, constraint, ok := classifier(cause); ok {
+			wrapped.Conflict = conflict
+			wrapped.Constraint = constraint
+			break
+		}
+	}
+	return wrapped
+}
+
+// NewConflictError returns the sentinel of the operation failing with the conflict of the
+// constraint, for the repositories without driver.
+func NewConflictError(err error, conflict error, constraint string) error {
+	return &Error{Err: err, Conflict: conflict, Constraint: constraint}
+}
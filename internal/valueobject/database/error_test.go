@@ -0,0 +1,38 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWrap tests the sentinel of the operation wraps the error of the driver.
+func TestWrap(t *testing.T) {
+	// Given the sentinel of an operation and the error of the driver
+	errWriting := errors.New("error writing")
+	cause := errors.New("connection reset by peer")
+	// When wrapping the error
+	err := database.Wrap(errWriting, cause)
+	// Then it matches the sentinel and the cause
+	assert.ErrorIs(t, err, errWriting)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, database.ErrUniqueViolation)
+	assert.Equal(t, "error writing: connection reset by peer", err.Error())
+	// And without cause the sentinel is returned
+	assert.Equal(t, errWriting, database.Wrap(errWriting, nil))
+}
+
+// TestNewConflictError tests the conflicts of the repositories without driver.
+func TestNewConflictError(t *testing.T) {
+	// Given the sentinel of an operation
+	errWriting := errors.New("error writing")
+	// When it fails with a unique violation
+	err := database.NewConflictError(errWriting, database.ErrUniqueViolation, "users_pkey")
+	// Then it matches the sentinel and the conflict, not other conflicts
+	assert.ErrorIs(t, err, errWriting)
+	assert.ErrorIs(t, err, database.ErrUniqueViolation)
+	assert.NotErrorIs(t, err, database.ErrForeignKeyViolation)
+	assert.Equal(t, "error writing: unique constraint violated (users_pkey)", err.Error())
+}
@@ -0,0 +1,31 @@
+package database
+
+import "errors"
+
+var (
+	// ErrOpeningDatabase is returned when the database cannot be opened.
+	ErrOpeningDatabase = errors.New("error opening database")
+	// ErrBeginningTransaction is returned when the transaction cannot be started.
+	ErrBeginningTransaction = errors.New("error beginning transaction")
+	// ErrNilConfiguration is returned when the configuration is nil.
+	ErrNilConfiguration = errors.New("error nil configuration")
+	// ErrDBIsNil is the error returned when the database is nil.
+	ErrDBIsNil = errors.New("database is nil")
+	// ErrExec is the error returned when the query cannot be executed.
+	ErrExec = errors.New("error executing dml")
+	// ErrCommittingTransaction is the error returned when the transaction cannot be committed.
+	ErrCommittingTransaction = errors.New("error committing transaction")
+	// ErrRollingBackTransaction is the error returned when the transaction cannot be rolled back.
+	ErrRollingBackTransaction = errors.New("error rolling back transaction")
+	// ErrQueryingDatabase is the error returned when the database cannot be queried.
+	ErrQueryingDatabase = errors.New("error querying database")
+	// ErrPoolIsShutDown is the error returned when the pool is used after its shutdown.
+	ErrPoolIsShutDown = errors.New("database pool is shut down")
+	// ErrUnknownDialect is the error returned when the dialect of the configuration is not supported.
+	ErrUnknownDialect = errors.New("unknown database dialect")
+	// ErrUniqueViolation is the conflict of a statement writing a duplicated key.
+	ErrUniqueViolation = errors.New("unique constraint violated")
+	// ErrForeignKeyViolation is the conflict of a statement referencing a missing row, or
+	// deleting a referenced one.
+	ErrForeignKeyViolation = errors.New("foreign key constraint violated")
+)
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// Pool interface is a Database sharing one connection pool between all its users. Open
+// returns the shared pool and Close keeps it open, so the repositories can be used
+// unchanged.
+type Pool interface {
+	Database
+	// Ping checks the database is reachable.
+	Ping(ctx context.Context) (err error)
+	// Stats returns the statistics of the pool.
+	Stats() (stats sql.DBStats)
+	// Shutdown closes the pool, Open fails afterwards.
+	Shutdown() (err error)
+}
+
+// pooledDatabase is the pooled implementation of Database interface.
+type pooledDatabase struct {
+	baseDatabase
+	mu       sync.Mutex
+	db       *sql.DB
+	shutdown bool
+}
+
+// NewPool creates a new instance of Pool interface implementation. The connections are
+// opened on first use.
+func NewPool(configuration *Configuration) (pool Pool, err error) {
+	if configuration == nil {
+		err = ErrNilConfiguration
+		return
+	}
+	if !configuration.Dialect.valid() {
+		err = ErrUnknownDialect
+		return
+	}
+	pool = &pooledDatabase{
+		baseDatabase: baseDatabase{configuration: configuration},
+	}
+	return
+}
+
+// Open returns the shared pool, creating it on the first call.
+func (pool *pooledDatabase) Open() (db *sql.DB, err error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.shutdown {
+		err = ErrPoolIsShutDown
+		return
+	}
+	if pool.db == nil {
+		pool.db, err = sql.Open(pool.configuration.Dialect.DriverName(), pool.configuration.DataSourceName)
+		if err != nil {
+			pool.db = nil
+			return
+		}
+		pool.db.SetMaxOpenConns(pool.configuration.MaxOpenConns)
+		pool.db.SetMaxIdleConns(pool.configuration.MaxIdleConns)
+		pool.db.SetConnMaxLifetime(pool.configuration.ConnMaxLifetime)
+	}
+	db = pool.db
+	return
+}
+
+// Close keeps the shared pool open.
+func (pool *pooledDatabase) Close(db *sql.DB) (err error) {
+	return
+}
+
+// Ping checks the database is reachable.
+func (pool *pooledDatabase) Ping(ctx context.Context) (err error) {
+	db, err := pool.Open()
+	if err != nil {
+		return
+	}
+	err = db.PingContext(ctx)
+	return
+}
+
+// Stats returns the statistics of the pool, empty before the first use.
+func (pool *pooledDatabase) Stats() (stats sql.DBStats) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.db != nil {
+		stats = pool.db.Stats()
+	}
+	return
+}
+
+// Shutdown closes the pool.
+func (pool *pooledDatabase) Shutdown() (err error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.shutdown = true
+	if pool.db != nil {
+		err = pool.db.Close()
+		pool.db = nil
+	}
+	return
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+)
+
+// Exec executes the statement in the transaction within a span of the context, so the
+// statements of a traced operation are its children.
+func Exec(ctx context.Context, db Database, tx *sql.Tx, statement string, args ...interface{}) (result sql.Result, err error) {
+	_, span := tracing.Start(ctx, "sql.exec", "db.system", string(db.Dialect()), "db.statement", statement)
+	defer span.End()
+	result, err = db.Exec(tx, statement, args...)
+	span.RecordError(err)
+	return
+}
+
+// Query runs the query in the transaction within a span of the context. The span ends
+// when the query returns, the rows are read after it.
+func Query(ctx context.Context, db Database, tx *sql.Tx, query string, args ...interface{}) (rows *sql.Rows, err error) {
+	_, span := tracing.Start(ctx, "sql.query", "db.system", string(db.Dialect()), "db.statement", query)
+	defer span.End()
+	rows, err = db.Query(tx, query, args...)
+	span.RecordError(err)
+	return
+}
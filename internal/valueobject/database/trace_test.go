@@ -0,0 +1,66 @@
+package database_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/sqlite"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTracedStatements tests a span is ended per statement, a child of the span of the context.
+func TestTracedStatements(t *testing.T) {
+	// Given a transaction of a SQLite database
+	pool, err := sqlite.NewSQLitePool(sqlite.NewSQLiteConfiguration(filepath.Join(t.TempDir(), "test.db")))
+	assert.Nil(t, err)
+	defer pool.Shutdown()
+	db, err := pool.Open()
+	assert.Nil(t, err)
+	defer pool.Close(db)
+	tx, err := pool.BeginTx(db)
+	assert.Nil(t, err)
+	defer pool.Rollback(tx)
+	// And a context carrying a span
+	tracer, recorder := tracingtest.NewTracer()
+	ctx, root := tracer.Start(context.Background(), "repository")
+	// When executing a statement, running a query and a wrong query
+	_, err = database.Exec(ctx, pool, tx, "CREATE TABLE numbers (n INTEGER)")
+	assert.Nil(t, err)
+	rows, err := database.Query(ctx, pool, tx, "SELECT n FROM numbers WHERE n > ?", 1)
+	assert.Nil(t, err)
+	rows.Close()
+	_, err = database.Query(ctx, pool, tx, "SELECT n FROM missing")
+	assert.NotNil(t, err)
+	root.End()
+	// Then a span is ended per statement, with its dialect and its statement
+	assert.Equal(t, []string{"sql.exec", "sql.query", "sql.query", "repository"}, recorder.Names())
+	spans := recorder.Spans()
+	assert.Equal(t, "sqlite", spans[0].Attributes["db.system"])
+	assert.Equal(t, "SELECT n FROM numbers WHERE n > ?", spans[1].Attributes["db.statement"])
+	assert.Equal(t, root.SpanContext().SpanID, spans[1].ParentSpanID)
+	// And the span of the wrong query has its error
+	assert.Empty(t, spans[1].Error)
+	assert.NotEmpty(t, spans[2].Error)
+}
+
+// TestUntracedStatements tests the statements run as is without a span in the context.
+func TestUntracedStatements(t *testing.T) {
+	// Given a transaction of a SQLite database
+	pool, err := sqlite.NewSQLitePool(sqlite.NewSQLiteConfiguration(filepath.Join(t.TempDir(), "test.db")))
+	assert.Nil(t, err)
+	defer pool.Shutdown()
+	db, err := pool.Open()
+	assert.Nil(t, err)
+	defer pool.Close(db)
+	tx, err := pool.BeginTx(db)
+	assert.Nil(t, err)
+	defer pool.Rollback(tx)
+	// When executing a statement without span
+	result, err := database.Exec(context.Background(), pool, tx, "CREATE TABLE numbers (n INTEGER)")
+	// Then the statement is executed
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+}
@@ -21,4 +21,20 @@ var (
 	ErrFileIsEmpty = errors.New("file is empty")
 	// ErrNilFileUseCases is the error returned when the file use cases is nil.
 	ErrNilFileUseCases = errors.New("file use cases is nil")
+	// ErrFileHeaderIsInvalid is the error returned when the file header is not Id,Date,Transaction.
+	ErrFileHeaderIsInvalid = errors.New("file header is invalid")
+	// ErrFileColumnCountIsInvalid is the error returned when a line does not have three columns.
+	ErrFileColumnCountIsInvalid = errors.New("file line must have 3 columns")
+	// ErrFileLineCouldNotBeParsed is the error returned when a line is not valid CSV.
+	ErrFileLineCouldNotBeParsed = errors.New("file line could not be parsed")
+	// ErrFileIDIsInvalid is the error returned when the Id column is not an integer.
+	ErrFileIDIsInvalid = errors.New("id must be an integer")
+	// ErrFileDateIsInvalid is the error returned when the Date column is not month/day.
+	ErrFileDateIsInvalid = errors.New("date must have the format month/day")
+	// ErrFileAmountIsInvalid is the error returned when the Transaction column is not a signed number.
+	ErrFileAmountIsInvalid = errors.New("transaction must be a number with an explicit sign")
+	// ErrFileHasInvalidLines is the error returned when a file has invalid lines.
+	ErrFileHasInvalidLines = errors.New("file has invalid lines")
+	// ErrFileNotInRequest is the error returned when the request has no "file" part.
+	ErrFileNotInRequest = errors.New("request has no file")
 )
@@ -0,0 +1,46 @@
+package file
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LineError struct defines why a line of a file is invalid. It matches ErrFileLineIsInvalid
+// and the error of the line with errors.Is, and wraps the error parsing the value.
+type LineError struct {
+	// Line is the 1-based line of the file, the header is the line 1. It is 0 when the
+	// line is not known.
+	Line int
+	// Column is the name of the invalid column, empty when the whole line is invalid.
+	Column string
+	// Value is the invalid value.
+	Value string
+	// Err is the error of the line, such as ErrFileDateIsInvalid.
+	Err error
+	// Cause is the error parsing the value, if any.
+	Cause error
+}
+
+func (lineErr *LineError) Error() string {
+	var builder strings.Builder
+	if lineErr.Line > 0 {
+		builder.WriteString("line " + strconv.Itoa(lineErr.Line) + ": ")
+	}
+	if lineErr.Column != "" {
+		builder.WriteString("column " + lineErr.Column + ": ")
+	}
+	builder.WriteString(lineErr.Err.Error())
+	if lineErr.Cause != nil {
+		builder.WriteString(": " + lineErr.Cause.Error())
+	}
+	return builder.String()
+}
+
+// Is returns true for ErrFileLineIsInvalid and the error of the line.
+func (lineErr *LineError) Is(target error) bool {
+	return target == ErrFileLineIsInvalid || target == lineErr.Err
+}
+
+func (lineErr *LineError) Unwrap() error {
+	return lineErr.Cause
+}
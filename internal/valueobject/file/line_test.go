@@ -0,0 +1,27 @@
+package file_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	voFile "github.com/braejan/go-transactions-summary/internal/valueobject/file"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLineError tests the error of a line matches its sentinels and keeps the cause.
+func TestLineError(t *testing.T) {
+	// Given the error parsing the Id of the line 3
+	_, cause := strconv.ParseInt("abc", 10, 64)
+	err := error(&voFile.LineError{Line: 3, Column: "Id", Value: "abc", Err: voFile.ErrFileIDIsInvalid, Cause: cause})
+	// Then it is an invalid line with an invalid Id
+	assert.ErrorIs(t, err, voFile.ErrFileLineIsInvalid)
+	assert.ErrorIs(t, err, voFile.ErrFileIDIsInvalid)
+	assert.NotErrorIs(t, err, voFile.ErrFileDateIsInvalid)
+	// And the cause is kept
+	var numErr *strconv.NumError
+	assert.True(t, errors.As(err, &numErr))
+	assert.Equal(t, `line 3: column Id: id must be an integer: strconv.ParseInt: parsing "abc": invalid syntax`, err.Error())
+	// And a line without line number, column nor cause only has its error
+	assert.Equal(t, voFile.ErrFileColumnCountIsInvalid.Error(), (&voFile.LineError{Err: voFile.ErrFileColumnCountIsInvalid}).Error())
+}
@@ -0,0 +1,45 @@
+// Package httpresponse inspects the responses written by the HTTP handlers.
+package httpresponse
+
+import "net/http"
+
+// StatusRecorder struct defines a response writer keeping the status code written by a
+// handler, for the middlewares logging, counting, tracing or validating the requests.
+type StatusRecorder struct {
+	http.ResponseWriter
+	// Status is the status code of the response, 200 until the handler writes one.
+	Status      int
+	wroteHeader bool
+}
+
+// NewStatusRecorder returns a StatusRecorder writing to the writer.
+func NewStatusRecorder(writer http.ResponseWriter) *StatusRecorder {
+	return &StatusRecorder{ResponseWriter: writer, Status: http.StatusOK}
+}
+
+// WriteHeader records the first status code, the one sent to the client, and writes it.
+func (recorder *StatusRecorder) WriteHeader(status int) {
+	if !recorder.wroteHeader {
+		recorder.Status = status
+		recorder.wroteHeader = true
+	}
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes the data, the status is 200 when the handler did not write one.
+func (recorder *StatusRecorder) Write(data []byte) (int, error) {
+	recorder.wroteHeader = true
+	return recorder.ResponseWriter.Write(data)
+}
+
+// Flush sends the buffered data to the client when the writer supports it.
+func (recorder *StatusRecorder) Flush() {
+	if flusher, ok := recorder.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the writer, so http.ResponseController reaches it.
+func (recorder *StatusRecorder) Unwrap() http.ResponseWriter {
+	return recorder.ResponseWriter
+}
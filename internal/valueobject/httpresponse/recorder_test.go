@@ -0,0 +1,50 @@
+package httpresponse_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/httpresponse"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStatusRecorder tests the status code written by a handler is recorded.
+func TestStatusRecorder(t *testing.T) {
+	// Given a recorder of a response
+	response := httptest.NewRecorder()
+	recorder := httpresponse.NewStatusRecorder(response)
+	// Then the status is 200 until the handler writes one
+	assert.Equal(t, http.StatusOK, recorder.Status)
+	// When the handler writes a status
+	recorder.WriteHeader(http.StatusTeapot)
+	// Then it is recorded and written
+	assert.Equal(t, http.StatusTeapot, recorder.Status)
+	assert.Equal(t, http.StatusTeapot, response.Code)
+}
+
+// TestStatusRecorderFirstStatus tests only the status sent to the client is recorded.
+func TestStatusRecorderFirstStatus(t *testing.T) {
+	// Given a recorder of a response
+	response := httptest.NewRecorder()
+	recorder := httpresponse.NewStatusRecorder(response)
+	// When the handler writes the body and then a status
+	_, err := recorder.Write([]byte("body"))
+	assert.Nil(t, err)
+	recorder.WriteHeader(http.StatusTeapot)
+	// Then the recorded status is the 200 sent with the body
+	assert.Equal(t, http.StatusOK, recorder.Status)
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+// TestStatusRecorderFlush tests the recorder flushes and unwraps the writer.
+func TestStatusRecorderFlush(t *testing.T) {
+	// Given a recorder of a response
+	response := httptest.NewRecorder()
+	recorder := httpresponse.NewStatusRecorder(response)
+	// When the handler flushes
+	recorder.Flush()
+	// Then the writer is flushed and reachable
+	assert.True(t, response.Flushed)
+	assert.Equal(t, response, recorder.Unwrap())
+}
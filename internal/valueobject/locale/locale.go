@@ -0,0 +1,162 @@
+package locale
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Spanish is the tag of the spanish locale.
+	Spanish = "es"
+	// English is the tag of the english locale.
+	English = "en"
+	// DefaultTag is the tag used when a user has no locale or an unknown one.
+	DefaultTag = Spanish
+)
+
+// Locale struct defines the language dependent settings used to render messages.
+type Locale struct {
+	// Tag is the language tag of the locale, e.g. "es".
+	Tag string
+	// DecimalSeparator separates the integer and the fractional part of a number.
+	DecimalSeparator string
+	// ThousandsSeparator groups the digits of the integer part of a number.
+	ThousandsSeparator string
+	// months are the month names, starting at January.
+	months [12]string
+	// messages are the translated texts indexed by key.
+	messages map[string]string
+}
+
+var locales = map[string]*Locale{
+	Spanish: {
+		Tag:                Spanish,
+		DecimalSeparator:   ",",
+		ThousandsSeparator: ".",
+		months: [12]string{
+			"enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre",
+		},
+		messages: map[string]string{
+			"summary.subject":         "Resumen de tus transacciones",
+			"summary.greeting":        "Hola",
+			"summary.intro":           "Este es el resumen de las transacciones que recibimos en tu archivo.",
+			"summary.balance":         "Saldo total",
+			"summary.total_credits":   "Total de créditos",
+			"summary.total_debits":    "Total de débitos",
+			"summary.average_credit":  "Crédito promedio",
+			"summary.average_debit":   "Débito promedio",
+			"summary.monthly":         "Transacciones por mes",
+			"summary.month":           "Mes",
+			"summary.count":           "Número de transacciones",
+			"summary.no_transactions": "No hay transacciones para mostrar.",
+			"summary.footer":          "Este es un mensaje automático, por favor no lo respondas.",
+		},
+	},
+	English: {
+		Tag:                English,
+		DecimalSeparator:   ".",
+		ThousandsSeparator: ",",
+		months: [12]string{
+			"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December",
+		},
+		messages: map[string]string{
+			"summary.subject":         "Your transactions summary",
+			"summary.greeting":        "Hi",
+			"summary.intro":           "This is the summary of the transactions we received in your file.",
+			"summary.balance":         "Total balance",
+			"summary.total_credits":   "Total credits",
+			"summary.total_debits":    "Total debits",
+			"summary.average_credit":  "Average credit",
+			"summary.average_debit":   "Average debit",
+			"summary.monthly":         "Transactions per month",
+			"summary.month":           "Month",
+			"summary.count":           "Number of transactions",
+			"summary.no_transactions": "There are no transactions to show.",
+			"summary.footer":          "This is an automated message, please do not reply.",
+		},
+	},
+}
+
+// Get returns the locale for the given tag. Region subtags are ignored ("en-US" is "en")
+// and unknown or empty tags fall back to the default locale.
+func Get(tag string) (locale *Locale) {
+	locale, ok := locales[normalize(tag)]
+	if !ok {
+		locale = locales[DefaultTag]
+	}
+	return
+}
+
+// IsSupported returns true if there is a locale for the given tag.
+func IsSupported(tag string) (supported bool) {
+	_, supported = locales[normalize(tag)]
+	return
+}
+
+// normalize returns the lower case language of the tag without region subtags.
+func normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if index := strings.IndexAny(tag, "-_"); index >= 0 {
+		tag = tag[:index]
+	}
+	return tag
+}
+
+// Translate returns the text for the given key. The key itself is returned when
+// the locale has no text for it.
+func (locale *Locale) Translate(key string) (text string) {
+	text, ok := locale.messages[key]
+	if !ok {
+		text = key
+	}
+	return
+}
+
+// MonthName returns the name of the month in the locale language.
+func (locale *Locale) MonthName(month time.Month) (name string) {
+	if month < time.January || month > time.December {
+		return
+	}
+	name = locale.months[month-1]
+	return
+}
+
+// FormatNumber formats the value rounded to the given decimals using the locale separators.
+func (locale *Locale) FormatNumber(value float64, decimals int) (formatted string) {
+	factor := math.Pow(10, float64(decimals))
+	value = math.Round(value*factor) / factor
+	sign := ""
+	if value < 0 {
+		sign = "-"
+	}
+	value = math.Abs(value)
+	digits := strconv.FormatFloat(value, 'f', decimals, 64)
+	integer, fraction, _ := strings.Cut(digits, ".")
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteString(locale.ThousandsSeparator)
+		}
+		grouped.WriteRune(digit)
+	}
+	formatted = sign + grouped.String()
+	if fraction != "" {
+		formatted += locale.DecimalSeparator + fraction
+	}
+	return
+}
+
+// FormatMoney formats the value as an amount of money with two decimals.
+func (locale *Locale) FormatMoney(value float64) (formatted string) {
+	formatted = locale.FormatNumber(value, 2)
+	if strings.HasPrefix(formatted, "-") {
+		formatted = "-$" + formatted[1:]
+		return
+	}
+	formatted = "$" + formatted
+	return
+}
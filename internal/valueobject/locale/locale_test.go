@@ -0,0 +1,75 @@
+package locale_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/locale"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetFallsBackToDefault tests the Get function with an unknown tag.
+func TestGetFallsBackToDefault(t *testing.T) {
+	// Given an unknown tag
+	tag := "fr"
+	// When call Get
+	loc := locale.Get(tag)
+	// Then the default locale is returned
+	assert.Equal(t, locale.DefaultTag, loc.Tag)
+}
+
+// TestGetIgnoresRegion tests the Get function with a region subtag.
+func TestGetIgnoresRegion(t *testing.T) {
+	// Given a tag with a region subtag
+	tag := "en-US"
+	// When call Get
+	loc := locale.Get(tag)
+	// Then the english locale is returned
+	assert.Equal(t, locale.English, loc.Tag)
+}
+
+// TestFormatNumber tests the FormatNumber method for each locale.
+func TestFormatNumber(t *testing.T) {
+	// Given a spanish and an english locale
+	spanish := locale.Get(locale.Spanish)
+	english := locale.Get(locale.English)
+	// When call FormatNumber
+	// Then the number is formatted with the locale separators
+	assert.Equal(t, "1.234.567,89", spanish.FormatNumber(1234567.891, 2))
+	assert.Equal(t, "1,234,567.89", english.FormatNumber(1234567.891, 2))
+	assert.Equal(t, "-10,30", spanish.FormatNumber(-10.3, 2))
+	assert.Equal(t, "999", english.FormatNumber(999, 0))
+	assert.Equal(t, "0,00", spanish.FormatNumber(-0.001, 2))
+}
+
+// TestFormatMoney tests the FormatMoney method.
+func TestFormatMoney(t *testing.T) {
+	// Given an english locale
+	english := locale.Get(locale.English)
+	// When call FormatMoney
+	// Then the amount has the currency symbol after the sign
+	assert.Equal(t, "$1,060.50", english.FormatMoney(1060.5))
+	assert.Equal(t, "-$20.46", english.FormatMoney(-20.46))
+}
+
+// TestMonthName tests the MonthName method.
+func TestMonthName(t *testing.T) {
+	// Given a spanish and an english locale
+	spanish := locale.Get(locale.Spanish)
+	english := locale.Get(locale.English)
+	// When call MonthName
+	// Then the month name is in the locale language
+	assert.Equal(t, "julio", spanish.MonthName(time.July))
+	assert.Equal(t, "July", english.MonthName(time.July))
+	assert.Equal(t, "", english.MonthName(time.Month(13)))
+}
+
+// TestTranslate tests the Translate method.
+func TestTranslate(t *testing.T) {
+	// Given a spanish locale
+	spanish := locale.Get(locale.Spanish)
+	// When call Translate with a known and an unknown key
+	// Then the text is returned or the key itself
+	assert.Equal(t, "Saldo total", spanish.Translate("summary.balance"))
+	assert.Equal(t, "unknown.key", spanish.Translate("unknown.key"))
+}
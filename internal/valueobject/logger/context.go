@@ -0,0 +1,49 @@
+package logger
+
+import "context"
+
+// contextKey is the type of the keys of the IDs stored in a context.
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	fileIDKey
+)
+
+// WithRequestID returns a copy of the context carrying the ID of the request.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the ID of the request carried by the context, empty when there is none.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithFileID returns a copy of the context carrying the ID of the file or the job processing it.
+func WithFileID(ctx context.Context, fileID string) context.Context {
+	return context.WithValue(ctx, fileIDKey, fileID)
+}
+
+// FileID returns the ID of the file carried by the context, empty when there is none.
+func FileID(ctx context.Context) string {
+	fileID, _ := ctx.Value(fileIDKey).(string)
+	return fileID
+}
+
+// FromContext returns a logger adding the request and the file IDs carried by the context
+// to every line.
+func FromContext(ctx context.Context, logger Logger) Logger {
+	args := []interface{}{}
+	if requestID := RequestID(ctx); requestID != "" {
+		args = append(args, "request_id", requestID)
+	}
+	if fileID := FileID(ctx); fileID != "" {
+		args = append(args, "file_id", fileID)
+	}
+	if len(args) == 0 {
+		return logger
+	}
+	return logger.With(args...)
+}
@@ -0,0 +1,12 @@
+package logger
+
+import "errors"
+
+var (
+	// ErrNilConfiguration is the error returned when the logger configuration is nil.
+	ErrNilConfiguration = errors.New("logger configuration is nil")
+	// ErrUnknownLevel is the error returned when the log level is unknown.
+	ErrUnknownLevel = errors.New("unknown log level")
+	// ErrNilLogger is the error returned when the logger is nil.
+	ErrNilLogger = errors.New("logger is nil")
+)
@@ -0,0 +1,245 @@
+// Package logger provides the structured logger of the application. Every line is a JSON
+// object with the time, the level, the message and the fields of the line, so the lines of
+// a request or a file can be filtered by their IDs.
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log line.
+type Level int
+
+const (
+	// LevelDebug logs the details of every step, such as the sampled rows of a file.
+	LevelDebug Level = iota
+	// LevelInfo logs the progress of the requests and the files.
+	LevelInfo
+	// LevelWarn logs the failures that are retried or ignored.
+	LevelWarn
+	// LevelError logs the failures returned to the caller.
+	LevelError
+)
+
+// String returns the name of the level.
+func (level Level) String() string {
+	switch level {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+	return "level(" + strconv.Itoa(int(level)) + ")"
+}
+
+// ParseLevel returns the level of the name, in any case.
+func ParseLevel(name string) (level Level, err error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		level = LevelDebug
+	case "info", "":
+		level = LevelInfo
+	case "warn", "warning":
+		level = LevelWarn
+	case "error":
+		level = LevelError
+	default:
+		err = ErrUnknownLevel
+	}
+	return
+}
+
+// Logger interface defines a structured logger. The args of a line are key-value pairs,
+// a key without value is logged with the "!BADKEY" key.
+type Logger interface {
+	// Debug logs a line at LevelDebug.
+	Debug(msg string, args ...interface{})
+	// Info logs a line at LevelInfo.
+	Info(msg string, args ...interface{})
+	// Warn logs a line at LevelWarn.
+	Warn(msg string, args ...interface{})
+	// Error logs a line at LevelError.
+	Error(msg string, args ...interface{})
+	// With returns a logger adding the key-value pairs to every line.
+	With(args ...interface{}) Logger
+	// Enabled returns true when the lines of the level are logged.
+	Enabled(level Level) bool
+}
+
+// LoggerConfiguration struct defines the configuration of the logger.
+type LoggerConfiguration struct {
+	// Level is the name of the minimum level logged.
+	Level string
+	// RowSampling logs one of every RowSampling rows of a file at LevelDebug, 0 disables
+	// the row lines.
+	RowSampling int
+}
+
+// NewDefaultLoggerConfiguration returns the configuration used when nothing is set.
+func NewDefaultLoggerConfiguration() (configuration *LoggerConfiguration) {
+	configuration = &LoggerConfiguration{
+		Level:       LevelInfo.String(),
+		RowSampling: 1000,
+	}
+	return
+}
+
+// NewLoggerConfigurationFromEnv returns the configuration from the environment variables.
+func NewLoggerConfigurationFromEnv() (configuration *LoggerConfiguration) {
+	configuration = NewDefaultLoggerConfiguration()
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		configuration.Level = level
+	}
+	if sampling, err := strconv.Atoi(os.Getenv("LOG_ROW_SAMPLING")); err == nil && sampling >= 0 {
+		configuration.RowSampling = sampling
+	}
+	return
+}
+
+// NewLogger returns a logger writing JSON lines to the standard error at the configured level.
+func NewLogger(configuration *LoggerConfiguration) (logger Logger, err error) {
+	if configuration == nil {
+		err = ErrNilConfiguration
+		return
+	}
+	level, err := ParseLevel(configuration.Level)
+	if err != nil {
+		return
+	}
+	logger = NewJSONLogger(os.Stderr, level)
+	return
+}
+
+// NewDefaultLogger returns the logger used when none is injected, it writes JSON lines to
+// the standard error at LevelInfo.
+func NewDefaultLogger() Logger {
+	return NewJSONLogger(os.Stderr, LevelInfo)
+}
+
+// Sampled returns true when the line is one of the logged rows of a file, the first one
+// and one of every rowSampling after it.
+func Sampled(line int, rowSampling int) bool {
+	return rowSampling > 0 && (line-1)%rowSampling == 0
+}
+
+// jsonLogger struct implements the Logger interface writing a JSON object per line.
+type jsonLogger struct {
+	// mutex serializes the lines of the loggers sharing the writer.
+	mutex *sync.Mutex
+	// writer receives the lines.
+	writer io.Writer
+	// level is the minimum level logged.
+	level Level
+	// fields are the encoded key-value pairs added by With.
+	fields []byte
+}
+
+// NewJSONLogger returns a logger writing JSON lines of the level and above to the writer.
+func NewJSONLogger(writer io.Writer, level Level) Logger {
+	return &jsonLogger{
+		mutex:  &sync.Mutex{},
+		writer: writer,
+		level:  level,
+	}
+}
+
+// Logger interface implementation
+
+func (logger *jsonLogger) Debug(msg string, args ...interface{}) {
+	logger.log(LevelDebug, msg, args)
+}
+
+func (logger *jsonLogger) Info(msg string, args ...interface{}) {
+	logger.log(LevelInfo, msg, args)
+}
+
+func (logger *jsonLogger) Warn(msg string, args ...interface{}) {
+	logger.log(LevelWarn, msg, args)
+}
+
+func (logger *jsonLogger) Error(msg string, args ...interface{}) {
+	logger.log(LevelError, msg, args)
+}
+
+func (logger *jsonLogger) With(args ...interface{}) Logger {
+	fields := append([]byte{}, logger.fields...)
+	return &jsonLogger{
+		mutex:  logger.mutex,
+		writer: logger.writer,
+		level:  logger.level,
+		fields: appendFields(fields, args),
+	}
+}
+
+func (logger *jsonLogger) Enabled(level Level) bool {
+	return level >= logger.level
+}
+
+// log writes the line when its level is enabled.
+func (logger *jsonLogger) log(level Level, msg string, args []interface{}) {
+	if !logger.Enabled(level) {
+		return
+	}
+	line := bytes.NewBufferString(`{"time":`)
+	line.Write(encode(time.Now().UTC().Format(time.RFC3339Nano)))
+	line.WriteString(`,"level":`)
+	line.Write(encode(level.String()))
+	line.WriteString(`,"msg":`)
+	line.Write(encode(msg))
+	line.Write(logger.fields)
+	line.Write(appendFields(nil, args))
+	line.WriteString("}\n")
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	_, _ = logger.writer.Write(line.Bytes())
+}
+
+// appendFields appends the key-value pairs to the encoded fields, each one preceded by a comma.
+func appendFields(fields []byte, args []interface{}) []byte {
+	for i := 0; i < len(args); i += 2 {
+		key, ok := args[i].(string)
+		if !ok || i+1 == len(args) {
+			fields = appendField(fields, "!BADKEY", args[i])
+			i--
+			continue
+		}
+		fields = appendField(fields, key, args[i+1])
+	}
+	return fields
+}
+
+func appendField(fields []byte, key string, value interface{}) []byte {
+	fields = append(fields, ',')
+	fields = append(fields, encode(key)...)
+	fields = append(fields, ':')
+	switch v := value.(type) {
+	case error:
+		value = v.Error()
+	case time.Duration:
+		value = v.String()
+	case fmt.Stringer:
+		value = v.String()
+	}
+	return append(fields, encode(value)...)
+}
+
+// encode returns the JSON of the value, or its fmt representation when it cannot be encoded.
+func encode(value interface{}) []byte {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		encoded, _ = json.Marshal(fmt.Sprintf("%+v", value))
+	}
+	return encoded
+}
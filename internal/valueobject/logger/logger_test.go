@@ -0,0 +1,113 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// lines decodes the JSON lines written to the buffer.
+func lines(t *testing.T, buffer *bytes.Buffer) (decoded []map[string]interface{}) {
+	t.Helper()
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal([]byte(line), &fields), line)
+		decoded = append(decoded, fields)
+	}
+	return
+}
+
+// TestJSONLogger tests the lines are JSON objects with the level, the message and the fields.
+func TestJSONLogger(t *testing.T) {
+	// Given a logger at LevelInfo
+	buffer := &bytes.Buffer{}
+	log := logger.NewJSONLogger(buffer, logger.LevelInfo).With("component", "test")
+	// When logging a line of every level
+	log.Debug("hidden", "line", 1)
+	log.Info("file processed", "file", "txns.csv", "lines", 4)
+	log.Error("file failed", "error", errors.New("boom"), "dangling")
+	// Then the debug line is not written
+	decoded := lines(t, buffer)
+	assert.Len(t, decoded, 2)
+	// And the lines have the fields of the logger and of the line
+	assert.Equal(t, "info", decoded[0]["level"])
+	assert.Equal(t, "file processed", decoded[0]["msg"])
+	assert.Equal(t, "test", decoded[0]["component"])
+	assert.Equal(t, "txns.csv", decoded[0]["file"])
+	assert.Equal(t, float64(4), decoded[0]["lines"])
+	assert.NotEmpty(t, decoded[0]["time"])
+	// And errors are logged by their message, a key without value as a bad key
+	assert.Equal(t, "error", decoded[1]["level"])
+	assert.Equal(t, "boom", decoded[1]["error"])
+	assert.Equal(t, "dangling", decoded[1]["!BADKEY"])
+}
+
+// TestFromContext tests the IDs carried by the context are added to the lines.
+func TestFromContext(t *testing.T) {
+	// Given a context with a request and a file ID
+	buffer := &bytes.Buffer{}
+	ctx := logger.WithFileID(logger.WithRequestID(context.Background(), "request-1"), "file-1")
+	// When logging from the context
+	logger.FromContext(ctx, logger.NewJSONLogger(buffer, logger.LevelDebug)).Debug("row read")
+	// Then the line has both IDs
+	decoded := lines(t, buffer)
+	assert.Len(t, decoded, 1)
+	assert.Equal(t, "request-1", decoded[0]["request_id"])
+	assert.Equal(t, "file-1", decoded[0]["file_id"])
+	// And a context without IDs has none
+	assert.Empty(t, logger.RequestID(context.Background()))
+	assert.Empty(t, logger.FileID(context.Background()))
+}
+
+// TestParseLevel tests the names of the levels.
+func TestParseLevel(t *testing.T) {
+	for name, expected := range map[string]logger.Level{
+		"debug": logger.LevelDebug, "INFO": logger.LevelInfo, "": logger.LevelInfo,
+		"warning": logger.LevelWarn, "error": logger.LevelError,
+	} {
+		level, err := logger.ParseLevel(name)
+		assert.Nil(t, err, name)
+		assert.Equal(t, expected, level, name)
+	}
+	_, err := logger.ParseLevel("verbose")
+	assert.Equal(t, logger.ErrUnknownLevel, err)
+}
+
+// TestNewLoggerConfigurationFromEnv tests the configuration from the environment variables.
+func TestNewLoggerConfigurationFromEnv(t *testing.T) {
+	// Given the logger environment variables
+	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("LOG_ROW_SAMPLING", "0")
+	// When reading the configuration
+	configuration := logger.NewLoggerConfigurationFromEnv()
+	// Then the level and the row sampling are set
+	assert.Equal(t, "debug", configuration.Level)
+	assert.Equal(t, 0, configuration.RowSampling)
+	// And the logger is built from it
+	_, err := logger.NewLogger(configuration)
+	assert.Nil(t, err)
+	// And an unknown level or a nil configuration is an error
+	configuration.Level = "verbose"
+	_, err = logger.NewLogger(configuration)
+	assert.Equal(t, logger.ErrUnknownLevel, err)
+	_, err = logger.NewLogger(nil)
+	assert.Equal(t, logger.ErrNilConfiguration, err)
+}
+
+// TestSampled tests the sampled rows of a file.
+func TestSampled(t *testing.T) {
+	assert.True(t, logger.Sampled(1, 1000))
+	assert.False(t, logger.Sampled(2, 1000))
+	assert.True(t, logger.Sampled(1001, 1000))
+	assert.True(t, logger.Sampled(7, 1))
+	assert.False(t, logger.Sampled(1, 0))
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/httpresponse"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the header carrying the ID of a request.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the longest request ID accepted from a client.
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware returns a middleware carrying the ID of every request in its context
+// and its response. The ID of the X-Request-ID header is kept, a new one is generated when
+// it is missing or too long. A line is logged when the request is completed.
+func RequestIDMiddleware(logger Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			requestID := request.Header.Get(RequestIDHeader)
+			if requestID == "" || len(requestID) > maxRequestIDLength {
+				requestID = uuid.New().String()
+			}
+			writer.Header().Set(RequestIDHeader, requestID)
+			request = request.WithContext(WithRequestID(request.Context(), requestID))
+			recorder := httpresponse.NewStatusRecorder(writer)
+			start := time.Now()
+			next.ServeHTTP(recorder, request)
+			FromContext(request.Context(), logger).Info("request completed",
+				"method", request.Method,
+				"path", request.URL.Path,
+				"status", recorder.Status,
+				"duration_ms", time.Since(start).Milliseconds(),
+			)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package logger_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRequestIDMiddleware tests the ID of the request is kept in its context and its response.
+func TestRequestIDMiddleware(t *testing.T) {
+	// Given a handler behind the middleware
+	buffer := &bytes.Buffer{}
+	var seen string
+	handler := logger.RequestIDMiddleware(logger.NewJSONLogger(buffer, logger.LevelInfo))(
+		http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			seen = logger.RequestID(request.Context())
+			writer.WriteHeader(http.StatusCreated)
+		}))
+	// When a request with an ID is served
+	request := httptest.NewRequest(http.MethodPost, "/loadfile", nil)
+	request.Header.Set(logger.RequestIDHeader, "request-1")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	// Then the handler and the response have its ID
+	assert.Equal(t, "request-1", seen)
+	assert.Equal(t, "request-1", recorder.Header().Get(logger.RequestIDHeader))
+	// And the completed request is logged with its status
+	decoded := lines(t, buffer)
+	assert.Len(t, decoded, 1)
+	assert.Equal(t, "request-1", decoded[0]["request_id"])
+	assert.Equal(t, float64(http.StatusCreated), decoded[0]["status"])
+	assert.Equal(t, "/loadfile", decoded[0]["path"])
+}
+
+// TestRequestIDMiddlewareGeneratesID tests an ID is generated when the request has a
+// missing or too long one.
+func TestRequestIDMiddlewareGeneratesID(t *testing.T) {
+	handler := logger.RequestIDMiddleware(logger.NewJSONLogger(&bytes.Buffer{}, logger.LevelInfo))(
+		http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	for _, requestID := range []string{"", strings.Repeat("x", 129)} {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set(logger.RequestIDHeader, requestID)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		generated := recorder.Header().Get(logger.RequestIDHeader)
+		assert.NotEmpty(t, generated)
+		assert.NotEqual(t, requestID, generated)
+	}
+}
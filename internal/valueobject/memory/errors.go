@@ -0,0 +1,8 @@
+package memory
+
+import "errors"
+
+var (
+	// ErrReadOnlyTransaction is the error returned when writing in a read transaction.
+	ErrReadOnlyTransaction = errors.New("memory transaction is read only")
+)
@@ -0,0 +1,133 @@
+// Package memory provides an in-memory database for the memory repositories, so the whole
+// application runs without an external database in demos and tests.
+package memory
+
+import "sync"
+
+// Database struct is an in-memory database of tables of rows by primary key. A single lock
+// guards every table, so a write spanning several tables is atomic and isolated, like a
+// transaction of a SQL database.
+type Database struct {
+	lock   sync.RWMutex
+	tables map[string]*table
+}
+
+// table struct keeps the rows of a table in insertion order.
+type table struct {
+	keys []string
+	rows map[string]interface{}
+}
+
+// NewDatabase returns a new empty Database.
+func NewDatabase() (database *Database) {
+	database = &Database{tables: map[string]*table{}}
+	return
+}
+
+// Read runs fn in a read transaction. Concurrent reads do not block each other.
+func (database *Database) Read(fn func(tx *Tx) error) (err error) {
+	database.lock.RLock()
+	defer database.lock.RUnlock()
+	err = fn(&Tx{database: database})
+	return
+}
+
+// Write runs fn in a write transaction. The rows put by fn are committed when it returns
+// nil and discarded otherwise.
+func (database *Database) Write(fn func(tx *Tx) error) (err error) {
+	database.lock.Lock()
+	defer database.lock.Unlock()
+	tx := &Tx{database: database, writable: true, changes: map[string]*table{}}
+	if err = fn(tx); err != nil {
+		return
+	}
+	for name, changes := range tx.changes {
+		committed := database.table(name)
+		for _, key := range changes.keys {
+			if _, ok := committed.rows[key]; !ok {
+				committed.keys = append(committed.keys, key)
+			}
+			committed.rows[key] = changes.rows[key]
+		}
+	}
+	return
+}
+
+// table returns the committed table with the given name, creating it when it does not exist.
+func (database *Database) table(name string) (t *table) {
+	t, ok := database.tables[name]
+	if !ok {
+		t = &table{rows: map[string]interface{}{}}
+		database.tables[name] = t
+	}
+	return
+}
+
+// Tx struct is a transaction of the in-memory database.
+type Tx struct {
+	database *Database
+	writable bool
+	changes  map[string]*table
+}
+
+// Get returns the row of the table with the given key.
+func (tx *Tx) Get(tableName string, key string) (row interface{}, ok bool) {
+	if changes, changed := tx.changes[tableName]; changed {
+		if row, ok = changes.rows[key]; ok {
+			return
+		}
+	}
+	if committed, exists := tx.database.tables[tableName]; exists {
+		row, ok = committed.rows[key]
+	}
+	return
+}
+
+// Put creates or replaces the row of the table with the given key.
+func (tx *Tx) Put(tableName string, key string, row interface{}) (err error) {
+	if !tx.writable {
+		err = ErrReadOnlyTransaction
+		return
+	}
+	changes, ok := tx.changes[tableName]
+	if !ok {
+		changes = &table{rows: map[string]interface{}{}}
+		tx.changes[tableName] = changes
+	}
+	if _, exists := changes.rows[key]; !exists {
+		changes.keys = append(changes.keys, key)
+	}
+	changes.rows[key] = row
+	return
+}
+
+// Scan calls fn with every row of the table in insertion order, until fn returns false.
+func (tx *Tx) Scan(tableName string, fn func(row interface{}) bool) {
+	changes := tx.changes[tableName]
+	if committed, ok := tx.database.tables[tableName]; ok {
+		for _, key := range committed.keys {
+			row := committed.rows[key]
+			if changes != nil {
+				if changed, ok := changes.rows[key]; ok {
+					row = changed
+				}
+			}
+			if !fn(row) {
+				return
+			}
+		}
+	}
+	if changes == nil {
+		return
+	}
+	for _, key := range changes.keys {
+		if tx.database.tables[tableName] != nil {
+			if _, ok := tx.database.tables[tableName].rows[key]; ok {
+				continue
+			}
+		}
+		if !fn(changes.rows[key]) {
+			return
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package memory_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// scanTable returns the rows of the table in scan order.
+func scanTable(tx *memory.Tx, tableName string) (rows []interface{}) {
+	tx.Scan(tableName, func(row interface{}) bool {
+		rows = append(rows, row)
+		return true
+	})
+	return
+}
+
+// TestWriteCommitsRows tests the rows put in a successful write are committed.
+func TestWriteCommitsRows(t *testing.T) {
+	// Given an empty database
+	database := memory.NewDatabase()
+	// When putting two rows
+	err := database.Write(func(tx *memory.Tx) error {
+		_ = tx.Put("users", "1", "first")
+		return tx.Put("users", "2", "second")
+	})
+	// Then they are read in insertion order
+	assert.Nil(t, err)
+	_ = database.Read(func(tx *memory.Tx) error {
+		row, ok := tx.Get("users", "1")
+		assert.True(t, ok)
+		assert.Equal(t, "first", row)
+		assert.Equal(t, []interface{}{"first", "second"}, scanTable(tx, "users"))
+		return nil
+	})
+}
+
+// TestWriteDiscardsRowsOnError tests the rows put in a failing write are discarded.
+func TestWriteDiscardsRowsOnError(t *testing.T) {
+	// Given a database with a row
+	database := memory.NewDatabase()
+	_ = database.Write(func(tx *memory.Tx) error { return tx.Put("users", "1", "first") })
+	errFailing := errors.New("failing")
+	// When a write replacing it and adding another one fails
+	err := database.Write(func(tx *memory.Tx) error {
+		_ = tx.Put("users", "1", "replaced")
+		_ = tx.Put("users", "2", "second")
+		// And the write reads its own changes
+		assert.Equal(t, []interface{}{"replaced", "second"}, scanTable(tx, "users"))
+		return errFailing
+	})
+	// Then the error is returned
+	assert.Equal(t, errFailing, err)
+	// And nothing changed
+	_ = database.Read(func(tx *memory.Tx) error {
+		assert.Equal(t, []interface{}{"first"}, scanTable(tx, "users"))
+		return nil
+	})
+}
+
+// TestReadIsReadOnly tests the error returned when writing in a read transaction.
+func TestReadIsReadOnly(t *testing.T) {
+	// Given an empty database
+	database := memory.NewDatabase()
+	// When putting a row in a read transaction
+	err := database.Read(func(tx *memory.Tx) error { return tx.Put("users", "1", "first") })
+	// Then the error returned is ErrReadOnlyTransaction
+	assert.Equal(t, memory.ErrReadOnlyTransaction, err)
+}
+
+// TestConcurrentWrites tests concurrent writes do not lose rows.
+func TestConcurrentWrites(t *testing.T) {
+	// Given an empty database
+	database := memory.NewDatabase()
+	// When incrementing a counter concurrently
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = database.Write(func(tx *memory.Tx) error {
+				count, _ := tx.Get("counters", "count")
+				next, _ := count.(int)
+				return tx.Put("counters", "count", next+1)
+			})
+		}()
+	}
+	wg.Wait()
+	// Then every increment is kept
+	_ = database.Read(func(tx *memory.Tx) error {
+		count, _ := tx.Get("counters", "count")
+		assert.Equal(t, 50, count)
+		return nil
+	})
+}
@@ -0,0 +1,8 @@
+package metrics
+
+// RepositoryLatency returns the histogram of the latency of the repository methods, per
+// repository and method.
+func RepositoryLatency(registry *Registry) *Histogram {
+	return registry.Histogram("repository_query_duration_seconds", "Latency of the repository methods.",
+		DefaultBuckets, "repository", "method")
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/httpresponse"
+	"github.com/gorilla/mux"
+)
+
+// ContentType is the content type of the Prometheus text format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Handler returns the handler serving the metrics of the registry.
+func (registry *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", ContentType)
+		_ = registry.Write(writer)
+	})
+}
+
+// HTTPMiddleware returns a middleware counting the requests and observing their latency
+// per method, route template and status, so the IDs in a path do not create new series.
+func (registry *Registry) HTTPMiddleware() func(next http.Handler) http.Handler {
+	requests := registry.Counter("http_requests_total", "HTTP requests served.", "method", "route", "status")
+	latency := registry.Histogram("http_request_duration_seconds", "Latency of the HTTP requests.",
+		DefaultBuckets, "method", "route")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			recorder := httpresponse.NewStatusRecorder(writer)
+			start := time.Now()
+			next.ServeHTTP(recorder, request)
+			// The router only runs its middlewares for the matched routes.
+			route, _ := mux.CurrentRoute(request).GetPathTemplate()
+			latency.ObserveSince(start, request.Method, route)
+			requests.Inc(request.Method, route, strconv.Itoa(recorder.Status))
+		})
+	}
+}
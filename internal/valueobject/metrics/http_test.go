@@ -0,0 +1,34 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHTTPMiddleware tests the requests are counted by their route template.
+func TestHTTPMiddleware(t *testing.T) {
+	// Given a router with the middleware and the metrics endpoint
+	registry := metrics.NewRegistry()
+	router := mux.NewRouter()
+	router.Use(registry.HTTPMiddleware())
+	router.HandleFunc("/accounts/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+	}).Methods("GET")
+	router.Handle("/metrics", registry.Handler()).Methods("GET")
+	// When two accounts are requested
+	for _, path := range []string{"/accounts/1", "/accounts/2"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	// Then the metrics have one series for the route
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, metrics.ContentType, recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), `http_requests_total{method="GET",route="/accounts/{id}",status="404"} 2`)
+	assert.Contains(t, recorder.Body.String(), `http_request_duration_seconds_count{method="GET",route="/accounts/{id}"} 2`)
+}
@@ -0,0 +1,277 @@
+// Package metrics provides the metrics of the application in the Prometheus text format.
+// The metrics are registered by name, so the decorators of every repository and use case
+// share the series of a metric.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds in seconds of the latency histograms.
+var DefaultBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// collector interface defines a registered metric.
+type collector interface {
+	// write writes the HELP, the TYPE and the series of the metric.
+	write(writer *bufio.Writer)
+}
+
+// Registry struct holds the metrics of the application.
+type Registry struct {
+	mutex      sync.Mutex
+	names      []string
+	collectors map[string]collector
+}
+
+// NewRegistry returns a new empty Registry instance.
+func NewRegistry() *Registry {
+	return &Registry{collectors: map[string]collector{}}
+}
+
+// register returns the metric registered with the name, or registers the new one.
+func (registry *Registry) register(name string, newCollector func() collector) collector {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if registered, ok := registry.collectors[name]; ok {
+		return registered
+	}
+	registered := newCollector()
+	registry.names = append(registry.names, name)
+	registry.collectors[name] = registered
+	return registered
+}
+
+// Counter returns the counter with the name, registering it on the first call. It panics
+// when the name is registered with another type.
+func (registry *Registry) Counter(name, help string, labels ...string) *Counter {
+	return registry.register(name, func() collector {
+		return &Counter{family: newFamily(name, help, "counter", labels)}
+	}).(*Counter)
+}
+
+// Histogram returns the histogram with the name and the buckets, registering it on the
+// first call. It panics when the name is registered with another type.
+func (registry *Registry) Histogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return registry.register(name, func() collector {
+		return &Histogram{family: newFamily(name, help, "histogram", labels), buckets: buckets}
+	}).(*Histogram)
+}
+
+// GaugeFunc registers a gauge whose value is returned by the function when the metrics
+// are written.
+func (registry *Registry) GaugeFunc(name, help string, value func() float64) {
+	registry.register(name, func() collector {
+		return &valueFunc{family: newFamily(name, help, "gauge", nil), value: value}
+	})
+}
+
+// CounterFunc registers a counter whose value is returned by the function when the
+// metrics are written.
+func (registry *Registry) CounterFunc(name, help string, value func() float64) {
+	registry.register(name, func() collector {
+		return &valueFunc{family: newFamily(name, help, "counter", nil), value: value}
+	})
+}
+
+// Write writes the metrics in the Prometheus text format, in order of registration.
+func (registry *Registry) Write(writer io.Writer) (err error) {
+	registry.mutex.Lock()
+	collectors := make([]collector, 0, len(registry.names))
+	for _, name := range registry.names {
+		collectors = append(collectors, registry.collectors[name])
+	}
+	registry.mutex.Unlock()
+	buffered := bufio.NewWriter(writer)
+	for _, registered := range collectors {
+		registered.write(buffered)
+	}
+	err = buffered.Flush()
+	return
+}
+
+// family struct holds the name, the help and the label names of a metric.
+type family struct {
+	mutex  sync.Mutex
+	name   string
+	help   string
+	kind   string
+	labels []string
+}
+
+func newFamily(name, help, kind string, labels []string) family {
+	return family{name: name, help: help, kind: kind, labels: labels}
+}
+
+// key returns the key of the series of the label values, it panics when the number of
+// values is not the number of labels.
+func (metric *family) key(values []string) string {
+	if len(values) != len(metric.labels) {
+		panic(fmt.Sprintf("metric %s has %d labels, got %d values", metric.name, len(metric.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// writeHeader writes the HELP and the TYPE lines of the metric.
+func (metric *family) writeHeader(writer *bufio.Writer) {
+	help := strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(metric.help)
+	fmt.Fprintf(writer, "# HELP %s %s\n# TYPE %s %s\n", metric.name, help, metric.name, metric.kind)
+}
+
+// writeSample writes a sample of the series with the label values and the extra label.
+func (metric *family) writeSample(writer *bufio.Writer, suffix string, values []string, extraLabel, extraValue string, value float64) {
+	writer.WriteString(metric.name + suffix)
+	pairs := []string{}
+	for i, label := range metric.labels {
+		pairs = append(pairs, label+`="`+escapeLabel(values[i])+`"`)
+	}
+	if extraLabel != "" {
+		pairs = append(pairs, extraLabel+`="`+extraValue+`"`)
+	}
+	if len(pairs) > 0 {
+		writer.WriteString("{" + strings.Join(pairs, ",") + "}")
+	}
+	writer.WriteString(" " + formatValue(value) + "\n")
+}
+
+// Counter struct is a metric that only increases, with a series per label values.
+type Counter struct {
+	family
+	series map[string]*counterSeries
+}
+
+type counterSeries struct {
+	values []string
+	value  float64
+}
+
+// Inc adds one to the series of the label values.
+func (counter *Counter) Inc(values ...string) {
+	counter.Add(1, values...)
+}
+
+// Add adds the delta to the series of the label values, negative deltas are ignored.
+func (counter *Counter) Add(delta float64, values ...string) {
+	if delta < 0 {
+		return
+	}
+	key := counter.key(values)
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+	if counter.series == nil {
+		counter.series = map[string]*counterSeries{}
+	}
+	series, ok := counter.series[key]
+	if !ok {
+		series = &counterSeries{values: append([]string{}, values...)}
+		counter.series[key] = series
+	}
+	series.value += delta
+}
+
+func (counter *Counter) write(writer *bufio.Writer) {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+	counter.writeHeader(writer)
+	for _, key := range sortedKeys(counter.series) {
+		series := counter.series[key]
+		counter.writeSample(writer, "", series.values, "", "", series.value)
+	}
+}
+
+// Histogram struct is a metric counting the observations per bucket, with a series per
+// label values.
+type Histogram struct {
+	family
+	buckets []float64
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	values []string
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// Observe adds the value to the series of the label values.
+func (histogram *Histogram) Observe(value float64, values ...string) {
+	key := histogram.key(values)
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+	if histogram.series == nil {
+		histogram.series = map[string]*histogramSeries{}
+	}
+	series, ok := histogram.series[key]
+	if !ok {
+		series = &histogramSeries{values: append([]string{}, values...), counts: make([]uint64, len(histogram.buckets))}
+		histogram.series[key] = series
+	}
+	for i, bound := range histogram.buckets {
+		if value <= bound {
+			series.counts[i]++
+		}
+	}
+	series.sum += value
+	series.count++
+}
+
+// ObserveSince adds the seconds elapsed since the start to the series of the label values.
+func (histogram *Histogram) ObserveSince(start time.Time, values ...string) {
+	histogram.Observe(time.Since(start).Seconds(), values...)
+}
+
+func (histogram *Histogram) write(writer *bufio.Writer) {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+	histogram.writeHeader(writer)
+	for _, key := range sortedKeys(histogram.series) {
+		series := histogram.series[key]
+		for i, bound := range histogram.buckets {
+			histogram.writeSample(writer, "_bucket", series.values, "le", formatValue(bound), float64(series.counts[i]))
+		}
+		histogram.writeSample(writer, "_bucket", series.values, "le", "+Inf", float64(series.count))
+		histogram.writeSample(writer, "_sum", series.values, "", "", series.sum)
+		histogram.writeSample(writer, "_count", series.values, "", "", float64(series.count))
+	}
+}
+
+// valueFunc struct is a metric without labels whose value is read when it is written.
+type valueFunc struct {
+	family
+	value func() float64
+}
+
+func (metric *valueFunc) write(writer *bufio.Writer) {
+	metric.writeHeader(writer)
+	metric.writeSample(writer, "", nil, "", "", metric.value())
+}
+
+func sortedKeys[T any](series map[string]T) (keys []string) {
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
+
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,61 @@
+package metrics_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRegistryWrite tests the metrics are written in the Prometheus text format.
+func TestRegistryWrite(t *testing.T) {
+	// Given a registry with a counter, a histogram and a gauge
+	registry := metrics.NewRegistry()
+	files := registry.Counter("files_processed_total", "Files processed, by result.", "result")
+	latency := registry.Histogram("latency_seconds", "Latency.", []float64{0.1, 1}, "method")
+	registry.GaugeFunc("db_pool_idle_connections", "Idle connections.", func() float64 { return 2 })
+	// When the metrics are observed
+	files.Inc("succeeded")
+	files.Add(2, "failed")
+	files.Add(-1, "failed")
+	latency.Observe(0.05, "GetByID")
+	latency.Observe(0.5, "GetByID")
+	// Then they are written with their series sorted by labels
+	out := &bytes.Buffer{}
+	assert.Nil(t, registry.Write(out))
+	assert.Equal(t, `# HELP files_processed_total Files processed, by result.
+# TYPE files_processed_total counter
+files_processed_total{result="failed"} 2
+files_processed_total{result="succeeded"} 1
+# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{method="GetByID",le="0.1"} 1
+latency_seconds_bucket{method="GetByID",le="1"} 2
+latency_seconds_bucket{method="GetByID",le="+Inf"} 2
+latency_seconds_sum{method="GetByID"} 0.55
+latency_seconds_count{method="GetByID"} 2
+# HELP db_pool_idle_connections Idle connections.
+# TYPE db_pool_idle_connections gauge
+db_pool_idle_connections 2
+`, out.String())
+}
+
+// TestRegistrySharesMetrics tests a metric registered twice is the same metric.
+func TestRegistrySharesMetrics(t *testing.T) {
+	// Given a registry
+	registry := metrics.NewRegistry()
+	// When the same counter is registered twice
+	first := registry.Counter("users_created_total", "Users created.")
+	second := registry.Counter("users_created_total", "Users created.")
+	// Then both share the series
+	assert.Same(t, first, second)
+	// And the label values are escaped
+	labeled := registry.Counter("rows_rejected_total", "Rows rejected.", "error")
+	labeled.Inc(`id "x" is invalid`)
+	out := &bytes.Buffer{}
+	assert.Nil(t, registry.Write(out))
+	assert.Contains(t, out.String(), `rows_rejected_total{error="id \"x\" is invalid"} 1`)
+	// And a wrong number of label values panics
+	assert.Panics(t, func() { labeled.Inc() })
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import "database/sql"
+
+// RegisterPoolStats registers the statistics of a connection pool, read on every scrape.
+func RegisterPoolStats(registry *Registry, stats func() sql.DBStats) {
+	registry.GaugeFunc("db_pool_max_open_connections", "Maximum number of open connections of the pool.", func() float64 {
+		return float64(stats().MaxOpenConnections)
+	})
+	registry.GaugeFunc("db_pool_open_connections", "Open connections of the pool, in use and idle.", func() float64 {
+		return float64(stats().OpenConnections)
+	})
+	registry.GaugeFunc("db_pool_in_use_connections", "Connections of the pool in use.", func() float64 {
+		return float64(stats().InUse)
+	})
+	registry.GaugeFunc("db_pool_idle_connections", "Idle connections of the pool.", func() float64 {
+		return float64(stats().Idle)
+	})
+	registry.CounterFunc("db_pool_wait_count_total", "Connections waited for.", func() float64 {
+		return float64(stats().WaitCount)
+	})
+	registry.CounterFunc("db_pool_wait_duration_seconds_total", "Time blocked waiting for a connection.", func() float64 {
+		return stats().WaitDuration.Seconds()
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import "errors"
+
+var (
+	// ErrNilDatabase is the error returned when the database of the migrator is nil.
+	ErrNilDatabase = errors.New("migrations database is nil")
+	// ErrInvalidMigrationName is the error returned when a migration file is not named
+	// <version>_<name>.up.sql or <version>_<name>.down.sql.
+	ErrInvalidMigrationName = errors.New("migration file name is invalid")
+	// ErrDuplicateMigration is the error returned when two migrations have the same version.
+	ErrDuplicateMigration = errors.New("migration version is duplicated")
+	// ErrMissingUpMigration is the error returned when a migration has no up file.
+	ErrMissingUpMigration = errors.New("migration has no up file")
+	// ErrMissingDownMigration is the error returned when reverting a migration without down file.
+	ErrMissingDownMigration = errors.New("migration has no down file")
+	// ErrInvalidSteps is the error returned when the number of migrations to revert is not positive.
+	ErrInvalidSteps = errors.New("steps must be greater than zero")
+	// ErrLockingMigrations is the error returned when the migrations lock cannot be taken.
+	ErrLockingMigrations = errors.New("error locking the migrations")
+	// ErrReadingMigrations is the error returned when the applied migrations cannot be read.
+	ErrReadingMigrations = errors.New("error reading the applied migrations")
+	// ErrApplyingMigration is the error returned when a migration cannot be applied or reverted.
+	ErrApplyingMigration = errors.New("error applying migration")
+	// ErrUnknownMigration is the error returned when the database has a migration this
+	// version of the application does not know.
+	ErrUnknownMigration = errors.New("database has an unknown migration")
+)
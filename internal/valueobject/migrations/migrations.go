@@ -0,0 +1,137 @@
+// Package migrations evolves the database schema with versioned SQL files embedded in the
+// binary. The applied versions are recorded in the schema_migrations table.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// postgresFiles are the migrations of the postgres schema.
+//
+//go:embed postgres/*.sql
+var postgresFiles embed.FS
+
+// sqliteFiles are the migrations of the SQLite schema.
+//
+//go:embed sqlite/*.sql
+var sqliteFiles embed.FS
+
+// fileNamePattern matches <version>_<name>.<up|down>.sql.
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration struct defines a versioned change of the schema.
+type Migration struct {
+	// Version orders the migrations, it must be unique.
+	Version int64
+	// Name describes the migration.
+	Name string
+	// Up applies the migration.
+	Up string
+	// Down reverts the migration, empty when it cannot be reverted.
+	Down string
+}
+
+// MigrationStatus struct defines whether a migration is applied.
+type MigrationStatus struct {
+	// Version is the version of the migration.
+	Version int64
+	// Name describes the migration.
+	Name string
+	// Applied is true when the migration is applied to the database.
+	Applied bool
+	// AppliedAt is the date and time when the migration was applied.
+	AppliedAt time.Time
+}
+
+// Migrator interface defines the operations evolving the schema. Every operation holds a
+// database lock, so concurrent starts apply the migrations only once.
+type Migrator interface {
+	// Up applies the pending migrations in order and returns them.
+	Up() (applied []Migration, err error)
+	// Down reverts the last steps applied migrations and returns them.
+	Down(steps int) (reverted []Migration, err error)
+	// Status returns the status of every migration.
+	Status() (statuses []MigrationStatus, err error)
+}
+
+// PostgresMigrations returns the embedded migrations of the postgres schema.
+func PostgresMigrations() (migrations []Migration, err error) {
+	sub, err := fs.Sub(postgresFiles, "postgres")
+	if err != nil {
+		return
+	}
+	migrations, err = Load(sub)
+	return
+}
+
+// SQLiteMigrations returns the embedded migrations of the SQLite schema. They keep the
+// same versions and names as the postgres ones.
+func SQLiteMigrations() (migrations []Migration, err error) {
+	sub, err := fs.Sub(sqliteFiles, "sqlite")
+	if err != nil {
+		return
+	}
+	migrations, err = Load(sub)
+	return
+}
+
+// Load returns the migrations of the files at the root of fsys, ordered by version. Files
+// not ending in .sql are ignored.
+func Load(fsys fs.FS) (migrations []Migration, err error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return
+	}
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			err = ErrInvalidMigrationName
+			return
+		}
+		version, errVersion := strconv.ParseInt(matches[1], 10, 64)
+		if errVersion != nil {
+			err = ErrInvalidMigrationName
+			return
+		}
+		content, errRead := fs.ReadFile(fsys, entry.Name())
+		if errRead != nil {
+			err = errRead
+			return
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != matches[2] {
+			err = ErrDuplicateMigration
+			return
+		}
+		if matches[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			err = ErrMissingUpMigration
+			return
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return
+}
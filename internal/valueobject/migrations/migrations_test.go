@@ -0,0 +1,83 @@
+package migrations_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPostgresMigrations tests the embedded postgres migrations are loaded.
+func TestPostgresMigrations(t *testing.T) {
+	// When loading the embedded postgres migrations
+	loaded, err := migrations.PostgresMigrations()
+	// Then the initial schema is the first one
+	assert.Nil(t, err)
+	assert.NotEmpty(t, loaded)
+	assert.Equal(t, int64(1), loaded[0].Version)
+	assert.Equal(t, "initial_schema", loaded[0].Name)
+	assert.Contains(t, loaded[0].Up, "CREATE TABLE IF NOT EXISTS users")
+	// And the users table of the schema created before the migrations gets its locale
+	assert.Contains(t, loaded[0].Up, "ALTER TABLE users ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT 'es'")
+	assert.Contains(t, loaded[0].Down, "DROP TABLE IF EXISTS users")
+}
+
+// TestLoadOrdersByVersion tests the migrations are ordered by version.
+func TestLoadOrdersByVersion(t *testing.T) {
+	// Given migration files out of order
+	fsys := fstest.MapFS{
+		"0010_add_index.up.sql":      {Data: []byte("CREATE INDEX")},
+		"0002_add_column.up.sql":     {Data: []byte("ALTER TABLE")},
+		"0002_add_column.down.sql":   {Data: []byte("ALTER TABLE DROP")},
+		"0001_initial_schema.up.sql": {Data: []byte("CREATE TABLE")},
+		"README.md":                  {Data: []byte("ignored")},
+	}
+	// When loading the migrations
+	loaded, err := migrations.Load(fsys)
+	// Then they are ordered by version
+	assert.Nil(t, err)
+	assert.Len(t, loaded, 3)
+	assert.Equal(t, int64(1), loaded[0].Version)
+	assert.Equal(t, int64(2), loaded[1].Version)
+	assert.Equal(t, int64(10), loaded[2].Version)
+	// And the up and down files are paired
+	assert.Equal(t, migrations.Migration{Version: 2, Name: "add_column", Up: "ALTER TABLE", Down: "ALTER TABLE DROP"}, loaded[1])
+	// And a migration without a down file cannot be reverted
+	assert.Empty(t, loaded[2].Down)
+}
+
+// TestLoadErrInvalidMigrationName tests the error returned when a file name has no version.
+func TestLoadErrInvalidMigrationName(t *testing.T) {
+	// Given a migration file without version
+	fsys := fstest.MapFS{"initial_schema.up.sql": {Data: []byte("CREATE TABLE")}}
+	// When loading the migrations
+	loaded, err := migrations.Load(fsys)
+	// Then the error returned is ErrInvalidMigrationName
+	assert.Nil(t, loaded)
+	assert.Equal(t, migrations.ErrInvalidMigrationName, err)
+}
+
+// TestLoadErrDuplicateMigration tests the error returned when two migrations share a version.
+func TestLoadErrDuplicateMigration(t *testing.T) {
+	// Given two migrations with the same version
+	fsys := fstest.MapFS{
+		"0001_initial_schema.up.sql": {Data: []byte("CREATE TABLE")},
+		"0001_other_schema.up.sql":   {Data: []byte("CREATE TABLE")},
+	}
+	// When loading the migrations
+	_, err := migrations.Load(fsys)
+	// Then the error returned is ErrDuplicateMigration
+	assert.Equal(t, migrations.ErrDuplicateMigration, err)
+}
+
+// TestLoadErrMissingUpMigration tests the error returned when a migration has only a down file.
+func TestLoadErrMissingUpMigration(t *testing.T) {
+	// Given a migration with only a down file
+	fsys := fstest.MapFS{"0001_initial_schema.down.sql": {Data: []byte("DROP TABLE")}}
+	// When loading the migrations
+	loaded, err := migrations.Load(fsys)
+	// Then the error returned is ErrMissingUpMigration
+	assert.Nil(t, loaded)
+	assert.Equal(t, migrations.ErrMissingUpMigration, err)
+}
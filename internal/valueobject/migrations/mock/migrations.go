@@ -0,0 +1,79 @@
+package mock
+
+import (
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockMigrator is a mock of Migrator interface.
+type mockMigrator struct {
+	mock.Mock
+}
+
+// NewMockMigrator returns a new mock instance.
+func NewMockMigrator() *mockMigrator {
+	return &mockMigrator{}
+}
+
+// Up mocks base method.
+func (m *mockMigrator) Up() ([]migrations.Migration, error) {
+	ret := m.Called()
+
+	var r0 []migrations.Migration
+	if rf, ok := ret.Get(0).(func() []migrations.Migration); ok {
+		r0 = rf()
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]migrations.Migration)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Down mocks base method.
+func (m *mockMigrator) Down(steps int) ([]migrations.Migration, error) {
+	ret := m.Called(steps)
+
+	var r0 []migrations.Migration
+	if rf, ok := ret.Get(0).(func(int) []migrations.Migration); ok {
+		r0 = rf(steps)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]migrations.Migration)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(steps)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Status mocks base method.
+func (m *mockMigrator) Status() ([]migrations.MigrationStatus, error) {
+	ret := m.Called()
+
+	var r0 []migrations.MigrationStatus
+	if rf, ok := ret.Get(0).(func() []migrations.MigrationStatus); ok {
+		r0 = rf()
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]migrations.MigrationStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
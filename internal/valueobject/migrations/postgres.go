@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+)
+
+const (
+	// lockKey identifies the advisory lock of the migrations, shared by every process
+	// migrating the same database.
+	lockKey                  int64 = 4_812_337_190
+	postgresLockMigrations         = "SELECT pg_advisory_xact_lock($1)"
+	postgresCreateMigrations       = "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT PRIMARY KEY, name TEXT NOT NULL, applied_at TIMESTAMP NOT NULL DEFAULT NOW())"
+)
+
+// NewPostgresMigrator returns a new Migrator instance applying the embedded postgres
+// migrations.
+func NewPostgresMigrator(baseDB postgres.PostgresDatabase, options ...MigratorOption) (migrator Migrator, err error) {
+	migrations, err := PostgresMigrations()
+	if err != nil {
+		return
+	}
+	migrator, err = NewPostgresMigratorWithMigrations(baseDB, migrations, options...)
+	return
+}
+
+// NewPostgresMigratorWithMigrations returns a new Migrator instance applying the given
+// migrations to a postgres database. The migrations are locked with a transaction-level
+// advisory lock.
+func NewPostgresMigratorWithMigrations(baseDB postgres.PostgresDatabase, migrations []Migration, options ...MigratorOption) (migrator Migrator, err error) {
+	if baseDB == nil {
+		err = ErrNilDatabase
+		return
+	}
+	migrator, err = newSQLMigrator(&sqlMigrator{
+		baseDB:          baseDB,
+		migrations:      migrations,
+		lockStatement:   postgresLockMigrations,
+		lockArgs:        []interface{}{lockKey},
+		createStatement: postgresCreateMigrations,
+	}, options)
+	return
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS processing_records;
+DROP TABLE IF EXISTS outbox;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS accounts;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,101 @@
+-- Schema created by infraestructure/postgres/database.sql before the migrations existed.
+-- Every statement is idempotent, so a database created by that script adopts this
+-- migration: its tables are kept and the columns added since, such as users.locale, are
+-- added to them.
+
+CREATE TABLE IF NOT EXISTS users (
+    id     BIGINT PRIMARY KEY,
+    name   TEXT,
+    email  TEXT UNIQUE,
+    locale TEXT NOT NULL DEFAULT 'es'
+);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT 'es';
+COMMENT ON TABLE users IS 'Tabla de usuario';
+COMMENT ON COLUMN users.id IS 'Identificador único del usuario';
+COMMENT ON COLUMN users.name IS 'Nombre del usuario';
+COMMENT ON COLUMN users.email IS 'Dirección de correo electrónico del usuario';
+COMMENT ON COLUMN users.locale IS 'Idioma y formato regional de los mensajes enviados al usuario';
+
+CREATE TABLE IF NOT EXISTS accounts (
+    id      UUID PRIMARY KEY,
+    balance BIGINT,
+    userid  BIGINT UNIQUE,
+    active  BOOLEAN,
+    CONSTRAINT fk_account_user FOREIGN KEY (userid) REFERENCES users (id) ON DELETE CASCADE
+);
+COMMENT ON TABLE accounts IS 'Tabla de cuentas de usuario';
+COMMENT ON COLUMN accounts.id IS 'Identificador único de la cuenta';
+COMMENT ON COLUMN accounts.balance IS 'Saldo de la cuenta';
+COMMENT ON COLUMN accounts.userid IS 'ID de usuario asociado a la cuenta';
+COMMENT ON COLUMN accounts.active IS 'Indica si la cuenta está activa o no';
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id         UUID PRIMARY KEY,
+    accountid  UUID NOT NULL,
+    amount     FLOAT NOT NULL,
+    operation  VARCHAR(255) NOT NULL,
+    date       TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    origin     VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_transaction_account FOREIGN KEY (accountid) REFERENCES accounts (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_transactions_account_operation ON transactions(accountid, operation);
+CREATE INDEX IF NOT EXISTS idx_transactions_origin ON transactions(origin);
+COMMENT ON TABLE transactions IS 'Table to store transactions data';
+COMMENT ON COLUMN transactions.id IS 'Transaction ID';
+COMMENT ON COLUMN transactions.accountid IS 'Account ID of the transaction';
+COMMENT ON COLUMN transactions.amount IS 'Amount of the transaction';
+COMMENT ON COLUMN transactions.operation IS 'Operation of the transaction';
+COMMENT ON COLUMN transactions.date IS 'Date of the transaction';
+COMMENT ON COLUMN transactions.created_at IS 'Date and time when the transaction was created';
+COMMENT ON COLUMN transactions.origin IS 'Origin of the transaction';
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id              UUID PRIMARY KEY,
+    recipient       TEXT NOT NULL,
+    subject         TEXT NOT NULL,
+    payload         BYTEA NOT NULL,
+    status          VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error      TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at    TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(status, next_attempt_at);
+COMMENT ON TABLE outbox IS 'Messages waiting to be delivered, written with the transactions they summarize';
+COMMENT ON COLUMN outbox.id IS 'Delivery ID derived from the file hash and the user ID';
+COMMENT ON COLUMN outbox.recipient IS 'Recipient address';
+COMMENT ON COLUMN outbox.subject IS 'Subject of the message';
+COMMENT ON COLUMN outbox.payload IS 'MIME encoded message';
+COMMENT ON COLUMN outbox.status IS 'Delivery status: pending, delivered or dead';
+COMMENT ON COLUMN outbox.attempts IS 'Number of delivery attempts';
+COMMENT ON COLUMN outbox.next_attempt_at IS 'Date and time when the message can be delivered';
+COMMENT ON COLUMN outbox.last_error IS 'Error of the last failed delivery attempt';
+COMMENT ON COLUMN outbox.created_at IS 'Date and time when the message was enqueued';
+COMMENT ON COLUMN outbox.delivered_at IS 'Date and time when the message was delivered';
+
+CREATE TABLE IF NOT EXISTS processing_records (
+    bucket        VARCHAR(255) NOT NULL,
+    object_key    TEXT NOT NULL,
+    etag          VARCHAR(255) NOT NULL,
+    status        VARCHAR(16) NOT NULL,
+    error         TEXT NOT NULL DEFAULT '',
+    lines         INTEGER NOT NULL DEFAULT 0,
+    invalid_lines INTEGER NOT NULL DEFAULT 0,
+    transactions  INTEGER NOT NULL DEFAULT 0,
+    started_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at   TIMESTAMP,
+    PRIMARY KEY (bucket, object_key, etag)
+);
+COMMENT ON TABLE processing_records IS 'Result of processing every stored file';
+COMMENT ON COLUMN processing_records.bucket IS 'Bucket of the file';
+COMMENT ON COLUMN processing_records.object_key IS 'Key of the file';
+COMMENT ON COLUMN processing_records.etag IS 'ETag of the file, a new upload to the same key gets a new record';
+COMMENT ON COLUMN processing_records.status IS 'Processing status: processing, succeeded or failed';
+COMMENT ON COLUMN processing_records.error IS 'Reason of the failure';
+COMMENT ON COLUMN processing_records.lines IS 'Number of lines after the header';
+COMMENT ON COLUMN processing_records.invalid_lines IS 'Number of invalid lines';
+COMMENT ON COLUMN processing_records.transactions IS 'Number of stored transactions';
+COMMENT ON COLUMN processing_records.started_at IS 'Date and time when the processing started';
+COMMENT ON COLUMN processing_records.finished_at IS 'Date and time when the processing finished';
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    id         VARCHAR(32) PRIMARY KEY,
+    name       TEXT NOT NULL,
+    hash       CHAR(64) NOT NULL,
+    scopes     TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP
+);
+COMMENT ON TABLE api_keys IS 'API keys of the clients of the REST API';
+COMMENT ON COLUMN api_keys.id IS 'Public part of the key';
+COMMENT ON COLUMN api_keys.name IS 'Name of the client';
+COMMENT ON COLUMN api_keys.hash IS 'Hex encoded SHA-256 of the secret of the key';
+COMMENT ON COLUMN api_keys.scopes IS 'Space separated scopes granted to the client';
+COMMENT ON COLUMN api_keys.created_at IS 'Date and time when the key was created';
+COMMENT ON COLUMN api_keys.revoked_at IS 'Date and time when the key was revoked';
+
+ALTER TABLE processing_records ADD COLUMN IF NOT EXISTS uploader TEXT NOT NULL DEFAULT '';
+COMMENT ON COLUMN processing_records.uploader IS 'ID of the API key that uploaded the file';
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS processing_records_uploader_idx ON processing_records (uploader, started_at);
+COMMENT ON INDEX processing_records_uploader_idx IS 'Files of every uploader by date, for its daily quota';
@@ -0,0 +1,219 @@
+package migrations_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+	voPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	mockvoPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	lockQuery   = "SELECT pg_advisory_xact_lock($1)"
+	createQuery = "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT PRIMARY KEY, name TEXT NOT NULL, applied_at TIMESTAMP NOT NULL DEFAULT NOW())"
+	selectQuery = "SELECT version, name, applied_at FROM schema_migrations ORDER BY version"
+	insertQuery = "INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)"
+	deleteQuery = "DELETE FROM schema_migrations WHERE version = $1"
+)
+
+var (
+	migrationColumns = []string{"version", "name", "applied_at"}
+	testMigrations   = []migrations.Migration{
+		{Version: 1, Name: "initial_schema", Up: "CREATE TABLE users", Down: "DROP TABLE users"},
+		{Version: 2, Name: "add_accounts", Up: "CREATE TABLE accounts", Down: "DROP TABLE accounts"},
+	}
+)
+
+// mockedDatabase is the mocked postgres database of the tests.
+type mockedDatabase interface {
+	voPostgres.PostgresDatabase
+	On(methodName string, arguments ...interface{}) *mock.Call
+	AssertNotCalled(t mock.TestingT, methodName string, arguments ...interface{}) bool
+}
+
+// getTestDatabase returns a mocked database locking the migrations in a transaction of a
+// sqlmock database, with the given applied versions.
+func getTestDatabase(t *testing.T, applied ...int64) (dbBaseMocked mockedDatabase, dbTx *sql.Tx) {
+	dbBaseMocked = mockvoPostgres.NewMockBasePostgresDatabase()
+	dbBase := voPostgres.NewBasePostgresDatabase(voPostgres.NewDefaultPostgresConfiguration())
+	db, dbMocked, _ := sqlmock.New()
+	t.Cleanup(func() { db.Close() })
+	dbMocked.ExpectBegin()
+	dbTx, _ = db.BeginTx(context.Background(), nil)
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	dbBaseMocked.On("Exec", dbTx, lockQuery, []interface{}{int64(4_812_337_190)}).Return(sqlmock.NewResult(0, 0), nil)
+	dbBaseMocked.On("Exec", dbTx, createQuery, mock.Anything).Return(sqlmock.NewResult(0, 0), nil)
+	expected := sqlmock.NewRows(migrationColumns)
+	for _, version := range applied {
+		expected.AddRow(version, testMigrations[version-1].Name, time.Now())
+	}
+	dbMocked.ExpectQuery("SELECT (.+) FROM schema_migrations (.+)").WillReturnRows(expected)
+	rows, err := dbBase.Query(dbTx, selectQuery)
+	assert.Nil(t, err)
+	dbBaseMocked.On("Query", dbTx, selectQuery, mock.Anything).Return(rows, nil)
+	return
+}
+
+// TestNewPostgresMigratorWithNilDatabase tests the error returned when the database is nil.
+func TestNewPostgresMigratorWithNilDatabase(t *testing.T) {
+	// When creating a migrator without database
+	migrator, err := migrations.NewPostgresMigrator(nil)
+	// Then the error returned is ErrNilDatabase
+	assert.Nil(t, migrator)
+	assert.Equal(t, migrations.ErrNilDatabase, err)
+}
+
+// TestUpErrOpeningDatabase tests the error returned when the database cannot be opened.
+func TestUpErrOpeningDatabase(t *testing.T) {
+	// Given a mocked database failing to open
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	dbBaseMocked.On("Open").Return(nil, voPostgres.ErrOpeningDatabase)
+	migrator, err := migrations.NewPostgresMigratorWithMigrations(dbBaseMocked, testMigrations)
+	assert.Nil(t, err)
+	// When applying the migrations
+	applied, err := migrator.Up()
+	// Then the error returned is ErrOpeningDatabase
+	assert.Nil(t, applied)
+	assert.ErrorIs(t, err, voPostgres.ErrOpeningDatabase)
+}
+
+// TestUpErrLockingMigrations tests the error returned when the lock cannot be taken.
+func TestUpErrLockingMigrations(t *testing.T) {
+	// Given a mocked database failing to take the lock
+	dbBaseMocked := mockvoPostgres.NewMockBasePostgresDatabase()
+	db, dbMocked, _ := sqlmock.New()
+	defer db.Close()
+	dbMocked.ExpectBegin()
+	dbTx, _ := db.BeginTx(context.Background(), nil)
+	dbBaseMocked.On("Open").Return(db, nil)
+	dbBaseMocked.On("Close", db).Return(nil)
+	dbBaseMocked.On("BeginTx", db).Return(dbTx, nil)
+	dbBaseMocked.On("Rollback", mock.Anything).Return(nil)
+	dbBaseMocked.On("Exec", dbTx, lockQuery, mock.Anything).Return(nil, voPostgres.ErrExec)
+	migrator, err := migrations.NewPostgresMigratorWithMigrations(dbBaseMocked, testMigrations)
+	assert.Nil(t, err)
+	// When applying the migrations
+	_, err = migrator.Up()
+	// Then the error returned is ErrLockingMigrations
+	assert.ErrorIs(t, err, migrations.ErrLockingMigrations)
+}
+
+// TestUpAppliesPendingMigrations tests only the pending migrations are applied and recorded.
+func TestUpAppliesPendingMigrations(t *testing.T) {
+	// Given a database with the first migration applied
+	dbBaseMocked, dbTx := getTestDatabase(t, 1)
+	dbBaseMocked.On("Exec", dbTx, "CREATE TABLE accounts", mock.Anything).Return(sqlmock.NewResult(0, 0), nil)
+	dbBaseMocked.On("Exec", dbTx, insertQuery, mock.Anything).Return(sqlmock.NewResult(1, 1), nil)
+	dbBaseMocked.On("Commit", dbTx).Return(nil)
+	migrator, err := migrations.NewPostgresMigratorWithMigrations(dbBaseMocked, testMigrations)
+	assert.Nil(t, err)
+	// When applying the migrations
+	applied, err := migrator.Up()
+	// Then the second migration is applied
+	assert.Nil(t, err)
+	assert.Equal(t, []migrations.Migration{testMigrations[1]}, applied)
+	// And the first one is not applied again
+	dbBaseMocked.AssertNotCalled(t, "Exec", dbTx, "CREATE TABLE users", mock.Anything)
+}
+
+// TestUpWithoutPendingMigrations tests nothing is applied when every migration is applied.
+func TestUpWithoutPendingMigrations(t *testing.T) {
+	// Given a database with every migration applied
+	dbBaseMocked, dbTx := getTestDatabase(t, 1, 2)
+	dbBaseMocked.On("Commit", dbTx).Return(nil)
+	migrator, err := migrations.NewPostgresMigratorWithMigrations(dbBaseMocked, testMigrations)
+	assert.Nil(t, err)
+	// When applying the migrations
+	applied, err := migrator.Up()
+	// Then nothing is applied
+	assert.Nil(t, err)
+	assert.Empty(t, applied)
+}
+
+// TestUpErrApplyingMigration tests a failing migration is not committed.
+func TestUpErrApplyingMigration(t *testing.T) {
+	// Given a database without migrations
+	dbBaseMocked, dbTx := getTestDatabase(t)
+	// And a second migration failing
+	dbBaseMocked.On("Exec", dbTx, "CREATE TABLE users", mock.Anything).Return(sqlmock.NewResult(0, 0), nil)
+	dbBaseMocked.On("Exec", dbTx, "CREATE TABLE accounts", mock.Anything).Return(nil, voPostgres.ErrExec)
+	dbBaseMocked.On("Exec", dbTx, insertQuery, mock.Anything).Return(sqlmock.NewResult(1, 1), nil)
+	migrator, err := migrations.NewPostgresMigratorWithMigrations(dbBaseMocked, testMigrations)
+	assert.Nil(t, err)
+	// When applying the migrations
+	applied, err := migrator.Up()
+	// Then the error returned is ErrApplyingMigration
+	assert.Nil(t, applied)
+	assert.Equal(t, migrations.ErrApplyingMigration, err)
+	// And the transaction is not committed
+	dbBaseMocked.AssertNotCalled(t, "Commit", dbTx)
+}
+
+// TestDownErrInvalidSteps tests the error returned when the steps are not positive.
+func TestDownErrInvalidSteps(t *testing.T) {
+	// Given a migrator
+	migrator, err := migrations.NewPostgresMigratorWithMigrations(mockvoPostgres.NewMockBasePostgresDatabase(), testMigrations)
+	assert.Nil(t, err)
+	// When reverting zero migrations
+	reverted, err := migrator.Down(0)
+	// Then the error returned is ErrInvalidSteps
+	assert.Nil(t, reverted)
+	assert.Equal(t, migrations.ErrInvalidSteps, err)
+}
+
+// TestDownRevertsNewestMigrations tests the newest applied migrations are reverted first.
+func TestDownRevertsNewestMigrations(t *testing.T) {
+	// Given a database with every migration applied
+	dbBaseMocked, dbTx := getTestDatabase(t, 1, 2)
+	dbBaseMocked.On("Exec", dbTx, "DROP TABLE accounts", mock.Anything).Return(sqlmock.NewResult(0, 0), nil)
+	dbBaseMocked.On("Exec", dbTx, deleteQuery, []interface{}{int64(2)}).Return(sqlmock.NewResult(1, 1), nil)
+	dbBaseMocked.On("Commit", dbTx).Return(nil)
+	migrator, err := migrations.NewPostgresMigratorWithMigrations(dbBaseMocked, testMigrations)
+	assert.Nil(t, err)
+	// When reverting one migration
+	reverted, err := migrator.Down(1)
+	// Then the second migration is reverted
+	assert.Nil(t, err)
+	assert.Equal(t, []migrations.Migration{testMigrations[1]}, reverted)
+}
+
+// TestDownErrMissingDownMigration tests the error returned when a migration cannot be reverted.
+func TestDownErrMissingDownMigration(t *testing.T) {
+	// Given a database with the first migration applied
+	dbBaseMocked, _ := getTestDatabase(t, 1)
+	// And a first migration without down file
+	irreversible := []migrations.Migration{{Version: 1, Name: "initial_schema", Up: "CREATE TABLE users"}}
+	migrator, err := migrations.NewPostgresMigratorWithMigrations(dbBaseMocked, irreversible)
+	assert.Nil(t, err)
+	// When reverting one migration
+	_, err = migrator.Down(1)
+	// Then the error returned is ErrMissingDownMigration
+	assert.Equal(t, migrations.ErrMissingDownMigration, err)
+}
+
+// TestStatus tests the status of the applied and the pending migrations.
+func TestStatus(t *testing.T) {
+	// Given a database with the first migration applied
+	dbBaseMocked, dbTx := getTestDatabase(t, 1)
+	dbBaseMocked.On("Commit", dbTx).Return(nil)
+	migrator, err := migrations.NewPostgresMigratorWithMigrations(dbBaseMocked, testMigrations)
+	assert.Nil(t, err)
+	// When getting the status of the migrations
+	statuses, err := migrator.Status()
+	// Then the first migration is applied and the second one is pending
+	assert.Nil(t, err)
+	assert.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[0].AppliedAt.IsZero())
+	assert.Equal(t, "add_accounts", statuses[1].Name)
+	assert.False(t, statuses[1].Applied)
+}
@@ -0,0 +1,304 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+)
+
+const (
+	selectMigrations = "SELECT version, name, applied_at FROM schema_migrations ORDER BY version"
+	insertMigration  = "INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)"
+	deleteMigration  = "DELETE FROM schema_migrations WHERE version = $1"
+	selectVersions   = "SELECT version FROM schema_migrations"
+)
+
+// sqlMigrator struct implements the Migrator interface for the SQL databases. Every
+// operation runs in a single transaction holding the migrations lock of the dialect: a
+// failing migration leaves the schema untouched and a concurrent start waits for the lock
+// and then finds the migrations applied.
+type sqlMigrator struct {
+	baseDB     database.Database
+	migrations []Migration
+	// lockStatement takes the migrations lock, empty when beginning the transaction takes it.
+	lockStatement string
+	// lockArgs are the arguments of lockStatement.
+	lockArgs []interface{}
+	// createStatement creates the schema_migrations table.
+	createStatement string
+	// logger logs the applied and reverted migrations.
+	logger logger.Logger
+}
+
+// MigratorOption configures an optional collaborator of the migrator.
+type MigratorOption func(migrator *sqlMigrator) (err error)
+
+// WithLogger logs the migrations with the logger instead of the default one.
+func WithLogger(log logger.Logger) MigratorOption {
+	return func(migrator *sqlMigrator) (err error) {
+		if log == nil {
+			err = logger.ErrNilLogger
+			return
+		}
+		migrator.logger = log
+		return
+	}
+}
+
+// newSQLMigrator returns the migrator with its options applied.
+func newSQLMigrator(migrator *sqlMigrator, options []MigratorOption) (newMigrator Migrator, err error) {
+	migrator.logger = logger.NewDefaultLogger()
+	for _, option := range options {
+		if err = option(migrator); err != nil {
+			return
+		}
+	}
+	newMigrator = migrator
+	return
+}
+
+// NewMigrator returns a new Migrator instance applying the embedded migrations of the
+// dialect of the database.
+func NewMigrator(baseDB database.Database, options ...MigratorOption) (migrator Migrator, err error) {
+	if baseDB == nil {
+		err = ErrNilDatabase
+		return
+	}
+	switch baseDB.Dialect() {
+	case database.Postgres:
+		migrator, err = NewPostgresMigrator(baseDB, options...)
+	case database.SQLite:
+		migrator, err = NewSQLiteMigrator(baseDB, options...)
+	default:
+		err = database.ErrUnknownDialect
+	}
+	return
+}
+
+// Pending returns the embedded migrations of the dialect of the database it has not
+// applied. It only reads the applied versions, without taking the migrations lock nor
+// creating the schema_migrations table, so the readiness probes can call it often. The
+// applied versions this version of the application does not know are ignored.
+func Pending(ctx context.Context, baseDB database.Database) (pending []Migration, err error) {
+	if baseDB == nil {
+		err = ErrNilDatabase
+		return
+	}
+	var migrations []Migration
+	switch baseDB.Dialect() {
+	case database.Postgres:
+		migrations, err = PostgresMigrations()
+	case database.SQLite:
+		migrations, err = SQLiteMigrations()
+	default:
+		err = database.ErrUnknownDialect
+	}
+	if err != nil {
+		return
+	}
+	db, err := baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer baseDB.Close(db)
+	rows, err := db.QueryContext(ctx, selectVersions)
+	if err != nil {
+		err = database.Wrap(ErrReadingMigrations, err)
+		return
+	}
+	defer rows.Close()
+	applied := map[int64]bool{}
+	for rows.Next() {
+		var version int64
+		if err = rows.Scan(&version); err != nil {
+			err = database.Wrap(ErrReadingMigrations, err)
+			return
+		}
+		applied[version] = true
+	}
+	if err = rows.Err(); err != nil {
+		err = database.Wrap(ErrReadingMigrations, err)
+		return
+	}
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return
+}
+
+// Up implements the Migrator interface method.
+func (migrator *sqlMigrator) Up() (applied []Migration, err error) {
+	err = migrator.locked(func(tx *sql.Tx, statuses map[int64]MigrationStatus) (err error) {
+		now := time.Now().UTC()
+		for _, migration := range migrator.migrations {
+			if statuses[migration.Version].Applied {
+				continue
+			}
+			log := migrator.logger.With("version", migration.Version, "name", migration.Name)
+			log.Info("applying migration")
+			if _, err = migrator.baseDB.Exec(tx, migration.Up); err != nil {
+				log.Error("error applying migration", "error", err)
+				return ErrApplyingMigration
+			}
+			if _, err = migrator.baseDB.Exec(tx, insertMigration, migration.Version, migration.Name, now); err != nil {
+				log.Error("error recording migration", "error", err)
+				return ErrApplyingMigration
+			}
+			applied = append(applied, migration)
+		}
+		return
+	})
+	if err != nil {
+		applied = nil
+	}
+	return
+}
+
+// Down implements the Migrator interface method.
+func (migrator *sqlMigrator) Down(steps int) (reverted []Migration, err error) {
+	if steps <= 0 {
+		err = ErrInvalidSteps
+		return
+	}
+	known := map[int64]Migration{}
+	for _, migration := range migrator.migrations {
+		known[migration.Version] = migration
+	}
+	err = migrator.locked(func(tx *sql.Tx, statuses map[int64]MigrationStatus) (err error) {
+		for _, status := range sortedStatuses(statuses, true) {
+			if len(reverted) == steps {
+				break
+			}
+			migration, ok := known[status.Version]
+			if !ok {
+				return ErrUnknownMigration
+			}
+			if migration.Down == "" {
+				return ErrMissingDownMigration
+			}
+			log := migrator.logger.With("version", migration.Version, "name", migration.Name)
+			log.Info("reverting migration")
+			if _, err = migrator.baseDB.Exec(tx, migration.Down); err != nil {
+				log.Error("error reverting migration", "error", err)
+				return ErrApplyingMigration
+			}
+			if _, err = migrator.baseDB.Exec(tx, deleteMigration, migration.Version); err != nil {
+				log.Error("error recording migration", "error", err)
+				return ErrApplyingMigration
+			}
+			reverted = append(reverted, migration)
+		}
+		return
+	})
+	if err != nil {
+		reverted = nil
+	}
+	return
+}
+
+// Status implements the Migrator interface method. The applied migrations this version
+// of the application does not know are listed too.
+func (migrator *sqlMigrator) Status() (statuses []MigrationStatus, err error) {
+	err = migrator.locked(func(tx *sql.Tx, applied map[int64]MigrationStatus) (err error) {
+		for _, migration := range migrator.migrations {
+			if _, ok := applied[migration.Version]; !ok {
+				applied[migration.Version] = MigrationStatus{Version: migration.Version, Name: migration.Name}
+			}
+		}
+		statuses = sortedStatuses(applied, false)
+		return
+	})
+	if err != nil {
+		statuses = nil
+	}
+	return
+}
+
+// locked runs fn in a transaction holding the migrations lock, with the applied
+// migrations by version. The transaction is committed when fn succeeds.
+func (migrator *sqlMigrator) locked(fn func(tx *sql.Tx, applied map[int64]MigrationStatus) error) (err error) {
+	db, err := migrator.baseDB.Open()
+	if err != nil {
+		err = database.Wrap(database.ErrOpeningDatabase, err)
+		return
+	}
+	defer migrator.baseDB.Close(db)
+	dbTx, err := migrator.baseDB.BeginTx(db)
+	defer migrator.baseDB.Rollback(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrBeginningTransaction, err)
+		return
+	}
+	if migrator.lockStatement != "" {
+		if _, err = migrator.baseDB.Exec(dbTx, migrator.lockStatement, migrator.lockArgs...); err != nil {
+			migrator.logger.Error("error locking the migrations", "error", err)
+			err = database.Wrap(ErrLockingMigrations, err)
+			return
+		}
+	}
+	if _, err = migrator.baseDB.Exec(dbTx, migrator.createStatement); err != nil {
+		migrator.logger.Error("error creating the schema_migrations table", "error", err)
+		err = database.Wrap(ErrReadingMigrations, err)
+		return
+	}
+	applied, err := migrator.applied(dbTx)
+	if err != nil {
+		return
+	}
+	if err = fn(dbTx, applied); err != nil {
+		return
+	}
+	err = migrator.baseDB.Commit(dbTx)
+	if err != nil {
+		err = database.Wrap(database.ErrCommittingTransaction, err)
+	}
+	return
+}
+
+// applied returns the applied migrations by version.
+func (migrator *sqlMigrator) applied(tx *sql.Tx) (applied map[int64]MigrationStatus, err error) {
+	rows, err := migrator.baseDB.Query(tx, selectMigrations)
+	if err != nil {
+		migrator.logger.Error("error querying the applied migrations", "error", err)
+		err = database.Wrap(ErrReadingMigrations, err)
+		return
+	}
+	defer rows.Close()
+	applied = map[int64]MigrationStatus{}
+	for rows.Next() {
+		status := MigrationStatus{Applied: true}
+		if err = rows.Scan(&status.Version, &status.Name, &status.AppliedAt); err != nil {
+			migrator.logger.Error("error scanning the applied migrations", "error", err)
+			applied = nil
+			err = database.Wrap(ErrReadingMigrations, err)
+			return
+		}
+		applied[status.Version] = status
+	}
+	if rows.Err() != nil {
+		applied = nil
+		err = database.Wrap(ErrReadingMigrations, rows.Err())
+	}
+	return
+}
+
+// sortedStatuses returns the statuses ordered by version, the newest first when descending.
+func sortedStatuses(statuses map[int64]MigrationStatus, descending bool) (sorted []MigrationStatus) {
+	for _, status := range statuses {
+		sorted = append(sorted, status)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if descending {
+			return sorted[i].Version > sorted[j].Version
+		}
+		return sorted[i].Version < sorted[j].Version
+	})
+	return
+}
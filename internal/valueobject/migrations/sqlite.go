@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/braejan/go-transactions-summary/internal/valueobject/sqlite"
+)
+
+const (
+	sqliteCreateMigrations = "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT PRIMARY KEY, name TEXT NOT NULL, applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)"
+)
+
+// NewSQLiteMigrator returns a new Migrator instance applying the embedded SQLite
+// migrations.
+func NewSQLiteMigrator(baseDB sqlite.SQLiteDatabase, options ...MigratorOption) (migrator Migrator, err error) {
+	migrations, err := SQLiteMigrations()
+	if err != nil {
+		return
+	}
+	migrator, err = NewSQLiteMigratorWithMigrations(baseDB, migrations, options...)
+	return
+}
+
+// NewSQLiteMigratorWithMigrations returns a new Migrator instance applying the given
+// migrations to a SQLite database. The SQLite transactions take the write lock when they
+// begin, so the migrations need no other lock.
+func NewSQLiteMigratorWithMigrations(baseDB sqlite.SQLiteDatabase, migrations []Migration, options ...MigratorOption) (migrator Migrator, err error) {
+	if baseDB == nil {
+		err = ErrNilDatabase
+		return
+	}
+	migrator, err = newSQLMigrator(&sqlMigrator{
+		baseDB:          baseDB,
+		migrations:      migrations,
+		createStatement: sqliteCreateMigrations,
+	}, options)
+	return
+}
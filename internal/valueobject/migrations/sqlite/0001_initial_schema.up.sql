@@ -0,0 +1,63 @@
+-- Same schema as the postgres migration. SQLite has no UUID type, so the IDs are stored
+-- as text, and the times are stored as text in UTC so they sort chronologically.
+
+-- Tabla de usuario
+CREATE TABLE IF NOT EXISTS users (
+    id     BIGINT PRIMARY KEY,
+    name   TEXT,
+    email  TEXT UNIQUE,
+    locale TEXT NOT NULL DEFAULT 'es'
+);
+
+-- Tabla de cuentas de usuario
+CREATE TABLE IF NOT EXISTS accounts (
+    id      TEXT PRIMARY KEY,
+    balance BIGINT,
+    userid  BIGINT UNIQUE,
+    active  BOOLEAN,
+    CONSTRAINT fk_account_user FOREIGN KEY (userid) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Table to store transactions data
+CREATE TABLE IF NOT EXISTS transactions (
+    id         TEXT PRIMARY KEY,
+    accountid  TEXT NOT NULL,
+    amount     FLOAT NOT NULL,
+    operation  VARCHAR(255) NOT NULL,
+    date       TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    origin     VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_transaction_account FOREIGN KEY (accountid) REFERENCES accounts (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_transactions_account_operation ON transactions(accountid, operation);
+CREATE INDEX IF NOT EXISTS idx_transactions_origin ON transactions(origin);
+
+-- Messages waiting to be delivered, written with the transactions they summarize
+CREATE TABLE IF NOT EXISTS outbox (
+    id              TEXT PRIMARY KEY,
+    recipient       TEXT NOT NULL,
+    subject         TEXT NOT NULL,
+    payload         BLOB NOT NULL,
+    status          VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error      TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at    TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(status, next_attempt_at);
+
+-- Result of processing every stored file
+CREATE TABLE IF NOT EXISTS processing_records (
+    bucket        VARCHAR(255) NOT NULL,
+    object_key    TEXT NOT NULL,
+    etag          VARCHAR(255) NOT NULL,
+    status        VARCHAR(16) NOT NULL,
+    error         TEXT NOT NULL DEFAULT '',
+    lines         INTEGER NOT NULL DEFAULT 0,
+    invalid_lines INTEGER NOT NULL DEFAULT 0,
+    transactions  INTEGER NOT NULL DEFAULT 0,
+    started_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at   TIMESTAMP,
+    PRIMARY KEY (bucket, object_key, etag)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE processing_records DROP COLUMN uploader;
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,12 @@
+-- API keys of the clients of the REST API
+CREATE TABLE IF NOT EXISTS api_keys (
+    id         VARCHAR(32) PRIMARY KEY,
+    name       TEXT NOT NULL,
+    hash       CHAR(64) NOT NULL,
+    scopes     TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+-- ID of the API key that uploaded the file
+ALTER TABLE processing_records ADD COLUMN uploader TEXT NOT NULL DEFAULT '';
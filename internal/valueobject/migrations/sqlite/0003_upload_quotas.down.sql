@@ -0,0 +1 @@
+DROP INDEX IF EXISTS processing_records_uploader_idx;
@@ -0,0 +1,133 @@
+package migrations_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+	mockvoPostgres "github.com/braejan/go-transactions-summary/internal/valueobject/postgres/mock"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+// getSQLiteDatabase returns a pool of a new SQLite database file.
+func getSQLiteDatabase(t *testing.T) (pool sqlite.SQLitePool) {
+	pool, err := sqlite.NewSQLitePool(sqlite.NewSQLiteConfiguration(filepath.Join(t.TempDir(), "test.db")))
+	assert.Nil(t, err)
+	t.Cleanup(func() { _ = pool.Shutdown() })
+	return
+}
+
+// TestSQLiteMigrations tests the embedded SQLite migrations match the postgres ones.
+func TestSQLiteMigrations(t *testing.T) {
+	// When loading the embedded migrations of both dialects
+	sqliteMigrations, err := migrations.SQLiteMigrations()
+	assert.Nil(t, err)
+	postgresMigrations, err := migrations.PostgresMigrations()
+	assert.Nil(t, err)
+	// Then they have the same versions and names
+	assert.Len(t, sqliteMigrations, len(postgresMigrations))
+	for i, migration := range sqliteMigrations {
+		assert.Equal(t, postgresMigrations[i].Version, migration.Version)
+		assert.Equal(t, postgresMigrations[i].Name, migration.Name)
+		assert.NotEmpty(t, migration.Down)
+	}
+}
+
+// TestNewMigratorSelectsDialect tests the migrator of the dialect of the database is returned.
+func TestNewMigratorSelectsDialect(t *testing.T) {
+	// When creating a migrator without database
+	migrator, err := migrations.NewMigrator(nil)
+	// Then the error returned is ErrNilDatabase
+	assert.Nil(t, migrator)
+	assert.Equal(t, migrations.ErrNilDatabase, err)
+	// When creating the migrators of a postgres and a SQLite database
+	postgresMigrator, err := migrations.NewMigrator(mockvoPostgres.NewMockBasePostgresDatabase())
+	assert.Nil(t, err)
+	sqliteMigrator, err := migrations.NewMigrator(getSQLiteDatabase(t))
+	assert.Nil(t, err)
+	// Then both are created
+	assert.NotNil(t, postgresMigrator)
+	assert.NotNil(t, sqliteMigrator)
+}
+
+// TestSQLiteMigratorUpAndDown tests the SQLite migrations are applied, listed and reverted.
+func TestSQLiteMigratorUpAndDown(t *testing.T) {
+	// Given a new SQLite database
+	pool := getSQLiteDatabase(t)
+	migrator, err := migrations.NewSQLiteMigrator(pool)
+	assert.Nil(t, err)
+	// When applying the migrations twice
+	applied, err := migrator.Up()
+	assert.Nil(t, err)
+	reapplied, err := migrator.Up()
+	// Then they are applied once
+	assert.Nil(t, err)
+	assert.NotEmpty(t, applied)
+	assert.Empty(t, reapplied)
+	statuses, err := migrator.Status()
+	assert.Nil(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[0].AppliedAt.IsZero())
+	// When reverting every migration
+	reverted, err := migrator.Down(len(applied))
+	// Then the tables are dropped
+	assert.Nil(t, err)
+	assert.Len(t, reverted, len(applied))
+	db, _ := pool.Open()
+	var tables int
+	assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'users'").Scan(&tables))
+	assert.Equal(t, 0, tables)
+	assert.Equal(t, database.SQLite, pool.Dialect())
+}
+
+// TestMigratorWithLogger tests the applied migrations are logged with the given logger.
+func TestMigratorWithLogger(t *testing.T) {
+	// When creating a migrator with a nil logger
+	_, err := migrations.NewMigrator(getSQLiteDatabase(t), migrations.WithLogger(nil))
+	// Then the error returned is ErrNilLogger
+	assert.Equal(t, logger.ErrNilLogger, err)
+	// Given a migrator logging to a buffer
+	output := &bytes.Buffer{}
+	migrator, err := migrations.NewMigrator(getSQLiteDatabase(t), migrations.WithLogger(logger.NewJSONLogger(output, logger.LevelInfo)))
+	assert.Nil(t, err)
+	// When applying the migrations
+	_, err = migrator.Up()
+	assert.Nil(t, err)
+	// Then the first line is the first migration
+	line := map[string]interface{}{}
+	assert.Nil(t, json.NewDecoder(output).Decode(&line))
+	assert.Equal(t, "applying migration", line["msg"])
+	assert.Equal(t, float64(1), line["version"])
+	assert.Equal(t, "initial_schema", line["name"])
+}
+
+// TestPending tests the embedded migrations not applied are listed without writing.
+func TestPending(t *testing.T) {
+	// Given a SQLite database with every migration applied
+	pool := getSQLiteDatabase(t)
+	migrator, err := migrations.NewSQLiteMigrator(pool)
+	assert.Nil(t, err)
+	applied, err := migrator.Up()
+	assert.Nil(t, err)
+	// When listing the pending migrations
+	pending, err := migrations.Pending(context.Background(), pool)
+	// Then there is none
+	assert.Nil(t, err)
+	assert.Empty(t, pending)
+	// When the last one is reverted
+	_, err = migrator.Down(1)
+	assert.Nil(t, err)
+	pending, err = migrations.Pending(context.Background(), pool)
+	// Then it is pending
+	assert.Nil(t, err)
+	assert.Equal(t, []migrations.Migration{applied[len(applied)-1]}, pending)
+	// And a nil database returns ErrNilDatabase
+	_, err = migrations.Pending(context.Background(), nil)
+	assert.Equal(t, migrations.ErrNilDatabase, err)
+}
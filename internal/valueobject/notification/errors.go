@@ -0,0 +1,47 @@
+package notification
+
+import "errors"
+
+var (
+	// ErrTemplateNotFound is the error returned when a template cannot be found.
+	ErrTemplateNotFound = errors.New("template not found")
+	// ErrParsingTemplate is the error returned when a template cannot be parsed.
+	ErrParsingTemplate = errors.New("error parsing template")
+	// ErrRenderingTemplate is the error returned when a template cannot be executed.
+	ErrRenderingTemplate = errors.New("error rendering template")
+	// ErrTemplatesDirIsInvalid is the error returned when the templates override directory is not a directory.
+	ErrTemplatesDirIsInvalid = errors.New("templates directory is invalid")
+	// ErrNilRenderer is the error returned when the template renderer is nil.
+	ErrNilRenderer = errors.New("template renderer is nil")
+	// ErrEmptySender is the error returned when the sender address is empty.
+	ErrEmptySender = errors.New("sender address is empty")
+	// ErrEmptyRecipient is the error returned when the recipient address is empty.
+	ErrEmptyRecipient = errors.New("recipient address is empty")
+	// ErrNilSummary is the error returned when the summary is nil.
+	ErrNilSummary = errors.New("summary is nil")
+)
+
+var (
+	// ErrNilOutboxRepository is the error returned when the outbox repository is nil.
+	ErrNilOutboxRepository = errors.New("outbox repository is nil")
+	// ErrNilNotifier is the error returned when the notifier is nil.
+	ErrNilNotifier = errors.New("notifier is nil")
+	// ErrNilNotificationUseCases is the error returned when the notification use cases is nil.
+	ErrNilNotificationUseCases = errors.New("notification use cases is nil")
+	// ErrNilOutboxMessage is the error returned when the outbox message is nil.
+	ErrNilOutboxMessage = errors.New("outbox message is nil")
+	// ErrEnqueuingOutboxMessage is the error returned when an outbox message cannot be enqueued.
+	ErrEnqueuingOutboxMessage = errors.New("error enqueuing outbox message")
+	// ErrClaimingOutboxMessages is the error returned when the pending outbox messages cannot be claimed.
+	ErrClaimingOutboxMessages = errors.New("error claiming outbox messages")
+	// ErrScanningOutboxMessage is the error returned when an outbox message cannot be scanned.
+	ErrScanningOutboxMessage = errors.New("error scanning outbox message")
+	// ErrUpdatingOutboxMessage is the error returned when an outbox message cannot be updated.
+	ErrUpdatingOutboxMessage = errors.New("error updating outbox message")
+	// ErrDeliveringMessage is the error returned when a notifier cannot deliver a message.
+	ErrDeliveringMessage = errors.New("error delivering message")
+	// ErrNotifierIsInvalid is the error returned when a notifier cannot be created with the given settings.
+	ErrNotifierIsInvalid = errors.New("notifier is invalid")
+	// ErrUnknownNotifier is the error returned when the configured notifier does not exist.
+	ErrUnknownNotifier = errors.New("unknown notifier")
+)
@@ -0,0 +1,95 @@
+package notification
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// LogNotifier is the notifier that only logs the messages.
+	LogNotifier = "log"
+	// FileNotifier is the notifier that writes the messages as .eml files.
+	FileNotifier = "file"
+	// SESNotifier is the notifier that sends the messages with Amazon SES.
+	SESNotifier = "ses"
+)
+
+// NotificationConfiguration struct defines how the summary emails are rendered and delivered.
+type NotificationConfiguration struct {
+	// Sender is the address used as the sender of the messages.
+	Sender string
+	// TemplatesDir is the directory with templates overriding the embedded ones.
+	TemplatesDir string
+	// Notifier is the name of the notifier used to deliver the messages.
+	Notifier string
+	// NotifierDir is the directory where the file notifier writes the messages.
+	NotifierDir string
+	// Interval is the time the dispatcher waits between two polls of the outbox.
+	Interval time.Duration
+	// BatchSize is the max number of messages claimed on each poll.
+	BatchSize int
+	// Lease is the time a claimed message is hidden from other dispatchers.
+	Lease time.Duration
+	// MaxAttempts is the number of failed attempts before a message is dead.
+	MaxAttempts int
+	// BaseBackoff is the wait after the first failed attempt, it doubles on every attempt.
+	BaseBackoff time.Duration
+	// MaxBackoff is the max wait between two attempts.
+	MaxBackoff time.Duration
+}
+
+// NewDefaultNotificationConfiguration returns the configuration used in local environments.
+func NewDefaultNotificationConfiguration() (configuration *NotificationConfiguration) {
+	configuration = &NotificationConfiguration{
+		Sender:      "no-reply@amazingemail.com",
+		Notifier:    LogNotifier,
+		NotifierDir: "outbox",
+		Interval:    5 * time.Second,
+		BatchSize:   10,
+		Lease:       time.Minute,
+		MaxAttempts: 5,
+		BaseBackoff: 30 * time.Second,
+		MaxBackoff:  time.Hour,
+	}
+	return
+}
+
+// NewNotificationConfigurationFromEnv returns the default configuration overridden by the
+// NOTIFICATION_* environment variables. Invalid numbers and durations are ignored.
+func NewNotificationConfigurationFromEnv() (configuration *NotificationConfiguration) {
+	configuration = NewDefaultNotificationConfiguration()
+	if sender := os.Getenv("NOTIFICATION_SENDER"); sender != "" {
+		configuration.Sender = sender
+	}
+	configuration.TemplatesDir = os.Getenv("NOTIFICATION_TEMPLATES_DIR")
+	if notifier := os.Getenv("NOTIFICATION_NOTIFIER"); notifier != "" {
+		configuration.Notifier = notifier
+	}
+	if notifierDir := os.Getenv("NOTIFICATION_DIR"); notifierDir != "" {
+		configuration.NotifierDir = notifierDir
+	}
+	configuration.Interval = durationFromEnv("NOTIFICATION_INTERVAL", configuration.Interval)
+	configuration.BatchSize = intFromEnv("NOTIFICATION_BATCH_SIZE", configuration.BatchSize)
+	configuration.Lease = durationFromEnv("NOTIFICATION_LEASE", configuration.Lease)
+	configuration.MaxAttempts = intFromEnv("NOTIFICATION_MAX_ATTEMPTS", configuration.MaxAttempts)
+	configuration.BaseBackoff = durationFromEnv("NOTIFICATION_BASE_BACKOFF", configuration.BaseBackoff)
+	configuration.MaxBackoff = durationFromEnv("NOTIFICATION_MAX_BACKOFF", configuration.MaxBackoff)
+	return
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func intFromEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
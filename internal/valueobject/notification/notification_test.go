@@ -0,0 +1,50 @@
+package notification_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewDefaultNotificationConfiguration tests the NewDefaultNotificationConfiguration function.
+func TestNewDefaultNotificationConfiguration(t *testing.T) {
+	// When call NewDefaultNotificationConfiguration
+	configuration := notification.NewDefaultNotificationConfiguration()
+	// Then the messages are logged and retried with backoff
+	assert.Equal(t, "no-reply@amazingemail.com", configuration.Sender)
+	assert.Equal(t, notification.LogNotifier, configuration.Notifier)
+	assert.Equal(t, 5, configuration.MaxAttempts)
+	assert.Equal(t, 30*time.Second, configuration.BaseBackoff)
+	assert.Equal(t, time.Hour, configuration.MaxBackoff)
+}
+
+// TestNewNotificationConfigurationFromEnv tests the NewNotificationConfigurationFromEnv function.
+func TestNewNotificationConfigurationFromEnv(t *testing.T) {
+	// Given the NOTIFICATION_* environment variables
+	t.Setenv("NOTIFICATION_SENDER", "summary@amazingemail.com")
+	t.Setenv("NOTIFICATION_TEMPLATES_DIR", "/etc/templates")
+	t.Setenv("NOTIFICATION_NOTIFIER", notification.FileNotifier)
+	t.Setenv("NOTIFICATION_DIR", "/tmp/outbox")
+	t.Setenv("NOTIFICATION_INTERVAL", "1s")
+	t.Setenv("NOTIFICATION_BATCH_SIZE", "50")
+	t.Setenv("NOTIFICATION_MAX_ATTEMPTS", "3")
+	t.Setenv("NOTIFICATION_BASE_BACKOFF", "10s")
+	// And an invalid max backoff
+	t.Setenv("NOTIFICATION_MAX_BACKOFF", "forever")
+	// When call NewNotificationConfigurationFromEnv
+	configuration := notification.NewNotificationConfigurationFromEnv()
+	// Then the variables override the defaults
+	assert.Equal(t, "summary@amazingemail.com", configuration.Sender)
+	assert.Equal(t, "/etc/templates", configuration.TemplatesDir)
+	assert.Equal(t, notification.FileNotifier, configuration.Notifier)
+	assert.Equal(t, "/tmp/outbox", configuration.NotifierDir)
+	assert.Equal(t, time.Second, configuration.Interval)
+	assert.Equal(t, 50, configuration.BatchSize)
+	assert.Equal(t, time.Minute, configuration.Lease)
+	assert.Equal(t, 3, configuration.MaxAttempts)
+	assert.Equal(t, 10*time.Second, configuration.BaseBackoff)
+	// And the invalid value keeps the default
+	assert.Equal(t, time.Hour, configuration.MaxBackoff)
+}
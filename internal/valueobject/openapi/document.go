@@ -0,0 +1,245 @@
+// Package openapi embeds the OpenAPI document of the REST API and validates the requests
+// and the responses of the handlers against it, so a handler drifting from the document
+// fails its tests.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Path is the path the document is served at.
+const Path = "/openapi.json"
+
+// spec is the OpenAPI document of the REST API.
+//
+//go:embed openapi.json
+var spec []byte
+
+// componentsPrefix is the prefix of the references to the components of the document.
+const componentsPrefix = "#/components/"
+
+// Document struct defines the subset of an OpenAPI 3 document used to validate the
+// requests and the responses.
+type Document struct {
+	// OpenAPI is the version of the OpenAPI specification.
+	OpenAPI string `json:"openapi"`
+	// Paths are the operations of every path template, by lowercase method.
+	Paths map[string]map[string]*Operation `json:"paths"`
+	// Components are the schemas and the responses referenced by the operations.
+	Components Components `json:"components"`
+}
+
+// Components struct defines the reusable parts of the document.
+type Components struct {
+	// Schemas are the schemas by name.
+	Schemas map[string]*Schema `json:"schemas"`
+	// Responses are the responses by name.
+	Responses map[string]*Response `json:"responses"`
+}
+
+// Operation struct defines a method of a path.
+type Operation struct {
+	// OperationID identifies the operation.
+	OperationID string `json:"operationId"`
+	// Parameters are the path and query parameters of the operation.
+	Parameters []Parameter `json:"parameters"`
+	// RequestBody is the body of the requests, nil when the operation has no body.
+	RequestBody *RequestBody `json:"requestBody"`
+	// Responses are the responses of the operation by status code.
+	Responses map[string]*Response `json:"responses"`
+}
+
+// Parameter struct defines a path or a query parameter.
+type Parameter struct {
+	// Name is the name of the parameter.
+	Name string `json:"name"`
+	// In is path or query.
+	In string `json:"in"`
+	// Required is whether the requests must have the parameter.
+	Required bool `json:"required"`
+	// Schema is the schema of the value of the parameter.
+	Schema *Schema `json:"schema"`
+}
+
+// RequestBody struct defines the body of the requests of an operation.
+type RequestBody struct {
+	// Required is whether the requests must have a body.
+	Required bool `json:"required"`
+	// Content are the schemas of the body by media type.
+	Content map[string]MediaType `json:"content"`
+}
+
+// Response struct defines a response of an operation.
+type Response struct {
+	// Ref is the reference to a response of the components.
+	Ref string `json:"$ref"`
+	// Content are the schemas of the body by media type, empty when the response has no body.
+	Content map[string]MediaType `json:"content"`
+}
+
+// MediaType struct defines the schema of a body.
+type MediaType struct {
+	// Schema is the schema of the body.
+	Schema *Schema `json:"schema"`
+}
+
+// Load returns the embedded OpenAPI document.
+func Load() (document *Document, err error) {
+	return Parse(spec)
+}
+
+// Parse returns the OpenAPI document of the JSON, checking every reference resolves to
+// a component.
+func Parse(data []byte) (document *Document, err error) {
+	parsed := &Document{}
+	if err = json.Unmarshal(data, parsed); err != nil {
+		err = fmt.Errorf("%w: %s", ErrInvalidDocument, err)
+		return
+	}
+	if err = parsed.check(); err != nil {
+		return
+	}
+	document = parsed
+	return
+}
+
+// Operation returns the operation of the method and the path template, nil when the
+// document does not have it.
+func (document *Document) Operation(method string, template string) *Operation {
+	return document.Paths[template][strings.ToLower(method)]
+}
+
+// Routes returns the method and the path template of every operation, such as
+// "GET /accounts/{id}", sorted.
+func (document *Document) Routes() (routes []string) {
+	for template, operations := range document.Paths {
+		for method := range operations {
+			routes = append(routes, strings.ToUpper(method)+" "+template)
+		}
+	}
+	sort.Strings(routes)
+	return
+}
+
+// response returns the response of the operation for the status, resolving its reference.
+func (document *Document) response(operation *Operation, status int) (response *Response, found bool) {
+	response, found = operation.Responses[fmt.Sprint(status)]
+	if !found {
+		response, found = operation.Responses["default"]
+	}
+	if found && response.Ref != "" {
+		response, found = document.Components.Responses[strings.TrimPrefix(response.Ref, componentsPrefix+"responses/")]
+	}
+	return
+}
+
+// schema returns the schema of the reference to the components.
+func (document *Document) schema(ref string) (schema *Schema, found bool) {
+	if !strings.HasPrefix(ref, componentsPrefix+"schemas/") {
+		return
+	}
+	schema, found = document.Components.Schemas[strings.TrimPrefix(ref, componentsPrefix+"schemas/")]
+	return
+}
+
+// check returns ErrInvalidDocument when an operation has an unknown method or a
+// reference does not resolve.
+func (document *Document) check() (err error) {
+	for name, schema := range document.Components.Schemas {
+		if err = document.checkSchema(schema); err != nil {
+			return fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+	for name, response := range document.Components.Responses {
+		if err = document.checkContent(response.Content); err != nil {
+			return fmt.Errorf("response %s: %w", name, err)
+		}
+	}
+	for template, operations := range document.Paths {
+		for method, operation := range operations {
+			if err = document.checkOperation(method, operation); err != nil {
+				return fmt.Errorf("%s %s: %w", strings.ToUpper(method), template, err)
+			}
+		}
+	}
+	return
+}
+
+// checkOperation checks the method, the parameters, the body and the responses of the
+// operation.
+func (document *Document) checkOperation(method string, operation *Operation) (err error) {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead, http.MethodOptions:
+	default:
+		return fmt.Errorf("%w: unknown method %s", ErrInvalidDocument, method)
+	}
+	if operation == nil || len(operation.Responses) == 0 {
+		return fmt.Errorf("%w: operation without responses", ErrInvalidDocument)
+	}
+	for _, parameter := range operation.Parameters {
+		if parameter.In != "path" && parameter.In != "query" {
+			return fmt.Errorf("%w: parameter %s in %s is not supported", ErrInvalidDocument, parameter.Name, parameter.In)
+		}
+		if err = document.checkSchema(parameter.Schema); err != nil {
+			return
+		}
+	}
+	if operation.RequestBody != nil {
+		if err = document.checkContent(operation.RequestBody.Content); err != nil {
+			return
+		}
+	}
+	for status, response := range operation.Responses {
+		if response.Ref != "" {
+			if _, found := document.Components.Responses[strings.TrimPrefix(response.Ref, componentsPrefix+"responses/")]; !found {
+				return fmt.Errorf("%w: response %s references the missing %s", ErrInvalidDocument, status, response.Ref)
+			}
+			continue
+		}
+		if err = document.checkContent(response.Content); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// checkContent checks the schemas of the media types.
+func (document *Document) checkContent(content map[string]MediaType) (err error) {
+	for _, mediaType := range content {
+		if err = document.checkSchema(mediaType.Schema); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// checkSchema checks the references of the schema and of its nested schemas resolve.
+func (document *Document) checkSchema(schema *Schema) (err error) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		if _, found := document.schema(schema.Ref); !found {
+			return fmt.Errorf("%w: missing %s", ErrInvalidDocument, schema.Ref)
+		}
+	}
+	for _, property := range schema.Properties {
+		if err = document.checkSchema(property); err != nil {
+			return
+		}
+	}
+	return document.checkSchema(schema.Items)
+}
+
+// Handler returns the handler serving the embedded document.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write(spec)
+	})
+}
@@ -0,0 +1,60 @@
+package openapi_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoad tests the embedded document is valid and documents the routes of the API.
+func TestLoad(t *testing.T) {
+	// When the embedded document is loaded
+	document, err := openapi.Load()
+	// Then it has the operations of the routes
+	assert.Nil(t, err)
+	assert.Equal(t, "3.0.3", document.OpenAPI)
+	assert.Contains(t, document.Routes(), "POST /loadfile")
+	assert.Contains(t, document.Routes(), "GET /users/{id}/summary")
+	assert.NotNil(t, document.Operation(http.MethodGet, "/accounts/{id}"))
+	assert.Nil(t, document.Operation(http.MethodDelete, "/accounts/{id}"))
+}
+
+// TestParseInvalidDocument tests the documents that cannot be read or have missing
+// references are rejected.
+func TestParseInvalidDocument(t *testing.T) {
+	tests := map[string]string{
+		"not JSON":          `{`,
+		"missing schema":    `{"paths": {"/a": {"get": {"responses": {"200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Missing"}}}}}}}}}`,
+		"missing response":  `{"paths": {"/a": {"get": {"responses": {"404": {"$ref": "#/components/responses/Missing"}}}}}}`,
+		"unknown method":    `{"paths": {"/a": {"fetch": {"responses": {"200": {"description": "ok"}}}}}}`,
+		"without responses": `{"paths": {"/a": {"get": {}}}}`,
+		"header parameter":  `{"paths": {"/a": {"get": {"parameters": [{"name": "X", "in": "header"}], "responses": {"200": {"description": "ok"}}}}}}`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			// When the document is parsed
+			document, err := openapi.Parse([]byte(data))
+			// Then the error is ErrInvalidDocument
+			assert.Nil(t, document)
+			assert.ErrorIs(t, err, openapi.ErrInvalidDocument)
+		})
+	}
+}
+
+// TestHandler tests the embedded document is served as JSON.
+func TestHandler(t *testing.T) {
+	// When the document is requested
+	recorder := httptest.NewRecorder()
+	openapi.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, openapi.Path, nil))
+	// Then the served document is the embedded one
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	body, err := io.ReadAll(recorder.Body)
+	assert.Nil(t, err)
+	_, err = openapi.Parse(body)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,22 @@
+package openapi
+
+import "errors"
+
+var (
+	// ErrInvalidDocument is the error returned when the OpenAPI document cannot be read or
+	// has a reference to a missing component.
+	ErrInvalidDocument = errors.New("openapi document is not valid")
+	// ErrNilDocument is the error returned when the OpenAPI document is nil.
+	ErrNilDocument = errors.New("openapi document is nil")
+	// ErrNilValidator is the error returned when the validator is nil.
+	ErrNilValidator = errors.New("openapi validator is nil")
+	// ErrNilReport is the error returned when the function reporting the violations is nil.
+	ErrNilReport = errors.New("openapi report function is nil")
+	// ErrOperationNotDocumented is the error returned when the method and the path of a
+	// request have no operation in the document.
+	ErrOperationNotDocumented = errors.New("operation is not documented")
+	// ErrInvalidRequest is the error returned when a request does not match its operation.
+	ErrInvalidRequest = errors.New("request does not match the openapi document")
+	// ErrInvalidResponse is the error returned when a response does not match its operation.
+	ErrInvalidResponse = errors.New("response does not match the openapi document")
+)
@@ -0,0 +1,59 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/httpresponse"
+)
+
+// ReportFunc reports a violation of the document by a request or its response.
+type ReportFunc func(request *http.Request, err error)
+
+// Middleware returns a middleware validating every request and its response against the
+// document and reporting the violations, the responses are sent unchanged. The invalid
+// requests are only reported when they are answered with a 2xx status, since rejecting
+// them is the expected behaviour. The requests are buffered in memory, so it is meant for
+// the tests of the handlers, see the openapitest package, and not for the production traffic.
+func Middleware(documentValidator Validator, report ReportFunc) (middleware func(next http.Handler) http.Handler, err error) {
+	if documentValidator == nil {
+		err = ErrNilValidator
+		return
+	}
+	if report == nil {
+		err = ErrNilReport
+		return
+	}
+	middleware = func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			errRequest := documentValidator.ValidateRequest(request)
+			recorder := &responseRecorder{StatusRecorder: httpresponse.NewStatusRecorder(writer)}
+			next.ServeHTTP(recorder, request)
+			if errors.Is(errRequest, ErrOperationNotDocumented) {
+				report(request, errRequest)
+				return
+			}
+			if errRequest != nil && recorder.Status >= 200 && recorder.Status < 300 {
+				report(request, errRequest)
+			}
+			if errResponse := documentValidator.ValidateResponse(request, recorder.Status, writer.Header(), recorder.body.Bytes()); errResponse != nil {
+				report(request, errResponse)
+			}
+		})
+	}
+	return
+}
+
+// responseRecorder struct defines a response writer keeping a copy of the status and the
+// body of the response.
+type responseRecorder struct {
+	*httpresponse.StatusRecorder
+	body bytes.Buffer
+}
+
+// Write records the body and writes it.
+func (recorder *responseRecorder) Write(data []byte) (int, error) {
+	recorder.body.Write(data)
+	return recorder.StatusRecorder.Write(data)
+}
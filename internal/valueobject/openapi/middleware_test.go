@@ -0,0 +1,79 @@
+package openapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMiddleware tests the violations of the document are reported, except the invalid
+// requests the handler rejects.
+func TestMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		request    *http.Request
+		status     int
+		body       string
+		violations int
+	}{
+		"valid":                      {newRequest("/items/42?tag=a", validItem), http.StatusOK, validItem, 0},
+		"invalid request rejected":   {newRequest("/items/abc?tag=a", validItem), http.StatusBadRequest, "", 0},
+		"invalid request accepted":   {newRequest("/items/abc?tag=a", validItem), http.StatusOK, validItem, 1},
+		"invalid response":           {newRequest("/items/42?tag=a", validItem), http.StatusOK, `{"name": "book"}`, 1},
+		"undocumented operation":     {newRequest("/orders/42", validItem), http.StatusOK, validItem, 1},
+		"invalid request and answer": {newRequest("/items/abc?tag=a", validItem), http.StatusOK, "{}", 2},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Given a handler answering with the status and the body
+			var violations []error
+			middleware, err := openapi.Middleware(newTestValidator(t), func(request *http.Request, err error) {
+				violations = append(violations, err)
+			})
+			assert.Nil(t, err)
+			handler := middleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				if test.body != "" {
+					writer.Header().Set("Content-Type", "application/json")
+				}
+				writer.WriteHeader(test.status)
+				writer.Write([]byte(test.body))
+			}))
+			// When the request is served
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, test.request)
+			// Then the response is sent unchanged and the violations are reported
+			assert.Equal(t, test.status, recorder.Code)
+			assert.Equal(t, test.body, recorder.Body.String())
+			assert.Len(t, violations, test.violations, violations)
+		})
+	}
+}
+
+// TestMiddlewareWithNilArguments tests the errors returned without validator or report.
+func TestMiddlewareWithNilArguments(t *testing.T) {
+	_, err := openapi.Middleware(nil, func(request *http.Request, err error) {})
+	assert.Equal(t, openapi.ErrNilValidator, err)
+	_, err = openapi.Middleware(newTestValidator(t), nil)
+	assert.Equal(t, openapi.ErrNilReport, err)
+}
+
+// TestMiddlewareFlush tests the handlers can flush the response being validated.
+func TestMiddlewareFlush(t *testing.T) {
+	// Given a handler flushing its response
+	middleware, err := openapi.Middleware(newTestValidator(t), func(request *http.Request, err error) {})
+	assert.Nil(t, err)
+	handler := middleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		flusher, ok := writer.(http.Flusher)
+		assert.True(t, ok)
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(validItem))
+		flusher.Flush()
+	}))
+	// When the request is served
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newRequest("/items/42?tag=a", validItem))
+	// Then the response is flushed
+	assert.True(t, recorder.Flushed)
+}
@@ -0,0 +1,40 @@
+// Package openapitest validates the requests and the responses of the handlers under test
+// against the OpenAPI document, so a handler drifting from the document fails its tests.
+package openapitest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/openapi"
+	"github.com/gorilla/mux"
+)
+
+// NewRouter returns a router failing the test on every violation of the document by the
+// requests to its routes or by their responses.
+func NewRouter(t testing.TB) *mux.Router {
+	t.Helper()
+	router := mux.NewRouter()
+	router.Use(Middleware(t))
+	return router
+}
+
+// Middleware returns the middleware failing the test on every violation of the document.
+func Middleware(t testing.TB) func(next http.Handler) http.Handler {
+	t.Helper()
+	document, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("loading the openapi document: %v", err)
+	}
+	validator, err := openapi.NewValidator(document)
+	if err != nil {
+		t.Fatalf("creating the openapi validator: %v", err)
+	}
+	middleware, err := openapi.Middleware(validator, func(request *http.Request, err error) {
+		t.Errorf("openapi: %v", err)
+	})
+	if err != nil {
+		t.Fatalf("creating the openapi middleware: %v", err)
+	}
+	return middleware
+}
@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema struct defines the subset of the JSON schemas of OpenAPI 3 used by the document:
+// the types, the formats uuid, date-time, int64 and binary, the enums, the required and
+// the additional properties, and the references to the components.
+type Schema struct {
+	// Ref is the reference to a schema of the components.
+	Ref string `json:"$ref"`
+	// Type is object, array, string, integer, number or boolean, any value when empty.
+	Type string `json:"type"`
+	// Format is the format of the strings and the integers.
+	Format string `json:"format"`
+	// Nullable is whether the value can be null.
+	Nullable bool `json:"nullable"`
+	// Enum are the allowed values.
+	Enum []interface{} `json:"enum"`
+	// Required are the properties an object must have.
+	Required []string `json:"required"`
+	// Properties are the schemas of the properties of an object.
+	Properties map[string]*Schema `json:"properties"`
+	// AdditionalProperties is false when an object only has the documented properties.
+	AdditionalProperties *bool `json:"additionalProperties"`
+	// Items is the schema of the items of an array.
+	Items *Schema `json:"items"`
+}
+
+// validate returns the first violation of the schema by the value decoded with UseNumber,
+// located by its path in the body such as "report.problems[0].line".
+func (document *Document) validate(schema *Schema, value interface{}, location string) (err error) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		resolved, found := document.schema(schema.Ref)
+		if !found {
+			return fmt.Errorf("%s: missing %s", location, schema.Ref)
+		}
+		return document.validate(resolved, value, location)
+	}
+	if value == nil {
+		if schema.Nullable || schema.Type == "" {
+			return
+		}
+		return fmt.Errorf("%s: is null, expected %s", location, schema.Type)
+	}
+	if err = checkType(schema, value, location); err != nil {
+		return
+	}
+	if err = checkEnum(schema, value, location); err != nil {
+		return
+	}
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		return document.validateObject(schema, typed, location)
+	case []interface{}:
+		for index, item := range typed {
+			if err = document.validate(schema.Items, item, fmt.Sprintf("%s[%d]", location, index)); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// validateObject checks the required, the documented and the additional properties.
+func (document *Document) validateObject(schema *Schema, object map[string]interface{}, location string) (err error) {
+	for _, name := range schema.Required {
+		if _, found := object[name]; !found {
+			return fmt.Errorf("%s: missing the required property %s", location, name)
+		}
+	}
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property, documented := schema.Properties[name]
+		if !documented {
+			if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+				return fmt.Errorf("%s: the property %s is not documented", location, name)
+			}
+			continue
+		}
+		if err = document.validate(property, object[name], join(location, name)); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// checkType checks the value has the type and the format of the schema.
+func checkType(schema *Schema, value interface{}, location string) (err error) {
+	valid := true
+	switch schema.Type {
+	case "":
+	case "object":
+		_, valid = value.(map[string]interface{})
+	case "array":
+		_, valid = value.([]interface{})
+	case "boolean":
+		_, valid = value.(bool)
+	case "number":
+		number, isNumber := value.(json.Number)
+		_, errFloat := strconv.ParseFloat(string(number), 64)
+		valid = isNumber && errFloat == nil
+	case "integer":
+		number, isNumber := value.(json.Number)
+		valid = isNumber && isInteger(string(number), schema.Format)
+	case "string":
+		text, isString := value.(string)
+		valid = isString && hasFormat(text, schema.Format)
+	default:
+		return fmt.Errorf("%s: type %s is not supported", location, schema.Type)
+	}
+	if !valid {
+		expected := schema.Type
+		if schema.Format != "" {
+			expected += " of format " + schema.Format
+		}
+		return fmt.Errorf("%s: %v is not a valid %s", location, value, expected)
+	}
+	return
+}
+
+// checkEnum checks the value is one of the enum of the schema, if any.
+func checkEnum(schema *Schema, value interface{}, location string) (err error) {
+	if len(schema.Enum) == 0 {
+		return
+	}
+	for _, allowed := range schema.Enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return
+		}
+	}
+	return fmt.Errorf("%s: %v is not one of %v", location, value, schema.Enum)
+}
+
+// isInteger returns whether the number is an integer, in the range of int32 unless the
+// format is int64.
+func isInteger(number string, format string) bool {
+	bitSize := 32
+	if format == "int64" {
+		bitSize = 64
+	}
+	_, err := strconv.ParseInt(number, 10, bitSize)
+	return err == nil
+}
+
+// hasFormat returns whether the string has the format, any string has the unknown ones.
+func hasFormat(text string, format string) bool {
+	switch format {
+	case "uuid":
+		_, err := uuid.Parse(text)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339Nano, text)
+		return err == nil
+	}
+	return true
+}
+
+// parseParameter returns the value of the path or the query parameter converted to the
+// type of its schema, so it is validated like a JSON value.
+func parseParameter(schema *Schema, text string) interface{} {
+	if schema == nil {
+		return text
+	}
+	switch schema.Type {
+	case "integer", "number":
+		return json.Number(text)
+	case "boolean":
+		if parsed, err := strconv.ParseBool(text); err == nil {
+			return parsed
+		}
+	}
+	return text
+}
+
+// join returns the location of the property of the object at the location.
+func join(location string, name string) string {
+	if location == "" {
+		return name
+	}
+	return location + "." + name
+}
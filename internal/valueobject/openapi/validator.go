@@ -0,0 +1,267 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Validator interface defines the validation of the requests and the responses of the
+// REST API against the OpenAPI document.
+type Validator interface {
+	// ValidateRequest returns ErrInvalidRequest when the parameters or the body of the
+	// request do not match its operation. The body is read and restored.
+	ValidateRequest(request *http.Request) (err error)
+	// ValidateResponse returns ErrInvalidResponse when the status, the content type or the
+	// body of the response to the request are not documented by its operation.
+	ValidateResponse(request *http.Request, status int, header http.Header, body []byte) (err error)
+}
+
+// route struct defines the operation of a method and a path template.
+type route struct {
+	method    string
+	template  string
+	segments  []string
+	literals  int
+	operation *Operation
+}
+
+// validator struct implements the Validator interface.
+type validator struct {
+	document *Document
+	routes   []route
+}
+
+// NewValidator returns a Validator of the document.
+func NewValidator(document *Document) (documentValidator Validator, err error) {
+	if document == nil {
+		err = ErrNilDocument
+		return
+	}
+	newValidator := &validator{document: document}
+	for template, operations := range document.Paths {
+		segments := strings.Split(strings.Trim(template, "/"), "/")
+		literals := 0
+		for _, segment := range segments {
+			if !isParameter(segment) {
+				literals++
+			}
+		}
+		for method, operation := range operations {
+			newValidator.routes = append(newValidator.routes, route{method: strings.ToUpper(method), template: template,
+				segments: segments, literals: literals, operation: operation})
+		}
+	}
+	// The routes with more literal segments are matched first, so /loadfile/validate is
+	// not taken for a parameter of another template.
+	sort.Slice(newValidator.routes, func(i, j int) bool {
+		if newValidator.routes[i].literals != newValidator.routes[j].literals {
+			return newValidator.routes[i].literals > newValidator.routes[j].literals
+		}
+		return newValidator.routes[i].template < newValidator.routes[j].template
+	})
+	documentValidator = newValidator
+	return
+}
+
+// ValidateRequest returns ErrInvalidRequest when the request does not match its operation.
+func (validator *validator) ValidateRequest(request *http.Request) (err error) {
+	matched, parameters, err := validator.match(request)
+	if err != nil {
+		return
+	}
+	if err = validator.validateParameters(matched.operation, parameters, request); err == nil {
+		err = validator.validateRequestBody(matched.operation, request)
+	}
+	if err != nil {
+		err = fmt.Errorf("%w: %s %s: %s", ErrInvalidRequest, request.Method, request.URL.Path, err)
+	}
+	return
+}
+
+// ValidateResponse returns ErrInvalidResponse when the response is not documented.
+func (validator *validator) ValidateResponse(request *http.Request, status int, header http.Header, body []byte) (err error) {
+	matched, _, err := validator.match(request)
+	if err != nil {
+		return
+	}
+	if err = validator.validateResponse(matched.operation, status, header, body); err != nil {
+		err = fmt.Errorf("%w: %s %s: status %d: %s", ErrInvalidResponse, request.Method, request.URL.Path, status, err)
+	}
+	return
+}
+
+// match returns the route of the request and the values of its path parameters.
+func (validator *validator) match(request *http.Request) (matched route, parameters map[string]string, err error) {
+	segments := strings.Split(strings.Trim(request.URL.Path, "/"), "/")
+	for _, candidate := range validator.routes {
+		if candidate.method != request.Method {
+			continue
+		}
+		if values, found := matchSegments(candidate.segments, segments); found {
+			return candidate, values, nil
+		}
+	}
+	err = fmt.Errorf("%w: %s %s", ErrOperationNotDocumented, request.Method, request.URL.Path)
+	return
+}
+
+// validateParameters checks the path and the query parameters of the request.
+func (validator *validator) validateParameters(operation *Operation, path map[string]string, request *http.Request) (err error) {
+	query := request.URL.Query()
+	for _, parameter := range operation.Parameters {
+		value, found := path[parameter.Name], parameter.In == "path"
+		if parameter.In == "query" {
+			found = query.Has(parameter.Name)
+			value = query.Get(parameter.Name)
+		}
+		if !found {
+			if parameter.Required {
+				return fmt.Errorf("missing the required %s parameter %s", parameter.In, parameter.Name)
+			}
+			continue
+		}
+		location := parameter.In + " parameter " + parameter.Name
+		if err = validator.document.validate(parameter.Schema, parseParameter(parameter.Schema, value), location); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// validateRequestBody checks the content type and the body of the request. The body is
+// restored so the handler reads it.
+func (validator *validator) validateRequestBody(operation *Operation, request *http.Request) (err error) {
+	if operation.RequestBody == nil || request.Body == nil {
+		return
+	}
+	body, err := io.ReadAll(request.Body)
+	request.Body.Close()
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("reading the body: %s", err)
+	}
+	if len(body) == 0 {
+		if operation.RequestBody.Required {
+			return errors.New("missing the required body")
+		}
+		return
+	}
+	mediaType, params, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	if err != nil {
+		return fmt.Errorf("content type %q is not valid", request.Header.Get("Content-Type"))
+	}
+	content, documented := operation.RequestBody.Content[mediaType]
+	if !documented {
+		return fmt.Errorf("content type %s is not documented", mediaType)
+	}
+	value, err := decodeBody(mediaType, params, body)
+	if err != nil {
+		return
+	}
+	return validator.document.validate(content.Schema, value, "body")
+}
+
+// validateResponse checks the status, the content type and the body of the response.
+func (validator *validator) validateResponse(operation *Operation, status int, header http.Header, body []byte) (err error) {
+	response, documented := validator.document.response(operation, status)
+	if !documented {
+		return errors.New("the status is not documented")
+	}
+	if len(response.Content) == 0 {
+		if len(body) > 0 {
+			return errors.New("the body is not documented")
+		}
+		return
+	}
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return fmt.Errorf("content type %q is not valid", header.Get("Content-Type"))
+	}
+	content, documented := response.Content[mediaType]
+	if !documented {
+		return fmt.Errorf("content type %s is not documented", mediaType)
+	}
+	value, err := decodeBody(mediaType, params, body)
+	if err != nil {
+		return
+	}
+	return validator.document.validate(content.Schema, value, "body")
+}
+
+// decodeBody returns the JSON value of a JSON body, the fields of a multipart form as an
+// object, or the text of any other body.
+func decodeBody(mediaType string, params map[string]string, body []byte) (value interface{}, err error) {
+	switch {
+	case mediaType == "application/json":
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err = decoder.Decode(&value); err != nil {
+			err = fmt.Errorf("body is not valid JSON: %s", err)
+		}
+	case mediaType == "multipart/form-data":
+		value, err = decodeForm(params["boundary"], body)
+	default:
+		value = string(body)
+	}
+	return
+}
+
+// decodeForm returns the fields of the multipart form by name. The value of the files is
+// their name, their content is not read.
+func decodeForm(boundary string, body []byte) (form map[string]interface{}, err error) {
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	form = map[string]interface{}{}
+	for {
+		part, errPart := reader.NextPart()
+		if errPart == io.EOF {
+			return
+		}
+		if errPart != nil {
+			return nil, fmt.Errorf("body is not a valid multipart form: %s", errPart)
+		}
+		if part.FileName() != "" {
+			form[part.FormName()] = part.FileName()
+			continue
+		}
+		value, errRead := io.ReadAll(part)
+		if errRead != nil {
+			return nil, fmt.Errorf("body is not a valid multipart form: %s", errRead)
+		}
+		form[part.FormName()] = string(value)
+	}
+}
+
+// matchSegments returns the values of the parameters of the template when the segments
+// of the path match it.
+func matchSegments(template []string, path []string) (values map[string]string, found bool) {
+	if len(template) != len(path) {
+		return
+	}
+	values = map[string]string{}
+	for index, segment := range template {
+		if isParameter(segment) {
+			if path[index] == "" {
+				return nil, false
+			}
+			values[strings.Trim(segment, "{}")] = path[index]
+			continue
+		}
+		if segment != path[index] {
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+// isParameter returns whether the segment of a path template is a parameter.
+func isParameter(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
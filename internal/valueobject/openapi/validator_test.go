@@ -0,0 +1,195 @@
+package openapi_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+// testDocument documents an operation with path and query parameters and a JSON body.
+const testDocument = `{
+  "openapi": "3.0.3",
+  "paths": {
+    "/items/{id}": {
+      "put": {
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "schema": {"type": "integer", "format": "int64"}},
+          {"name": "dry_run", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "tag", "in": "query", "required": true, "schema": {"type": "string", "enum": ["a", "b"]}}
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Item"}}}
+        },
+        "responses": {
+          "200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Item"}}}},
+          "204": {"description": "no content"},
+          "400": {"description": "rejected"}
+        }
+      }
+    },
+    "/items/latest": {
+      "put": {"responses": {"200": {"description": "ok"}}}
+    }
+  },
+  "components": {
+    "schemas": {
+      "Item": {
+        "type": "object",
+        "required": ["id", "name"],
+        "properties": {
+          "id": {"type": "string", "format": "uuid"},
+          "name": {"type": "string"},
+          "price": {"type": "number", "nullable": true},
+          "tags": {"type": "array", "items": {"type": "string"}}
+        },
+        "additionalProperties": false
+      }
+    }
+  }
+}`
+
+// validItem is a body matching the Item schema.
+const validItem = `{"id": "6f1c8f3e-7d9a-4a8b-9a43-0e9b8c1f2a11", "name": "book", "price": null, "tags": ["new"]}`
+
+// newTestValidator returns the validator of the test document.
+func newTestValidator(t *testing.T) openapi.Validator {
+	document, err := openapi.Parse([]byte(testDocument))
+	assert.Nil(t, err)
+	validator, err := openapi.NewValidator(document)
+	assert.Nil(t, err)
+	return validator
+}
+
+// newRequest returns a PUT request with a JSON body.
+func newRequest(target string, body string) *http.Request {
+	request := httptest.NewRequest(http.MethodPut, target, strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	return request
+}
+
+// TestNewValidatorWithNilDocument tests the error returned without document.
+func TestNewValidatorWithNilDocument(t *testing.T) {
+	_, err := openapi.NewValidator(nil)
+	assert.Equal(t, openapi.ErrNilDocument, err)
+}
+
+// TestValidateRequest tests the parameters and the body of the requests are validated.
+func TestValidateRequest(t *testing.T) {
+	validator := newTestValidator(t)
+	textRequest := newRequest("/items/42?tag=a", validItem)
+	textRequest.Header.Set("Content-Type", "text/plain")
+	tests := map[string]struct {
+		request *http.Request
+		err     error
+	}{
+		"valid":                 {newRequest("/items/42?tag=a&dry_run=true", validItem), nil},
+		"literal template":      {newRequest("/items/latest", ""), nil},
+		"undocumented method":   {httptest.NewRequest(http.MethodGet, "/items/42", nil), openapi.ErrOperationNotDocumented},
+		"undocumented path":     {newRequest("/items/42/parts", validItem), openapi.ErrOperationNotDocumented},
+		"invalid path":          {newRequest("/items/abc?tag=a", validItem), openapi.ErrInvalidRequest},
+		"missing query":         {newRequest("/items/42", validItem), openapi.ErrInvalidRequest},
+		"invalid query":         {newRequest("/items/42?tag=a&dry_run=maybe", validItem), openapi.ErrInvalidRequest},
+		"value not in the enum": {newRequest("/items/42?tag=c", validItem), openapi.ErrInvalidRequest},
+		"missing body":          {newRequest("/items/42?tag=a", ""), openapi.ErrInvalidRequest},
+		"undocumented content":  {textRequest, openapi.ErrInvalidRequest},
+		"invalid JSON":          {newRequest("/items/42?tag=a", "{"), openapi.ErrInvalidRequest},
+		"missing property":      {newRequest("/items/42?tag=a", `{"id": "6f1c8f3e-7d9a-4a8b-9a43-0e9b8c1f2a11"}`), openapi.ErrInvalidRequest},
+		"invalid format":        {newRequest("/items/42?tag=a", `{"id": "42", "name": "book"}`), openapi.ErrInvalidRequest},
+		"undocumented property": {newRequest("/items/42?tag=a", `{"id": "6f1c8f3e-7d9a-4a8b-9a43-0e9b8c1f2a11", "name": "book", "stock": 1}`), openapi.ErrInvalidRequest},
+		"invalid item":          {newRequest("/items/42?tag=a", `{"id": "6f1c8f3e-7d9a-4a8b-9a43-0e9b8c1f2a11", "name": "book", "tags": [1]}`), openapi.ErrInvalidRequest},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// When the request is validated
+			err := validator.ValidateRequest(test.request)
+			// Then the error is the expected one
+			if test.err == nil {
+				assert.Nil(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+// TestValidateRequestRestoresBody tests the handler reads the body after the validation.
+func TestValidateRequestRestoresBody(t *testing.T) {
+	// Given a valid request
+	request := newRequest("/items/42?tag=a", validItem)
+	// When the request is validated
+	assert.Nil(t, newTestValidator(t).ValidateRequest(request))
+	// Then its body can be read again
+	body, err := io.ReadAll(request.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, validItem, string(body))
+}
+
+// TestValidateRequestMultipart tests the fields of the multipart forms are validated.
+func TestValidateRequestMultipart(t *testing.T) {
+	document, err := openapi.Load()
+	assert.Nil(t, err)
+	validator, err := openapi.NewValidator(document)
+	assert.Nil(t, err)
+	newUpload := func(field string) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile(field, "txns.csv")
+		assert.Nil(t, err)
+		_, err = part.Write([]byte("Id,Date,Transaction\n"))
+		assert.Nil(t, err)
+		assert.Nil(t, writer.WriteField("filename", "txns.csv"))
+		assert.Nil(t, writer.Close())
+		request := httptest.NewRequest(http.MethodPost, "/loadfile", body)
+		request.Header.Set("Content-Type", writer.FormDataContentType())
+		return request
+	}
+	// When an upload with the file is validated
+	// Then it is valid
+	assert.Nil(t, validator.ValidateRequest(newUpload("file")))
+	// When an upload without the file is validated
+	// Then it is not valid
+	assert.ErrorIs(t, validator.ValidateRequest(newUpload("document")), openapi.ErrInvalidRequest)
+}
+
+// TestValidateResponse tests the status, the content type and the body of the responses
+// are validated.
+func TestValidateResponse(t *testing.T) {
+	validator := newTestValidator(t)
+	request := newRequest("/items/42?tag=a", validItem)
+	jsonHeader := http.Header{"Content-Type": []string{"application/json; charset=utf-8"}}
+	tests := map[string]struct {
+		status int
+		header http.Header
+		body   string
+		err    error
+	}{
+		"valid":                     {http.StatusOK, jsonHeader, validItem, nil},
+		"valid without body":        {http.StatusNoContent, http.Header{}, "", nil},
+		"undocumented status":       {http.StatusNotFound, jsonHeader, validItem, openapi.ErrInvalidResponse},
+		"undocumented body":         {http.StatusNoContent, jsonHeader, validItem, openapi.ErrInvalidResponse},
+		"undocumented content type": {http.StatusOK, http.Header{"Content-Type": []string{"text/plain"}}, validItem, openapi.ErrInvalidResponse},
+		"missing body":              {http.StatusOK, jsonHeader, "", openapi.ErrInvalidResponse},
+		"invalid body":              {http.StatusOK, jsonHeader, `{"id": 1, "name": "book"}`, openapi.ErrInvalidResponse},
+		"null property":             {http.StatusOK, jsonHeader, `{"id": "6f1c8f3e-7d9a-4a8b-9a43-0e9b8c1f2a11", "name": null}`, openapi.ErrInvalidResponse},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// When the response is validated
+			err := validator.ValidateResponse(request, test.status, test.header, []byte(test.body))
+			// Then the error is the expected one
+			if test.err == nil {
+				assert.Nil(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
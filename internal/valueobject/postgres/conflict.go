@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/lib/pq"
+)
+
+const (
+	// uniqueViolation is the SQLSTATE of a duplicated key.
+	uniqueViolation = pq.ErrorCode("23505")
+	// foreignKeyViolation is the SQLSTATE of a missing or referenced row.
+	foreignKeyViolation = pq.ErrorCode("23503")
+)
+
+func init() {
+	database.RegisterConflictClassifier(classifyConflict)
+}
+
+// classifyConflict returns the conflict of the unique and foreign key violations of
+// PostgreSQL with the name of their constraint.
+func classifyConflict(cause error) (conflict error, constraint string, ok bool) {
+	var pqErr *pq.Error
+	if !errors.As(cause, &pqErr) {
+		return
+	}
+	switch pqErr.Code {
+	case uniqueViolation:
+		conflict = database.ErrUniqueViolation
+	case foreignKeyViolation:
+		conflict = database.ErrForeignKeyViolation
+	default:
+		return
+	}
+	constraint, ok = pqErr.Constraint, true
+	return
+}
@@ -0,0 +1,43 @@
+package postgres_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConflictClassifier tests the unique and foreign key violations of PostgreSQL are
+// wrapped as conflicts with their constraint.
+func TestConflictClassifier(t *testing.T) {
+	errWriting := errors.New("error writing")
+	cases := []struct {
+		name       string
+		cause      error
+		conflict   error
+		constraint string
+	}{
+		{name: "unique", cause: &pq.Error{Code: "23505", Constraint: "users_email_key"}, conflict: postgres.ErrUniqueViolation, constraint: "users_email_key"},
+		{name: "foreign key", cause: &pq.Error{Code: "23503", Constraint: "fk_account_user"}, conflict: postgres.ErrForeignKeyViolation, constraint: "fk_account_user"},
+		{name: "other", cause: &pq.Error{Code: "57014"}},
+		{name: "not postgres", cause: errors.New("connection refused")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// When wrapping the error of the driver
+			err := database.Wrap(errWriting, tc.cause)
+			// Then the sentinel and the cause are kept
+			assert.ErrorIs(t, err, errWriting)
+			assert.ErrorIs(t, err, tc.cause)
+			// And only the constraint violations are conflicts
+			var dbErr *database.Error
+			if assert.ErrorAs(t, err, &dbErr) {
+				assert.Equal(t, tc.conflict, dbErr.Conflict)
+				assert.Equal(t, tc.constraint, dbErr.Constraint)
+			}
+		})
+	}
+}
@@ -1,22 +1,29 @@
 package postgres
 
-import "errors"
+import "github.com/braejan/go-transactions-summary/internal/valueobject/database"
 
+// The errors of the postgres databases are the errors of every database.
 var (
 	// ErrOpeningDatabase is returned when the database cannot be opened.
-	ErrOpeningDatabase = errors.New("error opening database")
+	ErrOpeningDatabase = database.ErrOpeningDatabase
 	// ErrBeginningTransaction is returned when the transaction cannot be started.
-	ErrBeginningTransaction = errors.New("error beginning transaction")
+	ErrBeginningTransaction = database.ErrBeginningTransaction
 	// ErrNilConfiguration is returned when the configuration is nil.
-	ErrNilConfiguration = errors.New("error nil configuration")
+	ErrNilConfiguration = database.ErrNilConfiguration
 	// ErrDBIsNil is the error returned when the database is nil.
-	ErrDBIsNil = errors.New("database is nil")
+	ErrDBIsNil = database.ErrDBIsNil
 	// ErrExec is the error returned when the query cannot be executed.
-	ErrExec = errors.New("error executing dml")
+	ErrExec = database.ErrExec
 	// ErrCommittingTransaction is the error returned when the transaction cannot be committed.
-	ErrCommittingTransaction = errors.New("error committing transaction")
+	ErrCommittingTransaction = database.ErrCommittingTransaction
 	// ErrRollingBackTransaction is the error returned when the transaction cannot be rolled back.
-	ErrRollingBackTransaction = errors.New("error rolling back transaction")
+	ErrRollingBackTransaction = database.ErrRollingBackTransaction
 	// ErrQueryingDatabase is the error returned when the database cannot be queried.
-	ErrQueryingDatabase = errors.New("error querying database")
+	ErrQueryingDatabase = database.ErrQueryingDatabase
+	// ErrPoolIsShutDown is the error returned when the pool is used after its shutdown.
+	ErrPoolIsShutDown = database.ErrPoolIsShutDown
+	// ErrUniqueViolation is the conflict of a statement writing a duplicated key.
+	ErrUniqueViolation = database.ErrUniqueViolation
+	// ErrForeignKeyViolation is the conflict of a statement referencing a missing row.
+	ErrForeignKeyViolation = database.ErrForeignKeyViolation
 )
@@ -3,6 +3,7 @@ package mock
 import (
 	"database/sql"
 
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -147,3 +148,8 @@ func (_m *mockBasePostgresDatabase) Query(tx *sql.Tx, query string, args ...inte
 
 	return r0, r1
 }
+
+// Dialect returns the postgres dialect, the mock does not record the call.
+func (_m *mockBasePostgresDatabase) Dialect() (dialect database.Dialect) {
+	return database.Postgres
+}
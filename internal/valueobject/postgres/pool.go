@@ -0,0 +1,20 @@
+package postgres
+
+import (
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	_ "github.com/lib/pq"
+)
+
+// PostgresPool is the database.Pool of the postgres dialect.
+type PostgresPool = database.Pool
+
+// NewPostgresPool creates a new instance of PostgresPool interface implementation. The
+// connections are opened on first use.
+func NewPostgresPool(postgresConfig *PostgresConfiguration) (pool PostgresPool, err error) {
+	if postgresConfig == nil {
+		err = ErrNilConfiguration
+		return
+	}
+	pool, err = database.NewPool(postgresConfig.DatabaseConfiguration())
+	return
+}
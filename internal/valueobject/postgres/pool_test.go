@@ -0,0 +1,63 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewPostgresPoolWithNilConfiguration tests the NewPostgresPool function with a nil configuration.
+func TestNewPostgresPoolWithNilConfiguration(t *testing.T) {
+	// When call NewPostgresPool with a nil configuration
+	pool, err := postgres.NewPostgresPool(nil)
+	// Then return ErrNilConfiguration
+	assert.Nil(t, pool)
+	assert.Equal(t, postgres.ErrNilConfiguration, err)
+}
+
+// TestPostgresPoolReusesDatabase tests Open returns the same pool and Close keeps it open.
+func TestPostgresPoolReusesDatabase(t *testing.T) {
+	// Given a pool with the default configuration
+	pool, err := postgres.NewPostgresPool(postgres.NewDefaultPostgresConfiguration())
+	assert.Nil(t, err)
+	// When opening the database twice and closing it in between
+	first, err := pool.Open()
+	assert.Nil(t, err)
+	assert.Nil(t, pool.Close(first))
+	second, err := pool.Open()
+	assert.Nil(t, err)
+	// Then the same pool is returned
+	assert.Same(t, first, second)
+	assert.Equal(t, 10, second.Stats().MaxOpenConnections)
+}
+
+// TestPostgresPoolShutdown tests the pool cannot be used after its shutdown.
+func TestPostgresPoolShutdown(t *testing.T) {
+	// Given a pool already used
+	pool, _ := postgres.NewPostgresPool(postgres.NewDefaultPostgresConfiguration())
+	_, err := pool.Open()
+	assert.Nil(t, err)
+	// When shutting down the pool
+	err = pool.Shutdown()
+	// Then Open and Ping fail
+	assert.Nil(t, err)
+	_, err = pool.Open()
+	assert.Equal(t, postgres.ErrPoolIsShutDown, err)
+	assert.Equal(t, postgres.ErrPoolIsShutDown, pool.Ping(context.Background()))
+}
+
+// TestPostgresPoolPingUnreachable tests Ping fails when the database is unreachable.
+func TestPostgresPoolPingUnreachable(t *testing.T) {
+	// Given a pool pointing to a closed port
+	pool, _ := postgres.NewPostgresPool(postgres.NewPostgresConfiguration("127.0.0.1", 1, "postgres", "postgres", "db"))
+	defer pool.Shutdown()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	// When pinging the database
+	err := pool.Ping(ctx)
+	// Then an error is returned
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,66 @@
+// Package postgrestest provides a real PostgreSQL database to the repository contract
+// tests. The tests are skipped unless POSTGRES_TEST_DSN is set.
+package postgrestest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/postgres"
+)
+
+const (
+	// DSNVariable is the environment variable with the data source name of the test database.
+	DSNVariable = "POSTGRES_TEST_DSN"
+	// lockKey serializes the tests of the packages sharing the database, go test runs the
+	// packages in parallel.
+	lockKey        int64 = 4_812_337_191
+	lockDatabase         = "SELECT pg_advisory_lock($1)"
+	unlockDatabase       = "SELECT pg_advisory_unlock($1)"
+	truncateTables       = "TRUNCATE users, accounts, transactions, outbox, processing_records, api_keys CASCADE"
+)
+
+// NewDatabase returns a pool of the test database with the migrations applied and every
+// table empty. The test holds the database until it finishes. The test is skipped when
+// DSNVariable is not set.
+func NewDatabase(t *testing.T) (pool postgres.PostgresPool) {
+	t.Helper()
+	dsn := os.Getenv(DSNVariable)
+	if dsn == "" {
+		t.Skipf("%s is not set", DSNVariable)
+	}
+	pool, err := postgres.NewPostgresPool(postgres.NewPostgresConfigurationFromDSN(dsn))
+	if err != nil {
+		t.Fatalf("creating the pool of the test database: %v", err)
+	}
+	t.Cleanup(func() { _ = pool.Shutdown() })
+	db, err := pool.Open()
+	if err != nil {
+		t.Fatalf("opening the test database: %v", err)
+	}
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("connecting to the test database: %v", err)
+	}
+	if _, err = conn.ExecContext(ctx, lockDatabase, lockKey); err != nil {
+		t.Fatalf("locking the test database: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = conn.ExecContext(ctx, unlockDatabase, lockKey)
+		_ = conn.Close()
+	})
+	migrator, err := migrations.NewPostgresMigrator(pool)
+	if err != nil {
+		t.Fatalf("creating the migrator of the test database: %v", err)
+	}
+	if _, err = migrator.Up(); err != nil {
+		t.Fatalf("migrating the test database: %v", err)
+	}
+	if _, err = conn.ExecContext(ctx, truncateTables); err != nil {
+		t.Fatalf("emptying the test database: %v", err)
+	}
+	return
+}
@@ -0,0 +1,33 @@
+package processing
+
+import "errors"
+
+var (
+	// ErrEmptyObjectKey is the error returned when the key of the processed object is empty.
+	ErrEmptyObjectKey = errors.New("object key is empty")
+	// ErrNilProcessingRecord is the error returned when the processing record is nil.
+	ErrNilProcessingRecord = errors.New("processing record is nil")
+	// ErrProcessingRecordNotFound is the error returned when the object has no processing record.
+	ErrProcessingRecordNotFound = errors.New("processing record not found")
+	// ErrObjectAlreadyProcessed is the error returned when the object was already processed successfully.
+	ErrObjectAlreadyProcessed = errors.New("object already processed")
+	// ErrQueryingProcessingRecord is the error returned when querying a processing record.
+	ErrQueryingProcessingRecord = errors.New("error querying processing record")
+	// ErrScanningProcessingRecord is the error returned when scanning a processing record.
+	ErrScanningProcessingRecord = errors.New("error scanning processing record")
+	// ErrSavingProcessingRecord is the error returned when saving a processing record.
+	ErrSavingProcessingRecord = errors.New("error saving processing record")
+	// ErrNilProcessingRepository is the error returned when the processing repository is nil.
+	ErrNilProcessingRepository = errors.New("processing repository is nil")
+	// ErrNilProcessingUseCases is the error returned when the processing use cases is nil.
+	ErrNilProcessingUseCases = errors.New("processing use cases is nil")
+	// ErrNilIngestionUseCases is the error returned when the ingestion use cases is nil.
+	ErrNilIngestionUseCases = errors.New("ingestion use cases is nil")
+	// ErrNilQueue is the error returned when a queue is nil.
+	ErrNilQueue = errors.New("queue is nil")
+	// ErrInvalidIngestionMessage is the error returned when a queue message does not reference a file.
+	ErrInvalidIngestionMessage = errors.New("ingestion message is invalid")
+	// ErrIngestionAttemptsExhausted is the error returned when the last attempt to ingest a
+	// file failed. The file is already moved to the failed prefix.
+	ErrIngestionAttemptsExhausted = errors.New("ingestion attempts exhausted")
+)
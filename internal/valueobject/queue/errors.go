@@ -0,0 +1,22 @@
+package queue
+
+import "errors"
+
+var (
+	// ErrNilConfiguration is the error returned when the queue configuration is nil.
+	ErrNilConfiguration = errors.New("queue configuration is nil")
+	// ErrNilSQSClient is the error returned when the SQS client is nil.
+	ErrNilSQSClient = errors.New("sqs client is nil")
+	// ErrEmptyQueueURL is the error returned when the URL of the queue is empty.
+	ErrEmptyQueueURL = errors.New("queue url is empty")
+	// ErrUnknownBackend is the error returned when the queue backend is unknown.
+	ErrUnknownBackend = errors.New("unknown queue backend")
+	// ErrEmptyReceiptHandle is the error returned when deleting a message without receipt handle.
+	ErrEmptyReceiptHandle = errors.New("receipt handle is empty")
+	// ErrSendingMessage is the error returned when a message cannot be sent.
+	ErrSendingMessage = errors.New("error sending message")
+	// ErrReceivingMessages is the error returned when the messages cannot be received.
+	ErrReceivingMessages = errors.New("error receiving messages")
+	// ErrDeletingMessage is the error returned when a message cannot be deleted.
+	ErrDeletingMessage = errors.New("error deleting message")
+)
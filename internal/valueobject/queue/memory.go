@@ -0,0 +1,86 @@
+package queue
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memoryMessage is a message kept by the memory queue.
+type memoryMessage struct {
+	id            string
+	body          []byte
+	receiveCount  int
+	receiptHandle string
+	visibleAt     time.Time
+}
+
+// memoryQueue struct implements the Queue interface in memory. It is safe for concurrent use.
+type memoryQueue struct {
+	mu       sync.Mutex
+	messages []*memoryMessage
+}
+
+// NewMemoryQueue returns an empty Queue kept in memory.
+func NewMemoryQueue() (queue Queue) {
+	queue = &memoryQueue{}
+	return
+}
+
+// Queue interface implementation
+
+// Send implements the Queue interface method.
+func (queue *memoryQueue) Send(body []byte) (id string, err error) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	id = uuid.NewString()
+	queue.messages = append(queue.messages, &memoryMessage{
+		id:   id,
+		body: append([]byte(nil), body...),
+	})
+	return
+}
+
+// Receive implements the Queue interface method.
+func (queue *memoryQueue) Receive(max int, visibility time.Duration) (messages []Message, err error) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	now := time.Now()
+	for _, message := range queue.messages {
+		if len(messages) >= max {
+			break
+		}
+		if message.visibleAt.After(now) {
+			continue
+		}
+		message.receiveCount++
+		message.receiptHandle = uuid.NewString()
+		message.visibleAt = now.Add(visibility)
+		messages = append(messages, Message{
+			ID:            message.id,
+			Body:          append([]byte(nil), message.body...),
+			ReceiptHandle: message.receiptHandle,
+			ReceiveCount:  message.receiveCount,
+		})
+	}
+	return
+}
+
+// Delete implements the Queue interface method. A receipt handle of an older reception
+// does not delete the message.
+func (queue *memoryQueue) Delete(receiptHandle string) (err error) {
+	if receiptHandle == "" {
+		err = ErrEmptyReceiptHandle
+		return
+	}
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	for i, message := range queue.messages {
+		if message.receiptHandle == receiptHandle {
+			queue.messages = append(queue.messages[:i], queue.messages[i+1:]...)
+			return
+		}
+	}
+	return
+}
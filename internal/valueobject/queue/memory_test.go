@@ -0,0 +1,69 @@
+package queue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMemoryQueueSendReceiveDelete tests a deleted message is not delivered again.
+func TestMemoryQueueSendReceiveDelete(t *testing.T) {
+	// Given a memory queue with two messages
+	memoryQueue := queue.NewMemoryQueue()
+	first, err := memoryQueue.Send([]byte("first"))
+	assert.Nil(t, err)
+	_, err = memoryQueue.Send([]byte("second"))
+	assert.Nil(t, err)
+	// When receiving one message
+	messages, err := memoryQueue.Receive(1, time.Minute)
+	// Then the first message is received once
+	assert.Nil(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, first, messages[0].ID)
+	assert.Equal(t, "first", string(messages[0].Body))
+	assert.Equal(t, 1, messages[0].ReceiveCount)
+	// And after deleting it only the second message is left
+	assert.Nil(t, memoryQueue.Delete(messages[0].ReceiptHandle))
+	messages, _ = memoryQueue.Receive(10, time.Minute)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "second", string(messages[0].Body))
+}
+
+// TestMemoryQueueVisibility tests a message not deleted is delivered again after its visibility timeout.
+func TestMemoryQueueVisibility(t *testing.T) {
+	// Given a memory queue with a received message
+	memoryQueue := queue.NewMemoryQueue()
+	_, _ = memoryQueue.Send([]byte("body"))
+	messages, _ := memoryQueue.Receive(10, time.Hour)
+	assert.Len(t, messages, 1)
+	// When receiving while the message is hidden
+	hidden, _ := memoryQueue.Receive(10, time.Hour)
+	// Then nothing is received
+	assert.Empty(t, hidden)
+	// And a message received without visibility timeout is delivered again
+	other := queue.NewMemoryQueue()
+	_, _ = other.Send([]byte("body"))
+	_, _ = other.Receive(10, 0)
+	again, _ := other.Receive(10, 0)
+	assert.Len(t, again, 1)
+	assert.Equal(t, 2, again[0].ReceiveCount)
+}
+
+// TestMemoryQueueDeleteWithOldReceipt tests an old receipt handle does not delete the message.
+func TestMemoryQueueDeleteWithOldReceipt(t *testing.T) {
+	// Given a message received twice
+	memoryQueue := queue.NewMemoryQueue()
+	_, _ = memoryQueue.Send([]byte("body"))
+	first, _ := memoryQueue.Receive(10, 0)
+	_, _ = memoryQueue.Receive(10, 0)
+	// When deleting it with the first receipt handle
+	err := memoryQueue.Delete(first[0].ReceiptHandle)
+	// Then the message is kept
+	assert.Nil(t, err)
+	messages, _ := memoryQueue.Receive(10, 0)
+	assert.Len(t, messages, 1)
+	// And an empty receipt handle is an error
+	assert.Equal(t, queue.ErrEmptyReceiptHandle, memoryQueue.Delete(""))
+}
@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockQueue is a mock of the Queue interface implementation.
+type mockQueue struct {
+	mock.Mock
+}
+
+// NewMockQueue returns a new mock instance.
+func NewMockQueue() *mockQueue {
+	return &mockQueue{}
+}
+
+// Send provides a mock function with given fields: body
+func (_m *mockQueue) Send(body []byte) (id string, err error) {
+	ret := _m.Called(body)
+	return ret.String(0), ret.Error(1)
+}
+
+// Receive provides a mock function with given fields: max, visibility
+func (_m *mockQueue) Receive(max int, visibility time.Duration) (messages []queue.Message, err error) {
+	ret := _m.Called(max, visibility)
+
+	var r0 []queue.Message
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]queue.Message)
+	}
+	return r0, ret.Error(1)
+}
+
+// Delete provides a mock function with given fields: receiptHandle
+func (_m *mockQueue) Delete(receiptHandle string) (err error) {
+	ret := _m.Called(receiptHandle)
+	return ret.Error(0)
+}
@@ -0,0 +1,136 @@
+package queue
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+const (
+	// SQSBackend sends the messages to Amazon SQS queues.
+	SQSBackend = "sqs"
+	// MemoryBackend keeps the messages in memory, for tests and local environments.
+	MemoryBackend = "memory"
+)
+
+// Queue interface defines the methods that a message queue must implement. A received
+// message is hidden from other consumers until its visibility timeout expires, and it is
+// delivered again unless it is deleted.
+type Queue interface {
+	// Send enqueues a message and returns its ID.
+	Send(body []byte) (id string, err error)
+	// Receive returns up to max visible messages and hides them for the visibility timeout.
+	Receive(max int, visibility time.Duration) (messages []Message, err error)
+	// Delete removes a received message from the queue.
+	Delete(receiptHandle string) (err error)
+}
+
+// Message struct defines a received message.
+type Message struct {
+	// ID is the ID of the message.
+	ID string
+	// Body is the content of the message.
+	Body []byte
+	// ReceiptHandle identifies the reception, it is used to delete the message.
+	ReceiptHandle string
+	// ReceiveCount is the number of times the message was received, this one included.
+	ReceiveCount int
+}
+
+// QueueConfiguration struct defines the queues of the ingestion.
+type QueueConfiguration struct {
+	// Backend is the name of the queue backend.
+	Backend string
+	// Region is the AWS region of the SQS queues.
+	Region string
+	// URL is the URL of the ingestion queue.
+	URL string
+	// DeadLetterURL is the URL of the dead-letter queue.
+	DeadLetterURL string
+	// MaxReceives is the number of receptions after which a failing message is moved to
+	// the dead-letter queue.
+	MaxReceives int
+	// Visibility is the time a received message is hidden from other consumers.
+	Visibility time.Duration
+	// BatchSize is the max number of messages received at once.
+	BatchSize int
+	// Interval is the time between two receptions when the queue is empty.
+	Interval time.Duration
+}
+
+// NewDefaultQueueConfiguration returns the configuration used in local environments.
+func NewDefaultQueueConfiguration() (configuration *QueueConfiguration) {
+	configuration = &QueueConfiguration{
+		Backend:     MemoryBackend,
+		MaxReceives: 3,
+		Visibility:  5 * time.Minute,
+		BatchSize:   10,
+		Interval:    5 * time.Second,
+	}
+	return
+}
+
+// NewQueueConfigurationFromEnv returns the configuration from the environment variables.
+// When QUEUE_BACKEND is not set, the SQS backend is used if INGESTION_QUEUE_URL is set.
+func NewQueueConfigurationFromEnv() (configuration *QueueConfiguration) {
+	configuration = NewDefaultQueueConfiguration()
+	configuration.Region = os.Getenv("AWS_REGION")
+	configuration.URL = os.Getenv("INGESTION_QUEUE_URL")
+	configuration.DeadLetterURL = os.Getenv("INGESTION_DLQ_URL")
+	if configuration.URL != "" {
+		configuration.Backend = SQSBackend
+	}
+	if backend := os.Getenv("QUEUE_BACKEND"); backend != "" {
+		configuration.Backend = backend
+	}
+	if maxReceives, err := strconv.Atoi(os.Getenv("INGESTION_MAX_RECEIVES")); err == nil && maxReceives > 0 {
+		configuration.MaxReceives = maxReceives
+	}
+	if visibility, err := time.ParseDuration(os.Getenv("INGESTION_VISIBILITY")); err == nil {
+		configuration.Visibility = visibility
+	}
+	if batchSize, err := strconv.Atoi(os.Getenv("INGESTION_BATCH_SIZE")); err == nil && batchSize > 0 {
+		configuration.BatchSize = batchSize
+	}
+	if interval, err := time.ParseDuration(os.Getenv("INGESTION_INTERVAL")); err == nil {
+		configuration.Interval = interval
+	}
+	return
+}
+
+// NewQueues returns the ingestion and the dead-letter queues of the configured backend. The
+// options configure the SQS backend.
+func NewQueues(configuration *QueueConfiguration, options ...SQSQueueOption) (ingestion Queue, deadLetter Queue, err error) {
+	if configuration == nil {
+		err = ErrNilConfiguration
+		return
+	}
+	switch configuration.Backend {
+	case SQSBackend:
+		sess, errSession := session.NewSession(&aws.Config{
+			Region: aws.String(configuration.Region),
+		})
+		if errSession != nil {
+			err = errSession
+			return
+		}
+		client := sqs.New(sess)
+		if ingestion, err = NewSQSQueue(client, configuration.URL, options...); err != nil {
+			return
+		}
+		deadLetter, err = NewSQSQueue(client, configuration.DeadLetterURL, options...)
+		if err != nil {
+			ingestion = nil
+		}
+	case MemoryBackend:
+		ingestion = NewMemoryQueue()
+		deadLetter = NewMemoryQueue()
+	default:
+		err = ErrUnknownBackend
+	}
+	return
+}
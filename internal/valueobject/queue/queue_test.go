@@ -0,0 +1,47 @@
+package queue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewQueueConfigurationFromEnvWithURL tests the SQS backend is used when the queue URL is set.
+func TestNewQueueConfigurationFromEnvWithURL(t *testing.T) {
+	// Given the ingestion queue environment variables
+	t.Setenv("INGESTION_QUEUE_URL", "https://sqs/ingestion")
+	t.Setenv("INGESTION_DLQ_URL", "https://sqs/ingestion-dlq")
+	t.Setenv("INGESTION_MAX_RECEIVES", "5")
+	t.Setenv("INGESTION_VISIBILITY", "90s")
+	// When reading the configuration
+	configuration := queue.NewQueueConfigurationFromEnv()
+	// Then the SQS backend is configured
+	assert.Equal(t, queue.SQSBackend, configuration.Backend)
+	assert.Equal(t, "https://sqs/ingestion-dlq", configuration.DeadLetterURL)
+	assert.Equal(t, 5, configuration.MaxReceives)
+	assert.Equal(t, 90*time.Second, configuration.Visibility)
+}
+
+// TestNewQueues tests the queues of every backend.
+func TestNewQueues(t *testing.T) {
+	// Given the default configuration
+	configuration := queue.NewDefaultQueueConfiguration()
+	// When creating the memory queues
+	ingestion, deadLetter, err := queue.NewQueues(configuration)
+	// Then two different queues are returned
+	assert.Nil(t, err)
+	assert.NotNil(t, ingestion)
+	assert.NotSame(t, ingestion, deadLetter)
+	// And an SQS backend without dead-letter URL is an error
+	configuration.Backend = queue.SQSBackend
+	configuration.Region = "us-east-2"
+	configuration.URL = "https://sqs/ingestion"
+	_, _, err = queue.NewQueues(configuration)
+	assert.Equal(t, queue.ErrEmptyQueueURL, err)
+	// And an unknown backend is an error
+	configuration.Backend = "carrier-pigeon"
+	_, _, err = queue.NewQueues(configuration)
+	assert.Equal(t, queue.ErrUnknownBackend, err)
+}
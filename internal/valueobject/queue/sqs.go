@@ -0,0 +1,123 @@
+package queue
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+)
+
+// maxSQSBatch is the max number of messages SQS returns at once.
+const maxSQSBatch = 10
+
+// sqsQueue struct implements the Queue interface with an Amazon SQS queue.
+type sqsQueue struct {
+	client sqsiface.SQSAPI
+	url    string
+	// logger logs the errors of SQS.
+	logger logger.Logger
+}
+
+// SQSQueueOption configures an optional collaborator of the SQS queue.
+type SQSQueueOption func(queue *sqsQueue) (err error)
+
+// WithSQSLogger logs the errors of SQS with the logger instead of the default one.
+func WithSQSLogger(log logger.Logger) SQSQueueOption {
+	return func(queue *sqsQueue) (err error) {
+		if log == nil {
+			err = logger.ErrNilLogger
+			return
+		}
+		queue.logger = log
+		return
+	}
+}
+
+// NewSQSQueue returns a Queue backed by the SQS queue.
+func NewSQSQueue(client sqsiface.SQSAPI, url string, options ...SQSQueueOption) (queue Queue, err error) {
+	if client == nil {
+		err = ErrNilSQSClient
+		return
+	}
+	if url == "" {
+		err = ErrEmptyQueueURL
+		return
+	}
+	newQueue := &sqsQueue{
+		client: client,
+		url:    url,
+		logger: logger.NewDefaultLogger(),
+	}
+	for _, option := range options {
+		if err = option(newQueue); err != nil {
+			return
+		}
+	}
+	queue = newQueue
+	return
+}
+
+// Queue interface implementation
+
+// Send implements the Queue interface method.
+func (queue *sqsQueue) Send(body []byte) (id string, err error) {
+	output, err := queue.client.SendMessage(&sqs.SendMessageInput{
+		QueueUrl:    aws.String(queue.url),
+		MessageBody: aws.String(string(body)),
+	})
+	if err != nil {
+		queue.logger.Error("error sending message to SQS", "queue_url", queue.url, "error", err)
+		err = ErrSendingMessage
+		return
+	}
+	id = aws.StringValue(output.MessageId)
+	return
+}
+
+// Receive implements the Queue interface method.
+func (queue *sqsQueue) Receive(max int, visibility time.Duration) (messages []Message, err error) {
+	if max > maxSQSBatch {
+		max = maxSQSBatch
+	}
+	output, err := queue.client.ReceiveMessage(&sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(queue.url),
+		MaxNumberOfMessages: aws.Int64(int64(max)),
+		VisibilityTimeout:   aws.Int64(int64(visibility / time.Second)),
+		AttributeNames:      []*string{aws.String(sqs.MessageSystemAttributeNameApproximateReceiveCount)},
+	})
+	if err != nil {
+		queue.logger.Error("error receiving messages from SQS", "queue_url", queue.url, "error", err)
+		err = ErrReceivingMessages
+		return
+	}
+	for _, received := range output.Messages {
+		receiveCount, _ := strconv.Atoi(aws.StringValue(received.Attributes[sqs.MessageSystemAttributeNameApproximateReceiveCount]))
+		messages = append(messages, Message{
+			ID:            aws.StringValue(received.MessageId),
+			Body:          []byte(aws.StringValue(received.Body)),
+			ReceiptHandle: aws.StringValue(received.ReceiptHandle),
+			ReceiveCount:  receiveCount,
+		})
+	}
+	return
+}
+
+// Delete implements the Queue interface method.
+func (queue *sqsQueue) Delete(receiptHandle string) (err error) {
+	if receiptHandle == "" {
+		err = ErrEmptyReceiptHandle
+		return
+	}
+	_, err = queue.client.DeleteMessage(&sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queue.url),
+		ReceiptHandle: aws.String(receiptHandle),
+	})
+	if err != nil {
+		queue.logger.Error("error deleting message from SQS", "queue_url", queue.url, "error", err)
+		err = ErrDeletingMessage
+	}
+	return
+}
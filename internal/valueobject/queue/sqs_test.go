@@ -0,0 +1,108 @@
+package queue_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/queue"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSQS records the requests and returns canned responses.
+type fakeSQS struct {
+	sqsiface.SQSAPI
+	sent     *sqs.SendMessageInput
+	received *sqs.ReceiveMessageInput
+	deleted  *sqs.DeleteMessageInput
+	err      error
+}
+
+func (fake *fakeSQS) SendMessage(input *sqs.SendMessageInput) (*sqs.SendMessageOutput, error) {
+	fake.sent = input
+	return &sqs.SendMessageOutput{MessageId: aws.String("id")}, fake.err
+}
+
+func (fake *fakeSQS) ReceiveMessage(input *sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error) {
+	fake.received = input
+	return &sqs.ReceiveMessageOutput{Messages: []*sqs.Message{{
+		MessageId:     aws.String("id"),
+		Body:          aws.String("body"),
+		ReceiptHandle: aws.String("receipt"),
+		Attributes:    map[string]*string{sqs.MessageSystemAttributeNameApproximateReceiveCount: aws.String("2")},
+	}}}, fake.err
+}
+
+func (fake *fakeSQS) DeleteMessage(input *sqs.DeleteMessageInput) (*sqs.DeleteMessageOutput, error) {
+	fake.deleted = input
+	return &sqs.DeleteMessageOutput{}, fake.err
+}
+
+// TestNewSQSQueueErrors tests the NewSQSQueue function with invalid parameters.
+func TestNewSQSQueueErrors(t *testing.T) {
+	// When calling NewSQSQueue without client or URL
+	_, errClient := queue.NewSQSQueue(nil, "url")
+	_, errURL := queue.NewSQSQueue(&fakeSQS{}, "")
+	// Then the errors are returned
+	assert.Equal(t, queue.ErrNilSQSClient, errClient)
+	assert.Equal(t, queue.ErrEmptyQueueURL, errURL)
+	// And a nil logger returns ErrNilLogger
+	_, errLogger := queue.NewSQSQueue(&fakeSQS{}, "url", queue.WithSQSLogger(nil))
+	assert.Equal(t, logger.ErrNilLogger, errLogger)
+}
+
+// TestSQSQueue tests the requests sent to SQS.
+func TestSQSQueue(t *testing.T) {
+	// Given an SQS queue
+	client := &fakeSQS{}
+	sqsQueue, err := queue.NewSQSQueue(client, "https://sqs/ingestion")
+	assert.Nil(t, err)
+	// When sending a message
+	id, err := sqsQueue.Send([]byte("body"))
+	// Then it is sent to the queue URL
+	assert.Nil(t, err)
+	assert.Equal(t, "id", id)
+	assert.Equal(t, "https://sqs/ingestion", *client.sent.QueueUrl)
+	assert.Equal(t, "body", *client.sent.MessageBody)
+	// When receiving more messages than the SQS limit
+	messages, err := sqsQueue.Receive(50, 30*time.Second)
+	// Then at most ten are requested with the receive count
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), *client.received.MaxNumberOfMessages)
+	assert.Equal(t, int64(30), *client.received.VisibilityTimeout)
+	assert.Equal(t, []queue.Message{{ID: "id", Body: []byte("body"), ReceiptHandle: "receipt", ReceiveCount: 2}}, messages)
+	// When deleting the message
+	err = sqsQueue.Delete("receipt")
+	// Then it is deleted by its receipt handle
+	assert.Nil(t, err)
+	assert.Equal(t, "receipt", *client.deleted.ReceiptHandle)
+}
+
+// TestSQSQueueErrors tests the errors returned when SQS fails.
+func TestSQSQueueErrors(t *testing.T) {
+	// Given an SQS queue failing every request and logging to a buffer
+	output := &bytes.Buffer{}
+	sqsQueue, _ := queue.NewSQSQueue(&fakeSQS{err: errors.New("throttled")}, "https://sqs/ingestion",
+		queue.WithSQSLogger(logger.NewJSONLogger(output, logger.LevelInfo)))
+	// When calling every method
+	_, errSend := sqsQueue.Send([]byte("body"))
+	_, errReceive := sqsQueue.Receive(1, time.Second)
+	errDelete := sqsQueue.Delete("receipt")
+	// Then the queue errors are returned
+	assert.Equal(t, queue.ErrSendingMessage, errSend)
+	assert.Equal(t, queue.ErrReceivingMessages, errReceive)
+	assert.Equal(t, queue.ErrDeletingMessage, errDelete)
+	assert.Equal(t, queue.ErrEmptyReceiptHandle, sqsQueue.Delete(""))
+	// And the first failure is logged with the queue URL
+	line := map[string]interface{}{}
+	assert.Nil(t, json.NewDecoder(output).Decode(&line))
+	assert.Equal(t, "error sending message to SQS", line["msg"])
+	assert.Equal(t, "https://sqs/ingestion", line["queue_url"])
+	assert.Equal(t, "throttled", line["error"])
+}
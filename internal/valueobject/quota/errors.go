@@ -0,0 +1,20 @@
+package quota
+
+import "errors"
+
+var (
+	// ErrNilQuotaConfiguration is the error returned when the quota configuration is nil.
+	ErrNilQuotaConfiguration = errors.New("quota configuration is nil")
+	// ErrNilUsageCounter is the error returned when the usage counter is nil.
+	ErrNilUsageCounter = errors.New("usage counter is nil")
+	// ErrNilLimiter is the error returned when the rate limiter is nil.
+	ErrNilLimiter = errors.New("rate limiter is nil")
+	// ErrInvalidRateLimit is the error returned when the rate or the burst of a limiter is not positive.
+	ErrInvalidRateLimit = errors.New("rate limit is not valid")
+	// ErrFileTooLarge is the error returned when a file exceeds the max file size.
+	ErrFileTooLarge = errors.New("file exceeds the max file size")
+	// ErrTooManyRows is the error returned when a file exceeds the max rows per file.
+	ErrTooManyRows = errors.New("file exceeds the max rows per file")
+	// ErrDailyQuotaExceeded is the error returned when an uploader reached the max files per day.
+	ErrDailyQuotaExceeded = errors.New("daily file quota exceeded")
+)
@@ -0,0 +1,107 @@
+package quota
+
+import (
+	"container/list"
+	"math"
+	"sync"
+	"time"
+)
+
+// maxBuckets is the max number of buckets. The bucket of the key seen least recently is
+// removed to make room for a new key, so the clients that stopped sending requests do not
+// keep their bucket.
+const maxBuckets = 10000
+
+// Decision struct defines the result of a request to a limiter.
+type Decision struct {
+	// Allowed is true when the request is within the rate limit.
+	Allowed bool
+	// Limit is the max number of requests in a burst.
+	Limit int
+	// Remaining is the number of requests allowed right now.
+	Remaining int
+	// RetryAfter is the wait until the next request is allowed, when it is not.
+	RetryAfter time.Duration
+}
+
+// Limiter interface defines a rate limit of the requests by key.
+type Limiter interface {
+	// Allow takes a token of the bucket of the key and returns whether the request is allowed.
+	Allow(key string, now time.Time) (decision Decision)
+}
+
+// bucket struct defines the tokens of a key.
+type bucket struct {
+	key     string
+	tokens  float64
+	updated time.Time
+}
+
+// tokenBucketLimiter struct implements the Limiter interface with a token bucket per key.
+type tokenBucketLimiter struct {
+	mutex   sync.Mutex
+	rate    float64
+	burst   int
+	buckets map[string]*list.Element
+	// recent orders the buckets by their last request, the most recent first.
+	recent *list.List
+}
+
+// NewTokenBucketLimiter returns a limiter allowing bursts of burst requests per key, with
+// the tokens refilled at rate per second.
+func NewTokenBucketLimiter(rate float64, burst int) (limiter Limiter, err error) {
+	if rate <= 0 || burst < 1 {
+		err = ErrInvalidRateLimit
+		return
+	}
+	limiter = &tokenBucketLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: map[string]*list.Element{},
+		recent:  list.New(),
+	}
+	return
+}
+
+// Limiter interface implementation
+
+func (limiter *tokenBucketLimiter) Allow(key string, now time.Time) (decision Decision) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	element := limiter.buckets[key]
+	if element == nil {
+		if limiter.recent.Len() >= maxBuckets {
+			limiter.removeOldest()
+		}
+		element = limiter.recent.PushFront(&bucket{key: key, tokens: float64(limiter.burst), updated: now})
+		limiter.buckets[key] = element
+	} else {
+		limiter.recent.MoveToFront(element)
+	}
+	current := element.Value.(*bucket)
+	limiter.refill(current, now)
+	decision.Limit = limiter.burst
+	if current.tokens >= 1 {
+		current.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = time.Duration((1 - current.tokens) / limiter.rate * float64(time.Second))
+	}
+	decision.Remaining = int(current.tokens)
+	return
+}
+
+// refill adds the tokens of the time elapsed since the last update of the bucket.
+func (limiter *tokenBucketLimiter) refill(current *bucket, now time.Time) {
+	if elapsed := now.Sub(current.updated); elapsed > 0 {
+		current.tokens = math.Min(float64(limiter.burst), current.tokens+elapsed.Seconds()*limiter.rate)
+		current.updated = now
+	}
+}
+
+// removeOldest removes the bucket of the key seen least recently, it is created again full.
+func (limiter *tokenBucketLimiter) removeOldest() {
+	oldest := limiter.recent.Back()
+	limiter.recent.Remove(oldest)
+	delete(limiter.buckets, oldest.Value.(*bucket).key)
+}
@@ -0,0 +1,68 @@
+package quota_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewTokenBucketLimiterInvalid tests the error returned with a rate or a burst that is not positive.
+func TestNewTokenBucketLimiterInvalid(t *testing.T) {
+	for _, limit := range []struct {
+		rate  float64
+		burst int
+	}{{0, 10}, {-1, 10}, {5, 0}} {
+		// When creating a limiter with the rate limit
+		_, err := quota.NewTokenBucketLimiter(limit.rate, limit.burst)
+		// Then the error returned is ErrInvalidRateLimit
+		assert.Equal(t, quota.ErrInvalidRateLimit, err)
+	}
+}
+
+// TestTokenBucketLimiter tests the requests beyond the burst are limited until the tokens are refilled.
+func TestTokenBucketLimiter(t *testing.T) {
+	// Given a limiter of bursts of 2 requests refilled at 1 request per second
+	limiter, err := quota.NewTokenBucketLimiter(1, 2)
+	assert.Nil(t, err)
+	now := time.Now()
+	// When a client sends 3 requests at once
+	first := limiter.Allow("client", now)
+	second := limiter.Allow("client", now)
+	third := limiter.Allow("client", now)
+	// Then the burst is allowed
+	assert.Equal(t, quota.Decision{Allowed: true, Limit: 2, Remaining: 1}, first)
+	assert.Equal(t, quota.Decision{Allowed: true, Limit: 2, Remaining: 0}, second)
+	// And the third request waits for a token
+	assert.False(t, third.Allowed)
+	assert.Equal(t, time.Second, third.RetryAfter)
+	// And other clients are not limited
+	assert.True(t, limiter.Allow("other", now).Allowed)
+	// When the client waits for a token
+	decision := limiter.Allow("client", now.Add(time.Second))
+	// Then the request is allowed
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+}
+
+// TestTokenBucketLimiterRemovesOldest tests the bucket of the key seen least recently is
+// removed when there are too many keys.
+func TestTokenBucketLimiterRemovesOldest(t *testing.T) {
+	// Given a limiter of bursts of 1 request
+	limiter, err := quota.NewTokenBucketLimiter(1, 1)
+	assert.Nil(t, err)
+	now := time.Now()
+	// And 10000 clients that spent their token, the first one seen again last
+	for i := 0; i < 10000; i++ {
+		assert.True(t, limiter.Allow(fmt.Sprintf("client-%d", i), now).Allowed)
+	}
+	assert.False(t, limiter.Allow("client-0", now).Allowed)
+	// When a new client sends a request
+	assert.True(t, limiter.Allow("new", now).Allowed)
+	// Then the client seen least recently gets a new bucket
+	assert.True(t, limiter.Allow("client-1", now).Allowed)
+	// And the client seen again keeps its limit
+	assert.False(t, limiter.Allow("client-0", now).Allowed)
+}
@@ -0,0 +1,62 @@
+package quota
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/apierror/envelope"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+)
+
+const (
+	// RateLimitHeader is the header with the max number of requests in a burst.
+	RateLimitHeader = "X-RateLimit-Limit"
+	// RateRemainingHeader is the header with the number of requests allowed right now.
+	RateRemainingHeader = "X-RateLimit-Remaining"
+)
+
+// RateLimitMiddleware returns a middleware limiting the requests of every client, or of
+// every IP address when the request is not authenticated. The requests beyond the limit
+// get 429 with Retry-After. Before the authentication middleware it limits every IP address,
+// after it every client.
+func RateLimitMiddleware(limiter Limiter, log logger.Logger) (middleware func(next http.Handler) http.Handler, err error) {
+	if limiter == nil {
+		err = ErrNilLimiter
+		return
+	}
+	if log == nil {
+		err = logger.ErrNilLogger
+		return
+	}
+	middleware = func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			key := RateLimitKey(request)
+			decision := limiter.Allow(key, time.Now())
+			writer.Header().Set(RateLimitHeader, strconv.Itoa(decision.Limit))
+			writer.Header().Set(RateRemainingHeader, strconv.Itoa(decision.Remaining))
+			if !decision.Allowed {
+				logger.FromContext(request.Context(), log).Warn("request rate limited", "key", key)
+				writer.Header().Set(RetryAfterHeader, RetryAfter(decision.RetryAfter))
+				envelope.Write(writer, request, http.StatusTooManyRequests, envelope.CodeRateLimited, "rate limit exceeded", nil)
+				return
+			}
+			next.ServeHTTP(writer, request)
+		})
+	}
+	return
+}
+
+// RateLimitKey returns the key of the rate limit of the request, its client or its IP address.
+func RateLimitKey(request *http.Request) string {
+	if clientID := auth.ClientID(request.Context()); clientID != "" {
+		return "client:" + clientID
+	}
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		host = request.RemoteAddr
+	}
+	return "ip:" + host
+}
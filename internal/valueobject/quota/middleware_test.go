@@ -0,0 +1,62 @@
+package quota_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/auth"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRateLimitMiddleware tests the requests of a client beyond the rate limit get 429.
+func TestRateLimitMiddleware(t *testing.T) {
+	// Given a handler limited to a burst of 1 request
+	limiter, err := quota.NewTokenBucketLimiter(1, 1)
+	assert.Nil(t, err)
+	middleware, err := quota.RateLimitMiddleware(limiter, logger.NewJSONLogger(&bytes.Buffer{}, logger.LevelInfo))
+	assert.Nil(t, err)
+	handler := middleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	send := func(clientID string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/accounts/1", nil)
+		request = request.WithContext(auth.WithPrincipal(request.Context(), &auth.Principal{ID: clientID}))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+	// When a client sends 2 requests at once
+	first, second := send("client"), send("client")
+	// Then the first request is handled with the rate limit headers
+	assert.Equal(t, http.StatusNoContent, first.Code)
+	assert.Equal(t, "1", first.Header().Get(quota.RateLimitHeader))
+	assert.Equal(t, "0", first.Header().Get(quota.RateRemainingHeader))
+	// And the second request is limited
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+	assert.Equal(t, "1", second.Header().Get(quota.RetryAfterHeader))
+	// And other clients are not limited
+	assert.Equal(t, http.StatusNoContent, send("other").Code)
+}
+
+// TestRateLimitMiddlewareWithNilLimiter tests the error returned without limiter.
+func TestRateLimitMiddlewareWithNilLimiter(t *testing.T) {
+	_, err := quota.RateLimitMiddleware(nil, logger.NewDefaultLogger())
+	assert.Equal(t, quota.ErrNilLimiter, err)
+}
+
+// TestRateLimitKey tests the requests are limited by client, or by IP address without client.
+func TestRateLimitKey(t *testing.T) {
+	// Given an anonymous request
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.RemoteAddr = "10.0.0.1:52000"
+	// Then it is limited by its IP address
+	assert.Equal(t, "ip:10.0.0.1", quota.RateLimitKey(request))
+	// Given an authenticated request
+	request = request.WithContext(auth.WithPrincipal(request.Context(), &auth.Principal{ID: "0123456789abcdef"}))
+	// Then it is limited by its client
+	assert.Equal(t, "client:0123456789abcdef", quota.RateLimitKey(request))
+}
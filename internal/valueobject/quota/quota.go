@@ -0,0 +1,174 @@
+// Package quota limits the uploads of the clients: the rate of their requests, the size
+// and the rows of their files and the number of files they upload per day.
+package quota
+
+import (
+	"context"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// LimitHeader is the header with the max files per day of the uploader.
+	LimitHeader = "X-Quota-Limit"
+	// RemainingHeader is the header with the files the uploader can still upload today.
+	RemainingHeader = "X-Quota-Remaining"
+	// ResetHeader is the header with the Unix time when the daily quota is reset.
+	ResetHeader = "X-Quota-Reset"
+	// RetryAfterHeader is the header with the seconds to wait before retrying.
+	RetryAfterHeader = "Retry-After"
+)
+
+// QuotaConfiguration struct defines the limits of the clients. A zero limit disables it.
+type QuotaConfiguration struct {
+	// MaxFileSize is the max size of a file, in bytes.
+	MaxFileSize int64
+	// MaxRowsPerFile is the max number of lines of a file after its header.
+	MaxRowsPerFile int
+	// MaxFilesPerDay is the max number of files an uploader uploads per UTC day.
+	MaxFilesPerDay int
+	// RequestsPerSecond is the rate the tokens of the rate limit are refilled at.
+	RequestsPerSecond float64
+	// Burst is the max number of requests of a client in a burst.
+	Burst int
+	// IPRequestsPerSecond is the rate the tokens of the rate limit of an IP address are
+	// refilled at. It is checked before the authentication, so it limits the credential guessing.
+	IPRequestsPerSecond float64
+	// IPBurst is the max number of requests of an IP address in a burst.
+	IPBurst int
+}
+
+// NewDefaultQuotaConfiguration returns the configuration used when nothing is set. The
+// max file size is the payload limit of API Gateway.
+func NewDefaultQuotaConfiguration() (configuration *QuotaConfiguration) {
+	configuration = &QuotaConfiguration{
+		MaxFileSize:         10 << 20,
+		MaxRowsPerFile:      100000,
+		MaxFilesPerDay:      100,
+		RequestsPerSecond:   5,
+		Burst:               10,
+		IPRequestsPerSecond: 20,
+		IPBurst:             40,
+	}
+	return
+}
+
+// NewQuotaConfigurationFromEnv returns the configuration from the environment variables.
+func NewQuotaConfigurationFromEnv() (configuration *QuotaConfiguration) {
+	configuration = NewDefaultQuotaConfiguration()
+	if size, err := strconv.ParseInt(os.Getenv("QUOTA_MAX_FILE_SIZE"), 10, 64); err == nil {
+		configuration.MaxFileSize = size
+	}
+	if rows, err := strconv.Atoi(os.Getenv("QUOTA_MAX_ROWS_PER_FILE")); err == nil {
+		configuration.MaxRowsPerFile = rows
+	}
+	if files, err := strconv.Atoi(os.Getenv("QUOTA_MAX_FILES_PER_DAY")); err == nil {
+		configuration.MaxFilesPerDay = files
+	}
+	if rate, err := strconv.ParseFloat(os.Getenv("RATE_LIMIT_RPS"), 64); err == nil {
+		configuration.RequestsPerSecond = rate
+	}
+	if burst, err := strconv.Atoi(os.Getenv("RATE_LIMIT_BURST")); err == nil {
+		configuration.Burst = burst
+	}
+	if rate, err := strconv.ParseFloat(os.Getenv("RATE_LIMIT_IP_RPS"), 64); err == nil {
+		configuration.IPRequestsPerSecond = rate
+	}
+	if burst, err := strconv.Atoi(os.Getenv("RATE_LIMIT_IP_BURST")); err == nil {
+		configuration.IPBurst = burst
+	}
+	return
+}
+
+// UsageCounter interface defines the count of the files of the uploaders.
+type UsageCounter interface {
+	// CountByUploaderSince returns the number of files of the uploader since the time,
+	// without the failed ones.
+	CountByUploaderSince(ctx context.Context, uploader string, since time.Time) (count int, err error)
+}
+
+// Usage struct defines the files an uploader uploaded today.
+type Usage struct {
+	// Limit is the max files per day, zero when there is no limit.
+	Limit int
+	// Used is the number of files uploaded today.
+	Used int
+	// ResetAt is the date and time when the quota is reset, the next UTC midnight.
+	ResetAt time.Time
+}
+
+// Remaining returns the files the uploader can still upload today.
+func (usage Usage) Remaining() int {
+	if usage.Used >= usage.Limit {
+		return 0
+	}
+	return usage.Limit - usage.Used
+}
+
+// Headers returns the quota headers of the usage, with Retry-After when the quota is
+// exhausted. There are no headers without limit.
+func (usage Usage) Headers(now time.Time) (headers map[string]string) {
+	if usage.Limit <= 0 {
+		return
+	}
+	headers = map[string]string{
+		LimitHeader:     strconv.Itoa(usage.Limit),
+		RemainingHeader: strconv.Itoa(usage.Remaining()),
+		ResetHeader:     strconv.FormatInt(usage.ResetAt.Unix(), 10),
+	}
+	if usage.Remaining() == 0 {
+		headers[RetryAfterHeader] = RetryAfter(usage.ResetAt.Sub(now))
+	}
+	return
+}
+
+// DayStart returns the UTC midnight of the day of the time.
+func DayStart(now time.Time) time.Time {
+	return now.UTC().Truncate(24 * time.Hour)
+}
+
+// RetryAfter returns the value of the Retry-After header of the wait, in whole seconds
+// rounded up and at least 1.
+func RetryAfter(wait time.Duration) string {
+	seconds := int64((wait + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.FormatInt(seconds, 10)
+}
+
+// SizeLimitedReader reads up to a max number of bytes. Reading beyond it fails with
+// ErrFileTooLarge.
+type SizeLimitedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+// NewSizeLimitedReader returns a reader of up to max bytes of the reader.
+func NewSizeLimitedReader(reader io.Reader, max int64) *SizeLimitedReader {
+	return &SizeLimitedReader{reader: reader, remaining: max}
+}
+
+// Read implements the io.Reader interface.
+func (limited *SizeLimitedReader) Read(p []byte) (n int, err error) {
+	if limited.Exceeded() {
+		return 0, ErrFileTooLarge
+	}
+	// One byte more than the limit tells a file of the max size from a larger one.
+	if int64(len(p)) > limited.remaining+1 {
+		p = p[:limited.remaining+1]
+	}
+	n, err = limited.reader.Read(p)
+	limited.remaining -= int64(n)
+	if limited.Exceeded() {
+		n, err = n+int(limited.remaining), ErrFileTooLarge
+	}
+	return
+}
+
+// Exceeded returns whether the reader found more bytes than the max.
+func (limited *SizeLimitedReader) Exceeded() bool {
+	return limited.remaining < 0
+}
@@ -0,0 +1,75 @@
+package quota_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/quota"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewQuotaConfigurationFromEnv tests the limits are read from the environment variables.
+func TestNewQuotaConfigurationFromEnv(t *testing.T) {
+	// Given the environment variables of the limits
+	t.Setenv("QUOTA_MAX_FILE_SIZE", "1024")
+	t.Setenv("QUOTA_MAX_ROWS_PER_FILE", "10")
+	t.Setenv("QUOTA_MAX_FILES_PER_DAY", "0")
+	t.Setenv("RATE_LIMIT_RPS", "0.5")
+	t.Setenv("RATE_LIMIT_BURST", "")
+	t.Setenv("RATE_LIMIT_IP_RPS", "2")
+	t.Setenv("RATE_LIMIT_IP_BURST", "4")
+	// When reading the configuration
+	configuration := quota.NewQuotaConfigurationFromEnv()
+	// Then the variables set override the defaults
+	assert.Equal(t, &quota.QuotaConfiguration{MaxFileSize: 1024, MaxRowsPerFile: 10, MaxFilesPerDay: 0,
+		RequestsPerSecond: 0.5, Burst: quota.NewDefaultQuotaConfiguration().Burst, IPRequestsPerSecond: 2, IPBurst: 4}, configuration)
+}
+
+// TestUsageHeaders tests the quota headers of an uploader.
+func TestUsageHeaders(t *testing.T) {
+	now := time.Date(2023, 5, 12, 23, 59, 30, 0, time.UTC)
+	resetAt := quota.DayStart(now).Add(24 * time.Hour)
+	// When the uploader has files left
+	headers := quota.Usage{Limit: 3, Used: 1, ResetAt: resetAt}.Headers(now)
+	// Then the headers have the remaining files and the reset time
+	assert.Equal(t, map[string]string{
+		quota.LimitHeader:     "3",
+		quota.RemainingHeader: "2",
+		quota.ResetHeader:     "1683936000",
+	}, headers)
+	// When the uploader has no files left
+	headers = quota.Usage{Limit: 3, Used: 4, ResetAt: resetAt}.Headers(now)
+	// Then the headers have the wait until the reset
+	assert.Equal(t, "0", headers[quota.RemainingHeader])
+	assert.Equal(t, "30", headers[quota.RetryAfterHeader])
+	// When there is no limit
+	// Then there are no headers
+	assert.Nil(t, quota.Usage{}.Headers(now))
+}
+
+// TestRetryAfter tests the waits are rounded up to whole seconds.
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, "1", quota.RetryAfter(0))
+	assert.Equal(t, "1", quota.RetryAfter(200*time.Millisecond))
+	assert.Equal(t, "2", quota.RetryAfter(1500*time.Millisecond))
+}
+
+// TestSizeLimitedReader tests the reader fails beyond the max size only.
+func TestSizeLimitedReader(t *testing.T) {
+	// When reading a content of the max size
+	reader := quota.NewSizeLimitedReader(strings.NewReader("12345"), 5)
+	content, err := io.ReadAll(reader)
+	// Then it is read
+	assert.Nil(t, err)
+	assert.Equal(t, "12345", string(content))
+	assert.False(t, reader.Exceeded())
+	// When reading a larger content
+	reader = quota.NewSizeLimitedReader(strings.NewReader("123456"), 5)
+	content, err = io.ReadAll(reader)
+	// Then the error returned is ErrFileTooLarge after the max size
+	assert.Equal(t, quota.ErrFileTooLarge, err)
+	assert.Equal(t, "12345", string(content))
+	assert.True(t, reader.Exceeded())
+}
@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// constraintPrefix precedes the columns of the unique constraint in the message of the
+// driver, such as "UNIQUE constraint failed: users.email".
+const constraintPrefix = "constraint failed: "
+
+func init() {
+	database.RegisterConflictClassifier(classifyConflict)
+}
+
+// classifyConflict returns the conflict of the unique, primary key and foreign key
+// violations of SQLite. SQLite does not name the constraints, the columns of the unique
+// ones are returned instead.
+func classifyConflict(cause error) (conflict error, constraint string, ok bool) {
+	var sqliteErr *sqlite.Error
+	if !errors.As(cause, &sqliteErr) {
+		return
+	}
+	switch sqliteErr.Code() {
+	case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+		conflict = database.ErrUniqueViolation
+		message := sqliteErr.Error()
+		if index := strings.LastIndex(message, constraintPrefix); index >= 0 {
+			constraint, _, _ = strings.Cut(message[index+len(constraintPrefix):], " (")
+		}
+	case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+		conflict = database.ErrForeignKeyViolation
+	default:
+		return
+	}
+	ok = true
+	return
+}
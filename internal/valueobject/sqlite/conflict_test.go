@@ -0,0 +1,58 @@
+package sqlite_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+// errWriting is the sentinel of the statements of the test.
+var errWriting = errors.New("error writing")
+
+// TestConflictClassifier tests the constraint violations of SQLite are wrapped as conflicts.
+func TestConflictClassifier(t *testing.T) {
+	// Given a memory database with a user referenced by an account
+	pool, err := sqlite.NewSQLitePool(sqlite.NewSQLiteConfiguration(sqlite.MemoryPath))
+	assert.Nil(t, err)
+	defer pool.Shutdown()
+	db, _ := pool.Open()
+	tx, err := pool.BeginTx(db)
+	assert.Nil(t, err)
+	defer pool.Rollback(tx)
+	for _, statement := range []string{
+		"CREATE TABLE users (id BIGINT PRIMARY KEY, email TEXT UNIQUE)",
+		"CREATE TABLE accounts (id BIGINT PRIMARY KEY, userid BIGINT REFERENCES users (id))",
+		"INSERT INTO users (id, email) VALUES (1, 'juana@amazingemail.com')",
+	} {
+		_, err = pool.Exec(tx, statement)
+		assert.Nil(t, err)
+	}
+	cases := []struct {
+		name       string
+		statement  string
+		conflict   error
+		constraint string
+	}{
+		{name: "primary key", statement: "INSERT INTO users (id, email) VALUES (1, 'pedro@amazingemail.com')", conflict: database.ErrUniqueViolation, constraint: "users.id"},
+		{name: "unique", statement: "INSERT INTO users (id, email) VALUES (2, 'juana@amazingemail.com')", conflict: database.ErrUniqueViolation, constraint: "users.email"},
+		{name: "foreign key", statement: "INSERT INTO accounts (id, userid) VALUES (1, 7)", conflict: database.ErrForeignKeyViolation},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// When the statement violates a constraint
+			_, cause := pool.Exec(tx, tc.statement)
+			err := database.Wrap(errWriting, cause)
+			// Then the error is the conflict of the sentinel wrapping the error of the driver
+			assert.ErrorIs(t, err, errWriting)
+			assert.ErrorIs(t, err, tc.conflict)
+			var dbErr *database.Error
+			if assert.ErrorAs(t, err, &dbErr) {
+				assert.Equal(t, tc.constraint, dbErr.Constraint)
+				assert.Equal(t, cause, dbErr.Cause)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package sqlite configures the SQLite databases, with the pure Go driver of
+// modernc.org/sqlite so the binaries do not need cgo.
+package sqlite
+
+import (
+	"os"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	// MemoryPath keeps the database in memory, it is lost when the pool is shut down.
+	MemoryPath = ":memory:"
+	// defaultPath is the database file used when none is configured.
+	defaultPath = "transactions.db"
+	// dataSourceOptions enforces the foreign keys, waits for the locks of other processes
+	// and starts every transaction holding the write lock, so the transactions of several
+	// processes sharing the file are serialized instead of failing when they write.
+	dataSourceOptions = "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate&_time_format=sqlite"
+)
+
+// SQLiteDatabase is the database.Database of the SQLite dialect.
+type SQLiteDatabase = database.Database
+
+// SQLitePool is the database.Pool of the SQLite dialect.
+type SQLitePool = database.Pool
+
+// SQLiteConfiguration struct defines the database file of SQLite.
+type SQLiteConfiguration struct {
+	// Path is the database file, MemoryPath keeps the database in memory.
+	Path string
+}
+
+// NewSQLiteConfiguration returns the configuration of the database stored in path.
+func NewSQLiteConfiguration(path string) (configuration *SQLiteConfiguration) {
+	configuration = &SQLiteConfiguration{Path: path}
+	return
+}
+
+// NewDefaultSQLiteConfiguration returns the configuration of the database stored in
+// transactions.db in the working directory.
+func NewDefaultSQLiteConfiguration() (configuration *SQLiteConfiguration) {
+	configuration = NewSQLiteConfiguration(defaultPath)
+	return
+}
+
+// NewSQLiteConfigurationFromEnv returns the configuration of the database stored in
+// SQLITE_PATH, the default configuration when it is not set.
+func NewSQLiteConfigurationFromEnv() (configuration *SQLiteConfiguration) {
+	path := os.Getenv("SQLITE_PATH")
+	if path == "" {
+		return NewDefaultSQLiteConfiguration()
+	}
+	configuration = NewSQLiteConfiguration(path)
+	return
+}
+
+// GetDataSourceName returns the data source name of the database file.
+func (configuration *SQLiteConfiguration) GetDataSourceName() (dataSourceName string) {
+	dataSourceName = "file:" + configuration.Path + "?" + dataSourceOptions
+	return
+}
+
+// DatabaseConfiguration returns the configuration of the SQLite database, nil when the
+// configuration is nil. SQLite has a single writer, so the pool keeps one connection
+// forever: the writes of the process wait for each other instead of for the file lock,
+// and a memory database lives as long as the pool.
+func (configuration *SQLiteConfiguration) DatabaseConfiguration() (databaseConfiguration *database.Configuration) {
+	if configuration == nil {
+		return
+	}
+	databaseConfiguration = &database.Configuration{
+		Dialect:        database.SQLite,
+		DataSourceName: configuration.GetDataSourceName(),
+		MaxOpenConns:   1,
+		MaxIdleConns:   1,
+	}
+	return
+}
+
+// NewSQLitePool creates a new instance of SQLitePool interface implementation. The
+// database file is created on first use.
+func NewSQLitePool(configuration *SQLiteConfiguration) (pool SQLitePool, err error) {
+	if configuration == nil {
+		err = database.ErrNilConfiguration
+		return
+	}
+	pool, err = database.NewPool(configuration.DatabaseConfiguration())
+	return
+}
@@ -0,0 +1,70 @@
+package sqlite_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/database"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewSQLiteConfigurationFromEnv tests the path is read from SQLITE_PATH.
+func TestNewSQLiteConfigurationFromEnv(t *testing.T) {
+	// Given SQLITE_PATH is not set
+	os.Unsetenv("SQLITE_PATH")
+	// Then the default file is used
+	assert.Equal(t, "transactions.db", sqlite.NewSQLiteConfigurationFromEnv().Path)
+	// Given SQLITE_PATH is set
+	t.Setenv("SQLITE_PATH", "/tmp/summary.db")
+	// Then its file is used
+	assert.Equal(t, "/tmp/summary.db", sqlite.NewSQLiteConfigurationFromEnv().Path)
+}
+
+// TestDatabaseConfiguration tests the pool of a SQLite database keeps a single connection.
+func TestDatabaseConfiguration(t *testing.T) {
+	// Given a SQLite configuration
+	configuration := sqlite.NewSQLiteConfiguration("summary.db")
+	// When getting its database configuration
+	databaseConfiguration := configuration.DatabaseConfiguration()
+	// Then it is a SQLite database with one connection enforcing the foreign keys
+	assert.Equal(t, database.SQLite, databaseConfiguration.Dialect)
+	assert.Equal(t, 1, databaseConfiguration.MaxOpenConns)
+	assert.Contains(t, databaseConfiguration.DataSourceName, "file:summary.db?")
+	assert.Contains(t, databaseConfiguration.DataSourceName, "_pragma=foreign_keys(1)")
+	// And a nil configuration has no database configuration
+	assert.Nil(t, (*sqlite.SQLiteConfiguration)(nil).DatabaseConfiguration())
+}
+
+// TestNewSQLitePoolWithNilConfiguration tests the error returned when the configuration is nil.
+func TestNewSQLitePoolWithNilConfiguration(t *testing.T) {
+	// When creating a pool without configuration
+	pool, err := sqlite.NewSQLitePool(nil)
+	// Then the error returned is ErrNilConfiguration
+	assert.Nil(t, pool)
+	assert.Equal(t, database.ErrNilConfiguration, err)
+}
+
+// TestMemoryPoolKeepsDatabase tests a memory database lives as long as its pool.
+func TestMemoryPoolKeepsDatabase(t *testing.T) {
+	// Given a pool of a memory database
+	pool, err := sqlite.NewSQLitePool(sqlite.NewSQLiteConfiguration(sqlite.MemoryPath))
+	assert.Nil(t, err)
+	defer pool.Shutdown()
+	assert.Nil(t, pool.Ping(context.Background()))
+	// When creating a table in a transaction
+	db, _ := pool.Open()
+	tx, err := pool.BeginTx(db)
+	assert.Nil(t, err)
+	_, err = pool.Exec(tx, "CREATE TABLE users (id BIGINT PRIMARY KEY)")
+	assert.Nil(t, err)
+	assert.Nil(t, pool.Commit(tx))
+	// Then the table is found by the next transaction
+	tx, err = pool.BeginTx(db)
+	assert.Nil(t, err)
+	defer pool.Rollback(tx)
+	rows, err := pool.Query(tx, "SELECT id FROM users")
+	assert.Nil(t, err)
+	assert.Nil(t, rows.Close())
+}
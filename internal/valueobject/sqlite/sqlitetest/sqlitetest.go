@@ -0,0 +1,29 @@
+// Package sqlitetest provides a SQLite database to the repository contract tests.
+package sqlitetest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/migrations"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/sqlite"
+)
+
+// NewDatabase returns a pool of a new database file with the migrations applied. The file
+// is removed when the test finishes.
+func NewDatabase(t *testing.T) (pool sqlite.SQLitePool) {
+	t.Helper()
+	pool, err := sqlite.NewSQLitePool(sqlite.NewSQLiteConfiguration(filepath.Join(t.TempDir(), "test.db")))
+	if err != nil {
+		t.Fatalf("creating the pool of the test database: %v", err)
+	}
+	t.Cleanup(func() { _ = pool.Shutdown() })
+	migrator, err := migrations.NewSQLiteMigrator(pool)
+	if err != nil {
+		t.Fatalf("creating the migrator of the test database: %v", err)
+	}
+	if _, err = migrator.Up(); err != nil {
+		t.Fatalf("migrating the test database: %v", err)
+	}
+	return
+}
@@ -0,0 +1,30 @@
+package storage
+
+import "errors"
+
+var (
+	// ErrObjectNotFound is the error returned when the object does not exist.
+	ErrObjectNotFound = errors.New("object not found")
+	// ErrInvalidKey is the error returned when the object key is empty or escapes the store.
+	ErrInvalidKey = errors.New("object key is invalid")
+	// ErrNilBody is the error returned when the object body is nil.
+	ErrNilBody = errors.New("object body is nil")
+	// ErrNilObjectStore is the error returned when the object store is nil.
+	ErrNilObjectStore = errors.New("object store is nil")
+	// ErrNilS3Client is the error returned when the S3 client is nil.
+	ErrNilS3Client = errors.New("s3 client is nil")
+	// ErrEmptyBucket is the error returned when the bucket name is empty.
+	ErrEmptyBucket = errors.New("bucket name is empty")
+	// ErrEmptyLocalDir is the error returned when the local directory is empty.
+	ErrEmptyLocalDir = errors.New("local directory is empty")
+	// ErrUnknownBackend is the error returned when the configured backend does not exist.
+	ErrUnknownBackend = errors.New("unknown storage backend")
+	// ErrPuttingObject is the error returned when an object cannot be stored.
+	ErrPuttingObject = errors.New("error putting object")
+	// ErrGettingObject is the error returned when an object cannot be read.
+	ErrGettingObject = errors.New("error getting object")
+	// ErrDeletingObject is the error returned when an object cannot be deleted.
+	ErrDeletingObject = errors.New("error deleting object")
+	// ErrListingObjects is the error returned when the objects cannot be listed.
+	ErrListingObjects = errors.New("error listing objects")
+)
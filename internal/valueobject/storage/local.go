@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// localObjectStore struct implements the ObjectStore interface with a local directory.
+type localObjectStore struct {
+	// dir is the root directory of the objects.
+	dir string
+}
+
+// NewLocalObjectStore returns an ObjectStore that keeps each object as a file under dir.
+func NewLocalObjectStore(dir string) (store ObjectStore, err error) {
+	if dir == "" {
+		err = ErrEmptyLocalDir
+		return
+	}
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+	store = &localObjectStore{
+		dir: dir,
+	}
+	return
+}
+
+// ObjectStore interface implementation
+
+// Put implements the ObjectStore interface method.
+// The body is written to a temporary file that replaces the object once complete,
+// so readers never see a partial object.
+func (store *localObjectStore) Put(key string, body io.Reader) (err error) {
+	if !validKey(key) {
+		err = ErrInvalidKey
+		return
+	}
+	if body == nil {
+		err = ErrNilBody
+		return
+	}
+	path := store.path(key)
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		err = ErrPuttingObject
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		err = ErrPuttingObject
+		return
+	}
+	defer os.Remove(tmp.Name())
+	_, err = io.Copy(tmp, body)
+	if errClose := tmp.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		err = ErrPuttingObject
+	}
+	return
+}
+
+// Get implements the ObjectStore interface method.
+func (store *localObjectStore) Get(key string) (body io.ReadCloser, err error) {
+	if !validKey(key) {
+		err = ErrInvalidKey
+		return
+	}
+	file, err := os.Open(store.path(key))
+	if err != nil {
+		err = localError(err, ErrGettingObject)
+		return
+	}
+	body = file
+	return
+}
+
+// Delete implements the ObjectStore interface method.
+func (store *localObjectStore) Delete(key string) (err error) {
+	if !validKey(key) {
+		err = ErrInvalidKey
+		return
+	}
+	err = os.Remove(store.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		err = nil
+	}
+	if err != nil {
+		err = ErrDeletingObject
+	}
+	return
+}
+
+// List implements the ObjectStore interface method.
+func (store *localObjectStore) List(prefix string) (objects []ObjectInfo, err error) {
+	err = filepath.WalkDir(store.dir, func(path string, entry fs.DirEntry, errWalk error) error {
+		if errWalk != nil {
+			return errWalk
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+			return nil
+		}
+		relative, errRel := filepath.Rel(store.dir, path)
+		if errRel != nil {
+			return errRel
+		}
+		key := filepath.ToSlash(relative)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		object, errHead := store.Head(key)
+		if errHead != nil {
+			return errHead
+		}
+		objects = append(objects, object)
+		return nil
+	})
+	if err != nil {
+		objects = nil
+		err = ErrListingObjects
+	}
+	return
+}
+
+// HeadWithContext implements the ObjectStore interface method. The context is only checked
+// before reading the file, the reads of the local disk are not cancelled.
+func (store *localObjectStore) HeadWithContext(ctx context.Context, key string) (object ObjectInfo, err error) {
+	if err = ctx.Err(); err != nil {
+		return
+	}
+	return store.Head(key)
+}
+
+// Head implements the ObjectStore interface method.
+func (store *localObjectStore) Head(key string) (object ObjectInfo, err error) {
+	if !validKey(key) {
+		err = ErrInvalidKey
+		return
+	}
+	file, err := os.Open(store.path(key))
+	if err != nil {
+		err = localError(err, ErrGettingObject)
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		err = ErrObjectNotFound
+		return
+	}
+	hasher := md5.New()
+	if _, err = io.Copy(hasher, file); err != nil {
+		err = ErrGettingObject
+		return
+	}
+	object = ObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		ETag:         hex.EncodeToString(hasher.Sum(nil)),
+		LastModified: info.ModTime(),
+	}
+	return
+}
+
+// path returns the file path of the key.
+func (store *localObjectStore) path(key string) string {
+	return filepath.Join(store.dir, filepath.FromSlash(key))
+}
+
+// localError returns ErrObjectNotFound for missing files and fallback otherwise.
+func localError(err error, fallback error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrObjectNotFound
+	}
+	return fallback
+}
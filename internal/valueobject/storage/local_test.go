@@ -0,0 +1,77 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewLocalObjectStoreWithEmptyDir tests the error returned when the directory is empty.
+func TestNewLocalObjectStoreWithEmptyDir(t *testing.T) {
+	// When call NewLocalObjectStore with an empty directory
+	_, err := storage.NewLocalObjectStore("")
+	// Then the error is ErrEmptyLocalDir
+	assert.Equal(t, storage.ErrEmptyLocalDir, err)
+}
+
+// TestLocalObjectStore tests an object goes through its whole life cycle.
+func TestLocalObjectStore(t *testing.T) {
+	// Given a local store
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	// When putting two objects
+	err = store.Put("uploads/txns.csv", strings.NewReader("Id,Date,Transaction\n"))
+	assert.Nil(t, err)
+	err = store.Put("other.csv", strings.NewReader("other"))
+	assert.Nil(t, err)
+	// Then the object can be read
+	body, err := store.Get("uploads/txns.csv")
+	assert.Nil(t, err)
+	content, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "Id,Date,Transaction\n", string(content))
+	// And its metadata has the size and the MD5 ETag
+	object, err := store.Head("uploads/txns.csv")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(20), object.Size)
+	assert.Equal(t, "14f3b607d07b9fdb3836588668f0b126", object.ETag)
+	// And its metadata cannot be read once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = store.HeadWithContext(ctx, "uploads/txns.csv")
+	assert.Equal(t, context.Canceled, err)
+	// And it is listed by prefix
+	objects, err := store.List("uploads/")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(objects))
+	assert.Equal(t, "uploads/txns.csv", objects[0].Key)
+	// And an empty prefix lists everything
+	objects, err = store.List("")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(objects))
+	// When deleting the object twice
+	assert.Nil(t, store.Delete("uploads/txns.csv"))
+	assert.Nil(t, store.Delete("uploads/txns.csv"))
+	// Then the object is not found
+	_, err = store.Get("uploads/txns.csv")
+	assert.Equal(t, storage.ErrObjectNotFound, err)
+	_, err = store.Head("uploads/txns.csv")
+	assert.Equal(t, storage.ErrObjectNotFound, err)
+}
+
+// TestLocalObjectStoreInvalidKeys tests keys escaping the directory are rejected.
+func TestLocalObjectStoreInvalidKeys(t *testing.T) {
+	// Given a local store
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	assert.Nil(t, err)
+	for _, key := range []string{"", ".", "../txns.csv", "/etc/passwd", "uploads/../../txns.csv"} {
+		// When putting an object with an invalid key
+		err = store.Put(key, strings.NewReader("content"))
+		// Then the error is ErrInvalidKey
+		assert.Equal(t, storage.ErrInvalidKey, err, key)
+	}
+}
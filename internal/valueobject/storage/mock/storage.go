@@ -0,0 +1,75 @@
+package mock
+
+import (
+	"context"
+	"io"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockObjectStore is a mock of the ObjectStore interface implementation.
+type mockObjectStore struct {
+	mock.Mock
+}
+
+// NewMockObjectStore returns a new mock instance.
+func NewMockObjectStore() *mockObjectStore {
+	return &mockObjectStore{}
+}
+
+// Put provides a mock function with given fields: key, body
+func (_m *mockObjectStore) Put(key string, body io.Reader) (err error) {
+	ret := _m.Called(key, body)
+	return ret.Error(0)
+}
+
+// Get provides a mock function with given fields: key
+func (_m *mockObjectStore) Get(key string) (body io.ReadCloser, err error) {
+	ret := _m.Called(key)
+
+	var r0 io.ReadCloser
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(io.ReadCloser)
+	}
+	return r0, ret.Error(1)
+}
+
+// Delete provides a mock function with given fields: key
+func (_m *mockObjectStore) Delete(key string) (err error) {
+	ret := _m.Called(key)
+	return ret.Error(0)
+}
+
+// List provides a mock function with given fields: prefix
+func (_m *mockObjectStore) List(prefix string) (objects []storage.ObjectInfo, err error) {
+	ret := _m.Called(prefix)
+
+	var r0 []storage.ObjectInfo
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]storage.ObjectInfo)
+	}
+	return r0, ret.Error(1)
+}
+
+// Head provides a mock function with given fields: key
+func (_m *mockObjectStore) Head(key string) (object storage.ObjectInfo, err error) {
+	ret := _m.Called(key)
+
+	var r0 storage.ObjectInfo
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(storage.ObjectInfo)
+	}
+	return r0, ret.Error(1)
+}
+
+// HeadWithContext provides a mock function with given fields: ctx, key
+func (_m *mockObjectStore) HeadWithContext(ctx context.Context, key string) (object storage.ObjectInfo, err error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 storage.ObjectInfo
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(storage.ObjectInfo)
+	}
+	return r0, ret.Error(1)
+}
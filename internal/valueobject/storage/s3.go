@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+)
+
+// s3ObjectStore struct implements the ObjectStore interface with an Amazon S3 bucket.
+type s3ObjectStore struct {
+	client s3iface.S3API
+	bucket string
+	// logger logs the errors of S3.
+	logger logger.Logger
+}
+
+// S3ObjectStoreOption configures an optional collaborator of the S3 object store.
+type S3ObjectStoreOption func(store *s3ObjectStore) (err error)
+
+// WithS3Logger logs the errors of S3 with the logger instead of the default one.
+func WithS3Logger(log logger.Logger) S3ObjectStoreOption {
+	return func(store *s3ObjectStore) (err error) {
+		if log == nil {
+			err = logger.ErrNilLogger
+			return
+		}
+		store.logger = log
+		return
+	}
+}
+
+// NewS3ObjectStore returns an ObjectStore backed by the S3 bucket.
+func NewS3ObjectStore(client s3iface.S3API, bucket string, options ...S3ObjectStoreOption) (store ObjectStore, err error) {
+	if client == nil {
+		err = ErrNilS3Client
+		return
+	}
+	if bucket == "" {
+		err = ErrEmptyBucket
+		return
+	}
+	s3Store := &s3ObjectStore{
+		client: client,
+		bucket: bucket,
+		logger: logger.NewDefaultLogger(),
+	}
+	for _, option := range options {
+		if err = option(s3Store); err != nil {
+			return
+		}
+	}
+	store = s3Store
+	return
+}
+
+// ObjectStore interface implementation
+
+// Put implements the ObjectStore interface method.
+func (store *s3ObjectStore) Put(key string, body io.Reader) (err error) {
+	if !validKey(key) {
+		err = ErrInvalidKey
+		return
+	}
+	if body == nil {
+		err = ErrNilBody
+		return
+	}
+	seeker, ok := body.(io.ReadSeeker)
+	if !ok {
+		content, errRead := io.ReadAll(body)
+		if errRead != nil {
+			err = ErrPuttingObject
+			return
+		}
+		seeker = bytes.NewReader(content)
+	}
+	_, err = store.client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(key),
+		Body:   seeker,
+	})
+	if err != nil {
+		store.logger.Error("error putting object in S3", "key", key, "error", err)
+		err = ErrPuttingObject
+	}
+	return
+}
+
+// Get implements the ObjectStore interface method.
+func (store *s3ObjectStore) Get(key string) (body io.ReadCloser, err error) {
+	if !validKey(key) {
+		err = ErrInvalidKey
+		return
+	}
+	output, err := store.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		err = store.s3Error(key, err, ErrGettingObject)
+		return
+	}
+	body = output.Body
+	return
+}
+
+// Delete implements the ObjectStore interface method.
+func (store *s3ObjectStore) Delete(key string) (err error) {
+	if !validKey(key) {
+		err = ErrInvalidKey
+		return
+	}
+	_, err = store.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		store.logger.Error("error deleting object from S3", "key", key, "error", err)
+		err = ErrDeletingObject
+	}
+	return
+}
+
+// List implements the ObjectStore interface method.
+func (store *s3ObjectStore) List(prefix string) (objects []ObjectInfo, err error) {
+	err = store.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(store.bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          aws.StringValue(object.Key),
+				Size:         aws.Int64Value(object.Size),
+				ETag:         strings.Trim(aws.StringValue(object.ETag), `"`),
+				LastModified: aws.TimeValue(object.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		store.logger.Error("error listing objects in S3", "prefix", prefix, "error", err)
+		objects = nil
+		err = ErrListingObjects
+	}
+	return
+}
+
+// Head implements the ObjectStore interface method.
+func (store *s3ObjectStore) Head(key string) (object ObjectInfo, err error) {
+	return store.HeadWithContext(context.Background(), key)
+}
+
+// HeadWithContext implements the ObjectStore interface method.
+func (store *s3ObjectStore) HeadWithContext(ctx context.Context, key string) (object ObjectInfo, err error) {
+	if !validKey(key) {
+		err = ErrInvalidKey
+		return
+	}
+	output, err := store.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		err = store.s3Error(key, err, ErrGettingObject)
+		return
+	}
+	object = ObjectInfo{
+		Key:          key,
+		Size:         aws.Int64Value(output.ContentLength),
+		ETag:         strings.Trim(aws.StringValue(output.ETag), `"`),
+		LastModified: aws.TimeValue(output.LastModified),
+	}
+	return
+}
+
+// s3Error returns ErrObjectNotFound for the S3 missing key errors and fallback otherwise.
+func (store *s3ObjectStore) s3Error(key string, err error, fallback error) error {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case s3.ErrCodeNoSuchKey, "NotFound":
+			return ErrObjectNotFound
+		}
+	}
+	store.logger.Error("error reading object from S3", "key", key, "error", err)
+	return fallback
+}
@@ -0,0 +1,155 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 keeps the objects of a single bucket in memory.
+type fakeS3 struct {
+	s3iface.S3API
+	objects map[string]string
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: map[string]string{}}
+}
+
+func (fake *fakeS3) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	content, _ := io.ReadAll(input.Body)
+	fake.objects[*input.Key] = string(content)
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (fake *fakeS3) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	content, ok := fake.objects[*input.Key]
+	if !ok {
+		return nil, awserr.New(s3.ErrCodeNoSuchKey, "missing", nil)
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(content))}, nil
+}
+
+func (fake *fakeS3) HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput, options ...request.Option) (*s3.HeadObjectOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, awserr.New(request.CanceledErrorCode, "canceled", err)
+	}
+	content, ok := fake.objects[*input.Key]
+	if !ok {
+		return nil, awserr.New("NotFound", "missing", nil)
+	}
+	return &s3.HeadObjectOutput{
+		ContentLength: aws.Int64(int64(len(content))),
+		ETag:          aws.String(`"etag"`),
+		LastModified:  aws.Time(time.Now()),
+	}, nil
+}
+
+func (fake *fakeS3) DeleteObject(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	delete(fake.objects, *input.Key)
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+// failingS3 fails to read every object.
+type failingS3 struct {
+	*fakeS3
+}
+
+func (fake *failingS3) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	return nil, awserr.New("SlowDown", "throttled", nil)
+}
+
+func (fake *fakeS3) ListObjectsV2Pages(input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error {
+	page := &s3.ListObjectsV2Output{}
+	for key, content := range fake.objects {
+		if strings.HasPrefix(key, *input.Prefix) {
+			page.Contents = append(page.Contents, &s3.Object{Key: aws.String(key), Size: aws.Int64(int64(len(content)))})
+		}
+	}
+	fn(page, true)
+	return nil
+}
+
+// TestNewS3ObjectStore tests the NewS3ObjectStore function validations.
+func TestNewS3ObjectStore(t *testing.T) {
+	// When call NewS3ObjectStore with a nil client
+	_, err := storage.NewS3ObjectStore(nil, "bucket")
+	// Then the error is ErrNilS3Client
+	assert.Equal(t, storage.ErrNilS3Client, err)
+	// And an empty bucket returns ErrEmptyBucket
+	_, err = storage.NewS3ObjectStore(newFakeS3(), "")
+	assert.Equal(t, storage.ErrEmptyBucket, err)
+	// And a nil logger returns ErrNilLogger
+	_, err = storage.NewS3ObjectStore(newFakeS3(), "bucket", storage.WithS3Logger(nil))
+	assert.Equal(t, logger.ErrNilLogger, err)
+}
+
+// TestS3ObjectStore tests an object goes through its whole life cycle.
+func TestS3ObjectStore(t *testing.T) {
+	// Given a S3 store
+	client := newFakeS3()
+	store, err := storage.NewS3ObjectStore(client, "bucket")
+	assert.Nil(t, err)
+	// When putting an object from a plain reader
+	err = store.Put("txns.csv", io.MultiReader(strings.NewReader("Id,Date,Transaction\n")))
+	// Then the object is stored
+	assert.Nil(t, err)
+	assert.Equal(t, "Id,Date,Transaction\n", client.objects["txns.csv"])
+	// And it can be read
+	body, err := store.Get("txns.csv")
+	assert.Nil(t, err)
+	content, _ := io.ReadAll(body)
+	assert.Equal(t, "Id,Date,Transaction\n", string(content))
+	// And its metadata has the unquoted ETag
+	object, err := store.Head("txns.csv")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(20), object.Size)
+	assert.Equal(t, "etag", object.ETag)
+	// And its metadata cannot be read once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = store.HeadWithContext(ctx, "txns.csv")
+	assert.Equal(t, storage.ErrGettingObject, err)
+	// And it is listed
+	objects, err := store.List("txns")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(objects))
+	// When deleting the object
+	assert.Nil(t, store.Delete("txns.csv"))
+	// Then it is not found
+	_, err = store.Get("txns.csv")
+	assert.Equal(t, storage.ErrObjectNotFound, err)
+	_, err = store.Head("txns.csv")
+	assert.Equal(t, storage.ErrObjectNotFound, err)
+}
+
+// TestS3ObjectStoreLogsErrors tests the errors of S3 are logged with the key.
+func TestS3ObjectStoreLogsErrors(t *testing.T) {
+	// Given a S3 object store failing to read and logging to a buffer
+	output := &bytes.Buffer{}
+	store, err := storage.NewS3ObjectStore(&failingS3{newFakeS3()}, "bucket",
+		storage.WithS3Logger(logger.NewJSONLogger(output, logger.LevelInfo)))
+	assert.Nil(t, err)
+	// When reading an object
+	_, err = store.Get("txns.csv")
+	// Then the error is ErrGettingObject
+	assert.Equal(t, storage.ErrGettingObject, err)
+	// And it is logged with the key
+	line := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(output.Bytes(), &line))
+	assert.Equal(t, "error reading object from S3", line["msg"])
+	assert.Equal(t, "txns.csv", line["key"])
+}
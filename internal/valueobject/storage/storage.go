@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	// S3Backend stores the objects in an Amazon S3 bucket.
+	S3Backend = "s3"
+	// LocalBackend stores the objects in a local directory.
+	LocalBackend = "local"
+)
+
+// ObjectStore interface defines the methods that an object storage must implement.
+// Keys are slash separated paths relative to the store root, e.g. "uploads/txns.csv".
+type ObjectStore interface {
+	// Put stores the body under the key, replacing any previous object.
+	Put(key string, body io.Reader) (err error)
+	// Get returns the body of the object. The caller must close it.
+	Get(key string) (body io.ReadCloser, err error)
+	// Delete deletes the object. Deleting a missing object is not an error.
+	Delete(key string) (err error)
+	// List returns the objects whose key starts with prefix, sorted by key.
+	List(prefix string) (objects []ObjectInfo, err error)
+	// Head returns the metadata of the object.
+	Head(key string) (object ObjectInfo, err error)
+	// HeadWithContext returns the metadata of the object, giving up when the context is done.
+	HeadWithContext(ctx context.Context, key string) (object ObjectInfo, err error)
+}
+
+// ObjectInfo struct defines the metadata of a stored object.
+type ObjectInfo struct {
+	// Key is the key of the object.
+	Key string
+	// Size is the size of the object in bytes.
+	Size int64
+	// ETag is the hex encoded MD5 of the object content.
+	ETag string
+	// LastModified is the date and time when the object was stored.
+	LastModified time.Time
+}
+
+// StorageConfiguration struct defines where the objects are stored.
+type StorageConfiguration struct {
+	// Backend is the name of the storage backend.
+	Backend string
+	// Bucket is the name of the S3 bucket.
+	Bucket string
+	// Region is the AWS region of the S3 bucket.
+	Region string
+	// LocalDir is the directory of the local backend.
+	LocalDir string
+}
+
+// NewDefaultStorageConfiguration returns the configuration used in local environments.
+func NewDefaultStorageConfiguration() (configuration *StorageConfiguration) {
+	configuration = &StorageConfiguration{
+		Backend:  LocalBackend,
+		LocalDir: "storage",
+	}
+	return
+}
+
+// NewStorageConfigurationFromEnv returns the configuration from the environment variables.
+// When STORAGE_BACKEND is not set, the S3 backend is used if AWS_S3_BUCKET_NAME is set
+// and the local backend otherwise.
+func NewStorageConfigurationFromEnv() (configuration *StorageConfiguration) {
+	configuration = NewDefaultStorageConfiguration()
+	configuration.Bucket = os.Getenv("AWS_S3_BUCKET_NAME")
+	configuration.Region = os.Getenv("AWS_REGION")
+	if configuration.Bucket != "" {
+		configuration.Backend = S3Backend
+	}
+	if backend := os.Getenv("STORAGE_BACKEND"); backend != "" {
+		configuration.Backend = backend
+	}
+	if localDir := os.Getenv("STORAGE_LOCAL_DIR"); localDir != "" {
+		configuration.LocalDir = localDir
+	}
+	return
+}
+
+// NewObjectStore returns the ObjectStore of the configured backend. The options configure
+// the S3 backend.
+func NewObjectStore(configuration *StorageConfiguration, options ...S3ObjectStoreOption) (store ObjectStore, err error) {
+	switch configuration.Backend {
+	case S3Backend:
+		sess, errSession := session.NewSession(&aws.Config{
+			Region: aws.String(configuration.Region),
+		})
+		if errSession != nil {
+			err = errSession
+			return
+		}
+		store, err = NewS3ObjectStore(s3.New(sess), configuration.Bucket, options...)
+	case LocalBackend:
+		store, err = NewLocalObjectStore(configuration.LocalDir)
+	default:
+		err = ErrUnknownBackend
+	}
+	return
+}
+
+// validKey returns true if the key is a relative slash separated path without "." or ".." elements.
+func validKey(key string) bool {
+	return fs.ValidPath(key) && key != "."
+}
@@ -0,0 +1,63 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewDefaultStorageConfiguration tests the NewDefaultStorageConfiguration function.
+func TestNewDefaultStorageConfiguration(t *testing.T) {
+	// When call NewDefaultStorageConfiguration
+	configuration := storage.NewDefaultStorageConfiguration()
+	// Then the local backend is used
+	assert.Equal(t, storage.LocalBackend, configuration.Backend)
+	assert.Equal(t, "storage", configuration.LocalDir)
+}
+
+// TestNewStorageConfigurationFromEnvWithBucket tests the S3 backend is used when a bucket is set.
+func TestNewStorageConfigurationFromEnvWithBucket(t *testing.T) {
+	// Given the AWS environment variables of the Lambdas
+	t.Setenv("STORAGE_BACKEND", "")
+	t.Setenv("AWS_S3_BUCKET_NAME", "stori-files")
+	t.Setenv("AWS_REGION", "us-east-2")
+	// When call NewStorageConfigurationFromEnv
+	configuration := storage.NewStorageConfigurationFromEnv()
+	// Then the S3 backend is used
+	assert.Equal(t, storage.S3Backend, configuration.Backend)
+	assert.Equal(t, "stori-files", configuration.Bucket)
+	assert.Equal(t, "us-east-2", configuration.Region)
+}
+
+// TestNewStorageConfigurationFromEnvWithBackend tests the STORAGE_BACKEND variable wins over the bucket.
+func TestNewStorageConfigurationFromEnvWithBackend(t *testing.T) {
+	// Given a bucket and an explicit local backend
+	t.Setenv("AWS_S3_BUCKET_NAME", "stori-files")
+	t.Setenv("STORAGE_BACKEND", storage.LocalBackend)
+	t.Setenv("STORAGE_LOCAL_DIR", "/tmp/files")
+	// When call NewStorageConfigurationFromEnv
+	configuration := storage.NewStorageConfigurationFromEnv()
+	// Then the local backend is used
+	assert.Equal(t, storage.LocalBackend, configuration.Backend)
+	assert.Equal(t, "/tmp/files", configuration.LocalDir)
+}
+
+// TestNewObjectStore tests the NewObjectStore function.
+func TestNewObjectStore(t *testing.T) {
+	// Given a local configuration
+	configuration := &storage.StorageConfiguration{Backend: storage.LocalBackend, LocalDir: t.TempDir()}
+	// When call NewObjectStore
+	store, err := storage.NewObjectStore(configuration)
+	// Then a store is returned
+	assert.Nil(t, err)
+	assert.NotNil(t, store)
+	// And an unknown backend returns an error
+	configuration.Backend = "ftp"
+	_, err = storage.NewObjectStore(configuration)
+	assert.Equal(t, storage.ErrUnknownBackend, err)
+	// And the S3 backend requires a bucket
+	configuration.Backend = storage.S3Backend
+	_, err = storage.NewObjectStore(configuration)
+	assert.Equal(t, storage.ErrEmptyBucket, err)
+}
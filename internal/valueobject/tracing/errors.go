@@ -0,0 +1,20 @@
+package tracing
+
+import "errors"
+
+var (
+	// ErrNilConfiguration is the error returned when the tracing configuration is nil.
+	ErrNilConfiguration = errors.New("tracing configuration is nil")
+	// ErrUnknownExporter is the error returned when the span exporter is unknown.
+	ErrUnknownExporter = errors.New("unknown span exporter")
+	// ErrMissingFile is the error returned when the file exporter has no file.
+	ErrMissingFile = errors.New("span file is missing")
+	// ErrMissingEndpoint is the error returned when the OTLP exporter has no endpoint.
+	ErrMissingEndpoint = errors.New("OTLP endpoint is missing")
+	// ErrNilExporter is the error returned when the span exporter is nil.
+	ErrNilExporter = errors.New("span exporter is nil")
+	// ErrNilTracer is the error returned when the tracer is nil.
+	ErrNilTracer = errors.New("tracer is nil")
+	// ErrExportingSpans is the error returned when the collector rejects the spans.
+	ErrExportingSpans = errors.New("error exporting spans")
+)
@@ -0,0 +1,123 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+)
+
+// Exporter interface defines the destination of the ended spans. It is used by every
+// goroutine ending a span.
+type Exporter interface {
+	// Export sends or buffers the ended span.
+	Export(span SpanData) (err error)
+	// Flush sends the buffered spans.
+	Flush(ctx context.Context) (err error)
+	// Shutdown sends the buffered spans and releases the exporter.
+	Shutdown(ctx context.Context) (err error)
+}
+
+// NewExporter returns the exporter of the configuration, the OTLP exporter logs its
+// failures with the logger.
+func NewExporter(configuration *TracingConfiguration, log logger.Logger) (exporter Exporter, err error) {
+	if configuration == nil {
+		err = ErrNilConfiguration
+		return
+	}
+	switch configuration.Exporter {
+	case StdoutExporter:
+		exporter = NewWriterExporter(os.Stdout)
+	case FileExporter:
+		exporter, err = NewFileExporter(configuration.File)
+	case OTLPExporter:
+		exporter, err = NewOTLPExporter(configuration.Endpoint, configuration.ServiceName, log)
+	default:
+		err = ErrUnknownExporter
+	}
+	return
+}
+
+// spanLine struct defines the JSON line of a span written by the writer exporter.
+type spanLine struct {
+	TraceID      string                 `json:"trace_id"`
+	SpanID       string                 `json:"span_id"`
+	ParentSpanID string                 `json:"parent_span_id,omitempty"`
+	Name         string                 `json:"name"`
+	Start        time.Time              `json:"start"`
+	DurationMS   float64                `json:"duration_ms"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+}
+
+// writerExporter struct implements the Exporter interface writing a JSON line per span.
+type writerExporter struct {
+	// mutex serializes the lines of the spans.
+	mutex  sync.Mutex
+	writer io.Writer
+	// closer closes the writer on Shutdown, nil when the writer is not owned.
+	closer io.Closer
+}
+
+// NewWriterExporter returns an exporter writing a JSON line per span to the writer, for
+// local use. The writer is not closed on Shutdown.
+func NewWriterExporter(writer io.Writer) Exporter {
+	return &writerExporter{writer: writer}
+}
+
+// NewFileExporter returns an exporter appending a JSON line per span to the file, which
+// is created when it does not exist and closed on Shutdown.
+func NewFileExporter(path string) (exporter Exporter, err error) {
+	if path == "" {
+		err = ErrMissingFile
+		return
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	exporter = &writerExporter{writer: file, closer: file}
+	return
+}
+
+// Exporter interface implementation
+
+func (exporter *writerExporter) Export(span SpanData) (err error) {
+	line := spanLine{
+		TraceID:    span.SpanContext.TraceID.String(),
+		SpanID:     span.SpanContext.SpanID.String(),
+		Name:       span.Name,
+		Start:      span.Start.UTC(),
+		DurationMS: float64(span.End.Sub(span.Start).Microseconds()) / 1000,
+		Attributes: span.Attributes,
+		Error:      span.Error,
+	}
+	if span.ParentSpanID.IsValid() {
+		line.ParentSpanID = span.ParentSpanID.String()
+	}
+	encoded, err := json.Marshal(line)
+	if err != nil {
+		return
+	}
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+	_, err = exporter.writer.Write(append(encoded, '\n'))
+	return
+}
+
+func (exporter *writerExporter) Flush(ctx context.Context) (err error) {
+	return
+}
+
+func (exporter *writerExporter) Shutdown(ctx context.Context) (err error) {
+	if exporter.closer == nil {
+		return
+	}
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+	return exporter.closer.Close()
+}
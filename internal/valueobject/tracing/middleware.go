@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/httpresponse"
+	"github.com/gorilla/mux"
+)
+
+// TraceParentHeader is the W3C Trace Context header carrying the span of the caller.
+const TraceParentHeader = "traceparent"
+
+// ParseTraceParent returns the span of a traceparent header value, such as
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01. The returned span is not valid
+// when the value is malformed.
+func ParseTraceParent(value string) (spanContext SpanContext) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		len(parts[1]) != 2*len(spanContext.TraceID) || len(parts[2]) != 2*len(spanContext.SpanID) {
+		return
+	}
+	var parsed SpanContext
+	if _, err := hex.Decode(parsed.TraceID[:], []byte(parts[1])); err != nil {
+		return
+	}
+	if _, err := hex.Decode(parsed.SpanID[:], []byte(parts[2])); err != nil {
+		return
+	}
+	spanContext = parsed
+	return
+}
+
+// FormatTraceParent returns the traceparent header value of the span.
+func FormatTraceParent(spanContext SpanContext) string {
+	return "00-" + spanContext.TraceID.String() + "-" + spanContext.SpanID.String() + "-01"
+}
+
+// HTTPMiddleware returns a middleware starting a span per request, named by its method and
+// route template. The span joins the trace of the traceparent header, and its context is
+// returned in the traceparent header of the response.
+func HTTPMiddleware(tracer Tracer) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			ctx := request.Context()
+			if remote := ParseTraceParent(request.Header.Get(TraceParentHeader)); remote.IsValid() {
+				ctx = ContextWithRemoteSpanContext(ctx, remote)
+			}
+			// The middlewares of a router only run for its matched routes, which have a template.
+			route, _ := mux.CurrentRoute(request).GetPathTemplate()
+			ctx, span := tracer.Start(ctx, request.Method+" "+route,
+				"http.method", request.Method,
+				"http.route", route,
+			)
+			defer span.End()
+			if span != nil {
+				writer.Header().Set(TraceParentHeader, FormatTraceParent(span.SpanContext()))
+			}
+			recorder := httpresponse.NewStatusRecorder(writer)
+			next.ServeHTTP(recorder, request.WithContext(ctx))
+			span.SetAttributes("http.status_code", recorder.Status)
+			if recorder.Status >= http.StatusInternalServerError {
+				span.RecordError(errors.New(http.StatusText(recorder.Status)))
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package tracing_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing/tracingtest"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseTraceParent tests the traceparent values are parsed, the malformed ones are not valid.
+func TestParseTraceParent(t *testing.T) {
+	// Given a valid traceparent
+	value := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	// When parsing and formatting it
+	spanContext := tracing.ParseTraceParent(value)
+	// Then the span is valid and formatted back to the value
+	assert.True(t, spanContext.IsValid())
+	assert.Equal(t, value, tracing.FormatTraceParent(spanContext))
+	// And the malformed values are not valid
+	for _, malformed := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+		"00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+	} {
+		assert.False(t, tracing.ParseTraceParent(malformed).IsValid(), malformed)
+	}
+}
+
+// TestHTTPMiddleware tests a span is started per request, named by its route template.
+func TestHTTPMiddleware(t *testing.T) {
+	// Given a router with the middleware
+	tracer, recorder := tracingtest.NewTracer()
+	router := mux.NewRouter()
+	router.Use(tracing.HTTPMiddleware(tracer))
+	var handlerSpan *tracing.Span
+	router.HandleFunc("/accounts/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		handlerSpan = tracing.SpanFromContext(request.Context())
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}).Methods("GET")
+	// When requesting an account with a traceparent
+	request := httptest.NewRequest(http.MethodGet, "/accounts/1", nil)
+	request.Header.Set(tracing.TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	// Then the handler runs within the span of the route
+	span, found := recorder.Span("GET /accounts/{id}")
+	assert.True(t, found)
+	assert.Equal(t, span.SpanContext, handlerSpan.SpanContext())
+	// And the span joins the trace of the caller
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID.String())
+	assert.Equal(t, "00f067aa0ba902b7", span.ParentSpanID.String())
+	// And the span has the status of the response, failed on server errors
+	assert.Equal(t, http.StatusServiceUnavailable, span.Attributes["http.status_code"])
+	assert.Equal(t, "/accounts/{id}", span.Attributes["http.route"])
+	assert.Equal(t, http.StatusText(http.StatusServiceUnavailable), span.Error)
+	// And the response carries the span
+	assert.Equal(t, tracing.FormatTraceParent(span.SpanContext), response.Header().Get(tracing.TraceParentHeader))
+}
@@ -0,0 +1,290 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+)
+
+const (
+	// otlpTracesPath is the path of the traces in the OTLP HTTP protocol.
+	otlpTracesPath = "/v1/traces"
+	// otlpBatchSize is the number of buffered spans sent at once.
+	otlpBatchSize = 512
+	// otlpMaxPending is the max number of buffered spans. The spans ended while the buffer
+	// is full are dropped, so a slow collector never blocks the spans being ended.
+	otlpMaxPending = 4 * otlpBatchSize
+	// otlpFlushInterval is the longest time a span is buffered.
+	otlpFlushInterval = 5 * time.Second
+	// otlpTimeout is the longest time a batch is being sent.
+	otlpTimeout = 10 * time.Second
+	// otlpStatusError is the code of the status of a failed span.
+	otlpStatusError = 2
+	// scopeName identifies the instrumentation in the collector.
+	scopeName = "github.com/braejan/go-transactions-summary"
+)
+
+// otlpExporter struct implements the Exporter interface sending the spans in batches to
+// an OpenTelemetry collector, with the JSON encoding of OTLP over HTTP.
+type otlpExporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+	logger      logger.Logger
+	mutex       sync.Mutex
+	pending     []SpanData
+	// dropped is the number of spans dropped since the last warning.
+	dropped int
+	// full wakes up the background flusher when a batch is full.
+	full    chan struct{}
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewOTLPExporter returns an exporter sending the spans to the collector of the endpoint,
+// such as http://localhost:4318. A background goroutine sends the spans when a batch is
+// full and every few seconds, and logs its failures and the dropped spans with the logger.
+func NewOTLPExporter(endpoint string, serviceName string, log logger.Logger) (exporter Exporter, err error) {
+	if endpoint == "" {
+		err = ErrMissingEndpoint
+		return
+	}
+	if log == nil {
+		err = logger.ErrNilLogger
+		return
+	}
+	newExporter := &otlpExporter{
+		url:         strings.TrimSuffix(endpoint, "/") + otlpTracesPath,
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: otlpTimeout},
+		logger:      log,
+		full:        make(chan struct{}, 1),
+		stop:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+	}
+	go newExporter.flushPeriodically(otlpFlushInterval)
+	exporter = newExporter
+	return
+}
+
+// Exporter interface implementation
+
+func (exporter *otlpExporter) Export(span SpanData) (err error) {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+	if len(exporter.pending) >= otlpMaxPending {
+		exporter.dropped++
+		return
+	}
+	exporter.pending = append(exporter.pending, span)
+	if len(exporter.pending) >= otlpBatchSize {
+		// The flusher is already woken up when the signal is pending.
+		select {
+		case exporter.full <- struct{}{}:
+		default:
+		}
+	}
+	return
+}
+
+func (exporter *otlpExporter) Shutdown(ctx context.Context) (err error) {
+	close(exporter.stop)
+	<-exporter.stopped
+	return exporter.flush(ctx)
+}
+
+// flushPeriodically sends the buffered spans every interval and when a batch is full,
+// until the exporter is shut down.
+func (exporter *otlpExporter) flushPeriodically(interval time.Duration) {
+	defer close(exporter.stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-exporter.stop:
+			return
+		case <-ticker.C:
+		case <-exporter.full:
+		}
+		if err := exporter.flush(context.Background()); err != nil {
+			exporter.logger.Warn("spans not exported", "error", err)
+		}
+		exporter.mutex.Lock()
+		dropped := exporter.dropped
+		exporter.dropped = 0
+		exporter.mutex.Unlock()
+		if dropped > 0 {
+			exporter.logger.Warn("spans dropped, the collector is too slow", "dropped", dropped)
+		}
+	}
+}
+
+func (exporter *otlpExporter) Flush(ctx context.Context) (err error) {
+	return exporter.flush(ctx)
+}
+
+// flush sends the buffered spans in batches.
+func (exporter *otlpExporter) flush(ctx context.Context) (err error) {
+	exporter.mutex.Lock()
+	pending := exporter.pending
+	exporter.pending = nil
+	exporter.mutex.Unlock()
+	for start := 0; start < len(pending); start += otlpBatchSize {
+		end := start + otlpBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		if err = exporter.send(ctx, pending[start:end]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// send posts the batch to the collector.
+func (exporter *otlpExporter) send(ctx context.Context, batch []SpanData) (err error) {
+	body, err := json.Marshal(newOTLPRequest(exporter.serviceName, batch))
+	if err != nil {
+		return
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, exporter.url, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := exporter.client.Do(request)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		err = ErrExportingSpans
+	}
+	return
+}
+
+// The OTLP types below are the JSON encoding of the ExportTraceServiceRequest message.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// newOTLPRequest returns the request exporting the spans of the service.
+func newOTLPRequest(serviceName string, batch []SpanData) (request otlpRequest) {
+	spans := make([]otlpSpan, 0, len(batch))
+	for _, span := range batch {
+		spans = append(spans, newOTLPSpan(span))
+	}
+	request.ResourceSpans = []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: newOTLPAttributes(map[string]interface{}{"service.name": serviceName})},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: scopeName}, Spans: spans}},
+	}}
+	return
+}
+
+func newOTLPSpan(span SpanData) (encoded otlpSpan) {
+	encoded = otlpSpan{
+		TraceID:           span.SpanContext.TraceID.String(),
+		SpanID:            span.SpanContext.SpanID.String(),
+		Name:              span.Name,
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+		Attributes:        newOTLPAttributes(span.Attributes),
+	}
+	if span.ParentSpanID.IsValid() {
+		encoded.ParentSpanID = span.ParentSpanID.String()
+	}
+	if span.Error != "" {
+		encoded.Status = &otlpStatus{Code: otlpStatusError, Message: span.Error}
+	}
+	return
+}
+
+// newOTLPAttributes returns the attributes sorted by key, so the requests are stable.
+func newOTLPAttributes(attributes map[string]interface{}) (encoded []otlpAttribute) {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		encoded = append(encoded, otlpAttribute{Key: key, Value: newOTLPValue(attributes[key])})
+	}
+	return
+}
+
+func newOTLPValue(value interface{}) (encoded otlpValue) {
+	switch v := value.(type) {
+	case bool:
+		encoded.BoolValue = &v
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		intValue := fmt.Sprint(v)
+		encoded.IntValue = &intValue
+	case float32:
+		doubleValue := float64(v)
+		encoded.DoubleValue = &doubleValue
+	case float64:
+		encoded.DoubleValue = &v
+	default:
+		stringValue := fmt.Sprint(v)
+		encoded.StringValue = &stringValue
+	}
+	return
+}
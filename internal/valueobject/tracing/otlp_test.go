@@ -0,0 +1,145 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOTLPExporter tests the buffered spans are sent to the collector when flushed.
+func TestOTLPExporter(t *testing.T) {
+	// Given a collector
+	var paths []string
+	var requests []map[string]interface{}
+	collector := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		paths = append(paths, request.URL.Path)
+		decoded := map[string]interface{}{}
+		assert.Nil(t, json.NewDecoder(request.Body).Decode(&decoded))
+		requests = append(requests, decoded)
+	}))
+	defer collector.Close()
+	// And a tracer exporting to it
+	configuration := &tracing.TracingConfiguration{
+		Exporter:    tracing.OTLPExporter,
+		Endpoint:    collector.URL + "/",
+		ServiceName: "transactions-api",
+	}
+	tracer, err := tracing.NewTracer(configuration, logger.NewDefaultLogger())
+	assert.Nil(t, err)
+	// When a root and a child span end
+	ctx, root := tracer.Start(context.Background(), "upload")
+	_, child := tracing.Start(ctx, "parse", "rows", 3, "valid", true, "ratio", 0.5)
+	child.End()
+	root.End()
+	// Then nothing is sent before a flush
+	assert.Empty(t, requests)
+	// When flushing and shutting down the tracer
+	assert.Nil(t, tracer.Flush(context.Background()))
+	assert.Nil(t, tracer.Shutdown(context.Background()))
+	// Then the spans are sent in one request to the traces path
+	assert.Equal(t, []string{"/v1/traces"}, paths)
+	resourceSpans := requests[0]["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	// And the resource has the service name
+	assert.Equal(t, map[string]interface{}{
+		"key":   "service.name",
+		"value": map[string]interface{}{"stringValue": "transactions-api"},
+	}, resourceSpans["resource"].(map[string]interface{})["attributes"].([]interface{})[0])
+	spans := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})
+	assert.Len(t, spans, 2)
+	// And the child has the IDs of its trace and its parent, and its typed attributes
+	parse := spans[0].(map[string]interface{})
+	assert.Equal(t, "parse", parse["name"])
+	assert.Equal(t, root.SpanContext().TraceID.String(), parse["traceId"])
+	assert.Equal(t, root.SpanContext().SpanID.String(), parse["parentSpanId"])
+	assert.NotEmpty(t, parse["startTimeUnixNano"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"key": "ratio", "value": map[string]interface{}{"doubleValue": 0.5}},
+		map[string]interface{}{"key": "rows", "value": map[string]interface{}{"intValue": "3"}},
+		map[string]interface{}{"key": "valid", "value": map[string]interface{}{"boolValue": true}},
+	}, parse["attributes"])
+	// And the root has no parent
+	assert.NotContains(t, spans[1].(map[string]interface{}), "parentSpanId")
+}
+
+// TestOTLPExporterRejected tests the error returned when the collector rejects the spans.
+func TestOTLPExporterRejected(t *testing.T) {
+	// Given a collector rejecting the spans
+	collector := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusBadRequest)
+	}))
+	defer collector.Close()
+	exporter, err := tracing.NewOTLPExporter(collector.URL, "transactions-api", logger.NewDefaultLogger())
+	assert.Nil(t, err)
+	// And a buffered span
+	assert.Nil(t, exporter.Export(tracing.SpanData{Name: "upload"}))
+	// When shutting down the exporter
+	err = exporter.Shutdown(context.Background())
+	// Then the error is ErrExportingSpans
+	assert.Equal(t, tracing.ErrExportingSpans, err)
+}
+
+// TestOTLPExporterSlowCollector tests the spans are exported without waiting for a slow
+// collector, and dropped when too many are buffered.
+func TestOTLPExporterSlowCollector(t *testing.T) {
+	// Given a collector blocking its first request until released
+	var mutex sync.Mutex
+	exported := 0
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	collector := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		decoded := map[string]interface{}{}
+		assert.Nil(t, json.NewDecoder(request.Body).Decode(&decoded))
+		resourceSpans := decoded["resourceSpans"].([]interface{})[0].(map[string]interface{})
+		spans := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})
+		mutex.Lock()
+		exported += len(spans)
+		mutex.Unlock()
+		once.Do(func() {
+			close(blocked)
+			<-release
+		})
+	}))
+	defer collector.Close()
+	// And an exporter logging to a buffer
+	output := &bytes.Buffer{}
+	exporter, err := tracing.NewOTLPExporter(collector.URL, "transactions-api", logger.NewJSONLogger(output, logger.LevelInfo))
+	assert.Nil(t, err)
+	// When a batch of spans ends
+	for i := 0; i < 512; i++ {
+		assert.Nil(t, exporter.Export(tracing.SpanData{Name: "upload"}))
+	}
+	// Then it is sent in the background
+	select {
+	case <-blocked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the full batch was not sent")
+	}
+	// When more spans than the buffer holds end while the collector is blocked
+	started := time.Now()
+	for i := 0; i < 4*512+10; i++ {
+		assert.Nil(t, exporter.Export(tracing.SpanData{Name: "upload"}))
+	}
+	// Then they end without waiting for the collector
+	assert.Less(t, time.Since(started), time.Second)
+	// When the collector is released and the exporter shut down
+	close(release)
+	assert.Nil(t, exporter.Shutdown(context.Background()))
+	// Then the buffered spans are exported and the others are dropped
+	mutex.Lock()
+	assert.Equal(t, 512+4*512, exported)
+	mutex.Unlock()
+	line := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(output.Bytes(), &line))
+	assert.Equal(t, "spans dropped, the collector is too slow", line["msg"])
+	assert.Equal(t, float64(10), line["dropped"])
+}
@@ -0,0 +1,175 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+)
+
+const (
+	// NoneExporter disables the tracing.
+	NoneExporter = "none"
+	// StdoutExporter writes a JSON line per span to the standard output.
+	StdoutExporter = "stdout"
+	// FileExporter appends a JSON line per span to a file.
+	FileExporter = "file"
+	// OTLPExporter sends the spans to an OpenTelemetry collector with OTLP over HTTP.
+	OTLPExporter = "otlp"
+)
+
+// TracingConfiguration struct defines the configuration of the tracing.
+type TracingConfiguration struct {
+	// Exporter is the exporter of the spans, NoneExporter when empty.
+	Exporter string
+	// File is the path of the file of the FileExporter.
+	File string
+	// Endpoint is the base URL of the collector of the OTLPExporter, the spans are sent
+	// to its /v1/traces path.
+	Endpoint string
+	// ServiceName identifies the process in the collector.
+	ServiceName string
+}
+
+// NewDefaultTracingConfiguration returns the configuration used when nothing is set.
+func NewDefaultTracingConfiguration() (configuration *TracingConfiguration) {
+	configuration = &TracingConfiguration{
+		Exporter:    NoneExporter,
+		ServiceName: "go-transactions-summary",
+	}
+	return
+}
+
+// NewTracingConfigurationFromEnv returns the configuration from the environment variables.
+func NewTracingConfigurationFromEnv() (configuration *TracingConfiguration) {
+	configuration = NewDefaultTracingConfiguration()
+	if exporter := os.Getenv("TRACING_EXPORTER"); exporter != "" {
+		configuration.Exporter = exporter
+	}
+	configuration.File = os.Getenv("TRACING_FILE")
+	configuration.Endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if serviceName := os.Getenv("OTEL_SERVICE_NAME"); serviceName != "" {
+		configuration.ServiceName = serviceName
+	}
+	return
+}
+
+// Tracer interface defines the starter of the root spans. The operations below a root
+// span start their spans with the Start function of the package.
+type Tracer interface {
+	// Start starts a span with the key-value pairs as attributes, and returns a copy of
+	// the context carrying it. The span is a child of the span carried by the context,
+	// or of the remote span when there is none, otherwise it starts a new trace.
+	Start(ctx context.Context, name string, args ...interface{}) (context.Context, *Span)
+	// Flush exports the pending spans, before a Lambda invocation is frozen.
+	Flush(ctx context.Context) (err error)
+	// Shutdown exports the pending spans and releases the exporter.
+	Shutdown(ctx context.Context) (err error)
+}
+
+// NewTracer returns the tracer sending the spans to the configured exporter. The
+// failures of the exporter are logged as warnings.
+func NewTracer(configuration *TracingConfiguration, log logger.Logger) (newTracer Tracer, err error) {
+	if configuration == nil {
+		err = ErrNilConfiguration
+		return
+	}
+	if log == nil {
+		err = logger.ErrNilLogger
+		return
+	}
+	if configuration.Exporter == "" || configuration.Exporter == NoneExporter {
+		newTracer = NewNoopTracer()
+		return
+	}
+	exporter, err := NewExporter(configuration, log)
+	if err != nil {
+		return
+	}
+	newTracer = NewExporterTracer(exporter, log)
+	return
+}
+
+// tracer struct implements the Tracer interface sending the ended spans to an exporter.
+type tracer struct {
+	exporter Exporter
+	logger   logger.Logger
+}
+
+// NewExporterTracer returns a tracer sending the ended spans to the exporter, logging its
+// failures with the logger.
+func NewExporterTracer(exporter Exporter, log logger.Logger) Tracer {
+	return &tracer{
+		exporter: exporter,
+		logger:   log,
+	}
+}
+
+// Tracer interface implementation
+
+func (tracer *tracer) Start(ctx context.Context, name string, args ...interface{}) (context.Context, *Span) {
+	parent := SpanFromContext(ctx).SpanContext()
+	if !parent.IsValid() {
+		parent = remoteSpanContext(ctx)
+	}
+	return tracer.start(ctx, name, parent, args)
+}
+
+func (tracer *tracer) Flush(ctx context.Context) (err error) {
+	return tracer.exporter.Flush(ctx)
+}
+
+func (tracer *tracer) Shutdown(ctx context.Context) (err error) {
+	return tracer.exporter.Shutdown(ctx)
+}
+
+// start starts a child of the parent, a root span when the parent is not valid.
+func (tracer *tracer) start(ctx context.Context, name string, parent SpanContext, args []interface{}) (context.Context, *Span) {
+	span := &Span{
+		tracer: tracer,
+		data: SpanData{
+			Name:       name,
+			Start:      time.Now(),
+			Attributes: map[string]interface{}{},
+		},
+	}
+	span.data.SpanContext.SpanID = newSpanID()
+	if parent.IsValid() {
+		span.data.SpanContext.TraceID = parent.TraceID
+		span.data.ParentSpanID = parent.SpanID
+	} else {
+		span.data.SpanContext.TraceID = newTraceID()
+	}
+	span.SetAttributes(args...)
+	return ContextWithSpan(ctx, span), span
+}
+
+// export sends the ended span to the exporter.
+func (tracer *tracer) export(span SpanData) {
+	if err := tracer.exporter.Export(span); err != nil {
+		tracer.logger.Warn("span not exported", "span", span.Name, "error", err)
+	}
+}
+
+// noopTracer struct implements the Tracer interface without starting spans.
+type noopTracer struct{}
+
+// NewNoopTracer returns the tracer used when the tracing is disabled, its spans are nil.
+func NewNoopTracer() Tracer {
+	return noopTracer{}
+}
+
+// Tracer interface implementation
+
+func (noopTracer) Start(ctx context.Context, name string, args ...interface{}) (context.Context, *Span) {
+	return ctx, nil
+}
+
+func (noopTracer) Flush(ctx context.Context) (err error) {
+	return
+}
+
+func (noopTracer) Shutdown(ctx context.Context) (err error) {
+	return
+}
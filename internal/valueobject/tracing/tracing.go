@@ -0,0 +1,216 @@
+// Package tracing provides the spans of the application. A span times an operation, such
+// as a request, the parse of a file, a repository call or a SQL statement, and it is
+// carried by the context, so the spans started from it are its children. Ended spans are
+// sent to an Exporter, which writes them locally or to an OpenTelemetry collector.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TraceID identifies the spans of an operation and its children.
+type TraceID [16]byte
+
+// String returns the ID as 32 hex characters.
+func (traceID TraceID) String() string {
+	return hex.EncodeToString(traceID[:])
+}
+
+// IsValid returns true when the ID is not zero.
+func (traceID TraceID) IsValid() bool {
+	return traceID != TraceID{}
+}
+
+// SpanID identifies a span in its trace.
+type SpanID [8]byte
+
+// String returns the ID as 16 hex characters.
+func (spanID SpanID) String() string {
+	return hex.EncodeToString(spanID[:])
+}
+
+// IsValid returns true when the ID is not zero.
+func (spanID SpanID) IsValid() bool {
+	return spanID != SpanID{}
+}
+
+// SpanContext struct defines the IDs identifying a span, propagated to its children.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+// IsValid returns true when both IDs are not zero.
+func (spanContext SpanContext) IsValid() bool {
+	return spanContext.TraceID.IsValid() && spanContext.SpanID.IsValid()
+}
+
+// SpanData struct defines an ended span, as received by the exporters.
+type SpanData struct {
+	// Name is the operation timed by the span.
+	Name string
+	// SpanContext identifies the span.
+	SpanContext SpanContext
+	// ParentSpanID is the span the span was started from, zero for a root span.
+	ParentSpanID SpanID
+	// Start is the time the span was started.
+	Start time.Time
+	// End is the time the span was ended.
+	End time.Time
+	// Attributes describe the operation, their values are strings, booleans or numbers.
+	Attributes map[string]interface{}
+	// Error is the message of the error recorded by the span, empty when it succeeded.
+	Error string
+}
+
+// Span struct defines an operation being timed. The methods of a nil span do nothing, so
+// the code starting a span does not check whether tracing is enabled.
+type Span struct {
+	mutex  sync.Mutex
+	tracer *tracer
+	data   SpanData
+	ended  bool
+}
+
+// SpanContext returns the IDs of the span, zero for a nil span.
+func (span *Span) SpanContext() (spanContext SpanContext) {
+	if span == nil {
+		return
+	}
+	spanContext = span.data.SpanContext
+	return
+}
+
+// SetAttributes adds the key-value pairs to the attributes of the span. A key without
+// value is added with the "!BADKEY" key.
+func (span *Span) SetAttributes(args ...interface{}) {
+	if span == nil {
+		return
+	}
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+	for i := 0; i < len(args); i += 2 {
+		key, ok := args[i].(string)
+		if !ok || i+1 == len(args) {
+			span.data.Attributes["!BADKEY"] = attributeValue(args[i])
+			i--
+			continue
+		}
+		span.data.Attributes[key] = attributeValue(args[i+1])
+	}
+}
+
+// attributeValue returns the value kept as attribute: strings, booleans and numbers are
+// kept as is, any other value is kept as its string.
+func attributeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprintf("%+v", value)
+}
+
+// RecordError marks the span as failed with the error, a nil error is ignored.
+func (span *Span) RecordError(err error) {
+	if span == nil || err == nil {
+		return
+	}
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+	span.data.Error = err.Error()
+}
+
+// End ends the span and sends it to the exporter of its tracer. Only the first call ends
+// the span.
+func (span *Span) End() {
+	if span == nil {
+		return
+	}
+	span.mutex.Lock()
+	if span.ended {
+		span.mutex.Unlock()
+		return
+	}
+	span.ended = true
+	span.data.End = time.Now()
+	data := span.data
+	span.mutex.Unlock()
+	span.tracer.export(data)
+}
+
+// EndWithError records the error pointed by err, when there is one, and ends the span.
+// It is deferred with the address of a named error result.
+func (span *Span) EndWithError(err *error) {
+	if err != nil {
+		span.RecordError(*err)
+	}
+	span.End()
+}
+
+// contextKey is the type of the keys of the spans stored in a context.
+type contextKey int
+
+const (
+	spanKey contextKey = iota
+	remoteSpanKey
+)
+
+// ContextWithSpan returns a copy of the context carrying the span.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanKey, span)
+}
+
+// SpanFromContext returns the span carried by the context, nil when there is none.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey).(*Span)
+	return span
+}
+
+// ContextWithRemoteSpanContext returns a copy of the context carrying the span of another
+// process, so the spans started from it join its trace.
+func ContextWithRemoteSpanContext(ctx context.Context, spanContext SpanContext) context.Context {
+	return context.WithValue(ctx, remoteSpanKey, spanContext)
+}
+
+// remoteSpanContext returns the span of another process carried by the context.
+func remoteSpanContext(ctx context.Context) (spanContext SpanContext) {
+	spanContext, _ = ctx.Value(remoteSpanKey).(SpanContext)
+	return
+}
+
+// Start starts a child of the span carried by the context, with the key-value pairs as
+// attributes, and returns a copy of the context carrying it. Without a span in the
+// context nothing is traced: the context is returned as is with a nil span.
+func Start(ctx context.Context, name string, args ...interface{}) (context.Context, *Span) {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+	return parent.tracer.start(ctx, name, parent.SpanContext(), args)
+}
+
+// newTraceID returns a random trace ID.
+func newTraceID() (traceID TraceID) {
+	readRandom(traceID[:])
+	return
+}
+
+// newSpanID returns a random span ID.
+func newSpanID() (spanID SpanID) {
+	readRandom(spanID[:])
+	return
+}
+
+// readRandom fills the ID with random bytes.
+func readRandom(id []byte) {
+	_, _ = rand.Read(id)
+}
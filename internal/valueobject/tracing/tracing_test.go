@@ -0,0 +1,166 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStartWithoutSpan tests nothing is traced without a span in the context.
+func TestStartWithoutSpan(t *testing.T) {
+	// Given a context without span
+	ctx := context.Background()
+	// When starting a span
+	spanCtx, span := tracing.Start(ctx, "parse")
+	// Then the span is nil and its methods do nothing
+	assert.Nil(t, span)
+	span.SetAttributes("rows", 3)
+	span.RecordError(errors.New("boom"))
+	span.End()
+	assert.False(t, span.SpanContext().IsValid())
+	// And the context is returned as is
+	assert.Equal(t, ctx, spanCtx)
+}
+
+// TestStartChildSpans tests the spans started from a root span join its trace.
+func TestStartChildSpans(t *testing.T) {
+	// Given a root span
+	tracer, recorder := tracingtest.NewTracer()
+	ctx, root := tracer.Start(context.Background(), "upload", "file", "txns.csv")
+	// When starting a child and a grandchild
+	childCtx, child := tracing.Start(ctx, "parse")
+	_, grandchild := tracing.Start(childCtx, "sql.query")
+	grandchild.RecordError(errors.New("boom"))
+	grandchild.End()
+	child.SetAttributes("rows", 3, "elapsed", time.Second, "dangling")
+	child.End()
+	root.End()
+	root.End()
+	// Then every span is exported once, when it ends
+	assert.Equal(t, []string{"sql.query", "parse", "upload"}, recorder.Names())
+	spans := recorder.Spans()
+	// And every span shares the trace of the root
+	traceID := root.SpanContext().TraceID
+	assert.True(t, traceID.IsValid())
+	for _, span := range spans {
+		assert.Equal(t, traceID, span.SpanContext.TraceID)
+	}
+	// And the parents are the spans they were started from
+	assert.False(t, spans[2].ParentSpanID.IsValid())
+	assert.Equal(t, root.SpanContext().SpanID, spans[1].ParentSpanID)
+	assert.Equal(t, child.SpanContext().SpanID, spans[0].ParentSpanID)
+	// And the attributes and the errors are kept
+	assert.Equal(t, "txns.csv", spans[2].Attributes["file"])
+	assert.Equal(t, 3, spans[1].Attributes["rows"])
+	assert.Equal(t, "1s", spans[1].Attributes["elapsed"])
+	assert.Equal(t, "dangling", spans[1].Attributes["!BADKEY"])
+	assert.Equal(t, "boom", spans[0].Error)
+	assert.False(t, spans[0].End.Before(spans[0].Start))
+}
+
+// TestTracerStartRemoteParent tests a root span joins the trace of the remote span.
+func TestTracerStartRemoteParent(t *testing.T) {
+	// Given a context carrying a remote span
+	tracer, recorder := tracingtest.NewTracer()
+	remote := tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := tracing.ContextWithRemoteSpanContext(context.Background(), remote)
+	// When starting a span
+	_, span := tracer.Start(ctx, "GET /accounts/{id}")
+	span.End()
+	// Then the span is a child of the remote span
+	spans := recorder.Spans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID.String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].ParentSpanID.String())
+}
+
+// TestNewTracer tests the tracer of every exporter of the configuration.
+func TestNewTracer(t *testing.T) {
+	log := logger.NewJSONLogger(&bytes.Buffer{}, logger.LevelInfo)
+	// Given a nil configuration
+	_, err := tracing.NewTracer(nil, log)
+	// Then the error is ErrNilConfiguration
+	assert.Equal(t, tracing.ErrNilConfiguration, err)
+	// Given a nil logger
+	_, err = tracing.NewTracer(tracing.NewDefaultTracingConfiguration(), nil)
+	// Then the error is ErrNilLogger
+	assert.Equal(t, logger.ErrNilLogger, err)
+	// Given the default configuration
+	tracer, err := tracing.NewTracer(tracing.NewDefaultTracingConfiguration(), log)
+	assert.Nil(t, err)
+	// Then the tracer does not start spans
+	_, span := tracer.Start(context.Background(), "upload")
+	assert.Nil(t, span)
+	assert.Nil(t, tracer.Shutdown(context.Background()))
+	// Given an unknown exporter
+	_, err = tracing.NewTracer(&tracing.TracingConfiguration{Exporter: "zipkin"}, log)
+	// Then the error is ErrUnknownExporter
+	assert.Equal(t, tracing.ErrUnknownExporter, err)
+	// Given the file exporter without file
+	_, err = tracing.NewTracer(&tracing.TracingConfiguration{Exporter: tracing.FileExporter}, log)
+	// Then the error is ErrMissingFile
+	assert.Equal(t, tracing.ErrMissingFile, err)
+	// Given the OTLP exporter without endpoint
+	_, err = tracing.NewTracer(&tracing.TracingConfiguration{Exporter: tracing.OTLPExporter}, log)
+	// Then the error is ErrMissingEndpoint
+	assert.Equal(t, tracing.ErrMissingEndpoint, err)
+}
+
+// TestNewTracingConfigurationFromEnv tests the configuration is read from the environment.
+func TestNewTracingConfigurationFromEnv(t *testing.T) {
+	// Given the tracing environment variables
+	t.Setenv("TRACING_EXPORTER", "otlp")
+	t.Setenv("TRACING_FILE", "spans.jsonl")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+	t.Setenv("OTEL_SERVICE_NAME", "transactions-api")
+	// When reading the configuration
+	configuration := tracing.NewTracingConfigurationFromEnv()
+	// Then the configuration has the values of the variables
+	assert.Equal(t, &tracing.TracingConfiguration{
+		Exporter:    tracing.OTLPExporter,
+		File:        "spans.jsonl",
+		Endpoint:    "http://collector:4318",
+		ServiceName: "transactions-api",
+	}, configuration)
+}
+
+// TestFileExporter tests a JSON line is appended to the file per span.
+func TestFileExporter(t *testing.T) {
+	// Given a tracer exporting to a file
+	path := filepath.Join(t.TempDir(), "spans.jsonl")
+	configuration := &tracing.TracingConfiguration{Exporter: tracing.FileExporter, File: path}
+	tracer, err := tracing.NewTracer(configuration, logger.NewDefaultLogger())
+	assert.Nil(t, err)
+	// When a root and a child span end
+	ctx, root := tracer.Start(context.Background(), "upload")
+	_, child := tracing.Start(ctx, "parse", "rows", 3)
+	child.RecordError(errors.New("boom"))
+	child.End()
+	root.End()
+	assert.Nil(t, tracer.Shutdown(context.Background()))
+	// Then the file has a line per span
+	content, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 2)
+	decoded := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	// And the line has the IDs, the name, the attributes and the error of the span
+	assert.Equal(t, "parse", decoded["name"])
+	assert.Equal(t, root.SpanContext().TraceID.String(), decoded["trace_id"])
+	assert.Equal(t, root.SpanContext().SpanID.String(), decoded["parent_span_id"])
+	assert.Equal(t, map[string]interface{}{"rows": float64(3)}, decoded["attributes"])
+	assert.Equal(t, "boom", decoded["error"])
+	assert.Contains(t, decoded, "duration_ms")
+}
@@ -0,0 +1,70 @@
+// Package tracingtest provides an exporter recording the spans, so the tests assert the
+// spans started by the code under test.
+package tracingtest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/braejan/go-transactions-summary/internal/valueobject/logger"
+	"github.com/braejan/go-transactions-summary/internal/valueobject/tracing"
+)
+
+// Recorder struct implements the tracing.Exporter interface keeping the ended spans.
+type Recorder struct {
+	mutex sync.Mutex
+	spans []tracing.SpanData
+}
+
+// NewTracer returns a tracer recording its spans in the returned recorder.
+func NewTracer() (tracer tracing.Tracer, recorder *Recorder) {
+	recorder = &Recorder{}
+	tracer = tracing.NewExporterTracer(recorder, logger.NewDefaultLogger())
+	return
+}
+
+// Export keeps the span.
+func (recorder *Recorder) Export(span tracing.SpanData) (err error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.spans = append(recorder.spans, span)
+	return
+}
+
+// Flush does nothing, the spans are kept.
+func (recorder *Recorder) Flush(ctx context.Context) (err error) {
+	return
+}
+
+// Shutdown does nothing, the spans are kept.
+func (recorder *Recorder) Shutdown(ctx context.Context) (err error) {
+	return
+}
+
+// Spans returns the ended spans in the order they were ended.
+func (recorder *Recorder) Spans() (spans []tracing.SpanData) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	spans = append(spans, recorder.spans...)
+	return
+}
+
+// Names returns the names of the ended spans in the order they were ended.
+func (recorder *Recorder) Names() (names []string) {
+	for _, span := range recorder.Spans() {
+		names = append(names, span.Name)
+	}
+	return
+}
+
+// Span returns the first ended span with the name, false when there is none.
+func (recorder *Recorder) Span(name string) (span tracing.SpanData, found bool) {
+	for _, span = range recorder.Spans() {
+		if span.Name == name {
+			found = true
+			return
+		}
+	}
+	span = tracing.SpanData{}
+	return
+}
@@ -7,6 +7,8 @@ var (
 	ErrTransactionAmountIsZero = errors.New("transaction amount is zero")
 	// ErrTransactionOriginIsEmpty is the error returned when the transaction origin is empty.
 	ErrTransactionOriginIsEmpty = errors.New("transaction origin is empty")
+	// ErrTransactionNotFound is the error returned when a transaction is not found.
+	ErrTransactionNotFound = errors.New("transaction not found")
 	// ErrQueryingTransactionByID is the error returned when querying a transaction by ID.
 	ErrQueryingTransactionByID = errors.New("error querying transaction by ID")
 	// ErrScanningTransactionByID is the error returned when scanning a transaction by ID.
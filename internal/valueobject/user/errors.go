@@ -27,4 +27,6 @@ var (
 	ErrNilUser = errors.New("user is nil")
 	// ErrNilUserUseCases is the error returned when the user use cases is nil.
 	ErrNilUserUseCases = errors.New("user use cases is nil")
+	// ErrInvalidUserID is returned when the user ID of a request is not an integer.
+	ErrInvalidUserID = errors.New("user ID is not valid")
 )